@@ -1,14 +1,20 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"time"
 
+	"github.com/yuki5155/go-google-auth/internal/application/account"
 	"github.com/yuki5155/go-google-auth/internal/infrastructure/config"
 	"github.com/yuki5155/go-google-auth/internal/infrastructure/container"
 	"github.com/yuki5155/go-google-auth/internal/presentation/http/router"
 )
 
+// accountPurgeInterval is how often deleted accounts past their grace period are purged
+const accountPurgeInterval = time.Hour
+
 func main() {
 	// Load configuration
 	cfg := config.Load()
@@ -16,6 +22,9 @@ func main() {
 	// Create dependency injection container
 	c := container.NewContainer(cfg)
 
+	// Purge deleted accounts in the background
+	go runAccountPurge(c.PurgeDeletedAccountsUseCase, accountPurgeInterval)
+
 	// Setup router with container
 	r := router.Setup(c)
 
@@ -26,3 +35,20 @@ func main() {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
+
+// runAccountPurge periodically hard-deletes accounts whose grace period has passed
+func runAccountPurge(uc *account.PurgeDeletedAccountsUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		purged, err := uc.Execute(context.Background())
+		if err != nil {
+			log.Printf("Account purge failed: %v", err)
+			continue
+		}
+		if purged > 0 {
+			log.Printf("Purged %d deleted account(s)", purged)
+		}
+	}
+}
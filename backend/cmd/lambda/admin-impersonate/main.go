@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	ginadapter "github.com/awslabs/aws-lambda-go-api-proxy/gin"
+	"github.com/yuki5155/go-google-auth/internal/presentation/http/handlers"
+	"github.com/yuki5155/go-google-auth/internal/presentation/http/middleware"
+	"github.com/yuki5155/go-google-auth/internal/presentation/lambda/common"
+)
+
+var ginLambda *ginadapter.GinLambda
+
+func init() {
+	// Bootstrap with shared initialization
+	r, c := common.Bootstrap()
+
+	// Create admin handler using use cases from container
+	adminHandler := handlers.NewAdminHandler(
+		c.StartImpersonationUseCase,
+		c.StopImpersonationUseCase,
+	)
+
+	// Register protected route with auth middleware
+	r.POST("/admin/impersonate/:userID",
+		middleware.Auth(c.TokenGenerator,
+			middleware.WithAccountStatus(c.AccountStatusService),
+			middleware.WithTokenPrecedence(c.Config.TokenPrecedence),
+			middleware.WithDPoP(c.DPoPAuthenticator),
+			middleware.WithRevokedTokens(c.RevokedTokens),
+		),
+		middleware.APIRateLimit(c.RateLimiter, c.Config),
+		middleware.BlockImpersonation(),
+		middleware.RequireRecentAuth(c.Config.RecentAuthMaxAge),
+		adminHandler.Impersonate,
+	)
+
+	// Wrap Gin router with Lambda adapter
+	ginLambda = ginadapter.New(r)
+}
+
+func Handler(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return ginLambda.ProxyWithContext(ctx, req)
+}
+
+func main() {
+	lambda.Start(Handler)
+}
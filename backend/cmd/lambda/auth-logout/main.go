@@ -22,7 +22,10 @@ func init() {
 		c.RefreshTokenUseCase,
 		c.GetCurrentUserUseCase,
 		c.LogoutUseCase,
+		c.VerifyMFAUseCase,
+		c.DPoPAuthenticator,
 		c.TokenGenerator,
+		c.CSRFTokens,
 		c.Config,
 	)
 
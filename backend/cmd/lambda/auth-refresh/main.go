@@ -7,6 +7,7 @@ import (
 	"github.com/aws/aws-lambda-go/lambda"
 	ginadapter "github.com/awslabs/aws-lambda-go-api-proxy/gin"
 	"github.com/yuki5155/go-google-auth/internal/presentation/http/handlers"
+	"github.com/yuki5155/go-google-auth/internal/presentation/http/middleware"
 	"github.com/yuki5155/go-google-auth/internal/presentation/lambda/common"
 )
 
@@ -22,12 +23,15 @@ func init() {
 		c.RefreshTokenUseCase,
 		c.GetCurrentUserUseCase,
 		c.LogoutUseCase,
+		c.VerifyMFAUseCase,
+		c.DPoPAuthenticator,
 		c.TokenGenerator,
+		c.CSRFTokens,
 		c.Config,
 	)
 
 	// Register this Lambda's specific endpoint
-	r.POST("/auth/refresh", authHandler.RefreshToken)
+	r.POST("/auth/refresh", middleware.RefreshRateLimit(c.RateLimiter, c.TokenGenerator, c.Config), authHandler.RefreshToken)
 
 	// Wrap Gin router with Lambda adapter
 	ginLambda = ginadapter.New(r)
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	ginadapter "github.com/awslabs/aws-lambda-go-api-proxy/gin"
+	"github.com/yuki5155/go-google-auth/internal/presentation/http/handlers"
+	"github.com/yuki5155/go-google-auth/internal/presentation/http/middleware"
+	"github.com/yuki5155/go-google-auth/internal/presentation/lambda/common"
+)
+
+var ginLambda *ginadapter.GinLambda
+
+func init() {
+	// Bootstrap with shared initialization
+	r, c := common.Bootstrap()
+
+	// Create API token handler using use cases from container
+	apiTokenHandler := handlers.NewAPITokenHandler(
+		c.CreateAPITokenUseCase,
+		c.ListAPITokensUseCase,
+		c.RevokeAPITokenUseCase,
+	)
+
+	// Register protected route with auth middleware
+	r.POST("/api/tokens",
+		middleware.Auth(c.TokenGenerator,
+			middleware.WithAccountStatus(c.AccountStatusService),
+			middleware.WithTokenPrecedence(c.Config.TokenPrecedence),
+			middleware.WithAPITokens(c.APITokenAuthenticator),
+			middleware.WithDPoP(c.DPoPAuthenticator),
+			middleware.WithRevokedTokens(c.RevokedTokens),
+		),
+		middleware.APIRateLimit(c.RateLimiter, c.Config),
+		middleware.BlockImpersonation(),
+		apiTokenHandler.Create,
+	)
+
+	// Wrap Gin router with Lambda adapter
+	ginLambda = ginadapter.New(r)
+}
+
+func Handler(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return ginLambda.ProxyWithContext(ctx, req)
+}
+
+func main() {
+	lambda.Start(Handler)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
 	ginadapter "github.com/awslabs/aws-lambda-go-api-proxy/gin"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
 	"github.com/yuki5155/go-google-auth/internal/presentation/http/handlers"
 	"github.com/yuki5155/go-google-auth/internal/presentation/http/middleware"
 	"github.com/yuki5155/go-google-auth/internal/presentation/lambda/common"
@@ -23,12 +24,26 @@ func init() {
 		c.RefreshTokenUseCase,
 		c.GetCurrentUserUseCase,
 		c.LogoutUseCase,
+		c.VerifyMFAUseCase,
+		c.DPoPAuthenticator,
 		c.TokenGenerator,
+		c.CSRFTokens,
 		c.Config,
 	)
 
 	// Register protected route with auth middleware
-	r.GET("/api/me", middleware.Auth(c.TokenGenerator), authHandler.GetCurrentUser)
+	r.GET("/api/me",
+		middleware.Auth(c.TokenGenerator,
+			middleware.WithAccountStatus(c.AccountStatusService),
+			middleware.WithTokenPrecedence(c.Config.TokenPrecedence),
+			middleware.WithAPITokens(c.APITokenAuthenticator),
+			middleware.WithDPoP(c.DPoPAuthenticator),
+			middleware.WithRevokedTokens(c.RevokedTokens),
+		),
+		middleware.APIRateLimit(c.RateLimiter, c.Config),
+		middleware.RequireScope(ports.ScopeProfileRead),
+		authHandler.GetCurrentUser,
+	)
 
 	// Wrap Gin router with Lambda adapter
 	ginLambda = ginadapter.New(r)
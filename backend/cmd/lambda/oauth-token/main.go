@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	ginadapter "github.com/awslabs/aws-lambda-go-api-proxy/gin"
+	"github.com/yuki5155/go-google-auth/internal/presentation/http/handlers"
+	"github.com/yuki5155/go-google-auth/internal/presentation/http/middleware"
+	"github.com/yuki5155/go-google-auth/internal/presentation/lambda/common"
+)
+
+var ginLambda *ginadapter.GinLambda
+
+func init() {
+	// Bootstrap with shared initialization
+	r, c := common.Bootstrap()
+
+	// Create OAuth handler using use cases from container
+	oauthHandler := handlers.NewOAuthHandler(
+		c.ClientCredentialsUseCase,
+		c.AuthorizationCodeUseCase,
+		c.TokenExchangeUseCase,
+		c.IntrospectTokenUseCase,
+		c.RevokeTokenUseCase,
+	)
+
+	// Register this Lambda's specific endpoint
+	r.POST("/oauth/token", middleware.LoginRateLimit(c.RateLimiter, c.Config), oauthHandler.Token)
+
+	// Wrap Gin router with Lambda adapter
+	ginLambda = ginadapter.New(r)
+}
+
+func Handler(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return ginLambda.ProxyWithContext(ctx, req)
+}
+
+func main() {
+	lambda.Start(Handler)
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	ginadapter "github.com/awslabs/aws-lambda-go-api-proxy/gin"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/presentation/http/handlers"
+	"github.com/yuki5155/go-google-auth/internal/presentation/http/middleware"
+	"github.com/yuki5155/go-google-auth/internal/presentation/lambda/common"
+)
+
+var ginLambda *ginadapter.GinLambda
+
+func init() {
+	// Bootstrap with shared initialization
+	r, c := common.Bootstrap()
+
+	// Create OIDC handler using use cases from container
+	oidcHandler := handlers.NewOIDCHandler(
+		c.AuthorizeUseCase,
+		c.GetConsentRequestUseCase,
+		c.DecideConsentUseCase,
+		c.GetUserInfoUseCase,
+		c.RegisterClientUseCase,
+		c.IDTokens,
+		c.Config,
+	)
+
+	// Register this Lambda's specific endpoint
+	r.GET("/oauth/userinfo",
+		middleware.Auth(c.TokenGenerator,
+			middleware.WithAccountStatus(c.AccountStatusService),
+			middleware.WithRevokedTokens(c.RevokedTokens),
+		),
+		middleware.RequireScope(ports.ScopeOpenID),
+		oidcHandler.UserInfo,
+	)
+
+	// Wrap Gin router with Lambda adapter
+	ginLambda = ginadapter.New(r)
+}
+
+func Handler(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return ginLambda.ProxyWithContext(ctx, req)
+}
+
+func main() {
+	lambda.Start(Handler)
+}
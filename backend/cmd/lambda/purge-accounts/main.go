@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/yuki5155/go-google-auth/internal/application/account"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/config"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/container"
+)
+
+var purgeDeletedAccounts *account.PurgeDeletedAccountsUseCase
+
+func init() {
+	// Scheduled functions need the container but no router
+	c := container.NewContainer(config.Load())
+	purgeDeletedAccounts = c.PurgeDeletedAccountsUseCase
+}
+
+// Handler hard-deletes accounts whose grace period has passed; it is invoked
+// by an EventBridge schedule instead of API Gateway
+func Handler(ctx context.Context, event events.CloudWatchEvent) error {
+	purged, err := purgeDeletedAccounts.Execute(ctx)
+	if purged > 0 {
+		log.Printf("Purged %d deleted account(s)", purged)
+	}
+	return err
+}
+
+func main() {
+	lambda.Start(Handler)
+}
@@ -0,0 +1,176 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/events"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/organization"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// StatusCacheInvalidator drops cached account statuses so that a deletion
+// takes effect immediately in the auth middleware
+type StatusCacheInvalidator interface {
+	Invalidate(userID string)
+}
+
+// DeleteAccountUseCase handles self-service account deletion
+type DeleteAccountUseCase struct {
+	userRepo         user.Repository
+	sessionRepo      session.Repository
+	membershipRepo   organization.MembershipRepository
+	scheduler        ports.DeletionScheduler
+	eventPublisher   ports.EventPublisher
+	statusCache      StatusCacheInvalidator
+	recentAuthMaxAge time.Duration
+	gracePeriod      time.Duration
+}
+
+// NewDeleteAccountUseCase creates a new DeleteAccountUseCase
+// recentAuthMaxAge is how long ago the user may have last authenticated;
+// gracePeriod is how long the soft-deleted data is kept before it is purged
+func NewDeleteAccountUseCase(
+	userRepo user.Repository,
+	sessionRepo session.Repository,
+	membershipRepo organization.MembershipRepository,
+	scheduler ports.DeletionScheduler,
+	eventPublisher ports.EventPublisher,
+	statusCache StatusCacheInvalidator,
+	recentAuthMaxAge time.Duration,
+	gracePeriod time.Duration,
+) *DeleteAccountUseCase {
+	return &DeleteAccountUseCase{
+		userRepo:         userRepo,
+		sessionRepo:      sessionRepo,
+		membershipRepo:   membershipRepo,
+		scheduler:        scheduler,
+		eventPublisher:   eventPublisher,
+		statusCache:      statusCache,
+		recentAuthMaxAge: recentAuthMaxAge,
+		gracePeriod:      gracePeriod,
+	}
+}
+
+// Execute soft-deletes the authenticated user's account, revokes all of
+// their sessions and schedules the account data for hard deletion
+func (uc *DeleteAccountUseCase) Execute(ctx context.Context, claims *ports.TokenClaims) (*dto.DeleteAccountResponse, error) {
+	if claims == nil {
+		return nil, shared.ErrMissingToken
+	}
+
+	userID, err := user.NewUserID(claims.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID in token: %w", err)
+	}
+
+	domainUser, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if err == shared.ErrUserNotFound {
+			return nil, shared.ErrUnauthorized
+		}
+		return nil, fmt.Errorf("failed to retrieve user: %w", err)
+	}
+
+	// Deleting an account requires a recent authentication in this session,
+	// either at login or through POST /api/me/reauth
+	now := time.Now()
+	if !claims.AuthenticatedWithin(uc.recentAuthMaxAge, now) {
+		return nil, shared.ErrReauthenticationRequired
+	}
+
+	// Other members would be left without an owner; organizations the user
+	// is the only member of are purged with the account
+	owned, err := findSoleOwnerships(ctx, uc.membershipRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, ownership := range owned {
+		if len(ownership.members) > 1 {
+			return nil, shared.ErrLastOrganizationOwner
+		}
+	}
+
+	purgeAt := now.Add(uc.gracePeriod)
+	if err := domainUser.MarkDeleted(purgeAt); err != nil {
+		return nil, err
+	}
+
+	if err := uc.userRepo.Save(ctx, domainUser); err != nil {
+		return nil, fmt.Errorf("failed to delete user: %w", err)
+	}
+	uc.statusCache.Invalidate(userID.Value())
+
+	if err := uc.revokeSessions(ctx, userID, now); err != nil {
+		return nil, err
+	}
+
+	if err := uc.scheduler.Schedule(ctx, userID.Value(), purgeAt); err != nil {
+		return nil, fmt.Errorf("failed to schedule account purge: %w", err)
+	}
+
+	events.Publish(ctx, uc.eventPublisher, domainUser)
+
+	log.Printf("User deleted their account: %s (purge at %s)", userID.Value(), purgeAt.Format(time.RFC3339))
+
+	return &dto.DeleteAccountResponse{
+		Message: "Account deleted",
+		PurgeAt: purgeAt,
+	}, nil
+}
+
+// revokeSessions revokes every active session of the user
+func (uc *DeleteAccountUseCase) revokeSessions(ctx context.Context, userID user.UserID, now time.Time) error {
+	sessions, err := uc.sessionRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve sessions: %w", err)
+	}
+
+	for _, s := range sessions {
+		if s.IsRevoked() {
+			continue
+		}
+		s.Revoke(now)
+		if err := uc.sessionRepo.Save(ctx, s); err != nil {
+			return fmt.Errorf("failed to revoke session: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// soleOwnership is an organization the user is the only owner of
+type soleOwnership struct {
+	owner   *organization.Membership
+	members []*organization.Membership // longest-standing first, including the owner
+}
+
+// findSoleOwnerships returns the organizations the user is the only owner of
+func findSoleOwnerships(ctx context.Context, membershipRepo organization.MembershipRepository, userID user.UserID) ([]soleOwnership, error) {
+	memberships, err := membershipRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve organization memberships: %w", err)
+	}
+
+	var owned []soleOwnership
+	for _, membership := range memberships {
+		if membership.Role() != organization.RoleOwner {
+			continue
+		}
+		members, err := membershipRepo.FindByOrganization(ctx, membership.OrganizationID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve organization members: %w", err)
+		}
+		if organization.CountOwners(members) == 1 {
+			owned = append(owned, soleOwnership{owner: membership, members: members})
+		}
+	}
+
+	return owned, nil
+}
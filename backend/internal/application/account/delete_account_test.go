@@ -0,0 +1,296 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/organization"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+	"github.com/yuki5155/go-google-auth/internal/mocks"
+)
+
+// fakeStatusCache records invalidated user IDs
+type fakeStatusCache struct {
+	invalidated []string
+}
+
+func (f *fakeStatusCache) Invalidate(userID string) {
+	f.invalidated = append(f.invalidated, userID)
+}
+
+func newAccountTestUser(t *testing.T, loggedIn bool) *user.User {
+	t.Helper()
+
+	userID, _ := user.NewUserID("test-user-123")
+	email, _ := user.NewEmail("test@example.com", true)
+	profile := user.NewProfile("Test User", "https://example.com/photo.jpg")
+	domainUser, err := user.NewUser(userID, email, profile)
+	require.NoError(t, err)
+
+	if loggedIn {
+		domainUser.RecordLogin()
+	}
+	domainUser.ClearDomainEvents()
+
+	return domainUser
+}
+
+func TestDeleteAccountUseCase_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockMembershipRepo := mocks.NewMockMembershipRepository(ctrl)
+	mockScheduler := mocks.NewMockDeletionScheduler(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
+	statusCache := &fakeStatusCache{}
+	domainUser := newAccountTestUser(t, true)
+
+	activeSession, _ := session.NewSession(domainUser.ID(), time.Now().Add(time.Hour))
+	revokedSession, _ := session.NewSession(domainUser.ID(), time.Now().Add(time.Hour))
+	revokedSession.Revoke(time.Now().Add(-time.Minute))
+
+	mockRepo.EXPECT().
+		FindByID(ctx, domainUser.ID()).
+		Return(domainUser, nil)
+
+	mockMembershipRepo.EXPECT().
+		FindByUserID(ctx, domainUser.ID()).
+		Return(nil, nil)
+
+	mockRepo.EXPECT().
+		Save(ctx, domainUser).
+		Return(nil)
+
+	mockSessionRepo.EXPECT().
+		FindByUserID(ctx, domainUser.ID()).
+		Return([]*session.Session{activeSession, revokedSession}, nil)
+
+	// Only the active session needs to be revoked
+	mockSessionRepo.EXPECT().
+		Save(ctx, activeSession).
+		Return(nil)
+
+	mockScheduler.EXPECT().
+		Schedule(ctx, "test-user-123", gomock.Any()).
+		Return(nil)
+
+	mockPublisher.EXPECT().
+		Publish(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, events []shared.DomainEvent) error {
+			require.Len(t, events, 1)
+			assert.Equal(t, user.EventTypeUserDeleted, events[0].EventType())
+			return nil
+		})
+
+	useCase := NewDeleteAccountUseCase(mockRepo, mockSessionRepo, mockMembershipRepo, mockScheduler, mockPublisher, statusCache, 10*time.Minute, 30*24*time.Hour)
+
+	result, err := useCase.Execute(ctx, &ports.TokenClaims{UserID: "test-user-123", AuthTime: time.Now()})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Account deleted", result.Message)
+	assert.WithinDuration(t, time.Now().Add(30*24*time.Hour), result.PurgeAt, time.Minute)
+	assert.Equal(t, user.StatusDeleted, domainUser.Status())
+	assert.True(t, activeSession.IsRevoked())
+	assert.Equal(t, []string{"test-user-123"}, statusCache.invalidated)
+	assert.Empty(t, domainUser.DomainEvents())
+}
+
+func TestDeleteAccountUseCase_RequiresRecentAuth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockMembershipRepo := mocks.NewMockMembershipRepository(ctrl)
+	mockScheduler := mocks.NewMockDeletionScheduler(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
+	// A recent login on another device does not count for this session
+	domainUser := newAccountTestUser(t, true)
+
+	mockRepo.EXPECT().
+		FindByID(ctx, domainUser.ID()).
+		Return(domainUser, nil)
+
+	useCase := NewDeleteAccountUseCase(mockRepo, mockSessionRepo, mockMembershipRepo, mockScheduler, mockPublisher, &fakeStatusCache{}, 10*time.Minute, 30*24*time.Hour)
+
+	result, err := useCase.Execute(ctx, &ports.TokenClaims{UserID: "test-user-123", AuthTime: time.Now().Add(-time.Hour)})
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrReauthenticationRequired, err)
+	assert.Equal(t, user.StatusActive, domainUser.Status())
+}
+
+func TestDeleteAccountUseCase_TokenWithoutAuthTime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockMembershipRepo := mocks.NewMockMembershipRepository(ctrl)
+	mockScheduler := mocks.NewMockDeletionScheduler(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
+	domainUser := newAccountTestUser(t, true)
+
+	mockRepo.EXPECT().
+		FindByID(ctx, domainUser.ID()).
+		Return(domainUser, nil)
+
+	useCase := NewDeleteAccountUseCase(mockRepo, mockSessionRepo, mockMembershipRepo, mockScheduler, mockPublisher, &fakeStatusCache{}, 10*time.Minute, 30*24*time.Hour)
+
+	result, err := useCase.Execute(ctx, &ports.TokenClaims{UserID: "test-user-123"})
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrReauthenticationRequired, err)
+}
+
+func TestDeleteAccountUseCase_NilClaims(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useCase := NewDeleteAccountUseCase(
+		mocks.NewMockRepository(ctrl),
+		mocks.NewMockSessionRepository(ctrl),
+		mocks.NewMockMembershipRepository(ctrl),
+		mocks.NewMockDeletionScheduler(ctrl),
+		mocks.NewMockEventPublisher(ctrl),
+		&fakeStatusCache{},
+		10*time.Minute,
+		30*24*time.Hour,
+	)
+
+	result, err := useCase.Execute(context.Background(), nil)
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrMissingToken, err)
+}
+
+func TestDeleteAccountUseCase_UserNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+
+	mockRepo.EXPECT().
+		FindByID(ctx, gomock.Any()).
+		Return(nil, shared.ErrUserNotFound)
+
+	useCase := NewDeleteAccountUseCase(
+		mockRepo,
+		mocks.NewMockSessionRepository(ctrl),
+		mocks.NewMockMembershipRepository(ctrl),
+		mocks.NewMockDeletionScheduler(ctrl),
+		mocks.NewMockEventPublisher(ctrl),
+		&fakeStatusCache{},
+		10*time.Minute,
+		30*24*time.Hour,
+	)
+
+	result, err := useCase.Execute(ctx, &ports.TokenClaims{UserID: "missing-user"})
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrUnauthorized, err)
+}
+
+func TestDeleteAccountUseCase_SaveFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockMembershipRepo := mocks.NewMockMembershipRepository(ctrl)
+	domainUser := newAccountTestUser(t, true)
+
+	mockRepo.EXPECT().
+		FindByID(ctx, domainUser.ID()).
+		Return(domainUser, nil)
+
+	mockMembershipRepo.EXPECT().
+		FindByUserID(ctx, domainUser.ID()).
+		Return(nil, nil)
+
+	mockRepo.EXPECT().
+		Save(ctx, domainUser).
+		Return(errors.New("database error"))
+
+	useCase := NewDeleteAccountUseCase(
+		mockRepo,
+		mocks.NewMockSessionRepository(ctrl),
+		mockMembershipRepo,
+		mocks.NewMockDeletionScheduler(ctrl),
+		mocks.NewMockEventPublisher(ctrl),
+		&fakeStatusCache{},
+		10*time.Minute,
+		30*24*time.Hour,
+	)
+
+	result, err := useCase.Execute(ctx, &ports.TokenClaims{UserID: "test-user-123", AuthTime: time.Now()})
+
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "failed to delete user")
+}
+
+func TestDeleteAccountUseCase_LastOrganizationOwner(t *testing.T) {
+	orgID, _ := organization.NewOrganizationID("org-1")
+	memberID, _ := user.NewUserID("member-456")
+
+	tests := []struct {
+		name       string
+		memberRole organization.Role
+		wantErr    error
+	}{
+		{"other members would be left without an owner", organization.RoleMember, shared.ErrLastOrganizationOwner},
+		{"another owner remains", organization.RoleOwner, nil},
+		{"only member", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			mockRepo := mocks.NewMockRepository(ctrl)
+			mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+			mockMembershipRepo := mocks.NewMockMembershipRepository(ctrl)
+			mockScheduler := mocks.NewMockDeletionScheduler(ctrl)
+			mockPublisher := mocks.NewMockEventPublisher(ctrl)
+			domainUser := newAccountTestUser(t, true)
+			owner := organization.ReconstructMembership(orgID, domainUser.ID(), organization.RoleOwner, time.Now().Add(-time.Hour))
+			members := []*organization.Membership{owner}
+			if tt.memberRole != "" {
+				members = append(members, organization.ReconstructMembership(orgID, memberID, tt.memberRole, time.Now()))
+			}
+
+			mockRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+			mockMembershipRepo.EXPECT().FindByUserID(ctx, domainUser.ID()).Return([]*organization.Membership{owner}, nil)
+			mockMembershipRepo.EXPECT().FindByOrganization(ctx, orgID).Return(members, nil)
+			if tt.wantErr == nil {
+				mockRepo.EXPECT().Save(ctx, domainUser).Return(nil)
+				mockSessionRepo.EXPECT().FindByUserID(ctx, domainUser.ID()).Return(nil, nil)
+				mockScheduler.EXPECT().Schedule(ctx, "test-user-123", gomock.Any()).Return(nil)
+				mockPublisher.EXPECT().Publish(ctx, gomock.Any()).Return(nil)
+			}
+
+			useCase := NewDeleteAccountUseCase(mockRepo, mockSessionRepo, mockMembershipRepo, mockScheduler, mockPublisher, &fakeStatusCache{}, 10*time.Minute, 30*24*time.Hour)
+
+			_, err := useCase.Execute(ctx, &ports.TokenClaims{UserID: "test-user-123", AuthTime: time.Now()})
+
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
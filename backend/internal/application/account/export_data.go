@@ -0,0 +1,63 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/audit"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// ExportDataUseCase builds a data export (GDPR) of everything stored about a user
+type ExportDataUseCase struct {
+	userRepo    user.Repository
+	sessionRepo session.Repository
+	auditRepo   audit.Repository
+}
+
+// NewExportDataUseCase creates a new ExportDataUseCase
+func NewExportDataUseCase(userRepo user.Repository, sessionRepo session.Repository, auditRepo audit.Repository) *ExportDataUseCase {
+	return &ExportDataUseCase{
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		auditRepo:   auditRepo,
+	}
+}
+
+// Execute returns the authenticated user's profile, identities, sessions and audit history
+func (uc *ExportDataUseCase) Execute(ctx context.Context, claims *ports.TokenClaims) (*dto.AccountExport, error) {
+	if claims == nil {
+		return nil, shared.ErrMissingToken
+	}
+
+	userID, err := user.NewUserID(claims.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID in token: %w", err)
+	}
+
+	domainUser, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if err == shared.ErrUserNotFound {
+			return nil, shared.ErrUnauthorized
+		}
+		return nil, fmt.Errorf("failed to retrieve user: %w", err)
+	}
+
+	sessions, err := uc.sessionRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve sessions: %w", err)
+	}
+
+	entries, err := uc.auditRepo.FindByUserID(ctx, userID.Value())
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve audit history: %w", err)
+	}
+
+	export := dto.NewAccountExport(domainUser, sessions, entries, time.Now())
+	return &export, nil
+}
@@ -0,0 +1,126 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/audit"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/mocks"
+)
+
+func TestExportDataUseCase_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockAuditRepo := mocks.NewMockAuditRepository(ctrl)
+	domainUser := newAccountTestUser(t, true)
+
+	loginSession, _ := session.NewSession(domainUser.ID(), time.Now().Add(time.Hour))
+	revokedSession, _ := session.NewSession(domainUser.ID(), time.Now().Add(time.Hour))
+	revokedSession.Revoke(time.Now())
+	entries := []audit.Entry{
+		audit.NewEntry("test-user-123", "user.registered", time.Now().Add(-time.Hour)),
+		audit.NewEntry("test-user-123", "user.impersonation_started", time.Now()).WithActor("admin-123"),
+	}
+
+	mockRepo.EXPECT().
+		FindByID(ctx, domainUser.ID()).
+		Return(domainUser, nil)
+
+	mockSessionRepo.EXPECT().
+		FindByUserID(ctx, domainUser.ID()).
+		Return([]*session.Session{loginSession, revokedSession}, nil)
+
+	mockAuditRepo.EXPECT().
+		FindByUserID(ctx, "test-user-123").
+		Return(entries, nil)
+
+	useCase := NewExportDataUseCase(mockRepo, mockSessionRepo, mockAuditRepo)
+
+	result, err := useCase.Execute(ctx, &ports.TokenClaims{UserID: "test-user-123"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "test-user-123", result.Profile.ID)
+	assert.Equal(t, "active", result.Status)
+	require.Len(t, result.Identities, 1)
+	assert.Equal(t, "google", result.Identities[0].Provider)
+	assert.Equal(t, "test-user-123", result.Identities[0].Subject)
+	require.Len(t, result.Sessions, 2)
+	assert.Equal(t, loginSession.ID().Value(), result.Sessions[0].ID)
+	assert.Nil(t, result.Sessions[0].RevokedAt)
+	assert.NotNil(t, result.Sessions[1].RevokedAt)
+	require.Len(t, result.AuditHistory, 2)
+	assert.Equal(t, "user.registered", result.AuditHistory[0].Action)
+	assert.Empty(t, result.AuditHistory[0].Actor)
+	assert.Equal(t, "admin-123", result.AuditHistory[1].Actor)
+	assert.False(t, result.ExportedAt.IsZero())
+}
+
+func TestExportDataUseCase_NilClaims(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useCase := NewExportDataUseCase(
+		mocks.NewMockRepository(ctrl),
+		mocks.NewMockSessionRepository(ctrl),
+		mocks.NewMockAuditRepository(ctrl),
+	)
+
+	result, err := useCase.Execute(context.Background(), nil)
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrMissingToken, err)
+}
+
+func TestExportDataUseCase_UserNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+
+	mockRepo.EXPECT().
+		FindByID(ctx, gomock.Any()).
+		Return(nil, shared.ErrUserNotFound)
+
+	useCase := NewExportDataUseCase(mockRepo, mocks.NewMockSessionRepository(ctrl), mocks.NewMockAuditRepository(ctrl))
+
+	result, err := useCase.Execute(ctx, &ports.TokenClaims{UserID: "missing-user"})
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrUnauthorized, err)
+}
+
+func TestExportDataUseCase_AuditRepositoryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockAuditRepo := mocks.NewMockAuditRepository(ctrl)
+	domainUser := newAccountTestUser(t, true)
+
+	mockRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	mockSessionRepo.EXPECT().FindByUserID(ctx, domainUser.ID()).Return(nil, nil)
+	mockAuditRepo.EXPECT().FindByUserID(ctx, "test-user-123").Return(nil, errors.New("database error"))
+
+	useCase := NewExportDataUseCase(mockRepo, mockSessionRepo, mockAuditRepo)
+
+	result, err := useCase.Execute(ctx, &ports.TokenClaims{UserID: "test-user-123"})
+
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "failed to retrieve audit history")
+}
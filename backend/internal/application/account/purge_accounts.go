@@ -0,0 +1,192 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/apitoken"
+	"github.com/yuki5155/go-google-auth/internal/domain/audit"
+	"github.com/yuki5155/go-google-auth/internal/domain/consent"
+	"github.com/yuki5155/go-google-auth/internal/domain/mfa"
+	"github.com/yuki5155/go-google-auth/internal/domain/organization"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// PurgeDeletedAccountsUseCase hard-deletes accounts whose deletion grace period has passed
+type PurgeDeletedAccountsUseCase struct {
+	userRepo       user.Repository
+	sessionRepo    session.Repository
+	mfaRepo        mfa.Repository
+	tokenRepo      apitoken.Repository
+	consentRepo    consent.Repository
+	orgRepo        organization.Repository
+	membershipRepo organization.MembershipRepository
+	invitationRepo organization.InvitationRepository
+	auditRepo      audit.Repository
+	scheduler      ports.DeletionScheduler
+}
+
+// NewPurgeDeletedAccountsUseCase creates a new PurgeDeletedAccountsUseCase
+func NewPurgeDeletedAccountsUseCase(
+	userRepo user.Repository,
+	sessionRepo session.Repository,
+	mfaRepo mfa.Repository,
+	tokenRepo apitoken.Repository,
+	consentRepo consent.Repository,
+	orgRepo organization.Repository,
+	membershipRepo organization.MembershipRepository,
+	invitationRepo organization.InvitationRepository,
+	auditRepo audit.Repository,
+	scheduler ports.DeletionScheduler,
+) *PurgeDeletedAccountsUseCase {
+	return &PurgeDeletedAccountsUseCase{
+		userRepo:       userRepo,
+		sessionRepo:    sessionRepo,
+		mfaRepo:        mfaRepo,
+		tokenRepo:      tokenRepo,
+		consentRepo:    consentRepo,
+		orgRepo:        orgRepo,
+		membershipRepo: membershipRepo,
+		invitationRepo: invitationRepo,
+		auditRepo:      auditRepo,
+		scheduler:      scheduler,
+	}
+}
+
+// Execute purges all accounts that are due and returns how many were purged.
+// An account that fails is left scheduled for the next run and does not stop
+// the others; the failures are returned together.
+func (uc *PurgeDeletedAccountsUseCase) Execute(ctx context.Context) (int, error) {
+	due, err := uc.scheduler.Due(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to list due deletions: %w", err)
+	}
+
+	purged := 0
+	var errs []error
+	for _, rawUserID := range due {
+		if err := uc.purge(ctx, rawUserID); err != nil {
+			log.Printf("Failed to purge account %s: %v", rawUserID, err)
+			errs = append(errs, err)
+			continue
+		}
+		purged++
+	}
+
+	return purged, errors.Join(errs...)
+}
+
+// purge removes all data of a single soft-deleted user
+func (uc *PurgeDeletedAccountsUseCase) purge(ctx context.Context, rawUserID string) error {
+	userID, err := user.NewUserID(rawUserID)
+	if err != nil {
+		return uc.scheduler.Remove(ctx, rawUserID)
+	}
+
+	domainUser, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil && err != shared.ErrUserNotFound {
+		return fmt.Errorf("failed to retrieve user %s: %w", rawUserID, err)
+	}
+
+	// Only accounts that are still soft-deleted are purged
+	if domainUser != nil {
+		if domainUser.Status() != user.StatusDeleted {
+			log.Printf("Skipping purge of user %s: account is %s", rawUserID, domainUser.Status())
+			return uc.scheduler.Remove(ctx, rawUserID)
+		}
+
+		if err := uc.userRepo.Delete(ctx, userID); err != nil {
+			return fmt.Errorf("failed to delete user %s: %w", rawUserID, err)
+		}
+	}
+
+	if err := uc.sessionRepo.DeleteByUserID(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete sessions of user %s: %w", rawUserID, err)
+	}
+
+	if err := uc.mfaRepo.DeleteByUserID(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete MFA enrollment of user %s: %w", rawUserID, err)
+	}
+
+	if err := uc.tokenRepo.DeleteByUserID(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete API tokens of user %s: %w", rawUserID, err)
+	}
+
+	if err := uc.consentRepo.DeleteByUserID(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete OAuth consents of user %s: %w", rawUserID, err)
+	}
+
+	if err := uc.releaseOrganizations(ctx, userID); err != nil {
+		return fmt.Errorf("failed to release organizations of user %s: %w", rawUserID, err)
+	}
+
+	if err := uc.membershipRepo.DeleteByUserID(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete organization memberships of user %s: %w", rawUserID, err)
+	}
+
+	if err := uc.auditRepo.DeleteByUserID(ctx, rawUserID); err != nil {
+		return fmt.Errorf("failed to delete audit history of user %s: %w", rawUserID, err)
+	}
+
+	if err := uc.scheduler.Remove(ctx, rawUserID); err != nil {
+		return fmt.Errorf("failed to unschedule user %s: %w", rawUserID, err)
+	}
+
+	log.Printf("Purged deleted account: %s", rawUserID)
+	return nil
+}
+
+// releaseOrganizations deletes the organizations the user was the only member
+// of, and hands the others they owned alone to their longest-standing member,
+// who may have joined through a pending invitation after the account was deleted
+func (uc *PurgeDeletedAccountsUseCase) releaseOrganizations(ctx context.Context, userID user.UserID) error {
+	owned, err := findSoleOwnerships(ctx, uc.membershipRepo, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, ownership := range owned {
+		orgID := ownership.owner.OrganizationID()
+
+		var successor *organization.Membership
+		for _, member := range ownership.members {
+			if !member.UserID().Equals(userID) {
+				successor = member
+				break
+			}
+		}
+
+		if successor != nil {
+			if err := successor.ChangeRole(ownership.owner, organization.RoleOwner, 1); err != nil {
+				return err
+			}
+			if err := uc.membershipRepo.Save(ctx, successor); err != nil {
+				return fmt.Errorf("failed to transfer organization %s: %w", orgID.Value(), err)
+			}
+			log.Printf("Transferred organization %s to %s", orgID.Value(), successor.UserID().Value())
+			continue
+		}
+
+		invitations, err := uc.invitationRepo.FindByOrganization(ctx, orgID)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve invitations of organization %s: %w", orgID.Value(), err)
+		}
+		for _, invitation := range invitations {
+			if err := uc.invitationRepo.Delete(ctx, invitation.ID()); err != nil {
+				return fmt.Errorf("failed to delete invitation of organization %s: %w", orgID.Value(), err)
+			}
+		}
+		if err := uc.orgRepo.Delete(ctx, orgID); err != nil {
+			return fmt.Errorf("failed to delete organization %s: %w", orgID.Value(), err)
+		}
+		log.Printf("Deleted organization %s with its only member", orgID.Value())
+	}
+
+	return nil
+}
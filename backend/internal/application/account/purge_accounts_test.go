@@ -0,0 +1,220 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/organization"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+	"github.com/yuki5155/go-google-auth/internal/mocks"
+)
+
+func TestPurgeDeletedAccountsUseCase_PurgesDeletedUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockTokenRepo := mocks.NewMockAPITokenRepository(ctrl)
+	mockConsentRepo := mocks.NewMockConsentRepository(ctrl)
+	mockMembershipRepo := mocks.NewMockMembershipRepository(ctrl)
+	mockAuditRepo := mocks.NewMockAuditRepository(ctrl)
+	mockScheduler := mocks.NewMockDeletionScheduler(ctrl)
+	domainUser := newAccountTestUser(t, true)
+	require.NoError(t, domainUser.MarkDeleted(time.Now()))
+
+	mockScheduler.EXPECT().Due(ctx, gomock.Any()).Return([]string{"test-user-123"}, nil)
+	mockRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	mockRepo.EXPECT().Delete(ctx, domainUser.ID()).Return(nil)
+	mockSessionRepo.EXPECT().DeleteByUserID(ctx, domainUser.ID()).Return(nil)
+	mockMFARepo.EXPECT().DeleteByUserID(ctx, domainUser.ID()).Return(nil)
+	mockTokenRepo.EXPECT().DeleteByUserID(ctx, domainUser.ID()).Return(nil)
+	mockConsentRepo.EXPECT().DeleteByUserID(ctx, domainUser.ID()).Return(nil)
+	mockMembershipRepo.EXPECT().FindByUserID(ctx, domainUser.ID()).Return(nil, nil)
+	mockMembershipRepo.EXPECT().DeleteByUserID(ctx, domainUser.ID()).Return(nil)
+	mockAuditRepo.EXPECT().DeleteByUserID(ctx, "test-user-123").Return(nil)
+	mockScheduler.EXPECT().Remove(ctx, "test-user-123").Return(nil)
+
+	useCase := NewPurgeDeletedAccountsUseCase(mockRepo, mockSessionRepo, mockMFARepo, mockTokenRepo, mockConsentRepo, mocks.NewMockOrganizationRepository(ctrl), mockMembershipRepo, mocks.NewMockInvitationRepository(ctrl), mockAuditRepo, mockScheduler)
+
+	purged, err := useCase.Execute(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+}
+
+func TestPurgeDeletedAccountsUseCase_SkipsActiveUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockScheduler := mocks.NewMockDeletionScheduler(ctrl)
+	domainUser := newAccountTestUser(t, true)
+
+	mockScheduler.EXPECT().Due(ctx, gomock.Any()).Return([]string{"test-user-123"}, nil)
+	mockRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	mockScheduler.EXPECT().Remove(ctx, "test-user-123").Return(nil)
+
+	useCase := NewPurgeDeletedAccountsUseCase(mockRepo, mocks.NewMockSessionRepository(ctrl), mocks.NewMockMFARepository(ctrl), mocks.NewMockAPITokenRepository(ctrl), mocks.NewMockConsentRepository(ctrl), mocks.NewMockOrganizationRepository(ctrl), mocks.NewMockMembershipRepository(ctrl), mocks.NewMockInvitationRepository(ctrl), mocks.NewMockAuditRepository(ctrl), mockScheduler)
+
+	purged, err := useCase.Execute(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+}
+
+func TestPurgeDeletedAccountsUseCase_UserAlreadyGone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockTokenRepo := mocks.NewMockAPITokenRepository(ctrl)
+	mockConsentRepo := mocks.NewMockConsentRepository(ctrl)
+	mockMembershipRepo := mocks.NewMockMembershipRepository(ctrl)
+	mockAuditRepo := mocks.NewMockAuditRepository(ctrl)
+	mockScheduler := mocks.NewMockDeletionScheduler(ctrl)
+
+	mockScheduler.EXPECT().Due(ctx, gomock.Any()).Return([]string{"gone-user"}, nil)
+	mockRepo.EXPECT().FindByID(ctx, gomock.Any()).Return(nil, shared.ErrUserNotFound)
+	mockSessionRepo.EXPECT().DeleteByUserID(ctx, gomock.Any()).Return(nil)
+	mockMFARepo.EXPECT().DeleteByUserID(ctx, gomock.Any()).Return(nil)
+	mockTokenRepo.EXPECT().DeleteByUserID(ctx, gomock.Any()).Return(nil)
+	mockConsentRepo.EXPECT().DeleteByUserID(ctx, gomock.Any()).Return(nil)
+	mockMembershipRepo.EXPECT().FindByUserID(ctx, gomock.Any()).Return(nil, nil)
+	mockMembershipRepo.EXPECT().DeleteByUserID(ctx, gomock.Any()).Return(nil)
+	mockAuditRepo.EXPECT().DeleteByUserID(ctx, "gone-user").Return(nil)
+	mockScheduler.EXPECT().Remove(ctx, "gone-user").Return(nil)
+
+	useCase := NewPurgeDeletedAccountsUseCase(mockRepo, mockSessionRepo, mockMFARepo, mockTokenRepo, mockConsentRepo, mocks.NewMockOrganizationRepository(ctrl), mockMembershipRepo, mocks.NewMockInvitationRepository(ctrl), mockAuditRepo, mockScheduler)
+
+	purged, err := useCase.Execute(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+}
+
+func TestPurgeDeletedAccountsUseCase_NothingDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockScheduler := mocks.NewMockDeletionScheduler(ctrl)
+
+	mockScheduler.EXPECT().Due(ctx, gomock.Any()).Return([]string{}, nil)
+
+	useCase := NewPurgeDeletedAccountsUseCase(
+		mocks.NewMockRepository(ctrl),
+		mocks.NewMockSessionRepository(ctrl),
+		mocks.NewMockMFARepository(ctrl),
+		mocks.NewMockAPITokenRepository(ctrl),
+		mocks.NewMockConsentRepository(ctrl),
+		mocks.NewMockOrganizationRepository(ctrl),
+		mocks.NewMockMembershipRepository(ctrl),
+		mocks.NewMockInvitationRepository(ctrl),
+		mocks.NewMockAuditRepository(ctrl),
+		mockScheduler,
+	)
+
+	purged, err := useCase.Execute(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, purged)
+}
+
+func TestPurgeDeletedAccountsUseCase_ReleasesSoleOwnedOrganizations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockTokenRepo := mocks.NewMockAPITokenRepository(ctrl)
+	mockConsentRepo := mocks.NewMockConsentRepository(ctrl)
+	mockOrgRepo := mocks.NewMockOrganizationRepository(ctrl)
+	mockMembershipRepo := mocks.NewMockMembershipRepository(ctrl)
+	mockInvitationRepo := mocks.NewMockInvitationRepository(ctrl)
+	mockAuditRepo := mocks.NewMockAuditRepository(ctrl)
+	mockScheduler := mocks.NewMockDeletionScheduler(ctrl)
+	domainUser := newAccountTestUser(t, true)
+	require.NoError(t, domainUser.MarkDeleted(time.Now()))
+
+	// The user owns one organization alone and another that gained a member
+	// through an invitation during the grace period
+	soloOrgID, _ := organization.NewOrganizationID("org-solo")
+	sharedOrgID, _ := organization.NewOrganizationID("org-shared")
+	joinedAt := time.Now().Add(-time.Hour)
+	soloOwner := organization.ReconstructMembership(soloOrgID, domainUser.ID(), organization.RoleOwner, joinedAt)
+	sharedOwner := organization.ReconstructMembership(sharedOrgID, domainUser.ID(), organization.RoleOwner, joinedAt)
+	memberID, _ := user.NewUserID("member-456")
+	member := organization.ReconstructMembership(sharedOrgID, memberID, organization.RoleMember, time.Now())
+	inviteeEmail, _ := user.NewEmail("invitee@example.com", true)
+	invitation, _, err := organization.NewInvitation(soloOwner, inviteeEmail, organization.RoleMember, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	mockScheduler.EXPECT().Due(ctx, gomock.Any()).Return([]string{"test-user-123"}, nil)
+	mockRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	mockRepo.EXPECT().Delete(ctx, domainUser.ID()).Return(nil)
+	mockSessionRepo.EXPECT().DeleteByUserID(ctx, domainUser.ID()).Return(nil)
+	mockMFARepo.EXPECT().DeleteByUserID(ctx, domainUser.ID()).Return(nil)
+	mockTokenRepo.EXPECT().DeleteByUserID(ctx, domainUser.ID()).Return(nil)
+	mockConsentRepo.EXPECT().DeleteByUserID(ctx, domainUser.ID()).Return(nil)
+	mockMembershipRepo.EXPECT().FindByUserID(ctx, domainUser.ID()).Return([]*organization.Membership{soloOwner, sharedOwner}, nil)
+	mockMembershipRepo.EXPECT().FindByOrganization(ctx, soloOrgID).Return([]*organization.Membership{soloOwner}, nil)
+	mockMembershipRepo.EXPECT().FindByOrganization(ctx, sharedOrgID).Return([]*organization.Membership{sharedOwner, member}, nil)
+	mockInvitationRepo.EXPECT().FindByOrganization(ctx, soloOrgID).Return([]*organization.Invitation{invitation}, nil)
+	mockInvitationRepo.EXPECT().Delete(ctx, invitation.ID()).Return(nil)
+	mockOrgRepo.EXPECT().Delete(ctx, soloOrgID).Return(nil)
+	mockMembershipRepo.EXPECT().Save(ctx, member).Return(nil)
+	mockMembershipRepo.EXPECT().DeleteByUserID(ctx, domainUser.ID()).Return(nil)
+	mockAuditRepo.EXPECT().DeleteByUserID(ctx, "test-user-123").Return(nil)
+	mockScheduler.EXPECT().Remove(ctx, "test-user-123").Return(nil)
+
+	useCase := NewPurgeDeletedAccountsUseCase(mockRepo, mockSessionRepo, mockMFARepo, mockTokenRepo, mockConsentRepo, mockOrgRepo, mockMembershipRepo, mockInvitationRepo, mockAuditRepo, mockScheduler)
+
+	purged, err := useCase.Execute(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	assert.Equal(t, organization.RoleOwner, member.Role())
+}
+
+func TestPurgeDeletedAccountsUseCase_ContinuesAfterFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockScheduler := mocks.NewMockDeletionScheduler(ctrl)
+	domainUser := newAccountTestUser(t, true)
+	failingID, _ := user.NewUserID("failing-user")
+	brokenID, _ := user.NewUserID("broken-user")
+
+	mockScheduler.EXPECT().Due(ctx, gomock.Any()).Return([]string{"failing-user", "test-user-123", "broken-user"}, nil)
+	mockRepo.EXPECT().FindByID(ctx, failingID).Return(nil, errors.New("database error"))
+	mockRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	mockScheduler.EXPECT().Remove(ctx, "test-user-123").Return(nil)
+	mockRepo.EXPECT().FindByID(ctx, brokenID).Return(nil, errors.New("timeout"))
+
+	useCase := NewPurgeDeletedAccountsUseCase(mockRepo, mocks.NewMockSessionRepository(ctrl), mocks.NewMockMFARepository(ctrl), mocks.NewMockAPITokenRepository(ctrl), mocks.NewMockConsentRepository(ctrl), mocks.NewMockOrganizationRepository(ctrl), mocks.NewMockMembershipRepository(ctrl), mocks.NewMockInvitationRepository(ctrl), mocks.NewMockAuditRepository(ctrl), mockScheduler)
+
+	purged, err := useCase.Execute(ctx)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to retrieve user failing-user: database error")
+	assert.Contains(t, err.Error(), "failed to retrieve user broken-user: timeout")
+	assert.Equal(t, 1, purged)
+}
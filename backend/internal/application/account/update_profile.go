@@ -0,0 +1,98 @@
+package account
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/events"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// UpdateProfileUseCase handles user edits to their own profile and preferences
+type UpdateProfileUseCase struct {
+	userRepo       user.Repository
+	eventPublisher ports.EventPublisher
+}
+
+// NewUpdateProfileUseCase creates a new UpdateProfileUseCase
+func NewUpdateProfileUseCase(userRepo user.Repository, eventPublisher ports.EventPublisher) *UpdateProfileUseCase {
+	return &UpdateProfileUseCase{
+		userRepo:       userRepo,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// Execute applies a partial profile update for the authenticated user
+func (uc *UpdateProfileUseCase) Execute(ctx context.Context, claims *ports.TokenClaims, req dto.UpdateProfileRequest) (*dto.UserResponse, error) {
+	if claims == nil {
+		return nil, shared.ErrMissingToken
+	}
+
+	userID, err := user.NewUserID(claims.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID in token: %w", err)
+	}
+
+	domainUser, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if err == shared.ErrUserNotFound {
+			return nil, shared.ErrUnauthorized
+		}
+		return nil, fmt.Errorf("failed to retrieve user: %w", err)
+	}
+
+	profile, err := applyProfileChanges(domainUser.Profile(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	prefs, err := domainUser.Preferences().Merge(req.Preferences)
+	if err != nil {
+		return nil, err
+	}
+
+	domainUser.EditProfile(profile, prefs)
+
+	if err := uc.userRepo.Save(ctx, domainUser); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	events.Publish(ctx, uc.eventPublisher, domainUser)
+
+	response := dto.FromDomain(domainUser)
+	return &response, nil
+}
+
+// applyProfileChanges applies the fields present in the request to a profile
+func applyProfileChanges(profile user.Profile, req dto.UpdateProfileRequest) (user.Profile, error) {
+	var err error
+
+	if req.DisplayName != nil {
+		if profile, err = profile.WithDisplayName(*req.DisplayName); err != nil {
+			return user.Profile{}, err
+		}
+	}
+
+	if req.Picture != nil {
+		if profile, err = profile.WithCustomPicture(*req.Picture); err != nil {
+			return user.Profile{}, err
+		}
+	}
+
+	if req.Locale != nil {
+		if profile, err = profile.WithLocale(*req.Locale); err != nil {
+			return user.Profile{}, err
+		}
+	}
+
+	if req.Timezone != nil {
+		if profile, err = profile.WithTimezone(*req.Timezone); err != nil {
+			return user.Profile{}, err
+		}
+	}
+
+	return profile, nil
+}
@@ -0,0 +1,132 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+	"github.com/yuki5155/go-google-auth/internal/mocks"
+)
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestUpdateProfileUseCase_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
+	domainUser := newAccountTestUser(t, true)
+
+	mockRepo.EXPECT().
+		FindByID(ctx, domainUser.ID()).
+		Return(domainUser, nil)
+
+	mockRepo.EXPECT().
+		Save(ctx, domainUser).
+		Return(nil)
+
+	mockPublisher.EXPECT().
+		Publish(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, events []shared.DomainEvent) error {
+			require.Len(t, events, 1)
+			assert.Equal(t, user.EventTypeUserProfileUpdated, events[0].EventType())
+			return nil
+		})
+
+	useCase := NewUpdateProfileUseCase(mockRepo, mockPublisher)
+
+	result, err := useCase.Execute(ctx, &ports.TokenClaims{UserID: "test-user-123"}, dto.UpdateProfileRequest{
+		DisplayName: stringPtr("Custom Name"),
+		Locale:      stringPtr("ja-JP"),
+		Timezone:    stringPtr("Asia/Tokyo"),
+		Preferences: map[string]*string{"theme": stringPtr("dark")},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Custom Name", result.Name)
+	assert.Equal(t, "https://example.com/photo.jpg", result.Picture)
+	assert.Equal(t, "ja-JP", result.Locale)
+	assert.Equal(t, "Asia/Tokyo", result.Timezone)
+	assert.Equal(t, map[string]string{"theme": "dark"}, result.Preferences)
+	assert.True(t, domainUser.Profile().IsNameOverridden())
+	assert.False(t, domainUser.Profile().IsPictureOverridden())
+}
+
+func TestUpdateProfileUseCase_InvalidField(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	domainUser := newAccountTestUser(t, true)
+
+	mockRepo.EXPECT().
+		FindByID(ctx, domainUser.ID()).
+		Return(domainUser, nil).
+		Times(2)
+
+	useCase := NewUpdateProfileUseCase(mockRepo, mocks.NewMockEventPublisher(ctrl))
+
+	// Nothing is saved when validation fails
+	_, err := useCase.Execute(ctx, &ports.TokenClaims{UserID: "test-user-123"}, dto.UpdateProfileRequest{
+		Timezone: stringPtr("Not/AZone"),
+	})
+	assert.Equal(t, shared.ErrInvalidTimezone, err)
+
+	_, err = useCase.Execute(ctx, &ports.TokenClaims{UserID: "test-user-123"}, dto.UpdateProfileRequest{
+		Preferences: map[string]*string{"Bad Key": stringPtr("x")},
+	})
+	assert.ErrorIs(t, err, shared.ErrInvalidPreference)
+}
+
+func TestUpdateProfileUseCase_UserNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+
+	mockRepo.EXPECT().
+		FindByID(ctx, gomock.Any()).
+		Return(nil, shared.ErrUserNotFound)
+
+	useCase := NewUpdateProfileUseCase(mockRepo, mocks.NewMockEventPublisher(ctrl))
+
+	result, err := useCase.Execute(ctx, &ports.TokenClaims{UserID: "missing-user"}, dto.UpdateProfileRequest{})
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrUnauthorized, err)
+}
+
+func TestUpdateProfileUseCase_SaveFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	domainUser := newAccountTestUser(t, true)
+
+	mockRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	mockRepo.EXPECT().Save(ctx, domainUser).Return(errors.New("database error"))
+
+	useCase := NewUpdateProfileUseCase(mockRepo, mocks.NewMockEventPublisher(ctrl))
+
+	result, err := useCase.Execute(ctx, &ports.TokenClaims{UserID: "test-user-123"}, dto.UpdateProfileRequest{
+		DisplayName: stringPtr("Custom Name"),
+	})
+
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "failed to update user")
+}
@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// DefaultAccountStatusCacheTTL is how long a looked-up account status is reused
+const DefaultAccountStatusCacheTTL = 30 * time.Second
+
+// AccountStatusService checks whether a user account may still authenticate.
+// Results are cached for a short TTL so that per-request checks in middleware
+// do not hit the repository every time.
+type AccountStatusService struct {
+	userRepo   user.Repository
+	adminRoles *AdminRoles
+	ttl        time.Duration
+	now        func() time.Time
+
+	mu        sync.RWMutex
+	cache     map[string]statusCacheEntry
+	nextSweep time.Time
+}
+
+// statusCacheEntry is a cached account status lookup
+type statusCacheEntry struct {
+	err       error
+	expiresAt time.Time
+}
+
+// NewAccountStatusService creates a new AccountStatusService
+// A non-positive ttl disables caching
+func NewAccountStatusService(userRepo user.Repository, adminRoles *AdminRoles, ttl time.Duration) *AccountStatusService {
+	return &AccountStatusService{
+		userRepo:   userRepo,
+		adminRoles: adminRoles,
+		ttl:        ttl,
+		now:        time.Now,
+		cache:      make(map[string]statusCacheEntry),
+	}
+}
+
+// CheckStatus returns nil if the user exists and is active.
+// It returns shared.ErrAccountSuspended, shared.ErrAccountDeleted or
+// shared.ErrUserNotFound when the account cannot authenticate.
+func (s *AccountStatusService) CheckStatus(ctx context.Context, userID string) error {
+	if entry, ok := s.cached(userID); ok {
+		return entry.err
+	}
+
+	id, err := user.NewUserID(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	domainUser, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		if err == shared.ErrUserNotFound {
+			s.store(userID, shared.ErrUserNotFound)
+			return shared.ErrUserNotFound
+		}
+		// Repository failures are not cached so the next request retries
+		return fmt.Errorf("failed to retrieve user: %w", err)
+	}
+
+	statusErr := domainUser.EnsureActive()
+	s.store(userID, statusErr)
+	return statusErr
+}
+
+// CheckImpersonator returns nil if the administrator adminID may still act
+// through an impersonation token: their account is active and they still
+// have the admin role. It returns shared.ErrAdminRequired once they are
+// demoted. Results are not cached, so demotion takes effect immediately.
+func (s *AccountStatusService) CheckImpersonator(ctx context.Context, adminID string) error {
+	id, err := user.NewUserID(adminID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	admin, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		if err == shared.ErrUserNotFound {
+			return err
+		}
+		return fmt.Errorf("failed to retrieve user: %w", err)
+	}
+
+	if err := admin.EnsureActive(); err != nil {
+		return err
+	}
+	s.adminRoles.Apply(admin)
+	if !admin.HasRole(user.RoleAdmin) {
+		return shared.ErrAdminRequired
+	}
+	return nil
+}
+
+// Invalidate drops any cached status for the given user
+func (s *AccountStatusService) Invalidate(userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.cache, userID)
+}
+
+// cached returns a cached, unexpired status result
+func (s *AccountStatusService) cached(userID string) (statusCacheEntry, bool) {
+	if s.ttl <= 0 {
+		return statusCacheEntry{}, false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entry, ok := s.cache[userID]
+	if !ok || !s.now().Before(entry.expiresAt) {
+		return statusCacheEntry{}, false
+	}
+
+	return entry, true
+}
+
+// store caches a status result
+func (s *AccountStatusService) store(userID string, err error) {
+	if s.ttl <= 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if !now.Before(s.nextSweep) {
+		s.sweep(now)
+		s.nextSweep = now.Add(s.ttl)
+	}
+
+	s.cache[userID] = statusCacheEntry{
+		err:       err,
+		expiresAt: now.Add(s.ttl),
+	}
+}
+
+// sweep drops expired entries; it runs at most once per ttl, so an entry
+// stays in memory for no longer than twice the ttl
+func (s *AccountStatusService) sweep(now time.Time) {
+	for userID, entry := range s.cache {
+		if !now.Before(entry.expiresAt) {
+			delete(s.cache, userID)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+	"github.com/yuki5155/go-google-auth/internal/mocks"
+)
+
+func TestAccountStatusService_ActiveUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	domainUser := newTestUser(t, "test-user-123")
+
+	mockRepo.EXPECT().
+		FindByID(ctx, domainUser.ID()).
+		Return(domainUser, nil)
+
+	service := NewAccountStatusService(mockRepo, NewAdminRoles(nil, nil), time.Minute)
+
+	assert.NoError(t, service.CheckStatus(ctx, "test-user-123"))
+}
+
+func TestAccountStatusService_SuspendedUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	domainUser := newTestUser(t, "test-user-123")
+	require.NoError(t, domainUser.Suspend("abuse"))
+
+	mockRepo.EXPECT().
+		FindByID(ctx, domainUser.ID()).
+		Return(domainUser, nil)
+
+	service := NewAccountStatusService(mockRepo, NewAdminRoles(nil, nil), time.Minute)
+
+	assert.Equal(t, shared.ErrAccountSuspended, service.CheckStatus(ctx, "test-user-123"))
+}
+
+func TestAccountStatusService_UserNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+
+	mockRepo.EXPECT().
+		FindByID(ctx, gomock.Any()).
+		Return(nil, shared.ErrUserNotFound)
+
+	service := NewAccountStatusService(mockRepo, NewAdminRoles(nil, nil), time.Minute)
+
+	assert.Equal(t, shared.ErrUserNotFound, service.CheckStatus(ctx, "missing-user"))
+}
+
+func TestAccountStatusService_CachesResult(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	domainUser := newTestUser(t, "test-user-123")
+
+	// Only one repository lookup within the TTL
+	mockRepo.EXPECT().
+		FindByID(ctx, domainUser.ID()).
+		Return(domainUser, nil).
+		Times(1)
+
+	service := NewAccountStatusService(mockRepo, NewAdminRoles(nil, nil), time.Minute)
+
+	assert.NoError(t, service.CheckStatus(ctx, "test-user-123"))
+	assert.NoError(t, service.CheckStatus(ctx, "test-user-123"))
+}
+
+func TestAccountStatusService_CacheExpires(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	domainUser := newTestUser(t, "test-user-123")
+
+	mockRepo.EXPECT().
+		FindByID(ctx, domainUser.ID()).
+		Return(domainUser, nil).
+		Times(2)
+
+	now := time.Now()
+	service := NewAccountStatusService(mockRepo, NewAdminRoles(nil, nil), time.Minute)
+	service.now = func() time.Time { return now }
+
+	assert.NoError(t, service.CheckStatus(ctx, "test-user-123"))
+
+	now = now.Add(2 * time.Minute)
+	assert.NoError(t, service.CheckStatus(ctx, "test-user-123"))
+}
+
+func TestAccountStatusService_DropsExpiredEntries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+
+	mockRepo.EXPECT().
+		FindByID(ctx, gomock.Any()).
+		Return(nil, shared.ErrUserNotFound).
+		Times(3)
+
+	now := time.Now()
+	service := NewAccountStatusService(mockRepo, NewAdminRoles(nil, nil), time.Minute)
+	service.now = func() time.Time { return now }
+
+	assert.Equal(t, shared.ErrUserNotFound, service.CheckStatus(ctx, "user-1"))
+	assert.Equal(t, shared.ErrUserNotFound, service.CheckStatus(ctx, "user-2"))
+	assert.Len(t, service.cache, 2)
+
+	// Storing after the entries expired drops them
+	now = now.Add(2 * time.Minute)
+	assert.Equal(t, shared.ErrUserNotFound, service.CheckStatus(ctx, "user-3"))
+	assert.Len(t, service.cache, 1)
+	assert.Contains(t, service.cache, "user-3")
+}
+
+func TestAccountStatusService_Invalidate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	domainUser := newTestUser(t, "test-user-123")
+
+	gomock.InOrder(
+		mockRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil),
+		mockRepo.EXPECT().FindByID(ctx, domainUser.ID()).DoAndReturn(
+			func(ctx context.Context, id user.UserID) (*user.User, error) {
+				require.NoError(t, domainUser.Suspend("abuse"))
+				return domainUser, nil
+			}),
+	)
+
+	service := NewAccountStatusService(mockRepo, NewAdminRoles(nil, nil), time.Minute)
+
+	assert.NoError(t, service.CheckStatus(ctx, "test-user-123"))
+	service.Invalidate("test-user-123")
+	assert.Equal(t, shared.ErrAccountSuspended, service.CheckStatus(ctx, "test-user-123"))
+}
+
+func TestAccountStatusService_RepositoryErrorNotCached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	domainUser := newTestUser(t, "test-user-123")
+
+	gomock.InOrder(
+		mockRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(nil, errors.New("database connection error")),
+		mockRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil),
+	)
+
+	service := NewAccountStatusService(mockRepo, NewAdminRoles(nil, nil), time.Minute)
+
+	err := service.CheckStatus(ctx, "test-user-123")
+	assert.Contains(t, err.Error(), "failed to retrieve user")
+
+	assert.NoError(t, service.CheckStatus(ctx, "test-user-123"))
+}
+
+func TestAccountStatusService_CheckImpersonator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	admin := newTestUser(t, "test-user-123")
+
+	mockRepo.EXPECT().FindByID(ctx, admin.ID()).Return(admin, nil).Times(3)
+
+	service := NewAccountStatusService(mockRepo, NewAdminRoles([]string{"test-user-123"}, nil), time.Minute)
+	assert.NoError(t, service.CheckImpersonator(ctx, "test-user-123"))
+
+	// Removing the administrator from the configuration demotes them at once
+	service = NewAccountStatusService(mockRepo, NewAdminRoles(nil, nil), time.Minute)
+	assert.Equal(t, shared.ErrAdminRequired, service.CheckImpersonator(ctx, "test-user-123"))
+
+	service = NewAccountStatusService(mockRepo, NewAdminRoles([]string{"test-user-123"}, nil), time.Minute)
+	require.NoError(t, admin.Suspend("left the company"))
+	assert.Equal(t, shared.ErrAccountSuspended, service.CheckImpersonator(ctx, "test-user-123"))
+}
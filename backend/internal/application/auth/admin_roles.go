@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"strings"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// AdminRoles decides who has the admin role. The configured users, named by
+// user ID or verified email address, are granted it and everyone else loses
+// it, so that removing an administrator from the configuration demotes them.
+type AdminRoles struct {
+	userIDs map[string]bool
+	emails  map[string]bool
+}
+
+// NewAdminRoles creates AdminRoles for the given user IDs and email addresses
+func NewAdminRoles(userIDs, emails []string) *AdminRoles {
+	a := &AdminRoles{
+		userIDs: make(map[string]bool, len(userIDs)),
+		emails:  make(map[string]bool, len(emails)),
+	}
+	for _, id := range userIDs {
+		a.userIDs[id] = true
+	}
+	for _, email := range emails {
+		a.emails[strings.ToLower(email)] = true
+	}
+	return a
+}
+
+// Apply grants or revokes the user's admin role to match the configuration
+// and reports whether it changed
+func (a *AdminRoles) Apply(u *user.User) bool {
+	listed := a.userIDs[u.ID().Value()] ||
+		(u.Email().IsVerified() && a.emails[strings.ToLower(u.Email().Value())])
+	if listed == u.HasRole(user.RoleAdmin) {
+		return false
+	}
+
+	if listed {
+		u.GrantRole(user.RoleAdmin)
+	} else {
+		u.RevokeRole(user.RoleAdmin)
+	}
+	return true
+}
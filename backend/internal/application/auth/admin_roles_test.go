@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+func newAdminRolesTestUser(t *testing.T, rawID, address string, verified bool) *user.User {
+	t.Helper()
+
+	userID, _ := user.NewUserID(rawID)
+	email, err := user.NewEmail(address, verified)
+	require.NoError(t, err)
+	now := time.Now()
+	return user.ReconstructUser(userID, email, user.NewProfile("Test User", ""), user.Preferences{},
+		user.NewRoles(user.RoleUser), user.StatusActive, user.LoginHistory{}, user.NewPasskeys(), "", "", now, now)
+}
+
+func TestAdminRoles_Apply(t *testing.T) {
+	roles := NewAdminRoles([]string{"admin-1"}, []string{"Support@Example.com"})
+
+	byID := newAdminRolesTestUser(t, "admin-1", "someone@example.com", false)
+	assert.True(t, roles.Apply(byID))
+	assert.True(t, byID.HasRole(user.RoleAdmin))
+	assert.False(t, roles.Apply(byID))
+
+	byEmail := newAdminRolesTestUser(t, "admin-2", "support@example.com", true)
+	assert.True(t, roles.Apply(byEmail))
+	assert.True(t, byEmail.HasRole(user.RoleAdmin))
+
+	// An unverified address does not prove who the user is
+	unverified := newAdminRolesTestUser(t, "admin-3", "support@example.com", false)
+	assert.False(t, roles.Apply(unverified))
+	assert.False(t, unverified.HasRole(user.RoleAdmin))
+
+	demoted := newAdminRolesTestUser(t, "former-admin", "former@example.com", true)
+	demoted.GrantRole(user.RoleAdmin)
+	assert.True(t, roles.Apply(demoted))
+	assert.False(t, demoted.HasRole(user.RoleAdmin))
+}
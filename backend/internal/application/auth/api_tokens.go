@@ -0,0 +1,235 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/events"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/apitoken"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// apiTokenTouchInterval limits how often a token's last use is saved, so
+// that busy scripts do not write on every request
+const apiTokenTouchInterval = time.Minute
+
+// CreateAPITokenUseCase creates a personal access token for the current user
+type CreateAPITokenUseCase struct {
+	userRepo       user.Repository
+	tokenRepo      apitoken.Repository
+	eventPublisher ports.EventPublisher
+	defaultTTL     time.Duration
+	maxTTL         time.Duration
+}
+
+// NewCreateAPITokenUseCase creates a new CreateAPITokenUseCase
+// Tokens last defaultTTL unless the request asks for a lifetime up to maxTTL.
+func NewCreateAPITokenUseCase(
+	userRepo user.Repository,
+	tokenRepo apitoken.Repository,
+	eventPublisher ports.EventPublisher,
+	defaultTTL, maxTTL time.Duration,
+) *CreateAPITokenUseCase {
+	return &CreateAPITokenUseCase{
+		userRepo:       userRepo,
+		tokenRepo:      tokenRepo,
+		eventPublisher: eventPublisher,
+		defaultTTL:     defaultTTL,
+		maxTTL:         maxTTL,
+	}
+}
+
+// Execute creates the token and returns it with its plain value
+func (uc *CreateAPITokenUseCase) Execute(ctx context.Context, claims *ports.TokenClaims, req dto.CreateAPITokenRequest) (*dto.CreatedAPITokenResponse, error) {
+	domainUser, err := findActiveUser(ctx, uc.userRepo, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, scope := range req.Scopes {
+		if !ports.IsAPITokenScope(scope) {
+			return nil, shared.ErrInvalidAPITokenScope
+		}
+	}
+
+	ttl := uc.defaultTTL
+	if req.ExpiresInDays != 0 {
+		ttl = time.Duration(req.ExpiresInDays) * 24 * time.Hour
+	}
+	if ttl <= 0 || ttl > uc.maxTTL {
+		return nil, shared.ErrInvalidAPITokenExpiry
+	}
+
+	now := time.Now()
+	existing, err := uc.tokenRepo.FindByUserID(ctx, domainUser.ID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tokens: %w", err)
+	}
+	if countUnrevoked(existing) >= apitoken.MaxTokensPerUser {
+		return nil, shared.ErrTooManyAPITokens
+	}
+
+	token, plain, err := apitoken.NewToken(domainUser.ID(), req.Name, req.Scopes, now.Add(ttl))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.tokenRepo.Save(ctx, token); err != nil {
+		return nil, fmt.Errorf("failed to save token: %w", err)
+	}
+	events.Publish(ctx, uc.eventPublisher, token)
+
+	log.Printf("Personal access token %s created for user %s", token.ID().Value(), domainUser.ID().Value())
+	return &dto.CreatedAPITokenResponse{
+		Token:    plain,
+		APIToken: dto.NewAPITokenResponse(token),
+	}, nil
+}
+
+// ListAPITokensUseCase lists the current user's personal access tokens
+type ListAPITokensUseCase struct {
+	tokenRepo apitoken.Repository
+}
+
+// NewListAPITokensUseCase creates a new ListAPITokensUseCase
+func NewListAPITokensUseCase(tokenRepo apitoken.Repository) *ListAPITokensUseCase {
+	return &ListAPITokensUseCase{
+		tokenRepo: tokenRepo,
+	}
+}
+
+// Execute returns the user's unrevoked tokens, newest first
+func (uc *ListAPITokensUseCase) Execute(ctx context.Context, claims *ports.TokenClaims) (*dto.APITokenListResponse, error) {
+	userID, err := user.NewUserID(claims.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID in token: %w", err)
+	}
+
+	tokens, err := uc.tokenRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tokens: %w", err)
+	}
+
+	responses := make([]dto.APITokenResponse, 0, len(tokens))
+	for _, token := range tokens {
+		if !token.IsRevoked() {
+			responses = append(responses, dto.NewAPITokenResponse(token))
+		}
+	}
+
+	return &dto.APITokenListResponse{Tokens: responses}, nil
+}
+
+// RevokeAPITokenUseCase revokes one of the current user's personal access tokens
+type RevokeAPITokenUseCase struct {
+	tokenRepo      apitoken.Repository
+	eventPublisher ports.EventPublisher
+}
+
+// NewRevokeAPITokenUseCase creates a new RevokeAPITokenUseCase
+func NewRevokeAPITokenUseCase(tokenRepo apitoken.Repository, eventPublisher ports.EventPublisher) *RevokeAPITokenUseCase {
+	return &RevokeAPITokenUseCase{
+		tokenRepo:      tokenRepo,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// Execute revokes the token with the given ID; revoking it again is a no-op
+func (uc *RevokeAPITokenUseCase) Execute(ctx context.Context, claims *ports.TokenClaims, tokenID string) error {
+	id, err := apitoken.NewTokenID(tokenID)
+	if err != nil {
+		return err
+	}
+
+	token, err := uc.tokenRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	// Other users' tokens are reported as missing
+	if token.UserID().Value() != claims.UserID {
+		return shared.ErrAPITokenNotFound
+	}
+	if token.IsRevoked() {
+		return nil
+	}
+
+	token.Revoke(time.Now())
+	if err := uc.tokenRepo.Save(ctx, token); err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+	events.Publish(ctx, uc.eventPublisher, token)
+
+	return nil
+}
+
+// APITokenAuthenticator authenticates requests made with a personal access
+// token, producing the same claims as an access token
+type APITokenAuthenticator struct {
+	userRepo  user.Repository
+	tokenRepo apitoken.Repository
+}
+
+// NewAPITokenAuthenticator creates a new APITokenAuthenticator
+func NewAPITokenAuthenticator(userRepo user.Repository, tokenRepo apitoken.Repository) *APITokenAuthenticator {
+	return &APITokenAuthenticator{
+		userRepo:  userRepo,
+		tokenRepo: tokenRepo,
+	}
+}
+
+// Authenticate returns the claims of an active token. It returns
+// shared.ErrInvalidAPIToken for unknown, expired and revoked tokens, and the
+// account status error if the user can no longer sign in.
+func (a *APITokenAuthenticator) Authenticate(ctx context.Context, plain string) (*ports.TokenClaims, error) {
+	token, err := a.tokenRepo.FindByHash(ctx, apitoken.Hash(plain))
+	if err != nil {
+		if err == shared.ErrAPITokenNotFound {
+			return nil, shared.ErrInvalidAPIToken
+		}
+		return nil, fmt.Errorf("failed to look up token: %w", err)
+	}
+
+	now := time.Now()
+	if !token.IsActive(now) {
+		return nil, shared.ErrInvalidAPIToken
+	}
+
+	domainUser, err := findActiveUser(ctx, a.userRepo, token.UserID().Value())
+	if err != nil {
+		return nil, err
+	}
+
+	if now.Sub(token.LastUsedAt()) >= apiTokenTouchInterval {
+		token.Touch(now)
+		if err := a.tokenRepo.Save(ctx, token); err != nil {
+			log.Printf("Failed to record use of token %s: %v", token.ID().Value(), err)
+		}
+	}
+
+	return &ports.TokenClaims{
+		UserID:     domainUser.ID().Value(),
+		Email:      domainUser.Email().Value(),
+		Name:       domainUser.Profile().Name(),
+		Picture:    domainUser.Profile().Picture(),
+		Scopes:     token.Scopes(),
+		APITokenID: token.ID().Value(),
+		IssuedAt:   token.CreatedAt(),
+		ExpiresAt:  token.ExpiresAt(),
+	}, nil
+}
+
+// countUnrevoked counts the tokens that have not been revoked
+func countUnrevoked(tokens []*apitoken.Token) int {
+	count := 0
+	for _, token := range tokens {
+		if !token.IsRevoked() {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,256 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/apitoken"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+func (m *authMocks) createAPITokenUseCase() *CreateAPITokenUseCase {
+	return NewCreateAPITokenUseCase(m.userRepo, m.tokenRepo, m.eventPublisher, 30*24*time.Hour, 365*24*time.Hour)
+}
+
+// newAPITokenTestUser returns an active user and one of their tokens
+func newAPITokenTestUser(t *testing.T) (*user.User, *apitoken.Token, string) {
+	t.Helper()
+
+	domainUser := newTestUser(t, "user-123")
+
+	token, plain, err := apitoken.NewToken(domainUser.ID(), "ci", []string{ports.ScopeProfileRead}, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	token.ClearDomainEvents()
+
+	return domainUser, token, plain
+}
+
+func TestCreateAPITokenUseCase_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser, _, _ := newAPITokenTestUser(t)
+	claims := &ports.TokenClaims{UserID: "user-123"}
+
+	var saved *apitoken.Token
+	m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	m.tokenRepo.EXPECT().FindByUserID(ctx, domainUser.ID()).Return(nil, nil)
+	m.tokenRepo.EXPECT().Save(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, token *apitoken.Token) error {
+		saved = token
+		return nil
+	})
+	m.eventPublisher.EXPECT().Publish(ctx, gomock.Any()).Return(nil)
+
+	result, err := m.createAPITokenUseCase().Execute(ctx, claims, dto.CreateAPITokenRequest{
+		Name:          "deploy script",
+		Scopes:        []string{ports.ScopeProfileWrite, ports.ScopeProfileRead},
+		ExpiresInDays: 7,
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, saved)
+	assert.True(t, apitoken.LooksLikeToken(result.Token))
+	// Only the hash of the plain value is stored
+	assert.Equal(t, apitoken.Hash(result.Token), saved.Hash())
+	assert.NotContains(t, saved.Hash(), result.Token)
+	assert.Equal(t, "deploy script", result.APIToken.Name)
+	assert.Equal(t, []string{ports.ScopeProfileRead, ports.ScopeProfileWrite}, result.APIToken.Scopes)
+	assert.WithinDuration(t, time.Now().Add(7*24*time.Hour), result.APIToken.ExpiresAt, time.Second)
+}
+
+func TestCreateAPITokenUseCase_RejectsInvalidRequests(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     dto.CreateAPITokenRequest
+		wantErr error
+	}{
+		{"unknown scope", dto.CreateAPITokenRequest{Name: "ci", Scopes: []string{"admin"}}, shared.ErrInvalidAPITokenScope},
+		{"negative expiry", dto.CreateAPITokenRequest{Name: "ci", Scopes: []string{ports.ScopeProfileRead}, ExpiresInDays: -1}, shared.ErrInvalidAPITokenExpiry},
+		{"expiry above maximum", dto.CreateAPITokenRequest{Name: "ci", Scopes: []string{ports.ScopeProfileRead}, ExpiresInDays: 366}, shared.ErrInvalidAPITokenExpiry},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			m := newAuthMocks(ctrl)
+			domainUser, _, _ := newAPITokenTestUser(t)
+			m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+
+			result, err := m.createAPITokenUseCase().Execute(ctx, &ports.TokenClaims{UserID: "user-123"}, tt.req)
+
+			assert.Nil(t, result)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestCreateAPITokenUseCase_TooManyTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser, token, _ := newAPITokenTestUser(t)
+
+	existing := make([]*apitoken.Token, apitoken.MaxTokensPerUser)
+	for i := range existing {
+		existing[i] = token
+	}
+	m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	m.tokenRepo.EXPECT().FindByUserID(ctx, domainUser.ID()).Return(existing, nil)
+
+	result, err := m.createAPITokenUseCase().Execute(ctx, &ports.TokenClaims{UserID: "user-123"}, dto.CreateAPITokenRequest{
+		Name:   "one too many",
+		Scopes: []string{ports.ScopeProfileRead},
+	})
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrTooManyAPITokens, err)
+}
+
+func TestListAPITokensUseCase_HidesRevokedTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser, active, _ := newAPITokenTestUser(t)
+	revoked, _, err := apitoken.NewToken(domainUser.ID(), "old", []string{ports.ScopeProfileRead}, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	revoked.Revoke(time.Now())
+
+	m.tokenRepo.EXPECT().FindByUserID(ctx, domainUser.ID()).Return([]*apitoken.Token{active, revoked}, nil)
+
+	result, err := NewListAPITokensUseCase(m.tokenRepo).Execute(ctx, &ports.TokenClaims{UserID: "user-123"})
+
+	require.NoError(t, err)
+	require.Len(t, result.Tokens, 1)
+	assert.Equal(t, active.ID().Value(), result.Tokens[0].ID)
+}
+
+func TestRevokeAPITokenUseCase_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	_, token, _ := newAPITokenTestUser(t)
+
+	m.tokenRepo.EXPECT().FindByID(ctx, token.ID()).Return(token, nil)
+	m.tokenRepo.EXPECT().Save(ctx, token).Return(nil)
+	m.eventPublisher.EXPECT().Publish(ctx, gomock.Any()).Return(nil)
+
+	err := NewRevokeAPITokenUseCase(m.tokenRepo, m.eventPublisher).Execute(ctx, &ports.TokenClaims{UserID: "user-123"}, token.ID().Value())
+
+	require.NoError(t, err)
+	assert.True(t, token.IsRevoked())
+}
+
+func TestRevokeAPITokenUseCase_AnotherUsersToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	_, token, _ := newAPITokenTestUser(t)
+
+	m.tokenRepo.EXPECT().FindByID(ctx, token.ID()).Return(token, nil)
+
+	err := NewRevokeAPITokenUseCase(m.tokenRepo, m.eventPublisher).Execute(ctx, &ports.TokenClaims{UserID: "other-user"}, token.ID().Value())
+
+	assert.Equal(t, shared.ErrAPITokenNotFound, err)
+	assert.False(t, token.IsRevoked())
+}
+
+func TestAPITokenAuthenticator_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser, token, plain := newAPITokenTestUser(t)
+
+	m.tokenRepo.EXPECT().FindByHash(ctx, apitoken.Hash(plain)).Return(token, nil)
+	m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	m.tokenRepo.EXPECT().Save(ctx, token).Return(nil)
+
+	claims, err := NewAPITokenAuthenticator(m.userRepo, m.tokenRepo).Authenticate(ctx, plain)
+
+	require.NoError(t, err)
+	assert.Equal(t, "user-123", claims.UserID)
+	assert.Equal(t, "test@example.com", claims.Email)
+	assert.Equal(t, []string{ports.ScopeProfileRead}, claims.Scopes)
+	assert.Equal(t, token.ID().Value(), claims.APITokenID)
+	assert.True(t, claims.IsAPIToken())
+	assert.Empty(t, claims.SessionID)
+	assert.WithinDuration(t, time.Now(), token.LastUsedAt(), time.Second)
+}
+
+func TestAPITokenAuthenticator_RecentlyUsedTokenIsNotSaved(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser, token, plain := newAPITokenTestUser(t)
+	token.Touch(time.Now())
+
+	m.tokenRepo.EXPECT().FindByHash(ctx, apitoken.Hash(plain)).Return(token, nil)
+	m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+
+	_, err := NewAPITokenAuthenticator(m.userRepo, m.tokenRepo).Authenticate(ctx, plain)
+
+	require.NoError(t, err)
+}
+
+func TestAPITokenAuthenticator_RejectsInvalidTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	_, revoked, plain := newAPITokenTestUser(t)
+	revoked.Revoke(time.Now())
+
+	m.tokenRepo.EXPECT().FindByHash(ctx, apitoken.Hash("pat_unknown")).Return(nil, shared.ErrAPITokenNotFound)
+	m.tokenRepo.EXPECT().FindByHash(ctx, apitoken.Hash(plain)).Return(revoked, nil)
+
+	authenticator := NewAPITokenAuthenticator(m.userRepo, m.tokenRepo)
+
+	_, err := authenticator.Authenticate(ctx, "pat_unknown")
+	assert.Equal(t, shared.ErrInvalidAPIToken, err)
+
+	_, err = authenticator.Authenticate(ctx, plain)
+	assert.Equal(t, shared.ErrInvalidAPIToken, err)
+}
+
+func TestAPITokenAuthenticator_SuspendedUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser, token, plain := newAPITokenTestUser(t)
+	require.NoError(t, domainUser.Suspend("abuse"))
+
+	m.tokenRepo.EXPECT().FindByHash(ctx, apitoken.Hash(plain)).Return(token, nil)
+	m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+
+	claims, err := NewAPITokenAuthenticator(m.userRepo, m.tokenRepo).Authenticate(ctx, plain)
+
+	assert.Nil(t, claims)
+	assert.Equal(t, shared.ErrAccountSuspended, err)
+}
@@ -0,0 +1,194 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// codeVerifierPattern is the syntax of a PKCE code verifier (RFC 7636, section 4.1)
+var codeVerifierPattern = regexp.MustCompile(`^[A-Za-z0-9._~-]{43,128}$`)
+
+// AuthorizationCodeUseCase exchanges authorization codes for an access token
+// and an ID token (the OAuth 2.0 authorization_code grant)
+type AuthorizationCodeUseCase struct {
+	clients        *ServiceClientAuthenticator
+	codes          ports.AuthorizationGrantTokens
+	usedCodes      ports.AuthorizationCodeStore
+	userRepo       user.Repository
+	sessionRepo    session.Repository
+	tokenGenerator ports.TokenGenerator
+	idTokens       ports.IDTokenIssuer
+}
+
+// NewAuthorizationCodeUseCase creates a new AuthorizationCodeUseCase
+func NewAuthorizationCodeUseCase(
+	clients *ServiceClientAuthenticator,
+	codes ports.AuthorizationGrantTokens,
+	usedCodes ports.AuthorizationCodeStore,
+	userRepo user.Repository,
+	sessionRepo session.Repository,
+	tokenGenerator ports.TokenGenerator,
+	idTokens ports.IDTokenIssuer,
+) *AuthorizationCodeUseCase {
+	return &AuthorizationCodeUseCase{
+		clients:        clients,
+		codes:          codes,
+		usedCodes:      usedCodes,
+		userRepo:       userRepo,
+		sessionRepo:    sessionRepo,
+		tokenGenerator: tokenGenerator,
+		idTokens:       idTokens,
+	}
+}
+
+// Execute authenticates the client and redeems code, which must have been
+// issued to it for redirectURI and match the PKCE codeVerifier. A code can
+// only be redeemed once, and not after the user's session has ended.
+func (uc *AuthorizationCodeUseCase) Execute(ctx context.Context, creds dto.ClientCredentials, code, redirectURI, codeVerifier string) (*dto.OAuthTokenResponse, error) {
+	client, err := uc.clients.Authenticate(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+	if !client.CanUseAuthorizationCode() {
+		return nil, shared.ErrUnauthorizedClient
+	}
+
+	now := time.Now()
+	grant, err := uc.codes.Open(code, now)
+	if err != nil {
+		return nil, shared.ErrInvalidGrant
+	}
+	if grant.ClientID != client.ID() || grant.RedirectURI != redirectURI || !verifyCodeChallenge(codeVerifier, grant.CodeChallenge) {
+		return nil, shared.ErrInvalidGrant
+	}
+	if err := uc.usedCodes.MarkUsed(ctx, grant.ID, grant.ExpiresAt); err != nil {
+		return nil, err
+	}
+
+	domainUser, err := uc.activeUser(ctx, grant, now)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := profileForScopes(domainUser, func(scope string) bool { return slices.Contains(grant.Scopes, scope) })
+	accessToken, err := uc.tokenGenerator.GenerateDelegatedToken(ports.UserInfo{
+		UserID:    grant.UserID,
+		Email:     profile.Email,
+		Name:      profile.Name,
+		Picture:   profile.Picture,
+		SessionID: grant.SessionID,
+		AMR:       grant.AMR,
+		AuthTime:  grant.AuthTime,
+		ACR:       grant.ACR,
+	}, client.ID(), grant.Scopes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	idToken, err := uc.idTokens.IssueIDToken(ports.IDTokenClaims{
+		Subject:       grant.UserID,
+		Audience:      client.ID(),
+		Nonce:         grant.Nonce,
+		SessionID:     grant.SessionID,
+		AuthTime:      grant.AuthTime,
+		AMR:           grant.AMR,
+		ACR:           grant.ACR,
+		Email:         profile.Email,
+		EmailVerified: profile.EmailVerified,
+		Name:          profile.Name,
+		Picture:       profile.Picture,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ID token: %w", err)
+	}
+
+	log.Printf("Signed in user %s to client %s (%s)", grant.UserID, client.ID(), strings.Join(grant.Scopes, " "))
+	return &dto.OAuthTokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   uc.tokenGenerator.GetAccessTokenExpiry(),
+		Scope:       strings.Join(grant.Scopes, " "),
+		IDToken:     idToken,
+	}, nil
+}
+
+// activeUser loads the user a code was issued for. Codes of suspended or
+// deleted users, or of sessions that have since ended, are no longer valid.
+func (uc *AuthorizationCodeUseCase) activeUser(ctx context.Context, grant *ports.AuthorizationGrant, now time.Time) (*user.User, error) {
+	userID, err := user.NewUserID(grant.UserID)
+	if err != nil {
+		return nil, shared.ErrInvalidGrant
+	}
+
+	domainUser, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if err == shared.ErrUserNotFound {
+			return nil, shared.ErrInvalidGrant
+		}
+		return nil, fmt.Errorf("failed to retrieve user: %w", err)
+	}
+	if !domainUser.IsActive() {
+		return nil, shared.ErrInvalidGrant
+	}
+
+	if grant.SessionID != "" {
+		claims := &ports.TokenClaims{UserID: grant.UserID, SessionID: grant.SessionID}
+		if _, err := findActiveSession(ctx, uc.sessionRepo, claims, now); err != nil {
+			if err == shared.ErrSessionRevoked || err == shared.ErrSessionExpired {
+				return nil, shared.ErrInvalidGrant
+			}
+			return nil, err
+		}
+	}
+
+	return domainUser, nil
+}
+
+// verifyCodeChallenge reports whether verifier hashes to the S256 challenge
+func verifyCodeChallenge(verifier, challenge string) bool {
+	if !codeVerifierPattern.MatchString(verifier) {
+		return false
+	}
+
+	sum := sha256.Sum256([]byte(verifier))
+	computed := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(challenge)) == 1
+}
+
+// oidcProfile holds the profile claims a client may see
+type oidcProfile struct {
+	Email         string
+	EmailVerified bool
+	Name          string
+	Picture       string
+}
+
+// profileForScopes returns the user's profile claims for the scopes that
+// hasScope reports as granted: "profile" for the name and picture, "email"
+// for the email address
+func profileForScopes(u *user.User, hasScope func(scope string) bool) oidcProfile {
+	var profile oidcProfile
+	if hasScope(ports.ScopeProfile) {
+		profile.Name = u.Profile().Name()
+		profile.Picture = u.Profile().Picture()
+	}
+	if hasScope(ports.ScopeEmail) {
+		profile.Email = u.Email().Value()
+		profile.EmailVerified = u.Email().IsVerified()
+	}
+	return profile
+}
@@ -0,0 +1,212 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/serviceclient"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+	"github.com/yuki5155/go-google-auth/internal/mocks"
+)
+
+// testCodeVerifier is the PKCE verifier of testCodeChallenge
+const testCodeVerifier = "dBjftJeZ4CVP-mJ92K9b6EXEbzVhU8m1BaAm1ZJ3tWs"
+
+func (m *authMocks) authorizationCodeUseCase() *AuthorizationCodeUseCase {
+	clients := NewServiceClientAuthenticator(m.clientRepo, mocks.NewMockClientAssertionVerifier(nil), mocks.NewMockClientAssertionStore(nil))
+	return NewAuthorizationCodeUseCase(clients, m.codes, m.usedCodes, m.userRepo, m.sessionRepo, m.tokenGenerator, m.idTokens)
+}
+
+// newCodeTestUser returns a user with an active login session and the code
+// issued to the wiki client during that session
+func newCodeTestUser(t *testing.T) (*user.User, *session.Session, *ports.AuthorizationGrant) {
+	t.Helper()
+
+	domainUser := newTestUser(t, "user-123")
+
+	loginSession, err := session.NewSession(domainUser.ID(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	grant := &ports.AuthorizationGrant{
+		ID:            "code-1",
+		ClientID:      "wiki",
+		RedirectURI:   testRedirectURI,
+		UserID:        "user-123",
+		Scopes:        []string{"email", "openid"},
+		Nonce:         "n-0S6",
+		CodeChallenge: testCodeChallenge,
+		SessionID:     loginSession.ID().Value(),
+		AMR:           []string{"pwd"},
+		ExpiresAt:     time.Now().Add(time.Minute),
+	}
+
+	return domainUser, loginSession, grant
+}
+
+func TestAuthorizationCodeUseCase_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser, loginSession, grant := newCodeTestUser(t)
+
+	m.clientRepo.EXPECT().FindByID(ctx, "wiki").Return(newRelyingPartyClient(t), nil)
+	m.codes.EXPECT().Open("the-code", gomock.Any()).Return(grant, nil)
+	m.usedCodes.EXPECT().MarkUsed(ctx, "code-1", grant.ExpiresAt).Return(nil)
+	m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	m.sessionRepo.EXPECT().FindByID(ctx, loginSession.ID()).Return(loginSession, nil)
+	m.tokenGenerator.EXPECT().GenerateDelegatedToken(ports.UserInfo{
+		UserID:    "user-123",
+		Email:     "test@example.com",
+		SessionID: loginSession.ID().Value(),
+		AMR:       []string{"pwd"},
+	}, "wiki", []string{"email", "openid"}).Return("access-token", nil)
+	m.tokenGenerator.EXPECT().GetAccessTokenExpiry().Return(900)
+	// The profile scope was not granted, so the name and picture are left out
+	m.idTokens.EXPECT().IssueIDToken(ports.IDTokenClaims{
+		Subject:       "user-123",
+		Audience:      "wiki",
+		Nonce:         "n-0S6",
+		SessionID:     loginSession.ID().Value(),
+		AMR:           []string{"pwd"},
+		Email:         "test@example.com",
+		EmailVerified: true,
+	}).Return("id-token", nil)
+
+	result, err := m.authorizationCodeUseCase().Execute(ctx, dto.ClientCredentials{ClientID: "wiki"}, "the-code", testRedirectURI, testCodeVerifier)
+
+	require.NoError(t, err)
+	assert.Equal(t, &dto.OAuthTokenResponse{
+		AccessToken: "access-token",
+		TokenType:   "Bearer",
+		ExpiresIn:   900,
+		Scope:       "email openid",
+		IDToken:     "id-token",
+	}, result)
+}
+
+func TestAuthorizationCodeUseCase_CodeMismatch(t *testing.T) {
+	tests := []struct {
+		name         string
+		clientID     string
+		redirectURI  string
+		codeVerifier string
+	}{
+		{"other client", "other", testRedirectURI, testCodeVerifier},
+		{"other redirect URI", "wiki", "https://wiki.example.com/other", testCodeVerifier},
+		{"wrong verifier", "wiki", testRedirectURI, "wrong-verifier-wrong-verifier-wrong-verifier"},
+		{"malformed verifier", "wiki", testRedirectURI, "short"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			m := newAuthMocks(ctrl)
+			_, _, grant := newCodeTestUser(t)
+			client := newRelyingPartyClient(t)
+			if tt.clientID != "wiki" {
+				client = newPublicTestClient(t, tt.clientID)
+			}
+
+			m.clientRepo.EXPECT().FindByID(ctx, tt.clientID).Return(client, nil)
+			m.codes.EXPECT().Open("the-code", gomock.Any()).Return(grant, nil)
+
+			result, err := m.authorizationCodeUseCase().Execute(ctx, dto.ClientCredentials{ClientID: tt.clientID}, "the-code", tt.redirectURI, tt.codeVerifier)
+
+			assert.Nil(t, result)
+			assert.Equal(t, shared.ErrInvalidGrant, err)
+		})
+	}
+}
+
+func TestAuthorizationCodeUseCase_InvalidCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+
+	m.clientRepo.EXPECT().FindByID(ctx, "wiki").Return(newRelyingPartyClient(t), nil)
+	m.codes.EXPECT().Open("forged", gomock.Any()).Return(nil, shared.ErrInvalidGrant)
+
+	result, err := m.authorizationCodeUseCase().Execute(ctx, dto.ClientCredentials{ClientID: "wiki"}, "forged", testRedirectURI, testCodeVerifier)
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrInvalidGrant, err)
+}
+
+func TestAuthorizationCodeUseCase_CodeAlreadyUsed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	_, _, grant := newCodeTestUser(t)
+
+	m.clientRepo.EXPECT().FindByID(ctx, "wiki").Return(newRelyingPartyClient(t), nil)
+	m.codes.EXPECT().Open("the-code", gomock.Any()).Return(grant, nil)
+	m.usedCodes.EXPECT().MarkUsed(ctx, "code-1", grant.ExpiresAt).Return(shared.ErrInvalidGrant)
+
+	result, err := m.authorizationCodeUseCase().Execute(ctx, dto.ClientCredentials{ClientID: "wiki"}, "the-code", testRedirectURI, testCodeVerifier)
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrInvalidGrant, err)
+}
+
+func TestAuthorizationCodeUseCase_SessionEnded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser, loginSession, grant := newCodeTestUser(t)
+	loginSession.Revoke(time.Now())
+
+	m.clientRepo.EXPECT().FindByID(ctx, "wiki").Return(newRelyingPartyClient(t), nil)
+	m.codes.EXPECT().Open("the-code", gomock.Any()).Return(grant, nil)
+	m.usedCodes.EXPECT().MarkUsed(ctx, "code-1", grant.ExpiresAt).Return(nil)
+	m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	m.sessionRepo.EXPECT().FindByID(ctx, loginSession.ID()).Return(loginSession, nil)
+
+	result, err := m.authorizationCodeUseCase().Execute(ctx, dto.ClientCredentials{ClientID: "wiki"}, "the-code", testRedirectURI, testCodeVerifier)
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrInvalidGrant, err)
+}
+
+func TestAuthorizationCodeUseCase_ClientWithoutRedirectURIs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+
+	m.clientRepo.EXPECT().FindByID(ctx, "billing").Return(newSecretTestClient(t), nil)
+
+	result, err := m.authorizationCodeUseCase().Execute(ctx, dto.ClientCredentials{ClientID: "billing", ClientSecret: "s3cret"}, "the-code", testRedirectURI, testCodeVerifier)
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrUnauthorizedClient, err)
+}
+
+// newPublicTestClient returns another relying party that shares the wiki's redirect URI
+func newPublicTestClient(t *testing.T, clientID string) *serviceclient.Client {
+	t.Helper()
+
+	client, err := serviceclient.NewPublicClient(clientID, "Other", ports.OIDCScopes, testRedirectURI)
+	require.NoError(t, err)
+	return client
+}
@@ -0,0 +1,391 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/events"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/consent"
+	"github.com/yuki5155/go-google-auth/internal/domain/serviceclient"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+const (
+	// authorizationCodeTTL is how long a client has to redeem a code
+	authorizationCodeTTL = time.Minute
+
+	// consentRequestTTL is how long the user has to answer the consent screen
+	consentRequestTTL = 10 * time.Minute
+)
+
+// Prompt values accepted at the authorization endpoint
+const (
+	promptNone    = "none"
+	promptConsent = "consent"
+)
+
+// codeChallengePattern is the syntax of an S256 code challenge: the
+// unpadded base64url encoding of a SHA-256 hash (RFC 7636, section 4.2)
+var codeChallengePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{43}$`)
+
+// authorizationError is an error the authorization endpoint reports to the
+// client at its redirect URI (RFC 6749, section 4.1.2.1)
+type authorizationError struct {
+	code        string
+	description string
+}
+
+// AuthorizeUseCase handles OpenID Connect authentication requests for the
+// authorization code flow with PKCE
+type AuthorizeUseCase struct {
+	clientRepo      serviceclient.Repository
+	consentRepo     consent.Repository
+	codes           ports.AuthorizationGrantTokens
+	consentRequests ports.AuthorizationGrantTokens
+}
+
+// NewAuthorizeUseCase creates a new AuthorizeUseCase
+func NewAuthorizeUseCase(
+	clientRepo serviceclient.Repository,
+	consentRepo consent.Repository,
+	codes ports.AuthorizationGrantTokens,
+	consentRequests ports.AuthorizationGrantTokens,
+) *AuthorizeUseCase {
+	return &AuthorizeUseCase{
+		clientRepo:      clientRepo,
+		consentRepo:     consentRepo,
+		codes:           codes,
+		consentRequests: consentRequests,
+	}
+}
+
+// Execute handles an authentication request for the signed-in user in
+// claims, which is nil when nobody is signed in. Requests naming an unknown
+// client or an unregistered redirect URI fail with shared.ErrInvalidClient or
+// shared.ErrInvalidRedirectURI, since it is not safe to redirect them; every
+// other outcome is a redirect.
+func (uc *AuthorizeUseCase) Execute(ctx context.Context, req dto.AuthorizationRequest, claims *ports.TokenClaims) (*dto.AuthorizationResult, error) {
+	client, redirectURI, err := uc.findClient(ctx, req.ClientID, req.RedirectURI)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes, prompts, authErr := validateAuthorizationRequest(client, req)
+	if authErr != nil {
+		return redirectWithError(redirectURI, req.State, *authErr), nil
+	}
+
+	// Only the user's own session counts; tokens issued to clients or scripts,
+	// or to an administrator impersonating the user, cannot sign the user in
+	// to another client
+	if claims == nil || claims.UserID == "" || claims.Scopes != nil || claims.Actor != nil {
+		if slices.Contains(prompts, promptNone) {
+			return redirectWithError(redirectURI, req.State, authorizationError{"login_required", "The user is not signed in"}), nil
+		}
+		return &dto.AuthorizationResult{LoginRequired: true}, nil
+	}
+
+	grant := ports.AuthorizationGrant{
+		ClientID:      client.ID(),
+		RedirectURI:   redirectURI,
+		UserID:        claims.UserID,
+		Scopes:        scopes,
+		Nonce:         req.Nonce,
+		CodeChallenge: req.CodeChallenge,
+	}
+
+	consented, err := uc.hasConsented(ctx, claims.UserID, client.ID(), scopes)
+	if err != nil {
+		return nil, err
+	}
+	if consented && !slices.Contains(prompts, promptConsent) {
+		redirectTo, err := issueAuthorizationCode(uc.codes, grant, claims, req.State)
+		if err != nil {
+			return nil, err
+		}
+		return &dto.AuthorizationResult{RedirectTo: redirectTo}, nil
+	}
+
+	if slices.Contains(prompts, promptNone) {
+		return redirectWithError(redirectURI, req.State, authorizationError{"consent_required", "The user has not approved this client"}), nil
+	}
+
+	grant.State = req.State
+	grant.ExpiresAt = time.Now().Add(consentRequestTTL)
+	consentRequest, err := uc.consentRequests.Seal(grant)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create consent request: %w", err)
+	}
+
+	return &dto.AuthorizationResult{ConsentRequest: consentRequest}, nil
+}
+
+// findClient returns the client and the redirect URI to use. The redirect URI
+// may only be left out when the client registered exactly one.
+func (uc *AuthorizeUseCase) findClient(ctx context.Context, clientID, redirectURI string) (*serviceclient.Client, string, error) {
+	if clientID == "" {
+		return nil, "", shared.ErrInvalidClient
+	}
+
+	client, err := uc.clientRepo.FindByID(ctx, clientID)
+	if err != nil {
+		if err == shared.ErrServiceClientNotFound {
+			return nil, "", shared.ErrInvalidClient
+		}
+		return nil, "", fmt.Errorf("failed to retrieve client: %w", err)
+	}
+
+	if redirectURI == "" {
+		if registered := client.RedirectURIs(); len(registered) == 1 {
+			return client, registered[0], nil
+		}
+		return nil, "", shared.ErrInvalidRedirectURI
+	}
+	if !client.AllowsRedirectURI(redirectURI) {
+		return nil, "", shared.ErrInvalidRedirectURI
+	}
+
+	return client, redirectURI, nil
+}
+
+// hasConsented reports whether the user already allowed the client all of scopes
+func (uc *AuthorizeUseCase) hasConsented(ctx context.Context, rawUserID, clientID string, scopes []string) (bool, error) {
+	userID, err := user.NewUserID(rawUserID)
+	if err != nil {
+		return false, fmt.Errorf("invalid user ID in token: %w", err)
+	}
+
+	existing, err := uc.consentRepo.Find(ctx, userID, clientID)
+	if err != nil {
+		if err == shared.ErrConsentNotFound {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to retrieve consent: %w", err)
+	}
+
+	return existing.Covers(scopes), nil
+}
+
+// validateAuthorizationRequest checks the request parameters, returning the
+// scopes to grant and the prompt values
+func validateAuthorizationRequest(client *serviceclient.Client, req dto.AuthorizationRequest) ([]string, []string, *authorizationError) {
+	if req.ResponseType != "code" {
+		return nil, nil, &authorizationError{"unsupported_response_type", "Only the authorization code flow (response_type=code) is supported"}
+	}
+
+	// PKCE is required of every client, confidential or not
+	if req.CodeChallenge == "" || req.CodeChallengeMethod != ports.PKCEMethodS256 || !codeChallengePattern.MatchString(req.CodeChallenge) {
+		return nil, nil, &authorizationError{"invalid_request", "A PKCE code_challenge with code_challenge_method=S256 is required"}
+	}
+
+	requested := strings.Fields(req.Scope)
+	if !slices.Contains(requested, ports.ScopeOpenID) {
+		return nil, nil, &authorizationError{"invalid_scope", "The openid scope is required"}
+	}
+	scopes, err := client.GrantScopes(requested)
+	if err != nil {
+		return nil, nil, &authorizationError{"invalid_scope", "The requested scope is not allowed for this client"}
+	}
+
+	prompts := strings.Fields(req.Prompt)
+	for _, prompt := range prompts {
+		if prompt != promptNone && prompt != promptConsent {
+			return nil, nil, &authorizationError{"invalid_request", fmt.Sprintf("prompt=%s is not supported", prompt)}
+		}
+	}
+	if slices.Contains(prompts, promptNone) && len(prompts) > 1 {
+		return nil, nil, &authorizationError{"invalid_request", "prompt=none cannot be combined with other values"}
+	}
+
+	return scopes, prompts, nil
+}
+
+// issueAuthorizationCode creates a code for grant, recording how the user in
+// claims signed in, and returns the redirect that delivers it to the client
+func issueAuthorizationCode(codes ports.AuthorizationGrantTokens, grant ports.AuthorizationGrant, claims *ports.TokenClaims, state string) (string, error) {
+	grant.State = ""
+	grant.SessionID = claims.SessionID
+	grant.AuthTime = claims.AuthTime
+	grant.AMR = claims.AMR
+	grant.ACR = claims.ACR
+	grant.ExpiresAt = time.Now().Add(authorizationCodeTTL)
+
+	code, err := codes.Seal(grant)
+	if err != nil {
+		return "", fmt.Errorf("failed to create authorization code: %w", err)
+	}
+
+	log.Printf("Issued authorization code for user %s to client %s", grant.UserID, grant.ClientID)
+	return withQuery(grant.RedirectURI, url.Values{"code": {code}}, state), nil
+}
+
+// redirectWithError returns the redirect that reports an error to the client
+func redirectWithError(redirectURI, state string, authErr authorizationError) *dto.AuthorizationResult {
+	params := url.Values{
+		"error":             {authErr.code},
+		"error_description": {authErr.description},
+	}
+	return &dto.AuthorizationResult{RedirectTo: withQuery(redirectURI, params, state)}
+}
+
+// withQuery adds params and, when the client sent one, state to the query of
+// a redirect URI, keeping any query the URI was registered with
+func withQuery(redirectURI string, params url.Values, state string) string {
+	if state != "" {
+		params.Set("state", state)
+	}
+
+	// Registered redirect URIs are validated, so they always parse
+	parsed, _ := url.Parse(redirectURI)
+	query := parsed.Query()
+	for key, values := range params {
+		query[key] = values
+	}
+	parsed.RawQuery = query.Encode()
+
+	return parsed.String()
+}
+
+// GetConsentRequestUseCase describes a pending consent request to the consent screen
+type GetConsentRequestUseCase struct {
+	clientRepo      serviceclient.Repository
+	consentRequests ports.AuthorizationGrantTokens
+}
+
+// NewGetConsentRequestUseCase creates a new GetConsentRequestUseCase
+func NewGetConsentRequestUseCase(clientRepo serviceclient.Repository, consentRequests ports.AuthorizationGrantTokens) *GetConsentRequestUseCase {
+	return &GetConsentRequestUseCase{
+		clientRepo:      clientRepo,
+		consentRequests: consentRequests,
+	}
+}
+
+// Execute returns the client and scopes a consent request asks the user to approve
+func (uc *GetConsentRequestUseCase) Execute(ctx context.Context, userID, request string) (*dto.ConsentRequestResponse, error) {
+	grant, client, err := openConsentRequest(ctx, uc.clientRepo, uc.consentRequests, userID, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.ConsentRequestResponse{
+		ClientID:    client.ID(),
+		ClientName:  client.Name(),
+		RedirectURI: grant.RedirectURI,
+		Scopes:      grant.Scopes,
+	}, nil
+}
+
+// DecideConsentUseCase records the user's answer to a consent request
+type DecideConsentUseCase struct {
+	clientRepo      serviceclient.Repository
+	consentRepo     consent.Repository
+	codes           ports.AuthorizationGrantTokens
+	consentRequests ports.AuthorizationGrantTokens
+	eventPublisher  ports.EventPublisher
+}
+
+// NewDecideConsentUseCase creates a new DecideConsentUseCase
+func NewDecideConsentUseCase(
+	clientRepo serviceclient.Repository,
+	consentRepo consent.Repository,
+	codes ports.AuthorizationGrantTokens,
+	consentRequests ports.AuthorizationGrantTokens,
+	eventPublisher ports.EventPublisher,
+) *DecideConsentUseCase {
+	return &DecideConsentUseCase{
+		clientRepo:      clientRepo,
+		consentRepo:     consentRepo,
+		codes:           codes,
+		consentRequests: consentRequests,
+		eventPublisher:  eventPublisher,
+	}
+}
+
+// Execute approves or denies a consent request for the signed-in user in
+// claims, returning the redirect that takes the user back to the client
+func (uc *DecideConsentUseCase) Execute(ctx context.Context, claims *ports.TokenClaims, request string, approve bool) (*dto.ConsentDecisionResponse, error) {
+	grant, client, err := openConsentRequest(ctx, uc.clientRepo, uc.consentRequests, claims.UserID, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if !approve {
+		denied := redirectWithError(grant.RedirectURI, grant.State, authorizationError{"access_denied", "The user denied the request"})
+		return &dto.ConsentDecisionResponse{RedirectTo: denied.RedirectTo}, nil
+	}
+
+	if err := uc.grantConsent(ctx, claims.UserID, client.ID(), grant.Scopes); err != nil {
+		return nil, err
+	}
+
+	redirectTo, err := issueAuthorizationCode(uc.codes, *grant, claims, grant.State)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.ConsentDecisionResponse{RedirectTo: redirectTo}, nil
+}
+
+// grantConsent adds scopes to the user's consent to the client
+func (uc *DecideConsentUseCase) grantConsent(ctx context.Context, rawUserID, clientID string, scopes []string) error {
+	userID, err := user.NewUserID(rawUserID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID in token: %w", err)
+	}
+
+	existing, err := uc.consentRepo.Find(ctx, userID, clientID)
+	switch {
+	case err == shared.ErrConsentNotFound:
+		existing, err = consent.NewConsent(userID, clientID, scopes)
+		if err != nil {
+			return err
+		}
+	case err != nil:
+		return fmt.Errorf("failed to retrieve consent: %w", err)
+	default:
+		existing.Grant(scopes)
+	}
+
+	if err := uc.consentRepo.Save(ctx, existing); err != nil {
+		return fmt.Errorf("failed to save consent: %w", err)
+	}
+
+	events.Publish(ctx, uc.eventPublisher, existing)
+	return nil
+}
+
+// openConsentRequest verifies a consent request made for userID and loads
+// its client, which must still accept the request's redirect URI
+func openConsentRequest(
+	ctx context.Context,
+	clientRepo serviceclient.Repository,
+	consentRequests ports.AuthorizationGrantTokens,
+	userID, request string,
+) (*ports.AuthorizationGrant, *serviceclient.Client, error) {
+	grant, err := consentRequests.Open(request, time.Now())
+	if err != nil || grant.UserID != userID {
+		return nil, nil, shared.ErrInvalidConsentRequest
+	}
+
+	client, err := clientRepo.FindByID(ctx, grant.ClientID)
+	if err != nil {
+		if err == shared.ErrServiceClientNotFound {
+			return nil, nil, shared.ErrInvalidConsentRequest
+		}
+		return nil, nil, fmt.Errorf("failed to retrieve client: %w", err)
+	}
+	if !client.AllowsRedirectURI(grant.RedirectURI) {
+		return nil, nil, shared.ErrInvalidConsentRequest
+	}
+
+	return grant, client, nil
+}
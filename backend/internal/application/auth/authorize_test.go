@@ -0,0 +1,398 @@
+package auth
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/consent"
+	"github.com/yuki5155/go-google-auth/internal/domain/serviceclient"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+const (
+	testRedirectURI   = "https://wiki.example.com/callback"
+	testCodeChallenge = "69DwtRqoFNrFq-fQnB1RFzBVnLzmKp19C5tPIwa5fUA"
+)
+
+func (m *authMocks) authorizeUseCase() *AuthorizeUseCase {
+	return NewAuthorizeUseCase(m.clientRepo, m.consentRepo, m.codes, m.consentRequests)
+}
+
+func (m *authMocks) decideUseCase() *DecideConsentUseCase {
+	return NewDecideConsentUseCase(m.clientRepo, m.consentRepo, m.codes, m.consentRequests, m.eventPublisher)
+}
+
+// newRelyingPartyClient returns a public client that may sign users in at testRedirectURI
+func newRelyingPartyClient(t *testing.T) *serviceclient.Client {
+	t.Helper()
+
+	client, err := serviceclient.NewPublicClient("wiki", "Wiki", ports.OIDCScopes, testRedirectURI)
+	require.NoError(t, err)
+	return client
+}
+
+// newAuthorizationRequest returns a valid request for the openid and email scopes
+func newAuthorizationRequest() dto.AuthorizationRequest {
+	return dto.AuthorizationRequest{
+		ClientID:            "wiki",
+		RedirectURI:         testRedirectURI,
+		ResponseType:        "code",
+		Scope:               "openid email",
+		State:               "xyz",
+		Nonce:               "n-0S6",
+		CodeChallenge:       testCodeChallenge,
+		CodeChallengeMethod: ports.PKCEMethodS256,
+	}
+}
+
+func newSessionClaims() *ports.TokenClaims {
+	return &ports.TokenClaims{
+		UserID:    "user-123",
+		SessionID: "session-1",
+		AuthTime:  time.Now().Add(-time.Minute),
+		AMR:       []string{"pwd"},
+	}
+}
+
+// redirectQuery parses the query of a redirect and checks where it goes
+func redirectQuery(t *testing.T, redirectTo string) url.Values {
+	t.Helper()
+
+	parsed, err := url.Parse(redirectTo)
+	require.NoError(t, err)
+	assert.Equal(t, testRedirectURI, parsed.Scheme+"://"+parsed.Host+parsed.Path)
+	return parsed.Query()
+}
+
+func TestAuthorizeUseCase_ExistingConsentIssuesCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	userID, _ := user.NewUserID("user-123")
+	existing, err := consent.NewConsent(userID, "wiki", []string{"openid", "email", "profile"})
+	require.NoError(t, err)
+
+	m.clientRepo.EXPECT().FindByID(ctx, "wiki").Return(newRelyingPartyClient(t), nil)
+	m.consentRepo.EXPECT().Find(ctx, userID, "wiki").Return(existing, nil)
+	m.codes.EXPECT().Seal(gomock.Any()).DoAndReturn(func(grant ports.AuthorizationGrant) (string, error) {
+		assert.Equal(t, "wiki", grant.ClientID)
+		assert.Equal(t, testRedirectURI, grant.RedirectURI)
+		assert.Equal(t, "user-123", grant.UserID)
+		assert.Equal(t, []string{"email", "openid"}, grant.Scopes)
+		assert.Equal(t, "n-0S6", grant.Nonce)
+		assert.Equal(t, testCodeChallenge, grant.CodeChallenge)
+		assert.Equal(t, "session-1", grant.SessionID)
+		assert.Equal(t, []string{"pwd"}, grant.AMR)
+		assert.Empty(t, grant.State)
+		assert.WithinDuration(t, time.Now().Add(authorizationCodeTTL), grant.ExpiresAt, time.Second)
+		return "the-code", nil
+	})
+
+	result, err := m.authorizeUseCase().Execute(ctx, newAuthorizationRequest(), newSessionClaims())
+
+	require.NoError(t, err)
+	query := redirectQuery(t, result.RedirectTo)
+	assert.Equal(t, "the-code", query.Get("code"))
+	assert.Equal(t, "xyz", query.Get("state"))
+}
+
+func TestAuthorizeUseCase_NoConsentReturnsConsentRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+
+	m.clientRepo.EXPECT().FindByID(ctx, "wiki").Return(newRelyingPartyClient(t), nil)
+	m.consentRepo.EXPECT().Find(ctx, gomock.Any(), "wiki").Return(nil, shared.ErrConsentNotFound)
+	m.consentRequests.EXPECT().Seal(gomock.Any()).DoAndReturn(func(grant ports.AuthorizationGrant) (string, error) {
+		assert.Equal(t, "xyz", grant.State)
+		assert.Empty(t, grant.SessionID)
+		assert.WithinDuration(t, time.Now().Add(consentRequestTTL), grant.ExpiresAt, time.Second)
+		return "consent-request", nil
+	})
+
+	result, err := m.authorizeUseCase().Execute(ctx, newAuthorizationRequest(), newSessionClaims())
+
+	require.NoError(t, err)
+	assert.Equal(t, &dto.AuthorizationResult{ConsentRequest: "consent-request"}, result)
+}
+
+func TestAuthorizeUseCase_PromptConsentAsksAgain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	userID, _ := user.NewUserID("user-123")
+	existing, err := consent.NewConsent(userID, "wiki", []string{"openid", "email"})
+	require.NoError(t, err)
+	req := newAuthorizationRequest()
+	req.Prompt = "consent"
+
+	m.clientRepo.EXPECT().FindByID(ctx, "wiki").Return(newRelyingPartyClient(t), nil)
+	m.consentRepo.EXPECT().Find(ctx, userID, "wiki").Return(existing, nil)
+	m.consentRequests.EXPECT().Seal(gomock.Any()).Return("consent-request", nil)
+
+	result, err := m.authorizeUseCase().Execute(ctx, req, newSessionClaims())
+
+	require.NoError(t, err)
+	assert.Equal(t, "consent-request", result.ConsentRequest)
+}
+
+func TestAuthorizeUseCase_NotSignedIn(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims *ports.TokenClaims
+	}{
+		{"no session", nil},
+		{"service client token", &ports.TokenClaims{ClientID: "billing", Scopes: []string{"users:read"}}},
+		{"personal access token", &ports.TokenClaims{UserID: "user-123", APITokenID: "tok-1", Scopes: []string{"profile:read"}}},
+		{"impersonation token", &ports.TokenClaims{UserID: "user-123", Actor: &ports.Actor{Subject: "admin-123"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			m := newAuthMocks(ctrl)
+			m.clientRepo.EXPECT().FindByID(ctx, "wiki").Return(newRelyingPartyClient(t), nil)
+
+			result, err := m.authorizeUseCase().Execute(ctx, newAuthorizationRequest(), tt.claims)
+
+			require.NoError(t, err)
+			assert.Equal(t, &dto.AuthorizationResult{LoginRequired: true}, result)
+		})
+	}
+}
+
+func TestAuthorizeUseCase_PromptNone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	req := newAuthorizationRequest()
+	req.Prompt = "none"
+
+	m.clientRepo.EXPECT().FindByID(ctx, "wiki").Return(newRelyingPartyClient(t), nil).Times(2)
+	m.consentRepo.EXPECT().Find(ctx, gomock.Any(), "wiki").Return(nil, shared.ErrConsentNotFound)
+
+	result, err := m.authorizeUseCase().Execute(ctx, req, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "login_required", redirectQuery(t, result.RedirectTo).Get("error"))
+
+	result, err = m.authorizeUseCase().Execute(ctx, req, newSessionClaims())
+	require.NoError(t, err)
+	query := redirectQuery(t, result.RedirectTo)
+	assert.Equal(t, "consent_required", query.Get("error"))
+	assert.Equal(t, "xyz", query.Get("state"))
+}
+
+func TestAuthorizeUseCase_InvalidRequestsRedirectWithError(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(req *dto.AuthorizationRequest)
+		error  string
+	}{
+		{"implicit flow", func(req *dto.AuthorizationRequest) { req.ResponseType = "token" }, "unsupported_response_type"},
+		{"no PKCE", func(req *dto.AuthorizationRequest) { req.CodeChallenge = "" }, "invalid_request"},
+		{"plain PKCE", func(req *dto.AuthorizationRequest) { req.CodeChallengeMethod = "plain" }, "invalid_request"},
+		{"malformed challenge", func(req *dto.AuthorizationRequest) { req.CodeChallenge = "short" }, "invalid_request"},
+		{"no openid scope", func(req *dto.AuthorizationRequest) { req.Scope = "email" }, "invalid_scope"},
+		{"scope not allowed", func(req *dto.AuthorizationRequest) { req.Scope = "openid users:read" }, "invalid_scope"},
+		{"unsupported prompt", func(req *dto.AuthorizationRequest) { req.Prompt = "login" }, "invalid_request"},
+		{"prompt none with consent", func(req *dto.AuthorizationRequest) { req.Prompt = "none consent" }, "invalid_request"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			m := newAuthMocks(ctrl)
+			m.clientRepo.EXPECT().FindByID(ctx, "wiki").Return(newRelyingPartyClient(t), nil)
+			req := newAuthorizationRequest()
+			tt.modify(&req)
+
+			result, err := m.authorizeUseCase().Execute(ctx, req, newSessionClaims())
+
+			require.NoError(t, err)
+			query := redirectQuery(t, result.RedirectTo)
+			assert.Equal(t, tt.error, query.Get("error"))
+			assert.Equal(t, "xyz", query.Get("state"))
+			assert.Empty(t, query.Get("code"))
+		})
+	}
+}
+
+func TestAuthorizeUseCase_UnsafeRequestsAreNotRedirected(t *testing.T) {
+	tests := []struct {
+		name        string
+		clientID    string
+		redirectURI string
+		found       bool
+		expectedErr error
+	}{
+		{"missing client", "", testRedirectURI, false, shared.ErrInvalidClient},
+		{"unknown client", "unknown", testRedirectURI, false, shared.ErrInvalidClient},
+		{"unregistered redirect URI", "wiki", "https://evil.example.com/callback", true, shared.ErrInvalidRedirectURI},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			m := newAuthMocks(ctrl)
+			switch {
+			case tt.found:
+				m.clientRepo.EXPECT().FindByID(ctx, tt.clientID).Return(newRelyingPartyClient(t), nil)
+			case tt.clientID != "":
+				m.clientRepo.EXPECT().FindByID(ctx, tt.clientID).Return(nil, shared.ErrServiceClientNotFound)
+			}
+			req := newAuthorizationRequest()
+			req.ClientID = tt.clientID
+			req.RedirectURI = tt.redirectURI
+
+			result, err := m.authorizeUseCase().Execute(ctx, req, newSessionClaims())
+
+			assert.Nil(t, result)
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
+
+func TestAuthorizeUseCase_SingleRedirectURIMayBeOmitted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	req := newAuthorizationRequest()
+	req.RedirectURI = ""
+
+	m.clientRepo.EXPECT().FindByID(ctx, "wiki").Return(newRelyingPartyClient(t), nil)
+
+	result, err := m.authorizeUseCase().Execute(ctx, req, nil)
+
+	require.NoError(t, err)
+	assert.True(t, result.LoginRequired)
+}
+
+func TestGetConsentRequestUseCase_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	grant := &ports.AuthorizationGrant{ClientID: "wiki", RedirectURI: testRedirectURI, UserID: "user-123", Scopes: []string{"email", "openid"}}
+
+	m.consentRequests.EXPECT().Open("consent-request", gomock.Any()).Return(grant, nil)
+	m.clientRepo.EXPECT().FindByID(ctx, "wiki").Return(newRelyingPartyClient(t), nil)
+
+	result, err := NewGetConsentRequestUseCase(m.clientRepo, m.consentRequests).Execute(ctx, "user-123", "consent-request")
+
+	require.NoError(t, err)
+	assert.Equal(t, &dto.ConsentRequestResponse{
+		ClientID:    "wiki",
+		ClientName:  "Wiki",
+		RedirectURI: testRedirectURI,
+		Scopes:      []string{"email", "openid"},
+	}, result)
+}
+
+func TestGetConsentRequestUseCase_OtherUsersRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+
+	m.consentRequests.EXPECT().Open("consent-request", gomock.Any()).Return(&ports.AuthorizationGrant{ClientID: "wiki", UserID: "someone-else"}, nil)
+
+	result, err := NewGetConsentRequestUseCase(m.clientRepo, m.consentRequests).Execute(ctx, "user-123", "consent-request")
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrInvalidConsentRequest, err)
+}
+
+func TestDecideConsentUseCase_Approve(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	userID, _ := user.NewUserID("user-123")
+	grant := &ports.AuthorizationGrant{ClientID: "wiki", RedirectURI: testRedirectURI, UserID: "user-123", Scopes: []string{"email", "openid"}, State: "xyz"}
+
+	m.consentRequests.EXPECT().Open("consent-request", gomock.Any()).Return(grant, nil)
+	m.clientRepo.EXPECT().FindByID(ctx, "wiki").Return(newRelyingPartyClient(t), nil)
+	m.consentRepo.EXPECT().Find(ctx, userID, "wiki").Return(nil, shared.ErrConsentNotFound)
+	m.consentRepo.EXPECT().Save(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, saved *consent.Consent) error {
+		assert.Equal(t, []string{"email", "openid"}, saved.Scopes())
+		return nil
+	})
+	m.eventPublisher.EXPECT().Publish(ctx, gomock.Any()).Return(nil)
+	m.codes.EXPECT().Seal(gomock.Any()).DoAndReturn(func(issued ports.AuthorizationGrant) (string, error) {
+		assert.Equal(t, "session-1", issued.SessionID)
+		assert.Empty(t, issued.State)
+		return "the-code", nil
+	})
+
+	result, err := m.decideUseCase().Execute(ctx, newSessionClaims(), "consent-request", true)
+
+	require.NoError(t, err)
+	query := redirectQuery(t, result.RedirectTo)
+	assert.Equal(t, "the-code", query.Get("code"))
+	assert.Equal(t, "xyz", query.Get("state"))
+}
+
+func TestDecideConsentUseCase_Deny(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	grant := &ports.AuthorizationGrant{ClientID: "wiki", RedirectURI: testRedirectURI, UserID: "user-123", Scopes: []string{"openid"}, State: "xyz"}
+
+	m.consentRequests.EXPECT().Open("consent-request", gomock.Any()).Return(grant, nil)
+	m.clientRepo.EXPECT().FindByID(ctx, "wiki").Return(newRelyingPartyClient(t), nil)
+
+	result, err := m.decideUseCase().Execute(ctx, newSessionClaims(), "consent-request", false)
+
+	require.NoError(t, err)
+	query := redirectQuery(t, result.RedirectTo)
+	assert.Equal(t, "access_denied", query.Get("error"))
+	assert.Equal(t, "xyz", query.Get("state"))
+}
+
+func TestDecideConsentUseCase_ExpiredRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+
+	m.consentRequests.EXPECT().Open("consent-request", gomock.Any()).Return(nil, shared.ErrInvalidGrant)
+
+	result, err := m.decideUseCase().Execute(ctx, newSessionClaims(), "consent-request", true)
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrInvalidConsentRequest, err)
+}
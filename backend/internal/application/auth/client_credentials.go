@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/serviceclient"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+// ServiceClientAuthenticator authenticates registered clients with their
+// secret or a private_key_jwt client assertion. Public clients are identified
+// by their client ID alone.
+type ServiceClientAuthenticator struct {
+	clientRepo     serviceclient.Repository
+	assertions     ports.ClientAssertionVerifier
+	usedAssertions ports.ClientAssertionStore
+}
+
+// NewServiceClientAuthenticator creates a new ServiceClientAuthenticator
+func NewServiceClientAuthenticator(
+	clientRepo serviceclient.Repository,
+	assertions ports.ClientAssertionVerifier,
+	usedAssertions ports.ClientAssertionStore,
+) *ServiceClientAuthenticator {
+	return &ServiceClientAuthenticator{
+		clientRepo:     clientRepo,
+		assertions:     assertions,
+		usedAssertions: usedAssertions,
+	}
+}
+
+// Authenticate returns the client that creds belong to, or
+// shared.ErrInvalidClient. A client must use the method it registered with.
+func (a *ServiceClientAuthenticator) Authenticate(ctx context.Context, creds dto.ClientCredentials) (*serviceclient.Client, error) {
+	if creds.ClientID == "" {
+		return nil, shared.ErrInvalidClient
+	}
+
+	client, err := a.clientRepo.FindByID(ctx, creds.ClientID)
+	if err != nil {
+		if err == shared.ErrServiceClientNotFound {
+			return nil, shared.ErrInvalidClient
+		}
+		return nil, fmt.Errorf("failed to retrieve service client: %w", err)
+	}
+
+	switch client.AuthMethod() {
+	case serviceclient.AuthMethodClientSecret:
+		if creds.ClientAssertion != "" || !client.VerifySecret(creds.ClientSecret) {
+			return nil, shared.ErrInvalidClient
+		}
+	case serviceclient.AuthMethodPrivateKeyJWT:
+		if creds.ClientSecret != "" || creds.ClientAssertionType != ports.ClientAssertionType {
+			return nil, shared.ErrInvalidClient
+		}
+		assertion, err := a.assertions.Verify(creds.ClientAssertion, client.ID(), client.PublicKey())
+		if err != nil {
+			return nil, err
+		}
+		// jti values are only unique per client
+		if err := a.usedAssertions.MarkUsed(ctx, client.ID()+":"+assertion.ID, assertion.ExpiresAt); err != nil {
+			return nil, err
+		}
+	case serviceclient.AuthMethodNone:
+		if creds.ClientSecret != "" || creds.ClientAssertion != "" {
+			return nil, shared.ErrInvalidClient
+		}
+	default:
+		return nil, shared.ErrInvalidClient
+	}
+
+	return client, nil
+}
+
+// ClientCredentialsUseCase issues access tokens to service clients acting on
+// their own behalf (the OAuth 2.0 client_credentials grant)
+type ClientCredentialsUseCase struct {
+	clients        *ServiceClientAuthenticator
+	tokenGenerator ports.TokenGenerator
+}
+
+// NewClientCredentialsUseCase creates a new ClientCredentialsUseCase
+func NewClientCredentialsUseCase(clients *ServiceClientAuthenticator, tokenGenerator ports.TokenGenerator) *ClientCredentialsUseCase {
+	return &ClientCredentialsUseCase{
+		clients:        clients,
+		tokenGenerator: tokenGenerator,
+	}
+}
+
+// Execute authenticates the client and issues an access token for the
+// space-separated scopes in scope, or for all of the client's scopes when
+// scope is empty. No refresh token is issued.
+func (uc *ClientCredentialsUseCase) Execute(ctx context.Context, creds dto.ClientCredentials, scope string) (*dto.OAuthTokenResponse, error) {
+	client, err := uc.clients.Authenticate(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+	if !client.CanUseClientCredentials() {
+		return nil, shared.ErrUnauthorizedClient
+	}
+
+	scopes, err := client.GrantScopes(strings.Fields(scope))
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, err := uc.tokenGenerator.GenerateClientToken(client.ID(), scopes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate client token: %w", err)
+	}
+
+	log.Printf("Issued client credentials token to %s (%s)", client.ID(), strings.Join(scopes, " "))
+	return &dto.OAuthTokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   uc.tokenGenerator.GetAccessTokenExpiry(),
+		Scope:       strings.Join(scopes, " "),
+	}, nil
+}
@@ -0,0 +1,216 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/serviceclient"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+const testClientPublicKey = "-----BEGIN PUBLIC KEY-----\nMFkw...\n-----END PUBLIC KEY-----"
+
+func (m *authMocks) clientCredentialsUseCase() *ClientCredentialsUseCase {
+	clients := NewServiceClientAuthenticator(m.clientRepo, m.assertions, m.usedAssertions)
+	return NewClientCredentialsUseCase(clients, m.tokenGenerator)
+}
+
+// newSecretTestClient returns a client whose secret is "s3cret"
+func newSecretTestClient(t *testing.T) *serviceclient.Client {
+	t.Helper()
+
+	client, err := serviceclient.NewSecretClient("billing", "Billing", serviceclient.HashSecret("s3cret"), []string{"invoices:write", "users:read"})
+	require.NoError(t, err)
+	return client
+}
+
+func TestClientCredentialsUseCase_ClientSecret_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	client := newSecretTestClient(t)
+
+	m.clientRepo.EXPECT().FindByID(ctx, "billing").Return(client, nil)
+	m.tokenGenerator.EXPECT().GenerateClientToken("billing", []string{"users:read"}).Return("client-token", nil)
+	m.tokenGenerator.EXPECT().GetAccessTokenExpiry().Return(900)
+
+	result, err := m.clientCredentialsUseCase().Execute(ctx, dto.ClientCredentials{ClientID: "billing", ClientSecret: "s3cret"}, "users:read")
+
+	require.NoError(t, err)
+	assert.Equal(t, &dto.OAuthTokenResponse{
+		AccessToken: "client-token",
+		TokenType:   "Bearer",
+		ExpiresIn:   900,
+		Scope:       "users:read",
+	}, result)
+}
+
+func TestClientCredentialsUseCase_NoScopeGrantsAllClientScopes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+
+	m.clientRepo.EXPECT().FindByID(ctx, "billing").Return(newSecretTestClient(t), nil)
+	m.tokenGenerator.EXPECT().GenerateClientToken("billing", []string{"invoices:write", "users:read"}).Return("client-token", nil)
+	m.tokenGenerator.EXPECT().GetAccessTokenExpiry().Return(900)
+
+	result, err := m.clientCredentialsUseCase().Execute(ctx, dto.ClientCredentials{ClientID: "billing", ClientSecret: "s3cret"}, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "invoices:write users:read", result.Scope)
+}
+
+func TestClientCredentialsUseCase_ScopeNotAllowed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+
+	m.clientRepo.EXPECT().FindByID(ctx, "billing").Return(newSecretTestClient(t), nil)
+
+	result, err := m.clientCredentialsUseCase().Execute(ctx, dto.ClientCredentials{ClientID: "billing", ClientSecret: "s3cret"}, "users:read users:write")
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrInvalidScope, err)
+}
+
+func TestClientCredentialsUseCase_InvalidClientSecret(t *testing.T) {
+	tests := []struct {
+		name  string
+		creds dto.ClientCredentials
+	}{
+		{"wrong secret", dto.ClientCredentials{ClientID: "billing", ClientSecret: "wrong"}},
+		{"missing secret", dto.ClientCredentials{ClientID: "billing"}},
+		{"assertion for a secret client", dto.ClientCredentials{ClientID: "billing", ClientAssertionType: ports.ClientAssertionType, ClientAssertion: "signed-jwt"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			m := newAuthMocks(ctrl)
+			m.clientRepo.EXPECT().FindByID(ctx, "billing").Return(newSecretTestClient(t), nil)
+
+			result, err := m.clientCredentialsUseCase().Execute(ctx, tt.creds, "")
+
+			assert.Nil(t, result)
+			assert.Equal(t, shared.ErrInvalidClient, err)
+		})
+	}
+}
+
+func TestClientCredentialsUseCase_UnknownClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+
+	m.clientRepo.EXPECT().FindByID(ctx, "unknown").Return(nil, shared.ErrServiceClientNotFound)
+
+	result, err := m.clientCredentialsUseCase().Execute(ctx, dto.ClientCredentials{ClientID: "unknown", ClientSecret: "s3cret"}, "")
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrInvalidClient, err)
+}
+
+func TestClientCredentialsUseCase_PrivateKeyJWT_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	client, err := serviceclient.NewPrivateKeyJWTClient("reports", "Reports", testClientPublicKey, []string{"users:read"})
+	require.NoError(t, err)
+	expiresAt := time.Now().Add(time.Minute)
+
+	m.clientRepo.EXPECT().FindByID(ctx, "reports").Return(client, nil)
+	m.assertions.EXPECT().Verify("signed-jwt", "reports", testClientPublicKey).Return(&ports.ClientAssertion{ID: "jti-1", ExpiresAt: expiresAt}, nil)
+	m.usedAssertions.EXPECT().MarkUsed(ctx, "reports:jti-1", expiresAt).Return(nil)
+	m.tokenGenerator.EXPECT().GenerateClientToken("reports", []string{"users:read"}).Return("client-token", nil)
+	m.tokenGenerator.EXPECT().GetAccessTokenExpiry().Return(900)
+
+	result, err := m.clientCredentialsUseCase().Execute(ctx, dto.ClientCredentials{
+		ClientID:            "reports",
+		ClientAssertionType: ports.ClientAssertionType,
+		ClientAssertion:     "signed-jwt",
+	}, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "client-token", result.AccessToken)
+}
+
+func TestClientCredentialsUseCase_PrivateKeyJWT_ReplayedAssertion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	client, err := serviceclient.NewPrivateKeyJWTClient("reports", "Reports", testClientPublicKey, []string{"users:read"})
+	require.NoError(t, err)
+
+	m.clientRepo.EXPECT().FindByID(ctx, "reports").Return(client, nil)
+	m.assertions.EXPECT().Verify("signed-jwt", "reports", testClientPublicKey).Return(&ports.ClientAssertion{ID: "jti-1"}, nil)
+	m.usedAssertions.EXPECT().MarkUsed(ctx, "reports:jti-1", gomock.Any()).Return(shared.ErrInvalidClient)
+
+	result, err := m.clientCredentialsUseCase().Execute(ctx, dto.ClientCredentials{
+		ClientID:            "reports",
+		ClientAssertionType: ports.ClientAssertionType,
+		ClientAssertion:     "signed-jwt",
+	}, "")
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrInvalidClient, err)
+}
+
+func TestClientCredentialsUseCase_PrivateKeyJWT_SecretIsRejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	client, err := serviceclient.NewPrivateKeyJWTClient("reports", "Reports", testClientPublicKey, []string{"users:read"})
+	require.NoError(t, err)
+
+	m.clientRepo.EXPECT().FindByID(ctx, "reports").Return(client, nil)
+
+	result, err := m.clientCredentialsUseCase().Execute(ctx, dto.ClientCredentials{ClientID: "reports", ClientSecret: "guess"}, "")
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrInvalidClient, err)
+}
+
+func TestClientCredentialsUseCase_PublicClientIsUnauthorized(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	client, err := serviceclient.NewPublicClient("spa", "SPA", []string{"openid"}, "https://app.example.com/callback")
+	require.NoError(t, err)
+
+	m.clientRepo.EXPECT().FindByID(ctx, "spa").Return(client, nil).Times(2)
+
+	result, err := m.clientCredentialsUseCase().Execute(ctx, dto.ClientCredentials{ClientID: "spa"}, "")
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrUnauthorizedClient, err)
+
+	// Public clients have no secret to send
+	result, err = m.clientCredentialsUseCase().Execute(ctx, dto.ClientCredentials{ClientID: "spa", ClientSecret: "guess"}, "")
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrInvalidClient, err)
+}
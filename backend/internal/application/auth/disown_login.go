@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/application/events"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// DisownLoginUseCase handles the "this wasn't me" link of a login alert by
+// revoking the session the alert was about
+type DisownLoginUseCase struct {
+	userRepo       user.Repository
+	sessionRepo    session.Repository
+	revokeLinks    ports.RevokeLinkTokenService
+	eventPublisher ports.EventPublisher
+}
+
+// NewDisownLoginUseCase creates a new DisownLoginUseCase
+func NewDisownLoginUseCase(
+	userRepo user.Repository,
+	sessionRepo session.Repository,
+	revokeLinks ports.RevokeLinkTokenService,
+	eventPublisher ports.EventPublisher,
+) *DisownLoginUseCase {
+	return &DisownLoginUseCase{
+		userRepo:       userRepo,
+		sessionRepo:    sessionRepo,
+		revokeLinks:    revokeLinks,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// Execute revokes the session named by a revoke link token. Using a link
+// again, or after the session ended, succeeds without changing anything.
+func (uc *DisownLoginUseCase) Execute(ctx context.Context, token string) error {
+	now := time.Now()
+	claims, err := uc.revokeLinks.Verify(token, now)
+	if err != nil {
+		return err
+	}
+
+	sessionID, err := session.NewSessionID(claims.SessionID)
+	if err != nil {
+		return shared.ErrInvalidRevokeLink
+	}
+
+	loginSession, err := uc.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		if err == shared.ErrSessionNotFound {
+			return nil
+		}
+		return fmt.Errorf("failed to retrieve session: %w", err)
+	}
+	if loginSession.UserID().Value() != claims.UserID {
+		return shared.ErrInvalidRevokeLink
+	}
+	if loginSession.IsRevoked() {
+		return nil
+	}
+
+	loginSession.Revoke(now)
+	if err := uc.sessionRepo.Save(ctx, loginSession); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	log.Printf("User %s disowned login, session %s revoked", claims.UserID, claims.SessionID)
+
+	// The audit entry is best-effort; the session is already revoked
+	domainUser, err := uc.userRepo.FindByID(ctx, loginSession.UserID())
+	if err != nil {
+		log.Printf("Failed to load user %s to record disowned login: %v", claims.UserID, err)
+		return nil
+	}
+	domainUser.DisownLogin(claims.SessionID)
+	events.Publish(ctx, uc.eventPublisher, domainUser)
+
+	return nil
+}
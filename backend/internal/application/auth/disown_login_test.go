@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+	"github.com/yuki5155/go-google-auth/internal/mocks"
+)
+
+func TestDisownLoginUseCase_RevokesSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockRevokeLinks := mocks.NewMockRevokeLinkTokenService(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
+	domainUser, _, loginSession := newAlertTestUser(t, unknownDevice)
+
+	mockRevokeLinks.EXPECT().
+		Verify("revoke-token", gomock.Any()).
+		Return(&ports.RevokeLinkClaims{UserID: "user-123", SessionID: loginSession.ID().Value()}, nil)
+	mockSessionRepo.EXPECT().FindByID(ctx, loginSession.ID()).Return(loginSession, nil)
+	mockSessionRepo.EXPECT().Save(ctx, loginSession).Return(nil)
+	mockRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	mockPublisher.EXPECT().
+		Publish(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, events []shared.DomainEvent) error {
+			require.Len(t, events, 1)
+			assert.Equal(t, user.EventTypeLoginDisowned, events[0].EventType())
+			return nil
+		})
+
+	useCase := NewDisownLoginUseCase(mockRepo, mockSessionRepo, mockRevokeLinks, mockPublisher)
+
+	err := useCase.Execute(ctx, "revoke-token")
+
+	require.NoError(t, err)
+	assert.True(t, loginSession.IsRevoked())
+}
+
+func TestDisownLoginUseCase_AlreadyRevoked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockRevokeLinks := mocks.NewMockRevokeLinkTokenService(ctrl)
+	_, _, loginSession := newAlertTestUser(t, unknownDevice)
+	revokedAt := time.Now().Add(-time.Minute)
+	loginSession.Revoke(revokedAt)
+
+	mockRevokeLinks.EXPECT().
+		Verify("revoke-token", gomock.Any()).
+		Return(&ports.RevokeLinkClaims{UserID: "user-123", SessionID: loginSession.ID().Value()}, nil)
+	mockSessionRepo.EXPECT().FindByID(ctx, loginSession.ID()).Return(loginSession, nil)
+
+	useCase := NewDisownLoginUseCase(mocks.NewMockRepository(ctrl), mockSessionRepo, mockRevokeLinks, mocks.NewMockEventPublisher(ctrl))
+
+	err := useCase.Execute(ctx, "revoke-token")
+
+	require.NoError(t, err)
+	assert.Equal(t, revokedAt, loginSession.RevokedAt())
+}
+
+func TestDisownLoginUseCase_SessionOfAnotherUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockRevokeLinks := mocks.NewMockRevokeLinkTokenService(ctrl)
+	_, _, loginSession := newAlertTestUser(t, unknownDevice)
+
+	mockRevokeLinks.EXPECT().
+		Verify("revoke-token", gomock.Any()).
+		Return(&ports.RevokeLinkClaims{UserID: "other-user", SessionID: loginSession.ID().Value()}, nil)
+	mockSessionRepo.EXPECT().FindByID(ctx, loginSession.ID()).Return(loginSession, nil)
+
+	useCase := NewDisownLoginUseCase(mocks.NewMockRepository(ctrl), mockSessionRepo, mockRevokeLinks, mocks.NewMockEventPublisher(ctrl))
+
+	err := useCase.Execute(ctx, "revoke-token")
+
+	assert.Equal(t, shared.ErrInvalidRevokeLink, err)
+	assert.False(t, loginSession.IsRevoked())
+}
+
+func TestDisownLoginUseCase_InvalidLink(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRevokeLinks := mocks.NewMockRevokeLinkTokenService(ctrl)
+	mockRevokeLinks.EXPECT().
+		Verify("bad-token", gomock.Any()).
+		Return(nil, shared.ErrInvalidRevokeLink)
+
+	useCase := NewDisownLoginUseCase(mocks.NewMockRepository(ctrl), mocks.NewMockSessionRepository(ctrl), mockRevokeLinks, mocks.NewMockEventPublisher(ctrl))
+
+	err := useCase.Execute(context.Background(), "bad-token")
+
+	assert.Equal(t, shared.ErrInvalidRevokeLink, err)
+}
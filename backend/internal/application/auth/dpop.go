@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+// DPoPAuthenticator checks the DPoP proofs (RFC 9449) that clients send to
+// prove they hold the key their tokens are bound to
+type DPoPAuthenticator struct {
+	verifier ports.DPoPVerifier
+	replays  ports.DPoPReplayCache
+}
+
+// NewDPoPAuthenticator creates a new DPoPAuthenticator
+func NewDPoPAuthenticator(verifier ports.DPoPVerifier, replays ports.DPoPReplayCache) *DPoPAuthenticator {
+	return &DPoPAuthenticator{
+		verifier: verifier,
+		replays:  replays,
+	}
+}
+
+// Verify checks that proof was made for req and has not been used before. It
+// returns shared.ErrInvalidDPoPProof for missing, invalid and replayed
+// proofs, and shared.ErrUseDPoPNonce when the proof needs a fresh Nonce.
+func (a *DPoPAuthenticator) Verify(ctx context.Context, proof string, req ports.DPoPProofRequest) (*ports.DPoPProof, error) {
+	if proof == "" {
+		return nil, shared.ErrInvalidDPoPProof
+	}
+
+	verified, err := a.verifier.Verify(proof, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.replays.MarkUsed(ctx, verified.ID, verified.ExpiresAt); err != nil {
+		if err == shared.ErrInvalidDPoPProof {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to record DPoP proof: %w", err)
+	}
+
+	return verified, nil
+}
+
+// Nonce returns a fresh nonce for the DPoP-Nonce response header
+func (a *DPoPAuthenticator) Nonce() string {
+	return a.verifier.Nonce()
+}
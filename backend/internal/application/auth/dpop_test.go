@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/mocks"
+)
+
+var refreshProofRequest = ports.DPoPProofRequest{Method: "POST", Path: "/auth/refresh"}
+
+func TestDPoPAuthenticator_Verify(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	verifier := mocks.NewMockDPoPVerifier(ctrl)
+	replays := mocks.NewMockDPoPReplayCache(ctrl)
+	proof := &ports.DPoPProof{ID: "jti-1", Thumbprint: "key-thumbprint", ExpiresAt: time.Now().Add(time.Minute)}
+
+	verifier.EXPECT().Verify("the-proof", refreshProofRequest).Return(proof, nil)
+	replays.EXPECT().MarkUsed(ctx, "jti-1", proof.ExpiresAt).Return(nil)
+
+	result, err := NewDPoPAuthenticator(verifier, replays).Verify(ctx, "the-proof", refreshProofRequest)
+
+	require.NoError(t, err)
+	assert.Equal(t, proof, result)
+}
+
+func TestDPoPAuthenticator_Rejected(t *testing.T) {
+	tests := []struct {
+		name  string
+		proof string
+		setup func(verifier *mocks.MockDPoPVerifier, replays *mocks.MockDPoPReplayCache)
+		err   error
+	}{
+		{"missing proof", "", func(*mocks.MockDPoPVerifier, *mocks.MockDPoPReplayCache) {}, shared.ErrInvalidDPoPProof},
+		{
+			name:  "invalid proof",
+			proof: "the-proof",
+			setup: func(verifier *mocks.MockDPoPVerifier, replays *mocks.MockDPoPReplayCache) {
+				verifier.EXPECT().Verify("the-proof", refreshProofRequest).Return(nil, shared.ErrInvalidDPoPProof)
+			},
+			err: shared.ErrInvalidDPoPProof,
+		},
+		{
+			name:  "nonce needed",
+			proof: "the-proof",
+			setup: func(verifier *mocks.MockDPoPVerifier, replays *mocks.MockDPoPReplayCache) {
+				verifier.EXPECT().Verify("the-proof", refreshProofRequest).Return(nil, shared.ErrUseDPoPNonce)
+			},
+			err: shared.ErrUseDPoPNonce,
+		},
+		{
+			name:  "replayed proof",
+			proof: "the-proof",
+			setup: func(verifier *mocks.MockDPoPVerifier, replays *mocks.MockDPoPReplayCache) {
+				verifier.EXPECT().Verify("the-proof", refreshProofRequest).Return(&ports.DPoPProof{ID: "jti-1"}, nil)
+				replays.EXPECT().MarkUsed(gomock.Any(), "jti-1", gomock.Any()).Return(shared.ErrInvalidDPoPProof)
+			},
+			err: shared.ErrInvalidDPoPProof,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			verifier := mocks.NewMockDPoPVerifier(ctrl)
+			replays := mocks.NewMockDPoPReplayCache(ctrl)
+			tt.setup(verifier, replays)
+
+			result, err := NewDPoPAuthenticator(verifier, replays).Verify(context.Background(), tt.proof, refreshProofRequest)
+
+			assert.Nil(t, result)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/events"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/mfa"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// emailUserIDPrefix distinguishes users created by email login from Google subject IDs
+const emailUserIDPrefix = "email-"
+
+// StartEmailLoginUseCase emails a single-use login link to an address
+type StartEmailLoginUseCase struct {
+	linkTokens ports.MagicLinkTokenService
+	mailer     ports.Mailer
+	linkURL    string
+	linkTTL    time.Duration
+}
+
+// NewStartEmailLoginUseCase creates a new StartEmailLoginUseCase; linkURL is
+// the public URL of the verify endpoint the link points to
+func NewStartEmailLoginUseCase(
+	linkTokens ports.MagicLinkTokenService,
+	mailer ports.Mailer,
+	linkURL string,
+	linkTTL time.Duration,
+) *StartEmailLoginUseCase {
+	return &StartEmailLoginUseCase{
+		linkTokens: linkTokens,
+		mailer:     mailer,
+		linkURL:    linkURL,
+		linkTTL:    linkTTL,
+	}
+}
+
+// Execute sends the login link. The link is sent whether or not an account
+// exists, so the response does not reveal which addresses are registered.
+func (uc *StartEmailLoginUseCase) Execute(ctx context.Context, req dto.EmailLoginRequest) error {
+	email, err := user.NewEmail(req.Email, false)
+	if err != nil {
+		return err
+	}
+
+	token, err := uc.linkTokens.Generate(email.Value(), time.Now().Add(uc.linkTTL))
+	if err != nil {
+		return fmt.Errorf("failed to generate login link: %w", err)
+	}
+
+	link, err := linkWithToken(uc.linkURL, token)
+	if err != nil {
+		return fmt.Errorf("invalid login link URL: %w", err)
+	}
+
+	message := ports.EmailMessage{
+		To:      email.Value(),
+		Subject: "Your login link",
+		Body: fmt.Sprintf("Use this link to log in. It expires in %s and can only be used once:\n\n%s\n\n"+
+			"If you did not request it, you can ignore this email.\n", uc.linkTTL, link),
+	}
+	if err := uc.mailer.Send(ctx, message); err != nil {
+		return fmt.Errorf("failed to send login link: %w", err)
+	}
+
+	log.Printf("Login link sent to %s", email.Value())
+	return nil
+}
+
+// linkWithToken adds token to the query of the URL an emailed link points to
+func linkWithToken(linkURL, token string) (string, error) {
+	link, err := url.Parse(linkURL)
+	if err != nil {
+		return "", err
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String(), nil
+}
+
+// EmailLoginUseCase logs a user in with a link sent by StartEmailLoginUseCase,
+// creating an account for addresses that do not have one yet
+type EmailLoginUseCase struct {
+	userRepo       user.Repository
+	sessionRepo    session.Repository
+	mfaRepo        mfa.Repository
+	linkTokens     ports.MagicLinkTokenService
+	usedLinks      ports.MagicLinkStore
+	tokenGenerator ports.TokenGenerator
+	eventPublisher ports.EventPublisher
+}
+
+// NewEmailLoginUseCase creates a new EmailLoginUseCase
+func NewEmailLoginUseCase(
+	userRepo user.Repository,
+	sessionRepo session.Repository,
+	mfaRepo mfa.Repository,
+	linkTokens ports.MagicLinkTokenService,
+	usedLinks ports.MagicLinkStore,
+	tokenGenerator ports.TokenGenerator,
+	eventPublisher ports.EventPublisher,
+) *EmailLoginUseCase {
+	return &EmailLoginUseCase{
+		userRepo:       userRepo,
+		sessionRepo:    sessionRepo,
+		mfaRepo:        mfaRepo,
+		linkTokens:     linkTokens,
+		usedLinks:      usedLinks,
+		tokenGenerator: tokenGenerator,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// Execute consumes the link token and issues a token pair, or an MFA-pending
+// token for users with a second factor
+func (uc *EmailLoginUseCase) Execute(ctx context.Context, token string) (*dto.LoginResponse, error) {
+	claims, err := uc.linkTokens.Verify(token, time.Now())
+	if err != nil {
+		return nil, shared.ErrInvalidMagicLink
+	}
+
+	if err := uc.usedLinks.MarkUsed(ctx, claims.ID, claims.ExpiresAt); err != nil {
+		if err == shared.ErrInvalidMagicLink {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to record login link use: %w", err)
+	}
+
+	// Following the link proves control of the address
+	email, err := user.NewEmail(claims.Email, true)
+	if err != nil {
+		return nil, shared.ErrInvalidMagicLink
+	}
+
+	existingUser, err := uc.userRepo.FindByEmail(ctx, email)
+	if err != nil && err != shared.ErrUserNotFound {
+		return nil, fmt.Errorf("failed to check user existence: %w", err)
+	}
+
+	amr := []string{ports.AMREmailLink}
+
+	var domainUser *user.User
+	if existingUser != nil {
+		// Suspended or deleted accounts cannot log in
+		if err := existingUser.EnsureActive(); err != nil {
+			log.Printf("Email login rejected for inactive user %s: %v", existingUser.ID().Value(), err)
+			return nil, err
+		}
+
+		methods, err := mfaMethods(ctx, uc.mfaRepo, existingUser)
+		if err != nil {
+			return nil, err
+		}
+		if len(methods) > 0 {
+			return mfaPending(uc.tokenGenerator, existingUser, methods, amr)
+		}
+
+		domainUser = existingUser
+		domainUser.RecordLogin()
+
+		if err := uc.userRepo.Save(ctx, domainUser); err != nil {
+			return nil, fmt.Errorf("failed to update user: %w", err)
+		}
+
+		log.Printf("Existing user logged in by email: %s (%s)", email.Value(), domainUser.ID().Value())
+	} else {
+		userID, err := newEmailUserID()
+		if err != nil {
+			return nil, err
+		}
+
+		// Name the account after the address until the user edits their profile
+		localPart, _, _ := strings.Cut(email.Value(), "@")
+		domainUser, err = user.NewUser(userID, email, user.NewProfile(localPart, ""))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create user: %w", err)
+		}
+		domainUser.RecordLogin()
+
+		if err := uc.userRepo.Save(ctx, domainUser); err != nil {
+			return nil, fmt.Errorf("failed to save new user: %w", err)
+		}
+
+		log.Printf("New user registered by email: %s (%s)", email.Value(), userID.Value())
+	}
+
+	events.Publish(ctx, uc.eventPublisher, domainUser)
+
+	accessToken, refreshToken, err := startSession(ctx, uc.sessionRepo, uc.tokenGenerator, domainUser, amr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.LoginResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		User:         dto.FromDomain(domainUser),
+		Message:      "Login successful",
+	}, nil
+}
+
+// newEmailUserID generates a random ID for a user created by email login
+func newEmailUserID() (user.UserID, error) {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return user.UserID{}, fmt.Errorf("failed to generate user ID: %w", err)
+	}
+	return user.NewUserID(emailUserIDPrefix + hex.EncodeToString(raw))
+}
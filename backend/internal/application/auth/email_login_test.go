@@ -0,0 +1,234 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+	"github.com/yuki5155/go-google-auth/internal/mocks"
+)
+
+func TestStartEmailLoginUseCase_SendsLink(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockTokens := mocks.NewMockMagicLinkTokenService(ctrl)
+	mockMailer := mocks.NewMockMailer(ctrl)
+
+	mockTokens.EXPECT().
+		Generate("user@example.com", gomock.Any()).
+		DoAndReturn(func(_ string, expiresAt time.Time) (string, error) {
+			assert.WithinDuration(t, time.Now().Add(15*time.Minute), expiresAt, time.Minute)
+			return "signed+token", nil
+		})
+	mockMailer.EXPECT().
+		Send(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, message ports.EmailMessage) error {
+			assert.Equal(t, "user@example.com", message.To)
+			assert.Contains(t, message.Body, "https://api.example.com/auth/email/verify?token="+url.QueryEscape("signed+token"))
+			return nil
+		})
+
+	useCase := NewStartEmailLoginUseCase(mockTokens, mockMailer, "https://api.example.com/auth/email/verify", 15*time.Minute)
+
+	err := useCase.Execute(ctx, dto.EmailLoginRequest{Email: " User@Example.com "})
+
+	require.NoError(t, err)
+}
+
+func TestStartEmailLoginUseCase_Errors(t *testing.T) {
+	t.Run("invalid address", func(t *testing.T) {
+		useCase := NewStartEmailLoginUseCase(nil, nil, "https://api.example.com/auth/email/verify", time.Minute)
+
+		err := useCase.Execute(context.Background(), dto.EmailLoginRequest{Email: "not-an-email"})
+
+		assert.Equal(t, shared.ErrInvalidEmail, err)
+	})
+
+	t.Run("mailer failure", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTokens := mocks.NewMockMagicLinkTokenService(ctrl)
+		mockMailer := mocks.NewMockMailer(ctrl)
+		mockTokens.EXPECT().Generate(gomock.Any(), gomock.Any()).Return("token", nil)
+		mockMailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(errors.New("connection refused"))
+
+		useCase := NewStartEmailLoginUseCase(mockTokens, mockMailer, "https://api.example.com/auth/email/verify", time.Minute)
+
+		err := useCase.Execute(context.Background(), dto.EmailLoginRequest{Email: "user@example.com"})
+
+		assert.ErrorContains(t, err, "failed to send login link")
+	})
+}
+
+func (m *authMocks) emailLoginUseCase() *EmailLoginUseCase {
+	return NewEmailLoginUseCase(m.userRepo, m.sessionRepo, m.mfaRepo, m.linkTokens, m.usedLinks, m.tokenGenerator, m.eventPublisher)
+}
+
+// expectValidLink expects "valid-link" to verify and be marked used
+func (m *authMocks) expectValidLink() {
+	linkClaims := &ports.MagicLinkClaims{
+		ID:        "link-1",
+		Email:     "user@example.com",
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+	m.linkTokens.EXPECT().Verify("valid-link", gomock.Any()).Return(linkClaims, nil)
+	m.usedLinks.EXPECT().MarkUsed(gomock.Any(), "link-1", linkClaims.ExpiresAt).Return(nil)
+}
+
+// expectSession expects a session to be started with the given amr
+func (m *authMocks) expectSession(t *testing.T, amr []string) {
+	m.tokenGenerator.EXPECT().GetRefreshTokenExpiry().Return(604800)
+	m.sessionRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+	m.tokenGenerator.EXPECT().
+		GenerateTokenPair(gomock.Any()).
+		DoAndReturn(func(info ports.UserInfo) (string, string, error) {
+			assert.Equal(t, amr, info.AMR)
+			return "mock-access-token", "mock-refresh-token", nil
+		})
+}
+
+func TestEmailLoginUseCase_NewUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	m.expectValidLink()
+
+	email, _ := user.NewEmail("user@example.com", true)
+	m.userRepo.EXPECT().FindByEmail(ctx, email).Return(nil, shared.ErrUserNotFound)
+	m.userRepo.EXPECT().Save(ctx, gomock.Any()).Return(nil)
+	m.eventPublisher.EXPECT().Publish(ctx, gomock.Any()).Return(nil)
+	m.expectSession(t, []string{ports.AMREmailLink})
+
+	result, err := m.emailLoginUseCase().Execute(ctx, "valid-link")
+
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(result.User.ID, emailUserIDPrefix))
+	assert.Equal(t, "user@example.com", result.User.Email)
+	assert.Equal(t, "user", result.User.Name)
+	assert.Equal(t, "mock-access-token", result.AccessToken)
+}
+
+func TestEmailLoginUseCase_ExistingUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	m.expectValidLink()
+
+	userID, _ := user.NewUserID("google-user-123")
+	email, _ := user.NewEmail("user@example.com", true)
+	existingUser, _ := user.NewUser(userID, email, user.NewProfile("Existing User", ""))
+	existingUser.ClearDomainEvents()
+
+	m.userRepo.EXPECT().FindByEmail(ctx, email).Return(existingUser, nil)
+	m.mfaRepo.EXPECT().FindByUserID(ctx, userID).Return(nil, shared.ErrMFANotEnabled)
+	m.userRepo.EXPECT().Save(ctx, existingUser).Return(nil)
+	m.eventPublisher.EXPECT().Publish(ctx, gomock.Any()).Return(nil)
+	m.expectSession(t, []string{ports.AMREmailLink})
+
+	result, err := m.emailLoginUseCase().Execute(ctx, "valid-link")
+
+	require.NoError(t, err)
+	assert.Equal(t, "google-user-123", result.User.ID)
+	assert.Equal(t, "Existing User", result.User.Name)
+}
+
+func TestEmailLoginUseCase_MFAUser_ReturnsPendingToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	m.expectValidLink()
+
+	userID, _ := user.NewUserID("google-user-123")
+	email, _ := user.NewEmail("user@example.com", true)
+	existingUser, _ := user.NewUser(userID, email, user.NewProfile("Existing User", ""))
+	passkey, err := user.NewPasskey([]byte("credential-1"), []byte("cose-key"), 0, "Laptop")
+	require.NoError(t, err)
+	require.NoError(t, existingUser.RegisterPasskey(passkey))
+
+	m.userRepo.EXPECT().FindByEmail(ctx, email).Return(existingUser, nil)
+	m.mfaRepo.EXPECT().FindByUserID(ctx, userID).Return(nil, shared.ErrMFANotEnabled)
+	m.tokenGenerator.EXPECT().GenerateMFAToken("google-user-123", []string{ports.AMREmailLink}).Return("mock-mfa-token", nil)
+
+	result, err := m.emailLoginUseCase().Execute(ctx, "valid-link")
+
+	require.NoError(t, err)
+	assert.True(t, result.MFARequired)
+	assert.Equal(t, []string{dto.MFAMethodPasskey}, result.MFAMethods)
+	assert.Empty(t, result.AccessToken)
+}
+
+func TestEmailLoginUseCase_SuspendedUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	m.expectValidLink()
+
+	userID, _ := user.NewUserID("google-user-123")
+	email, _ := user.NewEmail("user@example.com", true)
+	existingUser, _ := user.NewUser(userID, email, user.NewProfile("Existing User", ""))
+	require.NoError(t, existingUser.Suspend("abuse"))
+
+	m.userRepo.EXPECT().FindByEmail(ctx, email).Return(existingUser, nil)
+
+	result, err := m.emailLoginUseCase().Execute(ctx, "valid-link")
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrAccountSuspended, err)
+}
+
+func TestEmailLoginUseCase_InvalidLink(t *testing.T) {
+	t.Run("bad token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTokens := mocks.NewMockMagicLinkTokenService(ctrl)
+		mockTokens.EXPECT().Verify("forged", gomock.Any()).Return(nil, shared.ErrInvalidMagicLink)
+
+		useCase := NewEmailLoginUseCase(nil, nil, nil, mockTokens, nil, nil, nil)
+
+		result, err := useCase.Execute(context.Background(), "forged")
+
+		assert.Nil(t, result)
+		assert.Equal(t, shared.ErrInvalidMagicLink, err)
+	})
+
+	t.Run("already used", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		claims := &ports.MagicLinkClaims{ID: "link-1", Email: "user@example.com", ExpiresAt: time.Now().Add(time.Minute)}
+		mockTokens := mocks.NewMockMagicLinkTokenService(ctrl)
+		mockUsedLinks := mocks.NewMockMagicLinkStore(ctrl)
+		mockTokens.EXPECT().Verify("valid-link", gomock.Any()).Return(claims, nil)
+		mockUsedLinks.EXPECT().MarkUsed(gomock.Any(), "link-1", claims.ExpiresAt).Return(shared.ErrInvalidMagicLink)
+
+		useCase := NewEmailLoginUseCase(nil, nil, nil, mockTokens, mockUsedLinks, nil, nil)
+
+		result, err := useCase.Execute(context.Background(), "valid-link")
+
+		assert.Nil(t, result)
+		assert.Equal(t, shared.ErrInvalidMagicLink, err)
+	})
+}
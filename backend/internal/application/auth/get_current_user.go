@@ -39,25 +39,12 @@ func (uc *GetCurrentUserUseCase) Execute(ctx context.Context, accessToken string
 		return nil, fmt.Errorf("invalid access token: %w", err)
 	}
 
-	// Get user from repository
-	userID, err := user.NewUserID(claims.UserID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid user ID in token: %w", err)
-	}
-
-	domainUser, err := uc.userRepo.FindByID(ctx, userID)
-	if err != nil {
-		if err == shared.ErrUserNotFound {
-			return nil, shared.ErrUnauthorized
-		}
-		return nil, fmt.Errorf("failed to retrieve user: %w", err)
-	}
-
-	response := dto.FromDomain(domainUser)
-	return &response, nil
+	return uc.ExecuteFromClaims(ctx, claims)
 }
 
-// ExecuteFromClaims retrieves user information using token claims directly (for middleware use)
+// ExecuteFromClaims retrieves the current user's information using token claims
+// already validated by the auth middleware. The user is always loaded from the
+// repository so profile changes and deletions are reflected immediately.
 func (uc *GetCurrentUserUseCase) ExecuteFromClaims(ctx context.Context, claims *ports.TokenClaims) (*dto.UserResponse, error) {
 	if claims == nil {
 		return nil, shared.ErrMissingToken
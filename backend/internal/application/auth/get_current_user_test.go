@@ -181,6 +181,43 @@ func TestGetCurrentUserUseCase_ExecuteFromClaims_Success(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "test-user-123", result.ID)
 	assert.Equal(t, "test@example.com", result.Email)
+	assert.Equal(t, []string{"user"}, result.Roles)
+	assert.Equal(t, "active", result.Status)
+	assert.NotNil(t, result.CreatedAt)
+	assert.NotNil(t, result.UpdatedAt)
+}
+
+func TestGetCurrentUserUseCase_ExecuteFromClaims_ReturnsFreshProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+
+	userID, _ := user.NewUserID("test-user-123")
+	email, _ := user.NewEmail("test@example.com", true)
+	domainUser, _ := user.NewUser(userID, email, user.NewProfile("Renamed User", "https://example.com/new.jpg"))
+
+	mockRepo.EXPECT().
+		FindByID(ctx, userID).
+		Return(domainUser, nil)
+
+	useCase := NewGetCurrentUserUseCase(mockRepo, mockTokenGen)
+
+	// Claims still carry the name from when the token was issued
+	claims := &ports.TokenClaims{
+		UserID:  "test-user-123",
+		Email:   "test@example.com",
+		Name:    "Old Name",
+		Picture: "https://example.com/old.jpg",
+	}
+
+	result, err := useCase.ExecuteFromClaims(ctx, claims)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed User", result.Name)
+	assert.Equal(t, "https://example.com/new.jpg", result.Picture)
 }
 
 func TestGetCurrentUserUseCase_ExecuteFromClaims_NilClaims(t *testing.T) {
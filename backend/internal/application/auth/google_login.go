@@ -4,9 +4,14 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/events"
 	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/mfa"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
 	"github.com/yuki5155/go-google-auth/internal/domain/shared"
 	"github.com/yuki5155/go-google-auth/internal/domain/user"
 )
@@ -14,28 +19,44 @@ import (
 // GoogleLoginUseCase handles Google OAuth login flow
 type GoogleLoginUseCase struct {
 	userRepo       user.Repository
+	sessionRepo    session.Repository
+	mfaRepo        mfa.Repository
 	oauthValidator ports.OAuthValidator
 	tokenGenerator ports.TokenGenerator
+	eventPublisher ports.EventPublisher
+	loginAlerts    *LoginAlertService
 	clientID       string
 }
 
 // NewGoogleLoginUseCase creates a new GoogleLoginUseCase
+// loginAlerts may be nil to disable new-device detection.
 func NewGoogleLoginUseCase(
 	userRepo user.Repository,
+	sessionRepo session.Repository,
+	mfaRepo mfa.Repository,
 	oauthValidator ports.OAuthValidator,
 	tokenGenerator ports.TokenGenerator,
+	eventPublisher ports.EventPublisher,
+	loginAlerts *LoginAlertService,
 	clientID string,
 ) *GoogleLoginUseCase {
 	return &GoogleLoginUseCase{
 		userRepo:       userRepo,
+		sessionRepo:    sessionRepo,
+		mfaRepo:        mfaRepo,
 		oauthValidator: oauthValidator,
 		tokenGenerator: tokenGenerator,
+		eventPublisher: eventPublisher,
+		loginAlerts:    loginAlerts,
 		clientID:       clientID,
 	}
 }
 
-// Execute performs the Google login flow
-func (uc *GoogleLoginUseCase) Execute(ctx context.Context, credential string) (*dto.LoginResponse, error) {
+// Execute performs the Google login flow. Users with a confirmed second factor
+// get an MFA-pending token instead of a token pair; see VerifyMFAUseCase.
+// Logins from a device or location the user has not used before are
+// reported through the LoginAlertService.
+func (uc *GoogleLoginUseCase) Execute(ctx context.Context, credential string, client dto.ClientInfo) (*dto.LoginResponse, error) {
 	// Validate the Google ID token
 	oauthUser, err := uc.oauthValidator.ValidateToken(ctx, credential, uc.clientID)
 	if err != nil {
@@ -62,16 +83,38 @@ func (uc *GoogleLoginUseCase) Execute(ctx context.Context, credential string) (*
 	profile := user.NewProfile(oauthUser.Name, oauthUser.Picture)
 
 	// Check if user already exists
-	existingUser, err := uc.userRepo.FindByID(ctx, userID)
-	if err != nil && err != shared.ErrUserNotFound {
-		return nil, fmt.Errorf("failed to check user existence: %w", err)
+	existingUser, err := uc.findUser(ctx, userID, email)
+	if err != nil {
+		return nil, err
 	}
 
 	var domainUser *user.User
 	if existingUser != nil {
-		// User exists - update and record login
+		// Suspended or deleted accounts cannot log in
+		if err := existingUser.EnsureActive(); err != nil {
+			log.Printf("Login rejected for inactive user %s: %v", userID.Value(), err)
+			return nil, err
+		}
+
+		// Users with a second factor must verify it before tokens are issued
+		methods, err := mfaMethods(ctx, uc.mfaRepo, existingUser)
+		if err != nil {
+			return nil, err
+		}
+		if len(methods) > 0 {
+			return mfaPending(uc.tokenGenerator, existingUser, methods, []string{ports.AMRFederated})
+		}
+
+		// User exists - sync provider profile (keeping local edits) and record login
 		domainUser = existingUser
-		domainUser.UpdateProfile(profile)
+		if domainUser.ID() != userID {
+			// An email login user signing in with Google for the first time
+			if err := domainUser.LinkGoogleAccount(userID.Value()); err != nil {
+				return nil, err
+			}
+			log.Printf("Google account %s linked to user %s", userID.Value(), domainUser.ID().Value())
+		}
+		domainUser.SyncProfile(profile)
 		domainUser.RecordLogin()
 
 		if err := uc.userRepo.Save(ctx, domainUser); err != nil {
@@ -80,11 +123,12 @@ func (uc *GoogleLoginUseCase) Execute(ctx context.Context, credential string) (*
 
 		log.Printf("Existing user logged in: %s (%s)", email.Value(), userID.Value())
 	} else {
-		// New user - create and save
+		// New user - create, record the first login and save
 		domainUser, err = user.NewUser(userID, email, profile)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create user: %w", err)
 		}
+		domainUser.RecordLogin()
 
 		if err := uc.userRepo.Save(ctx, domainUser); err != nil {
 			return nil, fmt.Errorf("failed to save new user: %w", err)
@@ -93,18 +137,26 @@ func (uc *GoogleLoginUseCase) Execute(ctx context.Context, credential string) (*
 		log.Printf("New user registered: %s (%s)", email.Value(), userID.Value())
 	}
 
-	// Generate JWT tokens
-	userInfo := ports.UserInfo{
-		UserID:  domainUser.ID().Value(),
-		Email:   domainUser.Email().Value(),
-		Name:    domainUser.Profile().Name(),
-		Picture: domainUser.Profile().Picture(),
+	events.Publish(ctx, uc.eventPublisher, domainUser)
+
+	var device session.Device
+	if uc.loginAlerts != nil {
+		device = uc.loginAlerts.Fingerprint(client)
 	}
 
-	accessToken, refreshToken, err := uc.tokenGenerator.GenerateTokenPair(userInfo)
+	loginSession, err := newLoginSession(ctx, uc.sessionRepo, uc.tokenGenerator, domainUser, device)
 	if err != nil {
-		log.Printf("Failed to generate JWT tokens: %v", err)
-		return nil, fmt.Errorf("failed to generate authentication tokens: %w", err)
+		return nil, err
+	}
+
+	if uc.loginAlerts != nil {
+		uc.loginAlerts.Review(ctx, domainUser, loginSession)
+	}
+
+	amr := []string{ports.AMRFederated}
+	accessToken, refreshToken, err := issueSessionTokens(uc.tokenGenerator, domainUser, loginSession.ID().Value(), amr, time.Now())
+	if err != nil {
+		return nil, err
 	}
 
 	// Return response
@@ -115,3 +167,158 @@ func (uc *GoogleLoginUseCase) Execute(ctx context.Context, credential string) (*
 		Message:      "Login successful",
 	}, nil
 }
+
+// findUser returns the user a Google account signs in as, or nil for a new
+// user: the user created by Google sign-in, or the user created by email
+// login that the account was linked to. An email login user without a
+// linked Google account is found by their verified address and linked once
+// the login completes; any other user with the address is a conflict, so a
+// reassigned address never signs in to someone else's account.
+func (uc *GoogleLoginUseCase) findUser(ctx context.Context, userID user.UserID, email user.Email) (*user.User, error) {
+	existingUser, err := uc.userRepo.FindByID(ctx, userID)
+	if err == nil {
+		return existingUser, nil
+	}
+	if err != shared.ErrUserNotFound {
+		return nil, fmt.Errorf("failed to check user existence: %w", err)
+	}
+
+	existingUser, err = uc.userRepo.FindByGoogleID(ctx, userID.Value())
+	if err == nil {
+		return existingUser, nil
+	}
+	if err != shared.ErrUserNotFound {
+		return nil, fmt.Errorf("failed to check user existence: %w", err)
+	}
+
+	existingUser, err = uc.userRepo.FindByEmail(ctx, email)
+	if err == shared.ErrUserNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check user existence: %w", err)
+	}
+	if !isEmailLoginUser(existingUser) || existingUser.GoogleID() != "" {
+		log.Printf("Google account %s rejected: %s belongs to user %s", userID.Value(), email.Value(), existingUser.ID().Value())
+		return nil, shared.ErrGoogleAccountConflict
+	}
+	if !existingUser.Email().IsVerified() {
+		return nil, shared.ErrUnverifiedEmail
+	}
+
+	return existingUser, nil
+}
+
+// isEmailLoginUser reports whether a user was created by email login rather
+// than by Google sign-in
+func isEmailLoginUser(u *user.User) bool {
+	return strings.HasPrefix(u.ID().Value(), emailUserIDPrefix)
+}
+
+// mfaMethods returns the second factors the user can complete a login with:
+// a confirmed TOTP enrollment and any registered passkeys
+func mfaMethods(ctx context.Context, mfaRepo mfa.Repository, existingUser *user.User) ([]string, error) {
+	var methods []string
+
+	enrollment, err := mfaRepo.FindByUserID(ctx, existingUser.ID())
+	if err != nil && err != shared.ErrMFANotEnabled {
+		return nil, fmt.Errorf("failed to check MFA enrollment: %w", err)
+	}
+	if enrollment != nil && enrollment.IsConfirmed() {
+		methods = append(methods, dto.MFAMethodTOTP)
+	}
+
+	if existingUser.Passkeys().Len() > 0 {
+		methods = append(methods, dto.MFAMethodPasskey)
+	}
+
+	return methods, nil
+}
+
+// mfaPending returns the first-step response carrying an MFA-pending token
+// that records the first factor's amr
+func mfaPending(tokenGenerator ports.TokenGenerator, domainUser *user.User, methods []string, amr []string) (*dto.LoginResponse, error) {
+	mfaToken, err := tokenGenerator.GenerateMFAToken(domainUser.ID().Value(), amr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate MFA token: %w", err)
+	}
+
+	log.Printf("MFA required for user %s", domainUser.ID().Value())
+	return &dto.LoginResponse{
+		MFARequired: true,
+		MFAMethods:  methods,
+		MFAToken:    mfaToken,
+		Message:     "Multi-factor authentication required",
+	}, nil
+}
+
+// startSession starts a login session for the user and issues its token pair
+func startSession(
+	ctx context.Context,
+	sessionRepo session.Repository,
+	tokenGenerator ports.TokenGenerator,
+	domainUser *user.User,
+	amr []string,
+) (accessToken, refreshToken string, err error) {
+	loginSession, err := newLoginSession(ctx, sessionRepo, tokenGenerator, domainUser, session.Device{})
+	if err != nil {
+		return "", "", err
+	}
+
+	return issueSessionTokens(tokenGenerator, domainUser, loginSession.ID().Value(), amr, time.Now())
+}
+
+// newLoginSession starts and saves a new login session for the user,
+// recording the device it was started from when known
+func newLoginSession(
+	ctx context.Context,
+	sessionRepo session.Repository,
+	tokenGenerator ports.TokenGenerator,
+	domainUser *user.User,
+	device session.Device,
+) (*session.Session, error) {
+	refreshExpiry := time.Duration(tokenGenerator.GetRefreshTokenExpiry()) * time.Second
+	loginSession, err := session.NewSession(domainUser.ID(), time.Now().Add(refreshExpiry))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+	loginSession.RecordDevice(device)
+
+	if err := sessionRepo.Save(ctx, loginSession); err != nil {
+		return nil, fmt.Errorf("failed to save session: %w", err)
+	}
+
+	return loginSession, nil
+}
+
+// issueSessionTokens issues a token pair for an existing login session,
+// recording an authentication with the amr methods at authTime
+func issueSessionTokens(
+	tokenGenerator ports.TokenGenerator,
+	domainUser *user.User,
+	sessionID string,
+	amr []string,
+	authTime time.Time,
+) (accessToken, refreshToken string, err error) {
+	userInfo := ports.UserInfo{
+		UserID:    domainUser.ID().Value(),
+		Email:     domainUser.Email().Value(),
+		Name:      domainUser.Profile().Name(),
+		Picture:   domainUser.Profile().Picture(),
+		SessionID: sessionID,
+		AMR:       amr,
+		AuthTime:  authTime,
+		ACR:       ports.ACRForAMR(amr),
+
+		// Signing in picks the organization the user last selected
+		OrganizationID: domainUser.ActiveOrganizationID(),
+	}
+
+	accessToken, refreshToken, err = tokenGenerator.GenerateTokenPair(userInfo)
+	if err != nil {
+		log.Printf("Failed to generate JWT tokens: %v", err)
+		return "", "", fmt.Errorf("failed to generate authentication tokens: %w", err)
+	}
+
+	return accessToken, refreshToken, nil
+}
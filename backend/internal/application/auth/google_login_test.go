@@ -9,7 +9,10 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
 	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/mfa"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
 	"github.com/yuki5155/go-google-auth/internal/domain/shared"
 	"github.com/yuki5155/go-google-auth/internal/domain/user"
 	"github.com/yuki5155/go-google-auth/internal/mocks"
@@ -23,6 +26,9 @@ func TestGoogleLoginUseCase_NewUser_Success(t *testing.T) {
 	mockRepo := mocks.NewMockRepository(ctrl)
 	mockOAuth := mocks.NewMockOAuthValidator(ctrl)
 	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
 
 	oauthInfo := &ports.OAuthUserInfo{
 		UserID:        "google-user-123",
@@ -42,17 +48,37 @@ func TestGoogleLoginUseCase_NewUser_Success(t *testing.T) {
 		FindByID(ctx, userID).
 		Return(nil, shared.ErrUserNotFound)
 
+	mockRepo.EXPECT().
+		FindByGoogleID(ctx, "google-user-123").
+		Return(nil, shared.ErrUserNotFound)
+
+	mockRepo.EXPECT().
+		FindByEmail(ctx, gomock.Any()).
+		Return(nil, shared.ErrUserNotFound)
+
 	mockRepo.EXPECT().
 		Save(ctx, gomock.Any()).
 		Return(nil)
 
+	mockPublisher.EXPECT().
+		Publish(ctx, gomock.Any()).
+		Return(nil)
+
+	mockTokenGen.EXPECT().
+		GetRefreshTokenExpiry().
+		Return(604800)
+
+	mockSessionRepo.EXPECT().
+		Save(ctx, gomock.Any()).
+		Return(nil)
+
 	mockTokenGen.EXPECT().
 		GenerateTokenPair(gomock.Any()).
 		Return("mock-access-token", "mock-refresh-token", nil)
 
-	useCase := NewGoogleLoginUseCase(mockRepo, mockOAuth, mockTokenGen, "test-client-id")
+	useCase := NewGoogleLoginUseCase(mockRepo, mockSessionRepo, mockMFARepo, mockOAuth, mockTokenGen, mockPublisher, nil, "test-client-id")
 
-	result, err := useCase.Execute(ctx, "valid-google-token")
+	result, err := useCase.Execute(ctx, "valid-google-token", dto.ClientInfo{})
 
 	require.NoError(t, err)
 	assert.Equal(t, "Login successful", result.Message)
@@ -60,6 +86,8 @@ func TestGoogleLoginUseCase_NewUser_Success(t *testing.T) {
 	assert.Equal(t, "newuser@example.com", result.User.Email)
 	assert.Equal(t, "New User", result.User.Name)
 	assert.Equal(t, "https://example.com/photo.jpg", result.User.Picture)
+	assert.Equal(t, 1, result.User.LoginCount)
+	assert.NotNil(t, result.User.LastLoginAt)
 	assert.Equal(t, "mock-access-token", result.AccessToken)
 	assert.Equal(t, "mock-refresh-token", result.RefreshToken)
 }
@@ -72,6 +100,9 @@ func TestGoogleLoginUseCase_ExistingUser_Success(t *testing.T) {
 	mockRepo := mocks.NewMockRepository(ctrl)
 	mockOAuth := mocks.NewMockOAuthValidator(ctrl)
 	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
 
 	// Pre-create existing user
 	userID, _ := user.NewUserID("google-user-123")
@@ -96,17 +127,33 @@ func TestGoogleLoginUseCase_ExistingUser_Success(t *testing.T) {
 		FindByID(ctx, userID).
 		Return(existingUser, nil)
 
+	mockMFARepo.EXPECT().
+		FindByUserID(ctx, userID).
+		Return(nil, shared.ErrMFANotEnabled)
+
 	mockRepo.EXPECT().
 		Save(ctx, gomock.Any()).
 		Return(nil)
 
+	mockPublisher.EXPECT().
+		Publish(ctx, gomock.Any()).
+		Return(nil)
+
+	mockTokenGen.EXPECT().
+		GetRefreshTokenExpiry().
+		Return(604800)
+
+	mockSessionRepo.EXPECT().
+		Save(ctx, gomock.Any()).
+		Return(nil)
+
 	mockTokenGen.EXPECT().
 		GenerateTokenPair(gomock.Any()).
 		Return("mock-access-token", "mock-refresh-token", nil)
 
-	useCase := NewGoogleLoginUseCase(mockRepo, mockOAuth, mockTokenGen, "test-client-id")
+	useCase := NewGoogleLoginUseCase(mockRepo, mockSessionRepo, mockMFARepo, mockOAuth, mockTokenGen, mockPublisher, nil, "test-client-id")
 
-	result, err := useCase.Execute(ctx, "valid-google-token")
+	result, err := useCase.Execute(ctx, "valid-google-token", dto.ClientInfo{})
 
 	require.NoError(t, err)
 	assert.Equal(t, "Login successful", result.Message)
@@ -114,6 +161,176 @@ func TestGoogleLoginUseCase_ExistingUser_Success(t *testing.T) {
 	assert.Equal(t, "existing@example.com", result.User.Email)
 	assert.Equal(t, "Updated Name", result.User.Name)
 	assert.Equal(t, "https://example.com/new.jpg", result.User.Picture)
+	assert.Equal(t, 1, result.User.LoginCount)
+	assert.Len(t, result.User.RecentLogins, 1)
+}
+
+func TestGoogleLoginUseCase_EmailUser_SignsInToSameUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockOAuth := mocks.NewMockOAuthValidator(ctrl)
+	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
+
+	// The user first signed in with an email link
+	emailUserID, _ := user.NewUserID("email-0123456789abcdef")
+	email, _ := user.NewEmail("user@example.com", true)
+	emailUser, _ := user.NewUser(emailUserID, email, user.NewProfile("", ""))
+	emailUser.ClearDomainEvents()
+
+	googleUserID, _ := user.NewUserID("google-user-123")
+	oauthInfo := &ports.OAuthUserInfo{
+		UserID:        "google-user-123",
+		Email:         "user@example.com",
+		EmailVerified: true,
+		Name:          "Google Name",
+	}
+
+	mockOAuth.EXPECT().
+		ValidateToken(ctx, "valid-google-token", "test-client-id").
+		Return(oauthInfo, nil)
+
+	mockRepo.EXPECT().
+		FindByID(ctx, googleUserID).
+		Return(nil, shared.ErrUserNotFound)
+
+	mockRepo.EXPECT().
+		FindByGoogleID(ctx, "google-user-123").
+		Return(nil, shared.ErrUserNotFound)
+
+	mockRepo.EXPECT().
+		FindByEmail(ctx, email).
+		Return(emailUser, nil)
+
+	mockMFARepo.EXPECT().
+		FindByUserID(ctx, emailUserID).
+		Return(nil, shared.ErrMFANotEnabled)
+
+	mockRepo.EXPECT().
+		Save(ctx, emailUser).
+		Return(nil)
+
+	mockPublisher.EXPECT().
+		Publish(ctx, gomock.Any()).
+		Return(nil).
+		AnyTimes()
+
+	mockTokenGen.EXPECT().
+		GetRefreshTokenExpiry().
+		Return(604800)
+
+	mockSessionRepo.EXPECT().
+		Save(ctx, gomock.Any()).
+		Return(nil)
+
+	mockTokenGen.EXPECT().
+		GenerateTokenPair(gomock.Any()).
+		DoAndReturn(func(info ports.UserInfo) (string, string, error) {
+			assert.Equal(t, "email-0123456789abcdef", info.UserID)
+			return "mock-access-token", "mock-refresh-token", nil
+		})
+
+	useCase := NewGoogleLoginUseCase(mockRepo, mockSessionRepo, mockMFARepo, mockOAuth, mockTokenGen, mockPublisher, nil, "test-client-id")
+
+	result, err := useCase.Execute(ctx, "valid-google-token", dto.ClientInfo{})
+
+	require.NoError(t, err)
+	assert.Equal(t, "email-0123456789abcdef", result.User.ID)
+	assert.Equal(t, "Google Name", result.User.Name)
+	assert.Equal(t, 1, result.User.LoginCount)
+	// The Google account is linked, so it keeps signing in to this user
+	assert.Equal(t, "google-user-123", emailUser.GoogleID())
+}
+
+func TestGoogleLoginUseCase_LinkedEmailUser_FoundByGoogleID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockOAuth := mocks.NewMockOAuthValidator(ctrl)
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+
+	emailUserID, _ := user.NewUserID("email-0123456789abcdef")
+	email, _ := user.NewEmail("old@example.com", true)
+	emailUser, _ := user.NewUser(emailUserID, email, user.NewProfile("", ""))
+	require.NoError(t, emailUser.LinkGoogleAccount("google-user-123"))
+
+	// The Google account's address changed since it was linked
+	mockOAuth.EXPECT().
+		ValidateToken(ctx, "valid-google-token", "test-client-id").
+		Return(&ports.OAuthUserInfo{UserID: "google-user-123", Email: "new@example.com", EmailVerified: true}, nil)
+	mockRepo.EXPECT().
+		FindByID(ctx, gomock.Any()).
+		Return(nil, shared.ErrUserNotFound)
+	mockRepo.EXPECT().
+		FindByGoogleID(ctx, "google-user-123").
+		Return(emailUser, nil)
+	mockMFARepo.EXPECT().
+		FindByUserID(ctx, emailUserID).
+		Return(nil, shared.ErrMFANotEnabled)
+	mockRepo.EXPECT().Save(ctx, emailUser).Return(errors.New("database error"))
+
+	useCase := NewGoogleLoginUseCase(mockRepo, nil, mockMFARepo, mockOAuth, nil, nil, nil, "test-client-id")
+
+	_, err := useCase.Execute(ctx, "valid-google-token", dto.ClientInfo{})
+
+	// Reaching Save shows the linked user was found without an email lookup
+	assert.ErrorContains(t, err, "database error")
+}
+
+func TestGoogleLoginUseCase_EmailBelongsToAnotherAccount(t *testing.T) {
+	googleUserID, _ := user.NewUserID("google-user-999")
+	linkedUserID, _ := user.NewUserID("email-0123456789abcdef")
+	email, _ := user.NewEmail("user@example.com", true)
+
+	googleUser, _ := user.NewUser(googleUserID, email, user.NewProfile("", ""))
+	linkedUser, _ := user.NewUser(linkedUserID, email, user.NewProfile("", ""))
+	require.NoError(t, linkedUser.LinkGoogleAccount("google-user-999"))
+
+	tests := []struct {
+		name     string
+		existing *user.User
+	}{
+		{"created by Google sign-in", googleUser},
+		{"linked to another Google account", linkedUser},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			mockRepo := mocks.NewMockRepository(ctrl)
+			mockOAuth := mocks.NewMockOAuthValidator(ctrl)
+
+			mockOAuth.EXPECT().
+				ValidateToken(ctx, "valid-google-token", "test-client-id").
+				Return(&ports.OAuthUserInfo{UserID: "google-user-123", Email: "user@example.com", EmailVerified: true}, nil)
+			mockRepo.EXPECT().
+				FindByID(ctx, gomock.Any()).
+				Return(nil, shared.ErrUserNotFound)
+			mockRepo.EXPECT().
+				FindByGoogleID(ctx, "google-user-123").
+				Return(nil, shared.ErrUserNotFound)
+			mockRepo.EXPECT().
+				FindByEmail(ctx, email).
+				Return(tt.existing, nil)
+
+			useCase := NewGoogleLoginUseCase(mockRepo, nil, nil, mockOAuth, nil, nil, nil, "test-client-id")
+
+			result, err := useCase.Execute(ctx, "valid-google-token", dto.ClientInfo{})
+
+			assert.Equal(t, shared.ErrGoogleAccountConflict, err)
+			assert.Nil(t, result)
+		})
+	}
 }
 
 func TestGoogleLoginUseCase_InvalidToken(t *testing.T) {
@@ -124,14 +341,17 @@ func TestGoogleLoginUseCase_InvalidToken(t *testing.T) {
 	mockRepo := mocks.NewMockRepository(ctrl)
 	mockOAuth := mocks.NewMockOAuthValidator(ctrl)
 	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
 
 	mockOAuth.EXPECT().
 		ValidateToken(ctx, "invalid-token", "test-client-id").
 		Return(nil, errors.New("invalid token"))
 
-	useCase := NewGoogleLoginUseCase(mockRepo, mockOAuth, mockTokenGen, "test-client-id")
+	useCase := NewGoogleLoginUseCase(mockRepo, mockSessionRepo, mockMFARepo, mockOAuth, mockTokenGen, mockPublisher, nil, "test-client-id")
 
-	result, err := useCase.Execute(ctx, "invalid-token")
+	result, err := useCase.Execute(ctx, "invalid-token", dto.ClientInfo{})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -146,6 +366,9 @@ func TestGoogleLoginUseCase_UnverifiedEmail(t *testing.T) {
 	mockRepo := mocks.NewMockRepository(ctrl)
 	mockOAuth := mocks.NewMockOAuthValidator(ctrl)
 	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
 
 	oauthInfo := &ports.OAuthUserInfo{
 		UserID:        "google-user-123",
@@ -159,9 +382,9 @@ func TestGoogleLoginUseCase_UnverifiedEmail(t *testing.T) {
 		ValidateToken(ctx, "valid-token", "test-client-id").
 		Return(oauthInfo, nil)
 
-	useCase := NewGoogleLoginUseCase(mockRepo, mockOAuth, mockTokenGen, "test-client-id")
+	useCase := NewGoogleLoginUseCase(mockRepo, mockSessionRepo, mockMFARepo, mockOAuth, mockTokenGen, mockPublisher, nil, "test-client-id")
 
-	result, err := useCase.Execute(ctx, "valid-token")
+	result, err := useCase.Execute(ctx, "valid-token", dto.ClientInfo{})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -176,6 +399,9 @@ func TestGoogleLoginUseCase_TokenGenerationFails(t *testing.T) {
 	mockRepo := mocks.NewMockRepository(ctrl)
 	mockOAuth := mocks.NewMockOAuthValidator(ctrl)
 	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
 
 	oauthInfo := &ports.OAuthUserInfo{
 		UserID:        "google-user-123",
@@ -195,17 +421,37 @@ func TestGoogleLoginUseCase_TokenGenerationFails(t *testing.T) {
 		FindByID(ctx, userID).
 		Return(nil, shared.ErrUserNotFound)
 
+	mockRepo.EXPECT().
+		FindByGoogleID(ctx, "google-user-123").
+		Return(nil, shared.ErrUserNotFound)
+
+	mockRepo.EXPECT().
+		FindByEmail(ctx, gomock.Any()).
+		Return(nil, shared.ErrUserNotFound)
+
 	mockRepo.EXPECT().
 		Save(ctx, gomock.Any()).
 		Return(nil)
 
+	mockPublisher.EXPECT().
+		Publish(ctx, gomock.Any()).
+		Return(nil)
+
+	mockTokenGen.EXPECT().
+		GetRefreshTokenExpiry().
+		Return(604800)
+
+	mockSessionRepo.EXPECT().
+		Save(ctx, gomock.Any()).
+		Return(nil)
+
 	mockTokenGen.EXPECT().
 		GenerateTokenPair(gomock.Any()).
 		Return("", "", errors.New("token generation failed"))
 
-	useCase := NewGoogleLoginUseCase(mockRepo, mockOAuth, mockTokenGen, "test-client-id")
+	useCase := NewGoogleLoginUseCase(mockRepo, mockSessionRepo, mockMFARepo, mockOAuth, mockTokenGen, mockPublisher, nil, "test-client-id")
 
-	result, err := useCase.Execute(ctx, "valid-token")
+	result, err := useCase.Execute(ctx, "valid-token", dto.ClientInfo{})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -220,6 +466,9 @@ func TestGoogleLoginUseCase_RepositorySaveFails(t *testing.T) {
 	mockRepo := mocks.NewMockRepository(ctrl)
 	mockOAuth := mocks.NewMockOAuthValidator(ctrl)
 	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
 
 	oauthInfo := &ports.OAuthUserInfo{
 		UserID:        "google-user-123",
@@ -239,13 +488,21 @@ func TestGoogleLoginUseCase_RepositorySaveFails(t *testing.T) {
 		FindByID(ctx, userID).
 		Return(nil, shared.ErrUserNotFound)
 
+	mockRepo.EXPECT().
+		FindByGoogleID(ctx, "google-user-123").
+		Return(nil, shared.ErrUserNotFound)
+
+	mockRepo.EXPECT().
+		FindByEmail(ctx, gomock.Any()).
+		Return(nil, shared.ErrUserNotFound)
+
 	mockRepo.EXPECT().
 		Save(ctx, gomock.Any()).
 		Return(errors.New("database error"))
 
-	useCase := NewGoogleLoginUseCase(mockRepo, mockOAuth, mockTokenGen, "test-client-id")
+	useCase := NewGoogleLoginUseCase(mockRepo, mockSessionRepo, mockMFARepo, mockOAuth, mockTokenGen, mockPublisher, nil, "test-client-id")
 
-	result, err := useCase.Execute(ctx, "valid-token")
+	result, err := useCase.Execute(ctx, "valid-token", dto.ClientInfo{})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -260,6 +517,9 @@ func TestGoogleLoginUseCase_RepositoryUpdateFails(t *testing.T) {
 	mockRepo := mocks.NewMockRepository(ctrl)
 	mockOAuth := mocks.NewMockOAuthValidator(ctrl)
 	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
 
 	// Pre-create existing user
 	userID, _ := user.NewUserID("google-user-123")
@@ -283,13 +543,17 @@ func TestGoogleLoginUseCase_RepositoryUpdateFails(t *testing.T) {
 		FindByID(ctx, userID).
 		Return(existingUser, nil)
 
+	mockMFARepo.EXPECT().
+		FindByUserID(ctx, userID).
+		Return(nil, shared.ErrMFANotEnabled)
+
 	mockRepo.EXPECT().
 		Save(ctx, gomock.Any()).
 		Return(errors.New("database update error"))
 
-	useCase := NewGoogleLoginUseCase(mockRepo, mockOAuth, mockTokenGen, "test-client-id")
+	useCase := NewGoogleLoginUseCase(mockRepo, mockSessionRepo, mockMFARepo, mockOAuth, mockTokenGen, mockPublisher, nil, "test-client-id")
 
-	result, err := useCase.Execute(ctx, "valid-token")
+	result, err := useCase.Execute(ctx, "valid-token", dto.ClientInfo{})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -304,6 +568,9 @@ func TestGoogleLoginUseCase_RepositoryFindError(t *testing.T) {
 	mockRepo := mocks.NewMockRepository(ctrl)
 	mockOAuth := mocks.NewMockOAuthValidator(ctrl)
 	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
 
 	oauthInfo := &ports.OAuthUserInfo{
 		UserID:        "google-user-123",
@@ -323,11 +590,225 @@ func TestGoogleLoginUseCase_RepositoryFindError(t *testing.T) {
 		FindByID(ctx, userID).
 		Return(nil, errors.New("database connection error"))
 
-	useCase := NewGoogleLoginUseCase(mockRepo, mockOAuth, mockTokenGen, "test-client-id")
+	useCase := NewGoogleLoginUseCase(mockRepo, mockSessionRepo, mockMFARepo, mockOAuth, mockTokenGen, mockPublisher, nil, "test-client-id")
 
-	result, err := useCase.Execute(ctx, "valid-token")
+	result, err := useCase.Execute(ctx, "valid-token", dto.ClientInfo{})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
 	assert.Contains(t, err.Error(), "failed to check user existence")
 }
+
+func TestGoogleLoginUseCase_SuspendedUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockOAuth := mocks.NewMockOAuthValidator(ctrl)
+	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
+
+	userID, _ := user.NewUserID("google-user-123")
+	email, _ := user.NewEmail("existing@example.com", true)
+	profile := user.NewProfile("Existing User", "")
+	existingUser, _ := user.NewUser(userID, email, profile)
+	require.NoError(t, existingUser.Suspend("abuse"))
+
+	oauthInfo := &ports.OAuthUserInfo{
+		UserID:        "google-user-123",
+		Email:         "existing@example.com",
+		EmailVerified: true,
+		Name:          "Existing User",
+	}
+
+	mockOAuth.EXPECT().
+		ValidateToken(ctx, "valid-token", "test-client-id").
+		Return(oauthInfo, nil)
+
+	mockRepo.EXPECT().
+		FindByID(ctx, userID).
+		Return(existingUser, nil)
+
+	useCase := NewGoogleLoginUseCase(mockRepo, mockSessionRepo, mockMFARepo, mockOAuth, mockTokenGen, mockPublisher, nil, "test-client-id")
+
+	result, err := useCase.Execute(ctx, "valid-token", dto.ClientInfo{})
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrAccountSuspended, err)
+}
+
+func TestGoogleLoginUseCase_MFAEnrolledUser_ReturnsPendingToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockOAuth := mocks.NewMockOAuthValidator(ctrl)
+	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
+
+	userID, _ := user.NewUserID("google-user-123")
+	email, _ := user.NewEmail("existing@example.com", true)
+	existingUser, _ := user.NewUser(userID, email, user.NewProfile("Existing User", ""))
+
+	enrollment, err := mfa.NewEnrollment(userID, []byte("12345678901234567890"))
+	require.NoError(t, err)
+	_, err = enrollment.Confirm(1)
+	require.NoError(t, err)
+
+	oauthInfo := &ports.OAuthUserInfo{
+		UserID:        "google-user-123",
+		Email:         "existing@example.com",
+		EmailVerified: true,
+		Name:          "Existing User",
+	}
+
+	mockOAuth.EXPECT().
+		ValidateToken(ctx, "valid-token", "test-client-id").
+		Return(oauthInfo, nil)
+
+	mockRepo.EXPECT().
+		FindByID(ctx, userID).
+		Return(existingUser, nil)
+
+	mockMFARepo.EXPECT().
+		FindByUserID(ctx, userID).
+		Return(enrollment, nil)
+
+	mockTokenGen.EXPECT().
+		GenerateMFAToken("google-user-123", []string{ports.AMRFederated}).
+		Return("mock-mfa-token", nil)
+
+	// No login is recorded, no session is started and no token pair is issued
+	useCase := NewGoogleLoginUseCase(mockRepo, mockSessionRepo, mockMFARepo, mockOAuth, mockTokenGen, mockPublisher, nil, "test-client-id")
+
+	result, err := useCase.Execute(ctx, "valid-token", dto.ClientInfo{})
+
+	require.NoError(t, err)
+	assert.True(t, result.MFARequired)
+	assert.Equal(t, []string{dto.MFAMethodTOTP}, result.MFAMethods)
+	assert.Equal(t, "mock-mfa-token", result.MFAToken)
+	assert.Empty(t, result.AccessToken)
+	assert.Empty(t, result.RefreshToken)
+}
+
+func TestGoogleLoginUseCase_PasskeyUser_ReturnsPendingToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockOAuth := mocks.NewMockOAuthValidator(ctrl)
+	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
+
+	userID, _ := user.NewUserID("google-user-123")
+	email, _ := user.NewEmail("existing@example.com", true)
+	existingUser, _ := user.NewUser(userID, email, user.NewProfile("Existing User", ""))
+	passkey, err := user.NewPasskey([]byte("credential-1"), []byte("cose-key"), 0, "Laptop")
+	require.NoError(t, err)
+	require.NoError(t, existingUser.RegisterPasskey(passkey))
+
+	mockOAuth.EXPECT().
+		ValidateToken(ctx, "valid-token", "test-client-id").
+		Return(&ports.OAuthUserInfo{UserID: "google-user-123", Email: "existing@example.com", EmailVerified: true}, nil)
+	mockRepo.EXPECT().FindByID(ctx, userID).Return(existingUser, nil)
+	mockMFARepo.EXPECT().FindByUserID(ctx, userID).Return(nil, shared.ErrMFANotEnabled)
+	mockTokenGen.EXPECT().GenerateMFAToken("google-user-123", []string{ports.AMRFederated}).Return("mock-mfa-token", nil)
+
+	useCase := NewGoogleLoginUseCase(mockRepo, mockSessionRepo, mockMFARepo, mockOAuth, mockTokenGen, mockPublisher, nil, "test-client-id")
+
+	result, err := useCase.Execute(ctx, "valid-token", dto.ClientInfo{})
+
+	require.NoError(t, err)
+	assert.True(t, result.MFARequired)
+	assert.Equal(t, []string{dto.MFAMethodPasskey}, result.MFAMethods)
+	assert.Empty(t, result.AccessToken)
+}
+
+func TestGoogleLoginUseCase_CreatesSessionAndPublishesEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockOAuth := mocks.NewMockOAuthValidator(ctrl)
+	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
+
+	oauthInfo := &ports.OAuthUserInfo{
+		UserID:        "google-user-123",
+		Email:         "newuser@example.com",
+		EmailVerified: true,
+		Name:          "New User",
+	}
+
+	mockOAuth.EXPECT().
+		ValidateToken(ctx, "valid-token", "test-client-id").
+		Return(oauthInfo, nil)
+
+	mockRepo.EXPECT().
+		FindByID(ctx, gomock.Any()).
+		Return(nil, shared.ErrUserNotFound)
+
+	mockRepo.EXPECT().
+		FindByGoogleID(ctx, "google-user-123").
+		Return(nil, shared.ErrUserNotFound)
+
+	mockRepo.EXPECT().
+		FindByEmail(ctx, gomock.Any()).
+		Return(nil, shared.ErrUserNotFound)
+
+	mockRepo.EXPECT().
+		Save(ctx, gomock.Any()).
+		Return(nil)
+
+	var publishedTypes []string
+	mockPublisher.EXPECT().
+		Publish(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, events []shared.DomainEvent) error {
+			for _, event := range events {
+				publishedTypes = append(publishedTypes, event.EventType())
+			}
+			return nil
+		})
+
+	mockTokenGen.EXPECT().
+		GetRefreshTokenExpiry().
+		Return(604800)
+
+	var savedSession *session.Session
+	mockSessionRepo.EXPECT().
+		Save(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, s *session.Session) error {
+			savedSession = s
+			return nil
+		})
+
+	var issuedFor ports.UserInfo
+	mockTokenGen.EXPECT().
+		GenerateTokenPair(gomock.Any()).
+		DoAndReturn(func(info ports.UserInfo) (string, string, error) {
+			issuedFor = info
+			return "mock-access-token", "mock-refresh-token", nil
+		})
+
+	useCase := NewGoogleLoginUseCase(mockRepo, mockSessionRepo, mockMFARepo, mockOAuth, mockTokenGen, mockPublisher, nil, "test-client-id")
+
+	_, err := useCase.Execute(ctx, "valid-token", dto.ClientInfo{})
+
+	require.NoError(t, err)
+	require.NotNil(t, savedSession)
+	assert.Equal(t, "google-user-123", savedSession.UserID().Value())
+	assert.Equal(t, savedSession.ID().Value(), issuedFor.SessionID)
+	assert.Equal(t, []string{user.EventTypeUserRegistered, user.EventTypeUserLoggedIn}, publishedTypes)
+}
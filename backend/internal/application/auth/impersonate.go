@@ -0,0 +1,167 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/audit"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// StartImpersonationUseCase lets an administrator act as a user, to see what
+// they see when helping them
+type StartImpersonationUseCase struct {
+	userRepo       user.Repository
+	adminRoles     *AdminRoles
+	tokenGenerator ports.TokenGenerator
+	eventPublisher ports.EventPublisher
+	ttl            time.Duration
+}
+
+// NewStartImpersonationUseCase creates a new StartImpersonationUseCase
+// Impersonation tokens last ttl.
+func NewStartImpersonationUseCase(
+	userRepo user.Repository,
+	adminRoles *AdminRoles,
+	tokenGenerator ports.TokenGenerator,
+	eventPublisher ports.EventPublisher,
+	ttl time.Duration,
+) *StartImpersonationUseCase {
+	return &StartImpersonationUseCase{
+		userRepo:       userRepo,
+		adminRoles:     adminRoles,
+		tokenGenerator: tokenGenerator,
+		eventPublisher: eventPublisher,
+		ttl:            ttl,
+	}
+}
+
+// Execute issues an access token for the user targetUserID that names the
+// administrator in its act claim. The start is recorded in the user's audit
+// log before the token is returned; no token is issued if it cannot be.
+func (uc *StartImpersonationUseCase) Execute(ctx context.Context, claims *ports.TokenClaims, targetUserID string, req dto.ImpersonateRequest) (*dto.ImpersonationResponse, error) {
+	// Impersonation tokens cannot start another impersonation
+	if claims.ImpersonatorID() != "" {
+		return nil, shared.ErrImpersonationNotAllowed
+	}
+
+	admin, err := findActiveUser(ctx, uc.userRepo, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	// The admin role is granted and revoked by configuration
+	if uc.adminRoles.Apply(admin) {
+		if err := uc.userRepo.Save(ctx, admin); err != nil {
+			return nil, fmt.Errorf("failed to update admin role: %w", err)
+		}
+	}
+	// Checked before the target is looked up, so that other users cannot
+	// probe which user IDs exist
+	if !admin.HasRole(user.RoleAdmin) {
+		return nil, shared.ErrAdminRequired
+	}
+
+	targetID, err := user.NewUserID(targetUserID)
+	if err != nil {
+		return nil, shared.ErrUserNotFound
+	}
+	target, err := uc.userRepo.FindByID(ctx, targetID)
+	if err != nil {
+		if err == shared.ErrUserNotFound {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to retrieve user: %w", err)
+	}
+	uc.adminRoles.Apply(target)
+
+	expiresAt := time.Now().Add(uc.ttl)
+	if err := target.StartImpersonation(admin, req.Reason, expiresAt); err != nil {
+		return nil, err
+	}
+
+	accessToken, err := uc.tokenGenerator.GenerateImpersonationToken(ports.UserInfo{
+		UserID:         target.ID().Value(),
+		Email:          target.Email().Value(),
+		Name:           target.Profile().Name(),
+		Picture:        target.Profile().Picture(),
+		OrganizationID: target.ActiveOrganizationID(),
+	}, admin.ID().Value(), expiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate impersonation token: %w", err)
+	}
+
+	adminCtx := audit.ContextWithActor(ctx, admin.ID().Value())
+	if err := uc.eventPublisher.Publish(adminCtx, target.DomainEvents()); err != nil {
+		return nil, fmt.Errorf("failed to record impersonation: %w", err)
+	}
+	target.ClearDomainEvents()
+
+	log.Printf("Admin %s started impersonating user %s until %s (reason: %q)",
+		admin.ID().Value(), target.ID().Value(), expiresAt.Format(time.RFC3339), req.Reason)
+
+	return &dto.ImpersonationResponse{
+		Message:     "Impersonation started",
+		User:        dto.FromDomain(target),
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(uc.ttl.Seconds()),
+	}, nil
+}
+
+// StopImpersonationUseCase ends an administrator's impersonation of a user
+type StopImpersonationUseCase struct {
+	userRepo       user.Repository
+	revokedTokens  ports.RevokedTokenStore
+	eventPublisher ports.EventPublisher
+}
+
+// NewStopImpersonationUseCase creates a new StopImpersonationUseCase
+func NewStopImpersonationUseCase(
+	userRepo user.Repository,
+	revokedTokens ports.RevokedTokenStore,
+	eventPublisher ports.EventPublisher,
+) *StopImpersonationUseCase {
+	return &StopImpersonationUseCase{
+		userRepo:       userRepo,
+		revokedTokens:  revokedTokens,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// Execute revokes the impersonation token the request was made with and
+// records the stop in the user's audit log
+func (uc *StopImpersonationUseCase) Execute(ctx context.Context, claims *ports.TokenClaims) error {
+	adminID := claims.ImpersonatorID()
+	if adminID == "" {
+		return shared.ErrNotImpersonating
+	}
+
+	if claims.TokenID != "" {
+		if err := uc.revokedTokens.Revoke(ctx, claims.TokenID, claims.ExpiresAt); err != nil {
+			return fmt.Errorf("failed to revoke impersonation token: %w", err)
+		}
+	}
+
+	userID, err := user.NewUserID(claims.UserID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID in token: %w", err)
+	}
+	target, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve user: %w", err)
+	}
+
+	target.EndImpersonation(adminID)
+	if err := uc.eventPublisher.Publish(audit.ContextWithActor(ctx, adminID), target.DomainEvents()); err != nil {
+		return fmt.Errorf("failed to record end of impersonation: %w", err)
+	}
+	target.ClearDomainEvents()
+
+	log.Printf("Admin %s stopped impersonating user %s", adminID, claims.UserID)
+	return nil
+}
@@ -0,0 +1,243 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/audit"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+func (m *authMocks) startImpersonationUseCase() *StartImpersonationUseCase {
+	return NewStartImpersonationUseCase(m.userRepo, m.adminRoles, m.tokenGenerator, m.eventPublisher, 15*time.Minute)
+}
+
+func (m *authMocks) stopImpersonationUseCase() *StopImpersonationUseCase {
+	return NewStopImpersonationUseCase(m.userRepo, m.revokedTokens, m.eventPublisher)
+}
+
+// newImpersonationTestUsers returns an administrator and the user they act as
+func newImpersonationTestUsers(t *testing.T) (*user.User, *user.User) {
+	t.Helper()
+
+	adminID, _ := user.NewUserID("admin-123")
+	adminEmail, _ := user.NewEmail("admin@example.com", true)
+	admin, err := user.NewUser(adminID, adminEmail, user.NewProfile("Admin", ""))
+	require.NoError(t, err)
+	admin.GrantRole(user.RoleAdmin)
+	admin.ClearDomainEvents()
+
+	return admin, newTestUser(t, "user-123")
+}
+
+func TestStartImpersonationUseCase_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	admin, target := newImpersonationTestUsers(t)
+
+	m.userRepo.EXPECT().FindByID(ctx, admin.ID()).Return(admin, nil)
+	m.userRepo.EXPECT().FindByID(ctx, target.ID()).Return(target, nil)
+	m.tokenGenerator.EXPECT().
+		GenerateImpersonationToken(ports.UserInfo{UserID: "user-123", Email: "test@example.com", Name: "Test User", Picture: "https://example.com/photo.jpg"}, "admin-123", gomock.Any()).
+		Return("impersonation-token", nil)
+	m.eventPublisher.EXPECT().Publish(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, events []shared.DomainEvent) error {
+		assert.Equal(t, "admin-123", audit.ActorFromContext(ctx))
+		require.Len(t, events, 1)
+		started, ok := events[0].(user.ImpersonationStartedEvent)
+		require.True(t, ok)
+		assert.Equal(t, "ticket 42", started.Reason)
+		return nil
+	})
+
+	result, err := m.startImpersonationUseCase().Execute(ctx, &ports.TokenClaims{UserID: "admin-123"}, "user-123", dto.ImpersonateRequest{Reason: "ticket 42"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "impersonation-token", result.AccessToken)
+	assert.Equal(t, "Bearer", result.TokenType)
+	assert.Equal(t, 900, result.ExpiresIn)
+	assert.Equal(t, "user-123", result.User.ID)
+	assert.Empty(t, target.DomainEvents())
+}
+
+func TestStartImpersonationUseCase_NotAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	_, caller := newImpersonationTestUsers(t)
+
+	// The target is never looked up
+	m.userRepo.EXPECT().FindByID(ctx, caller.ID()).Return(caller, nil)
+
+	result, err := m.startImpersonationUseCase().Execute(ctx, &ports.TokenClaims{UserID: "user-123"}, "someone-else", dto.ImpersonateRequest{})
+
+	assert.ErrorIs(t, err, shared.ErrAdminRequired)
+	assert.Nil(t, result)
+}
+
+func TestStartImpersonationUseCase_GrantsConfiguredAdminRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	m.adminRoles = NewAdminRoles(nil, []string{"Admin@Example.com"})
+	admin, target := newImpersonationTestUsers(t)
+	admin.RevokeRole(user.RoleAdmin)
+
+	m.userRepo.EXPECT().FindByID(ctx, admin.ID()).Return(admin, nil)
+	m.userRepo.EXPECT().Save(ctx, admin).DoAndReturn(func(ctx context.Context, saved *user.User) error {
+		assert.True(t, saved.HasRole(user.RoleAdmin))
+		return nil
+	})
+	m.userRepo.EXPECT().FindByID(ctx, target.ID()).Return(target, nil)
+	m.tokenGenerator.EXPECT().GenerateImpersonationToken(gomock.Any(), "admin-123", gomock.Any()).Return("impersonation-token", nil)
+	m.eventPublisher.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil)
+
+	result, err := m.startImpersonationUseCase().Execute(ctx, &ports.TokenClaims{UserID: "admin-123"}, "user-123", dto.ImpersonateRequest{})
+
+	require.NoError(t, err)
+	assert.Equal(t, "impersonation-token", result.AccessToken)
+}
+
+func TestStartImpersonationUseCase_RevokesUnconfiguredAdminRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	m.adminRoles = NewAdminRoles(nil, nil)
+	admin, _ := newImpersonationTestUsers(t)
+
+	m.userRepo.EXPECT().FindByID(ctx, admin.ID()).Return(admin, nil)
+	m.userRepo.EXPECT().Save(ctx, admin).DoAndReturn(func(ctx context.Context, saved *user.User) error {
+		assert.False(t, saved.HasRole(user.RoleAdmin))
+		return nil
+	})
+
+	result, err := m.startImpersonationUseCase().Execute(ctx, &ports.TokenClaims{UserID: "admin-123"}, "user-123", dto.ImpersonateRequest{})
+
+	assert.ErrorIs(t, err, shared.ErrAdminRequired)
+	assert.Nil(t, result)
+}
+
+func TestStartImpersonationUseCase_AlreadyImpersonating(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := newAuthMocks(ctrl)
+	claims := &ports.TokenClaims{UserID: "user-123", Actor: &ports.Actor{Subject: "admin-123"}}
+
+	result, err := m.startImpersonationUseCase().Execute(context.Background(), claims, "user-456", dto.ImpersonateRequest{})
+
+	assert.ErrorIs(t, err, shared.ErrImpersonationNotAllowed)
+	assert.Nil(t, result)
+}
+
+func TestStartImpersonationUseCase_TargetIsAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	m.adminRoles = NewAdminRoles([]string{"admin-123", "user-123"}, nil)
+	admin, target := newImpersonationTestUsers(t)
+
+	m.userRepo.EXPECT().FindByID(ctx, admin.ID()).Return(admin, nil)
+	m.userRepo.EXPECT().FindByID(ctx, target.ID()).Return(target, nil)
+
+	result, err := m.startImpersonationUseCase().Execute(ctx, &ports.TokenClaims{UserID: "admin-123"}, "user-123", dto.ImpersonateRequest{})
+
+	assert.ErrorIs(t, err, shared.ErrImpersonationNotAllowed)
+	assert.Nil(t, result)
+}
+
+func TestStartImpersonationUseCase_UserNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	admin, _ := newImpersonationTestUsers(t)
+	missingID, _ := user.NewUserID("missing")
+
+	m.userRepo.EXPECT().FindByID(ctx, admin.ID()).Return(admin, nil)
+	m.userRepo.EXPECT().FindByID(ctx, missingID).Return(nil, shared.ErrUserNotFound)
+
+	result, err := m.startImpersonationUseCase().Execute(ctx, &ports.TokenClaims{UserID: "admin-123"}, "missing", dto.ImpersonateRequest{})
+
+	assert.ErrorIs(t, err, shared.ErrUserNotFound)
+	assert.Nil(t, result)
+}
+
+func TestStartImpersonationUseCase_AuditFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	admin, target := newImpersonationTestUsers(t)
+
+	m.userRepo.EXPECT().FindByID(ctx, admin.ID()).Return(admin, nil)
+	m.userRepo.EXPECT().FindByID(ctx, target.ID()).Return(target, nil)
+	m.tokenGenerator.EXPECT().GenerateImpersonationToken(gomock.Any(), "admin-123", gomock.Any()).Return("impersonation-token", nil)
+	m.eventPublisher.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(errors.New("audit store down"))
+
+	result, err := m.startImpersonationUseCase().Execute(ctx, &ports.TokenClaims{UserID: "admin-123"}, "user-123", dto.ImpersonateRequest{})
+
+	require.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestStopImpersonationUseCase_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	_, target := newImpersonationTestUsers(t)
+	expiresAt := time.Now().Add(10 * time.Minute)
+	claims := &ports.TokenClaims{
+		UserID:    "user-123",
+		TokenID:   "jti-1",
+		ExpiresAt: expiresAt,
+		Actor:     &ports.Actor{Subject: "admin-123"},
+	}
+
+	m.revokedTokens.EXPECT().Revoke(ctx, "jti-1", expiresAt).Return(nil)
+	m.userRepo.EXPECT().FindByID(ctx, target.ID()).Return(target, nil)
+	m.eventPublisher.EXPECT().Publish(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, events []shared.DomainEvent) error {
+		assert.Equal(t, "admin-123", audit.ActorFromContext(ctx))
+		require.Len(t, events, 1)
+		assert.Equal(t, user.EventTypeImpersonationEnded, events[0].EventType())
+		return nil
+	})
+
+	err := m.stopImpersonationUseCase().Execute(ctx, claims)
+
+	require.NoError(t, err)
+}
+
+func TestStopImpersonationUseCase_NotImpersonating(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := newAuthMocks(ctrl)
+
+	err := m.stopImpersonationUseCase().Execute(context.Background(), &ports.TokenClaims{UserID: "user-123", TokenID: "jti-1"})
+
+	assert.ErrorIs(t, err, shared.ErrNotImpersonating)
+}
@@ -0,0 +1,207 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/apitoken"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// Token type hints accepted by the introspection and revocation endpoints
+const (
+	TokenTypeHintAccessToken  = "access_token"
+	TokenTypeHintRefreshToken = "refresh_token"
+)
+
+// presentedToken is a valid JWT sent to the introspection or revocation endpoint
+type presentedToken struct {
+	claims  *ports.TokenClaims
+	refresh bool
+}
+
+// parsePresentedToken validates an access or refresh token, trying the type
+// named by hint first. Access tokens may be meant for any audience. It
+// returns nil for anything else, including expired tokens.
+func parsePresentedToken(tokenGenerator ports.TokenGenerator, token, hint string) *presentedToken {
+	refreshFirst := hint == TokenTypeHintRefreshToken
+	for _, refresh := range []bool{refreshFirst, !refreshFirst} {
+		validate := tokenGenerator.ValidateIssuedAccessToken
+		if refresh {
+			validate = tokenGenerator.ValidateRefreshToken
+		}
+		if claims, err := validate(token); err == nil {
+			return &presentedToken{claims: claims, refresh: refresh}
+		}
+	}
+	return nil
+}
+
+// isInactiveTokenError reports whether err means a token is no longer
+// active, as opposed to a failure to find out
+func isInactiveTokenError(err error) bool {
+	switch err {
+	case shared.ErrInvalidToken, shared.ErrInvalidAPIToken, shared.ErrUnauthorized, shared.ErrAccountSuspended,
+		shared.ErrAccountDeleted, shared.ErrSessionRevoked, shared.ErrSessionExpired:
+		return true
+	}
+	return false
+}
+
+// IntrospectTokenUseCase tells resource servers whether a token is active and
+// what it grants (RFC 7662), for servers that cannot verify tokens themselves
+type IntrospectTokenUseCase struct {
+	clients        *ServiceClientAuthenticator
+	tokenGenerator ports.TokenGenerator
+	apiTokens      *APITokenAuthenticator
+	userRepo       user.Repository
+	sessionRepo    session.Repository
+	revokedTokens  ports.RevokedTokenStore
+}
+
+// NewIntrospectTokenUseCase creates a new IntrospectTokenUseCase
+func NewIntrospectTokenUseCase(
+	clients *ServiceClientAuthenticator,
+	tokenGenerator ports.TokenGenerator,
+	apiTokens *APITokenAuthenticator,
+	userRepo user.Repository,
+	sessionRepo session.Repository,
+	revokedTokens ports.RevokedTokenStore,
+) *IntrospectTokenUseCase {
+	return &IntrospectTokenUseCase{
+		clients:        clients,
+		tokenGenerator: tokenGenerator,
+		apiTokens:      apiTokens,
+		userRepo:       userRepo,
+		sessionRepo:    sessionRepo,
+		revokedTokens:  revokedTokens,
+	}
+}
+
+// Execute authenticates the calling client and describes token. Access,
+// refresh and personal access tokens are active while they are valid, have
+// not been revoked, their session has not ended and their user may still
+// sign in. Only confidential clients may introspect tokens.
+func (uc *IntrospectTokenUseCase) Execute(ctx context.Context, creds dto.ClientCredentials, token, hint string) (*dto.IntrospectionResponse, error) {
+	client, err := uc.clients.Authenticate(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+	if !client.CanUseClientCredentials() {
+		return nil, shared.ErrUnauthorizedClient
+	}
+
+	inactive := &dto.IntrospectionResponse{Active: false}
+
+	if apitoken.LooksLikeToken(token) {
+		claims, err := uc.apiTokens.Authenticate(ctx, token)
+		if err != nil {
+			if isInactiveTokenError(err) {
+				return inactive, nil
+			}
+			return nil, err
+		}
+		return introspectionResponse(claims, "Bearer"), nil
+	}
+
+	presented := parsePresentedToken(uc.tokenGenerator, token, hint)
+	if presented == nil {
+		return inactive, nil
+	}
+
+	if err := ensureTokenActive(ctx, uc.revokedTokens, uc.userRepo, uc.sessionRepo, presented.claims); err != nil {
+		if isInactiveTokenError(err) {
+			return inactive, nil
+		}
+		return nil, err
+	}
+
+	tokenType := "Bearer"
+	if presented.refresh {
+		tokenType = TokenTypeHintRefreshToken
+	} else if presented.claims.DPoPKey != "" {
+		tokenType = "DPoP"
+	}
+	return introspectionResponse(presented.claims, tokenType), nil
+}
+
+// ensureTokenActive checks that a valid JWT has not been revoked, alone or
+// with its session, and that its user may still sign in
+func ensureTokenActive(
+	ctx context.Context,
+	revokedTokens ports.RevokedTokenStore,
+	userRepo user.Repository,
+	sessionRepo session.Repository,
+	claims *ports.TokenClaims,
+) error {
+	if claims.TokenID != "" {
+		revoked, err := revokedTokens.IsRevoked(ctx, claims.TokenID)
+		if err != nil {
+			return fmt.Errorf("failed to check token revocation: %w", err)
+		}
+		if revoked {
+			return shared.ErrInvalidToken
+		}
+	}
+
+	// Service client tokens have no user or session
+	if claims.IsServiceClient() {
+		return nil
+	}
+
+	if _, err := findActiveUser(ctx, userRepo, claims.UserID); err != nil {
+		return err
+	}
+	if claims.SessionID != "" {
+		if _, err := findActiveSession(ctx, sessionRepo, claims, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// introspectionResponse describes an active token
+func introspectionResponse(claims *ports.TokenClaims, tokenType string) *dto.IntrospectionResponse {
+	response := &dto.IntrospectionResponse{
+		Active:    true,
+		Scope:     strings.Join(claims.Scopes, " "),
+		ClientID:  claims.ClientID,
+		Username:  claims.Email,
+		TokenType: tokenType,
+		Subject:   claims.UserID,
+		TokenID:   claims.TokenID,
+		SessionID: claims.SessionID,
+		Audience:  claims.Audience,
+		Actor:     actorResponse(claims.Actor),
+
+		OrganizationID: claims.OrganizationID,
+	}
+	if claims.IsServiceClient() {
+		response.Subject = claims.ClientID
+	}
+	if claims.DPoPKey != "" {
+		response.Confirmation = &dto.Confirmation{JWKThumbprint: claims.DPoPKey}
+	}
+	if !claims.ExpiresAt.IsZero() {
+		response.ExpiresAt = claims.ExpiresAt.Unix()
+	}
+	if !claims.IssuedAt.IsZero() {
+		response.IssuedAt = claims.IssuedAt.Unix()
+	}
+	return response
+}
+
+// actorResponse converts an act claim chain for an introspection response
+func actorResponse(actor *ports.Actor) *dto.Actor {
+	if actor == nil {
+		return nil
+	}
+	return &dto.Actor{Subject: actor.Subject, Actor: actorResponse(actor.Actor)}
+}
@@ -0,0 +1,228 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/mocks"
+)
+
+// billingCreds authenticates as the client returned by newSecretTestClient
+var billingCreds = dto.ClientCredentials{ClientID: "billing", ClientSecret: "s3cret"}
+
+func (m *authMocks) clients() *ServiceClientAuthenticator {
+	return NewServiceClientAuthenticator(m.clientRepo, mocks.NewMockClientAssertionVerifier(nil), mocks.NewMockClientAssertionStore(nil))
+}
+
+func (m *authMocks) introspectUseCase() *IntrospectTokenUseCase {
+	apiTokens := NewAPITokenAuthenticator(m.userRepo, m.tokenRepo)
+	return NewIntrospectTokenUseCase(m.clients(), m.tokenGenerator, apiTokens, m.userRepo, m.sessionRepo, m.revokedTokens)
+}
+
+func (m *authMocks) revokeUseCase() *RevokeTokenUseCase {
+	return NewRevokeTokenUseCase(m.clients(), m.tokenGenerator, m.tokenRepo, m.sessionRepo, m.revokedTokens, m.eventPublisher)
+}
+
+func TestIntrospectTokenUseCase_ActiveDelegatedToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser, loginSession, _ := newCodeTestUser(t)
+	issuedAt := time.Now().Truncate(time.Second)
+	claims := &ports.TokenClaims{
+		UserID:    "user-123",
+		Email:     "test@example.com",
+		SessionID: loginSession.ID().Value(),
+		ClientID:  "wiki",
+		Scopes:    []string{"email", "openid"},
+		TokenID:   "jti-1",
+		IssuedAt:  issuedAt,
+		ExpiresAt: issuedAt.Add(15 * time.Minute),
+	}
+
+	m.clientRepo.EXPECT().FindByID(ctx, "billing").Return(newSecretTestClient(t), nil)
+	m.tokenGenerator.EXPECT().ValidateIssuedAccessToken("access-token").Return(claims, nil)
+	m.revokedTokens.EXPECT().IsRevoked(ctx, "jti-1").Return(false, nil)
+	m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	m.sessionRepo.EXPECT().FindByID(ctx, loginSession.ID()).Return(loginSession, nil)
+
+	result, err := m.introspectUseCase().Execute(ctx, billingCreds, "access-token", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, &dto.IntrospectionResponse{
+		Active:    true,
+		Scope:     "email openid",
+		ClientID:  "wiki",
+		Username:  "test@example.com",
+		TokenType: "Bearer",
+		ExpiresAt: issuedAt.Add(15 * time.Minute).Unix(),
+		IssuedAt:  issuedAt.Unix(),
+		Subject:   "user-123",
+		TokenID:   "jti-1",
+		SessionID: loginSession.ID().Value(),
+	}, result)
+}
+
+func TestIntrospectTokenUseCase_RefreshTokenHint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser, loginSession, _ := newCodeTestUser(t)
+	claims := &ports.TokenClaims{UserID: "user-123", SessionID: loginSession.ID().Value()}
+
+	m.clientRepo.EXPECT().FindByID(ctx, "billing").Return(newSecretTestClient(t), nil)
+	// The hint is tried first, so the access token check is never needed
+	m.tokenGenerator.EXPECT().ValidateRefreshToken("refresh-token").Return(claims, nil)
+	m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	m.sessionRepo.EXPECT().FindByID(ctx, loginSession.ID()).Return(loginSession, nil)
+
+	result, err := m.introspectUseCase().Execute(ctx, billingCreds, "refresh-token", TokenTypeHintRefreshToken)
+
+	require.NoError(t, err)
+	assert.True(t, result.Active)
+	assert.Equal(t, TokenTypeHintRefreshToken, result.TokenType)
+}
+
+func TestIntrospectTokenUseCase_DPoPBoundToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser, loginSession, _ := newCodeTestUser(t)
+	claims := &ports.TokenClaims{UserID: "user-123", SessionID: loginSession.ID().Value(), DPoPKey: "thumbprint"}
+
+	m.clientRepo.EXPECT().FindByID(ctx, "billing").Return(newSecretTestClient(t), nil)
+	m.tokenGenerator.EXPECT().ValidateIssuedAccessToken("access-token").Return(claims, nil)
+	m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	m.sessionRepo.EXPECT().FindByID(ctx, loginSession.ID()).Return(loginSession, nil)
+
+	result, err := m.introspectUseCase().Execute(ctx, billingCreds, "access-token", "")
+
+	require.NoError(t, err)
+	assert.True(t, result.Active)
+	assert.Equal(t, "DPoP", result.TokenType)
+	assert.Equal(t, &dto.Confirmation{JWKThumbprint: "thumbprint"}, result.Confirmation)
+}
+
+func TestIntrospectTokenUseCase_Inactive(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, m *authMocks)
+	}{
+		{
+			name: "invalid token",
+			setup: func(t *testing.T, m *authMocks) {
+				m.tokenGenerator.EXPECT().ValidateIssuedAccessToken("the-token").Return(nil, ports.ErrInvalidToken)
+				m.tokenGenerator.EXPECT().ValidateRefreshToken("the-token").Return(nil, ports.ErrInvalidToken)
+			},
+		},
+		{
+			name: "revoked token",
+			setup: func(t *testing.T, m *authMocks) {
+				m.tokenGenerator.EXPECT().ValidateIssuedAccessToken("the-token").Return(&ports.TokenClaims{ClientID: "billing", Scopes: []string{}, TokenID: "jti-1"}, nil)
+				m.revokedTokens.EXPECT().IsRevoked(gomock.Any(), "jti-1").Return(true, nil)
+			},
+		},
+		{
+			name: "session ended",
+			setup: func(t *testing.T, m *authMocks) {
+				domainUser, loginSession, _ := newCodeTestUser(t)
+				loginSession.Revoke(time.Now())
+				m.tokenGenerator.EXPECT().ValidateIssuedAccessToken("the-token").Return(&ports.TokenClaims{UserID: "user-123", SessionID: loginSession.ID().Value()}, nil)
+				m.userRepo.EXPECT().FindByID(gomock.Any(), domainUser.ID()).Return(domainUser, nil)
+				m.sessionRepo.EXPECT().FindByID(gomock.Any(), loginSession.ID()).Return(loginSession, nil)
+			},
+		},
+		{
+			name: "user suspended",
+			setup: func(t *testing.T, m *authMocks) {
+				domainUser, loginSession, _ := newCodeTestUser(t)
+				require.NoError(t, domainUser.Suspend("abuse"))
+				m.tokenGenerator.EXPECT().ValidateIssuedAccessToken("the-token").Return(&ports.TokenClaims{UserID: "user-123", SessionID: loginSession.ID().Value()}, nil)
+				m.userRepo.EXPECT().FindByID(gomock.Any(), domainUser.ID()).Return(domainUser, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			m := newAuthMocks(ctrl)
+			m.clientRepo.EXPECT().FindByID(ctx, "billing").Return(newSecretTestClient(t), nil)
+			tt.setup(t, m)
+
+			result, err := m.introspectUseCase().Execute(ctx, billingCreds, "the-token", "")
+
+			require.NoError(t, err)
+			assert.Equal(t, &dto.IntrospectionResponse{Active: false}, result)
+		})
+	}
+}
+
+func TestIntrospectTokenUseCase_APIToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser, token, plain := newAPITokenTestUser(t)
+
+	m.clientRepo.EXPECT().FindByID(ctx, "billing").Return(newSecretTestClient(t), nil)
+	m.tokenRepo.EXPECT().FindByHash(ctx, token.Hash()).Return(token, nil)
+	m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	m.tokenRepo.EXPECT().Save(ctx, token).Return(nil)
+
+	result, err := m.introspectUseCase().Execute(ctx, billingCreds, plain, "")
+
+	require.NoError(t, err)
+	assert.True(t, result.Active)
+	assert.Equal(t, ports.ScopeProfileRead, result.Scope)
+	assert.Equal(t, "user-123", result.Subject)
+	assert.Equal(t, token.ExpiresAt().Unix(), result.ExpiresAt)
+}
+
+func TestIntrospectTokenUseCase_PublicClientRejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+
+	m.clientRepo.EXPECT().FindByID(ctx, "wiki").Return(newRelyingPartyClient(t), nil)
+
+	result, err := m.introspectUseCase().Execute(ctx, dto.ClientCredentials{ClientID: "wiki"}, "the-token", "")
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrUnauthorizedClient, err)
+}
+
+func TestIntrospectTokenUseCase_InvalidClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+
+	m.clientRepo.EXPECT().FindByID(ctx, "billing").Return(newSecretTestClient(t), nil)
+
+	result, err := m.introspectUseCase().Execute(ctx, dto.ClientCredentials{ClientID: "billing", ClientSecret: "wrong"}, "the-token", "")
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrInvalidClient, err)
+}
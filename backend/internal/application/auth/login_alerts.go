@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"context"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/events"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// LoginAlertService detects logins from unfamiliar devices or locations and
+// notifies the user with a link that revokes the new session
+type LoginAlertService struct {
+	sessionRepo    session.Repository
+	fingerprinter  ports.DeviceFingerprinter
+	revokeLinks    ports.RevokeLinkTokenService
+	notifier       ports.LoginAlertNotifier
+	eventPublisher ports.EventPublisher
+	revokeURL      string
+}
+
+// NewLoginAlertService creates a new LoginAlertService; revokeURL is the
+// public URL of the endpoint the "this wasn't me" link points to
+func NewLoginAlertService(
+	sessionRepo session.Repository,
+	fingerprinter ports.DeviceFingerprinter,
+	revokeLinks ports.RevokeLinkTokenService,
+	notifier ports.LoginAlertNotifier,
+	eventPublisher ports.EventPublisher,
+	revokeURL string,
+) *LoginAlertService {
+	return &LoginAlertService{
+		sessionRepo:    sessionRepo,
+		fingerprinter:  fingerprinter,
+		revokeLinks:    revokeLinks,
+		notifier:       notifier,
+		eventPublisher: eventPublisher,
+		revokeURL:      revokeURL,
+	}
+}
+
+// Fingerprint returns the device a login request came from
+func (s *LoginAlertService) Fingerprint(client dto.ClientInfo) session.Device {
+	return s.fingerprinter.Fingerprint(client.UserAgent, client.IPAddress)
+}
+
+// Review compares a new session's device with the user's earlier sessions
+// and, if it is unfamiliar, emits a SuspiciousLoginDetected event and
+// notifies the user. Alerts are best-effort: failures are logged and never
+// fail the login.
+func (s *LoginAlertService) Review(ctx context.Context, domainUser *user.User, loginSession *session.Session) {
+	sessions, err := s.sessionRepo.FindByUserID(ctx, domainUser.ID())
+	if err != nil {
+		log.Printf("Failed to load login history for %s: %v", domainUser.ID().Value(), err)
+		return
+	}
+
+	history := make([]*session.Session, 0, len(sessions))
+	for _, previous := range sessions {
+		if !previous.ID().Equals(loginSession.ID()) {
+			history = append(history, previous)
+		}
+	}
+
+	device := loginSession.Device()
+	reasons := session.UnfamiliarDeviceReasons(history, device)
+	if len(reasons) == 0 {
+		return
+	}
+
+	sessionID := loginSession.ID().Value()
+	log.Printf("Suspicious login for user %s (session %s): %v", domainUser.ID().Value(), sessionID, reasons)
+	domainUser.FlagSuspiciousLogin(sessionID, reasons)
+	events.Publish(ctx, s.eventPublisher, domainUser)
+
+	// The link stays usable for as long as the session it revokes
+	token, err := s.revokeLinks.Generate(domainUser.ID().Value(), sessionID, loginSession.ExpiresAt())
+	if err != nil {
+		log.Printf("Failed to generate session revoke link: %v", err)
+		return
+	}
+
+	revokeURL, err := url.Parse(s.revokeURL)
+	if err != nil {
+		log.Printf("Invalid session revoke URL %q: %v", s.revokeURL, err)
+		return
+	}
+	query := revokeURL.Query()
+	query.Set("token", token)
+	revokeURL.RawQuery = query.Encode()
+
+	alert := ports.LoginAlert{
+		UserID:          domainUser.ID().Value(),
+		Email:           domainUser.Email().Value(),
+		Name:            domainUser.Profile().Name(),
+		SessionID:       sessionID,
+		Reasons:         reasons,
+		UserAgentFamily: device.UserAgentFamily(),
+		Network:         device.Network(),
+		Country:         device.Country(),
+		OccurredAt:      time.Now(),
+		RevokeURL:       revokeURL.String(),
+	}
+	if err := s.notifier.NotifySuspiciousLogin(ctx, alert); err != nil {
+		log.Printf("Failed to send login alert to %s: %v", domainUser.ID().Value(), err)
+	}
+}
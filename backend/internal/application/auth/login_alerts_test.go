@@ -0,0 +1,204 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+	"github.com/yuki5155/go-google-auth/internal/mocks"
+)
+
+var (
+	knownDevice   = session.NewDevice("Chrome on Windows", "203.0.113.0/24", "NL")
+	unknownDevice = session.NewDevice("Safari on iOS", "192.0.2.0/24", "BR")
+)
+
+func (m *authMocks) loginAlertService() *LoginAlertService {
+	return NewLoginAlertService(m.sessionRepo, m.fingerprinter, m.revokeLinks, m.notifier, m.eventPublisher, "https://api.example.com/auth/sessions/revoke")
+}
+
+// newAlertTestUser returns a user with an earlier session from knownDevice
+// and a new session from device
+func newAlertTestUser(t *testing.T, device session.Device) (*user.User, *session.Session, *session.Session) {
+	t.Helper()
+
+	domainUser := newTestUser(t, "user-123")
+
+	earlier, err := session.NewSession(domainUser.ID(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	earlier.RecordDevice(knownDevice)
+
+	current, err := session.NewSession(domainUser.ID(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	current.RecordDevice(device)
+
+	return domainUser, earlier, current
+}
+
+func TestLoginAlertService_Fingerprint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := newAuthMocks(ctrl)
+	m.fingerprinter.EXPECT().Fingerprint("Mozilla/5.0", "203.0.113.7").Return(knownDevice)
+
+	device := m.loginAlertService().Fingerprint(dto.ClientInfo{UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"})
+
+	assert.Equal(t, knownDevice, device)
+}
+
+func TestLoginAlertService_Review_UnfamiliarDevice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser, earlier, current := newAlertTestUser(t, unknownDevice)
+
+	m.sessionRepo.EXPECT().FindByUserID(ctx, domainUser.ID()).Return([]*session.Session{current, earlier}, nil)
+	m.eventPublisher.EXPECT().
+		Publish(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, events []shared.DomainEvent) error {
+			require.Len(t, events, 1)
+			detected, ok := events[0].(user.SuspiciousLoginDetectedEvent)
+			require.True(t, ok)
+			assert.Equal(t, current.ID().Value(), detected.SessionID)
+			assert.Equal(t, []string{session.ReasonNewDevice, session.ReasonNewLocation}, detected.Reasons)
+			return nil
+		})
+	m.revokeLinks.EXPECT().Generate("user-123", current.ID().Value(), current.ExpiresAt()).Return("revoke-token", nil)
+	m.notifier.EXPECT().
+		NotifySuspiciousLogin(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, alert ports.LoginAlert) error {
+			assert.Equal(t, "test@example.com", alert.Email)
+			assert.Equal(t, current.ID().Value(), alert.SessionID)
+			assert.Equal(t, "Safari on iOS", alert.UserAgentFamily)
+			assert.Equal(t, "BR", alert.Country)
+
+			revokeURL, err := url.Parse(alert.RevokeURL)
+			require.NoError(t, err)
+			assert.Equal(t, "api.example.com", revokeURL.Host)
+			assert.Equal(t, "revoke-token", revokeURL.Query().Get("token"))
+			return nil
+		})
+
+	m.loginAlertService().Review(ctx, domainUser, current)
+
+	assert.Empty(t, domainUser.DomainEvents())
+}
+
+func TestLoginAlertService_Review_FamiliarDevice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser, earlier, current := newAlertTestUser(t, knownDevice)
+
+	m.sessionRepo.EXPECT().FindByUserID(ctx, domainUser.ID()).Return([]*session.Session{current, earlier}, nil)
+
+	m.loginAlertService().Review(ctx, domainUser, current)
+
+	assert.Empty(t, domainUser.DomainEvents())
+}
+
+func TestLoginAlertService_Review_FirstLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser, _, current := newAlertTestUser(t, unknownDevice)
+
+	// The new session is the only one, so there is nothing to compare with
+	m.sessionRepo.EXPECT().FindByUserID(ctx, domainUser.ID()).Return([]*session.Session{current}, nil)
+
+	m.loginAlertService().Review(ctx, domainUser, current)
+
+	assert.Empty(t, domainUser.DomainEvents())
+}
+
+func TestLoginAlertService_Review_NotifierFailureIsIgnored(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser, earlier, current := newAlertTestUser(t, unknownDevice)
+
+	m.sessionRepo.EXPECT().FindByUserID(ctx, domainUser.ID()).Return([]*session.Session{current, earlier}, nil)
+	m.eventPublisher.EXPECT().Publish(ctx, gomock.Any()).Return(nil)
+	m.revokeLinks.EXPECT().Generate(gomock.Any(), gomock.Any(), gomock.Any()).Return("revoke-token", nil)
+	m.notifier.EXPECT().NotifySuspiciousLogin(ctx, gomock.Any()).Return(errors.New("smtp down"))
+
+	// Review has no error to return; the login goes ahead
+	m.loginAlertService().Review(ctx, domainUser, current)
+}
+
+func TestGoogleLoginUseCase_RecordsDeviceAndReviewsLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockOAuth := mocks.NewMockOAuthValidator(ctrl)
+	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
+	m := newAuthMocks(ctrl)
+	m.sessionRepo = mockSessionRepo
+
+	userID, _ := user.NewUserID("google-user-123")
+	mockOAuth.EXPECT().
+		ValidateToken(ctx, "valid-google-token", "test-client-id").
+		Return(&ports.OAuthUserInfo{UserID: "google-user-123", Email: "newuser@example.com", EmailVerified: true, Name: "New User"}, nil)
+	mockRepo.EXPECT().FindByID(ctx, userID).Return(nil, shared.ErrUserNotFound)
+	mockRepo.EXPECT().FindByGoogleID(ctx, "google-user-123").Return(nil, shared.ErrUserNotFound)
+	mockRepo.EXPECT().FindByEmail(ctx, gomock.Any()).Return(nil, shared.ErrUserNotFound)
+	mockRepo.EXPECT().Save(ctx, gomock.Any()).Return(nil)
+	mockPublisher.EXPECT().Publish(ctx, gomock.Any()).Return(nil)
+	mockTokenGen.EXPECT().GetRefreshTokenExpiry().Return(604800)
+	m.fingerprinter.EXPECT().Fingerprint("Mozilla/5.0", "203.0.113.7").Return(knownDevice)
+
+	var saved *session.Session
+	mockSessionRepo.EXPECT().
+		Save(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, s *session.Session) error {
+			saved = s
+			return nil
+		})
+	mockSessionRepo.EXPECT().
+		FindByUserID(ctx, userID).
+		DoAndReturn(func(ctx context.Context, id user.UserID) ([]*session.Session, error) {
+			return []*session.Session{saved}, nil
+		})
+
+	var issued ports.UserInfo
+	mockTokenGen.EXPECT().
+		GenerateTokenPair(gomock.Any()).
+		DoAndReturn(func(info ports.UserInfo) (string, string, error) {
+			issued = info
+			return "mock-access-token", "mock-refresh-token", nil
+		})
+
+	useCase := NewGoogleLoginUseCase(mockRepo, mockSessionRepo, mockMFARepo, mockOAuth, mockTokenGen, mockPublisher, m.loginAlertService(), "test-client-id")
+
+	_, err := useCase.Execute(ctx, "valid-google-token", dto.ClientInfo{UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"})
+
+	require.NoError(t, err)
+	require.NotNil(t, saved)
+	assert.Equal(t, knownDevice, saved.Device())
+	assert.Equal(t, saved.ID().Value(), issued.SessionID)
+}
@@ -0,0 +1,217 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/events"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// RegisterPasskeyUseCase adds a passkey to the current user's account
+type RegisterPasskeyUseCase struct {
+	userRepo       user.Repository
+	verifier       ports.WebAuthnVerifier
+	challenges     ports.WebAuthnChallengeStore
+	eventPublisher ports.EventPublisher
+	challengeTTL   time.Duration
+}
+
+// NewRegisterPasskeyUseCase creates a new RegisterPasskeyUseCase
+func NewRegisterPasskeyUseCase(
+	userRepo user.Repository,
+	verifier ports.WebAuthnVerifier,
+	challenges ports.WebAuthnChallengeStore,
+	eventPublisher ports.EventPublisher,
+	challengeTTL time.Duration,
+) *RegisterPasskeyUseCase {
+	return &RegisterPasskeyUseCase{
+		userRepo:       userRepo,
+		verifier:       verifier,
+		challenges:     challenges,
+		eventPublisher: eventPublisher,
+		challengeTTL:   challengeTTL,
+	}
+}
+
+// Begin returns the options for navigator.credentials.create(). Passkeys are
+// created as discoverable credentials with user verification so that they
+// can also be used to log in without Google.
+func (uc *RegisterPasskeyUseCase) Begin(ctx context.Context, claims *ports.TokenClaims) (*dto.PasskeyCreationOptions, error) {
+	domainUser, err := findActiveUser(ctx, uc.userRepo, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if domainUser.Passkeys().Len() >= user.MaxPasskeys {
+		return nil, shared.ErrTooManyPasskeys
+	}
+
+	challenge, err := issueChallenge(ctx, uc.verifier, uc.challenges, domainUser.ID().Value(), ports.WebAuthnPurposeRegistration, uc.challengeTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	displayName := domainUser.Profile().Name()
+	if displayName == "" {
+		displayName = domainUser.Email().Value()
+	}
+
+	params := make([]dto.PasskeyCredentialParameter, 0, len(uc.verifier.Algorithms()))
+	for _, alg := range uc.verifier.Algorithms() {
+		params = append(params, dto.PasskeyCredentialParameter{Type: publicKeyCredentialType, Alg: alg})
+	}
+
+	return &dto.PasskeyCreationOptions{
+		Challenge: challenge,
+		RP: dto.PasskeyRelyingParty{
+			ID:   uc.verifier.RelyingPartyID(),
+			Name: uc.verifier.RelyingPartyName(),
+		},
+		User: dto.PasskeyUserEntity{
+			// The user handle returned by discoverable credentials at login
+			ID:          encodeBase64URL([]byte(domainUser.ID().Value())),
+			Name:        domainUser.Email().Value(),
+			DisplayName: displayName,
+		},
+		PubKeyCredParams:   params,
+		Timeout:            uc.challengeTTL.Milliseconds(),
+		ExcludeCredentials: credentialDescriptors(domainUser.Passkeys()),
+		AuthenticatorSelection: dto.PasskeyAuthenticatorSelection{
+			ResidentKey:        residentKeyRequired,
+			RequireResidentKey: true,
+			UserVerification:   userVerificationRequired,
+		},
+		Attestation: attestationNone,
+	}, nil
+}
+
+// Finish verifies the new credential against the challenge from Begin and stores it
+func (uc *RegisterPasskeyUseCase) Finish(ctx context.Context, claims *ports.TokenClaims, req dto.PasskeyRegistrationRequest) (*dto.PasskeyResponse, error) {
+	domainUser, err := findActiveUser(ctx, uc.userRepo, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Credential.Type != publicKeyCredentialType {
+		return nil, shared.ErrInvalidPasskey
+	}
+	credentialID, err := decodeBase64URL(req.Credential.ID)
+	if err != nil {
+		return nil, err
+	}
+	clientData, err := decodeBase64URL(req.Credential.Response.ClientDataJSON)
+	if err != nil {
+		return nil, err
+	}
+	attestationObject, err := decodeBase64URL(req.Credential.Response.AttestationObject)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge, err := consumeChallenge(ctx, uc.verifier, uc.challenges, clientData, domainUser.ID().Value(), ports.WebAuthnPurposeRegistration)
+	if err != nil {
+		return nil, err
+	}
+
+	attestation := ports.WebAuthnAttestation{ClientDataJSON: clientData, AttestationObject: attestationObject}
+	credential, err := uc.verifier.VerifyRegistration(attestation, challenge, true)
+	if err != nil {
+		log.Printf("Passkey registration rejected for user %s: %v", domainUser.ID().Value(), err)
+		return nil, shared.ErrPasskeyVerification
+	}
+	if !bytes.Equal(credential.ID, credentialID) {
+		return nil, shared.ErrInvalidPasskey
+	}
+
+	name := req.Name
+	if name == "" {
+		name = defaultPasskeyName
+	}
+
+	passkey, err := user.NewPasskey(credential.ID, credential.PublicKey, credential.SignCount, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := domainUser.RegisterPasskey(passkey); err != nil {
+		return nil, err
+	}
+
+	if err := uc.userRepo.Save(ctx, domainUser); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+	events.Publish(ctx, uc.eventPublisher, domainUser)
+
+	response := dto.NewPasskeyResponse(passkey)
+	return &response, nil
+}
+
+// ListPasskeysUseCase lists the current user's passkeys
+type ListPasskeysUseCase struct {
+	userRepo user.Repository
+}
+
+// NewListPasskeysUseCase creates a new ListPasskeysUseCase
+func NewListPasskeysUseCase(userRepo user.Repository) *ListPasskeysUseCase {
+	return &ListPasskeysUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// Execute returns the passkeys of the user in the claims
+func (uc *ListPasskeysUseCase) Execute(ctx context.Context, claims *ports.TokenClaims) (*dto.PasskeyListResponse, error) {
+	domainUser, err := findActiveUser(ctx, uc.userRepo, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	passkeys := make([]dto.PasskeyResponse, 0, domainUser.Passkeys().Len())
+	for _, passkey := range domainUser.Passkeys().Values() {
+		passkeys = append(passkeys, dto.NewPasskeyResponse(passkey))
+	}
+
+	return &dto.PasskeyListResponse{Passkeys: passkeys}, nil
+}
+
+// RemovePasskeyUseCase removes one of the current user's passkeys
+type RemovePasskeyUseCase struct {
+	userRepo       user.Repository
+	eventPublisher ports.EventPublisher
+}
+
+// NewRemovePasskeyUseCase creates a new RemovePasskeyUseCase
+func NewRemovePasskeyUseCase(userRepo user.Repository, eventPublisher ports.EventPublisher) *RemovePasskeyUseCase {
+	return &RemovePasskeyUseCase{
+		userRepo:       userRepo,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// Execute removes the passkey with the given ID (its base64url credential ID)
+func (uc *RemovePasskeyUseCase) Execute(ctx context.Context, claims *ports.TokenClaims, passkeyID string) error {
+	credentialID, err := decodeBase64URL(passkeyID)
+	if err != nil {
+		return shared.ErrPasskeyNotFound
+	}
+
+	domainUser, err := findActiveUser(ctx, uc.userRepo, claims.UserID)
+	if err != nil {
+		return err
+	}
+
+	if err := domainUser.RemovePasskey(credentialID); err != nil {
+		return err
+	}
+
+	if err := uc.userRepo.Save(ctx, domainUser); err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+	events.Publish(ctx, uc.eventPublisher, domainUser)
+
+	return nil
+}
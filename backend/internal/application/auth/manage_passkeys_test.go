@@ -0,0 +1,257 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+	"github.com/yuki5155/go-google-auth/internal/mocks"
+)
+
+// newRegistrationRequest builds a registration as sent by the browser
+func newRegistrationRequest(name string) dto.PasskeyRegistrationRequest {
+	return dto.PasskeyRegistrationRequest{
+		Name: name,
+		Credential: dto.PasskeyAttestationCredential{
+			ID:   encodeBase64URL([]byte("credential-2")),
+			Type: "public-key",
+			Response: dto.PasskeyAttestationResponse{
+				ClientDataJSON:    encodeBase64URL([]byte("client-data")),
+				AttestationObject: encodeBase64URL([]byte("attestation-object")),
+			},
+		},
+	}
+}
+
+func TestRegisterPasskeyUseCase_Begin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockVerifier := mocks.NewMockWebAuthnVerifier(ctrl)
+	mockChallenges := mocks.NewMockWebAuthnChallengeStore(ctrl)
+
+	domainUser := newPasskeyUser(t, 0)
+
+	mockRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	mockVerifier.EXPECT().NewChallenge().Return("challenge-1", nil)
+	mockVerifier.EXPECT().RelyingPartyID().Return("example.com")
+	mockVerifier.EXPECT().RelyingPartyName().Return("Example")
+	mockVerifier.EXPECT().Algorithms().Return([]int{-7, -8}).AnyTimes()
+	mockChallenges.EXPECT().
+		Save(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, c ports.WebAuthnChallenge) error {
+			assert.Equal(t, "user-123", c.UserID)
+			assert.Equal(t, ports.WebAuthnPurposeRegistration, c.Purpose)
+			return nil
+		})
+
+	useCase := NewRegisterPasskeyUseCase(mockRepo, mockVerifier, mockChallenges, nil, testChallengeTTL)
+
+	options, err := useCase.Begin(ctx, &ports.TokenClaims{UserID: "user-123"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "challenge-1", options.Challenge)
+	assert.Equal(t, dto.PasskeyRelyingParty{ID: "example.com", Name: "Example"}, options.RP)
+	assert.Equal(t, encodeBase64URL([]byte("user-123")), options.User.ID)
+	assert.Equal(t, "test@example.com", options.User.Name)
+	assert.Equal(t, "Test User", options.User.DisplayName)
+	assert.Len(t, options.PubKeyCredParams, 2)
+	assert.Equal(t, "none", options.Attestation)
+	assert.Equal(t, "required", options.AuthenticatorSelection.ResidentKey)
+	assert.Equal(t, "required", options.AuthenticatorSelection.UserVerification)
+	require.Len(t, options.ExcludeCredentials, 1)
+	assert.Equal(t, encodeBase64URL(testCredentialID), options.ExcludeCredentials[0].ID)
+}
+
+func TestRegisterPasskeyUseCase_Finish_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockVerifier := mocks.NewMockWebAuthnVerifier(ctrl)
+	mockChallenges := mocks.NewMockWebAuthnChallengeStore(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
+
+	domainUser := newPasskeyUser(t, 0)
+
+	mockRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	mockVerifier.EXPECT().ClientChallenge([]byte("client-data")).Return("challenge-1", nil)
+	mockChallenges.EXPECT().
+		Consume(ctx, "challenge-1").
+		Return(&ports.WebAuthnChallenge{Challenge: "challenge-1", UserID: "user-123", Purpose: ports.WebAuthnPurposeRegistration}, nil)
+	mockVerifier.EXPECT().
+		VerifyRegistration(ports.WebAuthnAttestation{ClientDataJSON: []byte("client-data"), AttestationObject: []byte("attestation-object")}, "challenge-1", true).
+		Return(&ports.WebAuthnCredential{ID: []byte("credential-2"), PublicKey: []byte("cose-key-2"), UserVerified: true}, nil)
+	mockRepo.EXPECT().Save(ctx, domainUser).Return(nil)
+	mockPublisher.EXPECT().
+		Publish(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, events []shared.DomainEvent) error {
+			require.Len(t, events, 1)
+			assert.Equal(t, user.EventTypePasskeyRegistered, events[0].EventType())
+			return nil
+		})
+
+	useCase := NewRegisterPasskeyUseCase(mockRepo, mockVerifier, mockChallenges, mockPublisher, testChallengeTTL)
+
+	result, err := useCase.Finish(ctx, &ports.TokenClaims{UserID: "user-123"}, newRegistrationRequest(""))
+
+	require.NoError(t, err)
+	assert.Equal(t, encodeBase64URL([]byte("credential-2")), result.ID)
+	assert.Equal(t, "Passkey", result.Name)
+	assert.Equal(t, 2, domainUser.Passkeys().Len())
+}
+
+func TestRegisterPasskeyUseCase_Finish_Rejected(t *testing.T) {
+	tests := []struct {
+		name        string
+		registered  bool // credential-2 is already on the account
+		challenge   *ports.WebAuthnChallenge
+		verifyErr   error
+		credential  *ports.WebAuthnCredential
+		expectedErr error
+	}{
+		{
+			name:        "challenge issued to another user",
+			challenge:   &ports.WebAuthnChallenge{Challenge: "challenge-1", UserID: "user-456", Purpose: ports.WebAuthnPurposeRegistration},
+			expectedErr: shared.ErrPasskeyChallengeInvalid,
+		},
+		{
+			name:        "challenge issued for login",
+			challenge:   &ports.WebAuthnChallenge{Challenge: "challenge-1", Purpose: ports.WebAuthnPurposeLogin},
+			expectedErr: shared.ErrPasskeyChallengeInvalid,
+		},
+		{
+			name:        "attestation rejected",
+			challenge:   &ports.WebAuthnChallenge{Challenge: "challenge-1", UserID: "user-123", Purpose: ports.WebAuthnPurposeRegistration},
+			verifyErr:   errors.New("passkey response could not be verified: origin mismatch"),
+			expectedErr: shared.ErrPasskeyVerification,
+		},
+		{
+			name:        "credential ID does not match",
+			challenge:   &ports.WebAuthnChallenge{Challenge: "challenge-1", UserID: "user-123", Purpose: ports.WebAuthnPurposeRegistration},
+			credential:  &ports.WebAuthnCredential{ID: []byte("credential-3"), PublicKey: []byte("cose-key-3")},
+			expectedErr: shared.ErrInvalidPasskey,
+		},
+		{
+			name:        "already registered",
+			registered:  true,
+			challenge:   &ports.WebAuthnChallenge{Challenge: "challenge-1", UserID: "user-123", Purpose: ports.WebAuthnPurposeRegistration},
+			credential:  &ports.WebAuthnCredential{ID: []byte("credential-2"), PublicKey: []byte("cose-key-2")},
+			expectedErr: shared.ErrPasskeyAlreadyRegistered,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			mockRepo := mocks.NewMockRepository(ctrl)
+			mockVerifier := mocks.NewMockWebAuthnVerifier(ctrl)
+			mockChallenges := mocks.NewMockWebAuthnChallengeStore(ctrl)
+
+			domainUser := newPasskeyUser(t, 0)
+			if tt.registered {
+				existing, err := user.NewPasskey([]byte("credential-2"), []byte("cose-key-2"), 0, "Phone")
+				require.NoError(t, err)
+				require.NoError(t, domainUser.RegisterPasskey(existing))
+			}
+
+			mockRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+			mockVerifier.EXPECT().ClientChallenge(gomock.Any()).Return("challenge-1", nil)
+			mockChallenges.EXPECT().Consume(ctx, "challenge-1").Return(tt.challenge, nil)
+			if tt.verifyErr != nil || tt.credential != nil {
+				mockVerifier.EXPECT().VerifyRegistration(gomock.Any(), "challenge-1", true).Return(tt.credential, tt.verifyErr)
+			}
+
+			useCase := NewRegisterPasskeyUseCase(mockRepo, mockVerifier, mockChallenges, nil, testChallengeTTL)
+
+			result, err := useCase.Finish(ctx, &ports.TokenClaims{UserID: "user-123"}, newRegistrationRequest("Phone"))
+
+			assert.Nil(t, result)
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
+
+func TestListPasskeysUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	domainUser := newPasskeyUser(t, 0)
+
+	mockRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+
+	useCase := NewListPasskeysUseCase(mockRepo)
+
+	result, err := useCase.Execute(ctx, &ports.TokenClaims{UserID: "user-123"})
+
+	require.NoError(t, err)
+	require.Len(t, result.Passkeys, 1)
+	assert.Equal(t, encodeBase64URL(testCredentialID), result.Passkeys[0].ID)
+	assert.Equal(t, "Laptop", result.Passkeys[0].Name)
+	assert.Nil(t, result.Passkeys[0].LastUsedAt)
+}
+
+func TestRemovePasskeyUseCase(t *testing.T) {
+	ctx := context.Background()
+	claims := &ports.TokenClaims{UserID: "user-123"}
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockRepository(ctrl)
+		mockPublisher := mocks.NewMockEventPublisher(ctrl)
+		domainUser := newPasskeyUser(t, 0)
+
+		mockRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+		mockRepo.EXPECT().Save(ctx, domainUser).Return(nil)
+		mockPublisher.EXPECT().Publish(ctx, gomock.Any()).Return(nil)
+
+		useCase := NewRemovePasskeyUseCase(mockRepo, mockPublisher)
+
+		err := useCase.Execute(ctx, claims, encodeBase64URL(testCredentialID))
+
+		require.NoError(t, err)
+		assert.Zero(t, domainUser.Passkeys().Len())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockRepository(ctrl)
+		domainUser := newPasskeyUser(t, 0)
+
+		mockRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+
+		useCase := NewRemovePasskeyUseCase(mockRepo, nil)
+
+		err := useCase.Execute(ctx, claims, encodeBase64URL([]byte("unknown")))
+
+		assert.Equal(t, shared.ErrPasskeyNotFound, err)
+	})
+
+	t.Run("malformed ID", func(t *testing.T) {
+		useCase := NewRemovePasskeyUseCase(nil, nil)
+
+		err := useCase.Execute(ctx, claims, "not base64!")
+
+		assert.Equal(t, shared.ErrPasskeyNotFound, err)
+	})
+}
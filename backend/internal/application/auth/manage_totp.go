@@ -0,0 +1,255 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/events"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/mfa"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// EnrollTOTPUseCase starts TOTP enrollment for the current user
+type EnrollTOTPUseCase struct {
+	userRepo user.Repository
+	mfaRepo  mfa.Repository
+	totp     ports.TOTPService
+}
+
+// NewEnrollTOTPUseCase creates a new EnrollTOTPUseCase
+func NewEnrollTOTPUseCase(userRepo user.Repository, mfaRepo mfa.Repository, totp ports.TOTPService) *EnrollTOTPUseCase {
+	return &EnrollTOTPUseCase{
+		userRepo: userRepo,
+		mfaRepo:  mfaRepo,
+		totp:     totp,
+	}
+}
+
+// Execute generates a new secret, replacing any pending enrollment.
+// The enrollment only protects logins once confirmed with ConfirmTOTPUseCase.
+func (uc *EnrollTOTPUseCase) Execute(ctx context.Context, claims *ports.TokenClaims) (*dto.TOTPEnrollmentResponse, error) {
+	domainUser, err := findActiveUser(ctx, uc.userRepo, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.mfaRepo.FindByUserID(ctx, domainUser.ID())
+	if err != nil && err != shared.ErrMFANotEnabled {
+		return nil, fmt.Errorf("failed to retrieve MFA enrollment: %w", err)
+	}
+	if existing != nil && existing.IsConfirmed() {
+		return nil, shared.ErrMFAAlreadyEnabled
+	}
+
+	secret, err := uc.totp.GenerateSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate TOTP secret: %w", err)
+	}
+
+	enrollment, err := mfa.NewEnrollment(domainUser.ID(), secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.mfaRepo.Save(ctx, enrollment); err != nil {
+		return nil, fmt.Errorf("failed to save MFA enrollment: %w", err)
+	}
+
+	return &dto.TOTPEnrollmentResponse{
+		Secret:     uc.totp.EncodeSecret(secret),
+		OTPAuthURI: uc.totp.ProvisioningURI(secret, domainUser.Email().Value()),
+	}, nil
+}
+
+// ConfirmTOTPUseCase activates a pending TOTP enrollment
+type ConfirmTOTPUseCase struct {
+	mfaRepo        mfa.Repository
+	totp           ports.TOTPService
+	eventPublisher ports.EventPublisher
+}
+
+// NewConfirmTOTPUseCase creates a new ConfirmTOTPUseCase
+func NewConfirmTOTPUseCase(mfaRepo mfa.Repository, totp ports.TOTPService, eventPublisher ports.EventPublisher) *ConfirmTOTPUseCase {
+	return &ConfirmTOTPUseCase{
+		mfaRepo:        mfaRepo,
+		totp:           totp,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// Execute checks a code from the authenticator app and returns the recovery codes
+func (uc *ConfirmTOTPUseCase) Execute(ctx context.Context, claims *ports.TokenClaims, req dto.MFACodeRequest) (*dto.RecoveryCodesResponse, error) {
+	userID, err := user.NewUserID(claims.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID in token: %w", err)
+	}
+
+	enrollment, err := uc.mfaRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		if err == shared.ErrMFANotEnabled {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to retrieve MFA enrollment: %w", err)
+	}
+	if enrollment.IsConfirmed() {
+		return nil, shared.ErrMFAAlreadyEnabled
+	}
+
+	step, ok := uc.totp.Validate(enrollment.Secret(), req.Code, time.Now())
+	if !ok {
+		return nil, shared.ErrInvalidMFACode
+	}
+
+	codes, err := enrollment.Confirm(step)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.mfaRepo.Save(ctx, enrollment); err != nil {
+		return nil, fmt.Errorf("failed to save MFA enrollment: %w", err)
+	}
+	events.Publish(ctx, uc.eventPublisher, enrollment)
+
+	return &dto.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// DisableTOTPUseCase removes the current user's TOTP factor
+type DisableTOTPUseCase struct {
+	mfaRepo        mfa.Repository
+	totp           ports.TOTPService
+	eventPublisher ports.EventPublisher
+}
+
+// NewDisableTOTPUseCase creates a new DisableTOTPUseCase
+func NewDisableTOTPUseCase(mfaRepo mfa.Repository, totp ports.TOTPService, eventPublisher ports.EventPublisher) *DisableTOTPUseCase {
+	return &DisableTOTPUseCase{
+		mfaRepo:        mfaRepo,
+		totp:           totp,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// Execute removes the enrollment. A confirmed factor can only be removed with
+// a valid TOTP or recovery code; a pending enrollment is simply discarded.
+func (uc *DisableTOTPUseCase) Execute(ctx context.Context, claims *ports.TokenClaims, req dto.MFACodeRequest) error {
+	userID, err := user.NewUserID(claims.UserID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID in token: %w", err)
+	}
+
+	enrollment, err := uc.mfaRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		if err == shared.ErrMFANotEnabled {
+			return err
+		}
+		return fmt.Errorf("failed to retrieve MFA enrollment: %w", err)
+	}
+
+	if enrollment.IsConfirmed() {
+		if _, err := verifySecondFactor(uc.totp, enrollment, req, time.Now()); err != nil {
+			return err
+		}
+		if err := enrollment.Disable(); err != nil {
+			return err
+		}
+	}
+
+	if err := uc.mfaRepo.DeleteByUserID(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete MFA enrollment: %w", err)
+	}
+	events.Publish(ctx, uc.eventPublisher, enrollment)
+
+	return nil
+}
+
+// RegenerateRecoveryCodesUseCase replaces the current user's recovery codes
+type RegenerateRecoveryCodesUseCase struct {
+	mfaRepo        mfa.Repository
+	totp           ports.TOTPService
+	eventPublisher ports.EventPublisher
+}
+
+// NewRegenerateRecoveryCodesUseCase creates a new RegenerateRecoveryCodesUseCase
+func NewRegenerateRecoveryCodesUseCase(mfaRepo mfa.Repository, totp ports.TOTPService, eventPublisher ports.EventPublisher) *RegenerateRecoveryCodesUseCase {
+	return &RegenerateRecoveryCodesUseCase{
+		mfaRepo:        mfaRepo,
+		totp:           totp,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// Execute requires a current TOTP code and returns a fresh set of recovery codes
+func (uc *RegenerateRecoveryCodesUseCase) Execute(ctx context.Context, claims *ports.TokenClaims, req dto.MFACodeRequest) (*dto.RecoveryCodesResponse, error) {
+	userID, err := user.NewUserID(claims.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID in token: %w", err)
+	}
+
+	enrollment, err := findConfirmedEnrollment(ctx, uc.mfaRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifyTOTPCode(uc.totp, enrollment, req.Code, time.Now()); err != nil {
+		return nil, err
+	}
+
+	codes, err := enrollment.RegenerateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.mfaRepo.Save(ctx, enrollment); err != nil {
+		return nil, fmt.Errorf("failed to save MFA enrollment: %w", err)
+	}
+	events.Publish(ctx, uc.eventPublisher, enrollment)
+
+	return &dto.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// GetMFAStatusUseCase reports the current user's second factors
+type GetMFAStatusUseCase struct {
+	userRepo user.Repository
+	mfaRepo  mfa.Repository
+}
+
+// NewGetMFAStatusUseCase creates a new GetMFAStatusUseCase
+func NewGetMFAStatusUseCase(userRepo user.Repository, mfaRepo mfa.Repository) *GetMFAStatusUseCase {
+	return &GetMFAStatusUseCase{
+		userRepo: userRepo,
+		mfaRepo:  mfaRepo,
+	}
+}
+
+// Execute returns the MFA status of the user in the claims
+func (uc *GetMFAStatusUseCase) Execute(ctx context.Context, claims *ports.TokenClaims) (*dto.MFAStatusResponse, error) {
+	domainUser, err := findActiveUser(ctx, uc.userRepo, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &dto.MFAStatusResponse{
+		Passkeys: domainUser.Passkeys().Len(),
+	}
+
+	enrollment, err := uc.mfaRepo.FindByUserID(ctx, domainUser.ID())
+	if err != nil {
+		if err == shared.ErrMFANotEnabled {
+			return status, nil
+		}
+		return nil, fmt.Errorf("failed to retrieve MFA enrollment: %w", err)
+	}
+
+	status.TOTPEnabled = enrollment.IsConfirmed()
+	status.TOTPPending = !enrollment.IsConfirmed()
+	status.RecoveryCodesRemaining = enrollment.RecoveryCodes().Remaining()
+	if confirmedAt := enrollment.ConfirmedAt(); !confirmedAt.IsZero() {
+		status.EnabledAt = &confirmedAt
+	}
+
+	return status, nil
+}
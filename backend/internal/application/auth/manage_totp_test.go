@@ -0,0 +1,271 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/mfa"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+	"github.com/yuki5155/go-google-auth/internal/mocks"
+)
+
+func TestEnrollTOTPUseCase_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockTOTP := mocks.NewMockTOTPService(ctrl)
+
+	userID, _ := user.NewUserID("user-123")
+	email, _ := user.NewEmail("test@example.com", true)
+	domainUser, _ := user.NewUser(userID, email, user.NewProfile("Test User", ""))
+	secret := []byte("12345678901234567890")
+
+	mockRepo.EXPECT().FindByID(ctx, userID).Return(domainUser, nil)
+	mockMFARepo.EXPECT().FindByUserID(ctx, userID).Return(nil, shared.ErrMFANotEnabled)
+	mockTOTP.EXPECT().GenerateSecret().Return(secret, nil)
+	mockMFARepo.EXPECT().
+		Save(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, e *mfa.Enrollment) error {
+			assert.False(t, e.IsConfirmed())
+			assert.Equal(t, secret, e.Secret())
+			return nil
+		})
+	mockTOTP.EXPECT().EncodeSecret(secret).Return("ENCODED")
+	mockTOTP.EXPECT().ProvisioningURI(secret, "test@example.com").Return("otpauth://totp/test")
+
+	useCase := NewEnrollTOTPUseCase(mockRepo, mockMFARepo, mockTOTP)
+
+	result, err := useCase.Execute(ctx, &ports.TokenClaims{UserID: "user-123"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "ENCODED", result.Secret)
+	assert.Equal(t, "otpauth://totp/test", result.OTPAuthURI)
+}
+
+func TestEnrollTOTPUseCase_AlreadyEnabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockTOTP := mocks.NewMockTOTPService(ctrl)
+
+	userID, _ := user.NewUserID("user-123")
+	email, _ := user.NewEmail("test@example.com", true)
+	domainUser, _ := user.NewUser(userID, email, user.NewProfile("Test User", ""))
+	enrollment, _ := newConfirmedEnrollment(t, userID)
+
+	mockRepo.EXPECT().FindByID(ctx, userID).Return(domainUser, nil)
+	mockMFARepo.EXPECT().FindByUserID(ctx, userID).Return(enrollment, nil)
+
+	useCase := NewEnrollTOTPUseCase(mockRepo, mockMFARepo, mockTOTP)
+
+	result, err := useCase.Execute(ctx, &ports.TokenClaims{UserID: "user-123"})
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrMFAAlreadyEnabled, err)
+}
+
+func TestConfirmTOTPUseCase_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockTOTP := mocks.NewMockTOTPService(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
+
+	userID, _ := user.NewUserID("user-123")
+	enrollment, err := mfa.NewEnrollment(userID, []byte("12345678901234567890"))
+	require.NoError(t, err)
+
+	mockMFARepo.EXPECT().FindByUserID(ctx, userID).Return(enrollment, nil)
+	mockTOTP.EXPECT().Validate(gomock.Any(), "123456", gomock.Any()).Return(int64(7), true)
+	mockMFARepo.EXPECT().Save(ctx, enrollment).Return(nil)
+	mockPublisher.EXPECT().Publish(ctx, gomock.Any()).Return(nil)
+
+	useCase := NewConfirmTOTPUseCase(mockMFARepo, mockTOTP, mockPublisher)
+
+	result, err := useCase.Execute(ctx, &ports.TokenClaims{UserID: "user-123"}, dto.MFACodeRequest{Code: "123456"})
+
+	require.NoError(t, err)
+	assert.Len(t, result.RecoveryCodes, mfa.RecoveryCodeCount)
+	assert.True(t, enrollment.IsConfirmed())
+	assert.Equal(t, int64(7), enrollment.LastUsedStep())
+}
+
+func TestConfirmTOTPUseCase_InvalidCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockTOTP := mocks.NewMockTOTPService(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
+
+	userID, _ := user.NewUserID("user-123")
+	enrollment, err := mfa.NewEnrollment(userID, []byte("12345678901234567890"))
+	require.NoError(t, err)
+
+	mockMFARepo.EXPECT().FindByUserID(ctx, userID).Return(enrollment, nil)
+	mockTOTP.EXPECT().Validate(gomock.Any(), "000000", gomock.Any()).Return(int64(0), false)
+
+	useCase := NewConfirmTOTPUseCase(mockMFARepo, mockTOTP, mockPublisher)
+
+	result, err := useCase.Execute(ctx, &ports.TokenClaims{UserID: "user-123"}, dto.MFACodeRequest{Code: "000000"})
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrInvalidMFACode, err)
+	assert.False(t, enrollment.IsConfirmed())
+}
+
+func TestDisableTOTPUseCase_RequiresCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockTOTP := mocks.NewMockTOTPService(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
+
+	userID, _ := user.NewUserID("user-123")
+	enrollment, _ := newConfirmedEnrollment(t, userID)
+
+	mockMFARepo.EXPECT().FindByUserID(ctx, userID).Return(enrollment, nil)
+
+	useCase := NewDisableTOTPUseCase(mockMFARepo, mockTOTP, mockPublisher)
+
+	err := useCase.Execute(ctx, &ports.TokenClaims{UserID: "user-123"}, dto.MFACodeRequest{})
+
+	assert.Equal(t, shared.ErrInvalidMFACode, err)
+}
+
+func TestDisableTOTPUseCase_WithRecoveryCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockTOTP := mocks.NewMockTOTPService(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
+
+	userID, _ := user.NewUserID("user-123")
+	enrollment, codes := newConfirmedEnrollment(t, userID)
+
+	mockMFARepo.EXPECT().FindByUserID(ctx, userID).Return(enrollment, nil)
+	mockMFARepo.EXPECT().DeleteByUserID(ctx, userID).Return(nil)
+	mockPublisher.EXPECT().
+		Publish(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, events []shared.DomainEvent) error {
+			// recovery_code_used and totp_disabled
+			assert.Len(t, events, 2)
+			return nil
+		})
+
+	useCase := NewDisableTOTPUseCase(mockMFARepo, mockTOTP, mockPublisher)
+
+	err := useCase.Execute(ctx, &ports.TokenClaims{UserID: "user-123"}, dto.MFACodeRequest{RecoveryCode: codes[0]})
+
+	require.NoError(t, err)
+}
+
+func TestDisableTOTPUseCase_PendingEnrollment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockTOTP := mocks.NewMockTOTPService(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
+
+	userID, _ := user.NewUserID("user-123")
+	enrollment, err := mfa.NewEnrollment(userID, []byte("12345678901234567890"))
+	require.NoError(t, err)
+
+	mockMFARepo.EXPECT().FindByUserID(ctx, userID).Return(enrollment, nil)
+	mockMFARepo.EXPECT().DeleteByUserID(ctx, userID).Return(nil)
+
+	useCase := NewDisableTOTPUseCase(mockMFARepo, mockTOTP, mockPublisher)
+
+	err = useCase.Execute(ctx, &ports.TokenClaims{UserID: "user-123"}, dto.MFACodeRequest{})
+
+	require.NoError(t, err)
+}
+
+func TestRegenerateRecoveryCodesUseCase_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockTOTP := mocks.NewMockTOTPService(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
+
+	userID, _ := user.NewUserID("user-123")
+	enrollment, oldCodes := newConfirmedEnrollment(t, userID)
+
+	mockMFARepo.EXPECT().FindByUserID(ctx, userID).Return(enrollment, nil)
+	mockTOTP.EXPECT().Validate(gomock.Any(), "123456", gomock.Any()).Return(int64(2), true)
+	mockMFARepo.EXPECT().Save(ctx, enrollment).Return(nil)
+	mockPublisher.EXPECT().Publish(ctx, gomock.Any()).Return(nil)
+
+	useCase := NewRegenerateRecoveryCodesUseCase(mockMFARepo, mockTOTP, mockPublisher)
+
+	result, err := useCase.Execute(ctx, &ports.TokenClaims{UserID: "user-123"}, dto.MFACodeRequest{Code: "123456"})
+
+	require.NoError(t, err)
+	assert.Len(t, result.RecoveryCodes, mfa.RecoveryCodeCount)
+	assert.Error(t, enrollment.UseRecoveryCode(oldCodes[0]))
+}
+
+func TestGetMFAStatusUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	userID, _ := user.NewUserID("user-123")
+	email, _ := user.NewEmail("test@example.com", true)
+	domainUser, _ := user.NewUser(userID, email, user.NewProfile("Test User", ""))
+	claims := &ports.TokenClaims{UserID: "user-123"}
+	useCase := NewGetMFAStatusUseCase(mockRepo, mockMFARepo)
+
+	t.Run("not enrolled", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(ctx, userID).Return(domainUser, nil)
+		mockMFARepo.EXPECT().FindByUserID(ctx, userID).Return(nil, shared.ErrMFANotEnabled)
+
+		status, err := useCase.Execute(ctx, claims)
+
+		require.NoError(t, err)
+		assert.False(t, status.TOTPEnabled)
+		assert.False(t, status.TOTPPending)
+		assert.Nil(t, status.EnabledAt)
+		assert.Zero(t, status.Passkeys)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		enrollment, _ := newConfirmedEnrollment(t, userID)
+		mockRepo.EXPECT().FindByID(ctx, userID).Return(domainUser, nil)
+		mockMFARepo.EXPECT().FindByUserID(ctx, userID).Return(enrollment, nil)
+
+		status, err := useCase.Execute(ctx, claims)
+
+		require.NoError(t, err)
+		assert.True(t, status.TOTPEnabled)
+		assert.False(t, status.TOTPPending)
+		assert.NotNil(t, status.EnabledAt)
+		assert.Equal(t, mfa.RecoveryCodeCount, status.RecoveryCodesRemaining)
+	})
+}
@@ -0,0 +1,192 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// Values used in WebAuthn options
+const (
+	publicKeyCredentialType = "public-key"
+
+	userVerificationRequired  = "required"
+	userVerificationPreferred = "preferred"
+
+	residentKeyRequired = "required"
+	attestationNone     = "none"
+
+	defaultPasskeyName = "Passkey"
+)
+
+// decodeBase64URL decodes a binary WebAuthn value, tolerating padding
+func decodeBase64URL(value string) ([]byte, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil || len(decoded) == 0 {
+		return nil, shared.ErrInvalidPasskey
+	}
+	return decoded, nil
+}
+
+// encodeBase64URL encodes a binary WebAuthn value as unpadded base64url
+func encodeBase64URL(value []byte) string {
+	return base64.RawURLEncoding.EncodeToString(value)
+}
+
+// issueChallenge creates and stores a challenge for a ceremony
+func issueChallenge(
+	ctx context.Context,
+	verifier ports.WebAuthnVerifier,
+	challenges ports.WebAuthnChallengeStore,
+	userID, purpose string,
+	ttl time.Duration,
+) (string, error) {
+	challenge, err := verifier.NewChallenge()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate passkey challenge: %w", err)
+	}
+
+	err = challenges.Save(ctx, ports.WebAuthnChallenge{
+		Challenge: challenge,
+		UserID:    userID,
+		Purpose:   purpose,
+		ExpiresAt: time.Now().Add(ttl),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to save passkey challenge: %w", err)
+	}
+
+	return challenge, nil
+}
+
+// consumeChallenge takes the challenge the client signed out of the store,
+// requiring it to have been issued to the same user for the same ceremony
+func consumeChallenge(
+	ctx context.Context,
+	verifier ports.WebAuthnVerifier,
+	challenges ports.WebAuthnChallengeStore,
+	clientDataJSON []byte,
+	userID, purpose string,
+) (string, error) {
+	challenge, err := verifier.ClientChallenge(clientDataJSON)
+	if err != nil {
+		return "", shared.ErrPasskeyVerification
+	}
+
+	stored, err := challenges.Consume(ctx, challenge)
+	if err != nil {
+		if err == shared.ErrPasskeyChallengeInvalid {
+			return "", err
+		}
+		return "", fmt.Errorf("failed to retrieve passkey challenge: %w", err)
+	}
+
+	if stored.Purpose != purpose || stored.UserID != userID {
+		return "", shared.ErrPasskeyChallengeInvalid
+	}
+
+	return stored.Challenge, nil
+}
+
+// credentialDescriptors lists a user's passkeys for allow and exclude lists
+func credentialDescriptors(passkeys user.Passkeys) []dto.PasskeyCredentialDescriptor {
+	descriptors := make([]dto.PasskeyCredentialDescriptor, 0, passkeys.Len())
+	for _, passkey := range passkeys.Values() {
+		descriptors = append(descriptors, dto.PasskeyCredentialDescriptor{
+			Type: publicKeyCredentialType,
+			ID:   passkey.ID(),
+		})
+	}
+	return descriptors
+}
+
+// requestOptions builds the options for navigator.credentials.get()
+func requestOptions(verifier ports.WebAuthnVerifier, challenge string, ttl time.Duration, allow []dto.PasskeyCredentialDescriptor, userVerification string) *dto.PasskeyRequestOptions {
+	return &dto.PasskeyRequestOptions{
+		Challenge:        challenge,
+		Timeout:          ttl.Milliseconds(),
+		RPID:             verifier.RelyingPartyID(),
+		AllowCredentials: allow,
+		UserVerification: userVerification,
+	}
+}
+
+// assertionFromRequest decodes an assertion sent by the browser
+func assertionFromRequest(req dto.PasskeyAssertionRequest) (ports.WebAuthnAssertion, error) {
+	if req.Type != publicKeyCredentialType {
+		return ports.WebAuthnAssertion{}, shared.ErrInvalidPasskey
+	}
+
+	credentialID, err := decodeBase64URL(req.ID)
+	if err != nil {
+		return ports.WebAuthnAssertion{}, err
+	}
+	clientData, err := decodeBase64URL(req.Response.ClientDataJSON)
+	if err != nil {
+		return ports.WebAuthnAssertion{}, err
+	}
+	authData, err := decodeBase64URL(req.Response.AuthenticatorData)
+	if err != nil {
+		return ports.WebAuthnAssertion{}, err
+	}
+	signature, err := decodeBase64URL(req.Response.Signature)
+	if err != nil {
+		return ports.WebAuthnAssertion{}, err
+	}
+
+	assertion := ports.WebAuthnAssertion{
+		CredentialID:      credentialID,
+		ClientDataJSON:    clientData,
+		AuthenticatorData: authData,
+		Signature:         signature,
+	}
+	if req.Response.UserHandle != "" {
+		if assertion.UserHandle, err = decodeBase64URL(req.Response.UserHandle); err != nil {
+			return ports.WebAuthnAssertion{}, err
+		}
+	}
+
+	return assertion, nil
+}
+
+// verifyPasskeyAssertion checks an assertion against one of the user's
+// passkeys and records its signature counter. The caller must save the user.
+func verifyPasskeyAssertion(
+	verifier ports.WebAuthnVerifier,
+	domainUser *user.User,
+	assertion ports.WebAuthnAssertion,
+	challenge string,
+	requireUserVerification bool,
+) error {
+	passkey, exists := domainUser.Passkeys().Find(assertion.CredentialID)
+	if !exists {
+		return shared.ErrPasskeyVerification
+	}
+	if assertion.UserHandle != nil && !bytes.Equal(assertion.UserHandle, []byte(domainUser.ID().Value())) {
+		return shared.ErrPasskeyVerification
+	}
+
+	result, err := verifier.VerifyAssertion(assertion, challenge, passkey.PublicKey(), requireUserVerification)
+	if err != nil {
+		log.Printf("Passkey assertion rejected for user %s: %v", domainUser.ID().Value(), err)
+		return shared.ErrPasskeyVerification
+	}
+
+	if err := domainUser.UsePasskey(assertion.CredentialID, result.SignCount); err != nil {
+		if err == shared.ErrPasskeyCloned {
+			log.Printf("Passkey %s of user %s may be cloned: signature counter did not increase", passkey.ID(), domainUser.ID().Value())
+		}
+		return err
+	}
+
+	return nil
+}
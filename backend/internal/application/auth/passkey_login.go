@@ -0,0 +1,226 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/events"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// PasskeyLoginUseCase logs a user in with a passkey instead of Google
+type PasskeyLoginUseCase struct {
+	userRepo       user.Repository
+	sessionRepo    session.Repository
+	verifier       ports.WebAuthnVerifier
+	challenges     ports.WebAuthnChallengeStore
+	tokenGenerator ports.TokenGenerator
+	eventPublisher ports.EventPublisher
+	challengeTTL   time.Duration
+}
+
+// NewPasskeyLoginUseCase creates a new PasskeyLoginUseCase
+func NewPasskeyLoginUseCase(
+	userRepo user.Repository,
+	sessionRepo session.Repository,
+	verifier ports.WebAuthnVerifier,
+	challenges ports.WebAuthnChallengeStore,
+	tokenGenerator ports.TokenGenerator,
+	eventPublisher ports.EventPublisher,
+	challengeTTL time.Duration,
+) *PasskeyLoginUseCase {
+	return &PasskeyLoginUseCase{
+		userRepo:       userRepo,
+		sessionRepo:    sessionRepo,
+		verifier:       verifier,
+		challenges:     challenges,
+		tokenGenerator: tokenGenerator,
+		eventPublisher: eventPublisher,
+		challengeTTL:   challengeTTL,
+	}
+}
+
+// Begin returns the options for navigator.credentials.get(). The allow list
+// is empty because the user is not known yet: the browser offers the
+// discoverable credentials it holds for the RP ID.
+func (uc *PasskeyLoginUseCase) Begin(ctx context.Context) (*dto.PasskeyRequestOptions, error) {
+	challenge, err := issueChallenge(ctx, uc.verifier, uc.challenges, "", ports.WebAuthnPurposeLogin, uc.challengeTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return requestOptions(uc.verifier, challenge, uc.challengeTTL, []dto.PasskeyCredentialDescriptor{}, userVerificationRequired), nil
+}
+
+// Finish verifies the assertion and issues a token pair. A user-verified
+// passkey is a multi-factor credential on its own, so no further MFA step
+// is required.
+func (uc *PasskeyLoginUseCase) Finish(ctx context.Context, req dto.PasskeyAssertionRequest) (*dto.LoginResponse, error) {
+	assertion, err := assertionFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge, err := consumeChallenge(ctx, uc.verifier, uc.challenges, assertion.ClientDataJSON, "", ports.WebAuthnPurposeLogin)
+	if err != nil {
+		return nil, err
+	}
+
+	// Discoverable credentials return the user ID as the user handle
+	if len(assertion.UserHandle) == 0 {
+		return nil, shared.ErrPasskeyVerification
+	}
+	userID, err := user.NewUserID(string(assertion.UserHandle))
+	if err != nil {
+		return nil, shared.ErrPasskeyVerification
+	}
+
+	domainUser, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if err == shared.ErrUserNotFound {
+			return nil, shared.ErrPasskeyVerification
+		}
+		return nil, fmt.Errorf("failed to retrieve user: %w", err)
+	}
+
+	if err := verifyPasskeyAssertion(uc.verifier, domainUser, assertion, challenge, true); err != nil {
+		return nil, err
+	}
+
+	// Suspended or deleted accounts cannot log in
+	if err := domainUser.EnsureActive(); err != nil {
+		log.Printf("Passkey login rejected for inactive user %s: %v", userID.Value(), err)
+		return nil, err
+	}
+
+	domainUser.RecordLogin()
+	if err := uc.userRepo.Save(ctx, domainUser); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+	events.Publish(ctx, uc.eventPublisher, domainUser)
+
+	amr := []string{ports.AMRHardwareKey, ports.AMRMultiFactor}
+	accessToken, refreshToken, err := startSession(ctx, uc.sessionRepo, uc.tokenGenerator, domainUser, amr)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("User logged in with passkey: %s", domainUser.Email().Value())
+	return &dto.LoginResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		User:         dto.FromDomain(domainUser),
+		Message:      "Login successful",
+	}, nil
+}
+
+// PasskeyMFAUseCase completes an MFA-pending login with one of the user's passkeys
+type PasskeyMFAUseCase struct {
+	userRepo       user.Repository
+	sessionRepo    session.Repository
+	verifier       ports.WebAuthnVerifier
+	challenges     ports.WebAuthnChallengeStore
+	tokenGenerator ports.TokenGenerator
+	eventPublisher ports.EventPublisher
+	challengeTTL   time.Duration
+}
+
+// NewPasskeyMFAUseCase creates a new PasskeyMFAUseCase
+func NewPasskeyMFAUseCase(
+	userRepo user.Repository,
+	sessionRepo session.Repository,
+	verifier ports.WebAuthnVerifier,
+	challenges ports.WebAuthnChallengeStore,
+	tokenGenerator ports.TokenGenerator,
+	eventPublisher ports.EventPublisher,
+	challengeTTL time.Duration,
+) *PasskeyMFAUseCase {
+	return &PasskeyMFAUseCase{
+		userRepo:       userRepo,
+		sessionRepo:    sessionRepo,
+		verifier:       verifier,
+		challenges:     challenges,
+		tokenGenerator: tokenGenerator,
+		eventPublisher: eventPublisher,
+		challengeTTL:   challengeTTL,
+	}
+}
+
+// Begin returns the options for navigator.credentials.get(), allowing any of
+// the passkeys of the user the MFA-pending token was issued to
+func (uc *PasskeyMFAUseCase) Begin(ctx context.Context, mfaToken string) (*dto.PasskeyRequestOptions, error) {
+	claims, err := validateMFAToken(uc.tokenGenerator, mfaToken)
+	if err != nil {
+		return nil, err
+	}
+
+	domainUser, err := findActiveUser(ctx, uc.userRepo, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if domainUser.Passkeys().Len() == 0 {
+		return nil, shared.ErrMFANotEnabled
+	}
+
+	challenge, err := issueChallenge(ctx, uc.verifier, uc.challenges, domainUser.ID().Value(), ports.WebAuthnPurposeMFA, uc.challengeTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	allow := credentialDescriptors(domainUser.Passkeys())
+	return requestOptions(uc.verifier, challenge, uc.challengeTTL, allow, userVerificationPreferred), nil
+}
+
+// Finish verifies the assertion as the second factor and issues the token pair
+func (uc *PasskeyMFAUseCase) Finish(ctx context.Context, mfaToken string, req dto.PasskeyAssertionRequest) (*dto.LoginResponse, error) {
+	claims, err := validateMFAToken(uc.tokenGenerator, mfaToken)
+	if err != nil {
+		return nil, err
+	}
+
+	domainUser, err := findActiveUser(ctx, uc.userRepo, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	assertion, err := assertionFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge, err := consumeChallenge(ctx, uc.verifier, uc.challenges, assertion.ClientDataJSON, domainUser.ID().Value(), ports.WebAuthnPurposeMFA)
+	if err != nil {
+		return nil, err
+	}
+
+	// The first factor was already verified, so user verification is not required
+	if err := verifyPasskeyAssertion(uc.verifier, domainUser, assertion, challenge, false); err != nil {
+		return nil, err
+	}
+
+	domainUser.RecordLogin()
+	if err := uc.userRepo.Save(ctx, domainUser); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+	events.Publish(ctx, uc.eventPublisher, domainUser)
+
+	amr := secondFactorAMR(claims, ports.AMRHardwareKey)
+	accessToken, refreshToken, err := startSession(ctx, uc.sessionRepo, uc.tokenGenerator, domainUser, amr)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("User completed MFA login: %s (%s)", domainUser.Email().Value(), ports.AMRHardwareKey)
+	return &dto.LoginResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		User:         dto.FromDomain(domainUser),
+		Message:      "Login successful",
+	}, nil
+}
@@ -0,0 +1,354 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+	"github.com/yuki5155/go-google-auth/internal/mocks"
+)
+
+const testChallengeTTL = 5 * time.Minute
+
+var testCredentialID = []byte("credential-1")
+
+// newPasskeyUser returns a user with one registered passkey at the sign count
+func newPasskeyUser(t *testing.T, signCount uint32) *user.User {
+	t.Helper()
+
+	domainUser := newTestUser(t, "user-123")
+
+	passkey, err := user.NewPasskey(testCredentialID, []byte("cose-key"), signCount, "Laptop")
+	require.NoError(t, err)
+	require.NoError(t, domainUser.RegisterPasskey(passkey))
+	domainUser.ClearDomainEvents()
+
+	return domainUser
+}
+
+// newAssertionRequest builds an assertion as sent by the browser
+func newAssertionRequest(userHandle string) dto.PasskeyAssertionRequest {
+	return dto.PasskeyAssertionRequest{
+		ID:   encodeBase64URL(testCredentialID),
+		Type: "public-key",
+		Response: dto.PasskeyAssertionResponse{
+			ClientDataJSON:    encodeBase64URL([]byte("client-data")),
+			AuthenticatorData: encodeBase64URL([]byte("authenticator-data")),
+			Signature:         encodeBase64URL([]byte("signature")),
+			UserHandle:        userHandle,
+		},
+	}
+}
+
+func TestPasskeyLoginUseCase_Begin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockVerifier := mocks.NewMockWebAuthnVerifier(ctrl)
+	mockChallenges := mocks.NewMockWebAuthnChallengeStore(ctrl)
+
+	mockVerifier.EXPECT().NewChallenge().Return("challenge-1", nil)
+	mockVerifier.EXPECT().RelyingPartyID().Return("example.com")
+	mockChallenges.EXPECT().
+		Save(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, c ports.WebAuthnChallenge) error {
+			assert.Equal(t, "challenge-1", c.Challenge)
+			assert.Equal(t, ports.WebAuthnPurposeLogin, c.Purpose)
+			assert.Empty(t, c.UserID)
+			assert.True(t, c.ExpiresAt.After(time.Now()))
+			return nil
+		})
+
+	useCase := NewPasskeyLoginUseCase(nil, nil, mockVerifier, mockChallenges, nil, nil, testChallengeTTL)
+
+	options, err := useCase.Begin(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, "challenge-1", options.Challenge)
+	assert.Equal(t, "example.com", options.RPID)
+	assert.Equal(t, "required", options.UserVerification)
+	assert.Empty(t, options.AllowCredentials)
+	assert.Equal(t, testChallengeTTL.Milliseconds(), options.Timeout)
+}
+
+func TestPasskeyLoginUseCase_Finish_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockVerifier := mocks.NewMockWebAuthnVerifier(ctrl)
+	mockChallenges := mocks.NewMockWebAuthnChallengeStore(ctrl)
+	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
+
+	domainUser := newPasskeyUser(t, 4)
+
+	mockVerifier.EXPECT().ClientChallenge([]byte("client-data")).Return("challenge-1", nil)
+	mockChallenges.EXPECT().
+		Consume(ctx, "challenge-1").
+		Return(&ports.WebAuthnChallenge{Challenge: "challenge-1", Purpose: ports.WebAuthnPurposeLogin}, nil)
+	mockRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	mockVerifier.EXPECT().
+		VerifyAssertion(gomock.Any(), "challenge-1", []byte("cose-key"), true).
+		DoAndReturn(func(a ports.WebAuthnAssertion, _ string, _ []byte, _ bool) (*ports.WebAuthnAssertionResult, error) {
+			assert.Equal(t, testCredentialID, a.CredentialID)
+			assert.Equal(t, []byte("user-123"), a.UserHandle)
+			return &ports.WebAuthnAssertionResult{SignCount: 5, UserVerified: true}, nil
+		})
+	mockRepo.EXPECT().Save(ctx, domainUser).Return(nil)
+	mockPublisher.EXPECT().Publish(ctx, gomock.Any()).Return(nil)
+	mockTokenGen.EXPECT().GetRefreshTokenExpiry().Return(604800)
+	mockSessionRepo.EXPECT().Save(ctx, gomock.Any()).Return(nil)
+
+	var issued ports.UserInfo
+	mockTokenGen.EXPECT().
+		GenerateTokenPair(gomock.Any()).
+		DoAndReturn(func(info ports.UserInfo) (string, string, error) {
+			issued = info
+			return "access-token", "refresh-token", nil
+		})
+
+	useCase := NewPasskeyLoginUseCase(mockRepo, mockSessionRepo, mockVerifier, mockChallenges, mockTokenGen, mockPublisher, testChallengeTTL)
+
+	result, err := useCase.Finish(ctx, newAssertionRequest(encodeBase64URL([]byte("user-123"))))
+
+	require.NoError(t, err)
+	assert.Equal(t, "access-token", result.AccessToken)
+	assert.Equal(t, "refresh-token", result.RefreshToken)
+	assert.Equal(t, []string{ports.AMRHardwareKey, ports.AMRMultiFactor}, issued.AMR)
+
+	passkey, _ := domainUser.Passkeys().Find(testCredentialID)
+	assert.Equal(t, uint32(5), passkey.SignCount())
+	assert.False(t, passkey.LastUsedAt().IsZero())
+}
+
+func TestPasskeyLoginUseCase_Finish_ClonedAuthenticator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockVerifier := mocks.NewMockWebAuthnVerifier(ctrl)
+	mockChallenges := mocks.NewMockWebAuthnChallengeStore(ctrl)
+
+	domainUser := newPasskeyUser(t, 10)
+
+	mockVerifier.EXPECT().ClientChallenge(gomock.Any()).Return("challenge-1", nil)
+	mockChallenges.EXPECT().
+		Consume(ctx, "challenge-1").
+		Return(&ports.WebAuthnChallenge{Challenge: "challenge-1", Purpose: ports.WebAuthnPurposeLogin}, nil)
+	mockRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	mockVerifier.EXPECT().
+		VerifyAssertion(gomock.Any(), "challenge-1", gomock.Any(), true).
+		Return(&ports.WebAuthnAssertionResult{SignCount: 3, UserVerified: true}, nil)
+
+	// The user is not saved and no tokens are issued
+	useCase := NewPasskeyLoginUseCase(mockRepo, nil, mockVerifier, mockChallenges, nil, nil, testChallengeTTL)
+
+	result, err := useCase.Finish(ctx, newAssertionRequest(encodeBase64URL([]byte("user-123"))))
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrPasskeyCloned, err)
+}
+
+func TestPasskeyLoginUseCase_Finish_Rejected(t *testing.T) {
+	tests := []struct {
+		name        string
+		userHandle  string
+		challenge   *ports.WebAuthnChallenge
+		consumeErr  error
+		verifyErr   error
+		expectedErr error
+	}{
+		{
+			name:        "unknown challenge",
+			userHandle:  encodeBase64URL([]byte("user-123")),
+			consumeErr:  shared.ErrPasskeyChallengeInvalid,
+			expectedErr: shared.ErrPasskeyChallengeInvalid,
+		},
+		{
+			name:        "challenge issued for another ceremony",
+			userHandle:  encodeBase64URL([]byte("user-123")),
+			challenge:   &ports.WebAuthnChallenge{Challenge: "challenge-1", UserID: "user-123", Purpose: ports.WebAuthnPurposeMFA},
+			expectedErr: shared.ErrPasskeyChallengeInvalid,
+		},
+		{
+			name:        "missing user handle",
+			challenge:   &ports.WebAuthnChallenge{Challenge: "challenge-1", Purpose: ports.WebAuthnPurposeLogin},
+			expectedErr: shared.ErrPasskeyVerification,
+		},
+		{
+			name:        "invalid signature",
+			userHandle:  encodeBase64URL([]byte("user-123")),
+			challenge:   &ports.WebAuthnChallenge{Challenge: "challenge-1", Purpose: ports.WebAuthnPurposeLogin},
+			verifyErr:   errors.New("passkey response could not be verified: invalid signature"),
+			expectedErr: shared.ErrPasskeyVerification,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			mockRepo := mocks.NewMockRepository(ctrl)
+			mockVerifier := mocks.NewMockWebAuthnVerifier(ctrl)
+			mockChallenges := mocks.NewMockWebAuthnChallengeStore(ctrl)
+			domainUser := newPasskeyUser(t, 0)
+
+			mockVerifier.EXPECT().ClientChallenge(gomock.Any()).Return("challenge-1", nil)
+			mockChallenges.EXPECT().Consume(ctx, "challenge-1").Return(tt.challenge, tt.consumeErr)
+			if tt.verifyErr != nil {
+				mockRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+				mockVerifier.EXPECT().VerifyAssertion(gomock.Any(), "challenge-1", gomock.Any(), true).Return(nil, tt.verifyErr)
+			}
+
+			useCase := NewPasskeyLoginUseCase(mockRepo, nil, mockVerifier, mockChallenges, nil, nil, testChallengeTTL)
+
+			result, err := useCase.Finish(ctx, newAssertionRequest(tt.userHandle))
+
+			assert.Nil(t, result)
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
+
+func TestPasskeyMFAUseCase_Begin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockVerifier := mocks.NewMockWebAuthnVerifier(ctrl)
+	mockChallenges := mocks.NewMockWebAuthnChallengeStore(ctrl)
+	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+
+	domainUser := newPasskeyUser(t, 0)
+
+	mockTokenGen.EXPECT().ValidateMFAToken("mfa-token").Return(&ports.TokenClaims{UserID: "user-123"}, nil)
+	mockRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	mockVerifier.EXPECT().NewChallenge().Return("challenge-1", nil)
+	mockVerifier.EXPECT().RelyingPartyID().Return("example.com")
+	mockChallenges.EXPECT().
+		Save(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, c ports.WebAuthnChallenge) error {
+			assert.Equal(t, "user-123", c.UserID)
+			assert.Equal(t, ports.WebAuthnPurposeMFA, c.Purpose)
+			return nil
+		})
+
+	useCase := NewPasskeyMFAUseCase(mockRepo, nil, mockVerifier, mockChallenges, mockTokenGen, nil, testChallengeTTL)
+
+	options, err := useCase.Begin(ctx, "mfa-token")
+
+	require.NoError(t, err)
+	assert.Equal(t, "preferred", options.UserVerification)
+	require.Len(t, options.AllowCredentials, 1)
+	assert.Equal(t, encodeBase64URL(testCredentialID), options.AllowCredentials[0].ID)
+}
+
+func TestPasskeyMFAUseCase_Begin_NoPasskeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+
+	domainUser := newTestUser(t, "user-123")
+
+	mockTokenGen.EXPECT().ValidateMFAToken("mfa-token").Return(&ports.TokenClaims{UserID: "user-123"}, nil)
+	mockRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+
+	useCase := NewPasskeyMFAUseCase(mockRepo, nil, nil, nil, mockTokenGen, nil, testChallengeTTL)
+
+	options, err := useCase.Begin(ctx, "mfa-token")
+
+	assert.Nil(t, options)
+	assert.Equal(t, shared.ErrMFANotEnabled, err)
+}
+
+func TestPasskeyMFAUseCase_Finish_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockVerifier := mocks.NewMockWebAuthnVerifier(ctrl)
+	mockChallenges := mocks.NewMockWebAuthnChallengeStore(ctrl)
+	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
+
+	domainUser := newPasskeyUser(t, 0)
+
+	mockTokenGen.EXPECT().ValidateMFAToken("mfa-token").Return(&ports.TokenClaims{UserID: "user-123"}, nil)
+	mockRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	mockVerifier.EXPECT().ClientChallenge(gomock.Any()).Return("challenge-1", nil)
+	mockChallenges.EXPECT().
+		Consume(ctx, "challenge-1").
+		Return(&ports.WebAuthnChallenge{Challenge: "challenge-1", UserID: "user-123", Purpose: ports.WebAuthnPurposeMFA}, nil)
+	mockVerifier.EXPECT().
+		VerifyAssertion(gomock.Any(), "challenge-1", []byte("cose-key"), false).
+		Return(&ports.WebAuthnAssertionResult{SignCount: 0}, nil)
+	mockRepo.EXPECT().Save(ctx, domainUser).Return(nil)
+	mockPublisher.EXPECT().Publish(ctx, gomock.Any()).Return(nil)
+	mockTokenGen.EXPECT().GetRefreshTokenExpiry().Return(604800)
+	mockSessionRepo.EXPECT().Save(ctx, gomock.Any()).Return(nil)
+
+	var issued ports.UserInfo
+	mockTokenGen.EXPECT().
+		GenerateTokenPair(gomock.Any()).
+		DoAndReturn(func(info ports.UserInfo) (string, string, error) {
+			issued = info
+			return "access-token", "refresh-token", nil
+		})
+
+	useCase := NewPasskeyMFAUseCase(mockRepo, mockSessionRepo, mockVerifier, mockChallenges, mockTokenGen, mockPublisher, testChallengeTTL)
+
+	result, err := useCase.Finish(ctx, "mfa-token", newAssertionRequest(""))
+
+	require.NoError(t, err)
+	assert.Equal(t, "access-token", result.AccessToken)
+	assert.Equal(t, []string{ports.AMRFederated, ports.AMRHardwareKey, ports.AMRMultiFactor}, issued.AMR)
+}
+
+func TestPasskeyMFAUseCase_Finish_ChallengeForAnotherUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockVerifier := mocks.NewMockWebAuthnVerifier(ctrl)
+	mockChallenges := mocks.NewMockWebAuthnChallengeStore(ctrl)
+	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+
+	domainUser := newPasskeyUser(t, 0)
+
+	mockTokenGen.EXPECT().ValidateMFAToken("mfa-token").Return(&ports.TokenClaims{UserID: "user-123"}, nil)
+	mockRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	mockVerifier.EXPECT().ClientChallenge(gomock.Any()).Return("challenge-1", nil)
+	mockChallenges.EXPECT().
+		Consume(ctx, "challenge-1").
+		Return(&ports.WebAuthnChallenge{Challenge: "challenge-1", UserID: "user-456", Purpose: ports.WebAuthnPurposeMFA}, nil)
+
+	useCase := NewPasskeyMFAUseCase(mockRepo, nil, mockVerifier, mockChallenges, mockTokenGen, nil, testChallengeTTL)
+
+	result, err := useCase.Finish(ctx, "mfa-token", newAssertionRequest(""))
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrPasskeyChallengeInvalid, err)
+}
@@ -0,0 +1,316 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/events"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/mfa"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// StartReauthUseCase starts re-authentication for users who do not sign in
+// with Google: it emails them a link or issues a passkey challenge, which
+// ReauthenticateUseCase then verifies
+type StartReauthUseCase struct {
+	userRepo     user.Repository
+	linkTokens   ports.MagicLinkTokenService
+	mailer       ports.Mailer
+	verifier     ports.WebAuthnVerifier
+	challenges   ports.WebAuthnChallengeStore
+	linkURL      string
+	linkTTL      time.Duration
+	challengeTTL time.Duration
+}
+
+// NewStartReauthUseCase creates a new StartReauthUseCase; linkURL is the
+// frontend page re-authentication links point to
+func NewStartReauthUseCase(
+	userRepo user.Repository,
+	linkTokens ports.MagicLinkTokenService,
+	mailer ports.Mailer,
+	verifier ports.WebAuthnVerifier,
+	challenges ports.WebAuthnChallengeStore,
+	linkURL string,
+	linkTTL time.Duration,
+	challengeTTL time.Duration,
+) *StartReauthUseCase {
+	return &StartReauthUseCase{
+		userRepo:     userRepo,
+		linkTokens:   linkTokens,
+		mailer:       mailer,
+		verifier:     verifier,
+		challenges:   challenges,
+		linkURL:      linkURL,
+		linkTTL:      linkTTL,
+		challengeTTL: challengeTTL,
+	}
+}
+
+// SendEmailLink emails the signed-in user a single-use link whose token
+// re-authenticates them as the email_token of a ReauthRequest
+func (uc *StartReauthUseCase) SendEmailLink(ctx context.Context, claims *ports.TokenClaims) error {
+	if claims == nil {
+		return shared.ErrMissingToken
+	}
+
+	domainUser, err := findActiveUser(ctx, uc.userRepo, claims.UserID)
+	if err != nil {
+		return err
+	}
+
+	token, err := uc.linkTokens.Generate(domainUser.Email().Value(), time.Now().Add(uc.linkTTL))
+	if err != nil {
+		return fmt.Errorf("failed to generate re-authentication link: %w", err)
+	}
+	link, err := linkWithToken(uc.linkURL, token)
+	if err != nil {
+		return fmt.Errorf("invalid re-authentication link URL: %w", err)
+	}
+
+	message := ports.EmailMessage{
+		To:      domainUser.Email().Value(),
+		Subject: "Confirm it's you",
+		Body: fmt.Sprintf("Use this link to confirm it's you before changing your account. It expires in %s and can only be used once:\n\n%s\n\n"+
+			"If you did not request it, someone else may be signed in to your account.\n", uc.linkTTL, link),
+	}
+	if err := uc.mailer.Send(ctx, message); err != nil {
+		return fmt.Errorf("failed to send re-authentication link: %w", err)
+	}
+
+	log.Printf("Re-authentication link sent to user %s", domainUser.ID().Value())
+	return nil
+}
+
+// PasskeyOptions returns the options for navigator.credentials.get(),
+// allowing any of the signed-in user's passkeys. It returns
+// shared.ErrPasskeyNotFound for users without one.
+func (uc *StartReauthUseCase) PasskeyOptions(ctx context.Context, claims *ports.TokenClaims) (*dto.PasskeyRequestOptions, error) {
+	if claims == nil {
+		return nil, shared.ErrMissingToken
+	}
+
+	domainUser, err := findActiveUser(ctx, uc.userRepo, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if domainUser.Passkeys().Len() == 0 {
+		return nil, shared.ErrPasskeyNotFound
+	}
+
+	challenge, err := issueChallenge(ctx, uc.verifier, uc.challenges, domainUser.ID().Value(), ports.WebAuthnPurposeReauth, uc.challengeTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	allow := credentialDescriptors(domainUser.Passkeys())
+	return requestOptions(uc.verifier, challenge, uc.challengeTTL, allow, userVerificationRequired), nil
+}
+
+// ReauthenticateUseCase lets a signed-in user prove their identity again
+// before a sensitive operation. It refreshes auth_time on the current session
+// instead of starting a new one.
+type ReauthenticateUseCase struct {
+	userRepo       user.Repository
+	sessionRepo    session.Repository
+	mfaRepo        mfa.Repository
+	oauthValidator ports.OAuthValidator
+	linkTokens     ports.MagicLinkTokenService
+	usedLinks      ports.MagicLinkStore
+	verifier       ports.WebAuthnVerifier
+	challenges     ports.WebAuthnChallengeStore
+	tokenGenerator ports.TokenGenerator
+	totp           ports.TOTPService
+	eventPublisher ports.EventPublisher
+	clientID       string
+}
+
+// NewReauthenticateUseCase creates a new ReauthenticateUseCase
+func NewReauthenticateUseCase(
+	userRepo user.Repository,
+	sessionRepo session.Repository,
+	mfaRepo mfa.Repository,
+	oauthValidator ports.OAuthValidator,
+	linkTokens ports.MagicLinkTokenService,
+	usedLinks ports.MagicLinkStore,
+	verifier ports.WebAuthnVerifier,
+	challenges ports.WebAuthnChallengeStore,
+	tokenGenerator ports.TokenGenerator,
+	totp ports.TOTPService,
+	eventPublisher ports.EventPublisher,
+	clientID string,
+) *ReauthenticateUseCase {
+	return &ReauthenticateUseCase{
+		userRepo:       userRepo,
+		sessionRepo:    sessionRepo,
+		mfaRepo:        mfaRepo,
+		oauthValidator: oauthValidator,
+		linkTokens:     linkTokens,
+		usedLinks:      usedLinks,
+		verifier:       verifier,
+		challenges:     challenges,
+		tokenGenerator: tokenGenerator,
+		totp:           totp,
+		eventPublisher: eventPublisher,
+		clientID:       clientID,
+	}
+}
+
+// Execute verifies the credentials in req and reissues the session's token
+// pair with a fresh auth_time. A Google credential or email link and a
+// second-factor code may be combined to step up to a multi-factor acr; a
+// user-verified passkey is multi-factor on its own.
+func (uc *ReauthenticateUseCase) Execute(ctx context.Context, claims *ports.TokenClaims, req dto.ReauthRequest) (*dto.LoginResponse, error) {
+	if claims == nil {
+		return nil, shared.ErrMissingToken
+	}
+	if req.Credential == "" && req.Code == "" && req.RecoveryCode == "" && req.EmailToken == "" && req.Passkey == nil {
+		return nil, shared.ErrMissingCredential
+	}
+	// Tokens issued before sessions existed have no session to refresh
+	if claims.SessionID == "" {
+		return nil, shared.ErrSessionRevoked
+	}
+
+	domainUser, err := findActiveUser(ctx, uc.userRepo, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if _, err := findActiveSession(ctx, uc.sessionRepo, claims, now); err != nil {
+		return nil, err
+	}
+
+	var amr []string
+	if req.Credential != "" {
+		if err := uc.verifyGoogleCredential(ctx, domainUser, req.Credential); err != nil {
+			return nil, err
+		}
+		amr = append(amr, ports.AMRFederated)
+	}
+
+	if req.EmailToken != "" {
+		if err := uc.verifyEmailToken(ctx, domainUser, req.EmailToken, now); err != nil {
+			return nil, err
+		}
+		amr = append(amr, ports.AMREmailLink)
+	}
+
+	if req.Passkey != nil {
+		if err := uc.verifyPasskey(ctx, domainUser, *req.Passkey); err != nil {
+			return nil, err
+		}
+		amr = append(amr, ports.AMRHardwareKey)
+	}
+
+	if req.Code != "" || req.RecoveryCode != "" {
+		method, err := uc.verifyCode(ctx, domainUser, dto.MFACodeRequest{Code: req.Code, RecoveryCode: req.RecoveryCode}, now)
+		if err != nil {
+			return nil, err
+		}
+		amr = append(amr, method)
+	}
+
+	if len(amr) > 1 || req.Passkey != nil {
+		amr = append(amr, ports.AMRMultiFactor)
+	}
+
+	accessToken, refreshToken, err := issueSessionTokens(uc.tokenGenerator, domainUser, claims.SessionID, amr, now)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("User re-authenticated: %s (%v)", domainUser.Email().Value(), amr)
+	return &dto.LoginResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		User:         dto.FromDomain(domainUser),
+		Message:      "Re-authentication successful",
+	}, nil
+}
+
+// verifyGoogleCredential checks that a Google ID token was issued to the signed-in user
+func (uc *ReauthenticateUseCase) verifyGoogleCredential(ctx context.Context, domainUser *user.User, credential string) error {
+	oauthUser, err := uc.oauthValidator.ValidateToken(ctx, credential, uc.clientID)
+	if err != nil {
+		log.Printf("Failed to verify Google ID token: %v", err)
+		return fmt.Errorf("failed to verify Google ID token: %w", err)
+	}
+
+	if oauthUser.UserID != domainUser.ID().Value() {
+		return shared.ErrCredentialMismatch
+	}
+
+	return nil
+}
+
+// verifyEmailToken consumes a link token that was sent to the signed-in
+// user's address
+func (uc *ReauthenticateUseCase) verifyEmailToken(ctx context.Context, domainUser *user.User, token string, now time.Time) error {
+	claims, err := uc.linkTokens.Verify(token, now)
+	if err != nil {
+		return shared.ErrInvalidMagicLink
+	}
+	if claims.Email != domainUser.Email().Value() {
+		return shared.ErrCredentialMismatch
+	}
+
+	if err := uc.usedLinks.MarkUsed(ctx, claims.ID, claims.ExpiresAt); err != nil {
+		if err == shared.ErrInvalidMagicLink {
+			return err
+		}
+		return fmt.Errorf("failed to record login link use: %w", err)
+	}
+	return nil
+}
+
+// verifyPasskey checks a user-verified assertion against a challenge from
+// StartReauthUseCase.PasskeyOptions and saves the passkey's sign count
+func (uc *ReauthenticateUseCase) verifyPasskey(ctx context.Context, domainUser *user.User, req dto.PasskeyAssertionRequest) error {
+	assertion, err := assertionFromRequest(req)
+	if err != nil {
+		return err
+	}
+
+	challenge, err := consumeChallenge(ctx, uc.verifier, uc.challenges, assertion.ClientDataJSON, domainUser.ID().Value(), ports.WebAuthnPurposeReauth)
+	if err != nil {
+		return err
+	}
+
+	if err := verifyPasskeyAssertion(uc.verifier, domainUser, assertion, challenge, true); err != nil {
+		return err
+	}
+
+	if err := uc.userRepo.Save(ctx, domainUser); err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+	return nil
+}
+
+// verifyCode checks a TOTP or recovery code and saves the enrollment so the
+// code cannot be replayed
+func (uc *ReauthenticateUseCase) verifyCode(ctx context.Context, domainUser *user.User, req dto.MFACodeRequest, now time.Time) (string, error) {
+	enrollment, err := findConfirmedEnrollment(ctx, uc.mfaRepo, domainUser.ID())
+	if err != nil {
+		return "", err
+	}
+
+	method, err := verifySecondFactor(uc.totp, enrollment, req, now)
+	if err != nil {
+		return "", err
+	}
+
+	if err := uc.mfaRepo.Save(ctx, enrollment); err != nil {
+		return "", fmt.Errorf("failed to update MFA enrollment: %w", err)
+	}
+	events.Publish(ctx, uc.eventPublisher, enrollment)
+
+	return method, nil
+}
@@ -0,0 +1,376 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+func (m *authMocks) reauthUseCase() *ReauthenticateUseCase {
+	return NewReauthenticateUseCase(m.userRepo, m.sessionRepo, m.mfaRepo, m.oauthValidator, m.linkTokens, m.usedLinks,
+		m.verifier, m.challenges, m.tokenGenerator, m.totp, m.eventPublisher, "test-client-id")
+}
+
+func (m *authMocks) startReauthUseCase() *StartReauthUseCase {
+	return NewStartReauthUseCase(m.userRepo, m.linkTokens, m.mailer, m.verifier, m.challenges,
+		"https://app.example.com/reauth", 15*time.Minute, testChallengeTTL)
+}
+
+// newReauthTestUser returns a user with an active login session
+func newReauthTestUser(t *testing.T) (*user.User, *session.Session) {
+	t.Helper()
+
+	domainUser := newTestUser(t, "user-123")
+
+	loginSession, err := session.NewSession(domainUser.ID(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	return domainUser, loginSession
+}
+
+func TestReauthenticateUseCase_GoogleCredential_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser, loginSession := newReauthTestUser(t)
+	claims := &ports.TokenClaims{UserID: "user-123", SessionID: loginSession.ID().Value()}
+
+	m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	m.sessionRepo.EXPECT().FindByID(ctx, loginSession.ID()).Return(loginSession, nil)
+	m.oauthValidator.EXPECT().
+		ValidateToken(ctx, "google-credential", "test-client-id").
+		Return(&ports.OAuthUserInfo{UserID: "user-123", Email: "test@example.com", EmailVerified: true}, nil)
+
+	var issued ports.UserInfo
+	m.tokenGenerator.EXPECT().
+		GenerateTokenPair(gomock.Any()).
+		DoAndReturn(func(info ports.UserInfo) (string, string, error) {
+			issued = info
+			return "access-token", "refresh-token", nil
+		})
+
+	result, err := m.reauthUseCase().Execute(ctx, claims, dto.ReauthRequest{Credential: "google-credential"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "access-token", result.AccessToken)
+	assert.Equal(t, "refresh-token", result.RefreshToken)
+	// The existing session is kept and no login is recorded
+	assert.Equal(t, loginSession.ID().Value(), issued.SessionID)
+	assert.Equal(t, 0, result.User.LoginCount)
+	assert.Equal(t, []string{ports.AMRFederated}, issued.AMR)
+	assert.Equal(t, ports.ACRSingleFactor, issued.ACR)
+	assert.WithinDuration(t, time.Now(), issued.AuthTime, time.Second)
+}
+
+func TestReauthenticateUseCase_CredentialAndCode_StepsUpToMultiFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser, loginSession := newReauthTestUser(t)
+	enrollment, _ := newConfirmedEnrollment(t, domainUser.ID())
+	claims := &ports.TokenClaims{UserID: "user-123", SessionID: loginSession.ID().Value()}
+
+	m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	m.sessionRepo.EXPECT().FindByID(ctx, loginSession.ID()).Return(loginSession, nil)
+	m.oauthValidator.EXPECT().
+		ValidateToken(ctx, "google-credential", "test-client-id").
+		Return(&ports.OAuthUserInfo{UserID: "user-123"}, nil)
+	m.mfaRepo.EXPECT().FindByUserID(ctx, domainUser.ID()).Return(enrollment, nil)
+	m.totp.EXPECT().Validate(gomock.Any(), "123456", gomock.Any()).Return(int64(42), true)
+	m.mfaRepo.EXPECT().Save(ctx, enrollment).Return(nil)
+
+	var issued ports.UserInfo
+	m.tokenGenerator.EXPECT().
+		GenerateTokenPair(gomock.Any()).
+		DoAndReturn(func(info ports.UserInfo) (string, string, error) {
+			issued = info
+			return "access-token", "refresh-token", nil
+		})
+
+	_, err := m.reauthUseCase().Execute(ctx, claims, dto.ReauthRequest{Credential: "google-credential", Code: "123456"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{ports.AMRFederated, ports.AMROTP, ports.AMRMultiFactor}, issued.AMR)
+	assert.Equal(t, ports.ACRMultiFactor, issued.ACR)
+	assert.Equal(t, int64(42), enrollment.LastUsedStep())
+}
+
+func TestReauthenticateUseCase_CredentialForAnotherUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser, loginSession := newReauthTestUser(t)
+	claims := &ports.TokenClaims{UserID: "user-123", SessionID: loginSession.ID().Value()}
+
+	m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	m.sessionRepo.EXPECT().FindByID(ctx, loginSession.ID()).Return(loginSession, nil)
+	m.oauthValidator.EXPECT().
+		ValidateToken(ctx, "google-credential", "test-client-id").
+		Return(&ports.OAuthUserInfo{UserID: "other-user"}, nil)
+
+	result, err := m.reauthUseCase().Execute(ctx, claims, dto.ReauthRequest{Credential: "google-credential"})
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrCredentialMismatch, err)
+}
+
+func TestReauthenticateUseCase_RevokedSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser, loginSession := newReauthTestUser(t)
+	loginSession.Revoke(time.Now())
+	claims := &ports.TokenClaims{UserID: "user-123", SessionID: loginSession.ID().Value()}
+
+	m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	m.sessionRepo.EXPECT().FindByID(ctx, loginSession.ID()).Return(loginSession, nil)
+
+	result, err := m.reauthUseCase().Execute(ctx, claims, dto.ReauthRequest{Credential: "google-credential"})
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrSessionRevoked, err)
+}
+
+func TestReauthenticateUseCase_RejectsInvalidRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useCase := newAuthMocks(ctrl).reauthUseCase()
+
+	tests := []struct {
+		name    string
+		claims  *ports.TokenClaims
+		req     dto.ReauthRequest
+		wantErr error
+	}{
+		{"missing claims", nil, dto.ReauthRequest{Credential: "google-credential"}, shared.ErrMissingToken},
+		{"missing credential", &ports.TokenClaims{UserID: "user-123", SessionID: "session-1"}, dto.ReauthRequest{}, shared.ErrMissingCredential},
+		{"token without session", &ports.TokenClaims{UserID: "user-123"}, dto.ReauthRequest{Credential: "google-credential"}, shared.ErrSessionRevoked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := useCase.Execute(context.Background(), tt.claims, tt.req)
+
+			assert.Nil(t, result)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestReauthenticateUseCase_EmailToken_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser, loginSession := newReauthTestUser(t)
+	claims := &ports.TokenClaims{UserID: "user-123", SessionID: loginSession.ID().Value()}
+	linkExpiry := time.Now().Add(15 * time.Minute)
+
+	m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	m.sessionRepo.EXPECT().FindByID(ctx, loginSession.ID()).Return(loginSession, nil)
+	m.linkTokens.EXPECT().
+		Verify("link-token", gomock.Any()).
+		Return(&ports.MagicLinkClaims{ID: "link-1", Email: "test@example.com", ExpiresAt: linkExpiry}, nil)
+	m.usedLinks.EXPECT().MarkUsed(ctx, "link-1", linkExpiry).Return(nil)
+
+	var issued ports.UserInfo
+	m.tokenGenerator.EXPECT().
+		GenerateTokenPair(gomock.Any()).
+		DoAndReturn(func(info ports.UserInfo) (string, string, error) {
+			issued = info
+			return "access-token", "refresh-token", nil
+		})
+
+	result, err := m.reauthUseCase().Execute(ctx, claims, dto.ReauthRequest{EmailToken: "link-token"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "access-token", result.AccessToken)
+	assert.Equal(t, loginSession.ID().Value(), issued.SessionID)
+	assert.Equal(t, []string{ports.AMREmailLink}, issued.AMR)
+}
+
+func TestReauthenticateUseCase_EmailToken_Rejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		claims  *ports.MagicLinkClaims
+		verify  error
+		usedErr error
+		wantErr error
+	}{
+		{"invalid token", nil, shared.ErrInvalidMagicLink, nil, shared.ErrInvalidMagicLink},
+		{"sent to another address", &ports.MagicLinkClaims{ID: "link-1", Email: "other@example.com"}, nil, nil, shared.ErrCredentialMismatch},
+		{"already used", &ports.MagicLinkClaims{ID: "link-1", Email: "test@example.com"}, nil, shared.ErrInvalidMagicLink, shared.ErrInvalidMagicLink},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			m := newAuthMocks(ctrl)
+			domainUser, loginSession := newReauthTestUser(t)
+			claims := &ports.TokenClaims{UserID: "user-123", SessionID: loginSession.ID().Value()}
+
+			m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+			m.sessionRepo.EXPECT().FindByID(ctx, loginSession.ID()).Return(loginSession, nil)
+			m.linkTokens.EXPECT().Verify("link-token", gomock.Any()).Return(tt.claims, tt.verify)
+			if tt.claims != nil && tt.wantErr != shared.ErrCredentialMismatch {
+				m.usedLinks.EXPECT().MarkUsed(ctx, "link-1", gomock.Any()).Return(tt.usedErr)
+			}
+
+			result, err := m.reauthUseCase().Execute(ctx, claims, dto.ReauthRequest{EmailToken: "link-token"})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, result)
+		})
+	}
+}
+
+func TestReauthenticateUseCase_Passkey_IsMultiFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser := newPasskeyUser(t, 4)
+	_, loginSession := newReauthTestUser(t)
+	claims := &ports.TokenClaims{UserID: "user-123", SessionID: loginSession.ID().Value()}
+
+	m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	m.sessionRepo.EXPECT().FindByID(ctx, loginSession.ID()).Return(loginSession, nil)
+	m.verifier.EXPECT().ClientChallenge([]byte("client-data")).Return("challenge-1", nil)
+	m.challenges.EXPECT().
+		Consume(ctx, "challenge-1").
+		Return(&ports.WebAuthnChallenge{Challenge: "challenge-1", UserID: "user-123", Purpose: ports.WebAuthnPurposeReauth}, nil)
+	m.verifier.EXPECT().
+		VerifyAssertion(gomock.Any(), "challenge-1", []byte("cose-key"), true).
+		Return(&ports.WebAuthnAssertionResult{SignCount: 5, UserVerified: true}, nil)
+	m.userRepo.EXPECT().Save(ctx, domainUser).Return(nil)
+
+	var issued ports.UserInfo
+	m.tokenGenerator.EXPECT().
+		GenerateTokenPair(gomock.Any()).
+		DoAndReturn(func(info ports.UserInfo) (string, string, error) {
+			issued = info
+			return "access-token", "refresh-token", nil
+		})
+
+	passkey := newAssertionRequest("")
+	result, err := m.reauthUseCase().Execute(ctx, claims, dto.ReauthRequest{Passkey: &passkey})
+
+	require.NoError(t, err)
+	assert.Equal(t, "access-token", result.AccessToken)
+	assert.Equal(t, []string{ports.AMRHardwareKey, ports.AMRMultiFactor}, issued.AMR)
+	assert.Equal(t, ports.ACRMultiFactor, issued.ACR)
+}
+
+func TestReauthenticateUseCase_Passkey_ChallengeForAnotherCeremony(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser := newPasskeyUser(t, 4)
+	_, loginSession := newReauthTestUser(t)
+	claims := &ports.TokenClaims{UserID: "user-123", SessionID: loginSession.ID().Value()}
+
+	m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	m.sessionRepo.EXPECT().FindByID(ctx, loginSession.ID()).Return(loginSession, nil)
+	m.verifier.EXPECT().ClientChallenge(gomock.Any()).Return("challenge-1", nil)
+	m.challenges.EXPECT().
+		Consume(ctx, "challenge-1").
+		Return(&ports.WebAuthnChallenge{Challenge: "challenge-1", UserID: "user-123", Purpose: ports.WebAuthnPurposeMFA}, nil)
+
+	passkey := newAssertionRequest("")
+	result, err := m.reauthUseCase().Execute(ctx, claims, dto.ReauthRequest{Passkey: &passkey})
+
+	assert.ErrorIs(t, err, shared.ErrPasskeyChallengeInvalid)
+	assert.Nil(t, result)
+}
+
+func TestStartReauthUseCase_SendEmailLink(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser, _ := newReauthTestUser(t)
+
+	m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	m.linkTokens.EXPECT().Generate("test@example.com", gomock.Any()).Return("link-token", nil)
+	m.mailer.EXPECT().
+		Send(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, message ports.EmailMessage) error {
+			assert.Equal(t, "test@example.com", message.To)
+			assert.Contains(t, message.Body, "https://app.example.com/reauth?token=link-token")
+			return nil
+		})
+
+	err := m.startReauthUseCase().SendEmailLink(ctx, &ports.TokenClaims{UserID: "user-123"})
+
+	require.NoError(t, err)
+}
+
+func TestStartReauthUseCase_PasskeyOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser := newPasskeyUser(t, 4)
+
+	m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	m.verifier.EXPECT().NewChallenge().Return("challenge-1", nil)
+	m.verifier.EXPECT().RelyingPartyID().Return("example.com")
+	m.challenges.EXPECT().
+		Save(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, c ports.WebAuthnChallenge) error {
+			assert.Equal(t, "user-123", c.UserID)
+			assert.Equal(t, ports.WebAuthnPurposeReauth, c.Purpose)
+			return nil
+		})
+
+	options, err := m.startReauthUseCase().PasskeyOptions(ctx, &ports.TokenClaims{UserID: "user-123"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "challenge-1", options.Challenge)
+	assert.Equal(t, "required", options.UserVerification)
+	assert.Len(t, options.AllowCredentials, 1)
+}
+
+func TestStartReauthUseCase_PasskeyOptions_NoPasskeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser, _ := newReauthTestUser(t)
+
+	m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+
+	options, err := m.startReauthUseCase().PasskeyOptions(ctx, &ports.TokenClaims{UserID: "user-123"})
+
+	assert.ErrorIs(t, err, shared.ErrPasskeyNotFound)
+	assert.Nil(t, options)
+}
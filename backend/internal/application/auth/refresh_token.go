@@ -3,31 +3,66 @@ package auth
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/yuki5155/go-google-auth/internal/application/dto"
 	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
 )
 
 // RefreshTokenUseCase handles token refresh operations
 type RefreshTokenUseCase struct {
+	userRepo       user.Repository
+	sessionRepo    session.Repository
 	tokenGenerator ports.TokenGenerator
 }
 
 // NewRefreshTokenUseCase creates a new RefreshTokenUseCase
-func NewRefreshTokenUseCase(tokenGenerator ports.TokenGenerator) *RefreshTokenUseCase {
+func NewRefreshTokenUseCase(
+	userRepo user.Repository,
+	sessionRepo session.Repository,
+	tokenGenerator ports.TokenGenerator,
+) *RefreshTokenUseCase {
 	return &RefreshTokenUseCase{
+		userRepo:       userRepo,
+		sessionRepo:    sessionRepo,
 		tokenGenerator: tokenGenerator,
 	}
 }
 
-// Execute generates a new access token from a valid refresh token
-func (uc *RefreshTokenUseCase) Execute(ctx context.Context, refreshToken string) (*dto.RefreshResponse, error) {
+// Execute generates a new access token from a valid refresh token. dpopKey
+// is the thumbprint of the key the client proved possession of with a DPoP
+// proof, or "" without one. The first proof binds the session to its key;
+// after that every refresh needs a proof with the same key, and the new
+// access tokens are bound to it.
+func (uc *RefreshTokenUseCase) Execute(ctx context.Context, refreshToken, dpopKey string) (*dto.RefreshResponse, error) {
 	if refreshToken == "" {
 		return nil, fmt.Errorf("refresh token is required")
 	}
 
+	// Validate the refresh token to find out who it belongs to
+	claims, err := uc.tokenGenerator.ValidateRefreshToken(refreshToken)
+	if err != nil {
+		if ports.IsTokenExpired(err) {
+			return nil, ports.ErrExpiredToken
+		}
+		return nil, fmt.Errorf("invalid refresh token: %w", err)
+	}
+
+	// Suspended or deleted accounts cannot refresh their tokens
+	if err := uc.ensureActive(ctx, claims.UserID); err != nil {
+		return nil, err
+	}
+
+	// Revoked sessions cannot refresh their tokens
+	if err := uc.touchSession(ctx, claims, dpopKey); err != nil {
+		return nil, err
+	}
+
 	// Generate new access token from refresh token
-	newAccessToken, err := uc.tokenGenerator.RefreshAccessToken(refreshToken)
+	newAccessToken, err := uc.tokenGenerator.RefreshAccessToken(refreshToken, dpopKey)
 	if err != nil {
 		if ports.IsTokenExpired(err) {
 			return nil, ports.ErrExpiredToken
@@ -37,6 +72,77 @@ func (uc *RefreshTokenUseCase) Execute(ctx context.Context, refreshToken string)
 
 	return &dto.RefreshResponse{
 		AccessToken: newAccessToken,
+		DPoPBound:   dpopKey != "",
 		Message:     "Token refreshed successfully",
 	}, nil
 }
+
+// ensureActive checks that the refresh token's user still exists and is active
+func (uc *RefreshTokenUseCase) ensureActive(ctx context.Context, rawUserID string) error {
+	userID, err := user.NewUserID(rawUserID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID in token: %w", err)
+	}
+
+	domainUser, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if err == shared.ErrUserNotFound {
+			return shared.ErrUnauthorized
+		}
+		return fmt.Errorf("failed to retrieve user: %w", err)
+	}
+
+	return domainUser.EnsureActive()
+}
+
+// touchSession checks that the token's session is still active and bound to
+// dpopKey, if it is bound to a key, and records its use. Tokens issued before
+// sessions existed carry no session ID and are allowed through.
+func (uc *RefreshTokenUseCase) touchSession(ctx context.Context, claims *ports.TokenClaims, dpopKey string) error {
+	if claims.SessionID == "" {
+		return nil
+	}
+
+	now := time.Now()
+	loginSession, err := findActiveSession(ctx, uc.sessionRepo, claims, now)
+	if err != nil {
+		return err
+	}
+
+	if err := loginSession.BindDPoPKey(dpopKey); err != nil {
+		return err
+	}
+
+	loginSession.Touch(now)
+	if err := uc.sessionRepo.Save(ctx, loginSession); err != nil {
+		return fmt.Errorf("failed to update session: %w", err)
+	}
+
+	return nil
+}
+
+// findActiveSession loads the session a token belongs to, requiring it to be
+// active and owned by the token's user
+func findActiveSession(ctx context.Context, sessionRepo session.Repository, claims *ports.TokenClaims, now time.Time) (*session.Session, error) {
+	sessionID, err := session.NewSessionID(claims.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid session ID in token: %w", err)
+	}
+
+	loginSession, err := sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		if err == shared.ErrSessionNotFound {
+			return nil, shared.ErrSessionRevoked
+		}
+		return nil, fmt.Errorf("failed to retrieve session: %w", err)
+	}
+
+	if loginSession.UserID().Value() != claims.UserID {
+		return nil, shared.ErrSessionRevoked
+	}
+	if err := loginSession.EnsureActive(now); err != nil {
+		return nil, err
+	}
+
+	return loginSession, nil
+}
@@ -4,12 +4,16 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
 	"github.com/yuki5155/go-google-auth/internal/mocks"
 )
 
@@ -18,15 +22,26 @@ func TestRefreshTokenUseCase_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
 	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	domainUser := newTestUser(t, "test-user-123")
 
 	mockTokenGen.EXPECT().
-		RefreshAccessToken("valid-refresh-token").
+		ValidateRefreshToken("valid-refresh-token").
+		Return(&ports.TokenClaims{UserID: "test-user-123"}, nil)
+
+	mockRepo.EXPECT().
+		FindByID(ctx, domainUser.ID()).
+		Return(domainUser, nil)
+
+	mockTokenGen.EXPECT().
+		RefreshAccessToken("valid-refresh-token", "").
 		Return("new-access-token", nil)
 
-	useCase := NewRefreshTokenUseCase(mockTokenGen)
+	useCase := NewRefreshTokenUseCase(mockRepo, mockSessionRepo, mockTokenGen)
 
-	result, err := useCase.Execute(ctx, "valid-refresh-token")
+	result, err := useCase.Execute(ctx, "valid-refresh-token", "")
 
 	require.NoError(t, err)
 	assert.Equal(t, "new-access-token", result.AccessToken)
@@ -38,11 +53,13 @@ func TestRefreshTokenUseCase_EmptyToken(t *testing.T) {
 	defer ctrl.Finish()
 
 	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
 	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
 
-	useCase := NewRefreshTokenUseCase(mockTokenGen)
+	useCase := NewRefreshTokenUseCase(mockRepo, mockSessionRepo, mockTokenGen)
 
-	result, err := useCase.Execute(ctx, "")
+	result, err := useCase.Execute(ctx, "", "")
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -54,15 +71,17 @@ func TestRefreshTokenUseCase_InvalidToken(t *testing.T) {
 	defer ctrl.Finish()
 
 	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
 	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
 
 	mockTokenGen.EXPECT().
-		RefreshAccessToken("invalid-token").
-		Return("", errors.New("invalid refresh token"))
+		ValidateRefreshToken("invalid-token").
+		Return(nil, errors.New("invalid refresh token"))
 
-	useCase := NewRefreshTokenUseCase(mockTokenGen)
+	useCase := NewRefreshTokenUseCase(mockRepo, mockSessionRepo, mockTokenGen)
 
-	result, err := useCase.Execute(ctx, "invalid-token")
+	result, err := useCase.Execute(ctx, "invalid-token", "")
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -74,17 +93,245 @@ func TestRefreshTokenUseCase_ExpiredToken(t *testing.T) {
 	defer ctrl.Finish()
 
 	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
 	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
 
 	mockTokenGen.EXPECT().
-		RefreshAccessToken("expired-token").
-		Return("", ports.ErrExpiredToken)
+		ValidateRefreshToken("expired-token").
+		Return(nil, ports.ErrExpiredToken)
 
-	useCase := NewRefreshTokenUseCase(mockTokenGen)
+	useCase := NewRefreshTokenUseCase(mockRepo, mockSessionRepo, mockTokenGen)
 
-	result, err := useCase.Execute(ctx, "expired-token")
+	result, err := useCase.Execute(ctx, "expired-token", "")
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
 	assert.Equal(t, ports.ErrExpiredToken, err)
 }
+
+func TestRefreshTokenUseCase_SuspendedUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	domainUser := newTestUser(t, "test-user-123")
+	require.NoError(t, domainUser.Suspend("abuse"))
+
+	mockTokenGen.EXPECT().
+		ValidateRefreshToken("valid-refresh-token").
+		Return(&ports.TokenClaims{UserID: "test-user-123"}, nil)
+
+	mockRepo.EXPECT().
+		FindByID(ctx, domainUser.ID()).
+		Return(domainUser, nil)
+
+	useCase := NewRefreshTokenUseCase(mockRepo, mockSessionRepo, mockTokenGen)
+
+	result, err := useCase.Execute(ctx, "valid-refresh-token", "")
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrAccountSuspended, err)
+}
+
+func TestRefreshTokenUseCase_UserNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+
+	userID, _ := user.NewUserID("test-user-123")
+
+	mockTokenGen.EXPECT().
+		ValidateRefreshToken("valid-refresh-token").
+		Return(&ports.TokenClaims{UserID: "test-user-123"}, nil)
+
+	mockRepo.EXPECT().
+		FindByID(ctx, userID).
+		Return(nil, shared.ErrUserNotFound)
+
+	useCase := NewRefreshTokenUseCase(mockRepo, mockSessionRepo, mockTokenGen)
+
+	result, err := useCase.Execute(ctx, "valid-refresh-token", "")
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrUnauthorized, err)
+}
+
+func TestRefreshTokenUseCase_ActiveSessionIsTouched(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	domainUser := newTestUser(t, "test-user-123")
+
+	loginSession, err := session.NewSession(domainUser.ID(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	originalLastUsedAt := loginSession.LastUsedAt()
+
+	mockTokenGen.EXPECT().
+		ValidateRefreshToken("valid-refresh-token").
+		Return(&ports.TokenClaims{UserID: "test-user-123", SessionID: loginSession.ID().Value()}, nil)
+
+	mockRepo.EXPECT().
+		FindByID(ctx, domainUser.ID()).
+		Return(domainUser, nil)
+
+	mockSessionRepo.EXPECT().
+		FindByID(ctx, loginSession.ID()).
+		Return(loginSession, nil)
+
+	mockSessionRepo.EXPECT().
+		Save(ctx, loginSession).
+		Return(nil)
+
+	mockTokenGen.EXPECT().
+		RefreshAccessToken("valid-refresh-token", "").
+		Return("new-access-token", nil)
+
+	useCase := NewRefreshTokenUseCase(mockRepo, mockSessionRepo, mockTokenGen)
+
+	result, err := useCase.Execute(ctx, "valid-refresh-token", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "new-access-token", result.AccessToken)
+	assert.False(t, loginSession.LastUsedAt().Before(originalLastUsedAt))
+}
+
+func TestRefreshTokenUseCase_RevokedSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	domainUser := newTestUser(t, "test-user-123")
+
+	loginSession, err := session.NewSession(domainUser.ID(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	loginSession.Revoke(time.Now())
+
+	mockTokenGen.EXPECT().
+		ValidateRefreshToken("valid-refresh-token").
+		Return(&ports.TokenClaims{UserID: "test-user-123", SessionID: loginSession.ID().Value()}, nil)
+
+	mockRepo.EXPECT().
+		FindByID(ctx, domainUser.ID()).
+		Return(domainUser, nil)
+
+	mockSessionRepo.EXPECT().
+		FindByID(ctx, loginSession.ID()).
+		Return(loginSession, nil)
+
+	useCase := NewRefreshTokenUseCase(mockRepo, mockSessionRepo, mockTokenGen)
+
+	result, err := useCase.Execute(ctx, "valid-refresh-token", "")
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrSessionRevoked, err)
+}
+
+func TestRefreshTokenUseCase_UnknownSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	domainUser := newTestUser(t, "test-user-123")
+
+	mockTokenGen.EXPECT().
+		ValidateRefreshToken("valid-refresh-token").
+		Return(&ports.TokenClaims{UserID: "test-user-123", SessionID: "missing-session"}, nil)
+
+	mockRepo.EXPECT().
+		FindByID(ctx, domainUser.ID()).
+		Return(domainUser, nil)
+
+	mockSessionRepo.EXPECT().
+		FindByID(ctx, gomock.Any()).
+		Return(nil, shared.ErrSessionNotFound)
+
+	useCase := NewRefreshTokenUseCase(mockRepo, mockSessionRepo, mockTokenGen)
+
+	result, err := useCase.Execute(ctx, "valid-refresh-token", "")
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrSessionRevoked, err)
+}
+
+func TestRefreshTokenUseCase_DPoPBindsSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	domainUser := newTestUser(t, "test-user-123")
+
+	loginSession, err := session.NewSession(domainUser.ID(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	mockTokenGen.EXPECT().
+		ValidateRefreshToken("valid-refresh-token").
+		Return(&ports.TokenClaims{UserID: "test-user-123", SessionID: loginSession.ID().Value()}, nil)
+	mockRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	mockSessionRepo.EXPECT().FindByID(ctx, loginSession.ID()).Return(loginSession, nil)
+	mockSessionRepo.EXPECT().Save(ctx, loginSession).Return(nil)
+	mockTokenGen.EXPECT().
+		RefreshAccessToken("valid-refresh-token", "key-thumbprint").
+		Return("bound-access-token", nil)
+
+	useCase := NewRefreshTokenUseCase(mockRepo, mockSessionRepo, mockTokenGen)
+
+	result, err := useCase.Execute(ctx, "valid-refresh-token", "key-thumbprint")
+
+	require.NoError(t, err)
+	assert.Equal(t, "bound-access-token", result.AccessToken)
+	assert.True(t, result.DPoPBound)
+	assert.Equal(t, "key-thumbprint", loginSession.DPoPKey())
+}
+
+func TestRefreshTokenUseCase_DPoPBoundSessionRequiresItsKey(t *testing.T) {
+	for _, dpopKey := range []string{"", "other-key"} {
+		t.Run("key "+dpopKey, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			mockRepo := mocks.NewMockRepository(ctrl)
+			mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+			mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+			domainUser := newTestUser(t, "test-user-123")
+
+			loginSession, err := session.NewSession(domainUser.ID(), time.Now().Add(time.Hour))
+			require.NoError(t, err)
+			require.NoError(t, loginSession.BindDPoPKey("key-thumbprint"))
+
+			mockTokenGen.EXPECT().
+				ValidateRefreshToken("valid-refresh-token").
+				Return(&ports.TokenClaims{UserID: "test-user-123", SessionID: loginSession.ID().Value()}, nil)
+			mockRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+			mockSessionRepo.EXPECT().FindByID(ctx, loginSession.ID()).Return(loginSession, nil)
+
+			useCase := NewRefreshTokenUseCase(mockRepo, mockSessionRepo, mockTokenGen)
+
+			result, err := useCase.Execute(ctx, "valid-refresh-token", dpopKey)
+
+			assert.Nil(t, result)
+			assert.Equal(t, shared.ErrDPoPKeyMismatch, err)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/serviceclient"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+// Token endpoint authentication methods a client can register with (RFC 7591, section 2)
+const (
+	authMethodClientSecretBasic = "client_secret_basic"
+	authMethodClientSecretPost  = "client_secret_post"
+	authMethodNone              = "none"
+)
+
+// RegisterClientUseCase registers OpenID Connect relying parties (dynamic
+// client registration, RFC 7591). Registration needs an initial access token
+// shared with the teams allowed to register clients.
+type RegisterClientUseCase struct {
+	clientRepo         serviceclient.Repository
+	initialAccessToken string
+}
+
+// NewRegisterClientUseCase creates a new RegisterClientUseCase; registration
+// is disabled when initialAccessToken is empty
+func NewRegisterClientUseCase(clientRepo serviceclient.Repository, initialAccessToken string) *RegisterClientUseCase {
+	return &RegisterClientUseCase{
+		clientRepo:         clientRepo,
+		initialAccessToken: initialAccessToken,
+	}
+}
+
+// Execute registers a client, returning its generated client ID and, for
+// confidential clients, its secret
+func (uc *RegisterClientUseCase) Execute(ctx context.Context, accessToken string, req dto.ClientRegistrationRequest) (*dto.ClientRegistrationResponse, error) {
+	if uc.initialAccessToken == "" {
+		return nil, shared.ErrRegistrationDisabled
+	}
+	if subtle.ConstantTimeCompare([]byte(accessToken), []byte(uc.initialAccessToken)) != 1 {
+		return nil, shared.ErrUnauthorized
+	}
+
+	authMethod := req.TokenEndpointAuthMethod
+	if authMethod == "" {
+		authMethod = authMethodClientSecretBasic
+	}
+	if authMethod != authMethodClientSecretBasic && authMethod != authMethodClientSecretPost && authMethod != authMethodNone {
+		return nil, shared.ErrInvalidClientMetadata
+	}
+
+	scopes := strings.Fields(req.Scope)
+	if len(scopes) == 0 {
+		scopes = ports.OIDCScopes
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(ports.OIDCScopes, scope) {
+			return nil, shared.ErrInvalidClientMetadata
+		}
+	}
+	if !slices.Contains(scopes, ports.ScopeOpenID) {
+		return nil, shared.ErrInvalidClientMetadata
+	}
+
+	if len(req.RedirectURIs) == 0 {
+		return nil, shared.ErrInvalidRedirectURI
+	}
+
+	clientID, err := serviceclient.GenerateClientID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate client ID: %w", err)
+	}
+
+	var client *serviceclient.Client
+	var secret string
+	if authMethod == authMethodNone {
+		client, err = serviceclient.NewPublicClient(clientID, req.ClientName, scopes, req.RedirectURIs...)
+	} else {
+		secret, err = serviceclient.GenerateSecret()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate client secret: %w", err)
+		}
+		client, err = serviceclient.NewSecretClient(clientID, req.ClientName, serviceclient.HashSecret(secret), scopes, req.RedirectURIs...)
+	}
+	if err != nil {
+		if err == shared.ErrInvalidRedirectURI {
+			return nil, err
+		}
+		return nil, shared.ErrInvalidClientMetadata
+	}
+
+	if err := uc.clientRepo.Save(ctx, client); err != nil {
+		return nil, fmt.Errorf("failed to save client: %w", err)
+	}
+
+	log.Printf("Registered OpenID Connect client %s (%s)", client.ID(), client.Name())
+	return &dto.ClientRegistrationResponse{
+		ClientID:                client.ID(),
+		ClientSecret:            secret,
+		ClientIDIssuedAt:        time.Now().Unix(),
+		ClientName:              client.Name(),
+		RedirectURIs:            client.RedirectURIs(),
+		TokenEndpointAuthMethod: authMethod,
+		GrantTypes:              []string{"authorization_code"},
+		ResponseTypes:           []string{"code"},
+		Scope:                   strings.Join(client.Scopes(), " "),
+	}, nil
+}
@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/domain/serviceclient"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/mocks"
+)
+
+func TestRegisterClientUseCase_ConfidentialClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	clientRepo := mocks.NewMockServiceClientRepository(ctrl)
+
+	var saved *serviceclient.Client
+	clientRepo.EXPECT().Save(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, client *serviceclient.Client) error {
+		saved = client
+		return nil
+	})
+
+	result, err := NewRegisterClientUseCase(clientRepo, "initial-token").Execute(ctx, "initial-token", dto.ClientRegistrationRequest{
+		ClientName:   "Wiki",
+		RedirectURIs: []string{testRedirectURI},
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, saved)
+	assert.Equal(t, saved.ID(), result.ClientID)
+	assert.NotEmpty(t, result.ClientSecret)
+	assert.True(t, saved.VerifySecret(result.ClientSecret))
+	assert.Equal(t, "client_secret_basic", result.TokenEndpointAuthMethod)
+	assert.Equal(t, "email openid profile", result.Scope)
+	assert.Equal(t, []string{testRedirectURI}, result.RedirectURIs)
+	assert.True(t, saved.CanUseAuthorizationCode())
+}
+
+func TestRegisterClientUseCase_PublicClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	clientRepo := mocks.NewMockServiceClientRepository(ctrl)
+
+	clientRepo.EXPECT().Save(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, client *serviceclient.Client) error {
+		assert.True(t, client.IsPublic())
+		return nil
+	})
+
+	result, err := NewRegisterClientUseCase(clientRepo, "initial-token").Execute(ctx, "initial-token", dto.ClientRegistrationRequest{
+		RedirectURIs:            []string{"http://localhost:5173/callback"},
+		TokenEndpointAuthMethod: "none",
+		Scope:                   "openid",
+	})
+
+	require.NoError(t, err)
+	assert.Empty(t, result.ClientSecret)
+	assert.Equal(t, "openid", result.Scope)
+}
+
+func TestRegisterClientUseCase_Rejected(t *testing.T) {
+	valid := dto.ClientRegistrationRequest{RedirectURIs: []string{testRedirectURI}}
+
+	tests := []struct {
+		name        string
+		configured  string
+		accessToken string
+		modify      func(req *dto.ClientRegistrationRequest)
+		expectedErr error
+	}{
+		{"registration disabled", "", "", func(*dto.ClientRegistrationRequest) {}, shared.ErrRegistrationDisabled},
+		{"wrong access token", "initial-token", "guess", func(*dto.ClientRegistrationRequest) {}, shared.ErrUnauthorized},
+		{"unsupported auth method", "initial-token", "initial-token", func(req *dto.ClientRegistrationRequest) { req.TokenEndpointAuthMethod = "private_key_jwt" }, shared.ErrInvalidClientMetadata},
+		{"scope without openid", "initial-token", "initial-token", func(req *dto.ClientRegistrationRequest) { req.Scope = "email" }, shared.ErrInvalidClientMetadata},
+		{"API scope", "initial-token", "initial-token", func(req *dto.ClientRegistrationRequest) { req.Scope = "openid users:read" }, shared.ErrInvalidClientMetadata},
+		{"no redirect URIs", "initial-token", "initial-token", func(req *dto.ClientRegistrationRequest) { req.RedirectURIs = nil }, shared.ErrInvalidRedirectURI},
+		{"insecure redirect URI", "initial-token", "initial-token", func(req *dto.ClientRegistrationRequest) {
+			req.RedirectURIs = []string{"http://wiki.example.com/callback"}
+		}, shared.ErrInvalidRedirectURI},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			req := valid
+			tt.modify(&req)
+
+			result, err := NewRegisterClientUseCase(mocks.NewMockServiceClientRepository(ctrl), tt.configured).Execute(context.Background(), tt.accessToken, req)
+
+			assert.Nil(t, result)
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/events"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/apitoken"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+// RevokeTokenUseCase lets clients revoke tokens they no longer need (RFC 7009)
+type RevokeTokenUseCase struct {
+	clients        *ServiceClientAuthenticator
+	tokenGenerator ports.TokenGenerator
+	tokenRepo      apitoken.Repository
+	sessionRepo    session.Repository
+	revokedTokens  ports.RevokedTokenStore
+	eventPublisher ports.EventPublisher
+}
+
+// NewRevokeTokenUseCase creates a new RevokeTokenUseCase
+func NewRevokeTokenUseCase(
+	clients *ServiceClientAuthenticator,
+	tokenGenerator ports.TokenGenerator,
+	tokenRepo apitoken.Repository,
+	sessionRepo session.Repository,
+	revokedTokens ports.RevokedTokenStore,
+	eventPublisher ports.EventPublisher,
+) *RevokeTokenUseCase {
+	return &RevokeTokenUseCase{
+		clients:        clients,
+		tokenGenerator: tokenGenerator,
+		tokenRepo:      tokenRepo,
+		sessionRepo:    sessionRepo,
+		revokedTokens:  revokedTokens,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// Execute authenticates the calling client and revokes token. Tokens issued
+// to a client can only be revoked by that client, and are revoked alone.
+// Tokens of a user's own login session end the session, and personal access
+// tokens are revoked for good. Invalid and expired tokens are ignored, since
+// there is nothing left to revoke (RFC 7009, section 2.2).
+func (uc *RevokeTokenUseCase) Execute(ctx context.Context, creds dto.ClientCredentials, token, hint string) error {
+	client, err := uc.clients.Authenticate(ctx, creds)
+	if err != nil {
+		return err
+	}
+
+	if apitoken.LooksLikeToken(token) {
+		return uc.revokeAPIToken(ctx, token)
+	}
+
+	presented := parsePresentedToken(uc.tokenGenerator, token, hint)
+	if presented == nil {
+		return nil
+	}
+	claims := presented.claims
+
+	if claims.ClientID != "" {
+		if claims.ClientID != client.ID() {
+			return shared.ErrUnauthorizedClient
+		}
+		if claims.TokenID == "" {
+			return nil
+		}
+		if err := uc.revokedTokens.Revoke(ctx, claims.TokenID, claims.ExpiresAt); err != nil {
+			return fmt.Errorf("failed to revoke token: %w", err)
+		}
+		log.Printf("Client %s revoked token %s", client.ID(), claims.TokenID)
+		return nil
+	}
+
+	if claims.SessionID == "" {
+		return nil
+	}
+	return uc.revokeSession(ctx, client.ID(), claims)
+}
+
+// revokeAPIToken revokes a personal access token
+func (uc *RevokeTokenUseCase) revokeAPIToken(ctx context.Context, plain string) error {
+	token, err := uc.tokenRepo.FindByHash(ctx, apitoken.Hash(plain))
+	if err != nil {
+		if err == shared.ErrAPITokenNotFound {
+			return nil
+		}
+		return fmt.Errorf("failed to look up token: %w", err)
+	}
+	if token.IsRevoked() {
+		return nil
+	}
+
+	token.Revoke(time.Now())
+	if err := uc.tokenRepo.Save(ctx, token); err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+	events.Publish(ctx, uc.eventPublisher, token)
+
+	return nil
+}
+
+// revokeSession ends the login session a user's token belongs to, which
+// revokes every token issued for it
+func (uc *RevokeTokenUseCase) revokeSession(ctx context.Context, clientID string, claims *ports.TokenClaims) error {
+	loginSession, err := findActiveSession(ctx, uc.sessionRepo, claims, time.Now())
+	if err != nil {
+		if err == shared.ErrSessionRevoked || err == shared.ErrSessionExpired {
+			return nil
+		}
+		return err
+	}
+
+	loginSession.Revoke(time.Now())
+	if err := uc.sessionRepo.Save(ctx, loginSession); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	log.Printf("Client %s revoked session %s of user %s", clientID, claims.SessionID, claims.UserID)
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+func TestRevokeTokenUseCase_ClientToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	expiresAt := time.Now().Add(15 * time.Minute)
+
+	m.clientRepo.EXPECT().FindByID(ctx, "wiki").Return(newRelyingPartyClient(t), nil)
+	m.tokenGenerator.EXPECT().ValidateIssuedAccessToken("access-token").Return(&ports.TokenClaims{
+		UserID:    "user-123",
+		SessionID: "session-1",
+		ClientID:  "wiki",
+		TokenID:   "jti-1",
+		ExpiresAt: expiresAt,
+	}, nil)
+	// Only the token is revoked, never the user's own session
+	m.revokedTokens.EXPECT().Revoke(ctx, "jti-1", expiresAt).Return(nil)
+
+	err := m.revokeUseCase().Execute(ctx, dto.ClientCredentials{ClientID: "wiki"}, "access-token", TokenTypeHintAccessToken)
+
+	assert.NoError(t, err)
+}
+
+func TestRevokeTokenUseCase_OtherClientsToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+
+	m.clientRepo.EXPECT().FindByID(ctx, "billing").Return(newSecretTestClient(t), nil)
+	m.tokenGenerator.EXPECT().ValidateIssuedAccessToken("access-token").Return(&ports.TokenClaims{ClientID: "wiki", TokenID: "jti-1"}, nil)
+
+	err := m.revokeUseCase().Execute(ctx, billingCreds, "access-token", "")
+
+	assert.Equal(t, shared.ErrUnauthorizedClient, err)
+}
+
+func TestRevokeTokenUseCase_SessionToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	_, loginSession, _ := newCodeTestUser(t)
+
+	m.clientRepo.EXPECT().FindByID(ctx, "billing").Return(newSecretTestClient(t), nil)
+	m.tokenGenerator.EXPECT().ValidateRefreshToken("refresh-token").Return(&ports.TokenClaims{UserID: "user-123", SessionID: loginSession.ID().Value()}, nil)
+	m.sessionRepo.EXPECT().FindByID(ctx, loginSession.ID()).Return(loginSession, nil)
+	m.sessionRepo.EXPECT().Save(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, saved *session.Session) error {
+		assert.True(t, saved.IsRevoked())
+		return nil
+	})
+
+	err := m.revokeUseCase().Execute(ctx, billingCreds, "refresh-token", TokenTypeHintRefreshToken)
+
+	assert.NoError(t, err)
+}
+
+func TestRevokeTokenUseCase_APIToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	_, token, plain := newAPITokenTestUser(t)
+
+	m.clientRepo.EXPECT().FindByID(ctx, "billing").Return(newSecretTestClient(t), nil)
+	m.tokenRepo.EXPECT().FindByHash(ctx, token.Hash()).Return(token, nil)
+	m.tokenRepo.EXPECT().Save(ctx, token).Return(nil)
+	m.eventPublisher.EXPECT().Publish(ctx, gomock.Any()).Return(nil)
+
+	err := m.revokeUseCase().Execute(ctx, billingCreds, plain, "")
+
+	require.NoError(t, err)
+	assert.True(t, token.IsRevoked())
+}
+
+func TestRevokeTokenUseCase_InvalidTokenIgnored(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+
+	m.clientRepo.EXPECT().FindByID(ctx, "billing").Return(newSecretTestClient(t), nil)
+	m.tokenGenerator.EXPECT().ValidateIssuedAccessToken("expired").Return(nil, ports.ErrExpiredToken)
+	m.tokenGenerator.EXPECT().ValidateRefreshToken("expired").Return(nil, ports.ErrExpiredToken)
+
+	err := m.revokeUseCase().Execute(ctx, billingCreds, "expired", "")
+
+	assert.NoError(t, err)
+}
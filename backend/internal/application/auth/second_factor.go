@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/mfa"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// verifySecondFactor checks a TOTP or recovery code against a confirmed
+// enrollment, updating it to prevent reuse, and returns the amr value of
+// the method used. The caller must save the enrollment.
+func verifySecondFactor(totp ports.TOTPService, enrollment *mfa.Enrollment, req dto.MFACodeRequest, now time.Time) (string, error) {
+	if !enrollment.IsConfirmed() {
+		return "", shared.ErrMFANotEnabled
+	}
+
+	if req.RecoveryCode != "" {
+		if err := enrollment.UseRecoveryCode(req.RecoveryCode); err != nil {
+			return "", err
+		}
+		return ports.AMRRecoveryCode, nil
+	}
+
+	if err := verifyTOTPCode(totp, enrollment, req.Code, now); err != nil {
+		return "", err
+	}
+	return ports.AMROTP, nil
+}
+
+// verifyTOTPCode checks a TOTP code and records its time step against replays
+func verifyTOTPCode(totp ports.TOTPService, enrollment *mfa.Enrollment, code string, now time.Time) error {
+	if code == "" {
+		return shared.ErrInvalidMFACode
+	}
+
+	step, ok := totp.Validate(enrollment.Secret(), code, now)
+	if !ok {
+		return shared.ErrInvalidMFACode
+	}
+
+	return enrollment.AcceptStep(step)
+}
+
+// validateMFAToken validates an MFA-pending token from the first login step
+func validateMFAToken(tokenGenerator ports.TokenGenerator, mfaToken string) (*ports.TokenClaims, error) {
+	claims, err := tokenGenerator.ValidateMFAToken(mfaToken)
+	if err != nil {
+		if ports.IsTokenExpired(err) {
+			return nil, ports.ErrExpiredToken
+		}
+		return nil, ports.ErrInvalidToken
+	}
+	return claims, nil
+}
+
+// secondFactorAMR returns the amr of a login completed with the given second
+// factor: the first factor recorded in the MFA-pending token, the second
+// factor and "mfa"
+func secondFactorAMR(claims *ports.TokenClaims, method string) []string {
+	amr := []string{ports.AMRFederated} // tokens issued before amr was recorded
+	if len(claims.AMR) > 0 {
+		amr = append([]string{}, claims.AMR...)
+	}
+	return append(amr, method, ports.AMRMultiFactor)
+}
+
+// findActiveUser loads the user a token was issued to; missing users are unauthorized
+func findActiveUser(ctx context.Context, userRepo user.Repository, rawUserID string) (*user.User, error) {
+	userID, err := user.NewUserID(rawUserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID in token: %w", err)
+	}
+
+	domainUser, err := userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if err == shared.ErrUserNotFound {
+			return nil, shared.ErrUnauthorized
+		}
+		return nil, fmt.Errorf("failed to retrieve user: %w", err)
+	}
+
+	if err := domainUser.EnsureActive(); err != nil {
+		return nil, err
+	}
+
+	return domainUser, nil
+}
+
+// findConfirmedEnrollment loads a user's enrollment, requiring it to be confirmed
+func findConfirmedEnrollment(ctx context.Context, mfaRepo mfa.Repository, userID user.UserID) (*mfa.Enrollment, error) {
+	enrollment, err := mfaRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		if err == shared.ErrMFANotEnabled {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to retrieve MFA enrollment: %w", err)
+	}
+
+	if !enrollment.IsConfirmed() {
+		return nil, shared.ErrMFANotEnabled
+	}
+
+	return enrollment, nil
+}
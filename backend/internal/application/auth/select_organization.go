@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/organization"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// SelectOrganizationUseCase switches the organization a signed-in user's
+// tokens act in. The choice is remembered, so later logins select it too.
+type SelectOrganizationUseCase struct {
+	userRepo       user.Repository
+	sessionRepo    session.Repository
+	membershipRepo organization.MembershipRepository
+	tokenGenerator ports.TokenGenerator
+}
+
+// NewSelectOrganizationUseCase creates a new SelectOrganizationUseCase
+func NewSelectOrganizationUseCase(
+	userRepo user.Repository,
+	sessionRepo session.Repository,
+	membershipRepo organization.MembershipRepository,
+	tokenGenerator ports.TokenGenerator,
+) *SelectOrganizationUseCase {
+	return &SelectOrganizationUseCase{
+		userRepo:       userRepo,
+		sessionRepo:    sessionRepo,
+		membershipRepo: membershipRepo,
+		tokenGenerator: tokenGenerator,
+	}
+}
+
+// Execute selects req.OrganizationID, which the user must be a member of, or
+// clears the selection when it is empty, and reissues the session's token
+// pair with the new org_id claim. The session's amr and auth_time are kept.
+func (uc *SelectOrganizationUseCase) Execute(ctx context.Context, claims *ports.TokenClaims, req dto.SelectOrganizationRequest) (*dto.LoginResponse, error) {
+	if claims == nil {
+		return nil, shared.ErrMissingToken
+	}
+	// Tokens without a session, such as personal access tokens, cannot be reissued
+	if claims.SessionID == "" {
+		return nil, shared.ErrSessionRevoked
+	}
+
+	domainUser, err := findActiveUser(ctx, uc.userRepo, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := findActiveSession(ctx, uc.sessionRepo, claims, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if req.OrganizationID != "" {
+		orgID, err := organization.NewOrganizationID(req.OrganizationID)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := uc.membershipRepo.Find(ctx, orgID, domainUser.ID()); err != nil {
+			if err == shared.ErrNotOrganizationMember {
+				return nil, err
+			}
+			return nil, fmt.Errorf("failed to retrieve membership: %w", err)
+		}
+	}
+
+	domainUser.SelectOrganization(req.OrganizationID)
+	if err := uc.userRepo.Save(ctx, domainUser); err != nil {
+		return nil, fmt.Errorf("failed to save user: %w", err)
+	}
+
+	accessToken, refreshToken, err := issueSessionTokens(uc.tokenGenerator, domainUser, claims.SessionID, claims.AMR, claims.AuthTime)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("User %s selected organization %q", domainUser.ID().Value(), req.OrganizationID)
+	return &dto.LoginResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		User:         dto.FromDomain(domainUser),
+		Message:      "Organization selected",
+	}, nil
+}
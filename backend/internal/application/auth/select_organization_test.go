@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/organization"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/mocks"
+)
+
+func (m *authMocks) selectOrganizationUseCase() *SelectOrganizationUseCase {
+	return NewSelectOrganizationUseCase(m.userRepo, m.sessionRepo, m.membershipRepo, m.tokenGenerator)
+}
+
+func TestSelectOrganizationUseCase_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser, loginSession := newReauthTestUser(t)
+	authTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	claims := &ports.TokenClaims{
+		UserID:    "user-123",
+		SessionID: loginSession.ID().Value(),
+		AMR:       []string{ports.AMRFederated},
+		AuthTime:  authTime,
+	}
+	orgID, _ := organization.NewOrganizationID("org-1")
+
+	m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	m.sessionRepo.EXPECT().FindByID(ctx, loginSession.ID()).Return(loginSession, nil)
+	m.membershipRepo.EXPECT().Find(ctx, orgID, domainUser.ID()).
+		Return(organization.ReconstructMembership(orgID, domainUser.ID(), organization.RoleMember, time.Now()), nil)
+	m.userRepo.EXPECT().Save(ctx, domainUser).Return(nil)
+
+	var issued ports.UserInfo
+	m.tokenGenerator.EXPECT().
+		GenerateTokenPair(gomock.Any()).
+		DoAndReturn(func(info ports.UserInfo) (string, string, error) {
+			issued = info
+			return "access-token", "refresh-token", nil
+		})
+
+	result, err := m.selectOrganizationUseCase().Execute(ctx, claims, dto.SelectOrganizationRequest{OrganizationID: "org-1"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "access-token", result.AccessToken)
+	assert.Equal(t, "org-1", domainUser.ActiveOrganizationID())
+	assert.Equal(t, "org-1", issued.OrganizationID)
+	assert.Equal(t, loginSession.ID().Value(), issued.SessionID)
+	// Switching organizations is not a new authentication
+	assert.Equal(t, claims.AMR, issued.AMR)
+	assert.True(t, authTime.Equal(issued.AuthTime))
+}
+
+func TestSelectOrganizationUseCase_ClearsSelection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser, loginSession := newReauthTestUser(t)
+	domainUser.SelectOrganization("org-1")
+	claims := &ports.TokenClaims{UserID: "user-123", SessionID: loginSession.ID().Value(), OrganizationID: "org-1"}
+
+	m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	m.sessionRepo.EXPECT().FindByID(ctx, loginSession.ID()).Return(loginSession, nil)
+	m.userRepo.EXPECT().Save(ctx, domainUser).Return(nil)
+	m.tokenGenerator.EXPECT().
+		GenerateTokenPair(gomock.Any()).
+		DoAndReturn(func(info ports.UserInfo) (string, string, error) {
+			assert.Empty(t, info.OrganizationID)
+			return "access-token", "refresh-token", nil
+		})
+
+	_, err := m.selectOrganizationUseCase().Execute(ctx, claims, dto.SelectOrganizationRequest{})
+
+	require.NoError(t, err)
+	assert.Empty(t, domainUser.ActiveOrganizationID())
+}
+
+func TestSelectOrganizationUseCase_NotMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser, loginSession := newReauthTestUser(t)
+	claims := &ports.TokenClaims{UserID: "user-123", SessionID: loginSession.ID().Value()}
+
+	m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	m.sessionRepo.EXPECT().FindByID(ctx, loginSession.ID()).Return(loginSession, nil)
+	m.membershipRepo.EXPECT().Find(ctx, gomock.Any(), domainUser.ID()).Return(nil, shared.ErrNotOrganizationMember)
+
+	result, err := m.selectOrganizationUseCase().Execute(ctx, claims, dto.SelectOrganizationRequest{OrganizationID: "org-2"})
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrNotOrganizationMember, err)
+	assert.Empty(t, domainUser.ActiveOrganizationID())
+}
+
+func TestSelectOrganizationUseCase_RequiresSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := newAuthMocks(ctrl)
+	claims := &ports.TokenClaims{UserID: "user-123", APITokenID: "token-1"}
+
+	result, err := m.selectOrganizationUseCase().Execute(context.Background(), claims, dto.SelectOrganizationRequest{OrganizationID: "org-1"})
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrSessionRevoked, err)
+}
+
+func TestIssueSessionTokens_UsesActiveOrganization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tokenGenerator := mocks.NewMockTokenGenerator(ctrl)
+	domainUser := newTestUser(t, "user-123")
+	domainUser.SelectOrganization("org-1")
+
+	tokenGenerator.EXPECT().
+		GenerateTokenPair(gomock.Any()).
+		DoAndReturn(func(info ports.UserInfo) (string, string, error) {
+			assert.Equal(t, "org-1", info.OrganizationID)
+			return "access-token", "refresh-token", nil
+		})
+
+	_, _, err := issueSessionTokens(tokenGenerator, domainUser, "session-1", []string{ports.AMRFederated}, time.Now())
+	require.NoError(t, err)
+}
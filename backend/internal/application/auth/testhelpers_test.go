@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+	"github.com/yuki5155/go-google-auth/internal/mocks"
+)
+
+// authMocks holds a test double for every dependency of the auth use cases;
+// each test sets expectations on the ones its use case takes
+type authMocks struct {
+	userRepo        *mocks.MockRepository
+	sessionRepo     *mocks.MockSessionRepository
+	mfaRepo         *mocks.MockMFARepository
+	tokenRepo       *mocks.MockAPITokenRepository
+	membershipRepo  *mocks.MockMembershipRepository
+	clientRepo      *mocks.MockServiceClientRepository
+	consentRepo     *mocks.MockConsentRepository
+	adminRoles      *AdminRoles
+	oauthValidator  *mocks.MockOAuthValidator
+	tokenGenerator  *mocks.MockTokenGenerator
+	idTokens        *mocks.MockIDTokenIssuer
+	revokedTokens   *mocks.MockRevokedTokenStore
+	codes           *mocks.MockAuthorizationGrantTokens
+	consentRequests *mocks.MockAuthorizationGrantTokens
+	usedCodes       *mocks.MockAuthorizationCodeStore
+	assertions      *mocks.MockClientAssertionVerifier
+	usedAssertions  *mocks.MockClientAssertionStore
+	linkTokens      *mocks.MockMagicLinkTokenService
+	usedLinks       *mocks.MockMagicLinkStore
+	mailer          *mocks.MockMailer
+	verifier        *mocks.MockWebAuthnVerifier
+	challenges      *mocks.MockWebAuthnChallengeStore
+	totp            *mocks.MockTOTPService
+	fingerprinter   *mocks.MockDeviceFingerprinter
+	revokeLinks     *mocks.MockRevokeLinkTokenService
+	notifier        *mocks.MockLoginAlertNotifier
+	eventPublisher  *mocks.MockEventPublisher
+}
+
+// newAuthMocks creates the mocks; admin-123 is the only configured administrator
+func newAuthMocks(ctrl *gomock.Controller) *authMocks {
+	return &authMocks{
+		userRepo:        mocks.NewMockRepository(ctrl),
+		sessionRepo:     mocks.NewMockSessionRepository(ctrl),
+		mfaRepo:         mocks.NewMockMFARepository(ctrl),
+		tokenRepo:       mocks.NewMockAPITokenRepository(ctrl),
+		membershipRepo:  mocks.NewMockMembershipRepository(ctrl),
+		clientRepo:      mocks.NewMockServiceClientRepository(ctrl),
+		consentRepo:     mocks.NewMockConsentRepository(ctrl),
+		adminRoles:      NewAdminRoles([]string{"admin-123"}, nil),
+		oauthValidator:  mocks.NewMockOAuthValidator(ctrl),
+		tokenGenerator:  mocks.NewMockTokenGenerator(ctrl),
+		idTokens:        mocks.NewMockIDTokenIssuer(ctrl),
+		revokedTokens:   mocks.NewMockRevokedTokenStore(ctrl),
+		codes:           mocks.NewMockAuthorizationGrantTokens(ctrl),
+		consentRequests: mocks.NewMockAuthorizationGrantTokens(ctrl),
+		usedCodes:       mocks.NewMockAuthorizationCodeStore(ctrl),
+		assertions:      mocks.NewMockClientAssertionVerifier(ctrl),
+		usedAssertions:  mocks.NewMockClientAssertionStore(ctrl),
+		linkTokens:      mocks.NewMockMagicLinkTokenService(ctrl),
+		usedLinks:       mocks.NewMockMagicLinkStore(ctrl),
+		mailer:          mocks.NewMockMailer(ctrl),
+		verifier:        mocks.NewMockWebAuthnVerifier(ctrl),
+		challenges:      mocks.NewMockWebAuthnChallengeStore(ctrl),
+		totp:            mocks.NewMockTOTPService(ctrl),
+		fingerprinter:   mocks.NewMockDeviceFingerprinter(ctrl),
+		revokeLinks:     mocks.NewMockRevokeLinkTokenService(ctrl),
+		notifier:        mocks.NewMockLoginAlertNotifier(ctrl),
+		eventPublisher:  mocks.NewMockEventPublisher(ctrl),
+	}
+}
+
+// newTestUser creates an active user with the verified address test@example.com
+// and no pending domain events
+func newTestUser(t *testing.T, rawID string) *user.User {
+	t.Helper()
+
+	userID, _ := user.NewUserID(rawID)
+	email, _ := user.NewEmail("test@example.com", true)
+	domainUser, err := user.NewUser(userID, email, user.NewProfile("Test User", "https://example.com/photo.jpg"))
+	require.NoError(t, err)
+	domainUser.ClearDomainEvents()
+
+	return domainUser
+}
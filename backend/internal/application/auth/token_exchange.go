@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/serviceclient"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// TokenTypeAccessToken identifies access tokens in token exchange requests
+// and responses (RFC 8693, section 3)
+const TokenTypeAccessToken = "urn:ietf:params:oauth:token-type:access_token"
+
+// TokenExchangeUseCase lets a service that received a user's token get a
+// narrower one to call another service for that user (RFC 8693). The new
+// token names the calling service in its act claim.
+type TokenExchangeUseCase struct {
+	clients        *ServiceClientAuthenticator
+	tokenGenerator ports.TokenGenerator
+	userRepo       user.Repository
+	sessionRepo    session.Repository
+	revokedTokens  ports.RevokedTokenStore
+}
+
+// NewTokenExchangeUseCase creates a new TokenExchangeUseCase
+func NewTokenExchangeUseCase(
+	clients *ServiceClientAuthenticator,
+	tokenGenerator ports.TokenGenerator,
+	userRepo user.Repository,
+	sessionRepo session.Repository,
+	revokedTokens ports.RevokedTokenStore,
+) *TokenExchangeUseCase {
+	return &TokenExchangeUseCase{
+		clients:        clients,
+		tokenGenerator: tokenGenerator,
+		userRepo:       userRepo,
+		sessionRepo:    sessionRepo,
+		revokedTokens:  revokedTokens,
+	}
+}
+
+// Execute authenticates the client and exchanges req.SubjectToken, a user's
+// active access token, for one meant for req.Audience. The client must be
+// registered for that audience. The new token gets the requested scopes, or
+// all of the client's scopes when none are requested, but never more than
+// the subject token had. It expires no later than the subject token.
+func (uc *TokenExchangeUseCase) Execute(ctx context.Context, creds dto.ClientCredentials, req dto.TokenExchangeRequest) (*dto.OAuthTokenResponse, error) {
+	client, err := uc.clients.Authenticate(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+	if !client.CanUseClientCredentials() {
+		return nil, shared.ErrUnauthorizedClient
+	}
+
+	subject, err := uc.tokenGenerator.ValidateIssuedAccessToken(req.SubjectToken)
+	if err != nil {
+		return nil, shared.ErrInvalidSubjectToken
+	}
+	// Only user tokens can be exchanged, and a token already exchanged for
+	// one service can only be exchanged again by that service
+	if subject.UserID == "" || (subject.Audience != "" && subject.Audience != client.ID()) {
+		return nil, shared.ErrInvalidSubjectToken
+	}
+	if err := ensureTokenActive(ctx, uc.revokedTokens, uc.userRepo, uc.sessionRepo, subject); err != nil {
+		if isInactiveTokenError(err) {
+			return nil, shared.ErrInvalidSubjectToken
+		}
+		return nil, err
+	}
+
+	if !client.CanExchangeTokenFor(req.Audience) {
+		return nil, shared.ErrInvalidTarget
+	}
+
+	scopes, err := narrowScopes(client, subject, strings.Fields(req.Scope))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(time.Duration(uc.tokenGenerator.GetAccessTokenExpiry()) * time.Second)
+	if !subject.ExpiresAt.IsZero() && subject.ExpiresAt.Before(expiresAt) {
+		expiresAt = subject.ExpiresAt
+	}
+
+	accessToken, err := uc.tokenGenerator.GenerateExchangedToken(ports.ExchangedToken{
+		User: ports.UserInfo{
+			UserID:    subject.UserID,
+			Email:     subject.Email,
+			Name:      subject.Name,
+			Picture:   subject.Picture,
+			SessionID: subject.SessionID,
+			AMR:       subject.AMR,
+			AuthTime:  subject.AuthTime,
+			ACR:       subject.ACR,
+
+			OrganizationID: subject.OrganizationID,
+		},
+		ClientID:  client.ID(),
+		Audience:  req.Audience,
+		Scopes:    scopes,
+		Actor:     &ports.Actor{Subject: client.ID(), Actor: subject.Actor},
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate exchanged token: %w", err)
+	}
+
+	log.Printf("Client %s exchanged a token of user %s for %s (%s)", client.ID(), subject.UserID, req.Audience, strings.Join(scopes, " "))
+	return &dto.OAuthTokenResponse{
+		AccessToken:     accessToken,
+		TokenType:       "Bearer",
+		ExpiresIn:       int(expiresAt.Sub(now).Seconds()),
+		Scope:           strings.Join(scopes, " "),
+		IssuedTokenType: TokenTypeAccessToken,
+	}, nil
+}
+
+// narrowScopes returns the scopes of an exchanged token: those the client
+// is granted for requested, limited to what the subject token allows.
+// Requesting a scope the subject token lacks fails with shared.ErrInvalidScope.
+func narrowScopes(client *serviceclient.Client, subject *ports.TokenClaims, requested []string) ([]string, error) {
+	scopes, err := client.GrantScopes(requested)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(requested) > 0 {
+		for _, scope := range scopes {
+			if !subject.HasScope(scope) {
+				return nil, shared.ErrInvalidScope
+			}
+		}
+	} else {
+		scopes = slices.DeleteFunc(scopes, func(scope string) bool { return !subject.HasScope(scope) })
+	}
+
+	if len(scopes) == 0 {
+		return nil, shared.ErrInvalidScope
+	}
+	return scopes, nil
+}
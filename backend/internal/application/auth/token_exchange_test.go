@@ -0,0 +1,238 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/serviceclient"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+// ordersCreds authenticates as the client returned by newExchangeTestClient
+var ordersCreds = dto.ClientCredentials{ClientID: "orders", ClientSecret: "s3cret"}
+
+func (m *authMocks) exchangeUseCase() *TokenExchangeUseCase {
+	return NewTokenExchangeUseCase(m.clients(), m.tokenGenerator, m.userRepo, m.sessionRepo, m.revokedTokens)
+}
+
+// newExchangeTestClient returns a client that may exchange tokens for the inventory service
+func newExchangeTestClient(t *testing.T) *serviceclient.Client {
+	t.Helper()
+
+	client, err := serviceclient.NewSecretClient("orders", "Orders", serviceclient.HashSecret("s3cret"), []string{"inventory:read", "inventory:write"})
+	require.NoError(t, err)
+	require.NoError(t, client.AllowTokenExchange([]string{"inventory"}))
+	return client
+}
+
+// newSubjectClaims returns the claims of a user's session access token
+func newSubjectClaims(sessionID string, expiresAt time.Time) *ports.TokenClaims {
+	return &ports.TokenClaims{
+		UserID:    "user-123",
+		Email:     "test@example.com",
+		SessionID: sessionID,
+		AMR:       []string{"fed"},
+		TokenID:   "jti-1",
+		ExpiresAt: expiresAt,
+	}
+}
+
+func TestTokenExchangeUseCase_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser, loginSession, _ := newCodeTestUser(t)
+	subjectExpiry := time.Now().Add(10 * time.Minute)
+
+	m.clientRepo.EXPECT().FindByID(ctx, "orders").Return(newExchangeTestClient(t), nil)
+	m.tokenGenerator.EXPECT().ValidateIssuedAccessToken("subject-token").Return(newSubjectClaims(loginSession.ID().Value(), subjectExpiry), nil)
+	m.revokedTokens.EXPECT().IsRevoked(ctx, "jti-1").Return(false, nil)
+	m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	m.sessionRepo.EXPECT().FindByID(ctx, loginSession.ID()).Return(loginSession, nil)
+	m.tokenGenerator.EXPECT().GetAccessTokenExpiry().Return(900)
+	// The token ends with the subject token, which expires before 15 minutes
+	m.tokenGenerator.EXPECT().GenerateExchangedToken(ports.ExchangedToken{
+		User: ports.UserInfo{
+			UserID:    "user-123",
+			Email:     "test@example.com",
+			SessionID: loginSession.ID().Value(),
+			AMR:       []string{"fed"},
+		},
+		ClientID:  "orders",
+		Audience:  "inventory",
+		Scopes:    []string{"inventory:read"},
+		Actor:     &ports.Actor{Subject: "orders"},
+		ExpiresAt: subjectExpiry,
+	}).Return("exchanged-token", nil)
+
+	result, err := m.exchangeUseCase().Execute(ctx, ordersCreds, dto.TokenExchangeRequest{
+		SubjectToken: "subject-token",
+		Audience:     "inventory",
+		Scope:        "inventory:read",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "exchanged-token", result.AccessToken)
+	assert.Equal(t, "Bearer", result.TokenType)
+	assert.Equal(t, "inventory:read", result.Scope)
+	assert.Equal(t, TokenTypeAccessToken, result.IssuedTokenType)
+	assert.InDelta(t, 600, result.ExpiresIn, 1)
+}
+
+func TestTokenExchangeUseCase_ExchangedAgainByItsAudience(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser, loginSession, _ := newCodeTestUser(t)
+	subject := newSubjectClaims(loginSession.ID().Value(), time.Now().Add(time.Hour))
+	subject.ClientID = "web-api"
+	subject.Audience = "orders"
+	subject.Scopes = []string{"inventory:read", "orders:write"}
+	subject.Actor = &ports.Actor{Subject: "web-api"}
+
+	m.clientRepo.EXPECT().FindByID(ctx, "orders").Return(newExchangeTestClient(t), nil)
+	m.tokenGenerator.EXPECT().ValidateIssuedAccessToken("subject-token").Return(subject, nil)
+	m.revokedTokens.EXPECT().IsRevoked(ctx, "jti-1").Return(false, nil)
+	m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	m.sessionRepo.EXPECT().FindByID(ctx, loginSession.ID()).Return(loginSession, nil)
+	m.tokenGenerator.EXPECT().GetAccessTokenExpiry().Return(900)
+	m.tokenGenerator.EXPECT().GenerateExchangedToken(gomock.Any()).DoAndReturn(func(token ports.ExchangedToken) (string, error) {
+		// Without a scope request, the client's scopes the subject token has
+		assert.Equal(t, []string{"inventory:read"}, token.Scopes)
+		assert.Equal(t, &ports.Actor{Subject: "orders", Actor: &ports.Actor{Subject: "web-api"}}, token.Actor)
+		return "exchanged-token", nil
+	})
+
+	result, err := m.exchangeUseCase().Execute(ctx, ordersCreds, dto.TokenExchangeRequest{SubjectToken: "subject-token", Audience: "inventory"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "inventory:read", result.Scope)
+}
+
+func TestTokenExchangeUseCase_InvalidSubjectToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		subject *ports.TokenClaims
+		err     error
+	}{
+		{"invalid", nil, ports.ErrInvalidToken},
+		{"service client token", &ports.TokenClaims{ClientID: "billing", Scopes: []string{}}, nil},
+		{"meant for another service", &ports.TokenClaims{UserID: "user-123", ClientID: "web-api", Audience: "payroll", Scopes: []string{"inventory:read"}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			m := newAuthMocks(ctrl)
+
+			m.clientRepo.EXPECT().FindByID(ctx, "orders").Return(newExchangeTestClient(t), nil)
+			m.tokenGenerator.EXPECT().ValidateIssuedAccessToken("subject-token").Return(tt.subject, tt.err)
+
+			result, err := m.exchangeUseCase().Execute(ctx, ordersCreds, dto.TokenExchangeRequest{SubjectToken: "subject-token", Audience: "inventory"})
+
+			assert.Nil(t, result)
+			assert.Equal(t, shared.ErrInvalidSubjectToken, err)
+		})
+	}
+}
+
+func TestTokenExchangeUseCase_SessionEnded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+	domainUser, loginSession, _ := newCodeTestUser(t)
+	loginSession.Revoke(time.Now())
+
+	m.clientRepo.EXPECT().FindByID(ctx, "orders").Return(newExchangeTestClient(t), nil)
+	m.tokenGenerator.EXPECT().ValidateIssuedAccessToken("subject-token").Return(newSubjectClaims(loginSession.ID().Value(), time.Now().Add(time.Hour)), nil)
+	m.revokedTokens.EXPECT().IsRevoked(ctx, "jti-1").Return(false, nil)
+	m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	m.sessionRepo.EXPECT().FindByID(ctx, loginSession.ID()).Return(loginSession, nil)
+
+	result, err := m.exchangeUseCase().Execute(ctx, ordersCreds, dto.TokenExchangeRequest{SubjectToken: "subject-token", Audience: "inventory"})
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrInvalidSubjectToken, err)
+}
+
+func TestTokenExchangeUseCase_Rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		audience string
+		scope    string
+		subject  func(claims *ports.TokenClaims)
+		err      error
+	}{
+		{"audience not allowed", "payroll", "", nil, shared.ErrInvalidTarget},
+		{"scope not allowed for client", "inventory", "payroll:read", nil, shared.ErrInvalidScope},
+		{"scope the subject token lacks", "inventory", "inventory:write", func(claims *ports.TokenClaims) {
+			claims.ClientID = "web-api"
+			claims.Scopes = []string{"inventory:read"}
+		}, shared.ErrInvalidScope},
+		{"no scope in common", "inventory", "", func(claims *ports.TokenClaims) {
+			claims.ClientID = "wiki"
+			claims.Scopes = []string{"email", "openid"}
+		}, shared.ErrInvalidScope},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			m := newAuthMocks(ctrl)
+			domainUser, loginSession, _ := newCodeTestUser(t)
+			subject := newSubjectClaims(loginSession.ID().Value(), time.Now().Add(time.Hour))
+			if tt.subject != nil {
+				tt.subject(subject)
+			}
+
+			m.clientRepo.EXPECT().FindByID(ctx, "orders").Return(newExchangeTestClient(t), nil)
+			m.tokenGenerator.EXPECT().ValidateIssuedAccessToken("subject-token").Return(subject, nil)
+			m.revokedTokens.EXPECT().IsRevoked(ctx, "jti-1").Return(false, nil)
+			m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+			m.sessionRepo.EXPECT().FindByID(ctx, loginSession.ID()).Return(loginSession, nil)
+
+			result, err := m.exchangeUseCase().Execute(ctx, ordersCreds, dto.TokenExchangeRequest{
+				SubjectToken: "subject-token",
+				Audience:     tt.audience,
+				Scope:        tt.scope,
+			})
+
+			assert.Nil(t, result)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestTokenExchangeUseCase_PublicClientRejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newAuthMocks(ctrl)
+
+	m.clientRepo.EXPECT().FindByID(ctx, "wiki").Return(newRelyingPartyClient(t), nil)
+
+	result, err := m.exchangeUseCase().Execute(ctx, dto.ClientCredentials{ClientID: "wiki"}, dto.TokenExchangeRequest{SubjectToken: "subject-token", Audience: "inventory"})
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrUnauthorizedClient, err)
+}
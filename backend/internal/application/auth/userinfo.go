@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// GetUserInfoUseCase returns the claims about a user that a client was
+// granted (the OpenID Connect UserInfo endpoint)
+type GetUserInfoUseCase struct {
+	userRepo user.Repository
+}
+
+// NewGetUserInfoUseCase creates a new GetUserInfoUseCase
+func NewGetUserInfoUseCase(userRepo user.Repository) *GetUserInfoUseCase {
+	return &GetUserInfoUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// Execute returns the user's claims for the scopes of the access token in
+// claims. The user is loaded from the repository so that profile changes are
+// reflected immediately.
+func (uc *GetUserInfoUseCase) Execute(ctx context.Context, claims *ports.TokenClaims) (*dto.UserInfoResponse, error) {
+	userID, err := user.NewUserID(claims.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID in token: %w", err)
+	}
+
+	domainUser, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if err == shared.ErrUserNotFound {
+			return nil, shared.ErrUnauthorized
+		}
+		return nil, fmt.Errorf("failed to retrieve user: %w", err)
+	}
+
+	profile := profileForScopes(domainUser, claims.HasScope)
+	response := &dto.UserInfoResponse{
+		Subject: domainUser.ID().Value(),
+		Name:    profile.Name,
+		Picture: profile.Picture,
+		Email:   profile.Email,
+	}
+	if profile.Email != "" {
+		response.EmailVerified = &profile.EmailVerified
+	}
+
+	return response, nil
+}
@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/mocks"
+)
+
+func TestGetUserInfoUseCase_ClaimsFollowScopes(t *testing.T) {
+	verified := true
+
+	tests := []struct {
+		name     string
+		scopes   []string
+		expected *dto.UserInfoResponse
+	}{
+		{"openid only", []string{"openid"}, &dto.UserInfoResponse{Subject: "user-123"}},
+		{"profile", []string{"openid", "profile"}, &dto.UserInfoResponse{Subject: "user-123", Name: "Test User", Picture: "https://example.com/photo.jpg"}},
+		{"email", []string{"openid", "email"}, &dto.UserInfoResponse{Subject: "user-123", Email: "test@example.com", EmailVerified: &verified}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			userRepo := mocks.NewMockRepository(ctrl)
+			domainUser, _, _ := newCodeTestUser(t)
+			userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+
+			result, err := NewGetUserInfoUseCase(userRepo).Execute(ctx, &ports.TokenClaims{UserID: "user-123", ClientID: "wiki", Scopes: tt.scopes})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestGetUserInfoUseCase_UserDeleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userRepo := mocks.NewMockRepository(ctrl)
+	userRepo.EXPECT().FindByID(ctx, gomock.Any()).Return(nil, shared.ErrUserNotFound)
+
+	result, err := NewGetUserInfoUseCase(userRepo).Execute(ctx, &ports.TokenClaims{UserID: "user-123", Scopes: []string{"openid"}})
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrUnauthorized, err)
+}
@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/events"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/mfa"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// VerifyMFAUseCase completes a login that GoogleLoginUseCase left MFA-pending
+type VerifyMFAUseCase struct {
+	userRepo       user.Repository
+	sessionRepo    session.Repository
+	mfaRepo        mfa.Repository
+	tokenGenerator ports.TokenGenerator
+	totp           ports.TOTPService
+	eventPublisher ports.EventPublisher
+}
+
+// NewVerifyMFAUseCase creates a new VerifyMFAUseCase
+func NewVerifyMFAUseCase(
+	userRepo user.Repository,
+	sessionRepo session.Repository,
+	mfaRepo mfa.Repository,
+	tokenGenerator ports.TokenGenerator,
+	totp ports.TOTPService,
+	eventPublisher ports.EventPublisher,
+) *VerifyMFAUseCase {
+	return &VerifyMFAUseCase{
+		userRepo:       userRepo,
+		sessionRepo:    sessionRepo,
+		mfaRepo:        mfaRepo,
+		tokenGenerator: tokenGenerator,
+		totp:           totp,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// Execute checks the second factor for an MFA-pending token and issues the token pair
+func (uc *VerifyMFAUseCase) Execute(ctx context.Context, mfaToken string, req dto.MFACodeRequest) (*dto.LoginResponse, error) {
+	claims, err := validateMFAToken(uc.tokenGenerator, mfaToken)
+	if err != nil {
+		return nil, err
+	}
+
+	domainUser, err := findActiveUser(ctx, uc.userRepo, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	enrollment, err := findConfirmedEnrollment(ctx, uc.mfaRepo, domainUser.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	method, err := verifySecondFactor(uc.totp, enrollment, req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// Save the enrollment first so the code cannot be replayed
+	if err := uc.mfaRepo.Save(ctx, enrollment); err != nil {
+		return nil, fmt.Errorf("failed to update MFA enrollment: %w", err)
+	}
+	events.Publish(ctx, uc.eventPublisher, enrollment)
+
+	domainUser.RecordLogin()
+	if err := uc.userRepo.Save(ctx, domainUser); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+	events.Publish(ctx, uc.eventPublisher, domainUser)
+
+	amr := secondFactorAMR(claims, method)
+	accessToken, refreshToken, err := startSession(ctx, uc.sessionRepo, uc.tokenGenerator, domainUser, amr)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("User completed MFA login: %s (%s)", domainUser.Email().Value(), method)
+	return &dto.LoginResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		User:         dto.FromDomain(domainUser),
+		Message:      "Login successful",
+	}, nil
+}
@@ -0,0 +1,249 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/mfa"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+	"github.com/yuki5155/go-google-auth/internal/mocks"
+)
+
+// newConfirmedEnrollment returns a confirmed enrollment and its recovery codes
+func newConfirmedEnrollment(t *testing.T, userID user.UserID) (*mfa.Enrollment, []string) {
+	t.Helper()
+
+	enrollment, err := mfa.NewEnrollment(userID, []byte("12345678901234567890"))
+	require.NoError(t, err)
+	codes, err := enrollment.Confirm(1)
+	require.NoError(t, err)
+	enrollment.ClearDomainEvents()
+
+	return enrollment, codes
+}
+
+func TestVerifyMFAUseCase_TOTPCode_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+	mockTOTP := mocks.NewMockTOTPService(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
+
+	userID, _ := user.NewUserID("user-123")
+	email, _ := user.NewEmail("test@example.com", true)
+	domainUser, _ := user.NewUser(userID, email, user.NewProfile("Test User", ""))
+	domainUser.ClearDomainEvents()
+	enrollment, _ := newConfirmedEnrollment(t, userID)
+
+	mockTokenGen.EXPECT().ValidateMFAToken("mfa-token").Return(&ports.TokenClaims{UserID: "user-123"}, nil)
+	mockRepo.EXPECT().FindByID(ctx, userID).Return(domainUser, nil)
+	mockMFARepo.EXPECT().FindByUserID(ctx, userID).Return(enrollment, nil)
+	mockTOTP.EXPECT().Validate(gomock.Any(), "123456", gomock.Any()).Return(int64(42), true)
+	mockMFARepo.EXPECT().Save(ctx, enrollment).Return(nil)
+	mockRepo.EXPECT().Save(ctx, domainUser).Return(nil)
+	mockPublisher.EXPECT().Publish(ctx, gomock.Any()).Return(nil)
+	mockTokenGen.EXPECT().GetRefreshTokenExpiry().Return(604800)
+	mockSessionRepo.EXPECT().Save(ctx, gomock.Any()).Return(nil)
+
+	var issued ports.UserInfo
+	mockTokenGen.EXPECT().
+		GenerateTokenPair(gomock.Any()).
+		DoAndReturn(func(info ports.UserInfo) (string, string, error) {
+			issued = info
+			return "access-token", "refresh-token", nil
+		})
+
+	useCase := NewVerifyMFAUseCase(mockRepo, mockSessionRepo, mockMFARepo, mockTokenGen, mockTOTP, mockPublisher)
+
+	result, err := useCase.Execute(ctx, "mfa-token", dto.MFACodeRequest{Code: "123456"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "access-token", result.AccessToken)
+	assert.Equal(t, "refresh-token", result.RefreshToken)
+	assert.Equal(t, 1, result.User.LoginCount)
+	assert.Equal(t, []string{ports.AMRFederated, ports.AMROTP, ports.AMRMultiFactor}, issued.AMR)
+	assert.Equal(t, int64(42), enrollment.LastUsedStep())
+}
+
+func TestVerifyMFAUseCase_RecoveryCode_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+	mockTOTP := mocks.NewMockTOTPService(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
+
+	userID, _ := user.NewUserID("user-123")
+	email, _ := user.NewEmail("test@example.com", true)
+	domainUser, _ := user.NewUser(userID, email, user.NewProfile("Test User", ""))
+	domainUser.ClearDomainEvents()
+	enrollment, codes := newConfirmedEnrollment(t, userID)
+
+	mockTokenGen.EXPECT().ValidateMFAToken("mfa-token").Return(&ports.TokenClaims{UserID: "user-123"}, nil)
+	mockRepo.EXPECT().FindByID(ctx, userID).Return(domainUser, nil)
+	mockMFARepo.EXPECT().FindByUserID(ctx, userID).Return(enrollment, nil)
+	mockMFARepo.EXPECT().Save(ctx, enrollment).Return(nil)
+	mockRepo.EXPECT().Save(ctx, domainUser).Return(nil)
+	mockPublisher.EXPECT().Publish(ctx, gomock.Any()).Return(nil).Times(2)
+	mockTokenGen.EXPECT().GetRefreshTokenExpiry().Return(604800)
+	mockSessionRepo.EXPECT().Save(ctx, gomock.Any()).Return(nil)
+
+	var issued ports.UserInfo
+	mockTokenGen.EXPECT().
+		GenerateTokenPair(gomock.Any()).
+		DoAndReturn(func(info ports.UserInfo) (string, string, error) {
+			issued = info
+			return "access-token", "refresh-token", nil
+		})
+
+	useCase := NewVerifyMFAUseCase(mockRepo, mockSessionRepo, mockMFARepo, mockTokenGen, mockTOTP, mockPublisher)
+
+	_, err := useCase.Execute(ctx, "mfa-token", dto.MFACodeRequest{RecoveryCode: codes[0]})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{ports.AMRFederated, ports.AMRRecoveryCode, ports.AMRMultiFactor}, issued.AMR)
+	assert.Equal(t, mfa.RecoveryCodeCount-1, enrollment.RecoveryCodes().Remaining())
+}
+
+func TestVerifyMFAUseCase_InvalidCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+	mockTOTP := mocks.NewMockTOTPService(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
+
+	userID, _ := user.NewUserID("user-123")
+	email, _ := user.NewEmail("test@example.com", true)
+	domainUser, _ := user.NewUser(userID, email, user.NewProfile("Test User", ""))
+	enrollment, _ := newConfirmedEnrollment(t, userID)
+
+	mockTokenGen.EXPECT().ValidateMFAToken("mfa-token").Return(&ports.TokenClaims{UserID: "user-123"}, nil)
+	mockRepo.EXPECT().FindByID(ctx, userID).Return(domainUser, nil)
+	mockMFARepo.EXPECT().FindByUserID(ctx, userID).Return(enrollment, nil)
+	mockTOTP.EXPECT().Validate(gomock.Any(), "000000", gomock.Any()).Return(int64(0), false)
+
+	useCase := NewVerifyMFAUseCase(mockRepo, mockSessionRepo, mockMFARepo, mockTokenGen, mockTOTP, mockPublisher)
+
+	result, err := useCase.Execute(ctx, "mfa-token", dto.MFACodeRequest{Code: "000000"})
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrInvalidMFACode, err)
+}
+
+func TestVerifyMFAUseCase_ReplayedStep(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockMFARepo := mocks.NewMockMFARepository(ctrl)
+	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+	mockTOTP := mocks.NewMockTOTPService(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
+
+	userID, _ := user.NewUserID("user-123")
+	email, _ := user.NewEmail("test@example.com", true)
+	domainUser, _ := user.NewUser(userID, email, user.NewProfile("Test User", ""))
+	enrollment, _ := newConfirmedEnrollment(t, userID)
+
+	mockTokenGen.EXPECT().ValidateMFAToken("mfa-token").Return(&ports.TokenClaims{UserID: "user-123"}, nil)
+	mockRepo.EXPECT().FindByID(ctx, userID).Return(domainUser, nil)
+	mockMFARepo.EXPECT().FindByUserID(ctx, userID).Return(enrollment, nil)
+	// Step 1 was already used to confirm the enrollment
+	mockTOTP.EXPECT().Validate(gomock.Any(), "123456", gomock.Any()).Return(int64(1), true)
+
+	useCase := NewVerifyMFAUseCase(mockRepo, mockSessionRepo, mockMFARepo, mockTokenGen, mockTOTP, mockPublisher)
+
+	_, err := useCase.Execute(ctx, "mfa-token", dto.MFACodeRequest{Code: "123456"})
+
+	assert.Equal(t, shared.ErrInvalidMFACode, err)
+}
+
+func TestVerifyMFAUseCase_InvalidToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockTokenGen := mocks.NewMockTokenGenerator(ctrl)
+
+	tests := []struct {
+		name     string
+		tokenErr error
+		expected error
+	}{
+		{"expired", ports.ErrExpiredToken, ports.ErrExpiredToken},
+		{"invalid", ports.ErrInvalidToken, ports.ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTokenGen.EXPECT().ValidateMFAToken("bad-token").Return(nil, tt.tokenErr)
+
+			useCase := NewVerifyMFAUseCase(
+				mocks.NewMockRepository(ctrl),
+				mocks.NewMockSessionRepository(ctrl),
+				mocks.NewMockMFARepository(ctrl),
+				mockTokenGen,
+				mocks.NewMockTOTPService(ctrl),
+				mocks.NewMockEventPublisher(ctrl),
+			)
+
+			result, err := useCase.Execute(ctx, "bad-token", dto.MFACodeRequest{Code: "123456"})
+
+			assert.Nil(t, result)
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
+
+func TestSecondFactorAMR(t *testing.T) {
+	tests := []struct {
+		name     string
+		claims   *ports.TokenClaims
+		expected []string
+	}{
+		{
+			name:     "Google first factor",
+			claims:   &ports.TokenClaims{AMR: []string{ports.AMRFederated}},
+			expected: []string{ports.AMRFederated, ports.AMROTP, ports.AMRMultiFactor},
+		},
+		{
+			name:     "email link first factor",
+			claims:   &ports.TokenClaims{AMR: []string{ports.AMREmailLink}},
+			expected: []string{ports.AMREmailLink, ports.AMROTP, ports.AMRMultiFactor},
+		},
+		{
+			name:     "token issued before amr was recorded",
+			claims:   &ports.TokenClaims{},
+			expected: []string{ports.AMRFederated, ports.AMROTP, ports.AMRMultiFactor},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, secondFactorAMR(tt.claims, ports.AMROTP))
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package dto
+
+// UpdateProfileRequest represents a partial profile update (PATCH /api/me)
+// Omitted fields are left unchanged
+type UpdateProfileRequest struct {
+	DisplayName *string            `json:"display_name"`
+	Picture     *string            `json:"picture"`
+	Locale      *string            `json:"locale"`
+	Timezone    *string            `json:"timezone"`
+	Preferences map[string]*string `json:"preferences"` // a null value removes the key
+}
@@ -0,0 +1,94 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/audit"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// DeleteAccountResponse represents the response from an account deletion
+type DeleteAccountResponse struct {
+	Message string    `json:"message"`
+	PurgeAt time.Time `json:"purge_at"`
+}
+
+// AccountExport is the data archive returned by the data export endpoint
+type AccountExport struct {
+	ExportedAt   time.Time          `json:"exported_at"`
+	Profile      UserResponse       `json:"profile"`
+	Status       string             `json:"status"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at"`
+	Identities   []IdentityExport   `json:"identities"`
+	Sessions     []SessionExport    `json:"sessions"`
+	AuditHistory []AuditEntryExport `json:"audit_history"`
+}
+
+// IdentityExport describes an external identity linked to the account
+type IdentityExport struct {
+	Provider string `json:"provider"`
+	Subject  string `json:"subject"`
+	Email    string `json:"email"`
+}
+
+// SessionExport describes a login session in a data export
+type SessionExport struct {
+	ID         string     `json:"id"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt time.Time  `json:"last_used_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// AuditEntryExport describes an audit log entry in a data export
+type AuditEntryExport struct {
+	Action     string    `json:"action"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Actor      string    `json:"actor,omitempty"` // administrator acting as the user
+}
+
+// NewAccountExport builds a data export from the user's aggregate, sessions and audit history
+func NewAccountExport(u *user.User, sessions []*session.Session, entries []audit.Entry, exportedAt time.Time) AccountExport {
+	export := AccountExport{
+		ExportedAt: exportedAt,
+		Profile:    FromDomain(u),
+		Status:     u.Status().String(),
+		CreatedAt:  u.CreatedAt(),
+		UpdatedAt:  u.UpdatedAt(),
+		Identities: []IdentityExport{
+			{
+				Provider: "google",
+				Subject:  u.ID().Value(),
+				Email:    u.Email().Value(),
+			},
+		},
+		Sessions:     make([]SessionExport, 0, len(sessions)),
+		AuditHistory: make([]AuditEntryExport, 0, len(entries)),
+	}
+
+	for _, s := range sessions {
+		sessionExport := SessionExport{
+			ID:         s.ID().Value(),
+			CreatedAt:  s.CreatedAt(),
+			LastUsedAt: s.LastUsedAt(),
+			ExpiresAt:  s.ExpiresAt(),
+		}
+		if s.IsRevoked() {
+			revokedAt := s.RevokedAt()
+			sessionExport.RevokedAt = &revokedAt
+		}
+		export.Sessions = append(export.Sessions, sessionExport)
+	}
+
+	for _, entry := range entries {
+		export.AuditHistory = append(export.AuditHistory, AuditEntryExport{
+			Action:     entry.Action(),
+			OccurredAt: entry.OccurredAt(),
+			Actor:      entry.ActorID(),
+		})
+	}
+
+	return export
+}
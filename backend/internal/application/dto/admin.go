@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+// ImpersonateRequest starts acting as a user; the optional Reason, such as a
+// support ticket, is recorded with the impersonation
+type ImpersonateRequest struct {
+	Reason string `json:"reason" binding:"max=200"`
+}
+
+// ImpersonationResponse returns the access token an administrator acts as a
+// user with. It cannot be refreshed.
+type ImpersonationResponse struct {
+	Message     string       `json:"message"`
+	User        UserResponse `json:"user"`
+	AccessToken string       `json:"access_token"`
+	TokenType   string       `json:"token_type"`
+	ExpiresIn   int          `json:"expires_in"`
+}
+
+// ImpersonationStatus tells GET /api/me that an administrator is acting as
+// the user
+type ImpersonationStatus struct {
+	AdminID   string    `json:"admin_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
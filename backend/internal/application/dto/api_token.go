@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/apitoken"
+)
+
+// CreateAPITokenRequest creates a personal access token
+// ExpiresInDays defaults to the configured lifetime when zero.
+type CreateAPITokenRequest struct {
+	Name          string   `json:"name" binding:"required"`
+	Scopes        []string `json:"scopes" binding:"required"`
+	ExpiresInDays int      `json:"expires_in_days"`
+}
+
+// APITokenResponse describes a personal access token without its secret
+type APITokenResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// CreatedAPITokenResponse returns a new token; the plain Token is only shown once
+type CreatedAPITokenResponse struct {
+	Token    string           `json:"token"`
+	APIToken APITokenResponse `json:"api_token"`
+}
+
+// APITokenListResponse lists the current user's personal access tokens
+type APITokenListResponse struct {
+	Tokens []APITokenResponse `json:"tokens"`
+}
+
+// NewAPITokenResponse converts a domain token to an APITokenResponse
+func NewAPITokenResponse(t *apitoken.Token) APITokenResponse {
+	response := APITokenResponse{
+		ID:        t.ID().Value(),
+		Name:      t.Name(),
+		Scopes:    t.Scopes(),
+		CreatedAt: t.CreatedAt(),
+		ExpiresAt: t.ExpiresAt(),
+	}
+	if lastUsedAt := t.LastUsedAt(); !lastUsedAt.IsZero() {
+		response.LastUsedAt = &lastUsedAt
+	}
+	return response
+}
@@ -9,3 +9,30 @@ type GoogleLoginRequest struct {
 type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
 }
+
+// EmailLoginRequest requests a login link for an email address
+type EmailLoginRequest struct {
+	Email string `json:"email" binding:"required"`
+}
+
+// DisownLoginRequest confirms a login alert's "this wasn't me" link
+type DisownLoginRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// ReauthRequest carries the credentials for re-authenticating a signed-in
+// user: a Google ID token, a second-factor code, or both; or a link token
+// from a re-authentication email; or a passkey assertion
+type ReauthRequest struct {
+	Credential   string                   `json:"credential"`
+	Code         string                   `json:"code"`
+	RecoveryCode string                   `json:"recovery_code"`
+	EmailToken   string                   `json:"email_token"`
+	Passkey      *PasskeyAssertionRequest `json:"passkey"`
+}
+
+// ClientInfo describes the client a login request came from
+type ClientInfo struct {
+	UserAgent string
+	IPAddress string
+}
@@ -1,19 +1,50 @@
 package dto
 
 // LoginResponse represents the response from a login operation
+// When MFARequired is set, only MFAToken, MFAMethods and Message are filled in.
+// Tokens are set as cookies, or returned in a BearerTokenResponse to
+// non-browser clients.
 type LoginResponse struct {
 	AccessToken  string       `json:"-"` // Not included in JSON, set as cookie
 	RefreshToken string       `json:"-"` // Not included in JSON, set as cookie
 	User         UserResponse `json:"user"`
 	Message      string       `json:"message"`
+	MFARequired  bool         `json:"mfa_required,omitempty"`
+	MFAMethods   []string     `json:"mfa_methods,omitempty"`
+	MFAToken     string       `json:"-"` // Not included in JSON, set as cookie
 }
 
 // RefreshResponse represents the response from a token refresh operation
 type RefreshResponse struct {
 	AccessToken string `json:"-"` // Not included in JSON, set as cookie
+	DPoPBound   bool   `json:"-"` // the access token is bound to a DPoP key
 	Message     string `json:"message"`
 }
 
+// BearerTokenResponse returns tokens in the response body to non-browser
+// clients, which send the access token in an Authorization header
+type BearerTokenResponse struct {
+	Message      string        `json:"message"`
+	User         *UserResponse `json:"user,omitempty"`
+	AccessToken  string        `json:"access_token"`
+	RefreshToken string        `json:"refresh_token,omitempty"`
+	TokenType    string        `json:"token_type"`
+	ExpiresIn    int           `json:"expires_in"`
+}
+
+// NewBearerTokenResponse creates a BearerTokenResponse for a login result
+func NewBearerTokenResponse(result *LoginResponse, expiresIn int) BearerTokenResponse {
+	user := result.User
+	return BearerTokenResponse{
+		Message:      result.Message,
+		User:         &user,
+		AccessToken:  result.AccessToken,
+		RefreshToken: result.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    expiresIn,
+	}
+}
+
 // LogoutResponse represents the response from a logout operation
 type LogoutResponse struct {
 	Message string `json:"message"`
@@ -0,0 +1,35 @@
+package dto
+
+import "time"
+
+// Second-factor methods offered to complete an MFA-pending login
+const (
+	MFAMethodTOTP    = "totp"
+	MFAMethodPasskey = "passkey"
+)
+
+// MFACodeRequest carries a second-factor code; exactly one field is expected
+type MFACodeRequest struct {
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recovery_code"`
+}
+
+// TOTPEnrollmentResponse is returned when TOTP enrollment starts
+type TOTPEnrollmentResponse struct {
+	Secret     string `json:"secret"`      // base32, for manual entry
+	OTPAuthURI string `json:"otpauth_uri"` // QR code payload
+}
+
+// RecoveryCodesResponse carries newly issued recovery codes, shown only once
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// MFAStatusResponse describes a user's second factors
+type MFAStatusResponse struct {
+	TOTPEnabled            bool       `json:"totp_enabled"`
+	TOTPPending            bool       `json:"totp_pending"`
+	EnabledAt              *time.Time `json:"enabled_at,omitempty"`
+	RecoveryCodesRemaining int        `json:"recovery_codes_remaining"`
+	Passkeys               int        `json:"passkeys"`
+}
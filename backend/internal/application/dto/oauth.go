@@ -0,0 +1,166 @@
+package dto
+
+// ClientCredentials are the credentials a client sent to an OAuth endpoint,
+// either a client secret or a private_key_jwt client assertion. Public
+// clients send only their client ID.
+type ClientCredentials struct {
+	ClientID            string
+	ClientSecret        string
+	ClientAssertionType string
+	ClientAssertion     string
+}
+
+// OAuthTokenResponse is a successful token endpoint response (RFC 6749, section 5.1)
+type OAuthTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+	Scope       string `json:"scope,omitempty"`
+	IDToken     string `json:"id_token,omitempty"` // authorization code grant only
+
+	// IssuedTokenType is set by token exchange (RFC 8693, section 2.2.1)
+	IssuedTokenType string `json:"issued_token_type,omitempty"`
+}
+
+// TokenExchangeRequest holds the parameters of a token exchange request
+// (RFC 8693, section 2.1)
+type TokenExchangeRequest struct {
+	SubjectToken string
+	Audience     string
+	Scope        string
+}
+
+// Actor is a client acting on a user's behalf (RFC 8693, section 4.1)
+type Actor struct {
+	Subject string `json:"sub"`
+	Actor   *Actor `json:"act,omitempty"`
+}
+
+// IntrospectionResponse describes a token to a resource server (RFC 7662,
+// section 2.2). Only Active is set for tokens that are not active.
+type IntrospectionResponse struct {
+	Active    bool   `json:"active"`
+	Scope     string `json:"scope,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	Username  string `json:"username,omitempty"`
+	TokenType string `json:"token_type,omitempty"` // "Bearer", "DPoP", or "refresh_token" for refresh tokens
+	ExpiresAt int64  `json:"exp,omitempty"`
+	IssuedAt  int64  `json:"iat,omitempty"`
+	Subject   string `json:"sub,omitempty"`
+	TokenID   string `json:"jti,omitempty"`
+	SessionID string `json:"sid,omitempty"`
+	Audience  string `json:"aud,omitempty"`
+	Actor     *Actor `json:"act,omitempty"`
+	// OrganizationID is the organization selected when the token was issued
+	OrganizationID string `json:"org_id,omitempty"`
+	// Confirmation is the key a DPoP-bound token is bound to (RFC 9449, section 6.2)
+	Confirmation *Confirmation `json:"cnf,omitempty"`
+}
+
+// Confirmation identifies the key a token is bound to by its JWK SHA-256
+// thumbprint
+type Confirmation struct {
+	JWKThumbprint string `json:"jkt"`
+}
+
+// AuthorizationRequest holds the parameters of an OpenID Connect
+// authentication request (OpenID Connect Core, section 3.1.2.1)
+type AuthorizationRequest struct {
+	ClientID            string
+	RedirectURI         string
+	ResponseType        string
+	Scope               string
+	State               string
+	Nonce               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	Prompt              string
+}
+
+// AuthorizationResult tells the authorization endpoint where to send the
+// user next. Exactly one field is set.
+type AuthorizationResult struct {
+	// RedirectTo is the client's redirect URI with a code or an error
+	RedirectTo string
+
+	// LoginRequired is set when the user has to sign in first
+	LoginRequired bool
+
+	// ConsentRequest is set when the user has to approve the client; it is a
+	// signed token for the consent screen
+	ConsentRequest string
+}
+
+// ConsentRequestResponse describes a pending consent request to the consent screen
+type ConsentRequestResponse struct {
+	ClientID    string   `json:"client_id"`
+	ClientName  string   `json:"client_name"`
+	RedirectURI string   `json:"redirect_uri"`
+	Scopes      []string `json:"scopes"`
+}
+
+// ConsentDecisionRequest is the user's answer to a consent request
+type ConsentDecisionRequest struct {
+	Request string `json:"request" binding:"required"`
+	Approve bool   `json:"approve"`
+}
+
+// ConsentDecisionResponse tells the consent screen where to send the user
+type ConsentDecisionResponse struct {
+	RedirectTo string `json:"redirect_to"`
+}
+
+// UserInfoResponse is a UserInfo endpoint response (OpenID Connect Core,
+// section 5.3); the profile claims depend on the scopes granted
+type UserInfoResponse struct {
+	Subject       string `json:"sub"`
+	Name          string `json:"name,omitempty"`
+	Picture       string `json:"picture,omitempty"`
+	Email         string `json:"email,omitempty"`
+	EmailVerified *bool  `json:"email_verified,omitempty"`
+}
+
+// ClientRegistrationRequest is a dynamic client registration request (RFC 7591, section 2)
+type ClientRegistrationRequest struct {
+	ClientName              string   `json:"client_name"`
+	RedirectURIs            []string `json:"redirect_uris"`
+	TokenEndpointAuthMethod string   `json:"token_endpoint_auth_method"`
+	Scope                   string   `json:"scope"`
+}
+
+// ClientRegistrationResponse is a successful registration response (RFC 7591,
+// section 3.2.1). The client secret is only ever shown here.
+type ClientRegistrationResponse struct {
+	ClientID                string   `json:"client_id"`
+	ClientSecret            string   `json:"client_secret,omitempty"`
+	ClientIDIssuedAt        int64    `json:"client_id_issued_at"`
+	ClientSecretExpiresAt   int64    `json:"client_secret_expires_at"` // 0: never expires
+	ClientName              string   `json:"client_name"`
+	RedirectURIs            []string `json:"redirect_uris"`
+	TokenEndpointAuthMethod string   `json:"token_endpoint_auth_method"`
+	GrantTypes              []string `json:"grant_types"`
+	ResponseTypes           []string `json:"response_types"`
+	Scope                   string   `json:"scope"`
+}
+
+// OpenIDConfiguration is the OpenID Provider discovery document (OpenID
+// Connect Discovery, section 3)
+type OpenIDConfiguration struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserInfoEndpoint                  string   `json:"userinfo_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	RegistrationEndpoint              string   `json:"registration_endpoint,omitempty"`
+	IntrospectionEndpoint             string   `json:"introspection_endpoint"`
+	RevocationEndpoint                string   `json:"revocation_endpoint"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	PromptValuesSupported             []string `json:"prompt_values_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+}
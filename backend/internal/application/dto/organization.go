@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/organization"
+)
+
+// CreateOrganizationRequest creates an organization owned by the current user
+type CreateOrganizationRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// SelectOrganizationRequest makes an organization the one the user's tokens
+// act in; an empty OrganizationID clears the selection
+type SelectOrganizationRequest struct {
+	OrganizationID string `json:"organization_id"`
+}
+
+// InviteMemberRequest invites an email address to the active organization
+type InviteMemberRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"required"`
+}
+
+// AcceptInvitationRequest accepts an invitation with the token from its email
+type AcceptInvitationRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// ChangeMemberRoleRequest gives a member of the active organization a role
+type ChangeMemberRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// OrganizationResponse describes an organization the current user belongs to
+type OrganizationResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// OrganizationListResponse lists the current user's organizations, oldest
+// membership first
+type OrganizationListResponse struct {
+	Organizations []OrganizationResponse `json:"organizations"`
+}
+
+// MemberResponse describes a member of an organization
+type MemberResponse struct {
+	UserID   string    `json:"user_id"`
+	Email    string    `json:"email"`
+	Name     string    `json:"name"`
+	Picture  string    `json:"picture"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// InvitationResponse describes a pending invitation without its token
+type InvitationResponse struct {
+	ID        string    `json:"id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	InvitedBy string    `json:"invited_by"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// ActiveOrganizationResponse describes the active organization and its
+// members. Invitations are only listed for members who may manage them.
+type ActiveOrganizationResponse struct {
+	Organization OrganizationResponse `json:"organization"`
+	Members      []MemberResponse     `json:"members"`
+	Invitations  []InvitationResponse `json:"invitations,omitempty"`
+}
+
+// NewInvitationResponse converts a domain invitation to an InvitationResponse
+func NewInvitationResponse(i *organization.Invitation) InvitationResponse {
+	return InvitationResponse{
+		ID:        i.ID().Value(),
+		Email:     i.Email().Value(),
+		Role:      i.Role().String(),
+		InvitedBy: i.InvitedBy().Value(),
+		CreatedAt: i.CreatedAt(),
+		ExpiresAt: i.ExpiresAt(),
+	}
+}
@@ -0,0 +1,124 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// The option types below serialize to the JSON accepted by the browser's
+// PublicKeyCredential.parseCreationOptionsFromJSON() and
+// parseRequestOptionsFromJSON(); binary values are unpadded base64url.
+
+// PasskeyRelyingParty identifies the site credentials are created for
+type PasskeyRelyingParty struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// PasskeyUserEntity identifies the account a credential is created for
+type PasskeyUserEntity struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+// PasskeyCredentialParameter is a supported public key algorithm
+type PasskeyCredentialParameter struct {
+	Type string `json:"type"`
+	Alg  int    `json:"alg"`
+}
+
+// PasskeyCredentialDescriptor refers to an existing credential
+type PasskeyCredentialDescriptor struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// PasskeyAuthenticatorSelection states the authenticator requirements
+type PasskeyAuthenticatorSelection struct {
+	ResidentKey        string `json:"residentKey"`
+	RequireResidentKey bool   `json:"requireResidentKey"`
+	UserVerification   string `json:"userVerification"`
+}
+
+// PasskeyCreationOptions are the options for navigator.credentials.create()
+type PasskeyCreationOptions struct {
+	Challenge              string                        `json:"challenge"`
+	RP                     PasskeyRelyingParty           `json:"rp"`
+	User                   PasskeyUserEntity             `json:"user"`
+	PubKeyCredParams       []PasskeyCredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                         `json:"timeout"`
+	ExcludeCredentials     []PasskeyCredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection PasskeyAuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                        `json:"attestation"`
+}
+
+// PasskeyRequestOptions are the options for navigator.credentials.get()
+type PasskeyRequestOptions struct {
+	Challenge        string                        `json:"challenge"`
+	Timeout          int64                         `json:"timeout"`
+	RPID             string                        `json:"rpId"`
+	AllowCredentials []PasskeyCredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                        `json:"userVerification"`
+}
+
+// PasskeyAttestationResponse is the response part of a new credential
+type PasskeyAttestationResponse struct {
+	ClientDataJSON    string `json:"clientDataJSON"`
+	AttestationObject string `json:"attestationObject"`
+}
+
+// PasskeyAttestationCredential is a new credential, as serialized by PublicKeyCredential.toJSON()
+type PasskeyAttestationCredential struct {
+	ID       string                     `json:"id"`
+	Type     string                     `json:"type"`
+	Response PasskeyAttestationResponse `json:"response"`
+}
+
+// PasskeyRegistrationRequest registers a new passkey under a display name
+type PasskeyRegistrationRequest struct {
+	Name       string                       `json:"name"`
+	Credential PasskeyAttestationCredential `json:"credential"`
+}
+
+// PasskeyAssertionResponse is the response part of an assertion
+type PasskeyAssertionResponse struct {
+	ClientDataJSON    string `json:"clientDataJSON"`
+	AuthenticatorData string `json:"authenticatorData"`
+	Signature         string `json:"signature"`
+	UserHandle        string `json:"userHandle,omitempty"`
+}
+
+// PasskeyAssertionRequest is an assertion, as serialized by PublicKeyCredential.toJSON()
+type PasskeyAssertionRequest struct {
+	ID       string                   `json:"id"`
+	Type     string                   `json:"type"`
+	Response PasskeyAssertionResponse `json:"response"`
+}
+
+// PasskeyResponse describes a registered passkey
+type PasskeyResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// PasskeyListResponse lists the current user's passkeys
+type PasskeyListResponse struct {
+	Passkeys []PasskeyResponse `json:"passkeys"`
+}
+
+// NewPasskeyResponse converts a domain passkey to a PasskeyResponse
+func NewPasskeyResponse(p user.Passkey) PasskeyResponse {
+	response := PasskeyResponse{
+		ID:        p.ID(),
+		Name:      p.Name(),
+		CreatedAt: p.CreatedAt(),
+	}
+	if lastUsedAt := p.LastUsedAt(); !lastUsedAt.IsZero() {
+		response.LastUsedAt = &lastUsedAt
+	}
+	return response
+}
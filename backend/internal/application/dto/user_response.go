@@ -1,23 +1,57 @@
 package dto
 
-import "github.com/yuki5155/go-google-auth/internal/domain/user"
+import (
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
 
 // UserResponse represents user information in API responses
 type UserResponse struct {
-	ID      string `json:"id"`
-	Email   string `json:"email"`
-	Name    string `json:"name"`
-	Picture string `json:"picture"`
+	ID           string            `json:"id"`
+	Email        string            `json:"email"`
+	Name         string            `json:"name"`
+	Picture      string            `json:"picture"`
+	Locale       string            `json:"locale,omitempty"`
+	Timezone     string            `json:"timezone,omitempty"`
+	Preferences  map[string]string `json:"preferences,omitempty"`
+	Roles        []string          `json:"roles,omitempty"`
+	Status       string            `json:"status,omitempty"`
+	CreatedAt    *time.Time        `json:"created_at,omitempty"`
+	UpdatedAt    *time.Time        `json:"updated_at,omitempty"`
+	LastLoginAt  *time.Time        `json:"last_login_at,omitempty"`
+	LoginCount   int               `json:"login_count"`
+	RecentLogins []time.Time       `json:"recent_logins,omitempty"`
 }
 
 // FromDomain converts a domain User to a UserResponse DTO
 func FromDomain(u *user.User) UserResponse {
-	return UserResponse{
-		ID:      u.ID().Value(),
-		Email:   u.Email().Value(),
-		Name:    u.Profile().Name(),
-		Picture: u.Profile().Picture(),
+	response := UserResponse{
+		ID:           u.ID().Value(),
+		Email:        u.Email().Value(),
+		Name:         u.Profile().Name(),
+		Picture:      u.Profile().Picture(),
+		Locale:       u.Profile().Locale(),
+		Timezone:     u.Profile().Timezone(),
+		Preferences:  u.Preferences().All(),
+		Roles:        u.Roles().Strings(),
+		Status:       u.Status().String(),
+		LoginCount:   u.LoginCount(),
+		RecentLogins: u.LoginHistory().RecentLogins(),
+	}
+
+	if createdAt := u.CreatedAt(); !createdAt.IsZero() {
+		response.CreatedAt = &createdAt
 	}
+	if updatedAt := u.UpdatedAt(); !updatedAt.IsZero() {
+		response.UpdatedAt = &updatedAt
+	}
+
+	if lastLoginAt := u.LastLoginAt(); !lastLoginAt.IsZero() {
+		response.LastLoginAt = &lastLoginAt
+	}
+
+	return response
 }
 
 // NewUserResponse creates a UserResponse from individual fields
@@ -0,0 +1,34 @@
+// Package events hands the domain events recorded by aggregates to the event
+// publisher once the use cases have saved them.
+package events
+
+import (
+	"context"
+	"log"
+
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+// Source is an aggregate that records domain events
+type Source interface {
+	DomainEvents() []shared.DomainEvent
+	ClearDomainEvents()
+}
+
+// Publish publishes and clears the pending domain events of each source.
+// Publishing is best-effort: the aggregates have already been saved, so a
+// failure is logged rather than failing the request.
+func Publish(ctx context.Context, publisher ports.EventPublisher, sources ...Source) {
+	for _, source := range sources {
+		pending := source.DomainEvents()
+		if len(pending) == 0 {
+			continue
+		}
+
+		if err := publisher.Publish(ctx, pending); err != nil {
+			log.Printf("Failed to publish domain events for %s: %v", pending[0].AggregateID(), err)
+		}
+		source.ClearDomainEvents()
+	}
+}
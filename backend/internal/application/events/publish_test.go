@@ -0,0 +1,56 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+	"github.com/yuki5155/go-google-auth/internal/mocks"
+)
+
+// fakeSource records domain events like an aggregate does
+type fakeSource struct {
+	events []shared.DomainEvent
+}
+
+func (s *fakeSource) DomainEvents() []shared.DomainEvent { return s.events }
+func (s *fakeSource) ClearDomainEvents()                 { s.events = nil }
+
+func TestPublish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	publisher := mocks.NewMockEventPublisher(ctrl)
+	first := &fakeSource{events: []shared.DomainEvent{user.NewUserLoggedInEvent("user-1", "a@example.com")}}
+	empty := &fakeSource{}
+	second := &fakeSource{events: []shared.DomainEvent{user.NewUserSuspendedEvent("user-2", "abuse")}}
+
+	publisher.EXPECT().Publish(ctx, first.events).Return(nil)
+	publisher.EXPECT().Publish(ctx, second.events).Return(nil)
+
+	Publish(ctx, publisher, first, empty, second)
+
+	assert.Empty(t, first.events)
+	assert.Empty(t, second.events)
+}
+
+func TestPublish_FailureIsNotFatal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	publisher := mocks.NewMockEventPublisher(ctrl)
+	source := &fakeSource{events: []shared.DomainEvent{user.NewUserLoggedInEvent("user-1", "a@example.com")}}
+
+	publisher.EXPECT().Publish(ctx, gomock.Any()).Return(errors.New("queue unavailable"))
+
+	Publish(ctx, publisher, source)
+
+	assert.Empty(t, source.events)
+}
@@ -0,0 +1,70 @@
+package ports
+
+// Limits on the claims enrichers add, since access tokens travel in a cookie
+// or header on every request
+const (
+	MaxExtraClaims     = 16
+	MaxExtraClaimsSize = 1024 // bytes of JSON
+)
+
+// Errors for claims enrichers that break the rules
+var (
+	ErrReservedClaim       = &TokenError{Message: "claim name is reserved"}
+	ErrExtraClaimsTooLarge = &TokenError{Message: "extra claims exceed the size limit"}
+)
+
+// ClaimsEnricher adds custom claims, such as a tenant, roles or feature
+// entitlements, to the access tokens issued when a user signs in and when a
+// session is refreshed. Enrichers run in order, and a later enricher's value
+// replaces an earlier one's. They may not set the claims the token already
+// uses (ErrReservedClaim), and together may add at most MaxExtraClaims
+// claims of MaxExtraClaimsSize bytes (ErrExtraClaimsTooLarge).
+type ClaimsEnricher interface {
+	// EnrichClaims returns the claims to add to user's access token. An
+	// error fails issuing the token.
+	EnrichClaims(user UserInfo) (ExtraClaims, error)
+}
+
+// ClaimsEnricherFunc adapts a function to ClaimsEnricher
+type ClaimsEnricherFunc func(user UserInfo) (ExtraClaims, error)
+
+// EnrichClaims calls f(user)
+func (f ClaimsEnricherFunc) EnrichClaims(user UserInfo) (ExtraClaims, error) {
+	return f(user)
+}
+
+// ExtraClaims are the custom claims of an access token by name. Read from a
+// token, values are what JSON decodes to: string, float64, bool, []any and
+// map[string]any.
+type ExtraClaims map[string]any
+
+// String returns the string claim name
+func (e ExtraClaims) String(name string) (string, bool) {
+	value, ok := e[name].(string)
+	return value, ok
+}
+
+// Strings returns the claim name as a list of strings
+func (e ExtraClaims) Strings(name string) ([]string, bool) {
+	switch value := e[name].(type) {
+	case []string:
+		return value, true
+	case []any:
+		values := make([]string, 0, len(value))
+		for _, item := range value {
+			s, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			values = append(values, s)
+		}
+		return values, true
+	}
+	return nil, false
+}
+
+// Bool returns the boolean claim name
+func (e ExtraClaims) Bool(name string) (bool, bool) {
+	value, ok := e[name].(bool)
+	return value, ok
+}
@@ -0,0 +1,32 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// ClientAssertionType is the client_assertion_type of private_key_jwt client
+// authentication (RFC 7523)
+const ClientAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+// ClientAssertion is a verified private_key_jwt client assertion
+type ClientAssertion struct {
+	ID        string // jti, recorded so that the assertion cannot be replayed
+	ExpiresAt time.Time
+}
+
+// ClientAssertionVerifier verifies the signed JWTs that private_key_jwt
+// clients authenticate with
+type ClientAssertionVerifier interface {
+	// Verify checks that assertion was issued by clientID for this server and
+	// is signed by the private key of publicKey (PEM), returning
+	// shared.ErrInvalidClient otherwise
+	Verify(assertion, clientID, publicKey string) (*ClientAssertion, error)
+}
+
+// ClientAssertionStore records used client assertions so that each can only be used once
+type ClientAssertionStore interface {
+	// MarkUsed records an assertion as used until it expires, returning
+	// shared.ErrInvalidClient if it was already used
+	MarkUsed(ctx context.Context, id string, expiresAt time.Time) error
+}
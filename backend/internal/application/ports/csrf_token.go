@@ -0,0 +1,12 @@
+package ports
+
+// CSRFTokenService issues and verifies anti-CSRF tokens for the
+// signed double-submit cookie pattern. Tokens are bound to a login session.
+type CSRFTokenService interface {
+	// Generate creates a new CSRF token signed for a login session
+	Generate(sessionID string) (string, error)
+
+	// Verify reports whether a token was issued by this service for the
+	// login session
+	Verify(token, sessionID string) bool
+}
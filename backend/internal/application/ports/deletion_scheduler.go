@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// DeletionScheduler defines the interface for scheduling hard deletion of
+// soft-deleted accounts once their grace period has passed
+type DeletionScheduler interface {
+	// Schedule records that a user's data should be purged at purgeAt
+	Schedule(ctx context.Context, userID string, purgeAt time.Time) error
+
+	// Due returns the IDs of users whose purge time is at or before now
+	Due(ctx context.Context, now time.Time) ([]string, error)
+
+	// Remove drops a user from the schedule
+	Remove(ctx context.Context, userID string) error
+}
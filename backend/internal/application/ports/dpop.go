@@ -0,0 +1,41 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// DPoPProof is a verified DPoP proof (RFC 9449)
+type DPoPProof struct {
+	ID         string    // jti, recorded so that the proof cannot be replayed
+	Thumbprint string    // JWK SHA-256 thumbprint of the proof's key (RFC 7638)
+	ExpiresAt  time.Time // when the proof stops being accepted
+}
+
+// DPoPProofRequest is the request a DPoP proof must have been made for
+type DPoPProofRequest struct {
+	Method string
+	Path   string // resolved against the API's public URL to check htu
+
+	// AccessToken is the token presented with the proof, which the proof
+	// must hash in its ath claim; empty at the refresh endpoint
+	AccessToken string
+}
+
+// DPoPVerifier verifies DPoP proofs and issues the nonces they must carry
+type DPoPVerifier interface {
+	// Verify checks that proof is signed by the key in its header and made
+	// for req, returning shared.ErrInvalidDPoPProof otherwise, or
+	// shared.ErrUseDPoPNonce when it lacks a current nonce
+	Verify(proof string, req DPoPProofRequest) (*DPoPProof, error)
+
+	// Nonce returns a fresh nonce for the DPoP-Nonce response header
+	Nonce() string
+}
+
+// DPoPReplayCache records used DPoP proofs so that each can only be used once
+type DPoPReplayCache interface {
+	// MarkUsed records a proof as used until it expires, returning
+	// shared.ErrInvalidDPoPProof if it was already used
+	MarkUsed(ctx context.Context, id string, expiresAt time.Time) error
+}
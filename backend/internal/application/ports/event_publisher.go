@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+// EventPublisher defines the interface for publishing domain events
+// after an aggregate has been persisted
+type EventPublisher interface {
+	// Publish delivers domain events to interested subscribers (e.g. the audit log)
+	Publish(ctx context.Context, events []shared.DomainEvent) error
+}
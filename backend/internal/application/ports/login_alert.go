@@ -0,0 +1,51 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+)
+
+// DeviceFingerprinter derives a coarse device fingerprint from a login request
+type DeviceFingerprinter interface {
+	// Fingerprint returns the device for a user agent and client IP address
+	Fingerprint(userAgent, ipAddress string) session.Device
+}
+
+// LoginAlert describes a login from an unfamiliar device or location
+type LoginAlert struct {
+	UserID          string
+	Email           string
+	Name            string
+	SessionID       string
+	Reasons         []string // session.ReasonNewDevice and/or session.ReasonNewLocation
+	UserAgentFamily string
+	Network         string
+	Country         string
+	OccurredAt      time.Time
+	RevokeURL       string // "this wasn't me" link that revokes the session
+}
+
+// LoginAlertNotifier tells a user about a suspicious login
+type LoginAlertNotifier interface {
+	// NotifySuspiciousLogin delivers the alert, e.g. by email or webhook
+	NotifySuspiciousLogin(ctx context.Context, alert LoginAlert) error
+}
+
+// RevokeLinkClaims are the contents of a verified session revoke link
+type RevokeLinkClaims struct {
+	UserID    string
+	SessionID string
+}
+
+// RevokeLinkTokenService issues and verifies the signed tokens carried by
+// "this wasn't me" links
+type RevokeLinkTokenService interface {
+	// Generate creates a signed token for a user's session
+	Generate(userID, sessionID string, expiresAt time.Time) (string, error)
+
+	// Verify checks a token's signature and expiry and returns its claims,
+	// or shared.ErrInvalidRevokeLink
+	Verify(token string, now time.Time) (*RevokeLinkClaims, error)
+}
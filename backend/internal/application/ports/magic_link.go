@@ -0,0 +1,30 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// MagicLinkClaims are the contents of a verified email login link
+type MagicLinkClaims struct {
+	ID        string // unique per link, recorded when the link is used
+	Email     string
+	ExpiresAt time.Time
+}
+
+// MagicLinkTokenService issues and verifies the signed tokens carried by email login links
+type MagicLinkTokenService interface {
+	// Generate creates a signed token for an email address
+	Generate(email string, expiresAt time.Time) (string, error)
+
+	// Verify checks a token's signature and expiry and returns its claims,
+	// or shared.ErrInvalidMagicLink
+	Verify(token string, now time.Time) (*MagicLinkClaims, error)
+}
+
+// MagicLinkStore records used login links so that each can only be used once
+type MagicLinkStore interface {
+	// MarkUsed records a link as used until it expires, returning
+	// shared.ErrInvalidMagicLink if it was already used
+	MarkUsed(ctx context.Context, id string, expiresAt time.Time) error
+}
@@ -0,0 +1,16 @@
+package ports
+
+import "context"
+
+// EmailMessage is a plain-text email
+type EmailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer defines the interface for sending email
+type Mailer interface {
+	// Send delivers a message to its recipient
+	Send(ctx context.Context, message EmailMessage) error
+}
@@ -0,0 +1,84 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// PKCEMethodS256 is the only PKCE code challenge method accepted (RFC 7636)
+const PKCEMethodS256 = "S256"
+
+// AuthorizationGrant is what a user approved at the authorization endpoint.
+// Authorization codes carry one, and so do consent requests waiting for the
+// user's answer.
+type AuthorizationGrant struct {
+	ID            string // unique per token, recorded when a code is redeemed
+	ClientID      string
+	RedirectURI   string
+	UserID        string
+	Scopes        []string
+	State         string // only carried by consent requests
+	Nonce         string
+	CodeChallenge string // S256 PKCE code challenge
+	SessionID     string
+	AuthTime      time.Time
+	AMR           []string
+	ACR           string
+	ExpiresAt     time.Time
+}
+
+// AuthorizationGrantTokens seals authorization grants into signed tokens, so
+// that codes issued by one instance can be redeemed at any other
+type AuthorizationGrantTokens interface {
+	// Seal creates a signed token for grant, which expires at
+	// grant.ExpiresAt and gets a new ID
+	Seal(grant AuthorizationGrant) (string, error)
+
+	// Open checks a token's signature and expiry and returns its grant, or
+	// shared.ErrInvalidGrant
+	Open(token string, now time.Time) (*AuthorizationGrant, error)
+}
+
+// AuthorizationCodeStore records redeemed authorization codes so that each can only be used once
+type AuthorizationCodeStore interface {
+	// MarkUsed records a code as used until it expires, returning
+	// shared.ErrInvalidGrant if it was already used
+	MarkUsed(ctx context.Context, id string, expiresAt time.Time) error
+}
+
+// IDTokenClaims are the claims of an OpenID Connect ID token. The profile
+// claims are only set for the scopes the client was granted.
+type IDTokenClaims struct {
+	Subject   string
+	Audience  string // the client the user signed in to
+	Nonce     string
+	SessionID string
+	AuthTime  time.Time
+	AMR       []string
+	ACR       string
+
+	Email         string
+	EmailVerified bool
+	Name          string
+	Picture       string
+}
+
+// JSONWebKey is a public signing key in JWK format (RFC 7517)
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	Modulus   string `json:"n"`
+	Exponent  string `json:"e"`
+}
+
+// IDTokenIssuer signs ID tokens with a private key, whose public half relying
+// parties fetch from the JWKS endpoint
+type IDTokenIssuer interface {
+	// IssueIDToken creates a signed ID token
+	IssueIDToken(claims IDTokenClaims) (string, error)
+
+	// PublicKeys returns the keys ID tokens may be verified with
+	PublicKeys() []JSONWebKey
+}
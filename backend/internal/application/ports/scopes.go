@@ -0,0 +1,31 @@
+package ports
+
+import "slices"
+
+// Scopes a personal access token can be granted. Session tokens are not
+// limited by scope; a route that accepts personal access tokens declares the
+// scope it needs with middleware.RequireScope.
+const (
+	ScopeProfileRead   = "profile:read"   // read the user's profile
+	ScopeProfileWrite  = "profile:write"  // update the user's profile
+	ScopeAccountExport = "account:export" // download the user's data export
+)
+
+// APITokenScopes lists the scopes personal access tokens may be granted
+var APITokenScopes = []string{ScopeProfileRead, ScopeProfileWrite, ScopeAccountExport}
+
+// IsAPITokenScope reports whether a personal access token may be granted scope
+func IsAPITokenScope(scope string) bool {
+	return slices.Contains(APITokenScopes, scope)
+}
+
+// OpenID Connect scopes relying parties may request when signing users in
+const (
+	ScopeOpenID  = "openid"  // sign the user in; required for every OIDC request
+	ScopeProfile = "profile" // the user's name and picture
+	ScopeEmail   = "email"   // the user's email address
+)
+
+// OIDCScopes lists the scopes clients registered through the registration
+// endpoint may request
+var OIDCScopes = []string{ScopeOpenID, ScopeProfile, ScopeEmail}
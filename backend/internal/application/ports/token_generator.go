@@ -1,13 +1,50 @@
 package ports
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 // UserInfo represents user information for token generation
 type UserInfo struct {
-	UserID  string
-	Email   string
-	Name    string
-	Picture string
+	UserID    string
+	Email     string
+	Name      string
+	Picture   string
+	SessionID string    // login session the tokens belong to
+	AMR       []string  // authentication methods used to log in (amr claim)
+	AuthTime  time.Time // when the user last authenticated (auth_time claim)
+	ACR       string    // assurance level of that authentication (acr claim)
+	DPoPKey   string    // thumbprint of the DPoP key the token is bound to (cnf claim)
+
+	// OrganizationID is the organization the user has selected (org_id
+	// claim); empty when none is
+	OrganizationID string
+}
+
+// Authentication method references recorded in the amr claim (RFC 8176)
+const (
+	AMRFederated    = "fed"   // signed in through Google
+	AMROTP          = "otp"   // TOTP code from an authenticator app
+	AMRRecoveryCode = "rec"   // single-use MFA recovery code
+	AMRHardwareKey  = "hwk"   // WebAuthn passkey or security key
+	AMREmailLink    = "email" // single-use login link sent by email
+	AMRMultiFactor  = "mfa"   // more than one factor was used
+)
+
+// Authentication context class references recorded in the acr claim, after
+// the NIST SP 800-63B authenticator assurance levels
+const (
+	ACRSingleFactor = "aal1"
+	ACRMultiFactor  = "aal2"
+)
+
+// ACRForAMR returns the acr value for the authentication methods in amr
+func ACRForAMR(amr []string) string {
+	if slices.Contains(amr, AMRMultiFactor) {
+		return ACRMultiFactor
+	}
+	return ACRSingleFactor
 }
 
 // TokenPair represents an access token and refresh token pair
@@ -18,10 +55,99 @@ type TokenPair struct {
 
 // TokenClaims represents the claims extracted from a token
 type TokenClaims struct {
-	UserID  string
-	Email   string
-	Name    string
-	Picture string
+	UserID    string
+	Email     string
+	Name      string
+	Picture   string
+	SessionID string    // empty for tokens issued before sessions existed
+	AMR       []string  // empty for tokens issued before amr was recorded
+	AuthTime  time.Time // zero for tokens issued before auth_time was recorded
+	ACR       string    // empty for tokens issued before acr was recorded
+
+	// OrganizationID is the organization the user had selected when the
+	// token was issued. It only picks the organization; membership is
+	// checked on each request.
+	OrganizationID string
+
+	// Scopes limits what the token may do; nil for session tokens, which may
+	// do anything the user can
+	Scopes []string
+
+	// APITokenID is set when the request used a personal access token
+	APITokenID string
+
+	// ClientID is the service client the token was issued to (client_id claim)
+	ClientID string
+
+	// TokenID identifies the token (jti claim); empty for personal access
+	// tokens and tokens issued before jti was recorded
+	TokenID   string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+
+	// Audience is the other service an exchanged token is meant for (aud
+	// claim); empty for tokens meant for this API
+	Audience string
+
+	// Actor is the client acting for the user in an exchanged token, or the
+	// administrator impersonating them (act claim)
+	Actor *Actor
+
+	// Extras are the custom claims ClaimsEnrichers added; nil when there are none
+	Extras ExtraClaims
+
+	// DPoPKey is the thumbprint of the DPoP key the token is bound to (cnf
+	// claim, RFC 9449); empty for bearer tokens
+	DPoPKey string
+}
+
+// Actor identifies a client or administrator acting on a user's behalf (RFC
+// 8693, section 4.1). A token exchanged more than once nests the earlier actors.
+type Actor struct {
+	Subject string `json:"sub"`
+	Actor   *Actor `json:"act,omitempty"`
+}
+
+// ExchangedToken describes an access token issued by token exchange (RFC 8693)
+type ExchangedToken struct {
+	User      UserInfo
+	ClientID  string // the client the token is issued to
+	Audience  string
+	Scopes    []string
+	Actor     *Actor
+	ExpiresAt time.Time
+}
+
+// HasScope reports whether the token grants scope
+func (c *TokenClaims) HasScope(scope string) bool {
+	return c.Scopes == nil || slices.Contains(c.Scopes, scope)
+}
+
+// IsAPIToken reports whether the claims come from a personal access token
+func (c *TokenClaims) IsAPIToken() bool {
+	return c.APITokenID != ""
+}
+
+// IsServiceClient reports whether the token was issued to a service client
+// acting on its own behalf rather than for a user
+func (c *TokenClaims) IsServiceClient() bool {
+	return c.ClientID != "" && c.UserID == ""
+}
+
+// ImpersonatorID returns the administrator acting as the user in an
+// impersonation token, or "". Exchanged tokens, whose actor is a client,
+// are not impersonation tokens.
+func (c *TokenClaims) ImpersonatorID() string {
+	if c.Actor == nil || c.ClientID != "" {
+		return ""
+	}
+	return c.Actor.Subject
+}
+
+// AuthenticatedWithin reports whether the user authenticated no more than
+// maxAge before now; tokens without auth_time never count as recent
+func (c *TokenClaims) AuthenticatedWithin(maxAge time.Duration, now time.Time) bool {
+	return !c.AuthTime.IsZero() && now.Sub(c.AuthTime) <= maxAge
 }
 
 // TokenGenerator defines the interface for JWT token operations
@@ -29,12 +155,50 @@ type TokenGenerator interface {
 	// GenerateTokenPair generates both access and refresh tokens
 	GenerateTokenPair(userInfo UserInfo) (accessToken, refreshToken string, err error)
 
-	// RefreshAccessToken generates a new access token from a valid refresh token
-	RefreshAccessToken(refreshToken string) (string, error)
+	// RefreshAccessToken generates a new access token from a valid refresh
+	// token, bound to the DPoP key with thumbprint dpopKey unless it is empty
+	RefreshAccessToken(refreshToken, dpopKey string) (string, error)
 
-	// ValidateAccessToken validates an access token and returns the claims
+	// ValidateAccessToken validates an access token meant for this API and
+	// returns the claims
 	ValidateAccessToken(accessToken string) (*TokenClaims, error)
 
+	// ValidateIssuedAccessToken validates an access token issued by this
+	// service for any audience, for the token endpoints that also handle
+	// tokens meant for other services
+	ValidateIssuedAccessToken(accessToken string) (*TokenClaims, error)
+
+	// ValidateRefreshToken validates a refresh token and returns the claims
+	ValidateRefreshToken(refreshToken string) (*TokenClaims, error)
+
+	// GenerateMFAToken generates a short-lived token proving the first login
+	// factor (recorded in amr) succeeded, to be exchanged for a token pair
+	// after MFA verification
+	GenerateMFAToken(userID string, amr []string) (string, error)
+
+	// GenerateClientToken generates an access token for a service client
+	// acting on its own behalf, with client_id and scope claims and no user
+	GenerateClientToken(clientID string, scopes []string) (string, error)
+
+	// GenerateDelegatedToken generates an access token that a client uses on
+	// a user's behalf, with the user's claims and client_id and scope claims
+	GenerateDelegatedToken(user UserInfo, clientID string, scopes []string) (string, error)
+
+	// GenerateExchangedToken generates an access token for token exchange,
+	// with the user's claims, aud, scope and act claims and the given expiry
+	GenerateExchangedToken(token ExchangedToken) (string, error)
+
+	// GenerateImpersonationToken generates an access token with which the
+	// administrator adminID acts as user until expiresAt, naming the
+	// administrator in an act claim
+	GenerateImpersonationToken(user UserInfo, adminID string, expiresAt time.Time) (string, error)
+
+	// ValidateMFAToken validates an MFA-pending token and returns the claims
+	ValidateMFAToken(mfaToken string) (*TokenClaims, error)
+
+	// GetMFATokenExpiry returns the MFA-pending token expiry duration in seconds
+	GetMFATokenExpiry() int
+
 	// GetAccessTokenExpiry returns the access token expiry duration in seconds
 	GetAccessTokenExpiry() int
 
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// RevokedTokenStore records revoked access tokens by their jti until they
+// expire, for tokens that are not tied to a session that can be revoked instead
+type RevokedTokenStore interface {
+	// Revoke records a token as revoked until expiresAt
+	Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error
+
+	// IsRevoked reports whether a token was revoked
+	IsRevoked(ctx context.Context, tokenID string) (bool, error)
+}
@@ -0,0 +1,19 @@
+package ports
+
+import "time"
+
+// TOTPService generates and checks time-based one-time passwords (RFC 6238)
+type TOTPService interface {
+	// GenerateSecret creates a new random shared secret
+	GenerateSecret() ([]byte, error)
+
+	// EncodeSecret renders a secret for manual entry into an authenticator app
+	EncodeSecret(secret []byte) string
+
+	// ProvisioningURI builds the otpauth:// URI that authenticator apps scan as a QR code
+	ProvisioningURI(secret []byte, accountName string) string
+
+	// Validate checks a code at the given time, allowing for small clock drift,
+	// and returns the time step the code belongs to
+	Validate(secret []byte, code string, at time.Time) (step int64, ok bool)
+}
@@ -0,0 +1,89 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// WebAuthn ceremony purposes, recorded with each challenge so that a
+// challenge issued for one ceremony cannot complete another
+const (
+	WebAuthnPurposeRegistration = "registration"
+	WebAuthnPurposeLogin        = "login"
+	WebAuthnPurposeMFA          = "mfa"
+	WebAuthnPurposeReauth       = "reauth"
+)
+
+// WebAuthnChallenge is a pending WebAuthn ceremony
+type WebAuthnChallenge struct {
+	Challenge string // unpadded base64url, as echoed back in clientDataJSON
+	UserID    string // empty for passkey login, where the user is not yet known
+	Purpose   string
+	ExpiresAt time.Time
+}
+
+// WebAuthnChallengeStore keeps issued challenges until they are used once
+type WebAuthnChallengeStore interface {
+	// Save stores a new challenge
+	Save(ctx context.Context, challenge WebAuthnChallenge) error
+
+	// Consume removes and returns an unexpired challenge, or
+	// shared.ErrPasskeyChallengeInvalid if there is none
+	Consume(ctx context.Context, challenge string) (*WebAuthnChallenge, error)
+}
+
+// WebAuthnAttestation is the browser's response to navigator.credentials.create()
+type WebAuthnAttestation struct {
+	ClientDataJSON    []byte
+	AttestationObject []byte
+}
+
+// WebAuthnAssertion is the browser's response to navigator.credentials.get()
+type WebAuthnAssertion struct {
+	CredentialID      []byte
+	ClientDataJSON    []byte
+	AuthenticatorData []byte
+	Signature         []byte
+	UserHandle        []byte // set by discoverable credentials
+}
+
+// WebAuthnCredential is a credential verified during registration
+type WebAuthnCredential struct {
+	ID           []byte
+	PublicKey    []byte // COSE_Key encoded
+	SignCount    uint32
+	UserVerified bool
+}
+
+// WebAuthnAssertionResult is the outcome of a verified assertion
+type WebAuthnAssertionResult struct {
+	SignCount    uint32
+	UserVerified bool
+}
+
+// WebAuthnVerifier runs the relying party side of WebAuthn ceremonies.
+// Verification failures are reported as shared.ErrPasskeyVerification.
+type WebAuthnVerifier interface {
+	// RelyingPartyID returns the RP ID (the site's domain) credentials are scoped to
+	RelyingPartyID() string
+
+	// RelyingPartyName returns the site name shown by authenticators
+	RelyingPartyName() string
+
+	// Algorithms returns the supported COSE algorithm identifiers, most preferred first
+	Algorithms() []int
+
+	// NewChallenge creates a random challenge in unpadded base64url
+	NewChallenge() (string, error)
+
+	// ClientChallenge extracts the challenge the client signed from clientDataJSON
+	ClientChallenge(clientDataJSON []byte) (string, error)
+
+	// VerifyRegistration checks an attestation against the challenge and
+	// returns the new credential. Only "none" attestation is accepted.
+	VerifyRegistration(attestation WebAuthnAttestation, challenge string, requireUserVerification bool) (*WebAuthnCredential, error)
+
+	// VerifyAssertion checks an assertion against the challenge and the
+	// credential's COSE public key
+	VerifyAssertion(assertion WebAuthnAssertion, challenge string, publicKey []byte, requireUserVerification bool) (*WebAuthnAssertionResult, error)
+}
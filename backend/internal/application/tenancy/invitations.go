@@ -0,0 +1,271 @@
+package tenancy
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/events"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/organization"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// InviteMemberUseCase emails an invitation to join the active organization
+type InviteMemberUseCase struct {
+	orgRepo        organization.Repository
+	membershipRepo organization.MembershipRepository
+	invitationRepo organization.InvitationRepository
+	mailer         ports.Mailer
+	eventPublisher ports.EventPublisher
+	linkURL        string
+	ttl            time.Duration
+}
+
+// NewInviteMemberUseCase creates a new InviteMemberUseCase; linkURL is the
+// frontend page invitation links point to, and invitations last ttl
+func NewInviteMemberUseCase(
+	orgRepo organization.Repository,
+	membershipRepo organization.MembershipRepository,
+	invitationRepo organization.InvitationRepository,
+	mailer ports.Mailer,
+	eventPublisher ports.EventPublisher,
+	linkURL string,
+	ttl time.Duration,
+) *InviteMemberUseCase {
+	return &InviteMemberUseCase{
+		orgRepo:        orgRepo,
+		membershipRepo: membershipRepo,
+		invitationRepo: invitationRepo,
+		mailer:         mailer,
+		eventPublisher: eventPublisher,
+		linkURL:        linkURL,
+		ttl:            ttl,
+	}
+}
+
+// Execute invites req.Email with req.Role. A pending invitation for the same
+// address is replaced, so inviting again resends the email.
+func (uc *InviteMemberUseCase) Execute(ctx context.Context, claims *ports.TokenClaims, req dto.InviteMemberRequest) (*dto.InvitationResponse, error) {
+	inviter, err := activeMembership(ctx, uc.membershipRepo, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := organization.ParseRole(req.Role)
+	if err != nil {
+		return nil, err
+	}
+	email, err := user.NewEmail(req.Email, false)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := uc.orgRepo.FindByID(ctx, inviter.OrganizationID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve organization: %w", err)
+	}
+
+	now := time.Now()
+	existing, err := uc.invitationRepo.FindByOrganization(ctx, org.ID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invitations: %w", err)
+	}
+	var replaced *organization.Invitation
+	pending := 0
+	for _, invitation := range existing {
+		if !invitation.IsPending(now) {
+			continue
+		}
+		if invitation.Email().Value() == email.Value() {
+			replaced = invitation
+			continue
+		}
+		pending++
+	}
+	if pending >= organization.MaxPendingInvitations {
+		return nil, shared.ErrTooManyInvitations
+	}
+
+	invitation, plain, err := organization.NewInvitation(inviter, email, role, now.Add(uc.ttl))
+	if err != nil {
+		return nil, err
+	}
+	if replaced != nil {
+		if err := replaced.Revoke(inviter); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.invitationRepo.Save(ctx, invitation); err != nil {
+		return nil, fmt.Errorf("failed to save invitation: %w", err)
+	}
+	if replaced != nil {
+		if err := uc.invitationRepo.Delete(ctx, replaced.ID()); err != nil {
+			return nil, fmt.Errorf("failed to delete invitation: %w", err)
+		}
+		events.Publish(ctx, uc.eventPublisher, replaced)
+	}
+	events.Publish(ctx, uc.eventPublisher, invitation)
+
+	if err := uc.sendInvitation(ctx, org, invitation, plain); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Invitation %s to organization %s sent to %s", invitation.ID().Value(), org.ID().Value(), email.Value())
+	response := dto.NewInvitationResponse(invitation)
+	return &response, nil
+}
+
+// sendInvitation emails the invitation link with its plain token
+func (uc *InviteMemberUseCase) sendInvitation(ctx context.Context, org *organization.Organization, invitation *organization.Invitation, plain string) error {
+	link, err := url.Parse(uc.linkURL)
+	if err != nil {
+		return fmt.Errorf("invalid invitation link URL: %w", err)
+	}
+	query := link.Query()
+	query.Set("token", plain)
+	link.RawQuery = query.Encode()
+
+	message := ports.EmailMessage{
+		To:      invitation.Email().Value(),
+		Subject: fmt.Sprintf("You have been invited to join %s", org.Name()),
+		Body: fmt.Sprintf("You have been invited to join %s as %s. Sign in with this address and open this link "+
+			"to accept. It expires in %s:\n\n%s\n\nIf you were not expecting it, you can ignore this email.\n",
+			org.Name(), invitation.Role(), uc.ttl, link.String()),
+	}
+	if err := uc.mailer.Send(ctx, message); err != nil {
+		return fmt.Errorf("failed to send invitation: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeInvitationUseCase withdraws a pending invitation to the active organization
+type RevokeInvitationUseCase struct {
+	membershipRepo organization.MembershipRepository
+	invitationRepo organization.InvitationRepository
+	eventPublisher ports.EventPublisher
+}
+
+// NewRevokeInvitationUseCase creates a new RevokeInvitationUseCase
+func NewRevokeInvitationUseCase(
+	membershipRepo organization.MembershipRepository,
+	invitationRepo organization.InvitationRepository,
+	eventPublisher ports.EventPublisher,
+) *RevokeInvitationUseCase {
+	return &RevokeInvitationUseCase{
+		membershipRepo: membershipRepo,
+		invitationRepo: invitationRepo,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// Execute deletes the invitation so that its link stops working
+func (uc *RevokeInvitationUseCase) Execute(ctx context.Context, claims *ports.TokenClaims, rawInvitationID string) error {
+	actor, err := activeMembership(ctx, uc.membershipRepo, claims)
+	if err != nil {
+		return err
+	}
+
+	invitationID, err := organization.NewInvitationID(rawInvitationID)
+	if err != nil {
+		return err
+	}
+	invitation, err := uc.invitationRepo.FindByID(ctx, actor.OrganizationID(), invitationID)
+	if err != nil {
+		if err == shared.ErrInvitationNotFound {
+			return err
+		}
+		return fmt.Errorf("failed to retrieve invitation: %w", err)
+	}
+
+	if err := invitation.Revoke(actor); err != nil {
+		return err
+	}
+	if err := uc.invitationRepo.Delete(ctx, invitation.ID()); err != nil {
+		return fmt.Errorf("failed to delete invitation: %w", err)
+	}
+	events.Publish(ctx, uc.eventPublisher, invitation)
+
+	log.Printf("Invitation %s revoked by user %s", invitationID.Value(), actor.UserID().Value())
+	return nil
+}
+
+// AcceptInvitationUseCase makes the current user a member of the
+// organization an invitation was sent for
+type AcceptInvitationUseCase struct {
+	userRepo       user.Repository
+	orgRepo        organization.Repository
+	membershipRepo organization.MembershipRepository
+	invitationRepo organization.InvitationRepository
+	eventPublisher ports.EventPublisher
+}
+
+// NewAcceptInvitationUseCase creates a new AcceptInvitationUseCase
+func NewAcceptInvitationUseCase(
+	userRepo user.Repository,
+	orgRepo organization.Repository,
+	membershipRepo organization.MembershipRepository,
+	invitationRepo organization.InvitationRepository,
+	eventPublisher ports.EventPublisher,
+) *AcceptInvitationUseCase {
+	return &AcceptInvitationUseCase{
+		userRepo:       userRepo,
+		orgRepo:        orgRepo,
+		membershipRepo: membershipRepo,
+		invitationRepo: invitationRepo,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// Execute accepts the invitation with the token from its email. It can only
+// be used once, by the user whose verified email it was sent to.
+func (uc *AcceptInvitationUseCase) Execute(ctx context.Context, claims *ports.TokenClaims, req dto.AcceptInvitationRequest) (*dto.OrganizationResponse, error) {
+	domainUser, err := findUser(ctx, uc.userRepo, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation, err := uc.invitationRepo.FindByHash(ctx, organization.HashInvitationToken(req.Token))
+	if err != nil {
+		if err == shared.ErrInvitationNotFound {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to retrieve invitation: %w", err)
+	}
+
+	_, err = uc.membershipRepo.Find(ctx, invitation.OrganizationID(), domainUser.ID())
+	if err == nil {
+		return nil, shared.ErrAlreadyOrganizationMember
+	}
+	if err != shared.ErrNotOrganizationMember {
+		return nil, fmt.Errorf("failed to retrieve membership: %w", err)
+	}
+
+	membership, err := invitation.Accept(domainUser, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := uc.orgRepo.FindByID(ctx, invitation.OrganizationID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve organization: %w", err)
+	}
+
+	if err := uc.membershipRepo.Save(ctx, membership); err != nil {
+		return nil, fmt.Errorf("failed to save membership: %w", err)
+	}
+	if err := uc.invitationRepo.Delete(ctx, invitation.ID()); err != nil {
+		return nil, fmt.Errorf("failed to delete invitation: %w", err)
+	}
+	events.Publish(ctx, uc.eventPublisher, membership)
+
+	log.Printf("User %s joined organization %s", domainUser.ID().Value(), org.ID().Value())
+	response := organizationResponse(org, membership, claims)
+	return &response, nil
+}
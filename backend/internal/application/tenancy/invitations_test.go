@@ -0,0 +1,243 @@
+package tenancy
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/organization"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+func (m *tenancyMocks) inviteUseCase() *InviteMemberUseCase {
+	return NewInviteMemberUseCase(m.orgRepo, m.membershipRepo, m.invitationRepo, m.mailer, m.eventPublisher,
+		"https://app.example.com/invitations/accept", 7*24*time.Hour)
+}
+
+func (m *tenancyMocks) acceptUseCase() *AcceptInvitationUseCase {
+	return NewAcceptInvitationUseCase(m.userRepo, m.orgRepo, m.membershipRepo, m.invitationRepo, m.eventPublisher)
+}
+
+func TestInviteMemberUseCase_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newTenancyMocks(ctrl)
+	org, members := newTestOrganization()
+	claims := m.expectActiveMembership(ctx, members[1])
+
+	var saved *organization.Invitation
+	var sent ports.EmailMessage
+	m.orgRepo.EXPECT().FindByID(ctx, org.ID()).Return(org, nil)
+	m.invitationRepo.EXPECT().FindByOrganization(ctx, org.ID()).Return(nil, nil)
+	m.invitationRepo.EXPECT().Save(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, invitation *organization.Invitation) error {
+		saved = invitation
+		return nil
+	})
+	m.eventPublisher.EXPECT().Publish(ctx, gomock.Any()).Return(nil)
+	m.mailer.EXPECT().Send(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, message ports.EmailMessage) error {
+		sent = message
+		return nil
+	})
+
+	result, err := m.inviteUseCase().Execute(ctx, claims, dto.InviteMemberRequest{Email: "New@Example.com", Role: "member"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "new@example.com", result.Email)
+	assert.Equal(t, "member", result.Role)
+	assert.Equal(t, "admin-1", result.InvitedBy)
+	assert.WithinDuration(t, time.Now().Add(7*24*time.Hour), result.ExpiresAt, time.Second)
+
+	// The email carries the plain token, of which only the hash is stored
+	assert.Equal(t, "new@example.com", sent.To)
+	assert.Contains(t, sent.Subject, "Acme")
+	start := strings.Index(sent.Body, "https://app.example.com/invitations/accept?")
+	require.GreaterOrEqual(t, start, 0)
+	link, err := url.Parse(strings.Fields(sent.Body[start:])[0])
+	require.NoError(t, err)
+	assert.Equal(t, saved.Hash(), organization.HashInvitationToken(link.Query().Get("token")))
+}
+
+func TestInviteMemberUseCase_ReplacesPendingInvitation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newTenancyMocks(ctrl)
+	org, members := newTestOrganization()
+	claims := m.expectActiveMembership(ctx, members[0])
+	previous, _, err := organization.NewInvitation(members[0], mustEmail(t, "new@example.com"), organization.RoleMember, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	previous.ClearDomainEvents()
+
+	m.orgRepo.EXPECT().FindByID(ctx, org.ID()).Return(org, nil)
+	m.invitationRepo.EXPECT().FindByOrganization(ctx, org.ID()).Return([]*organization.Invitation{previous}, nil)
+	m.invitationRepo.EXPECT().Save(ctx, gomock.Any()).Return(nil)
+	m.invitationRepo.EXPECT().Delete(ctx, previous.ID()).Return(nil)
+	m.eventPublisher.EXPECT().Publish(ctx, gomock.Any()).Return(nil).Times(2)
+	m.mailer.EXPECT().Send(ctx, gomock.Any()).Return(nil)
+
+	result, err := m.inviteUseCase().Execute(ctx, claims, dto.InviteMemberRequest{Email: "new@example.com", Role: "admin"})
+
+	require.NoError(t, err)
+	assert.NotEqual(t, previous.ID().Value(), result.ID)
+	assert.Equal(t, "admin", result.Role)
+}
+
+func TestInviteMemberUseCase_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		member  int
+		req     dto.InviteMemberRequest
+		wantErr error
+	}{
+		{"member may not invite", 2, dto.InviteMemberRequest{Email: "new@example.com", Role: "member"}, shared.ErrOrganizationPermissionDenied},
+		{"admin may not invite owners", 1, dto.InviteMemberRequest{Email: "new@example.com", Role: "owner"}, shared.ErrOrganizationPermissionDenied},
+		{"unknown role", 0, dto.InviteMemberRequest{Email: "new@example.com", Role: "guest"}, shared.ErrInvalidOrganizationRole},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			m := newTenancyMocks(ctrl)
+			org, members := newTestOrganization()
+			claims := m.expectActiveMembership(ctx, members[tt.member])
+			m.orgRepo.EXPECT().FindByID(ctx, org.ID()).Return(org, nil).AnyTimes()
+			m.invitationRepo.EXPECT().FindByOrganization(ctx, org.ID()).Return(nil, nil).AnyTimes()
+
+			result, err := m.inviteUseCase().Execute(ctx, claims, tt.req)
+
+			assert.Nil(t, result)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestInviteMemberUseCase_TooManyInvitations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newTenancyMocks(ctrl)
+	org, members := newTestOrganization()
+	claims := m.expectActiveMembership(ctx, members[0])
+
+	pending := make([]*organization.Invitation, 0, organization.MaxPendingInvitations)
+	for len(pending) < organization.MaxPendingInvitations {
+		invitation, _, err := organization.NewInvitation(members[0], mustEmail(t, fmt.Sprintf("user%d@example.com", len(pending))),
+			organization.RoleMember, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		pending = append(pending, invitation)
+	}
+	m.orgRepo.EXPECT().FindByID(ctx, org.ID()).Return(org, nil)
+	m.invitationRepo.EXPECT().FindByOrganization(ctx, org.ID()).Return(pending, nil)
+
+	result, err := m.inviteUseCase().Execute(ctx, claims, dto.InviteMemberRequest{Email: "new@example.com", Role: "member"})
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrTooManyInvitations, err)
+}
+
+func TestRevokeInvitationUseCase_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newTenancyMocks(ctrl)
+	_, members := newTestOrganization()
+	claims := m.expectActiveMembership(ctx, members[1])
+	invitation, _, err := organization.NewInvitation(members[0], mustEmail(t, "new@example.com"), organization.RoleMember, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	invitation.ClearDomainEvents()
+
+	m.invitationRepo.EXPECT().FindByID(ctx, members[1].OrganizationID(), invitation.ID()).Return(invitation, nil)
+	m.invitationRepo.EXPECT().Delete(ctx, invitation.ID()).Return(nil)
+	m.eventPublisher.EXPECT().Publish(ctx, gomock.Any()).Return(nil)
+
+	uc := NewRevokeInvitationUseCase(m.membershipRepo, m.invitationRepo, m.eventPublisher)
+	require.NoError(t, uc.Execute(ctx, claims, invitation.ID().Value()))
+}
+
+func TestAcceptInvitationUseCase_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newTenancyMocks(ctrl)
+	org, members := newTestOrganization()
+	invitee := newTestUser(t, "user-9", "new@example.com")
+	invitation, plain, err := organization.NewInvitation(members[0], mustEmail(t, "new@example.com"), organization.RoleAdmin, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	var joined *organization.Membership
+	m.userRepo.EXPECT().FindByID(ctx, invitee.ID()).Return(invitee, nil)
+	m.invitationRepo.EXPECT().FindByHash(ctx, organization.HashInvitationToken(plain)).Return(invitation, nil)
+	m.membershipRepo.EXPECT().Find(ctx, org.ID(), invitee.ID()).Return(nil, shared.ErrNotOrganizationMember)
+	m.orgRepo.EXPECT().FindByID(ctx, org.ID()).Return(org, nil)
+	m.membershipRepo.EXPECT().Save(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, membership *organization.Membership) error {
+		joined = membership
+		return nil
+	})
+	m.invitationRepo.EXPECT().Delete(ctx, invitation.ID()).Return(nil)
+	m.eventPublisher.EXPECT().Publish(ctx, gomock.Any()).Return(nil)
+
+	result, err := m.acceptUseCase().Execute(ctx, &ports.TokenClaims{UserID: "user-9"}, dto.AcceptInvitationRequest{Token: plain})
+
+	require.NoError(t, err)
+	assert.Equal(t, "org-1", result.ID)
+	assert.Equal(t, "admin", result.Role)
+	require.NotNil(t, joined)
+	assert.True(t, joined.UserID().Equals(invitee.ID()))
+}
+
+func TestAcceptInvitationUseCase_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		member  bool
+		wantErr error
+	}{
+		{"another user's invitation", "other@example.com", false, shared.ErrInvitationEmailMismatch},
+		{"already a member", "new@example.com", true, shared.ErrAlreadyOrganizationMember},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			m := newTenancyMocks(ctrl)
+			_, members := newTestOrganization()
+			invitee := newTestUser(t, "user-9", tt.address)
+			invitation, plain, err := organization.NewInvitation(members[0], mustEmail(t, "new@example.com"), organization.RoleMember, time.Now().Add(time.Hour))
+			require.NoError(t, err)
+
+			m.userRepo.EXPECT().FindByID(ctx, invitee.ID()).Return(invitee, nil)
+			m.invitationRepo.EXPECT().FindByHash(ctx, gomock.Any()).Return(invitation, nil)
+			if tt.member {
+				m.membershipRepo.EXPECT().Find(ctx, invitation.OrganizationID(), invitee.ID()).Return(members[2], nil)
+			} else {
+				m.membershipRepo.EXPECT().Find(ctx, invitation.OrganizationID(), invitee.ID()).Return(nil, shared.ErrNotOrganizationMember)
+			}
+
+			result, err := m.acceptUseCase().Execute(ctx, &ports.TokenClaims{UserID: "user-9"}, dto.AcceptInvitationRequest{Token: plain})
+
+			assert.Nil(t, result)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package tenancy
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/events"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/organization"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// ChangeMemberRoleUseCase gives a member of the active organization another role
+type ChangeMemberRoleUseCase struct {
+	membershipRepo organization.MembershipRepository
+	eventPublisher ports.EventPublisher
+}
+
+// NewChangeMemberRoleUseCase creates a new ChangeMemberRoleUseCase
+func NewChangeMemberRoleUseCase(membershipRepo organization.MembershipRepository, eventPublisher ports.EventPublisher) *ChangeMemberRoleUseCase {
+	return &ChangeMemberRoleUseCase{
+		membershipRepo: membershipRepo,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// Execute changes the role of the member with rawUserID. The organization
+// always keeps at least one owner.
+func (uc *ChangeMemberRoleUseCase) Execute(ctx context.Context, claims *ports.TokenClaims, rawUserID string, req dto.ChangeMemberRoleRequest) error {
+	actor, err := activeMembership(ctx, uc.membershipRepo, claims)
+	if err != nil {
+		return err
+	}
+
+	role, err := organization.ParseRole(req.Role)
+	if err != nil {
+		return err
+	}
+	member, owners, err := findMember(ctx, uc.membershipRepo, actor, rawUserID)
+	if err != nil {
+		return err
+	}
+
+	if err := member.ChangeRole(actor, role, owners); err != nil {
+		return err
+	}
+	if err := uc.membershipRepo.Save(ctx, member); err != nil {
+		return fmt.Errorf("failed to save membership: %w", err)
+	}
+	events.Publish(ctx, uc.eventPublisher, member)
+
+	log.Printf("User %s is now %s of organization %s", member.UserID().Value(), member.Role(), member.OrganizationID().Value())
+	return nil
+}
+
+// RemoveMemberUseCase removes a member from the active organization, or lets
+// a member leave it
+type RemoveMemberUseCase struct {
+	userRepo       user.Repository
+	membershipRepo organization.MembershipRepository
+	eventPublisher ports.EventPublisher
+}
+
+// NewRemoveMemberUseCase creates a new RemoveMemberUseCase
+func NewRemoveMemberUseCase(
+	userRepo user.Repository,
+	membershipRepo organization.MembershipRepository,
+	eventPublisher ports.EventPublisher,
+) *RemoveMemberUseCase {
+	return &RemoveMemberUseCase{
+		userRepo:       userRepo,
+		membershipRepo: membershipRepo,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// Execute removes the member with rawUserID, who leaves when it is the
+// current user. The organization always keeps at least one owner. If it was
+// the removed user's active organization, their next login selects none.
+func (uc *RemoveMemberUseCase) Execute(ctx context.Context, claims *ports.TokenClaims, rawUserID string) error {
+	actor, err := activeMembership(ctx, uc.membershipRepo, claims)
+	if err != nil {
+		return err
+	}
+
+	member, owners, err := findMember(ctx, uc.membershipRepo, actor, rawUserID)
+	if err != nil {
+		return err
+	}
+
+	if err := member.Remove(actor, owners); err != nil {
+		return err
+	}
+	if err := uc.membershipRepo.Delete(ctx, member.OrganizationID(), member.UserID()); err != nil {
+		return fmt.Errorf("failed to delete membership: %w", err)
+	}
+	events.Publish(ctx, uc.eventPublisher, member)
+
+	removedUser, err := uc.userRepo.FindByID(ctx, member.UserID())
+	if err != nil && err != shared.ErrUserNotFound {
+		return fmt.Errorf("failed to retrieve user: %w", err)
+	}
+	if removedUser != nil && removedUser.ActiveOrganizationID() == member.OrganizationID().Value() {
+		removedUser.LeaveOrganization(member.OrganizationID().Value())
+		if err := uc.userRepo.Save(ctx, removedUser); err != nil {
+			return fmt.Errorf("failed to save user: %w", err)
+		}
+	}
+
+	log.Printf("User %s removed from organization %s by user %s",
+		member.UserID().Value(), member.OrganizationID().Value(), actor.UserID().Value())
+	return nil
+}
+
+// findMember loads the membership of rawUserID in actor's organization and
+// counts the organization's owners
+func findMember(
+	ctx context.Context,
+	membershipRepo organization.MembershipRepository,
+	actor *organization.Membership,
+	rawUserID string,
+) (*organization.Membership, int, error) {
+	userID, err := user.NewUserID(rawUserID)
+	if err != nil {
+		return nil, 0, shared.ErrNotOrganizationMember
+	}
+
+	members, err := membershipRepo.FindByOrganization(ctx, actor.OrganizationID())
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list members: %w", err)
+	}
+	for _, member := range members {
+		if member.UserID().Equals(userID) {
+			return member, organization.CountOwners(members), nil
+		}
+	}
+
+	return nil, 0, shared.ErrNotOrganizationMember
+}
@@ -0,0 +1,124 @@
+package tenancy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/domain/organization"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+func TestChangeMemberRoleUseCase_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newTenancyMocks(ctrl)
+	org, members := newTestOrganization()
+	claims := m.expectActiveMembership(ctx, members[1])
+
+	m.membershipRepo.EXPECT().FindByOrganization(ctx, org.ID()).Return(members, nil)
+	m.membershipRepo.EXPECT().Save(ctx, members[2]).Return(nil)
+	m.eventPublisher.EXPECT().Publish(ctx, gomock.Any()).Return(nil)
+
+	uc := NewChangeMemberRoleUseCase(m.membershipRepo, m.eventPublisher)
+	err := uc.Execute(ctx, claims, "member-1", dto.ChangeMemberRoleRequest{Role: "admin"})
+
+	require.NoError(t, err)
+	assert.Equal(t, organization.RoleAdmin, members[2].Role())
+}
+
+func TestChangeMemberRoleUseCase_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		actor   int
+		target  string
+		role    string
+		wantErr error
+	}{
+		{"admin may not demote owners", 1, "owner-1", "member", shared.ErrOrganizationPermissionDenied},
+		{"member may not change roles", 2, "admin-1", "member", shared.ErrOrganizationPermissionDenied},
+		{"last owner keeps the role", 0, "owner-1", "admin", shared.ErrLastOrganizationOwner},
+		{"not a member", 0, "stranger", "admin", shared.ErrNotOrganizationMember},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			m := newTenancyMocks(ctrl)
+			org, members := newTestOrganization()
+			claims := m.expectActiveMembership(ctx, members[tt.actor])
+			m.membershipRepo.EXPECT().FindByOrganization(ctx, org.ID()).Return(members, nil)
+
+			uc := NewChangeMemberRoleUseCase(m.membershipRepo, m.eventPublisher)
+			err := uc.Execute(ctx, claims, tt.target, dto.ChangeMemberRoleRequest{Role: tt.role})
+
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestRemoveMemberUseCase_UnselectsOrganization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newTenancyMocks(ctrl)
+	org, members := newTestOrganization()
+	claims := m.expectActiveMembership(ctx, members[0])
+	removed := newTestUser(t, "member-1", "member@example.com")
+	removed.SelectOrganization("org-1")
+
+	m.membershipRepo.EXPECT().FindByOrganization(ctx, org.ID()).Return(members, nil)
+	m.membershipRepo.EXPECT().Delete(ctx, org.ID(), members[2].UserID()).Return(nil)
+	m.eventPublisher.EXPECT().Publish(ctx, gomock.Any()).Return(nil)
+	m.userRepo.EXPECT().FindByID(ctx, removed.ID()).Return(removed, nil)
+	m.userRepo.EXPECT().Save(ctx, removed).Return(nil)
+
+	uc := NewRemoveMemberUseCase(m.userRepo, m.membershipRepo, m.eventPublisher)
+	require.NoError(t, uc.Execute(ctx, claims, "member-1"))
+	assert.Empty(t, removed.ActiveOrganizationID())
+}
+
+func TestRemoveMemberUseCase_Leave(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newTenancyMocks(ctrl)
+	org, members := newTestOrganization()
+	claims := m.expectActiveMembership(ctx, members[2])
+	userID, _ := user.NewUserID("member-1")
+
+	m.membershipRepo.EXPECT().FindByOrganization(ctx, org.ID()).Return(members, nil)
+	m.membershipRepo.EXPECT().Delete(ctx, org.ID(), userID).Return(nil)
+	m.eventPublisher.EXPECT().Publish(ctx, gomock.Any()).Return(nil)
+	// A user who selected another organization keeps that selection
+	m.userRepo.EXPECT().FindByID(ctx, userID).Return(newTestUser(t, "member-1", "member@example.com"), nil)
+
+	uc := NewRemoveMemberUseCase(m.userRepo, m.membershipRepo, m.eventPublisher)
+	require.NoError(t, uc.Execute(ctx, claims, "member-1"))
+}
+
+func TestRemoveMemberUseCase_LastOwnerCannotLeave(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newTenancyMocks(ctrl)
+	org, members := newTestOrganization()
+	claims := m.expectActiveMembership(ctx, members[0])
+	m.membershipRepo.EXPECT().FindByOrganization(ctx, org.ID()).Return(members, nil)
+
+	uc := NewRemoveMemberUseCase(m.userRepo, m.membershipRepo, m.eventPublisher)
+	assert.Equal(t, shared.ErrLastOrganizationOwner, uc.Execute(ctx, claims, "owner-1"))
+}
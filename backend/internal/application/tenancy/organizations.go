@@ -0,0 +1,245 @@
+package tenancy
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/events"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/organization"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// CreateOrganizationUseCase creates an organization with the current user as
+// its first owner
+type CreateOrganizationUseCase struct {
+	userRepo       user.Repository
+	orgRepo        organization.Repository
+	membershipRepo organization.MembershipRepository
+	eventPublisher ports.EventPublisher
+}
+
+// NewCreateOrganizationUseCase creates a new CreateOrganizationUseCase
+func NewCreateOrganizationUseCase(
+	userRepo user.Repository,
+	orgRepo organization.Repository,
+	membershipRepo organization.MembershipRepository,
+	eventPublisher ports.EventPublisher,
+) *CreateOrganizationUseCase {
+	return &CreateOrganizationUseCase{
+		userRepo:       userRepo,
+		orgRepo:        orgRepo,
+		membershipRepo: membershipRepo,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// Execute creates the organization. It does not become the active one until
+// the user selects it.
+func (uc *CreateOrganizationUseCase) Execute(ctx context.Context, claims *ports.TokenClaims, req dto.CreateOrganizationRequest) (*dto.OrganizationResponse, error) {
+	domainUser, err := findUser(ctx, uc.userRepo, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := organization.NewOrganization(req.Name, domainUser.ID())
+	if err != nil {
+		return nil, err
+	}
+	owner, err := organization.NewMembership(org.ID(), domainUser.ID(), organization.RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.orgRepo.Save(ctx, org); err != nil {
+		return nil, fmt.Errorf("failed to save organization: %w", err)
+	}
+	if err := uc.membershipRepo.Save(ctx, owner); err != nil {
+		return nil, fmt.Errorf("failed to save membership: %w", err)
+	}
+	events.Publish(ctx, uc.eventPublisher, org, owner)
+
+	log.Printf("Organization %s created by user %s", org.ID().Value(), domainUser.ID().Value())
+	response := organizationResponse(org, owner, claims)
+	return &response, nil
+}
+
+// ListOrganizationsUseCase lists the organizations the current user belongs to
+type ListOrganizationsUseCase struct {
+	orgRepo        organization.Repository
+	membershipRepo organization.MembershipRepository
+}
+
+// NewListOrganizationsUseCase creates a new ListOrganizationsUseCase
+func NewListOrganizationsUseCase(orgRepo organization.Repository, membershipRepo organization.MembershipRepository) *ListOrganizationsUseCase {
+	return &ListOrganizationsUseCase{
+		orgRepo:        orgRepo,
+		membershipRepo: membershipRepo,
+	}
+}
+
+// Execute returns the user's organizations with their role in each, marking
+// the one their token acts in as active
+func (uc *ListOrganizationsUseCase) Execute(ctx context.Context, claims *ports.TokenClaims) (*dto.OrganizationListResponse, error) {
+	userID, err := user.NewUserID(claims.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID in token: %w", err)
+	}
+
+	memberships, err := uc.membershipRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list memberships: %w", err)
+	}
+
+	response := &dto.OrganizationListResponse{Organizations: make([]dto.OrganizationResponse, 0, len(memberships))}
+	for _, membership := range memberships {
+		org, err := uc.orgRepo.FindByID(ctx, membership.OrganizationID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve organization: %w", err)
+		}
+		response.Organizations = append(response.Organizations, organizationResponse(org, membership, claims))
+	}
+
+	return response, nil
+}
+
+// GetActiveOrganizationUseCase describes the organization the current user's
+// token acts in
+type GetActiveOrganizationUseCase struct {
+	userRepo       user.Repository
+	orgRepo        organization.Repository
+	membershipRepo organization.MembershipRepository
+	invitationRepo organization.InvitationRepository
+}
+
+// NewGetActiveOrganizationUseCase creates a new GetActiveOrganizationUseCase
+func NewGetActiveOrganizationUseCase(
+	userRepo user.Repository,
+	orgRepo organization.Repository,
+	membershipRepo organization.MembershipRepository,
+	invitationRepo organization.InvitationRepository,
+) *GetActiveOrganizationUseCase {
+	return &GetActiveOrganizationUseCase{
+		userRepo:       userRepo,
+		orgRepo:        orgRepo,
+		membershipRepo: membershipRepo,
+		invitationRepo: invitationRepo,
+	}
+}
+
+// Execute returns the active organization with its members, and its pending
+// invitations when the user may manage members
+func (uc *GetActiveOrganizationUseCase) Execute(ctx context.Context, claims *ports.TokenClaims) (*dto.ActiveOrganizationResponse, error) {
+	membership, err := activeMembership(ctx, uc.membershipRepo, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := uc.orgRepo.FindByID(ctx, membership.OrganizationID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve organization: %w", err)
+	}
+
+	members, err := uc.membershipRepo.FindByOrganization(ctx, org.ID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members: %w", err)
+	}
+
+	response := &dto.ActiveOrganizationResponse{
+		Organization: organizationResponse(org, membership, claims),
+		Members:      make([]dto.MemberResponse, 0, len(members)),
+	}
+	for _, member := range members {
+		memberUser, err := uc.userRepo.FindByID(ctx, member.UserID())
+		if err == shared.ErrUserNotFound {
+			continue // purged accounts lose their memberships shortly after
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve member: %w", err)
+		}
+		response.Members = append(response.Members, dto.MemberResponse{
+			UserID:   member.UserID().Value(),
+			Email:    memberUser.Email().Value(),
+			Name:     memberUser.Profile().Name(),
+			Picture:  memberUser.Profile().Picture(),
+			Role:     member.Role().String(),
+			JoinedAt: member.JoinedAt(),
+		})
+	}
+
+	if membership.Role().CanManageMembers() {
+		invitations, err := uc.invitationRepo.FindByOrganization(ctx, org.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to list invitations: %w", err)
+		}
+		now := time.Now()
+		for _, invitation := range invitations {
+			if invitation.IsPending(now) {
+				response.Invitations = append(response.Invitations, dto.NewInvitationResponse(invitation))
+			}
+		}
+	}
+
+	return response, nil
+}
+
+// findUser loads the user a token was issued to
+func findUser(ctx context.Context, userRepo user.Repository, rawUserID string) (*user.User, error) {
+	userID, err := user.NewUserID(rawUserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID in token: %w", err)
+	}
+
+	domainUser, err := userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if err == shared.ErrUserNotFound {
+			return nil, shared.ErrUnauthorized
+		}
+		return nil, fmt.Errorf("failed to retrieve user: %w", err)
+	}
+
+	return domainUser, nil
+}
+
+// activeMembership loads the current user's membership of the organization
+// their token acts in. The org_id claim only selects the organization, so
+// membership is checked on every request: a removed member's tokens lose
+// access straight away.
+func activeMembership(ctx context.Context, membershipRepo organization.MembershipRepository, claims *ports.TokenClaims) (*organization.Membership, error) {
+	if claims.OrganizationID == "" {
+		return nil, shared.ErrNoActiveOrganization
+	}
+	orgID, err := organization.NewOrganizationID(claims.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := user.NewUserID(claims.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID in token: %w", err)
+	}
+
+	membership, err := membershipRepo.Find(ctx, orgID, userID)
+	if err != nil {
+		if err == shared.ErrNotOrganizationMember {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to retrieve membership: %w", err)
+	}
+
+	return membership, nil
+}
+
+// organizationResponse describes org as seen by the holder of membership
+func organizationResponse(org *organization.Organization, membership *organization.Membership, claims *ports.TokenClaims) dto.OrganizationResponse {
+	return dto.OrganizationResponse{
+		ID:        org.ID().Value(),
+		Name:      org.Name(),
+		Role:      membership.Role().String(),
+		Active:    claims.OrganizationID == org.ID().Value(),
+		CreatedAt: org.CreatedAt(),
+	}
+}
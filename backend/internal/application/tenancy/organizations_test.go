@@ -0,0 +1,161 @@
+package tenancy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/organization"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+func TestCreateOrganizationUseCase_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newTenancyMocks(ctrl)
+	domainUser := newTestUser(t, "user-1", "owner@example.com")
+
+	var owner *organization.Membership
+	m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+	m.orgRepo.EXPECT().Save(ctx, gomock.Any()).Return(nil)
+	m.membershipRepo.EXPECT().Save(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, membership *organization.Membership) error {
+		owner = membership
+		return nil
+	})
+	m.eventPublisher.EXPECT().Publish(ctx, gomock.Any()).Return(nil).Times(2)
+
+	uc := NewCreateOrganizationUseCase(m.userRepo, m.orgRepo, m.membershipRepo, m.eventPublisher)
+	result, err := uc.Execute(ctx, &ports.TokenClaims{UserID: "user-1"}, dto.CreateOrganizationRequest{Name: "  Acme  "})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Acme", result.Name)
+	assert.Equal(t, "owner", result.Role)
+	assert.False(t, result.Active)
+	require.NotNil(t, owner)
+	assert.Equal(t, result.ID, owner.OrganizationID().Value())
+	assert.Equal(t, organization.RoleOwner, owner.Role())
+}
+
+func TestCreateOrganizationUseCase_InvalidName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newTenancyMocks(ctrl)
+	domainUser := newTestUser(t, "user-1", "owner@example.com")
+	m.userRepo.EXPECT().FindByID(ctx, domainUser.ID()).Return(domainUser, nil)
+
+	uc := NewCreateOrganizationUseCase(m.userRepo, m.orgRepo, m.membershipRepo, m.eventPublisher)
+	result, err := uc.Execute(ctx, &ports.TokenClaims{UserID: "user-1"}, dto.CreateOrganizationRequest{Name: " "})
+
+	assert.Nil(t, result)
+	assert.Equal(t, shared.ErrInvalidOrganizationName, err)
+}
+
+func TestListOrganizationsUseCase_MarksActiveOrganization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newTenancyMocks(ctrl)
+	org, members := newTestOrganization()
+	admin := members[1]
+	claims := &ports.TokenClaims{UserID: "admin-1", OrganizationID: "org-1"}
+
+	m.membershipRepo.EXPECT().FindByUserID(ctx, admin.UserID()).Return([]*organization.Membership{admin}, nil)
+	m.orgRepo.EXPECT().FindByID(ctx, org.ID()).Return(org, nil)
+
+	uc := NewListOrganizationsUseCase(m.orgRepo, m.membershipRepo)
+	result, err := uc.Execute(ctx, claims)
+
+	require.NoError(t, err)
+	require.Len(t, result.Organizations, 1)
+	assert.Equal(t, dto.OrganizationResponse{
+		ID:        "org-1",
+		Name:      "Acme",
+		Role:      "admin",
+		Active:    true,
+		CreatedAt: org.CreatedAt(),
+	}, result.Organizations[0])
+}
+
+func TestGetActiveOrganizationUseCase(t *testing.T) {
+	tests := []struct {
+		name            string
+		member          int
+		wantInvitations int
+	}{
+		{"owner sees invitations", 0, 1},
+		{"member does not", 2, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			m := newTenancyMocks(ctrl)
+			org, members := newTestOrganization()
+			claims := m.expectActiveMembership(ctx, members[tt.member])
+
+			m.orgRepo.EXPECT().FindByID(ctx, org.ID()).Return(org, nil)
+			m.membershipRepo.EXPECT().FindByOrganization(ctx, org.ID()).Return(members, nil)
+			m.userRepo.EXPECT().FindByID(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, id user.UserID) (*user.User, error) {
+				if id.Value() == "admin-1" {
+					return nil, shared.ErrUserNotFound
+				}
+				return newTestUser(t, id.Value(), id.Value()+"@example.com"), nil
+			}).Times(3)
+			if tt.wantInvitations > 0 {
+				pending, _, _ := organization.NewInvitation(members[0], mustEmail(t, "new@example.com"), organization.RoleMember, time.Now().Add(time.Hour))
+				expired, _, _ := organization.NewInvitation(members[0], mustEmail(t, "old@example.com"), organization.RoleMember, time.Now().Add(-time.Hour))
+				m.invitationRepo.EXPECT().FindByOrganization(ctx, org.ID()).Return([]*organization.Invitation{pending, expired}, nil)
+			}
+
+			uc := NewGetActiveOrganizationUseCase(m.userRepo, m.orgRepo, m.membershipRepo, m.invitationRepo)
+			result, err := uc.Execute(ctx, claims)
+
+			require.NoError(t, err)
+			assert.True(t, result.Organization.Active)
+			// Members whose accounts were purged are left out
+			require.Len(t, result.Members, 2)
+			assert.Equal(t, "owner-1@example.com", result.Members[0].Email)
+			assert.Equal(t, "member", result.Members[1].Role)
+			assert.Len(t, result.Invitations, tt.wantInvitations)
+		})
+	}
+}
+
+func TestActiveMembership(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m := newTenancyMocks(ctrl)
+
+	_, err := activeMembership(ctx, m.membershipRepo, &ports.TokenClaims{UserID: "user-1"})
+	assert.Equal(t, shared.ErrNoActiveOrganization, err)
+
+	// The claim only selects the organization; membership is checked each time
+	m.membershipRepo.EXPECT().Find(ctx, gomock.Any(), gomock.Any()).Return(nil, shared.ErrNotOrganizationMember)
+	_, err = activeMembership(ctx, m.membershipRepo, &ports.TokenClaims{UserID: "user-1", OrganizationID: "org-1"})
+	assert.Equal(t, shared.ErrNotOrganizationMember, err)
+}
+
+func mustEmail(t *testing.T, address string) user.Email {
+	t.Helper()
+
+	email, err := user.NewEmail(address, false)
+	require.NoError(t, err)
+	return email
+}
@@ -0,0 +1,80 @@
+package tenancy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/organization"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+	"github.com/yuki5155/go-google-auth/internal/mocks"
+)
+
+// tenancyMocks holds a mock of every dependency of the tenancy use cases
+type tenancyMocks struct {
+	userRepo       *mocks.MockRepository
+	orgRepo        *mocks.MockOrganizationRepository
+	membershipRepo *mocks.MockMembershipRepository
+	invitationRepo *mocks.MockInvitationRepository
+	mailer         *mocks.MockMailer
+	eventPublisher *mocks.MockEventPublisher
+}
+
+func newTenancyMocks(ctrl *gomock.Controller) *tenancyMocks {
+	return &tenancyMocks{
+		userRepo:       mocks.NewMockRepository(ctrl),
+		orgRepo:        mocks.NewMockOrganizationRepository(ctrl),
+		membershipRepo: mocks.NewMockMembershipRepository(ctrl),
+		invitationRepo: mocks.NewMockInvitationRepository(ctrl),
+		mailer:         mocks.NewMockMailer(ctrl),
+		eventPublisher: mocks.NewMockEventPublisher(ctrl),
+	}
+}
+
+// newTestUser returns a user with a verified email
+func newTestUser(t *testing.T, rawID, address string) *user.User {
+	t.Helper()
+
+	userID, _ := user.NewUserID(rawID)
+	email, _ := user.NewEmail(address, true)
+	domainUser, err := user.NewUser(userID, email, user.NewProfile("Test User", ""))
+	require.NoError(t, err)
+	domainUser.ClearDomainEvents()
+	return domainUser
+}
+
+// newTestOrganization returns org-1 with a member of each role:
+// owner-1, admin-1 and member-1
+func newTestOrganization() (*organization.Organization, []*organization.Membership) {
+	orgID, _ := organization.NewOrganizationID("org-1")
+	joinedAt := time.Now().Add(-time.Hour)
+	roles := []struct {
+		userID string
+		role   organization.Role
+	}{
+		{"owner-1", organization.RoleOwner},
+		{"admin-1", organization.RoleAdmin},
+		{"member-1", organization.RoleMember},
+	}
+	members := make([]*organization.Membership, 0, len(roles))
+	for _, r := range roles {
+		userID, _ := user.NewUserID(r.userID)
+		members = append(members, organization.ReconstructMembership(orgID, userID, r.role, joinedAt))
+		joinedAt = joinedAt.Add(time.Minute)
+	}
+	creator, _ := user.NewUserID("owner-1")
+	return organization.ReconstructOrganization(orgID, "Acme", creator, joinedAt), members
+}
+
+// expectActiveMembership expects the lookup of membership for a token that acts in its organization
+func (m *tenancyMocks) expectActiveMembership(ctx context.Context, membership *organization.Membership) *ports.TokenClaims {
+	m.membershipRepo.EXPECT().Find(ctx, membership.OrganizationID(), membership.UserID()).Return(membership, nil)
+	return &ports.TokenClaims{
+		UserID:         membership.UserID().Value(),
+		OrganizationID: membership.OrganizationID().Value(),
+	}
+}
@@ -0,0 +1,47 @@
+package apitoken
+
+import "github.com/yuki5155/go-google-auth/internal/domain/shared"
+
+// Event type constants
+const (
+	EventTypeTokenCreated = "api_token.created"
+	EventTypeTokenRevoked = "api_token.revoked"
+)
+
+// TokenCreatedEvent is emitted when a user creates a personal access token
+type TokenCreatedEvent struct {
+	shared.BaseDomainEvent
+	UserID  string
+	TokenID string
+	Name    string
+	Scopes  []string
+}
+
+// NewTokenCreatedEvent creates a new TokenCreatedEvent
+func NewTokenCreatedEvent(userID, tokenID, name string, scopes []string) TokenCreatedEvent {
+	return TokenCreatedEvent{
+		BaseDomainEvent: shared.NewBaseDomainEvent(EventTypeTokenCreated, userID),
+		UserID:          userID,
+		TokenID:         tokenID,
+		Name:            name,
+		Scopes:          scopes,
+	}
+}
+
+// TokenRevokedEvent is emitted when a personal access token is revoked
+type TokenRevokedEvent struct {
+	shared.BaseDomainEvent
+	UserID  string
+	TokenID string
+	Name    string
+}
+
+// NewTokenRevokedEvent creates a new TokenRevokedEvent
+func NewTokenRevokedEvent(userID, tokenID, name string) TokenRevokedEvent {
+	return TokenRevokedEvent{
+		BaseDomainEvent: shared.NewBaseDomainEvent(EventTypeTokenRevoked, userID),
+		UserID:          userID,
+		TokenID:         tokenID,
+		Name:            name,
+	}
+}
@@ -0,0 +1,25 @@
+package apitoken
+
+import (
+	"context"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// Repository defines the interface for personal access token persistence
+type Repository interface {
+	// Save persists a token
+	Save(ctx context.Context, token *Token) error
+
+	// FindByID retrieves a token by its ID, or shared.ErrAPITokenNotFound
+	FindByID(ctx context.Context, id TokenID) (*Token, error)
+
+	// FindByHash retrieves a token by the hash of its plain value, or shared.ErrAPITokenNotFound
+	FindByHash(ctx context.Context, hash string) (*Token, error)
+
+	// FindByUserID retrieves all tokens of a user, newest first
+	FindByUserID(ctx context.Context, userID user.UserID) ([]*Token, error)
+
+	// DeleteByUserID removes all tokens of a user
+	DeleteByUserID(ctx context.Context, userID user.UserID) error
+}
@@ -0,0 +1,217 @@
+package apitoken
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+const (
+	// Prefix starts every personal access token, so that they are easy to tell
+	// apart from JWTs and to find with secret scanners
+	Prefix = "pat_"
+
+	// MaxTokensPerUser caps how many unrevoked tokens a user may hold
+	MaxTokensPerUser = 25
+
+	// maxNameLength is the longest allowed token name, in characters
+	maxNameLength = 64
+
+	// secretBytes is the entropy of a token
+	secretBytes = 32
+)
+
+// Token is a long-lived personal access token that scripts use instead of
+// browser cookies. Only a SHA-256 hash of the secret is kept; the plain token
+// is shown to the user once, when it is created.
+type Token struct {
+	id         TokenID
+	userID     user.UserID
+	name       string
+	scopes     []string
+	hash       string
+	createdAt  time.Time
+	expiresAt  time.Time
+	lastUsedAt time.Time
+	revokedAt  time.Time
+	events     []shared.DomainEvent
+}
+
+// NewToken creates a token with the given scopes that expires at expiresAt,
+// returning it together with the plain token to show to the user
+func NewToken(userID user.UserID, name string, scopes []string, expiresAt time.Time) (*Token, string, error) {
+	if userID.IsEmpty() {
+		return nil, "", shared.ErrEmptyUserID
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxNameLength {
+		return nil, "", shared.ErrInvalidAPITokenName
+	}
+	if len(scopes) == 0 {
+		return nil, "", shared.ErrInvalidAPITokenScope
+	}
+
+	now := time.Now()
+	if !expiresAt.After(now) {
+		return nil, "", shared.ErrInvalidAPITokenExpiry
+	}
+
+	id, err := GenerateTokenID()
+	if err != nil {
+		return nil, "", err
+	}
+
+	bytes := make([]byte, secretBytes)
+	if _, err := rand.Read(bytes); err != nil {
+		return nil, "", err
+	}
+	plain := Prefix + base64.RawURLEncoding.EncodeToString(bytes)
+
+	token := &Token{
+		id:        id,
+		userID:    userID,
+		name:      name,
+		scopes:    normalizeScopes(scopes),
+		hash:      Hash(plain),
+		createdAt: now,
+		expiresAt: expiresAt,
+		events:    make([]shared.DomainEvent, 0),
+	}
+	token.addEvent(NewTokenCreatedEvent(userID.Value(), id.Value(), name, token.Scopes()))
+
+	return token, plain, nil
+}
+
+// ReconstructToken reconstructs a Token from persistence (without domain events)
+func ReconstructToken(
+	id TokenID,
+	userID user.UserID,
+	name string,
+	scopes []string,
+	hash string,
+	createdAt, expiresAt, lastUsedAt, revokedAt time.Time,
+) *Token {
+	return &Token{
+		id:         id,
+		userID:     userID,
+		name:       name,
+		scopes:     slices.Clone(scopes),
+		hash:       hash,
+		createdAt:  createdAt,
+		expiresAt:  expiresAt,
+		lastUsedAt: lastUsedAt,
+		revokedAt:  revokedAt,
+		events:     make([]shared.DomainEvent, 0),
+	}
+}
+
+// Hash returns the hex SHA-256 hash under which a plain token is stored
+func Hash(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
+
+// LooksLikeToken reports whether value has the personal access token format
+func LooksLikeToken(value string) bool {
+	return strings.HasPrefix(value, Prefix)
+}
+
+// ID returns the token's ID
+func (t *Token) ID() TokenID {
+	return t.id
+}
+
+// UserID returns the ID of the user the token acts for
+func (t *Token) UserID() user.UserID {
+	return t.userID
+}
+
+// Name returns the user's label for the token
+func (t *Token) Name() string {
+	return t.name
+}
+
+// Scopes returns a copy of the scopes the token grants
+func (t *Token) Scopes() []string {
+	return slices.Clone(t.scopes)
+}
+
+// Hash returns the SHA-256 hash of the plain token
+func (t *Token) Hash() string {
+	return t.hash
+}
+
+// CreatedAt returns when the token was created
+func (t *Token) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+// ExpiresAt returns when the token expires
+func (t *Token) ExpiresAt() time.Time {
+	return t.expiresAt
+}
+
+// LastUsedAt returns when the token was last used (zero if never)
+func (t *Token) LastUsedAt() time.Time {
+	return t.lastUsedAt
+}
+
+// RevokedAt returns when the token was revoked (zero if not revoked)
+func (t *Token) RevokedAt() time.Time {
+	return t.revokedAt
+}
+
+// IsRevoked returns true if the token has been revoked
+func (t *Token) IsRevoked() bool {
+	return !t.revokedAt.IsZero()
+}
+
+// IsActive returns true if the token is neither revoked nor expired at the given time
+func (t *Token) IsActive(now time.Time) bool {
+	return !t.IsRevoked() && now.Before(t.expiresAt)
+}
+
+// Touch records that the token was used at the given time
+func (t *Token) Touch(at time.Time) {
+	t.lastUsedAt = at
+}
+
+// Revoke disables the token; revoking an already revoked token is a no-op
+func (t *Token) Revoke(at time.Time) {
+	if t.IsRevoked() {
+		return
+	}
+	t.revokedAt = at
+	t.addEvent(NewTokenRevokedEvent(t.userID.Value(), t.id.Value(), t.name))
+}
+
+// DomainEvents returns all domain events
+func (t *Token) DomainEvents() []shared.DomainEvent {
+	return t.events
+}
+
+// ClearDomainEvents clears all domain events
+func (t *Token) ClearDomainEvents() {
+	t.events = make([]shared.DomainEvent, 0)
+}
+
+// addEvent adds a domain event
+func (t *Token) addEvent(event shared.DomainEvent) {
+	t.events = append(t.events, event)
+}
+
+// normalizeScopes sorts scopes and drops duplicates
+func normalizeScopes(scopes []string) []string {
+	normalized := slices.Clone(scopes)
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
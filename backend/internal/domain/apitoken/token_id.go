@@ -0,0 +1,54 @@
+package apitoken
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+// TokenID identifies a personal access token; unlike the secret, it is not sensitive
+type TokenID struct {
+	value string
+}
+
+// NewTokenID creates a TokenID from an existing value with validation
+func NewTokenID(id string) (TokenID, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return TokenID{}, shared.ErrAPITokenNotFound
+	}
+
+	return TokenID{value: id}, nil
+}
+
+// GenerateTokenID creates a new random TokenID
+func GenerateTokenID() (TokenID, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return TokenID{}, err
+	}
+
+	return TokenID{value: hex.EncodeToString(bytes)}, nil
+}
+
+// Value returns the string value of the TokenID
+func (t TokenID) Value() string {
+	return t.value
+}
+
+// String implements the Stringer interface
+func (t TokenID) String() string {
+	return t.value
+}
+
+// Equals compares two TokenIDs for equality
+func (t TokenID) Equals(other TokenID) bool {
+	return t.value == other.value
+}
+
+// IsEmpty returns true if the TokenID is empty
+func (t TokenID) IsEmpty() bool {
+	return t.value == ""
+}
@@ -0,0 +1,119 @@
+package apitoken
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+func TestNewToken_Success(t *testing.T) {
+	userID, _ := user.NewUserID("user-123")
+	expiresAt := time.Now().Add(24 * time.Hour)
+
+	token, plain, err := NewToken(userID, "  deploy script ", []string{"profile:read", "account:export", "profile:read"}, expiresAt)
+
+	require.NoError(t, err)
+	assert.False(t, token.ID().IsEmpty())
+	assert.Equal(t, "deploy script", token.Name())
+	assert.Equal(t, []string{"account:export", "profile:read"}, token.Scopes())
+	assert.Equal(t, expiresAt, token.ExpiresAt())
+	assert.True(t, token.LastUsedAt().IsZero())
+	assert.True(t, token.IsActive(time.Now()))
+
+	// Only the hash of the plain token is kept
+	assert.True(t, strings.HasPrefix(plain, Prefix))
+	assert.True(t, LooksLikeToken(plain))
+	assert.Equal(t, Hash(plain), token.Hash())
+	assert.NotContains(t, token.Hash(), strings.TrimPrefix(plain, Prefix))
+
+	require.Len(t, token.DomainEvents(), 1)
+	assert.Equal(t, EventTypeTokenCreated, token.DomainEvents()[0].EventType())
+	assert.Equal(t, "user-123", token.DomainEvents()[0].AggregateID())
+}
+
+func TestNewToken_UniqueValues(t *testing.T) {
+	userID, _ := user.NewUserID("user-123")
+	expiresAt := time.Now().Add(time.Hour)
+
+	first, plain1, _ := NewToken(userID, "one", []string{"profile:read"}, expiresAt)
+	second, plain2, _ := NewToken(userID, "two", []string{"profile:read"}, expiresAt)
+
+	assert.NotEqual(t, first.ID(), second.ID())
+	assert.NotEqual(t, plain1, plain2)
+}
+
+func TestNewToken_Validation(t *testing.T) {
+	userID, _ := user.NewUserID("user-123")
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		userID    user.UserID
+		tokenName string
+		scopes    []string
+		expiresAt time.Time
+		wantErr   error
+	}{
+		{"empty user", user.UserID{}, "script", []string{"profile:read"}, future, shared.ErrEmptyUserID},
+		{"empty name", userID, "  ", []string{"profile:read"}, future, shared.ErrInvalidAPITokenName},
+		{"long name", userID, strings.Repeat("x", 65), []string{"profile:read"}, future, shared.ErrInvalidAPITokenName},
+		{"no scopes", userID, "script", nil, future, shared.ErrInvalidAPITokenScope},
+		{"expired", userID, "script", []string{"profile:read"}, time.Now().Add(-time.Minute), shared.ErrInvalidAPITokenExpiry},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, plain, err := NewToken(tt.userID, tt.tokenName, tt.scopes, tt.expiresAt)
+
+			assert.Equal(t, tt.wantErr, err)
+			assert.Nil(t, token)
+			assert.Empty(t, plain)
+		})
+	}
+}
+
+func TestToken_IsActive(t *testing.T) {
+	userID, _ := user.NewUserID("user-123")
+	now := time.Now()
+	token, _, err := NewToken(userID, "script", []string{"profile:read"}, now.Add(time.Hour))
+	require.NoError(t, err)
+
+	assert.True(t, token.IsActive(now))
+	assert.False(t, token.IsActive(now.Add(time.Hour)))
+
+	token.Revoke(now)
+
+	assert.True(t, token.IsRevoked())
+	assert.False(t, token.IsActive(now))
+}
+
+func TestToken_Revoke_Idempotent(t *testing.T) {
+	userID, _ := user.NewUserID("user-123")
+	token, _, err := NewToken(userID, "script", []string{"profile:read"}, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	token.ClearDomainEvents()
+
+	first := time.Now()
+	token.Revoke(first)
+	token.Revoke(first.Add(time.Minute))
+
+	assert.Equal(t, first, token.RevokedAt())
+	require.Len(t, token.DomainEvents(), 1)
+	assert.Equal(t, EventTypeTokenRevoked, token.DomainEvents()[0].EventType())
+}
+
+func TestToken_Touch(t *testing.T) {
+	userID, _ := user.NewUserID("user-123")
+	token, _, err := NewToken(userID, "script", []string{"profile:read"}, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	usedAt := time.Now().Add(time.Minute)
+	token.Touch(usedAt)
+
+	assert.Equal(t, usedAt, token.LastUsedAt())
+}
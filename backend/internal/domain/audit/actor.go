@@ -0,0 +1,18 @@
+package audit
+
+import "context"
+
+// actorKey is the context key of the administrator acting as a user
+type actorKey struct{}
+
+// ContextWithActor returns a context whose audit entries are attributed to
+// the administrator actorID, who is acting as the user
+func ContextWithActor(ctx context.Context, actorID string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actorID)
+}
+
+// ActorFromContext returns the administrator acting as the user, or ""
+func ActorFromContext(ctx context.Context) string {
+	actorID, _ := ctx.Value(actorKey{}).(string)
+	return actorID
+}
@@ -0,0 +1,48 @@
+package audit
+
+import "time"
+
+// Entry is a single record in a user's audit history
+type Entry struct {
+	userID     string
+	action     string
+	occurredAt time.Time
+	actorID    string
+}
+
+// NewEntry creates a new audit Entry
+func NewEntry(userID, action string, occurredAt time.Time) Entry {
+	return Entry{
+		userID:     userID,
+		action:     action,
+		occurredAt: occurredAt,
+	}
+}
+
+// UserID returns the ID of the user the entry is about
+func (e Entry) UserID() string {
+	return e.userID
+}
+
+// Action returns what happened, e.g. "user.logged_in"
+func (e Entry) Action() string {
+	return e.action
+}
+
+// OccurredAt returns when the action happened
+func (e Entry) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+// ActorID returns the administrator who acted as the user, or "" when the
+// user acted themselves
+func (e Entry) ActorID() string {
+	return e.actorID
+}
+
+// WithActor returns a copy of the entry attributed to the administrator
+// actorID
+func (e Entry) WithActor(actorID string) Entry {
+	e.actorID = actorID
+	return e
+}
@@ -0,0 +1,15 @@
+package audit
+
+import "context"
+
+// Repository defines the interface for audit log persistence
+type Repository interface {
+	// Append adds an entry to the audit log
+	Append(ctx context.Context, entry Entry) error
+
+	// FindByUserID retrieves a user's audit history, oldest first
+	FindByUserID(ctx context.Context, userID string) ([]Entry, error)
+
+	// DeleteByUserID removes a user's audit history
+	DeleteByUserID(ctx context.Context, userID string) error
+}
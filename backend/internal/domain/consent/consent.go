@@ -0,0 +1,114 @@
+package consent
+
+import (
+	"slices"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// Consent records the scopes a user has allowed a client to receive when
+// signing in with OpenID Connect, so that they are not asked again
+type Consent struct {
+	userID    user.UserID
+	clientID  string
+	scopes    []string
+	grantedAt time.Time
+	events    []shared.DomainEvent
+}
+
+// NewConsent records that a user allowed a client the given scopes
+func NewConsent(userID user.UserID, clientID string, scopes []string) (*Consent, error) {
+	if userID.IsEmpty() {
+		return nil, shared.ErrEmptyUserID
+	}
+	if clientID == "" {
+		return nil, shared.ErrInvalidServiceClient
+	}
+
+	consent := &Consent{
+		userID:   userID,
+		clientID: clientID,
+		events:   make([]shared.DomainEvent, 0),
+	}
+	consent.Grant(scopes)
+
+	return consent, nil
+}
+
+// ReconstructConsent reconstructs a Consent from persistence (without domain events)
+func ReconstructConsent(userID user.UserID, clientID string, scopes []string, grantedAt time.Time) *Consent {
+	return &Consent{
+		userID:    userID,
+		clientID:  clientID,
+		scopes:    slices.Clone(scopes),
+		grantedAt: grantedAt,
+		events:    make([]shared.DomainEvent, 0),
+	}
+}
+
+// UserID returns the ID of the user who consented
+func (c *Consent) UserID() user.UserID {
+	return c.userID
+}
+
+// ClientID returns the ID of the client the user consented to
+func (c *Consent) ClientID() string {
+	return c.clientID
+}
+
+// Scopes returns a copy of the scopes the user allowed
+func (c *Consent) Scopes() []string {
+	return slices.Clone(c.scopes)
+}
+
+// GrantedAt returns when the user last allowed new scopes
+func (c *Consent) GrantedAt() time.Time {
+	return c.grantedAt
+}
+
+// Covers reports whether the user has already allowed all of scopes
+func (c *Consent) Covers(scopes []string) bool {
+	for _, scope := range scopes {
+		if !slices.Contains(c.scopes, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// Grant adds scopes to those the user allowed; granting only scopes the user
+// already allowed changes nothing
+func (c *Consent) Grant(scopes []string) {
+	added := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !slices.Contains(c.scopes, scope) && !slices.Contains(added, scope) {
+			added = append(added, scope)
+		}
+	}
+	if len(added) == 0 {
+		return
+	}
+
+	merged := append(slices.Clone(c.scopes), added...)
+	slices.Sort(merged)
+	c.scopes = merged
+	c.grantedAt = time.Now()
+	c.addEvent(NewGrantedEvent(c.userID.Value(), c.clientID, added))
+}
+
+// DomainEvents returns all domain events
+func (c *Consent) DomainEvents() []shared.DomainEvent {
+	return c.events
+}
+
+// ClearDomainEvents clears all domain events
+func (c *Consent) ClearDomainEvents() {
+	c.events = make([]shared.DomainEvent, 0)
+}
+
+// addEvent adds a domain event
+func (c *Consent) addEvent(event shared.DomainEvent) {
+	c.events = append(c.events, event)
+}
@@ -0,0 +1,61 @@
+package consent
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+func TestNewConsent(t *testing.T) {
+	userID, _ := user.NewUserID("user-123")
+
+	consent, err := NewConsent(userID, "wiki", []string{"openid", "email", "openid"})
+
+	require.NoError(t, err)
+	assert.Equal(t, userID, consent.UserID())
+	assert.Equal(t, "wiki", consent.ClientID())
+	assert.Equal(t, []string{"email", "openid"}, consent.Scopes())
+	assert.False(t, consent.GrantedAt().IsZero())
+	require.Len(t, consent.DomainEvents(), 1)
+	assert.Equal(t, EventTypeConsentGranted, consent.DomainEvents()[0].EventType())
+}
+
+func TestNewConsent_Validation(t *testing.T) {
+	userID, _ := user.NewUserID("user-123")
+
+	_, err := NewConsent(user.UserID{}, "wiki", []string{"openid"})
+	assert.Equal(t, shared.ErrEmptyUserID, err)
+
+	_, err = NewConsent(userID, "", []string{"openid"})
+	assert.Equal(t, shared.ErrInvalidServiceClient, err)
+}
+
+func TestConsent_Covers(t *testing.T) {
+	userID, _ := user.NewUserID("user-123")
+	consent := ReconstructConsent(userID, "wiki", []string{"email", "openid"}, time.Now())
+
+	assert.True(t, consent.Covers([]string{"openid"}))
+	assert.True(t, consent.Covers([]string{"openid", "email"}))
+	assert.False(t, consent.Covers([]string{"openid", "profile"}))
+}
+
+func TestConsent_Grant(t *testing.T) {
+	userID, _ := user.NewUserID("user-123")
+	grantedAt := time.Now().Add(-time.Hour)
+	consent := ReconstructConsent(userID, "wiki", []string{"openid"}, grantedAt)
+
+	// Scopes the user already allowed change nothing
+	consent.Grant([]string{"openid"})
+	assert.Empty(t, consent.DomainEvents())
+	assert.Equal(t, grantedAt, consent.GrantedAt())
+
+	consent.Grant([]string{"profile", "openid"})
+	assert.Equal(t, []string{"openid", "profile"}, consent.Scopes())
+	assert.True(t, consent.GrantedAt().After(grantedAt))
+	require.Len(t, consent.DomainEvents(), 1)
+	assert.Equal(t, []string{"profile"}, consent.DomainEvents()[0].(GrantedEvent).Scopes)
+}
@@ -0,0 +1,26 @@
+package consent
+
+import "github.com/yuki5155/go-google-auth/internal/domain/shared"
+
+// Event type constants
+const (
+	EventTypeConsentGranted = "oauth_consent.granted"
+)
+
+// GrantedEvent is emitted when a user allows a client to receive scopes
+type GrantedEvent struct {
+	shared.BaseDomainEvent
+	UserID   string
+	ClientID string
+	Scopes   []string
+}
+
+// NewGrantedEvent creates a new GrantedEvent
+func NewGrantedEvent(userID, clientID string, scopes []string) GrantedEvent {
+	return GrantedEvent{
+		BaseDomainEvent: shared.NewBaseDomainEvent(EventTypeConsentGranted, userID),
+		UserID:          userID,
+		ClientID:        clientID,
+		Scopes:          scopes,
+	}
+}
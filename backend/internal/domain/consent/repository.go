@@ -0,0 +1,19 @@
+package consent
+
+import (
+	"context"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// Repository defines the interface for consent persistence
+type Repository interface {
+	// Save persists a consent, replacing the user's earlier consent to the same client
+	Save(ctx context.Context, consent *Consent) error
+
+	// Find retrieves a user's consent to a client, or shared.ErrConsentNotFound
+	Find(ctx context.Context, userID user.UserID, clientID string) (*Consent, error)
+
+	// DeleteByUserID removes all consents of a user
+	DeleteByUserID(ctx context.Context, userID user.UserID) error
+}
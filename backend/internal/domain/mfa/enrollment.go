@@ -0,0 +1,186 @@
+package mfa
+
+import (
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// Enrollment is a user's TOTP second factor. It starts pending and only
+// protects logins once confirmed with a valid code from the authenticator app.
+type Enrollment struct {
+	userID        user.UserID
+	secret        []byte
+	recoveryCodes RecoveryCodes
+	lastUsedStep  int64 // TOTP time step of the last accepted code, to reject replays
+	createdAt     time.Time
+	confirmedAt   time.Time
+	events        []shared.DomainEvent
+}
+
+// NewEnrollment starts a pending TOTP enrollment with the given shared secret
+func NewEnrollment(userID user.UserID, secret []byte) (*Enrollment, error) {
+	if userID.IsEmpty() {
+		return nil, shared.ErrEmptyUserID
+	}
+	if len(secret) == 0 {
+		return nil, shared.ErrInvalidMFASecret
+	}
+
+	return &Enrollment{
+		userID:    userID,
+		secret:    copyBytes(secret),
+		createdAt: time.Now(),
+		events:    make([]shared.DomainEvent, 0),
+	}, nil
+}
+
+// ReconstructEnrollment reconstructs an Enrollment from persistence (without domain events)
+func ReconstructEnrollment(
+	userID user.UserID,
+	secret []byte,
+	recoveryCodes RecoveryCodes,
+	lastUsedStep int64,
+	createdAt, confirmedAt time.Time,
+) *Enrollment {
+	return &Enrollment{
+		userID:        userID,
+		secret:        copyBytes(secret),
+		recoveryCodes: recoveryCodes,
+		lastUsedStep:  lastUsedStep,
+		createdAt:     createdAt,
+		confirmedAt:   confirmedAt,
+		events:        make([]shared.DomainEvent, 0),
+	}
+}
+
+// UserID returns the ID of the enrolled user
+func (e *Enrollment) UserID() user.UserID {
+	return e.userID
+}
+
+// Secret returns a copy of the TOTP shared secret
+func (e *Enrollment) Secret() []byte {
+	return copyBytes(e.secret)
+}
+
+// RecoveryCodes returns the unused recovery codes
+func (e *Enrollment) RecoveryCodes() RecoveryCodes {
+	return e.recoveryCodes
+}
+
+// LastUsedStep returns the TOTP time step of the last accepted code
+func (e *Enrollment) LastUsedStep() int64 {
+	return e.lastUsedStep
+}
+
+// CreatedAt returns when enrollment was started
+func (e *Enrollment) CreatedAt() time.Time {
+	return e.createdAt
+}
+
+// ConfirmedAt returns when the enrollment was confirmed (zero if pending)
+func (e *Enrollment) ConfirmedAt() time.Time {
+	return e.confirmedAt
+}
+
+// IsConfirmed returns true if the enrollment protects logins
+func (e *Enrollment) IsConfirmed() bool {
+	return !e.confirmedAt.IsZero()
+}
+
+// Confirm activates a pending enrollment after a valid code for the given
+// time step, returning the plain recovery codes to show to the user once
+func (e *Enrollment) Confirm(step int64) ([]string, error) {
+	if e.IsConfirmed() {
+		return nil, shared.ErrMFAAlreadyEnabled
+	}
+
+	codes, plain, err := GenerateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	e.lastUsedStep = step
+	e.recoveryCodes = codes
+	e.confirmedAt = time.Now()
+	e.addEvent(NewTOTPEnabledEvent(e.userID.Value()))
+
+	return plain, nil
+}
+
+// AcceptStep records a valid code for the given time step; codes from the
+// same or an earlier step than the last accepted one are rejected as replays
+func (e *Enrollment) AcceptStep(step int64) error {
+	if step <= e.lastUsedStep {
+		return shared.ErrInvalidMFACode
+	}
+
+	e.lastUsedStep = step
+	return nil
+}
+
+// UseRecoveryCode consumes a recovery code in place of a TOTP code
+func (e *Enrollment) UseRecoveryCode(code string) error {
+	if !e.IsConfirmed() {
+		return shared.ErrMFANotEnabled
+	}
+
+	remaining, ok := e.recoveryCodes.Use(code)
+	if !ok {
+		return shared.ErrInvalidMFACode
+	}
+
+	e.recoveryCodes = remaining
+	e.addEvent(NewRecoveryCodeUsedEvent(e.userID.Value(), remaining.Remaining()))
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes, returning the new plain codes
+func (e *Enrollment) RegenerateRecoveryCodes() ([]string, error) {
+	if !e.IsConfirmed() {
+		return nil, shared.ErrMFANotEnabled
+	}
+
+	codes, plain, err := GenerateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	e.recoveryCodes = codes
+	e.addEvent(NewRecoveryCodesRegeneratedEvent(e.userID.Value()))
+	return plain, nil
+}
+
+// Disable records that the enrollment is being removed
+func (e *Enrollment) Disable() error {
+	if !e.IsConfirmed() {
+		return shared.ErrMFANotEnabled
+	}
+
+	e.addEvent(NewTOTPDisabledEvent(e.userID.Value()))
+	return nil
+}
+
+// DomainEvents returns all domain events
+func (e *Enrollment) DomainEvents() []shared.DomainEvent {
+	return e.events
+}
+
+// ClearDomainEvents clears all domain events (after they've been published)
+func (e *Enrollment) ClearDomainEvents() {
+	e.events = make([]shared.DomainEvent, 0)
+}
+
+// addEvent adds a domain event
+func (e *Enrollment) addEvent(event shared.DomainEvent) {
+	e.events = append(e.events, event)
+}
+
+// copyBytes returns a copy of b
+func copyBytes(b []byte) []byte {
+	copied := make([]byte, len(b))
+	copy(copied, b)
+	return copied
+}
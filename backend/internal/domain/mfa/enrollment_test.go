@@ -0,0 +1,134 @@
+package mfa
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+func newTestEnrollment(t *testing.T) *Enrollment {
+	t.Helper()
+
+	userID, _ := user.NewUserID("test-user-123")
+	e, err := NewEnrollment(userID, []byte("12345678901234567890"))
+	require.NoError(t, err)
+	return e
+}
+
+func TestNewEnrollment(t *testing.T) {
+	e := newTestEnrollment(t)
+
+	assert.False(t, e.IsConfirmed())
+	assert.Equal(t, 0, e.RecoveryCodes().Remaining())
+	assert.False(t, e.CreatedAt().IsZero())
+}
+
+func TestNewEnrollment_Invalid(t *testing.T) {
+	userID, _ := user.NewUserID("test-user-123")
+
+	_, err := NewEnrollment(user.UserID{}, []byte("secret"))
+	assert.Equal(t, shared.ErrEmptyUserID, err)
+
+	_, err = NewEnrollment(userID, nil)
+	assert.Equal(t, shared.ErrInvalidMFASecret, err)
+}
+
+func TestEnrollment_Confirm(t *testing.T) {
+	e := newTestEnrollment(t)
+
+	codes, err := e.Confirm(42)
+
+	require.NoError(t, err)
+	assert.True(t, e.IsConfirmed())
+	assert.Equal(t, int64(42), e.LastUsedStep())
+	assert.Len(t, codes, RecoveryCodeCount)
+	assert.Equal(t, RecoveryCodeCount, e.RecoveryCodes().Remaining())
+	for _, code := range codes {
+		assert.Len(t, code, 11)
+		assert.Equal(t, "-", code[5:6])
+	}
+
+	require.Len(t, e.DomainEvents(), 1)
+	assert.Equal(t, EventTypeTOTPEnabled, e.DomainEvents()[0].EventType())
+	assert.Equal(t, "test-user-123", e.DomainEvents()[0].AggregateID())
+
+	_, err = e.Confirm(43)
+	assert.Equal(t, shared.ErrMFAAlreadyEnabled, err)
+}
+
+func TestEnrollment_AcceptStep_RejectsReplay(t *testing.T) {
+	e := newTestEnrollment(t)
+	_, err := e.Confirm(100)
+	require.NoError(t, err)
+
+	assert.Equal(t, shared.ErrInvalidMFACode, e.AcceptStep(100))
+	assert.Equal(t, shared.ErrInvalidMFACode, e.AcceptStep(99))
+	assert.NoError(t, e.AcceptStep(101))
+	assert.Equal(t, int64(101), e.LastUsedStep())
+}
+
+func TestEnrollment_UseRecoveryCode(t *testing.T) {
+	e := newTestEnrollment(t)
+	codes, err := e.Confirm(1)
+	require.NoError(t, err)
+	e.ClearDomainEvents()
+
+	// Codes are accepted regardless of case, dashes and spaces
+	input := strings.ToUpper(strings.Replace(codes[3], "-", " ", 1))
+	require.NoError(t, e.UseRecoveryCode(input))
+	assert.Equal(t, RecoveryCodeCount-1, e.RecoveryCodes().Remaining())
+	require.Len(t, e.DomainEvents(), 1)
+	assert.Equal(t, EventTypeRecoveryCodeUsed, e.DomainEvents()[0].EventType())
+
+	// Each code works only once
+	assert.Equal(t, shared.ErrInvalidMFACode, e.UseRecoveryCode(codes[3]))
+	assert.Equal(t, shared.ErrInvalidMFACode, e.UseRecoveryCode("aaaaa-bbbbb"))
+}
+
+func TestEnrollment_UseRecoveryCode_NotConfirmed(t *testing.T) {
+	e := newTestEnrollment(t)
+
+	assert.Equal(t, shared.ErrMFANotEnabled, e.UseRecoveryCode("aaaaa-bbbbb"))
+}
+
+func TestEnrollment_RegenerateRecoveryCodes(t *testing.T) {
+	e := newTestEnrollment(t)
+	oldCodes, err := e.Confirm(1)
+	require.NoError(t, err)
+	require.NoError(t, e.UseRecoveryCode(oldCodes[0]))
+
+	newCodes, err := e.RegenerateRecoveryCodes()
+
+	require.NoError(t, err)
+	assert.Len(t, newCodes, RecoveryCodeCount)
+	assert.Equal(t, RecoveryCodeCount, e.RecoveryCodes().Remaining())
+	assert.Equal(t, shared.ErrInvalidMFACode, e.UseRecoveryCode(oldCodes[1]))
+	assert.NoError(t, e.UseRecoveryCode(newCodes[0]))
+}
+
+func TestEnrollment_Disable(t *testing.T) {
+	e := newTestEnrollment(t)
+	assert.Equal(t, shared.ErrMFANotEnabled, e.Disable())
+
+	_, err := e.Confirm(1)
+	require.NoError(t, err)
+	e.ClearDomainEvents()
+
+	require.NoError(t, e.Disable())
+	require.Len(t, e.DomainEvents(), 1)
+	assert.Equal(t, EventTypeTOTPDisabled, e.DomainEvents()[0].EventType())
+}
+
+func TestEnrollment_SecretIsCopied(t *testing.T) {
+	e := newTestEnrollment(t)
+
+	secret := e.Secret()
+	secret[0] = 'X'
+
+	assert.Equal(t, byte('1'), e.Secret()[0])
+}
@@ -0,0 +1,69 @@
+package mfa
+
+import "github.com/yuki5155/go-google-auth/internal/domain/shared"
+
+// Event type constants
+const (
+	EventTypeTOTPEnabled              = "mfa.totp_enabled"
+	EventTypeTOTPDisabled             = "mfa.totp_disabled"
+	EventTypeRecoveryCodeUsed         = "mfa.recovery_code_used"
+	EventTypeRecoveryCodesRegenerated = "mfa.recovery_codes_regenerated"
+)
+
+// TOTPEnabledEvent is emitted when a user confirms TOTP enrollment
+type TOTPEnabledEvent struct {
+	shared.BaseDomainEvent
+	UserID string
+}
+
+// NewTOTPEnabledEvent creates a new TOTPEnabledEvent
+func NewTOTPEnabledEvent(userID string) TOTPEnabledEvent {
+	return TOTPEnabledEvent{
+		BaseDomainEvent: shared.NewBaseDomainEvent(EventTypeTOTPEnabled, userID),
+		UserID:          userID,
+	}
+}
+
+// TOTPDisabledEvent is emitted when a user removes their TOTP factor
+type TOTPDisabledEvent struct {
+	shared.BaseDomainEvent
+	UserID string
+}
+
+// NewTOTPDisabledEvent creates a new TOTPDisabledEvent
+func NewTOTPDisabledEvent(userID string) TOTPDisabledEvent {
+	return TOTPDisabledEvent{
+		BaseDomainEvent: shared.NewBaseDomainEvent(EventTypeTOTPDisabled, userID),
+		UserID:          userID,
+	}
+}
+
+// RecoveryCodeUsedEvent is emitted when a recovery code is used to log in
+type RecoveryCodeUsedEvent struct {
+	shared.BaseDomainEvent
+	UserID    string
+	Remaining int
+}
+
+// NewRecoveryCodeUsedEvent creates a new RecoveryCodeUsedEvent
+func NewRecoveryCodeUsedEvent(userID string, remaining int) RecoveryCodeUsedEvent {
+	return RecoveryCodeUsedEvent{
+		BaseDomainEvent: shared.NewBaseDomainEvent(EventTypeRecoveryCodeUsed, userID),
+		UserID:          userID,
+		Remaining:       remaining,
+	}
+}
+
+// RecoveryCodesRegeneratedEvent is emitted when a user replaces their recovery codes
+type RecoveryCodesRegeneratedEvent struct {
+	shared.BaseDomainEvent
+	UserID string
+}
+
+// NewRecoveryCodesRegeneratedEvent creates a new RecoveryCodesRegeneratedEvent
+func NewRecoveryCodesRegeneratedEvent(userID string) RecoveryCodesRegeneratedEvent {
+	return RecoveryCodesRegeneratedEvent{
+		BaseDomainEvent: shared.NewBaseDomainEvent(EventTypeRecoveryCodesRegenerated, userID),
+		UserID:          userID,
+	}
+}
@@ -0,0 +1,96 @@
+package mfa
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	// RecoveryCodeCount is how many recovery codes are issued at a time
+	RecoveryCodeCount = 10
+
+	// recoveryCodeBytes is the entropy per code (50 bits encodes to 10 base32 characters)
+	recoveryCodeBytes = 7
+	recoveryCodeLen   = 10
+)
+
+// recoveryEncoding renders codes in lowercase base32 without padding
+var recoveryEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// RecoveryCodes is the set of unused recovery codes of an enrollment.
+// Only SHA-256 hashes are kept; the plain codes are shown to the user once.
+type RecoveryCodes struct {
+	hashes []string
+}
+
+// GenerateRecoveryCodes creates RecoveryCodeCount new codes, returning the set
+// and the plain codes formatted as "xxxxx-xxxxx"
+func GenerateRecoveryCodes() (RecoveryCodes, []string, error) {
+	plain := make([]string, 0, RecoveryCodeCount)
+	hashes := make([]string, 0, RecoveryCodeCount)
+
+	for i := 0; i < RecoveryCodeCount; i++ {
+		bytes := make([]byte, recoveryCodeBytes)
+		if _, err := rand.Read(bytes); err != nil {
+			return RecoveryCodes{}, nil, err
+		}
+
+		code := recoveryEncoding.EncodeToString(bytes)[:recoveryCodeLen]
+		plain = append(plain, code[:5]+"-"+code[5:])
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+
+	return RecoveryCodes{hashes: hashes}, plain, nil
+}
+
+// ReconstructRecoveryCodes rebuilds a RecoveryCodes set from persisted hashes
+func ReconstructRecoveryCodes(hashes []string) RecoveryCodes {
+	copied := make([]string, len(hashes))
+	copy(copied, hashes)
+	return RecoveryCodes{hashes: copied}
+}
+
+// Hashes returns a copy of the hashes of the unused codes
+func (r RecoveryCodes) Hashes() []string {
+	hashes := make([]string, len(r.hashes))
+	copy(hashes, r.hashes)
+	return hashes
+}
+
+// Remaining returns how many codes are still unused
+func (r RecoveryCodes) Remaining() int {
+	return len(r.hashes)
+}
+
+// Use returns the set without the given code, or false if the code is not in the set
+func (r RecoveryCodes) Use(code string) (RecoveryCodes, bool) {
+	hash := hashRecoveryCode(normalizeRecoveryCode(code))
+
+	for i, candidate := range r.hashes {
+		if subtle.ConstantTimeCompare([]byte(candidate), []byte(hash)) == 1 {
+			remaining := make([]string, 0, len(r.hashes)-1)
+			remaining = append(remaining, r.hashes[:i]...)
+			remaining = append(remaining, r.hashes[i+1:]...)
+			return RecoveryCodes{hashes: remaining}, true
+		}
+	}
+
+	return r, false
+}
+
+// normalizeRecoveryCode accepts codes with any case, spaces or dashes
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
+
+// hashRecoveryCode hashes a normalized code; codes are random, so no salt is needed
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
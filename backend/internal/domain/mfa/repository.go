@@ -0,0 +1,19 @@
+package mfa
+
+import (
+	"context"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// Repository defines the interface for MFA enrollment persistence
+type Repository interface {
+	// Save persists an enrollment, replacing any existing one for the user
+	Save(ctx context.Context, enrollment *Enrollment) error
+
+	// FindByUserID retrieves a user's enrollment, or shared.ErrMFANotEnabled if there is none
+	FindByUserID(ctx context.Context, userID user.UserID) (*Enrollment, error)
+
+	// DeleteByUserID removes a user's enrollment
+	DeleteByUserID(ctx context.Context, userID user.UserID) error
+}
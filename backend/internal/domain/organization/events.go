@@ -0,0 +1,131 @@
+package organization
+
+import "github.com/yuki5155/go-google-auth/internal/domain/shared"
+
+// Event type constants. Organization events are recorded against the user
+// they concern, so that they appear in that user's audit history.
+const (
+	EventTypeOrganizationCreated = "organization.created"
+	EventTypeMemberJoined        = "organization.member_joined"
+	EventTypeMemberRoleChanged   = "organization.member_role_changed"
+	EventTypeMemberRemoved       = "organization.member_removed"
+	EventTypeInvitationCreated   = "organization.invitation_created"
+	EventTypeInvitationRevoked   = "organization.invitation_revoked"
+)
+
+// OrganizationCreatedEvent is emitted when a user creates an organization
+type OrganizationCreatedEvent struct {
+	shared.BaseDomainEvent
+	OrganizationID string
+	Name           string
+	CreatedBy      string
+}
+
+// NewOrganizationCreatedEvent creates a new OrganizationCreatedEvent
+func NewOrganizationCreatedEvent(orgID, name, createdBy string) OrganizationCreatedEvent {
+	return OrganizationCreatedEvent{
+		BaseDomainEvent: shared.NewBaseDomainEvent(EventTypeOrganizationCreated, createdBy),
+		OrganizationID:  orgID,
+		Name:            name,
+		CreatedBy:       createdBy,
+	}
+}
+
+// MemberJoinedEvent is emitted when a user becomes a member of an organization
+type MemberJoinedEvent struct {
+	shared.BaseDomainEvent
+	OrganizationID string
+	UserID         string
+	Role           string
+}
+
+// NewMemberJoinedEvent creates a new MemberJoinedEvent
+func NewMemberJoinedEvent(orgID, userID, role string) MemberJoinedEvent {
+	return MemberJoinedEvent{
+		BaseDomainEvent: shared.NewBaseDomainEvent(EventTypeMemberJoined, userID),
+		OrganizationID:  orgID,
+		UserID:          userID,
+		Role:            role,
+	}
+}
+
+// MemberRoleChangedEvent is emitted when a member is given a different role
+type MemberRoleChangedEvent struct {
+	shared.BaseDomainEvent
+	OrganizationID string
+	UserID         string
+	Role           string
+	ChangedBy      string
+}
+
+// NewMemberRoleChangedEvent creates a new MemberRoleChangedEvent
+func NewMemberRoleChangedEvent(orgID, userID, role, changedBy string) MemberRoleChangedEvent {
+	return MemberRoleChangedEvent{
+		BaseDomainEvent: shared.NewBaseDomainEvent(EventTypeMemberRoleChanged, userID),
+		OrganizationID:  orgID,
+		UserID:          userID,
+		Role:            role,
+		ChangedBy:       changedBy,
+	}
+}
+
+// MemberRemovedEvent is emitted when a member leaves or is removed from an
+// organization; RemovedBy is the member themselves when they left
+type MemberRemovedEvent struct {
+	shared.BaseDomainEvent
+	OrganizationID string
+	UserID         string
+	RemovedBy      string
+}
+
+// NewMemberRemovedEvent creates a new MemberRemovedEvent
+func NewMemberRemovedEvent(orgID, userID, removedBy string) MemberRemovedEvent {
+	return MemberRemovedEvent{
+		BaseDomainEvent: shared.NewBaseDomainEvent(EventTypeMemberRemoved, userID),
+		OrganizationID:  orgID,
+		UserID:          userID,
+		RemovedBy:       removedBy,
+	}
+}
+
+// InvitationCreatedEvent is emitted when a member invites an email address
+type InvitationCreatedEvent struct {
+	shared.BaseDomainEvent
+	OrganizationID string
+	InvitationID   string
+	Email          string
+	Role           string
+	InvitedBy      string
+}
+
+// NewInvitationCreatedEvent creates a new InvitationCreatedEvent
+func NewInvitationCreatedEvent(orgID, invitationID, email, role, invitedBy string) InvitationCreatedEvent {
+	return InvitationCreatedEvent{
+		BaseDomainEvent: shared.NewBaseDomainEvent(EventTypeInvitationCreated, invitedBy),
+		OrganizationID:  orgID,
+		InvitationID:    invitationID,
+		Email:           email,
+		Role:            role,
+		InvitedBy:       invitedBy,
+	}
+}
+
+// InvitationRevokedEvent is emitted when a member withdraws an invitation
+type InvitationRevokedEvent struct {
+	shared.BaseDomainEvent
+	OrganizationID string
+	InvitationID   string
+	Email          string
+	RevokedBy      string
+}
+
+// NewInvitationRevokedEvent creates a new InvitationRevokedEvent
+func NewInvitationRevokedEvent(orgID, invitationID, email, revokedBy string) InvitationRevokedEvent {
+	return InvitationRevokedEvent{
+		BaseDomainEvent: shared.NewBaseDomainEvent(EventTypeInvitationRevoked, revokedBy),
+		OrganizationID:  orgID,
+		InvitationID:    invitationID,
+		Email:           email,
+		RevokedBy:       revokedBy,
+	}
+}
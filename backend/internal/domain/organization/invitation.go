@@ -0,0 +1,195 @@
+package organization
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+const (
+	// InvitationPrefix starts every invitation token
+	InvitationPrefix = "inv_"
+
+	// MaxPendingInvitations caps how many unexpired invitations an
+	// organization may have out at once
+	MaxPendingInvitations = 50
+
+	// invitationSecretBytes is the entropy of an invitation token
+	invitationSecretBytes = 32
+)
+
+// Invitation invites an email address to join an organization with a role.
+// Only a SHA-256 hash of its token is kept; the plain token is emailed once.
+type Invitation struct {
+	id             InvitationID
+	organizationID OrganizationID
+	email          user.Email
+	role           Role
+	invitedBy      user.UserID
+	hash           string
+	createdAt      time.Time
+	expiresAt      time.Time
+	events         []shared.DomainEvent
+}
+
+// NewInvitation invites email to the inviter's organization with role until
+// expiresAt, returning the invitation together with the plain token to send.
+// The inviter must be allowed to give the role (see Role.CanAssign).
+func NewInvitation(inviter *Membership, email user.Email, role Role, expiresAt time.Time) (*Invitation, string, error) {
+	if _, err := ParseRole(role.String()); err != nil {
+		return nil, "", err
+	}
+	if !inviter.role.CanManageMembers() || !inviter.role.CanAssign(role) {
+		return nil, "", shared.ErrOrganizationPermissionDenied
+	}
+
+	id, err := GenerateInvitationID()
+	if err != nil {
+		return nil, "", err
+	}
+
+	bytes := make([]byte, invitationSecretBytes)
+	if _, err := rand.Read(bytes); err != nil {
+		return nil, "", err
+	}
+	plain := InvitationPrefix + base64.RawURLEncoding.EncodeToString(bytes)
+
+	invitation := &Invitation{
+		id:             id,
+		organizationID: inviter.organizationID,
+		email:          email,
+		role:           role,
+		invitedBy:      inviter.userID,
+		hash:           HashInvitationToken(plain),
+		createdAt:      time.Now(),
+		expiresAt:      expiresAt,
+		events:         make([]shared.DomainEvent, 0),
+	}
+	invitation.addEvent(NewInvitationCreatedEvent(
+		inviter.organizationID.Value(), id.Value(), email.Value(), role.String(), inviter.userID.Value(),
+	))
+
+	return invitation, plain, nil
+}
+
+// ReconstructInvitation reconstructs an Invitation from persistence (without domain events)
+func ReconstructInvitation(
+	id InvitationID,
+	orgID OrganizationID,
+	email user.Email,
+	role Role,
+	invitedBy user.UserID,
+	hash string,
+	createdAt, expiresAt time.Time,
+) *Invitation {
+	return &Invitation{
+		id:             id,
+		organizationID: orgID,
+		email:          email,
+		role:           role,
+		invitedBy:      invitedBy,
+		hash:           hash,
+		createdAt:      createdAt,
+		expiresAt:      expiresAt,
+		events:         make([]shared.DomainEvent, 0),
+	}
+}
+
+// HashInvitationToken returns the hex SHA-256 hash under which a plain
+// invitation token is stored
+func HashInvitationToken(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
+
+// ID returns the invitation's ID
+func (i *Invitation) ID() InvitationID {
+	return i.id
+}
+
+// OrganizationID returns the ID of the organization the invitation is to
+func (i *Invitation) OrganizationID() OrganizationID {
+	return i.organizationID
+}
+
+// Email returns the address the invitation was sent to
+func (i *Invitation) Email() user.Email {
+	return i.email
+}
+
+// Role returns the role the invited user will have
+func (i *Invitation) Role() Role {
+	return i.role
+}
+
+// InvitedBy returns the ID of the member who sent the invitation
+func (i *Invitation) InvitedBy() user.UserID {
+	return i.invitedBy
+}
+
+// Hash returns the SHA-256 hash of the plain token
+func (i *Invitation) Hash() string {
+	return i.hash
+}
+
+// CreatedAt returns when the invitation was sent
+func (i *Invitation) CreatedAt() time.Time {
+	return i.createdAt
+}
+
+// ExpiresAt returns when the invitation expires
+func (i *Invitation) ExpiresAt() time.Time {
+	return i.expiresAt
+}
+
+// IsPending returns true if the invitation can still be accepted at the given time
+func (i *Invitation) IsPending(now time.Time) bool {
+	return now.Before(i.expiresAt)
+}
+
+// Accept makes u a member with the invited role. Only the user the
+// invitation was sent to may accept it, as shown by their verified email.
+// The caller deletes the invitation.
+func (i *Invitation) Accept(u *user.User, now time.Time) (*Membership, error) {
+	if !i.IsPending(now) {
+		return nil, shared.ErrInvitationNotFound
+	}
+	if !u.Email().IsVerified() || u.Email().Value() != i.email.Value() {
+		return nil, shared.ErrInvitationEmailMismatch
+	}
+
+	return NewMembership(i.organizationID, u.ID(), i.role)
+}
+
+// Revoke records that actor withdrew the invitation; the caller deletes it
+func (i *Invitation) Revoke(actor *Membership) error {
+	if !actor.organizationID.Equals(i.organizationID) {
+		return shared.ErrInvitationNotFound
+	}
+	if !actor.role.CanManageMembers() || !actor.role.CanAssign(i.role) {
+		return shared.ErrOrganizationPermissionDenied
+	}
+
+	i.addEvent(NewInvitationRevokedEvent(i.organizationID.Value(), i.id.Value(), i.email.Value(), actor.userID.Value()))
+	return nil
+}
+
+// DomainEvents returns all domain events
+func (i *Invitation) DomainEvents() []shared.DomainEvent {
+	return i.events
+}
+
+// ClearDomainEvents clears all domain events
+func (i *Invitation) ClearDomainEvents() {
+	i.events = make([]shared.DomainEvent, 0)
+}
+
+// addEvent adds a domain event
+func (i *Invitation) addEvent(event shared.DomainEvent) {
+	i.events = append(i.events, event)
+}
@@ -0,0 +1,54 @@
+package organization
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+// InvitationID identifies an invitation; unlike its token, it is not sensitive
+type InvitationID struct {
+	value string
+}
+
+// NewInvitationID creates a InvitationID from an existing value with validation
+func NewInvitationID(id string) (InvitationID, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return InvitationID{}, shared.ErrInvitationNotFound
+	}
+
+	return InvitationID{value: id}, nil
+}
+
+// GenerateInvitationID creates a new random InvitationID
+func GenerateInvitationID() (InvitationID, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return InvitationID{}, err
+	}
+
+	return InvitationID{value: hex.EncodeToString(bytes)}, nil
+}
+
+// Value returns the string value of the InvitationID
+func (i InvitationID) Value() string {
+	return i.value
+}
+
+// String implements the Stringer interface
+func (i InvitationID) String() string {
+	return i.value
+}
+
+// Equals compares two InvitationIDs for equality
+func (i InvitationID) Equals(other InvitationID) bool {
+	return i.value == other.value
+}
+
+// IsEmpty returns true if the InvitationID is empty
+func (i InvitationID) IsEmpty() bool {
+	return i.value == ""
+}
@@ -0,0 +1,98 @@
+package organization
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// newTestInvitee returns the user invitations in these tests are sent to
+func newTestInvitee(t *testing.T, address string) *user.User {
+	t.Helper()
+
+	userID, _ := user.NewUserID("user-456")
+	email, _ := user.NewEmail(address, true)
+	invitee, err := user.NewUser(userID, email, user.NewProfile("Bob", ""))
+	require.NoError(t, err)
+	return invitee
+}
+
+func TestNewInvitation_Success(t *testing.T) {
+	admin := newTestMembership(t, "admin-1", RoleAdmin)
+	email, _ := user.NewEmail("Bob@Example.com", false)
+	expiresAt := time.Now().Add(time.Hour)
+
+	invitation, plain, err := NewInvitation(admin, email, RoleMember, expiresAt)
+
+	require.NoError(t, err)
+	assert.Equal(t, "org-1", invitation.OrganizationID().Value())
+	assert.Equal(t, "bob@example.com", invitation.Email().Value())
+	assert.Equal(t, RoleMember, invitation.Role())
+	assert.True(t, invitation.IsPending(time.Now()))
+
+	// Only the hash of the plain token is kept
+	assert.True(t, strings.HasPrefix(plain, InvitationPrefix))
+	assert.Equal(t, HashInvitationToken(plain), invitation.Hash())
+
+	require.Len(t, invitation.DomainEvents(), 1)
+	assert.Equal(t, EventTypeInvitationCreated, invitation.DomainEvents()[0].EventType())
+	assert.Equal(t, "admin-1", invitation.DomainEvents()[0].AggregateID())
+}
+
+func TestNewInvitation_PermissionDenied(t *testing.T) {
+	email, _ := user.NewEmail("bob@example.com", false)
+	expiresAt := time.Now().Add(time.Hour)
+
+	_, _, err := NewInvitation(newTestMembership(t, "user-123", RoleMember), email, RoleMember, expiresAt)
+	assert.ErrorIs(t, err, shared.ErrOrganizationPermissionDenied)
+
+	_, _, err = NewInvitation(newTestMembership(t, "admin-1", RoleAdmin), email, RoleOwner, expiresAt)
+	assert.ErrorIs(t, err, shared.ErrOrganizationPermissionDenied)
+}
+
+func TestInvitation_Accept(t *testing.T) {
+	admin := newTestMembership(t, "admin-1", RoleAdmin)
+	email, _ := user.NewEmail("bob@example.com", false)
+	invitation, _, err := NewInvitation(admin, email, RoleAdmin, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	membership, err := invitation.Accept(newTestInvitee(t, "bob@example.com"), time.Now())
+
+	require.NoError(t, err)
+	assert.Equal(t, "org-1", membership.OrganizationID().Value())
+	assert.Equal(t, "user-456", membership.UserID().Value())
+	assert.Equal(t, RoleAdmin, membership.Role())
+}
+
+func TestInvitation_Accept_Rejected(t *testing.T) {
+	admin := newTestMembership(t, "admin-1", RoleAdmin)
+	email, _ := user.NewEmail("bob@example.com", false)
+	invitation, _, err := NewInvitation(admin, email, RoleMember, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	_, err = invitation.Accept(newTestInvitee(t, "mallory@example.com"), time.Now())
+	assert.ErrorIs(t, err, shared.ErrInvitationEmailMismatch)
+
+	_, err = invitation.Accept(newTestInvitee(t, "bob@example.com"), time.Now().Add(2*time.Hour))
+	assert.ErrorIs(t, err, shared.ErrInvitationNotFound)
+}
+
+func TestInvitation_Revoke(t *testing.T) {
+	owner := newTestMembership(t, "owner-1", RoleOwner)
+	email, _ := user.NewEmail("bob@example.com", false)
+	invitation, _, err := NewInvitation(owner, email, RoleOwner, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	invitation.ClearDomainEvents()
+
+	// Admins cannot withdraw an invitation to become an owner
+	assert.ErrorIs(t, invitation.Revoke(newTestMembership(t, "admin-1", RoleAdmin)), shared.ErrOrganizationPermissionDenied)
+
+	require.NoError(t, invitation.Revoke(owner))
+	require.Len(t, invitation.DomainEvents(), 1)
+	assert.Equal(t, EventTypeInvitationRevoked, invitation.DomainEvents()[0].EventType())
+}
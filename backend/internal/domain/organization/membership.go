@@ -0,0 +1,149 @@
+package organization
+
+import (
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// Membership makes a user a member of an organization with a role there. A
+// user may belong to several organizations, with a different role in each.
+type Membership struct {
+	organizationID OrganizationID
+	userID         user.UserID
+	role           Role
+	joinedAt       time.Time
+	events         []shared.DomainEvent
+}
+
+// NewMembership makes a user a member of an organization with role
+func NewMembership(orgID OrganizationID, userID user.UserID, role Role) (*Membership, error) {
+	if orgID.IsEmpty() {
+		return nil, shared.ErrOrganizationNotFound
+	}
+	if userID.IsEmpty() {
+		return nil, shared.ErrEmptyUserID
+	}
+	if _, err := ParseRole(role.String()); err != nil {
+		return nil, err
+	}
+
+	membership := &Membership{
+		organizationID: orgID,
+		userID:         userID,
+		role:           role,
+		joinedAt:       time.Now(),
+		events:         make([]shared.DomainEvent, 0),
+	}
+	membership.addEvent(NewMemberJoinedEvent(orgID.Value(), userID.Value(), role.String()))
+
+	return membership, nil
+}
+
+// ReconstructMembership reconstructs a Membership from persistence (without domain events)
+func ReconstructMembership(orgID OrganizationID, userID user.UserID, role Role, joinedAt time.Time) *Membership {
+	return &Membership{
+		organizationID: orgID,
+		userID:         userID,
+		role:           role,
+		joinedAt:       joinedAt,
+		events:         make([]shared.DomainEvent, 0),
+	}
+}
+
+// CountOwners returns how many of members are owners
+func CountOwners(members []*Membership) int {
+	owners := 0
+	for _, member := range members {
+		if member.role == RoleOwner {
+			owners++
+		}
+	}
+	return owners
+}
+
+// OrganizationID returns the ID of the organization
+func (m *Membership) OrganizationID() OrganizationID {
+	return m.organizationID
+}
+
+// UserID returns the ID of the member
+func (m *Membership) UserID() user.UserID {
+	return m.userID
+}
+
+// Role returns the member's role in the organization
+func (m *Membership) Role() Role {
+	return m.role
+}
+
+// JoinedAt returns when the user became a member
+func (m *Membership) JoinedAt() time.Time {
+	return m.joinedAt
+}
+
+// ChangeRole gives the member role at the request of actor. owners is the
+// number of owners the organization has, which may not drop to zero.
+func (m *Membership) ChangeRole(actor *Membership, role Role, owners int) error {
+	if _, err := ParseRole(role.String()); err != nil {
+		return err
+	}
+	if err := actor.authorize(m, role); err != nil {
+		return err
+	}
+	if m.role == role {
+		return nil
+	}
+	if m.role == RoleOwner && owners <= 1 {
+		return shared.ErrLastOrganizationOwner
+	}
+
+	m.role = role
+	m.addEvent(NewMemberRoleChangedEvent(m.organizationID.Value(), m.userID.Value(), role.String(), actor.userID.Value()))
+	return nil
+}
+
+// Remove records that actor removed the member, or that the member left when
+// actor is the member themselves; the caller deletes the membership. owners
+// is the number of owners the organization has, which may not drop to zero.
+func (m *Membership) Remove(actor *Membership, owners int) error {
+	if !actor.userID.Equals(m.userID) {
+		if err := actor.authorize(m, ""); err != nil {
+			return err
+		}
+	}
+	if m.role == RoleOwner && owners <= 1 {
+		return shared.ErrLastOrganizationOwner
+	}
+
+	m.addEvent(NewMemberRemovedEvent(m.organizationID.Value(), m.userID.Value(), actor.userID.Value()))
+	return nil
+}
+
+// DomainEvents returns all domain events
+func (m *Membership) DomainEvents() []shared.DomainEvent {
+	return m.events
+}
+
+// ClearDomainEvents clears all domain events
+func (m *Membership) ClearDomainEvents() {
+	m.events = make([]shared.DomainEvent, 0)
+}
+
+// authorize checks that the member may manage target in the same
+// organization, and give them role unless it is empty
+func (m *Membership) authorize(target *Membership, role Role) error {
+	if !m.organizationID.Equals(target.organizationID) {
+		return shared.ErrNotOrganizationMember
+	}
+	if !m.role.CanManageMembers() || !m.role.CanAssign(target.role) || (role != "" && !m.role.CanAssign(role)) {
+		return shared.ErrOrganizationPermissionDenied
+	}
+	return nil
+}
+
+// addEvent adds a domain event
+func (m *Membership) addEvent(event shared.DomainEvent) {
+	m.events = append(m.events, event)
+}
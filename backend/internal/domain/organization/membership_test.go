@@ -0,0 +1,124 @@
+package organization
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// newTestMembership returns a member of org-1 with role, without events
+func newTestMembership(t *testing.T, userID string, role Role) *Membership {
+	t.Helper()
+
+	orgID, _ := NewOrganizationID("org-1")
+	id, _ := user.NewUserID(userID)
+	membership, err := NewMembership(orgID, id, role)
+	require.NoError(t, err)
+	membership.ClearDomainEvents()
+	return membership
+}
+
+func TestNewMembership(t *testing.T) {
+	orgID, _ := NewOrganizationID("org-1")
+	userID, _ := user.NewUserID("user-123")
+
+	membership, err := NewMembership(orgID, userID, RoleMember)
+
+	require.NoError(t, err)
+	assert.Equal(t, RoleMember, membership.Role())
+	require.Len(t, membership.DomainEvents(), 1)
+	assert.Equal(t, EventTypeMemberJoined, membership.DomainEvents()[0].EventType())
+	assert.Equal(t, "user-123", membership.DomainEvents()[0].AggregateID())
+
+	_, err = NewMembership(orgID, userID, Role("guest"))
+	assert.ErrorIs(t, err, shared.ErrInvalidOrganizationRole)
+}
+
+func TestMembership_ChangeRole(t *testing.T) {
+	owner := newTestMembership(t, "owner-1", RoleOwner)
+	member := newTestMembership(t, "user-123", RoleMember)
+
+	err := member.ChangeRole(owner, RoleAdmin, 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, RoleAdmin, member.Role())
+	require.Len(t, member.DomainEvents(), 1)
+	changed, ok := member.DomainEvents()[0].(MemberRoleChangedEvent)
+	require.True(t, ok)
+	assert.Equal(t, "admin", changed.Role)
+	assert.Equal(t, "owner-1", changed.ChangedBy)
+}
+
+func TestMembership_ChangeRole_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		actor   Role
+		target  Role
+		role    Role
+		owners  int
+		wantErr error
+	}{
+		{"member cannot manage", RoleMember, RoleMember, RoleAdmin, 1, shared.ErrOrganizationPermissionDenied},
+		{"admin cannot make owners", RoleAdmin, RoleMember, RoleOwner, 1, shared.ErrOrganizationPermissionDenied},
+		{"admin cannot demote owners", RoleAdmin, RoleOwner, RoleMember, 2, shared.ErrOrganizationPermissionDenied},
+		{"last owner stays", RoleOwner, RoleOwner, RoleAdmin, 1, shared.ErrLastOrganizationOwner},
+		{"unknown role", RoleOwner, RoleMember, Role("guest"), 1, shared.ErrInvalidOrganizationRole},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actor := newTestMembership(t, "actor-1", tt.actor)
+			target := newTestMembership(t, "user-123", tt.target)
+
+			err := target.ChangeRole(actor, tt.role, tt.owners)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.target, target.Role())
+			assert.Empty(t, target.DomainEvents())
+		})
+	}
+}
+
+func TestMembership_Remove(t *testing.T) {
+	admin := newTestMembership(t, "admin-1", RoleAdmin)
+	member := newTestMembership(t, "user-123", RoleMember)
+
+	require.NoError(t, member.Remove(admin, 1))
+	removed, ok := member.DomainEvents()[0].(MemberRemovedEvent)
+	require.True(t, ok)
+	assert.Equal(t, "admin-1", removed.RemovedBy)
+
+	// Members may always leave, unless they are the last owner
+	leaving := newTestMembership(t, "user-456", RoleMember)
+	assert.NoError(t, leaving.Remove(leaving, 1))
+
+	owner := newTestMembership(t, "owner-1", RoleOwner)
+	assert.ErrorIs(t, owner.Remove(owner, 1), shared.ErrLastOrganizationOwner)
+	assert.ErrorIs(t, owner.Remove(admin, 2), shared.ErrOrganizationPermissionDenied)
+	assert.ErrorIs(t, admin.Remove(member, 1), shared.ErrOrganizationPermissionDenied)
+}
+
+func TestMembership_OtherOrganization(t *testing.T) {
+	otherOrg, _ := NewOrganizationID("org-2")
+	ownerID, _ := user.NewUserID("owner-2")
+	outsider := ReconstructMembership(otherOrg, ownerID, RoleOwner, time.Now())
+	target := newTestMembership(t, "user-123", RoleMember)
+
+	err := target.ChangeRole(outsider, RoleAdmin, 1)
+
+	assert.ErrorIs(t, err, shared.ErrNotOrganizationMember)
+}
+
+func TestCountOwners(t *testing.T) {
+	members := []*Membership{
+		newTestMembership(t, "owner-1", RoleOwner),
+		newTestMembership(t, "owner-2", RoleOwner),
+		newTestMembership(t, "user-123", RoleMember),
+	}
+
+	assert.Equal(t, 2, CountOwners(members))
+}
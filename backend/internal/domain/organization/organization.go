@@ -0,0 +1,100 @@
+package organization
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// maxNameLength is the longest allowed organization name, in characters
+const maxNameLength = 100
+
+// Organization is a customer organization sharing the deployment with
+// others. Its members and invitations are kept apart from it (see Membership
+// and Invitation), so that an organization with many members is not loaded
+// whole for every request.
+type Organization struct {
+	id        OrganizationID
+	name      string
+	createdBy user.UserID
+	createdAt time.Time
+	events    []shared.DomainEvent
+}
+
+// NewOrganization creates an organization. The caller makes createdBy its
+// first owner with NewMembership.
+func NewOrganization(name string, createdBy user.UserID) (*Organization, error) {
+	if createdBy.IsEmpty() {
+		return nil, shared.ErrEmptyUserID
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxNameLength {
+		return nil, shared.ErrInvalidOrganizationName
+	}
+
+	id, err := GenerateOrganizationID()
+	if err != nil {
+		return nil, err
+	}
+
+	org := &Organization{
+		id:        id,
+		name:      name,
+		createdBy: createdBy,
+		createdAt: time.Now(),
+		events:    make([]shared.DomainEvent, 0),
+	}
+	org.addEvent(NewOrganizationCreatedEvent(id.Value(), name, createdBy.Value()))
+
+	return org, nil
+}
+
+// ReconstructOrganization reconstructs an Organization from persistence (without domain events)
+func ReconstructOrganization(id OrganizationID, name string, createdBy user.UserID, createdAt time.Time) *Organization {
+	return &Organization{
+		id:        id,
+		name:      name,
+		createdBy: createdBy,
+		createdAt: createdAt,
+		events:    make([]shared.DomainEvent, 0),
+	}
+}
+
+// ID returns the organization's ID
+func (o *Organization) ID() OrganizationID {
+	return o.id
+}
+
+// Name returns the organization's display name
+func (o *Organization) Name() string {
+	return o.name
+}
+
+// CreatedBy returns the ID of the user who created the organization
+func (o *Organization) CreatedBy() user.UserID {
+	return o.createdBy
+}
+
+// CreatedAt returns when the organization was created
+func (o *Organization) CreatedAt() time.Time {
+	return o.createdAt
+}
+
+// DomainEvents returns all domain events
+func (o *Organization) DomainEvents() []shared.DomainEvent {
+	return o.events
+}
+
+// ClearDomainEvents clears all domain events
+func (o *Organization) ClearDomainEvents() {
+	o.events = make([]shared.DomainEvent, 0)
+}
+
+// addEvent adds a domain event
+func (o *Organization) addEvent(event shared.DomainEvent) {
+	o.events = append(o.events, event)
+}
@@ -0,0 +1,54 @@
+package organization
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+// OrganizationID identifies an organization
+type OrganizationID struct {
+	value string
+}
+
+// NewOrganizationID creates a OrganizationID from an existing value with validation
+func NewOrganizationID(id string) (OrganizationID, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return OrganizationID{}, shared.ErrOrganizationNotFound
+	}
+
+	return OrganizationID{value: id}, nil
+}
+
+// GenerateOrganizationID creates a new random OrganizationID
+func GenerateOrganizationID() (OrganizationID, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return OrganizationID{}, err
+	}
+
+	return OrganizationID{value: hex.EncodeToString(bytes)}, nil
+}
+
+// Value returns the string value of the OrganizationID
+func (i OrganizationID) Value() string {
+	return i.value
+}
+
+// String implements the Stringer interface
+func (i OrganizationID) String() string {
+	return i.value
+}
+
+// Equals compares two OrganizationIDs for equality
+func (i OrganizationID) Equals(other OrganizationID) bool {
+	return i.value == other.value
+}
+
+// IsEmpty returns true if the OrganizationID is empty
+func (i OrganizationID) IsEmpty() bool {
+	return i.value == ""
+}
@@ -0,0 +1,66 @@
+package organization
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+func TestNewOrganization_Success(t *testing.T) {
+	userID, _ := user.NewUserID("user-123")
+
+	org, err := NewOrganization("  Acme Corp ", userID)
+
+	require.NoError(t, err)
+	assert.False(t, org.ID().IsEmpty())
+	assert.Equal(t, "Acme Corp", org.Name())
+	assert.True(t, org.CreatedBy().Equals(userID))
+
+	require.Len(t, org.DomainEvents(), 1)
+	created, ok := org.DomainEvents()[0].(OrganizationCreatedEvent)
+	require.True(t, ok)
+	assert.Equal(t, "user-123", created.AggregateID())
+	assert.Equal(t, org.ID().Value(), created.OrganizationID)
+}
+
+func TestNewOrganization_Validation(t *testing.T) {
+	userID, _ := user.NewUserID("user-123")
+
+	tests := []struct {
+		name      string
+		orgName   string
+		createdBy user.UserID
+		wantErr   error
+	}{
+		{"empty name", "   ", userID, shared.ErrInvalidOrganizationName},
+		{"long name", strings.Repeat("a", 101), userID, shared.ErrInvalidOrganizationName},
+		{"no creator", "Acme", user.UserID{}, shared.ErrEmptyUserID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			org, err := NewOrganization(tt.orgName, tt.createdBy)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, org)
+		})
+	}
+}
+
+func TestParseRole(t *testing.T) {
+	role, err := ParseRole("admin")
+	require.NoError(t, err)
+	assert.Equal(t, RoleAdmin, role)
+
+	_, err = ParseRole("superuser")
+	assert.ErrorIs(t, err, shared.ErrInvalidOrganizationRole)
+
+	assert.True(t, RoleOwner.CanAssign(RoleOwner))
+	assert.True(t, RoleAdmin.CanAssign(RoleAdmin))
+	assert.False(t, RoleAdmin.CanAssign(RoleOwner))
+	assert.False(t, RoleMember.CanManageMembers())
+}
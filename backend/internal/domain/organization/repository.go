@@ -0,0 +1,60 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// Repository defines the interface for organization persistence
+type Repository interface {
+	// Save persists an organization
+	Save(ctx context.Context, org *Organization) error
+
+	// FindByID retrieves an organization by its ID, or shared.ErrOrganizationNotFound
+	FindByID(ctx context.Context, id OrganizationID) (*Organization, error)
+
+	// Delete removes an organization
+	Delete(ctx context.Context, id OrganizationID) error
+}
+
+// MembershipRepository defines the interface for membership persistence.
+// Every query is scoped to one organization or to one user, so that one
+// organization's members are never listed for another.
+type MembershipRepository interface {
+	// Save persists a membership
+	Save(ctx context.Context, membership *Membership) error
+
+	// Find retrieves a user's membership of an organization, or shared.ErrNotOrganizationMember
+	Find(ctx context.Context, orgID OrganizationID, userID user.UserID) (*Membership, error)
+
+	// FindByOrganization retrieves the members of an organization, longest-standing first
+	FindByOrganization(ctx context.Context, orgID OrganizationID) ([]*Membership, error)
+
+	// FindByUserID retrieves the memberships of a user, oldest first
+	FindByUserID(ctx context.Context, userID user.UserID) ([]*Membership, error)
+
+	// Delete removes a user's membership of an organization
+	Delete(ctx context.Context, orgID OrganizationID, userID user.UserID) error
+
+	// DeleteByUserID removes all memberships of a user
+	DeleteByUserID(ctx context.Context, userID user.UserID) error
+}
+
+// InvitationRepository defines the interface for invitation persistence
+type InvitationRepository interface {
+	// Save persists an invitation
+	Save(ctx context.Context, invitation *Invitation) error
+
+	// FindByID retrieves one of an organization's invitations, or shared.ErrInvitationNotFound
+	FindByID(ctx context.Context, orgID OrganizationID, id InvitationID) (*Invitation, error)
+
+	// FindByHash retrieves an invitation by the hash of its token, or shared.ErrInvitationNotFound
+	FindByHash(ctx context.Context, hash string) (*Invitation, error)
+
+	// FindByOrganization retrieves the invitations of an organization, newest first
+	FindByOrganization(ctx context.Context, orgID OrganizationID) ([]*Invitation, error)
+
+	// Delete removes an invitation
+	Delete(ctx context.Context, id InvitationID) error
+}
@@ -0,0 +1,40 @@
+package organization
+
+import "github.com/yuki5155/go-google-auth/internal/domain/shared"
+
+// Role is the permission level a member holds within one organization
+type Role string
+
+// Role values
+const (
+	RoleOwner  Role = "owner"
+	RoleAdmin  Role = "admin"
+	RoleMember Role = "member"
+)
+
+// ParseRole converts a persisted or requested string into a Role
+func ParseRole(value string) (Role, error) {
+	switch Role(value) {
+	case RoleOwner, RoleAdmin, RoleMember:
+		return Role(value), nil
+	default:
+		return "", shared.ErrInvalidOrganizationRole
+	}
+}
+
+// String implements the Stringer interface
+func (r Role) String() string {
+	return string(r)
+}
+
+// CanManageMembers reports whether the role may invite members, change their
+// roles and remove them
+func (r Role) CanManageMembers() bool {
+	return r == RoleOwner || r == RoleAdmin
+}
+
+// CanAssign reports whether a member with the role may manage members who
+// hold role, or give it to them; only owners may manage owners
+func (r Role) CanAssign(role Role) bool {
+	return r == RoleOwner || (r == RoleAdmin && role != RoleOwner)
+}
@@ -0,0 +1,298 @@
+package serviceclient
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"net"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+// AuthMethod is how a client proves its identity at the token endpoint
+type AuthMethod string
+
+const (
+	// AuthMethodClientSecret authenticates with a shared secret, sent with
+	// HTTP Basic authentication or in the request body
+	AuthMethodClientSecret AuthMethod = "client_secret"
+
+	// AuthMethodPrivateKeyJWT authenticates with a JWT signed by the client's
+	// private key (RFC 7523)
+	AuthMethodPrivateKeyJWT AuthMethod = "private_key_jwt"
+
+	// AuthMethodNone is used by public clients, such as single-page and
+	// native apps, which cannot keep a secret and rely on PKCE instead
+	AuthMethodNone AuthMethod = "none"
+)
+
+var (
+	// clientIDPattern limits client IDs to characters that need no escaping
+	// in URLs, logs or HTTP Basic credentials
+	clientIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+	// scopePattern is the scope-token syntax of RFC 6749, section 3.3
+	scopePattern = regexp.MustCompile(`^[\x21\x23-\x5B\x5D-\x7E]+$`)
+)
+
+// Client is an application registered to use the OAuth endpoints: a backend
+// service calling the API on its own behalf, or a relying party signing users
+// in with OpenID Connect, which registers the redirect URIs it may use.
+// Only a SHA-256 hash of a client secret is kept; private_key_jwt clients
+// register a public key instead.
+type Client struct {
+	id           string
+	name         string
+	authMethod   AuthMethod
+	secretHash   string
+	publicKey    string
+	scopes       []string
+	redirectURIs []string
+
+	// exchangeAudiences are the services the client may exchange users'
+	// tokens for tokens to call (RFC 8693)
+	exchangeAudiences []string
+}
+
+// NewSecretClient creates a client that authenticates with a secret, given
+// as the hex SHA-256 hash under which it is stored
+func NewSecretClient(id, name, secretHash string, scopes []string, redirectURIs ...string) (*Client, error) {
+	secretHash = strings.ToLower(strings.TrimSpace(secretHash))
+	if decoded, err := hex.DecodeString(secretHash); err != nil || len(decoded) != sha256.Size {
+		return nil, shared.ErrInvalidServiceClient
+	}
+
+	return newClient(id, name, AuthMethodClientSecret, secretHash, "", scopes, redirectURIs)
+}
+
+// NewPrivateKeyJWTClient creates a client that authenticates with JWTs
+// signed by the private key belonging to publicKey, a PEM-encoded public key
+func NewPrivateKeyJWTClient(id, name, publicKey string, scopes []string, redirectURIs ...string) (*Client, error) {
+	publicKey = strings.TrimSpace(publicKey)
+	if publicKey == "" {
+		return nil, shared.ErrInvalidServiceClient
+	}
+
+	return newClient(id, name, AuthMethodPrivateKeyJWT, "", publicKey, scopes, redirectURIs)
+}
+
+// NewPublicClient creates a client without credentials. Public clients can
+// only sign users in, so they must register at least one redirect URI.
+func NewPublicClient(id, name string, scopes []string, redirectURIs ...string) (*Client, error) {
+	if len(redirectURIs) == 0 {
+		return nil, shared.ErrInvalidServiceClient
+	}
+
+	return newClient(id, name, AuthMethodNone, "", "", scopes, redirectURIs)
+}
+
+// newClient validates the fields common to all clients
+func newClient(id, name string, authMethod AuthMethod, secretHash, publicKey string, scopes, redirectURIs []string) (*Client, error) {
+	if !clientIDPattern.MatchString(id) {
+		return nil, shared.ErrInvalidServiceClient
+	}
+	if len(scopes) == 0 {
+		return nil, shared.ErrInvalidServiceClient
+	}
+	for _, scope := range scopes {
+		if !IsValidScope(scope) {
+			return nil, shared.ErrInvalidServiceClient
+		}
+	}
+	for _, uri := range redirectURIs {
+		if !IsValidRedirectURI(uri) {
+			return nil, shared.ErrInvalidRedirectURI
+		}
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = id
+	}
+
+	return &Client{
+		id:         id,
+		name:       name,
+		authMethod: authMethod,
+		secretHash: secretHash,
+		publicKey:  publicKey,
+		scopes:     normalizeScopes(scopes),
+		// Redirect URIs are compared exactly, so their order is kept
+		redirectURIs: slices.Compact(slices.Clone(redirectURIs)),
+	}, nil
+}
+
+// GenerateClientID returns a random client ID for a dynamically registered client
+func GenerateClientID() (string, error) {
+	return randomString(16)
+}
+
+// GenerateSecret returns a random client secret
+func GenerateSecret() (string, error) {
+	return randomString(32)
+}
+
+// randomString returns size random bytes, base64url-encoded
+func randomString(size int) (string, error) {
+	bytes := make([]byte, size)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+// HashSecret returns the hex SHA-256 hash under which a client secret is stored
+func HashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsValidScope reports whether scope has the RFC 6749 scope-token syntax
+func IsValidScope(scope string) bool {
+	return scopePattern.MatchString(scope)
+}
+
+// IsValidRedirectURI reports whether uri may be registered as a redirect URI:
+// an absolute URL without a fragment that uses https, or plain http on a
+// loopback address for apps in development and native apps (RFC 8252)
+func IsValidRedirectURI(uri string) bool {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Host == "" || strings.Contains(uri, "#") {
+		return false
+	}
+
+	switch parsed.Scheme {
+	case "https":
+		return true
+	case "http":
+		return isLoopback(parsed.Hostname())
+	default:
+		return false
+	}
+}
+
+// isLoopback reports whether host names the local machine
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// ID returns the client ID
+func (c *Client) ID() string {
+	return c.id
+}
+
+// Name returns the client's display name
+func (c *Client) Name() string {
+	return c.name
+}
+
+// AuthMethod returns how the client authenticates
+func (c *Client) AuthMethod() AuthMethod {
+	return c.authMethod
+}
+
+// PublicKey returns the PEM-encoded public key of a private_key_jwt client
+func (c *Client) PublicKey() string {
+	return c.publicKey
+}
+
+// Scopes returns the scopes the client may request
+func (c *Client) Scopes() []string {
+	return slices.Clone(c.scopes)
+}
+
+// RedirectURIs returns the redirect URIs registered for the authorization code flow
+func (c *Client) RedirectURIs() []string {
+	return slices.Clone(c.redirectURIs)
+}
+
+// AllowsRedirectURI reports whether uri exactly matches a registered redirect URI
+func (c *Client) AllowsRedirectURI(uri string) bool {
+	return slices.Contains(c.redirectURIs, uri)
+}
+
+// AllowTokenExchange lets the client exchange users' tokens for tokens meant
+// for the given audiences. Public clients cannot exchange tokens.
+func (c *Client) AllowTokenExchange(audiences []string) error {
+	if c.IsPublic() {
+		return shared.ErrInvalidServiceClient
+	}
+	for _, audience := range audiences {
+		// Audiences are service names or URLs, so they follow the scope syntax
+		if !IsValidScope(audience) {
+			return shared.ErrInvalidServiceClient
+		}
+	}
+
+	c.exchangeAudiences = normalizeScopes(audiences)
+	return nil
+}
+
+// ExchangeAudiences returns the audiences the client may exchange tokens for
+func (c *Client) ExchangeAudiences() []string {
+	return slices.Clone(c.exchangeAudiences)
+}
+
+// CanExchangeTokenFor reports whether the client may exchange users' tokens
+// for tokens meant for audience
+func (c *Client) CanExchangeTokenFor(audience string) bool {
+	return slices.Contains(c.exchangeAudiences, audience)
+}
+
+// IsPublic reports whether the client has no credentials
+func (c *Client) IsPublic() bool {
+	return c.authMethod == AuthMethodNone
+}
+
+// CanUseClientCredentials reports whether the client may get tokens for
+// itself; public clients cannot, as anyone could claim to be them
+func (c *Client) CanUseClientCredentials() bool {
+	return !c.IsPublic()
+}
+
+// CanUseAuthorizationCode reports whether the client may sign users in,
+// which needs a redirect URI to send them back to
+func (c *Client) CanUseAuthorizationCode() bool {
+	return len(c.redirectURIs) > 0
+}
+
+// VerifySecret reports whether secret is the client's secret
+func (c *Client) VerifySecret(secret string) bool {
+	if c.authMethod != AuthMethodClientSecret || secret == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(HashSecret(secret)), []byte(c.secretHash)) == 1
+}
+
+// GrantScopes returns the scopes to grant for a request of requested scopes.
+// A request without scopes gets all of the client's scopes; a request for a
+// scope the client may not use fails with shared.ErrInvalidScope.
+func (c *Client) GrantScopes(requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		return c.Scopes(), nil
+	}
+
+	for _, scope := range requested {
+		if !slices.Contains(c.scopes, scope) {
+			return nil, shared.ErrInvalidScope
+		}
+	}
+	return normalizeScopes(requested), nil
+}
+
+// normalizeScopes sorts scopes and removes duplicates
+func normalizeScopes(scopes []string) []string {
+	normalized := slices.Clone(scopes)
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
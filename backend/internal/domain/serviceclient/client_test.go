@@ -0,0 +1,176 @@
+package serviceclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+const testPublicKey = "-----BEGIN PUBLIC KEY-----\nMFkw...\n-----END PUBLIC KEY-----"
+
+func TestNewSecretClient_Success(t *testing.T) {
+	client, err := NewSecretClient("billing-service", "", HashSecret("s3cret"), []string{"users:read", "invoices:write", "users:read"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "billing-service", client.ID())
+	assert.Equal(t, "billing-service", client.Name())
+	assert.Equal(t, AuthMethodClientSecret, client.AuthMethod())
+	assert.Equal(t, []string{"invoices:write", "users:read"}, client.Scopes())
+	assert.True(t, client.VerifySecret("s3cret"))
+	assert.False(t, client.VerifySecret("wrong"))
+	assert.False(t, client.VerifySecret(""))
+}
+
+func TestNewPrivateKeyJWTClient_Success(t *testing.T) {
+	client, err := NewPrivateKeyJWTClient("reports", "Reports", testPublicKey, []string{"users:read"})
+
+	require.NoError(t, err)
+	assert.Equal(t, AuthMethodPrivateKeyJWT, client.AuthMethod())
+	assert.Equal(t, testPublicKey, client.PublicKey())
+	// Key-based clients have no secret to guess
+	assert.False(t, client.VerifySecret(""))
+	assert.False(t, client.VerifySecret("anything"))
+}
+
+func TestNewClient_Validation(t *testing.T) {
+	tests := []struct {
+		name   string
+		create func() (*Client, error)
+	}{
+		{"invalid client ID", func() (*Client, error) {
+			return NewSecretClient("billing service", "", HashSecret("s3cret"), []string{"users:read"})
+		}},
+		{"secret hash is not SHA-256", func() (*Client, error) {
+			return NewSecretClient("billing", "", "s3cret", []string{"users:read"})
+		}},
+		{"missing public key", func() (*Client, error) {
+			return NewPrivateKeyJWTClient("reports", "", " ", []string{"users:read"})
+		}},
+		{"no scopes", func() (*Client, error) {
+			return NewSecretClient("billing", "", HashSecret("s3cret"), nil)
+		}},
+		{"invalid scope", func() (*Client, error) {
+			return NewSecretClient("billing", "", HashSecret("s3cret"), []string{"users read"})
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := tt.create()
+
+			assert.Nil(t, client)
+			assert.Equal(t, shared.ErrInvalidServiceClient, err)
+		})
+	}
+}
+
+func TestClient_GrantScopes(t *testing.T) {
+	client, err := NewSecretClient("billing", "", HashSecret("s3cret"), []string{"users:read", "invoices:write"})
+	require.NoError(t, err)
+
+	granted, err := client.GrantScopes(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"invoices:write", "users:read"}, granted)
+
+	granted, err = client.GrantScopes([]string{"users:read", "users:read"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"users:read"}, granted)
+
+	_, err = client.GrantScopes([]string{"users:read", "users:write"})
+	assert.Equal(t, shared.ErrInvalidScope, err)
+}
+
+func TestNewPublicClient(t *testing.T) {
+	client, err := NewPublicClient("spa", "Single-page app", []string{"openid"}, "https://app.example.com/callback")
+
+	require.NoError(t, err)
+	assert.Equal(t, AuthMethodNone, client.AuthMethod())
+	assert.True(t, client.IsPublic())
+	assert.True(t, client.CanUseAuthorizationCode())
+	assert.False(t, client.CanUseClientCredentials())
+	assert.False(t, client.VerifySecret(""))
+
+	_, err = NewPublicClient("spa", "", []string{"openid"})
+	assert.Equal(t, shared.ErrInvalidServiceClient, err)
+}
+
+func TestClient_RedirectURIs(t *testing.T) {
+	client, err := NewSecretClient("wiki", "Wiki", HashSecret("s3cret"), []string{"openid", "profile"},
+		"https://wiki.example.com/callback", "http://localhost:3000/callback")
+	require.NoError(t, err)
+
+	assert.False(t, client.IsPublic())
+	assert.True(t, client.CanUseAuthorizationCode())
+	assert.True(t, client.CanUseClientCredentials())
+	assert.Equal(t, []string{"https://wiki.example.com/callback", "http://localhost:3000/callback"}, client.RedirectURIs())
+	assert.True(t, client.AllowsRedirectURI("https://wiki.example.com/callback"))
+	// Redirect URIs must match exactly
+	assert.False(t, client.AllowsRedirectURI("https://wiki.example.com/callback/"))
+	assert.False(t, client.AllowsRedirectURI("https://wiki.example.com/callback?next=/admin"))
+
+	serviceOnly, err := NewSecretClient("billing", "", HashSecret("s3cret"), []string{"users:read"})
+	require.NoError(t, err)
+	assert.False(t, serviceOnly.CanUseAuthorizationCode())
+}
+
+func TestClient_AllowTokenExchange(t *testing.T) {
+	client, err := NewSecretClient("orders", "Orders", HashSecret("s3cret"), []string{"inventory:read"})
+	require.NoError(t, err)
+	assert.False(t, client.CanExchangeTokenFor("inventory"))
+
+	require.NoError(t, client.AllowTokenExchange([]string{"inventory", "https://billing.example.com", "inventory"}))
+	assert.Equal(t, []string{"https://billing.example.com", "inventory"}, client.ExchangeAudiences())
+	assert.True(t, client.CanExchangeTokenFor("inventory"))
+	assert.False(t, client.CanExchangeTokenFor("payroll"))
+
+	assert.Equal(t, shared.ErrInvalidServiceClient, client.AllowTokenExchange([]string{"two words"}))
+
+	public, err := NewPublicClient("spa", "", []string{"openid"}, "https://app.example.com/callback")
+	require.NoError(t, err)
+	assert.Equal(t, shared.ErrInvalidServiceClient, public.AllowTokenExchange([]string{"inventory"}))
+}
+
+func TestIsValidRedirectURI(t *testing.T) {
+	tests := []struct {
+		uri   string
+		valid bool
+	}{
+		{"https://app.example.com/callback", true},
+		{"https://app.example.com/callback?source=oidc", true},
+		{"http://localhost:3000/callback", true},
+		{"http://127.0.0.1:49152/callback", true},
+		{"http://[::1]/callback", true},
+		{"http://app.example.com/callback", false},
+		{"https://app.example.com/callback#token", false},
+		{"/callback", false},
+		{"javascript:alert(1)", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			assert.Equal(t, tt.valid, IsValidRedirectURI(tt.uri))
+		})
+	}
+}
+
+func TestNewClient_InvalidRedirectURI(t *testing.T) {
+	client, err := NewSecretClient("wiki", "", HashSecret("s3cret"), []string{"openid"}, "http://wiki.example.com/callback")
+
+	assert.Nil(t, client)
+	assert.Equal(t, shared.ErrInvalidRedirectURI, err)
+}
+
+func TestGenerateClientID(t *testing.T) {
+	first, err := GenerateClientID()
+	require.NoError(t, err)
+	second, err := GenerateClientID()
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first, second)
+	// Generated IDs must be usable as client IDs
+	_, err = NewPublicClient(first, "", []string{"openid"}, "https://app.example.com/callback")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,12 @@
+package serviceclient
+
+import "context"
+
+// Repository defines the interface for service client persistence
+type Repository interface {
+	// Save registers or replaces a client
+	Save(ctx context.Context, client *Client) error
+
+	// FindByID retrieves a client by its client ID, or shared.ErrServiceClientNotFound
+	FindByID(ctx context.Context, id string) (*Client, error)
+}
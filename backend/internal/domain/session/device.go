@@ -0,0 +1,82 @@
+package session
+
+// Reasons a login is considered unfamiliar
+const (
+	ReasonNewDevice   = "new_device"   // browser and OS not seen before
+	ReasonNewLocation = "new_location" // network and country not seen before
+)
+
+// Device describes where a session was started from. It is a coarse
+// fingerprint: the browser family, the client's network prefix and, when
+// known, its country.
+type Device struct {
+	userAgentFamily string
+	network         string
+	country         string
+}
+
+// NewDevice creates a Device; any field may be empty when unknown
+func NewDevice(userAgentFamily, network, country string) Device {
+	return Device{
+		userAgentFamily: userAgentFamily,
+		network:         network,
+		country:         country,
+	}
+}
+
+// UserAgentFamily returns the browser and OS, e.g. "Chrome on Windows"
+func (d Device) UserAgentFamily() string {
+	return d.userAgentFamily
+}
+
+// Network returns the client's network prefix, e.g. "203.0.113.0/24"
+func (d Device) Network() string {
+	return d.network
+}
+
+// Country returns the ISO country code of the network (empty if unknown)
+func (d Device) Country() string {
+	return d.country
+}
+
+// IsZero returns true if nothing is known about the device
+func (d Device) IsZero() bool {
+	return d == Device{}
+}
+
+// UnfamiliarDeviceReasons compares a login's device against the devices of
+// the user's earlier sessions and returns why it looks unfamiliar. A login
+// is from a known location if its network or its country was seen before.
+// Without earlier fingerprinted sessions there is nothing to compare, so
+// a user's first login is never reported.
+func UnfamiliarDeviceReasons(history []*Session, device Device) []string {
+	knownDevice, knownLocation, compared := false, false, false
+	for _, s := range history {
+		previous := s.Device()
+		if previous.IsZero() {
+			continue
+		}
+		compared = true
+
+		if previous.userAgentFamily == device.userAgentFamily {
+			knownDevice = true
+		}
+		if previous.network == device.network ||
+			(device.country != "" && previous.country == device.country) {
+			knownLocation = true
+		}
+	}
+
+	if !compared || device.IsZero() {
+		return nil
+	}
+
+	var reasons []string
+	if !knownDevice {
+		reasons = append(reasons, ReasonNewDevice)
+	}
+	if !knownLocation {
+		reasons = append(reasons, ReasonNewLocation)
+	}
+	return reasons
+}
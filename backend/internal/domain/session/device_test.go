@@ -0,0 +1,53 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+func newDeviceSession(t *testing.T, device Device) *Session {
+	t.Helper()
+
+	userID, _ := user.NewUserID("user-123")
+	s, _ := NewSession(userID, time.Now().Add(time.Hour))
+	s.RecordDevice(device)
+	return s
+}
+
+func TestDevice_IsZero(t *testing.T) {
+	assert.True(t, Device{}.IsZero())
+	assert.False(t, NewDevice("Chrome on Windows", "", "").IsZero())
+}
+
+func TestUnfamiliarDeviceReasons(t *testing.T) {
+	laptop := NewDevice("Chrome on Windows", "203.0.113.0/24", "NL")
+	history := []*Session{
+		newDeviceSession(t, laptop),
+		newDeviceSession(t, Device{}), // sessions from before devices were recorded
+	}
+
+	tests := []struct {
+		name    string
+		history []*Session
+		device  Device
+		want    []string
+	}{
+		{"known device", history, laptop, nil},
+		{"same network, new browser", history, NewDevice("Firefox on Linux", "203.0.113.0/24", "NL"), []string{ReasonNewDevice}},
+		{"same country, new network", history, NewDevice("Chrome on Windows", "198.51.100.0/24", "NL"), nil},
+		{"new network, unknown country", history, NewDevice("Chrome on Windows", "198.51.100.0/24", ""), []string{ReasonNewLocation}},
+		{"new device and location", history, NewDevice("Safari on iOS", "192.0.2.0/24", "BR"), []string{ReasonNewDevice, ReasonNewLocation}},
+		{"no history", nil, laptop, nil},
+		{"history without devices", []*Session{newDeviceSession(t, Device{})}, laptop, nil},
+		{"unknown device", history, Device{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, UnfamiliarDeviceReasons(tt.history, tt.device))
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package session
+
+import (
+	"context"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// Repository defines the interface for session persistence
+type Repository interface {
+	// Save persists a session
+	Save(ctx context.Context, session *Session) error
+
+	// FindByID retrieves a session by its ID
+	FindByID(ctx context.Context, id SessionID) (*Session, error)
+
+	// FindByUserID retrieves all sessions of a user, newest first
+	FindByUserID(ctx context.Context, userID user.UserID) ([]*Session, error)
+
+	// DeleteByUserID removes all sessions of a user
+	DeleteByUserID(ctx context.Context, userID user.UserID) error
+}
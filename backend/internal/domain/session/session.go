@@ -0,0 +1,154 @@
+package session
+
+import (
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// Session represents a login session, i.e. one refresh token family.
+// Every token issued for a login carries the session ID, so revoking the
+// session invalidates all refresh tokens derived from that login.
+type Session struct {
+	id         SessionID
+	userID     user.UserID
+	createdAt  time.Time
+	lastUsedAt time.Time
+	expiresAt  time.Time
+	revokedAt  time.Time
+	device     Device
+
+	// dpopKey is the JWK thumbprint of the DPoP key the session is bound to
+	dpopKey string
+}
+
+// NewSession starts a new session for a user that expires at the given time
+func NewSession(userID user.UserID, expiresAt time.Time) (*Session, error) {
+	if userID.IsEmpty() {
+		return nil, shared.ErrEmptyUserID
+	}
+
+	id, err := GenerateSessionID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Session{
+		id:         id,
+		userID:     userID,
+		createdAt:  now,
+		lastUsedAt: now,
+		expiresAt:  expiresAt,
+	}, nil
+}
+
+// ReconstructSession reconstructs a Session from persistence
+func ReconstructSession(id SessionID, userID user.UserID, createdAt, lastUsedAt, expiresAt, revokedAt time.Time, device Device, dpopKey string) *Session {
+	return &Session{
+		id:         id,
+		userID:     userID,
+		createdAt:  createdAt,
+		lastUsedAt: lastUsedAt,
+		expiresAt:  expiresAt,
+		revokedAt:  revokedAt,
+		device:     device,
+		dpopKey:    dpopKey,
+	}
+}
+
+// ID returns the session's ID
+func (s *Session) ID() SessionID {
+	return s.id
+}
+
+// UserID returns the ID of the user the session belongs to
+func (s *Session) UserID() user.UserID {
+	return s.userID
+}
+
+// CreatedAt returns when the session was started
+func (s *Session) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+// LastUsedAt returns when the session was last used to refresh tokens
+func (s *Session) LastUsedAt() time.Time {
+	return s.lastUsedAt
+}
+
+// ExpiresAt returns when the session expires
+func (s *Session) ExpiresAt() time.Time {
+	return s.expiresAt
+}
+
+// RevokedAt returns when the session was revoked (zero if not revoked)
+func (s *Session) RevokedAt() time.Time {
+	return s.revokedAt
+}
+
+// Device returns the device the session was started from (zero if not recorded)
+func (s *Session) Device() Device {
+	return s.device
+}
+
+// IsRevoked returns true if the session has been revoked
+func (s *Session) IsRevoked() bool {
+	return !s.revokedAt.IsZero()
+}
+
+// IsActive returns true if the session is neither revoked nor expired at the given time
+func (s *Session) IsActive(now time.Time) bool {
+	return !s.IsRevoked() && now.Before(s.expiresAt)
+}
+
+// EnsureActive returns an error if the session can no longer be used
+func (s *Session) EnsureActive(now time.Time) error {
+	if s.IsRevoked() {
+		return shared.ErrSessionRevoked
+	}
+	if !now.Before(s.expiresAt) {
+		return shared.ErrSessionExpired
+	}
+	return nil
+}
+
+// DPoPKey returns the thumbprint of the DPoP key the session is bound to, or
+// "" when its tokens are bearer tokens
+func (s *Session) DPoPKey() string {
+	return s.dpopKey
+}
+
+// BindDPoPKey binds the session to the DPoP key with the given thumbprint
+// the first time a client proves possession of one. From then on the
+// session's refresh token only works with proofs signed by that key, so a
+// stolen refresh token is useless without the key.
+func (s *Session) BindDPoPKey(thumbprint string) error {
+	if s.dpopKey == "" {
+		s.dpopKey = thumbprint
+		return nil
+	}
+	if thumbprint != s.dpopKey {
+		return shared.ErrDPoPKeyMismatch
+	}
+	return nil
+}
+
+// RecordDevice records the device the session was started from
+func (s *Session) RecordDevice(device Device) {
+	s.device = device
+}
+
+// Touch records that the session was used at the given time
+func (s *Session) Touch(at time.Time) {
+	s.lastUsedAt = at
+}
+
+// Revoke ends the session; revoking an already revoked session is a no-op
+func (s *Session) Revoke(at time.Time) {
+	if s.IsRevoked() {
+		return
+	}
+	s.revokedAt = at
+}
@@ -0,0 +1,54 @@
+package session
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+// SessionID represents a unique identifier for a login session
+type SessionID struct {
+	value string
+}
+
+// NewSessionID creates a SessionID from an existing value with validation
+func NewSessionID(id string) (SessionID, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return SessionID{}, shared.ErrInvalidSessionID
+	}
+
+	return SessionID{value: id}, nil
+}
+
+// GenerateSessionID creates a new random SessionID
+func GenerateSessionID() (SessionID, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return SessionID{}, err
+	}
+
+	return SessionID{value: hex.EncodeToString(bytes)}, nil
+}
+
+// Value returns the string value of the SessionID
+func (s SessionID) Value() string {
+	return s.value
+}
+
+// String implements the Stringer interface
+func (s SessionID) String() string {
+	return s.value
+}
+
+// Equals compares two SessionIDs for equality
+func (s SessionID) Equals(other SessionID) bool {
+	return s.value == other.value
+}
+
+// IsEmpty returns true if the SessionID is empty
+func (s SessionID) IsEmpty() bool {
+	return s.value == ""
+}
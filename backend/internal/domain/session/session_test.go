@@ -0,0 +1,137 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+func TestNewSession_Success(t *testing.T) {
+	userID, _ := user.NewUserID("user-123")
+	expiresAt := time.Now().Add(time.Hour)
+
+	s, err := NewSession(userID, expiresAt)
+
+	require.NoError(t, err)
+	assert.False(t, s.ID().IsEmpty())
+	assert.Equal(t, userID, s.UserID())
+	assert.Equal(t, expiresAt, s.ExpiresAt())
+	assert.Equal(t, s.CreatedAt(), s.LastUsedAt())
+	assert.False(t, s.IsRevoked())
+	assert.True(t, s.IsActive(time.Now()))
+}
+
+func TestNewSession_EmptyUserID(t *testing.T) {
+	s, err := NewSession(user.UserID{}, time.Now().Add(time.Hour))
+
+	assert.Equal(t, shared.ErrEmptyUserID, err)
+	assert.Nil(t, s)
+}
+
+func TestNewSession_UniqueIDs(t *testing.T) {
+	userID, _ := user.NewUserID("user-123")
+
+	s1, _ := NewSession(userID, time.Now().Add(time.Hour))
+	s2, _ := NewSession(userID, time.Now().Add(time.Hour))
+
+	assert.False(t, s1.ID().Equals(s2.ID()))
+}
+
+func TestReconstructSession(t *testing.T) {
+	id, _ := NewSessionID("session-1")
+	userID, _ := user.NewUserID("user-123")
+	createdAt := time.Now().Add(-2 * time.Hour)
+	lastUsedAt := time.Now().Add(-time.Hour)
+	expiresAt := time.Now().Add(time.Hour)
+	revokedAt := time.Now()
+	device := NewDevice("Firefox on Linux", "203.0.113.0/24", "NL")
+
+	s := ReconstructSession(id, userID, createdAt, lastUsedAt, expiresAt, revokedAt, device, "key-thumbprint")
+
+	assert.Equal(t, id, s.ID())
+	assert.Equal(t, userID, s.UserID())
+	assert.Equal(t, createdAt, s.CreatedAt())
+	assert.Equal(t, lastUsedAt, s.LastUsedAt())
+	assert.Equal(t, expiresAt, s.ExpiresAt())
+	assert.Equal(t, revokedAt, s.RevokedAt())
+	assert.Equal(t, device, s.Device())
+	assert.Equal(t, "key-thumbprint", s.DPoPKey())
+	assert.True(t, s.IsRevoked())
+}
+
+func TestSession_BindDPoPKey(t *testing.T) {
+	userID, _ := user.NewUserID("user-123")
+	s, _ := NewSession(userID, time.Now().Add(time.Hour))
+
+	// Bearer refreshes leave the session unbound
+	require.NoError(t, s.BindDPoPKey(""))
+	assert.Empty(t, s.DPoPKey())
+
+	require.NoError(t, s.BindDPoPKey("key-1"))
+	assert.Equal(t, "key-1", s.DPoPKey())
+	assert.NoError(t, s.BindDPoPKey("key-1"))
+
+	// Once bound, other keys and bearer refreshes are refused
+	assert.Equal(t, shared.ErrDPoPKeyMismatch, s.BindDPoPKey("key-2"))
+	assert.Equal(t, shared.ErrDPoPKeyMismatch, s.BindDPoPKey(""))
+	assert.Equal(t, "key-1", s.DPoPKey())
+}
+
+func TestSession_EnsureActive(t *testing.T) {
+	userID, _ := user.NewUserID("user-123")
+	now := time.Now()
+
+	active, _ := NewSession(userID, now.Add(time.Hour))
+	assert.NoError(t, active.EnsureActive(now))
+
+	expired, _ := NewSession(userID, now.Add(-time.Minute))
+	assert.Equal(t, shared.ErrSessionExpired, expired.EnsureActive(now))
+	assert.False(t, expired.IsActive(now))
+
+	revoked, _ := NewSession(userID, now.Add(time.Hour))
+	revoked.Revoke(now)
+	assert.Equal(t, shared.ErrSessionRevoked, revoked.EnsureActive(now))
+	assert.False(t, revoked.IsActive(now))
+}
+
+func TestSession_Revoke_Idempotent(t *testing.T) {
+	userID, _ := user.NewUserID("user-123")
+	s, _ := NewSession(userID, time.Now().Add(time.Hour))
+
+	first := time.Now()
+	s.Revoke(first)
+	s.Revoke(first.Add(time.Minute))
+
+	assert.Equal(t, first, s.RevokedAt())
+}
+
+func TestSession_Touch(t *testing.T) {
+	userID, _ := user.NewUserID("user-123")
+	s, _ := NewSession(userID, time.Now().Add(time.Hour))
+
+	later := time.Now().Add(10 * time.Minute)
+	s.Touch(later)
+
+	assert.Equal(t, later, s.LastUsedAt())
+}
+
+func TestNewSessionID(t *testing.T) {
+	id, err := NewSessionID("  abc123  ")
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", id.Value())
+	assert.Equal(t, "abc123", id.String())
+
+	_, err = NewSessionID("   ")
+	assert.Equal(t, shared.ErrInvalidSessionID, err)
+}
+
+func TestGenerateSessionID(t *testing.T) {
+	id, err := GenerateSessionID()
+
+	require.NoError(t, err)
+	assert.Len(t, id.Value(), 32)
+}
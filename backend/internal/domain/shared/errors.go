@@ -13,13 +13,105 @@ var (
 	// User state errors
 	ErrUserNotFound     = errors.New("user not found")
 	ErrUserAlreadyExists = errors.New("user already exists")
+	ErrGoogleAccountConflict = errors.New("email address belongs to an account that cannot be linked to this Google account")
+
+	// Account status errors
+	ErrAccountSuspended        = errors.New("account is suspended")
+	ErrAccountDeleted          = errors.New("account has been deleted")
+	ErrInvalidStatus           = errors.New("invalid account status")
+	ErrInvalidStatusTransition = errors.New("invalid account status transition")
+	ErrInvalidRole             = errors.New("invalid role")
+
+	// Session errors
+	ErrInvalidSessionID = errors.New("invalid session ID")
+	ErrSessionNotFound  = errors.New("session not found")
+	ErrSessionRevoked   = errors.New("session has been revoked")
+	ErrSessionExpired   = errors.New("session has expired")
+	ErrDPoPKeyMismatch  = errors.New("session is bound to another DPoP key")
 
 	// Authentication errors
 	ErrInvalidToken     = errors.New("invalid token")
 	ErrExpiredToken     = errors.New("token has expired")
 	ErrMissingToken     = errors.New("token not found")
 	ErrUnauthorized     = errors.New("unauthorized access")
+	ErrReauthenticationRequired = errors.New("recent authentication required")
+	ErrMissingCredential        = errors.New("no credential provided")
+	ErrCredentialMismatch       = errors.New("credential belongs to a different user")
 
 	// Profile errors
 	ErrInvalidProfile   = errors.New("invalid profile data")
+	ErrInvalidDisplayName = errors.New("display name must be 1-100 characters")
+	ErrInvalidPictureURL  = errors.New("picture must be an https URL")
+	ErrInvalidLocale      = errors.New("invalid locale")
+	ErrInvalidTimezone    = errors.New("invalid time zone")
+	ErrInvalidPreference  = errors.New("invalid preference")
+
+	// MFA errors
+	ErrMFANotEnabled     = errors.New("multi-factor authentication is not enabled")
+	ErrMFAAlreadyEnabled = errors.New("multi-factor authentication is already enabled")
+	ErrInvalidMFACode    = errors.New("invalid verification code")
+	ErrInvalidMFASecret  = errors.New("invalid MFA secret")
+
+	// Passkey errors
+	ErrPasskeyNotFound          = errors.New("passkey not found")
+	ErrPasskeyAlreadyRegistered = errors.New("passkey is already registered")
+	ErrTooManyPasskeys          = errors.New("too many passkeys registered")
+	ErrInvalidPasskey           = errors.New("invalid passkey")
+	ErrPasskeyCloned            = errors.New("passkey signature counter did not increase")
+	ErrInvalidPasskeyName       = errors.New("passkey name must be 1-64 characters")
+	ErrPasskeyVerification      = errors.New("passkey response could not be verified")
+	ErrPasskeyChallengeInvalid  = errors.New("passkey challenge is unknown or has expired")
+
+	// Email login errors
+	ErrInvalidMagicLink = errors.New("login link is invalid, expired or already used")
+	ErrEmailUnavailable = errors.New("email delivery is not configured")
+
+	// Login alert errors
+	ErrInvalidRevokeLink = errors.New("session revoke link is invalid or expired")
+
+	// Personal access token errors
+	ErrInvalidAPIToken       = errors.New("personal access token is invalid, expired or revoked")
+	ErrAPITokenNotFound      = errors.New("personal access token not found")
+	ErrInvalidAPITokenName   = errors.New("token name must be 1-64 characters")
+	ErrInvalidAPITokenScope  = errors.New("unknown or missing token scope")
+	ErrInvalidAPITokenExpiry = errors.New("token expiry is out of range")
+	ErrTooManyAPITokens      = errors.New("too many personal access tokens")
+
+	// Service client errors
+	ErrServiceClientNotFound = errors.New("service client not found")
+	ErrInvalidServiceClient  = errors.New("service client registration is invalid")
+	ErrInvalidClient         = errors.New("client authentication failed")
+	ErrInvalidScope          = errors.New("requested scope is invalid or not allowed")
+	ErrUnsupportedGrantType  = errors.New("grant type is not supported")
+	ErrUnauthorizedClient    = errors.New("client is not allowed to use this grant type")
+	ErrInvalidRedirectURI    = errors.New("redirect URI is invalid or not registered")
+	ErrInvalidGrant          = errors.New("authorization grant is invalid, expired or already used")
+	ErrInvalidClientMetadata = errors.New("client metadata is invalid")
+	ErrRegistrationDisabled  = errors.New("client registration is disabled")
+	ErrInvalidTarget         = errors.New("requested audience is unknown or not allowed")
+	ErrInvalidSubjectToken   = errors.New("subject token is invalid, expired or revoked")
+	ErrInvalidDPoPProof      = errors.New("DPoP proof is invalid, expired or already used")
+	ErrUseDPoPNonce          = errors.New("DPoP proof must carry a current server nonce")
+
+	// Consent errors
+	ErrConsentNotFound       = errors.New("consent not found")
+	ErrInvalidConsentRequest = errors.New("consent request is invalid or has expired")
+
+	// Impersonation errors
+	ErrAdminRequired           = errors.New("administrator role required")
+	ErrImpersonationNotAllowed = errors.New("this user cannot be impersonated")
+	ErrNotImpersonating        = errors.New("not impersonating a user")
+
+	// Organization errors
+	ErrOrganizationNotFound         = errors.New("organization not found")
+	ErrInvalidOrganizationName      = errors.New("organization name must be 1-100 characters")
+	ErrInvalidOrganizationRole      = errors.New("invalid organization role")
+	ErrNoActiveOrganization         = errors.New("no organization selected")
+	ErrNotOrganizationMember        = errors.New("not a member of this organization")
+	ErrOrganizationPermissionDenied = errors.New("organization role does not allow this")
+	ErrLastOrganizationOwner        = errors.New("an organization must keep at least one owner")
+	ErrAlreadyOrganizationMember    = errors.New("already a member of this organization")
+	ErrInvitationNotFound           = errors.New("invitation not found or expired")
+	ErrInvitationEmailMismatch      = errors.New("invitation was sent to a different email address")
+	ErrTooManyInvitations           = errors.New("too many pending invitations")
 )
@@ -1,11 +1,31 @@
 package user
 
-import "github.com/yuki5155/go-google-auth/internal/domain/shared"
+import (
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
 
 // Event type constants
 const (
-	EventTypeUserRegistered = "user.registered"
-	EventTypeUserLoggedIn   = "user.logged_in"
+	EventTypeUserRegistered  = "user.registered"
+	EventTypeUserLoggedIn    = "user.logged_in"
+	EventTypeUserSuspended   = "user.suspended"
+	EventTypeUserReactivated = "user.reactivated"
+	EventTypeUserDeleted     = "user.deleted"
+
+	EventTypeUserProfileUpdated = "user.profile_updated"
+
+	EventTypePasskeyRegistered = "user.passkey_registered"
+	EventTypePasskeyRemoved    = "user.passkey_removed"
+
+	EventTypeSuspiciousLoginDetected = "user.suspicious_login_detected"
+	EventTypeLoginDisowned           = "user.login_disowned"
+
+	EventTypeImpersonationStarted = "user.impersonation_started"
+	EventTypeImpersonationEnded   = "user.impersonation_ended"
+
+	EventTypeGoogleAccountLinked = "user.google_account_linked"
 )
 
 // UserRegisteredEvent is emitted when a new user is registered
@@ -41,3 +61,189 @@ func NewUserLoggedInEvent(userID, email string) UserLoggedInEvent {
 		Email:           email,
 	}
 }
+
+// UserSuspendedEvent is emitted when a user account is suspended
+type UserSuspendedEvent struct {
+	shared.BaseDomainEvent
+	UserID string
+	Reason string
+}
+
+// NewUserSuspendedEvent creates a new UserSuspendedEvent
+func NewUserSuspendedEvent(userID, reason string) UserSuspendedEvent {
+	return UserSuspendedEvent{
+		BaseDomainEvent: shared.NewBaseDomainEvent(EventTypeUserSuspended, userID),
+		UserID:          userID,
+		Reason:          reason,
+	}
+}
+
+// UserReactivatedEvent is emitted when a suspended user account is reactivated
+type UserReactivatedEvent struct {
+	shared.BaseDomainEvent
+	UserID string
+}
+
+// NewUserReactivatedEvent creates a new UserReactivatedEvent
+func NewUserReactivatedEvent(userID string) UserReactivatedEvent {
+	return UserReactivatedEvent{
+		BaseDomainEvent: shared.NewBaseDomainEvent(EventTypeUserReactivated, userID),
+		UserID:          userID,
+	}
+}
+
+// UserDeletedEvent is emitted when a user account is soft-deleted
+// The account's data is purged once PurgeAt has passed
+type UserDeletedEvent struct {
+	shared.BaseDomainEvent
+	UserID  string
+	Email   string
+	PurgeAt time.Time
+}
+
+// NewUserDeletedEvent creates a new UserDeletedEvent
+func NewUserDeletedEvent(userID, email string, purgeAt time.Time) UserDeletedEvent {
+	return UserDeletedEvent{
+		BaseDomainEvent: shared.NewBaseDomainEvent(EventTypeUserDeleted, userID),
+		UserID:          userID,
+		Email:           email,
+		PurgeAt:         purgeAt,
+	}
+}
+
+// UserProfileUpdatedEvent is emitted when a user edits their own profile or preferences
+type UserProfileUpdatedEvent struct {
+	shared.BaseDomainEvent
+	UserID string
+}
+
+// NewUserProfileUpdatedEvent creates a new UserProfileUpdatedEvent
+func NewUserProfileUpdatedEvent(userID string) UserProfileUpdatedEvent {
+	return UserProfileUpdatedEvent{
+		BaseDomainEvent: shared.NewBaseDomainEvent(EventTypeUserProfileUpdated, userID),
+		UserID:          userID,
+	}
+}
+
+// PasskeyRegisteredEvent is emitted when a user registers a passkey
+type PasskeyRegisteredEvent struct {
+	shared.BaseDomainEvent
+	UserID    string
+	PasskeyID string
+}
+
+// NewPasskeyRegisteredEvent creates a new PasskeyRegisteredEvent
+func NewPasskeyRegisteredEvent(userID, passkeyID string) PasskeyRegisteredEvent {
+	return PasskeyRegisteredEvent{
+		BaseDomainEvent: shared.NewBaseDomainEvent(EventTypePasskeyRegistered, userID),
+		UserID:          userID,
+		PasskeyID:       passkeyID,
+	}
+}
+
+// PasskeyRemovedEvent is emitted when a user removes a passkey
+type PasskeyRemovedEvent struct {
+	shared.BaseDomainEvent
+	UserID    string
+	PasskeyID string
+}
+
+// NewPasskeyRemovedEvent creates a new PasskeyRemovedEvent
+func NewPasskeyRemovedEvent(userID, passkeyID string) PasskeyRemovedEvent {
+	return PasskeyRemovedEvent{
+		BaseDomainEvent: shared.NewBaseDomainEvent(EventTypePasskeyRemoved, userID),
+		UserID:          userID,
+		PasskeyID:       passkeyID,
+	}
+}
+
+// SuspiciousLoginDetectedEvent is emitted when a login comes from an
+// unfamiliar device or location
+type SuspiciousLoginDetectedEvent struct {
+	shared.BaseDomainEvent
+	UserID    string
+	SessionID string
+	Reasons   []string
+}
+
+// NewSuspiciousLoginDetectedEvent creates a new SuspiciousLoginDetectedEvent
+func NewSuspiciousLoginDetectedEvent(userID, sessionID string, reasons []string) SuspiciousLoginDetectedEvent {
+	return SuspiciousLoginDetectedEvent{
+		BaseDomainEvent: shared.NewBaseDomainEvent(EventTypeSuspiciousLoginDetected, userID),
+		UserID:          userID,
+		SessionID:       sessionID,
+		Reasons:         reasons,
+	}
+}
+
+// LoginDisownedEvent is emitted when a user reports that they did not make
+// a login, revoking its session
+type LoginDisownedEvent struct {
+	shared.BaseDomainEvent
+	UserID    string
+	SessionID string
+}
+
+// NewLoginDisownedEvent creates a new LoginDisownedEvent
+func NewLoginDisownedEvent(userID, sessionID string) LoginDisownedEvent {
+	return LoginDisownedEvent{
+		BaseDomainEvent: shared.NewBaseDomainEvent(EventTypeLoginDisowned, userID),
+		UserID:          userID,
+		SessionID:       sessionID,
+	}
+}
+
+// ImpersonationStartedEvent is emitted when an administrator starts acting
+// as the user
+type ImpersonationStartedEvent struct {
+	shared.BaseDomainEvent
+	UserID    string
+	AdminID   string
+	Reason    string
+	ExpiresAt time.Time
+}
+
+// NewImpersonationStartedEvent creates a new ImpersonationStartedEvent
+func NewImpersonationStartedEvent(userID, adminID, reason string, expiresAt time.Time) ImpersonationStartedEvent {
+	return ImpersonationStartedEvent{
+		BaseDomainEvent: shared.NewBaseDomainEvent(EventTypeImpersonationStarted, userID),
+		UserID:          userID,
+		AdminID:         adminID,
+		Reason:          reason,
+		ExpiresAt:       expiresAt,
+	}
+}
+
+// ImpersonationEndedEvent is emitted when an administrator stops acting as
+// the user
+type ImpersonationEndedEvent struct {
+	shared.BaseDomainEvent
+	UserID  string
+	AdminID string
+}
+
+// NewImpersonationEndedEvent creates a new ImpersonationEndedEvent
+func NewImpersonationEndedEvent(userID, adminID string) ImpersonationEndedEvent {
+	return ImpersonationEndedEvent{
+		BaseDomainEvent: shared.NewBaseDomainEvent(EventTypeImpersonationEnded, userID),
+		UserID:          userID,
+		AdminID:         adminID,
+	}
+}
+
+// GoogleAccountLinkedEvent is emitted when a user created by email login
+// signs in with Google for the first time
+type GoogleAccountLinkedEvent struct {
+	shared.BaseDomainEvent
+	UserID   string
+	GoogleID string
+}
+
+// NewGoogleAccountLinkedEvent creates a new GoogleAccountLinkedEvent
+func NewGoogleAccountLinkedEvent(userID, googleID string) GoogleAccountLinkedEvent {
+	return GoogleAccountLinkedEvent{
+		BaseDomainEvent: shared.NewBaseDomainEvent(EventTypeGoogleAccountLinked, userID),
+		UserID:          userID,
+		GoogleID:        googleID,
+	}
+}
@@ -0,0 +1,74 @@
+package user
+
+import "time"
+
+// MaxRecentLogins is the maximum number of login timestamps kept in a LoginHistory
+const MaxRecentLogins = 10
+
+// LoginHistory represents when and how often a user has signed in
+type LoginHistory struct {
+	lastLoginAt  time.Time
+	loginCount   int
+	recentLogins []time.Time // newest first, at most MaxRecentLogins entries
+}
+
+// NewLoginHistory creates a LoginHistory from persisted values
+// Recent logins are expected newest first and are truncated to MaxRecentLogins
+func NewLoginHistory(lastLoginAt time.Time, loginCount int, recentLogins []time.Time) LoginHistory {
+	if loginCount < 0 {
+		loginCount = 0
+	}
+
+	if len(recentLogins) > MaxRecentLogins {
+		recentLogins = recentLogins[:MaxRecentLogins]
+	}
+
+	recent := make([]time.Time, len(recentLogins))
+	copy(recent, recentLogins)
+
+	return LoginHistory{
+		lastLoginAt:  lastLoginAt,
+		loginCount:   loginCount,
+		recentLogins: recent,
+	}
+}
+
+// LastLoginAt returns when the user last signed in (zero if never)
+func (h LoginHistory) LastLoginAt() time.Time {
+	return h.lastLoginAt
+}
+
+// LoginCount returns the total number of logins
+func (h LoginHistory) LoginCount() int {
+	return h.loginCount
+}
+
+// RecentLogins returns a copy of the most recent login timestamps, newest first
+func (h LoginHistory) RecentLogins() []time.Time {
+	recent := make([]time.Time, len(h.recentLogins))
+	copy(recent, h.recentLogins)
+	return recent
+}
+
+// HasLoggedIn returns true if at least one login has been recorded
+func (h LoginHistory) HasLoggedIn() bool {
+	return h.loginCount > 0
+}
+
+// WithLogin creates a new LoginHistory with a login recorded at the given time
+func (h LoginHistory) WithLogin(at time.Time) LoginHistory {
+	size := len(h.recentLogins) + 1
+	if size > MaxRecentLogins {
+		size = MaxRecentLogins
+	}
+
+	recent := make([]time.Time, 0, size)
+	recent = append(recent, at)
+	recent = append(recent, h.recentLogins[:size-1]...)
+
+	return LoginHistory{
+		lastLoginAt:  at,
+		loginCount:   h.loginCount + 1,
+		recentLogins: recent,
+	}
+}
@@ -0,0 +1,92 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLoginHistory(t *testing.T) {
+	lastLoginAt := time.Now()
+	recent := []time.Time{lastLoginAt, lastLoginAt.Add(-time.Hour)}
+
+	history := NewLoginHistory(lastLoginAt, 5, recent)
+
+	assert.Equal(t, lastLoginAt, history.LastLoginAt())
+	assert.Equal(t, 5, history.LoginCount())
+	assert.Equal(t, recent, history.RecentLogins())
+	assert.True(t, history.HasLoggedIn())
+}
+
+func TestNewLoginHistory_Empty(t *testing.T) {
+	history := NewLoginHistory(time.Time{}, 0, nil)
+
+	assert.True(t, history.LastLoginAt().IsZero())
+	assert.Equal(t, 0, history.LoginCount())
+	assert.Empty(t, history.RecentLogins())
+	assert.False(t, history.HasLoggedIn())
+}
+
+func TestNewLoginHistory_NegativeCount(t *testing.T) {
+	history := NewLoginHistory(time.Time{}, -1, nil)
+
+	assert.Equal(t, 0, history.LoginCount())
+}
+
+func TestNewLoginHistory_TruncatesRecentLogins(t *testing.T) {
+	now := time.Now()
+	recent := make([]time.Time, MaxRecentLogins+5)
+	for i := range recent {
+		recent[i] = now.Add(-time.Duration(i) * time.Hour)
+	}
+
+	history := NewLoginHistory(now, len(recent), recent)
+
+	assert.Len(t, history.RecentLogins(), MaxRecentLogins)
+	assert.Equal(t, now, history.RecentLogins()[0])
+	assert.Equal(t, len(recent), history.LoginCount())
+}
+
+func TestLoginHistory_WithLogin(t *testing.T) {
+	first := time.Now().Add(-time.Hour)
+	second := time.Now()
+
+	original := NewLoginHistory(time.Time{}, 0, nil)
+	afterFirst := original.WithLogin(first)
+	afterSecond := afterFirst.WithLogin(second)
+
+	assert.Equal(t, second, afterSecond.LastLoginAt())
+	assert.Equal(t, 2, afterSecond.LoginCount())
+	assert.Equal(t, []time.Time{second, first}, afterSecond.RecentLogins())
+
+	// Original values are unchanged (immutability)
+	assert.Equal(t, 0, original.LoginCount())
+	assert.Equal(t, 1, afterFirst.LoginCount())
+	assert.Equal(t, []time.Time{first}, afterFirst.RecentLogins())
+}
+
+func TestLoginHistory_WithLogin_IsBounded(t *testing.T) {
+	history := NewLoginHistory(time.Time{}, 0, nil)
+	start := time.Now()
+
+	for i := 0; i < MaxRecentLogins+3; i++ {
+		history = history.WithLogin(start.Add(time.Duration(i) * time.Minute))
+	}
+
+	recent := history.RecentLogins()
+	assert.Len(t, recent, MaxRecentLogins)
+	assert.Equal(t, MaxRecentLogins+3, history.LoginCount())
+	assert.Equal(t, history.LastLoginAt(), recent[0])
+	assert.True(t, recent[0].After(recent[len(recent)-1]))
+}
+
+func TestLoginHistory_RecentLoginsReturnsCopy(t *testing.T) {
+	now := time.Now()
+	history := NewLoginHistory(now, 1, []time.Time{now})
+
+	recent := history.RecentLogins()
+	recent[0] = time.Time{}
+
+	assert.Equal(t, now, history.RecentLogins()[0])
+}
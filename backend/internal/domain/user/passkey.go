@@ -0,0 +1,194 @@
+package user
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+const (
+	// MaxPasskeys is the maximum number of passkeys a user can register
+	MaxPasskeys = 10
+
+	// MaxPasskeyNameLength is the maximum length of a passkey's display name
+	MaxPasskeyNameLength = 64
+
+	// maxCredentialIDLength is the WebAuthn limit on credential ID size
+	maxCredentialIDLength = 1023
+)
+
+// Passkey is a WebAuthn credential registered by the user
+type Passkey struct {
+	credentialID []byte
+	publicKey    []byte // COSE_Key encoded
+	signCount    uint32
+	name         string
+	createdAt    time.Time
+	lastUsedAt   time.Time
+}
+
+// NewPasskey creates a Passkey from a verified registration
+func NewPasskey(credentialID, publicKey []byte, signCount uint32, name string) (Passkey, error) {
+	if len(credentialID) == 0 || len(credentialID) > maxCredentialIDLength || len(publicKey) == 0 {
+		return Passkey{}, shared.ErrInvalidPasskey
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > MaxPasskeyNameLength {
+		return Passkey{}, shared.ErrInvalidPasskeyName
+	}
+
+	return Passkey{
+		credentialID: copyBytes(credentialID),
+		publicKey:    copyBytes(publicKey),
+		signCount:    signCount,
+		name:         name,
+		createdAt:    time.Now(),
+	}, nil
+}
+
+// ReconstructPasskey reconstructs a Passkey from persistence
+func ReconstructPasskey(credentialID, publicKey []byte, signCount uint32, name string, createdAt, lastUsedAt time.Time) Passkey {
+	return Passkey{
+		credentialID: copyBytes(credentialID),
+		publicKey:    copyBytes(publicKey),
+		signCount:    signCount,
+		name:         name,
+		createdAt:    createdAt,
+		lastUsedAt:   lastUsedAt,
+	}
+}
+
+// ID returns the credential ID in unpadded base64url, as used by WebAuthn clients
+func (p Passkey) ID() string {
+	return base64.RawURLEncoding.EncodeToString(p.credentialID)
+}
+
+// CredentialID returns a copy of the raw credential ID
+func (p Passkey) CredentialID() []byte {
+	return copyBytes(p.credentialID)
+}
+
+// PublicKey returns a copy of the COSE-encoded public key
+func (p Passkey) PublicKey() []byte {
+	return copyBytes(p.publicKey)
+}
+
+// SignCount returns the last signature counter reported by the authenticator
+func (p Passkey) SignCount() uint32 {
+	return p.signCount
+}
+
+// Name returns the passkey's display name
+func (p Passkey) Name() string {
+	return p.name
+}
+
+// CreatedAt returns when the passkey was registered
+func (p Passkey) CreatedAt() time.Time {
+	return p.createdAt
+}
+
+// LastUsedAt returns when the passkey was last used (zero if never)
+func (p Passkey) LastUsedAt() time.Time {
+	return p.lastUsedAt
+}
+
+// withAssertion records a successful assertion. Authenticators that keep a
+// signature counter must report a larger value each time; a counter that did
+// not increase suggests the credential was cloned.
+func (p Passkey) withAssertion(signCount uint32, at time.Time) (Passkey, error) {
+	if (signCount != 0 || p.signCount != 0) && signCount <= p.signCount {
+		return Passkey{}, shared.ErrPasskeyCloned
+	}
+
+	p.signCount = signCount
+	p.lastUsedAt = at
+	return p, nil
+}
+
+// Passkeys is an immutable list of a user's passkeys, in registration order
+type Passkeys struct {
+	passkeys []Passkey
+}
+
+// NewPasskeys creates a list of passkeys
+func NewPasskeys(passkeys ...Passkey) Passkeys {
+	copied := make([]Passkey, len(passkeys))
+	copy(copied, passkeys)
+	return Passkeys{passkeys: copied}
+}
+
+// Len returns the number of passkeys
+func (p Passkeys) Len() int {
+	return len(p.passkeys)
+}
+
+// Values returns a copy of the passkeys
+func (p Passkeys) Values() []Passkey {
+	values := make([]Passkey, len(p.passkeys))
+	copy(values, p.passkeys)
+	return values
+}
+
+// Find returns the passkey with the given raw credential ID
+func (p Passkeys) Find(credentialID []byte) (Passkey, bool) {
+	for _, passkey := range p.passkeys {
+		if bytes.Equal(passkey.credentialID, credentialID) {
+			return passkey, true
+		}
+	}
+	return Passkey{}, false
+}
+
+// CredentialIDs returns the raw credential IDs of all passkeys
+func (p Passkeys) CredentialIDs() [][]byte {
+	ids := make([][]byte, 0, len(p.passkeys))
+	for _, passkey := range p.passkeys {
+		ids = append(ids, passkey.CredentialID())
+	}
+	return ids
+}
+
+// with creates a new list containing the passkey, replacing one with the same ID
+func (p Passkeys) with(passkey Passkey) Passkeys {
+	updated := make([]Passkey, 0, len(p.passkeys)+1)
+	replaced := false
+	for _, existing := range p.passkeys {
+		if bytes.Equal(existing.credentialID, passkey.credentialID) {
+			updated = append(updated, passkey)
+			replaced = true
+			continue
+		}
+		updated = append(updated, existing)
+	}
+	if !replaced {
+		updated = append(updated, passkey)
+	}
+	return Passkeys{passkeys: updated}
+}
+
+// without creates a new list without the passkey with the given raw credential ID
+func (p Passkeys) without(credentialID []byte) Passkeys {
+	remaining := make([]Passkey, 0, len(p.passkeys))
+	for _, existing := range p.passkeys {
+		if !bytes.Equal(existing.credentialID, credentialID) {
+			remaining = append(remaining, existing)
+		}
+	}
+	return Passkeys{passkeys: remaining}
+}
+
+// copyBytes returns a copy of b (nil stays nil)
+func copyBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	copied := make([]byte, len(b))
+	copy(copied, b)
+	return copied
+}
@@ -0,0 +1,144 @@
+package user
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+func newPasskeyTestUser(t *testing.T) *User {
+	t.Helper()
+
+	userID, _ := NewUserID("google-user-123")
+	email, _ := NewEmail("test@example.com", true)
+	u, err := NewUser(userID, email, NewProfile("Test User", ""))
+	require.NoError(t, err)
+	u.ClearDomainEvents()
+	return u
+}
+
+func TestNewPasskey(t *testing.T) {
+	passkey, err := NewPasskey([]byte{0x01, 0x02, 0xff}, []byte("cose-key"), 5, "  YubiKey  ")
+
+	require.NoError(t, err)
+	assert.Equal(t, "AQL_", passkey.ID())
+	assert.Equal(t, []byte{0x01, 0x02, 0xff}, passkey.CredentialID())
+	assert.Equal(t, []byte("cose-key"), passkey.PublicKey())
+	assert.Equal(t, uint32(5), passkey.SignCount())
+	assert.Equal(t, "YubiKey", passkey.Name())
+	assert.False(t, passkey.CreatedAt().IsZero())
+	assert.True(t, passkey.LastUsedAt().IsZero())
+}
+
+func TestNewPasskey_Invalid(t *testing.T) {
+	tests := []struct {
+		name         string
+		credentialID []byte
+		publicKey    []byte
+		displayName  string
+		expected     error
+	}{
+		{"empty credential ID", nil, []byte("key"), "Key", shared.ErrInvalidPasskey},
+		{"credential ID too long", make([]byte, 1024), []byte("key"), "Key", shared.ErrInvalidPasskey},
+		{"empty public key", []byte("id"), nil, "Key", shared.ErrInvalidPasskey},
+		{"empty name", []byte("id"), []byte("key"), "  ", shared.ErrInvalidPasskeyName},
+		{"name too long", []byte("id"), []byte("key"), strings.Repeat("a", 65), shared.ErrInvalidPasskeyName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewPasskey(tt.credentialID, tt.publicKey, 0, tt.displayName)
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
+
+func TestUser_RegisterPasskey(t *testing.T) {
+	u := newPasskeyTestUser(t)
+	passkey, _ := NewPasskey([]byte("cred-1"), []byte("key"), 0, "Laptop")
+
+	require.NoError(t, u.RegisterPasskey(passkey))
+
+	assert.Equal(t, 1, u.Passkeys().Len())
+	require.Len(t, u.DomainEvents(), 1)
+	assert.Equal(t, EventTypePasskeyRegistered, u.DomainEvents()[0].EventType())
+
+	assert.Equal(t, shared.ErrPasskeyAlreadyRegistered, u.RegisterPasskey(passkey))
+}
+
+func TestUser_RegisterPasskey_Limit(t *testing.T) {
+	u := newPasskeyTestUser(t)
+	for i := 0; i < MaxPasskeys; i++ {
+		passkey, _ := NewPasskey([]byte{byte(i)}, []byte("key"), 0, "Key")
+		require.NoError(t, u.RegisterPasskey(passkey))
+	}
+
+	passkey, _ := NewPasskey([]byte("one-too-many"), []byte("key"), 0, "Key")
+	assert.Equal(t, shared.ErrTooManyPasskeys, u.RegisterPasskey(passkey))
+}
+
+func TestUser_RemovePasskey(t *testing.T) {
+	u := newPasskeyTestUser(t)
+	first, _ := NewPasskey([]byte("cred-1"), []byte("key"), 0, "Laptop")
+	second, _ := NewPasskey([]byte("cred-2"), []byte("key"), 0, "Phone")
+	require.NoError(t, u.RegisterPasskey(first))
+	require.NoError(t, u.RegisterPasskey(second))
+	u.ClearDomainEvents()
+
+	require.NoError(t, u.RemovePasskey([]byte("cred-1")))
+
+	_, found := u.Passkeys().Find([]byte("cred-1"))
+	assert.False(t, found)
+	assert.Equal(t, [][]byte{[]byte("cred-2")}, u.Passkeys().CredentialIDs())
+	require.Len(t, u.DomainEvents(), 1)
+	assert.Equal(t, EventTypePasskeyRemoved, u.DomainEvents()[0].EventType())
+
+	assert.Equal(t, shared.ErrPasskeyNotFound, u.RemovePasskey([]byte("cred-1")))
+}
+
+func TestUser_UsePasskey_SignCount(t *testing.T) {
+	tests := []struct {
+		name      string
+		stored    uint32
+		reported  uint32
+		expectErr error
+	}{
+		{"counter increased", 5, 6, nil},
+		{"authenticator without counter", 0, 0, nil},
+		{"counter started", 0, 1, nil},
+		{"counter repeated", 5, 5, shared.ErrPasskeyCloned},
+		{"counter went backwards", 5, 3, shared.ErrPasskeyCloned},
+		{"counter reset to zero", 5, 0, shared.ErrPasskeyCloned},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newPasskeyTestUser(t)
+			passkey, _ := NewPasskey([]byte("cred-1"), []byte("key"), tt.stored, "Laptop")
+			require.NoError(t, u.RegisterPasskey(passkey))
+
+			err := u.UsePasskey([]byte("cred-1"), tt.reported)
+
+			used, _ := u.Passkeys().Find([]byte("cred-1"))
+			if tt.expectErr != nil {
+				assert.Equal(t, tt.expectErr, err)
+				assert.Equal(t, tt.stored, used.SignCount())
+				assert.True(t, used.LastUsedAt().IsZero())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.reported, used.SignCount())
+			assert.False(t, used.LastUsedAt().IsZero())
+		})
+	}
+}
+
+func TestUser_UsePasskey_NotFound(t *testing.T) {
+	u := newPasskeyTestUser(t)
+
+	assert.Equal(t, shared.ErrPasskeyNotFound, u.UsePasskey([]byte("missing"), 1))
+}
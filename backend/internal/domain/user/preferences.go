@@ -0,0 +1,86 @@
+package user
+
+import (
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+const (
+	// MaxPreferences is the maximum number of preference entries per user
+	MaxPreferences = 50
+	// MaxPreferenceValueLength is the maximum length of a preference value
+	MaxPreferenceValueLength = 1024
+)
+
+var preferenceKeyRegex = regexp.MustCompile(`^[a-z][a-z0-9_.-]{0,63}$`)
+
+// Preferences is a validated set of free-form user settings, e.g. "theme" => "dark"
+type Preferences struct {
+	values map[string]string
+}
+
+// NewPreferences creates Preferences from key/value pairs with validation
+func NewPreferences(values map[string]string) (Preferences, error) {
+	if len(values) > MaxPreferences {
+		return Preferences{}, fmt.Errorf("%w: at most %d entries are allowed", shared.ErrInvalidPreference, MaxPreferences)
+	}
+
+	copied := make(map[string]string, len(values))
+	for key, value := range values {
+		if err := validatePreference(key, value); err != nil {
+			return Preferences{}, err
+		}
+		copied[key] = value
+	}
+
+	return Preferences{values: copied}, nil
+}
+
+// Get returns the value of a preference and whether it is set
+func (p Preferences) Get(key string) (string, bool) {
+	value, ok := p.values[key]
+	return value, ok
+}
+
+// All returns a copy of all preferences
+func (p Preferences) All() map[string]string {
+	values := make(map[string]string, len(p.values))
+	for key, value := range p.values {
+		values[key] = value
+	}
+	return values
+}
+
+// Len returns the number of preferences
+func (p Preferences) Len() int {
+	return len(p.values)
+}
+
+// Merge creates new Preferences with the given updates applied
+// A nil value removes the key
+func (p Preferences) Merge(updates map[string]*string) (Preferences, error) {
+	merged := p.All()
+	for key, value := range updates {
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+		merged[key] = *value
+	}
+
+	return NewPreferences(merged)
+}
+
+// validatePreference checks a single preference entry
+func validatePreference(key, value string) error {
+	if !preferenceKeyRegex.MatchString(key) {
+		return fmt.Errorf("%w: invalid key %q", shared.ErrInvalidPreference, key)
+	}
+	if utf8.RuneCountInString(value) > MaxPreferenceValueLength {
+		return fmt.Errorf("%w: value of %q is too long", shared.ErrInvalidPreference, key)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package user
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+func TestNewPreferences(t *testing.T) {
+	prefs, err := NewPreferences(map[string]string{"theme": "dark", "notifications.email": "off"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, prefs.Len())
+	value, ok := prefs.Get("theme")
+	assert.True(t, ok)
+	assert.Equal(t, "dark", value)
+}
+
+func TestNewPreferences_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string]string
+	}{
+		{"uppercase key", map[string]string{"Theme": "dark"}},
+		{"empty key", map[string]string{"": "dark"}},
+		{"key with spaces", map[string]string{"my theme": "dark"}},
+		{"value too long", map[string]string{"theme": strings.Repeat("a", MaxPreferenceValueLength+1)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewPreferences(tt.values)
+			assert.ErrorIs(t, err, shared.ErrInvalidPreference)
+		})
+	}
+}
+
+func TestNewPreferences_TooMany(t *testing.T) {
+	values := make(map[string]string)
+	for i := 0; i <= MaxPreferences; i++ {
+		values[fmt.Sprintf("key%d", i)] = "value"
+	}
+
+	_, err := NewPreferences(values)
+
+	assert.ErrorIs(t, err, shared.ErrInvalidPreference)
+}
+
+func TestPreferences_Merge(t *testing.T) {
+	original, _ := NewPreferences(map[string]string{"theme": "dark", "lang": "en"})
+	light := "light"
+
+	merged, err := original.Merge(map[string]*string{
+		"theme": &light,
+		"lang":  nil,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"theme": "light"}, merged.All())
+
+	// Original is unchanged (immutability)
+	assert.Equal(t, map[string]string{"theme": "dark", "lang": "en"}, original.All())
+}
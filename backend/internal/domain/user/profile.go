@@ -1,9 +1,31 @@
 package user
 
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	_ "time/tzdata" // embed the time zone database so timezone validation works on minimal runtimes
+	"unicode/utf8"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+// MaxDisplayNameLength is the maximum length of a user-chosen display name
+const MaxDisplayNameLength = 100
+
+var localeRegex = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+
 // Profile represents a user's profile information
+// Name and picture are synced from the identity provider on login unless the
+// user has overridden them locally
 type Profile struct {
-	name    string
-	picture string
+	name              string
+	picture           string
+	locale            string
+	timezone          string
+	nameOverridden    bool
+	pictureOverridden bool
 }
 
 // NewProfile creates a new Profile
@@ -14,6 +36,18 @@ func NewProfile(name, picture string) Profile {
 	}
 }
 
+// ReconstructProfile reconstructs a Profile from persistence
+func ReconstructProfile(name, picture, locale, timezone string, nameOverridden, pictureOverridden bool) Profile {
+	return Profile{
+		name:              name,
+		picture:           picture,
+		locale:            locale,
+		timezone:          timezone,
+		nameOverridden:    nameOverridden,
+		pictureOverridden: pictureOverridden,
+	}
+}
+
 // Name returns the user's display name
 func (p Profile) Name() string {
 	return p.name
@@ -24,20 +58,117 @@ func (p Profile) Picture() string {
 	return p.picture
 }
 
+// Locale returns the user's preferred locale, e.g. "en-US" (empty if unset)
+func (p Profile) Locale() string {
+	return p.locale
+}
+
+// Timezone returns the user's IANA time zone, e.g. "Asia/Tokyo" (empty if unset)
+func (p Profile) Timezone() string {
+	return p.timezone
+}
+
+// IsNameOverridden returns true if the user has set their own display name
+func (p Profile) IsNameOverridden() bool {
+	return p.nameOverridden
+}
+
+// IsPictureOverridden returns true if the user has set their own picture
+func (p Profile) IsPictureOverridden() bool {
+	return p.pictureOverridden
+}
+
 // WithName creates a new Profile with updated name
 func (p Profile) WithName(name string) Profile {
-	return Profile{
-		name:    name,
-		picture: p.picture,
-	}
+	updated := p
+	updated.name = name
+	return updated
 }
 
 // WithPicture creates a new Profile with updated picture
 func (p Profile) WithPicture(picture string) Profile {
-	return Profile{
-		name:    p.name,
-		picture: picture,
+	updated := p
+	updated.picture = picture
+	return updated
+}
+
+// WithDisplayName creates a new Profile with a user-chosen display name
+// that is kept across provider syncs
+func (p Profile) WithDisplayName(name string) (Profile, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > MaxDisplayNameLength {
+		return Profile{}, shared.ErrInvalidDisplayName
+	}
+
+	updated := p
+	updated.name = name
+	updated.nameOverridden = true
+	return updated, nil
+}
+
+// WithCustomPicture creates a new Profile with a user-chosen picture URL
+// that is kept across provider syncs. An empty URL removes the override so
+// the provider's picture is used again on the next login.
+func (p Profile) WithCustomPicture(picture string) (Profile, error) {
+	picture = strings.TrimSpace(picture)
+	updated := p
+
+	if picture == "" {
+		updated.pictureOverridden = false
+		return updated, nil
+	}
+
+	parsed, err := url.Parse(picture)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return Profile{}, shared.ErrInvalidPictureURL
+	}
+
+	updated.picture = picture
+	updated.pictureOverridden = true
+	return updated, nil
+}
+
+// WithLocale creates a new Profile with the given locale (empty clears it)
+func (p Profile) WithLocale(locale string) (Profile, error) {
+	locale = strings.TrimSpace(locale)
+	if locale != "" && !localeRegex.MatchString(locale) {
+		return Profile{}, shared.ErrInvalidLocale
+	}
+
+	updated := p
+	updated.locale = locale
+	return updated, nil
+}
+
+// WithTimezone creates a new Profile with the given IANA time zone (empty clears it)
+func (p Profile) WithTimezone(timezone string) (Profile, error) {
+	timezone = strings.TrimSpace(timezone)
+	if timezone != "" {
+		// LoadLocation treats "" and "Local" specially; only accept real zone names
+		if timezone == "Local" {
+			return Profile{}, shared.ErrInvalidTimezone
+		}
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return Profile{}, shared.ErrInvalidTimezone
+		}
+	}
+
+	updated := p
+	updated.timezone = timezone
+	return updated, nil
+}
+
+// SyncedWith creates a new Profile that takes name and picture from the
+// identity provider's profile, except for fields the user has overridden
+func (p Profile) SyncedWith(provider Profile) Profile {
+	updated := p
+	if !p.nameOverridden {
+		updated.name = provider.name
+	}
+	if !p.pictureOverridden {
+		updated.picture = provider.picture
 	}
+	return updated
 }
 
 // IsEmpty returns true if the profile has no name and no picture
@@ -1,9 +1,12 @@
 package user
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
 )
 
 func TestNewProfile(t *testing.T) {
@@ -53,3 +56,98 @@ func TestProfile_PartiallyEmpty(t *testing.T) {
 	profileWithPictureOnly := NewProfile("", "https://example.com/photo.jpg")
 	assert.False(t, profileWithPictureOnly.IsEmpty())
 }
+
+func TestProfile_WithDisplayName(t *testing.T) {
+	original := NewProfile("John Doe", "https://example.com/photo.jpg")
+
+	updated, err := original.WithDisplayName("  Johnny  ")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Johnny", updated.Name())
+	assert.True(t, updated.IsNameOverridden())
+	assert.False(t, original.IsNameOverridden())
+}
+
+func TestProfile_WithDisplayName_Invalid(t *testing.T) {
+	original := NewProfile("John Doe", "")
+
+	_, err := original.WithDisplayName("   ")
+	assert.Equal(t, shared.ErrInvalidDisplayName, err)
+
+	_, err = original.WithDisplayName(strings.Repeat("a", MaxDisplayNameLength+1))
+	assert.Equal(t, shared.ErrInvalidDisplayName, err)
+}
+
+func TestProfile_WithCustomPicture(t *testing.T) {
+	original := NewProfile("John Doe", "https://example.com/google.jpg")
+
+	updated, err := original.WithCustomPicture("https://cdn.example.com/me.png")
+	require.NoError(t, err)
+	assert.Equal(t, "https://cdn.example.com/me.png", updated.Picture())
+	assert.True(t, updated.IsPictureOverridden())
+
+	// An empty URL drops the override
+	reset, err := updated.WithCustomPicture("")
+	require.NoError(t, err)
+	assert.False(t, reset.IsPictureOverridden())
+
+	_, err = original.WithCustomPicture("http://example.com/me.png")
+	assert.Equal(t, shared.ErrInvalidPictureURL, err)
+
+	_, err = original.WithCustomPicture("not a url")
+	assert.Equal(t, shared.ErrInvalidPictureURL, err)
+}
+
+func TestProfile_WithLocale(t *testing.T) {
+	tests := []struct {
+		locale  string
+		wantErr bool
+	}{
+		{"en", false},
+		{"en-US", false},
+		{"zh-Hant-TW", false},
+		{"", false},
+		{"e", true},
+		{"en_US", true},
+		{"english!", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			updated, err := NewProfile("John Doe", "").WithLocale(tt.locale)
+			if tt.wantErr {
+				assert.Equal(t, shared.ErrInvalidLocale, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.locale, updated.Locale())
+		})
+	}
+}
+
+func TestProfile_WithTimezone(t *testing.T) {
+	updated, err := NewProfile("John Doe", "").WithTimezone("Asia/Tokyo")
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Tokyo", updated.Timezone())
+
+	_, err = updated.WithTimezone("Mars/Olympus_Mons")
+	assert.Equal(t, shared.ErrInvalidTimezone, err)
+
+	_, err = updated.WithTimezone("Local")
+	assert.Equal(t, shared.ErrInvalidTimezone, err)
+
+	cleared, err := updated.WithTimezone("")
+	require.NoError(t, err)
+	assert.Empty(t, cleared.Timezone())
+}
+
+func TestProfile_SyncedWith(t *testing.T) {
+	local, _ := NewProfile("Google Name", "https://example.com/google.jpg").WithCustomPicture("https://example.com/mine.jpg")
+	local, _ = local.WithLocale("en-GB")
+
+	synced := local.SyncedWith(NewProfile("New Google Name", "https://example.com/new.jpg"))
+
+	assert.Equal(t, "New Google Name", synced.Name())
+	assert.Equal(t, "https://example.com/mine.jpg", synced.Picture())
+	assert.Equal(t, "en-GB", synced.Locale())
+}
@@ -13,6 +13,9 @@ type Repository interface {
 	// FindByEmail retrieves a user by their email
 	FindByEmail(ctx context.Context, email Email) (*User, error)
 
+	// FindByGoogleID retrieves the user a Google account is linked to
+	FindByGoogleID(ctx context.Context, googleID string) (*User, error)
+
 	// Delete removes a user
 	Delete(ctx context.Context, id UserID) error
 
@@ -0,0 +1,101 @@
+package user
+
+import (
+	"sort"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+// Role represents a permission level granted to a user
+type Role string
+
+// Role values
+const (
+	RoleUser  Role = "user"
+	RoleAdmin Role = "admin"
+)
+
+// ParseRole converts a persisted string into a Role
+func ParseRole(value string) (Role, error) {
+	switch Role(value) {
+	case RoleUser:
+		return RoleUser, nil
+	case RoleAdmin:
+		return RoleAdmin, nil
+	default:
+		return "", shared.ErrInvalidRole
+	}
+}
+
+// String implements the Stringer interface
+func (r Role) String() string {
+	return string(r)
+}
+
+// Roles is an immutable set of roles
+type Roles struct {
+	roles []Role // sorted, without duplicates
+}
+
+// NewRoles creates a set of roles
+func NewRoles(roles ...Role) Roles {
+	return Roles{}.with(roles...)
+}
+
+// Has returns true if the set contains the role
+func (r Roles) Has(role Role) bool {
+	for _, existing := range r.roles {
+		if existing == role {
+			return true
+		}
+	}
+	return false
+}
+
+// With creates a new set that also contains the role
+func (r Roles) With(role Role) Roles {
+	return r.with(role)
+}
+
+// Without creates a new set that does not contain the role
+func (r Roles) Without(role Role) Roles {
+	remaining := make([]Role, 0, len(r.roles))
+	for _, existing := range r.roles {
+		if existing != role {
+			remaining = append(remaining, existing)
+		}
+	}
+	return Roles{roles: remaining}
+}
+
+// Values returns a copy of the roles, sorted by name
+func (r Roles) Values() []Role {
+	values := make([]Role, len(r.roles))
+	copy(values, r.roles)
+	return values
+}
+
+// Strings returns the role names, sorted
+func (r Roles) Strings() []string {
+	names := make([]string, len(r.roles))
+	for i, role := range r.roles {
+		names[i] = role.String()
+	}
+	return names
+}
+
+// with adds roles, keeping the set sorted and free of duplicates
+func (r Roles) with(roles ...Role) Roles {
+	merged := r.Values()
+	for _, role := range roles {
+		if !(Roles{roles: merged}).Has(role) {
+			merged = append(merged, role)
+		}
+	}
+
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i] < merged[j]
+	})
+
+	return Roles{roles: merged}
+}
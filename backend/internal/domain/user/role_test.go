@@ -0,0 +1,34 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+func TestParseRole(t *testing.T) {
+	role, err := ParseRole("admin")
+	assert.NoError(t, err)
+	assert.Equal(t, RoleAdmin, role)
+
+	_, err = ParseRole("superuser")
+	assert.Equal(t, shared.ErrInvalidRole, err)
+}
+
+func TestRoles(t *testing.T) {
+	roles := NewRoles(RoleUser, RoleUser)
+
+	assert.Equal(t, []string{"user"}, roles.Strings())
+	assert.True(t, roles.Has(RoleUser))
+	assert.False(t, roles.Has(RoleAdmin))
+
+	withAdmin := roles.With(RoleAdmin)
+	assert.Equal(t, []string{"admin", "user"}, withAdmin.Strings())
+
+	// Original is unchanged (immutability)
+	assert.False(t, roles.Has(RoleAdmin))
+
+	withoutUser := withAdmin.Without(RoleUser)
+	assert.Equal(t, []Role{RoleAdmin}, withoutUser.Values())
+}
@@ -0,0 +1,53 @@
+package user
+
+import "github.com/yuki5155/go-google-auth/internal/domain/shared"
+
+// Status represents the lifecycle state of a user account
+type Status string
+
+// Account status values
+const (
+	StatusActive    Status = "active"
+	StatusSuspended Status = "suspended"
+	StatusDeleted   Status = "deleted"
+)
+
+// ParseStatus converts a persisted string into a Status
+// An empty string is treated as active for records created before statuses existed
+func ParseStatus(value string) (Status, error) {
+	switch Status(value) {
+	case "", StatusActive:
+		return StatusActive, nil
+	case StatusSuspended:
+		return StatusSuspended, nil
+	case StatusDeleted:
+		return StatusDeleted, nil
+	default:
+		return "", shared.ErrInvalidStatus
+	}
+}
+
+// String implements the Stringer interface
+func (s Status) String() string {
+	return string(s)
+}
+
+// IsActive returns true if the account may authenticate
+func (s Status) IsActive() bool {
+	return s == StatusActive
+}
+
+// Err returns the domain error describing why the account cannot authenticate,
+// or nil if it is active
+func (s Status) Err() error {
+	switch s {
+	case StatusActive:
+		return nil
+	case StatusSuspended:
+		return shared.ErrAccountSuspended
+	case StatusDeleted:
+		return shared.ErrAccountDeleted
+	default:
+		return shared.ErrInvalidStatus
+	}
+}
@@ -0,0 +1,45 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+func TestParseStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected Status
+		err      error
+	}{
+		{name: "active", input: "active", expected: StatusActive},
+		{name: "suspended", input: "suspended", expected: StatusSuspended},
+		{name: "deleted", input: "deleted", expected: StatusDeleted},
+		{name: "empty defaults to active", input: "", expected: StatusActive},
+		{name: "unknown", input: "banned", err: shared.ErrInvalidStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := ParseStatus(tt.input)
+
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.expected, status)
+		})
+	}
+}
+
+func TestStatus_Err(t *testing.T) {
+	assert.NoError(t, StatusActive.Err())
+	assert.Equal(t, shared.ErrAccountSuspended, StatusSuspended.Err())
+	assert.Equal(t, shared.ErrAccountDeleted, StatusDeleted.Err())
+	assert.Equal(t, shared.ErrInvalidStatus, Status("unknown").Err())
+}
+
+func TestStatus_IsActive(t *testing.T) {
+	assert.True(t, StatusActive.IsActive())
+	assert.False(t, StatusSuspended.IsActive())
+	assert.False(t, StatusDeleted.IsActive())
+}
@@ -11,6 +11,13 @@ type User struct {
 	id        UserID
 	email     Email
 	profile   Profile
+	prefs     Preferences
+	roles     Roles
+	status    Status
+	logins    LoginHistory
+	passkeys  Passkeys
+	googleID  string // the Google account linked to a user created by email login, if any
+	orgID     string // the organization the user last selected, if any
 	createdAt time.Time
 	updatedAt time.Time
 	events    []shared.DomainEvent
@@ -31,6 +38,8 @@ func NewUser(id UserID, email Email, profile Profile) (*User, error) {
 		id:        id,
 		email:     email,
 		profile:   profile,
+		roles:     NewRoles(RoleUser),
+		status:    StatusActive,
 		createdAt: now,
 		updatedAt: now,
 		events:    make([]shared.DomainEvent, 0),
@@ -43,11 +52,18 @@ func NewUser(id UserID, email Email, profile Profile) (*User, error) {
 }
 
 // ReconstructUser reconstructs a User from persistence (without domain events)
-func ReconstructUser(id UserID, email Email, profile Profile, createdAt, updatedAt time.Time) *User {
+func ReconstructUser(id UserID, email Email, profile Profile, prefs Preferences, roles Roles, status Status, logins LoginHistory, passkeys Passkeys, googleID, orgID string, createdAt, updatedAt time.Time) *User {
 	return &User{
 		id:        id,
 		email:     email,
 		profile:   profile,
+		prefs:     prefs,
+		roles:     roles,
+		status:    status,
+		logins:    logins,
+		passkeys:  passkeys,
+		googleID:  googleID,
+		orgID:     orgID,
 		createdAt: createdAt,
 		updatedAt: updatedAt,
 		events:    make([]shared.DomainEvent, 0),
@@ -69,6 +85,63 @@ func (u *User) Profile() Profile {
 	return u.profile
 }
 
+// Preferences returns the user's preferences
+func (u *User) Preferences() Preferences {
+	return u.prefs
+}
+
+// Roles returns the roles granted to the user
+func (u *User) Roles() Roles {
+	return u.roles
+}
+
+// HasRole returns true if the user has been granted the role
+func (u *User) HasRole(role Role) bool {
+	return u.roles.Has(role)
+}
+
+// Status returns the user's account status
+func (u *User) Status() Status {
+	return u.status
+}
+
+// IsActive returns true if the account is active
+func (u *User) IsActive() bool {
+	return u.status.IsActive()
+}
+
+// EnsureActive returns an error if the account is not allowed to authenticate
+func (u *User) EnsureActive() error {
+	return u.status.Err()
+}
+
+// LoginHistory returns the user's login history
+func (u *User) LoginHistory() LoginHistory {
+	return u.logins
+}
+
+// LastLoginAt returns when the user last signed in (zero if never)
+func (u *User) LastLoginAt() time.Time {
+	return u.logins.LastLoginAt()
+}
+
+// LoginCount returns how many times the user has signed in
+func (u *User) LoginCount() int {
+	return u.logins.LoginCount()
+}
+
+// Passkeys returns the user's registered passkeys
+func (u *User) Passkeys() Passkeys {
+	return u.passkeys
+}
+
+// GoogleID returns the Google account linked to a user created by email
+// login, or "" when none is; users created by Google sign-in are identified
+// by their ID instead
+func (u *User) GoogleID() string {
+	return u.googleID
+}
+
 // CreatedAt returns when the user was created
 func (u *User) CreatedAt() time.Time {
 	return u.createdAt
@@ -85,6 +158,55 @@ func (u *User) UpdateProfile(profile Profile) {
 	u.updatedAt = time.Now()
 }
 
+// SyncProfile applies the identity provider's profile, keeping any fields
+// the user has overridden locally
+func (u *User) SyncProfile(provider Profile) {
+	u.profile = u.profile.SyncedWith(provider)
+	u.updatedAt = time.Now()
+}
+
+// EditProfile applies a profile and preferences edited by the user
+func (u *User) EditProfile(profile Profile, prefs Preferences) {
+	u.profile = profile
+	u.prefs = prefs
+	u.addEvent(NewUserProfileUpdatedEvent(u.id.Value()))
+	u.updatedAt = time.Now()
+}
+
+// ActiveOrganizationID returns the ID of the organization the user last
+// selected, which their tokens are issued for, or "" for none
+func (u *User) ActiveOrganizationID() string {
+	return u.orgID
+}
+
+// SelectOrganization makes orgID the organization the user's tokens are
+// issued for; the caller checks that they are a member. An empty orgID
+// selects none.
+func (u *User) SelectOrganization(orgID string) {
+	u.orgID = orgID
+	u.updatedAt = time.Now()
+}
+
+// LeaveOrganization unselects orgID if it is the user's active organization,
+// after they stop being a member
+func (u *User) LeaveOrganization(orgID string) {
+	if u.orgID == orgID {
+		u.SelectOrganization("")
+	}
+}
+
+// GrantRole grants a role to the user
+func (u *User) GrantRole(role Role) {
+	u.roles = u.roles.With(role)
+	u.updatedAt = time.Now()
+}
+
+// RevokeRole removes a role from the user
+func (u *User) RevokeRole(role Role) {
+	u.roles = u.roles.Without(role)
+	u.updatedAt = time.Now()
+}
+
 // UpdateEmail updates the user's email (must be verified)
 func (u *User) UpdateEmail(email Email) error {
 	if !email.IsVerified() {
@@ -96,10 +218,146 @@ func (u *User) UpdateEmail(email Email) error {
 	return nil
 }
 
-// RecordLogin records a login event
+// LinkGoogleAccount links a Google account to the user so that it signs in
+// as them; a user can only be linked to one Google account
+func (u *User) LinkGoogleAccount(googleID string) error {
+	if googleID == "" {
+		return shared.ErrInvalidUserID
+	}
+	if u.googleID != "" && u.googleID != googleID {
+		return shared.ErrGoogleAccountConflict
+	}
+	if u.googleID == googleID {
+		return nil
+	}
+
+	u.googleID = googleID
+	u.updatedAt = time.Now()
+	u.addEvent(NewGoogleAccountLinkedEvent(u.id.Value(), googleID))
+	return nil
+}
+
+// RecordLogin records a login in the user's history and emits a login event
 func (u *User) RecordLogin() {
+	now := time.Now()
+	u.logins = u.logins.WithLogin(now)
 	u.addEvent(NewUserLoggedInEvent(u.id.Value(), u.email.Value()))
+	u.updatedAt = now
+}
+
+// FlagSuspiciousLogin records that the login that started a session came
+// from an unfamiliar device or location
+func (u *User) FlagSuspiciousLogin(sessionID string, reasons []string) {
+	u.addEvent(NewSuspiciousLoginDetectedEvent(u.id.Value(), sessionID, reasons))
+}
+
+// DisownLogin records that the user reported a login as not their own
+func (u *User) DisownLogin(sessionID string) {
+	u.addEvent(NewLoginDisownedEvent(u.id.Value(), sessionID))
+}
+
+// StartImpersonation records that admin is acting as the user until
+// expiresAt. Only administrators may impersonate, and only active users who
+// are not administrators themselves.
+func (u *User) StartImpersonation(admin *User, reason string, expiresAt time.Time) error {
+	if !admin.HasRole(RoleAdmin) || !admin.IsActive() {
+		return shared.ErrAdminRequired
+	}
+	if admin.id.Equals(u.id) || u.HasRole(RoleAdmin) || !u.IsActive() {
+		return shared.ErrImpersonationNotAllowed
+	}
+
+	u.addEvent(NewImpersonationStartedEvent(u.id.Value(), admin.id.Value(), reason, expiresAt))
+	return nil
+}
+
+// EndImpersonation records that the administrator adminID stopped acting as
+// the user
+func (u *User) EndImpersonation(adminID string) {
+	u.addEvent(NewImpersonationEndedEvent(u.id.Value(), adminID))
+}
+
+// Suspend locks the account so it can no longer authenticate
+func (u *User) Suspend(reason string) error {
+	if u.status != StatusActive {
+		return shared.ErrInvalidStatusTransition
+	}
+
+	u.status = StatusSuspended
+	u.addEvent(NewUserSuspendedEvent(u.id.Value(), reason))
 	u.updatedAt = time.Now()
+	return nil
+}
+
+// Reactivate restores a suspended account
+func (u *User) Reactivate() error {
+	if u.status != StatusSuspended {
+		return shared.ErrInvalidStatusTransition
+	}
+
+	u.status = StatusActive
+	u.addEvent(NewUserReactivatedEvent(u.id.Value()))
+	u.updatedAt = time.Now()
+	return nil
+}
+
+// MarkDeleted soft-deletes the account; its data should be purged after purgeAt
+func (u *User) MarkDeleted(purgeAt time.Time) error {
+	if u.status == StatusDeleted {
+		return shared.ErrInvalidStatusTransition
+	}
+
+	u.status = StatusDeleted
+	u.addEvent(NewUserDeletedEvent(u.id.Value(), u.email.Value(), purgeAt))
+	u.updatedAt = time.Now()
+	return nil
+}
+
+// RegisterPasskey adds a verified passkey to the account
+func (u *User) RegisterPasskey(passkey Passkey) error {
+	if _, exists := u.passkeys.Find(passkey.credentialID); exists {
+		return shared.ErrPasskeyAlreadyRegistered
+	}
+	if u.passkeys.Len() >= MaxPasskeys {
+		return shared.ErrTooManyPasskeys
+	}
+
+	u.passkeys = u.passkeys.with(passkey)
+	u.addEvent(NewPasskeyRegisteredEvent(u.id.Value(), passkey.ID()))
+	u.updatedAt = time.Now()
+	return nil
+}
+
+// RemovePasskey removes a passkey by its raw credential ID
+func (u *User) RemovePasskey(credentialID []byte) error {
+	passkey, exists := u.passkeys.Find(credentialID)
+	if !exists {
+		return shared.ErrPasskeyNotFound
+	}
+
+	u.passkeys = u.passkeys.without(credentialID)
+	u.addEvent(NewPasskeyRemovedEvent(u.id.Value(), passkey.ID()))
+	u.updatedAt = time.Now()
+	return nil
+}
+
+// UsePasskey records a verified assertion, rejecting signature counters that
+// did not increase
+func (u *User) UsePasskey(credentialID []byte, signCount uint32) error {
+	passkey, exists := u.passkeys.Find(credentialID)
+	if !exists {
+		return shared.ErrPasskeyNotFound
+	}
+
+	now := time.Now()
+	used, err := passkey.withAssertion(signCount, now)
+	if err != nil {
+		return err
+	}
+
+	u.passkeys = u.passkeys.with(used)
+	u.updatedAt = now
+	return nil
 }
 
 // DomainEvents returns all domain events
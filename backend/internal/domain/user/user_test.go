@@ -23,6 +23,8 @@ func TestNewUser_Success(t *testing.T) {
 	assert.False(t, user.CreatedAt().IsZero())
 	assert.False(t, user.UpdatedAt().IsZero())
 	assert.Equal(t, user.CreatedAt(), user.UpdatedAt())
+	assert.Equal(t, StatusActive, user.Status())
+	assert.True(t, user.IsActive())
 
 	// Check domain event was recorded
 	events := user.DomainEvents()
@@ -60,12 +62,26 @@ func TestReconstructUser(t *testing.T) {
 	profile := NewProfile("Test User", "https://example.com/photo.jpg")
 	createdAt := time.Now().Add(-24 * time.Hour)
 	updatedAt := time.Now()
+	lastLoginAt := time.Now().Add(-time.Hour)
+	logins := NewLoginHistory(lastLoginAt, 3, []time.Time{lastLoginAt})
+	prefs, _ := NewPreferences(map[string]string{"theme": "dark"})
 
-	user := ReconstructUser(userID, email, profile, createdAt, updatedAt)
+	roles := NewRoles(RoleUser, RoleAdmin)
+	passkeys := NewPasskeys(ReconstructPasskey([]byte("cred-1"), []byte("key"), 7, "Laptop", createdAt, updatedAt))
+
+	user := ReconstructUser(userID, email, profile, prefs, roles, StatusSuspended, logins, passkeys, "google-456", "org-1", createdAt, updatedAt)
 
 	assert.Equal(t, userID, user.ID())
 	assert.Equal(t, email, user.Email())
 	assert.Equal(t, profile, user.Profile())
+	assert.Equal(t, prefs, user.Preferences())
+	assert.Equal(t, roles, user.Roles())
+	assert.Equal(t, StatusSuspended, user.Status())
+	assert.Equal(t, lastLoginAt, user.LastLoginAt())
+	assert.Equal(t, 3, user.LoginCount())
+	assert.Equal(t, passkeys, user.Passkeys())
+	assert.Equal(t, "google-456", user.GoogleID())
+	assert.Equal(t, "org-1", user.ActiveOrganizationID())
 	assert.Equal(t, createdAt, user.CreatedAt())
 	assert.Equal(t, updatedAt, user.UpdatedAt())
 
@@ -73,6 +89,101 @@ func TestReconstructUser(t *testing.T) {
 	assert.Empty(t, user.DomainEvents())
 }
 
+func TestUser_LinkGoogleAccount(t *testing.T) {
+	userID, _ := NewUserID("email-123")
+	email, _ := NewEmail("test@example.com", true)
+	user, _ := NewUser(userID, email, NewProfile("Test User", ""))
+	user.ClearDomainEvents()
+
+	require.NoError(t, user.LinkGoogleAccount("google-456"))
+	assert.Equal(t, "google-456", user.GoogleID())
+	events := user.DomainEvents()
+	require.Len(t, events, 1)
+	linked, ok := events[0].(GoogleAccountLinkedEvent)
+	require.True(t, ok)
+	assert.Equal(t, "google-456", linked.GoogleID)
+
+	// Linking the same account again changes nothing
+	require.NoError(t, user.LinkGoogleAccount("google-456"))
+	assert.Len(t, user.DomainEvents(), 1)
+
+	assert.Equal(t, shared.ErrGoogleAccountConflict, user.LinkGoogleAccount("google-789"))
+	assert.Equal(t, "google-456", user.GoogleID())
+	assert.Equal(t, shared.ErrInvalidUserID, user.LinkGoogleAccount(""))
+}
+
+func TestUser_Roles(t *testing.T) {
+	userID, _ := NewUserID("google-user-123")
+	email, _ := NewEmail("test@example.com", true)
+	user, _ := NewUser(userID, email, NewProfile("Test User", ""))
+
+	// New users get the default role
+	assert.True(t, user.HasRole(RoleUser))
+	assert.False(t, user.HasRole(RoleAdmin))
+
+	user.GrantRole(RoleAdmin)
+	assert.True(t, user.HasRole(RoleAdmin))
+
+	user.RevokeRole(RoleAdmin)
+	assert.False(t, user.HasRole(RoleAdmin))
+}
+
+func TestUser_SelectOrganization(t *testing.T) {
+	userID, _ := NewUserID("google-user-123")
+	email, _ := NewEmail("test@example.com", true)
+	user, _ := NewUser(userID, email, NewProfile("Test User", ""))
+	assert.Empty(t, user.ActiveOrganizationID())
+
+	user.SelectOrganization("org-1")
+	assert.Equal(t, "org-1", user.ActiveOrganizationID())
+
+	// Leaving another organization keeps the selection
+	user.LeaveOrganization("org-2")
+	assert.Equal(t, "org-1", user.ActiveOrganizationID())
+
+	user.LeaveOrganization("org-1")
+	assert.Empty(t, user.ActiveOrganizationID())
+}
+
+func TestUser_SyncProfile_KeepsOverrides(t *testing.T) {
+	userID, _ := NewUserID("google-user-123")
+	email, _ := NewEmail("test@example.com", true)
+	user, _ := NewUser(userID, email, NewProfile("Google Name", "https://example.com/google.jpg"))
+
+	edited, err := user.Profile().WithDisplayName("My Name")
+	require.NoError(t, err)
+	user.EditProfile(edited, user.Preferences())
+
+	user.SyncProfile(NewProfile("New Google Name", "https://example.com/new-google.jpg"))
+
+	// The overridden name is kept; the picture follows the provider
+	assert.Equal(t, "My Name", user.Profile().Name())
+	assert.Equal(t, "https://example.com/new-google.jpg", user.Profile().Picture())
+}
+
+func TestUser_EditProfile(t *testing.T) {
+	userID, _ := NewUserID("google-user-123")
+	email, _ := NewEmail("test@example.com", true)
+	user, _ := NewUser(userID, email, NewProfile("Test User", ""))
+	user.ClearDomainEvents()
+
+	profile, err := user.Profile().WithLocale("ja-JP")
+	require.NoError(t, err)
+	prefs, err := NewPreferences(map[string]string{"theme": "dark"})
+	require.NoError(t, err)
+
+	user.EditProfile(profile, prefs)
+
+	assert.Equal(t, "ja-JP", user.Profile().Locale())
+	theme, ok := user.Preferences().Get("theme")
+	assert.True(t, ok)
+	assert.Equal(t, "dark", theme)
+
+	events := user.DomainEvents()
+	require.Len(t, events, 1)
+	assert.Equal(t, EventTypeUserProfileUpdated, events[0].EventType())
+}
+
 func TestUser_UpdateProfile(t *testing.T) {
 	userID, _ := NewUserID("google-user-123")
 	email, _ := NewEmail("test@example.com", true)
@@ -140,6 +251,9 @@ func TestUser_RecordLogin(t *testing.T) {
 	user.RecordLogin()
 
 	assert.True(t, user.UpdatedAt().After(originalUpdatedAt))
+	assert.Equal(t, 1, user.LoginCount())
+	assert.Equal(t, user.UpdatedAt(), user.LastLoginAt())
+	assert.Equal(t, []time.Time{user.LastLoginAt()}, user.LoginHistory().RecentLogins())
 
 	// Check login event was recorded
 	events := user.DomainEvents()
@@ -152,6 +266,238 @@ func TestUser_RecordLogin(t *testing.T) {
 	assert.Equal(t, email.Value(), loggedInEvent.Email)
 }
 
+func TestUser_RecordLogin_NewUserHasNoLogins(t *testing.T) {
+	userID, _ := NewUserID("google-user-123")
+	email, _ := NewEmail("test@example.com", true)
+	profile := NewProfile("Test User", "https://example.com/photo.jpg")
+
+	user, _ := NewUser(userID, email, profile)
+
+	assert.Equal(t, 0, user.LoginCount())
+	assert.True(t, user.LastLoginAt().IsZero())
+	assert.Empty(t, user.LoginHistory().RecentLogins())
+}
+
+func TestUser_FlagSuspiciousLogin(t *testing.T) {
+	userID, _ := NewUserID("google-user-123")
+	email, _ := NewEmail("test@example.com", true)
+	user, _ := NewUser(userID, email, NewProfile("Test User", ""))
+	user.ClearDomainEvents()
+
+	user.FlagSuspiciousLogin("session-1", []string{"new_device"})
+	user.DisownLogin("session-1")
+
+	events := user.DomainEvents()
+	require.Len(t, events, 2)
+
+	detected, ok := events[0].(SuspiciousLoginDetectedEvent)
+	require.True(t, ok)
+	assert.Equal(t, EventTypeSuspiciousLoginDetected, detected.EventType())
+	assert.Equal(t, "session-1", detected.SessionID)
+	assert.Equal(t, []string{"new_device"}, detected.Reasons)
+
+	disowned, ok := events[1].(LoginDisownedEvent)
+	require.True(t, ok)
+	assert.Equal(t, EventTypeLoginDisowned, disowned.EventType())
+	assert.Equal(t, "session-1", disowned.SessionID)
+}
+
+func TestUser_Suspend(t *testing.T) {
+	userID, _ := NewUserID("google-user-123")
+	email, _ := NewEmail("test@example.com", true)
+	profile := NewProfile("Test User", "https://example.com/photo.jpg")
+
+	user, _ := NewUser(userID, email, profile)
+	user.ClearDomainEvents()
+
+	err := user.Suspend("terms violation")
+
+	require.NoError(t, err)
+	assert.Equal(t, StatusSuspended, user.Status())
+	assert.False(t, user.IsActive())
+	assert.Equal(t, shared.ErrAccountSuspended, user.EnsureActive())
+
+	events := user.DomainEvents()
+	require.Len(t, events, 1)
+	suspendedEvent, ok := events[0].(UserSuspendedEvent)
+	require.True(t, ok)
+	assert.Equal(t, EventTypeUserSuspended, suspendedEvent.EventType())
+	assert.Equal(t, "terms violation", suspendedEvent.Reason)
+}
+
+func TestUser_Suspend_AlreadySuspended(t *testing.T) {
+	userID, _ := NewUserID("google-user-123")
+	email, _ := NewEmail("test@example.com", true)
+	profile := NewProfile("Test User", "https://example.com/photo.jpg")
+
+	user, _ := NewUser(userID, email, profile)
+	require.NoError(t, user.Suspend("first"))
+	user.ClearDomainEvents()
+
+	err := user.Suspend("second")
+
+	assert.Equal(t, shared.ErrInvalidStatusTransition, err)
+	assert.Empty(t, user.DomainEvents())
+}
+
+func TestUser_Reactivate(t *testing.T) {
+	userID, _ := NewUserID("google-user-123")
+	email, _ := NewEmail("test@example.com", true)
+	profile := NewProfile("Test User", "https://example.com/photo.jpg")
+
+	user, _ := NewUser(userID, email, profile)
+	require.NoError(t, user.Suspend("investigation"))
+	user.ClearDomainEvents()
+
+	err := user.Reactivate()
+
+	require.NoError(t, err)
+	assert.Equal(t, StatusActive, user.Status())
+	assert.NoError(t, user.EnsureActive())
+
+	events := user.DomainEvents()
+	require.Len(t, events, 1)
+	assert.Equal(t, EventTypeUserReactivated, events[0].EventType())
+}
+
+func TestUser_Reactivate_NotSuspended(t *testing.T) {
+	userID, _ := NewUserID("google-user-123")
+	email, _ := NewEmail("test@example.com", true)
+	profile := NewProfile("Test User", "https://example.com/photo.jpg")
+
+	user, _ := NewUser(userID, email, profile)
+
+	err := user.Reactivate()
+
+	assert.Equal(t, shared.ErrInvalidStatusTransition, err)
+	assert.Equal(t, StatusActive, user.Status())
+}
+
+func TestUser_MarkDeleted(t *testing.T) {
+	userID, _ := NewUserID("google-user-123")
+	email, _ := NewEmail("test@example.com", true)
+	profile := NewProfile("Test User", "https://example.com/photo.jpg")
+
+	user, _ := NewUser(userID, email, profile)
+	user.ClearDomainEvents()
+	purgeAt := time.Now().Add(30 * 24 * time.Hour)
+
+	err := user.MarkDeleted(purgeAt)
+
+	require.NoError(t, err)
+	assert.Equal(t, StatusDeleted, user.Status())
+	assert.Equal(t, shared.ErrAccountDeleted, user.EnsureActive())
+
+	events := user.DomainEvents()
+	require.Len(t, events, 1)
+	deletedEvent, ok := events[0].(UserDeletedEvent)
+	require.True(t, ok)
+	assert.Equal(t, EventTypeUserDeleted, deletedEvent.EventType())
+	assert.Equal(t, "test@example.com", deletedEvent.Email)
+	assert.Equal(t, purgeAt, deletedEvent.PurgeAt)
+}
+
+func TestUser_MarkDeleted_AlreadyDeleted(t *testing.T) {
+	userID, _ := NewUserID("google-user-123")
+	email, _ := NewEmail("test@example.com", true)
+	profile := NewProfile("Test User", "https://example.com/photo.jpg")
+
+	user, _ := NewUser(userID, email, profile)
+	require.NoError(t, user.MarkDeleted(time.Now()))
+
+	err := user.MarkDeleted(time.Now())
+
+	assert.Equal(t, shared.ErrInvalidStatusTransition, err)
+}
+
+func TestUser_MarkDeleted_SuspendedCannotReactivate(t *testing.T) {
+	userID, _ := NewUserID("google-user-123")
+	email, _ := NewEmail("test@example.com", true)
+	profile := NewProfile("Test User", "https://example.com/photo.jpg")
+
+	user, _ := NewUser(userID, email, profile)
+	require.NoError(t, user.Suspend("abuse"))
+	require.NoError(t, user.MarkDeleted(time.Now()))
+
+	assert.Equal(t, shared.ErrInvalidStatusTransition, user.Reactivate())
+	assert.Equal(t, StatusDeleted, user.Status())
+}
+
+func newImpersonationTestUsers(t *testing.T) (admin, target *User) {
+	t.Helper()
+
+	adminID, _ := NewUserID("admin-123")
+	adminEmail, _ := NewEmail("admin@example.com", true)
+	admin, err := NewUser(adminID, adminEmail, NewProfile("Admin", ""))
+	require.NoError(t, err)
+	admin.GrantRole(RoleAdmin)
+
+	userID, _ := NewUserID("google-user-123")
+	email, _ := NewEmail("test@example.com", true)
+	target, err = NewUser(userID, email, NewProfile("Test User", ""))
+	require.NoError(t, err)
+	target.ClearDomainEvents()
+
+	return admin, target
+}
+
+func TestUser_StartImpersonation(t *testing.T) {
+	admin, target := newImpersonationTestUsers(t)
+	expiresAt := time.Now().Add(15 * time.Minute)
+
+	err := target.StartImpersonation(admin, "ticket 42", expiresAt)
+
+	require.NoError(t, err)
+	events := target.DomainEvents()
+	require.Len(t, events, 1)
+	started, ok := events[0].(ImpersonationStartedEvent)
+	require.True(t, ok)
+	assert.Equal(t, "google-user-123", started.AggregateID())
+	assert.Equal(t, "admin-123", started.AdminID)
+	assert.Equal(t, "ticket 42", started.Reason)
+	assert.Equal(t, expiresAt, started.ExpiresAt)
+
+	target.EndImpersonation("admin-123")
+	events = target.DomainEvents()
+	require.Len(t, events, 2)
+	assert.Equal(t, EventTypeImpersonationEnded, events[1].EventType())
+}
+
+func TestUser_StartImpersonation_Rejected(t *testing.T) {
+	expiresAt := time.Now().Add(15 * time.Minute)
+
+	t.Run("caller is not an admin", func(t *testing.T) {
+		admin, target := newImpersonationTestUsers(t)
+		admin.RevokeRole(RoleAdmin)
+		assert.Equal(t, shared.ErrAdminRequired, target.StartImpersonation(admin, "", expiresAt))
+	})
+
+	t.Run("admin is suspended", func(t *testing.T) {
+		admin, target := newImpersonationTestUsers(t)
+		require.NoError(t, admin.Suspend("offboarded"))
+		assert.Equal(t, shared.ErrAdminRequired, target.StartImpersonation(admin, "", expiresAt))
+	})
+
+	t.Run("target is an admin", func(t *testing.T) {
+		admin, target := newImpersonationTestUsers(t)
+		target.GrantRole(RoleAdmin)
+		assert.Equal(t, shared.ErrImpersonationNotAllowed, target.StartImpersonation(admin, "", expiresAt))
+	})
+
+	t.Run("target is the admin", func(t *testing.T) {
+		admin, _ := newImpersonationTestUsers(t)
+		assert.Equal(t, shared.ErrImpersonationNotAllowed, admin.StartImpersonation(admin, "", expiresAt))
+	})
+
+	t.Run("target is deleted", func(t *testing.T) {
+		admin, target := newImpersonationTestUsers(t)
+		require.NoError(t, target.MarkDeleted(time.Now()))
+		target.ClearDomainEvents()
+		assert.Equal(t, shared.ErrImpersonationNotAllowed, target.StartImpersonation(admin, "", expiresAt))
+		assert.Empty(t, target.DomainEvents())
+	})
+}
+
 func TestUser_DomainEvents(t *testing.T) {
 	userID, _ := NewUserID("google-user-123")
 	email, _ := NewEmail("test@example.com", true)
@@ -197,3 +543,20 @@ func TestUserLoggedInEvent(t *testing.T) {
 	assert.Equal(t, "test@example.com", event.Email)
 	assert.False(t, event.OccurredAt().IsZero())
 }
+
+func TestUserSuspendedEvent(t *testing.T) {
+	event := NewUserSuspendedEvent("user-123", "abuse")
+
+	assert.Equal(t, EventTypeUserSuspended, event.EventType())
+	assert.Equal(t, "user-123", event.AggregateID())
+	assert.Equal(t, "user-123", event.UserID)
+	assert.Equal(t, "abuse", event.Reason)
+}
+
+func TestUserReactivatedEvent(t *testing.T) {
+	event := NewUserReactivatedEvent("user-123")
+
+	assert.Equal(t, EventTypeUserReactivated, event.EventType())
+	assert.Equal(t, "user-123", event.AggregateID())
+	assert.Equal(t, "user-123", event.UserID)
+}
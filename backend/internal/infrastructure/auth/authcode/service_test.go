@@ -0,0 +1,108 @@
+package authcode
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+func newTestGrant(expiresAt time.Time) ports.AuthorizationGrant {
+	return ports.AuthorizationGrant{
+		ClientID:      "wiki",
+		RedirectURI:   "https://wiki.example.com/callback",
+		UserID:        "user-123",
+		Scopes:        []string{"email", "openid"},
+		State:         "state-1",
+		Nonce:         "nonce-1",
+		CodeChallenge: "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM",
+		SessionID:     "session-1",
+		AuthTime:      time.Unix(1_699_999_000, 0),
+		AMR:           []string{ports.AMRFederated},
+		ACR:           ports.ACRSingleFactor,
+		ExpiresAt:     expiresAt,
+	}
+}
+
+func TestService_SealAndOpen(t *testing.T) {
+	service := NewCodeService("test-secret")
+	now := time.Unix(1_700_000_000, 0)
+	grant := newTestGrant(now.Add(time.Minute))
+
+	token, err := service.Seal(grant)
+	require.NoError(t, err)
+
+	opened, err := service.Open(token, now)
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, opened.ID)
+	grant.ID = opened.ID
+	assert.Equal(t, grant.ExpiresAt.Unix(), opened.ExpiresAt.Unix())
+	grant.ExpiresAt = opened.ExpiresAt
+	assert.Equal(t, &grant, opened)
+}
+
+func TestService_Seal_UniqueIDs(t *testing.T) {
+	service := NewCodeService("test-secret")
+	now := time.Unix(1_700_000_000, 0)
+
+	first, err := service.Seal(newTestGrant(now.Add(time.Minute)))
+	require.NoError(t, err)
+	second, err := service.Seal(newTestGrant(now.Add(time.Minute)))
+	require.NoError(t, err)
+
+	firstGrant, err := service.Open(first, now)
+	require.NoError(t, err)
+	secondGrant, err := service.Open(second, now)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, firstGrant.ID, secondGrant.ID)
+}
+
+func TestService_Open_Rejects(t *testing.T) {
+	service := NewCodeService("test-secret")
+	now := time.Unix(1_700_000_000, 0)
+
+	token, err := service.Seal(newTestGrant(now.Add(time.Minute)))
+	require.NoError(t, err)
+	encoded, signature, _ := strings.Cut(token, ".")
+
+	other, err := NewCodeService("other-secret").Seal(newTestGrant(now.Add(time.Minute)))
+	require.NoError(t, err)
+	// A consent request must not be accepted as an authorization code
+	consentRequest, err := NewConsentRequestService("test-secret").Seal(newTestGrant(now.Add(time.Minute)))
+	require.NoError(t, err)
+
+	tampered := newTestGrant(now.Add(time.Minute))
+	tampered.UserID = "attacker"
+	tamperedToken, err := service.Seal(tampered)
+	require.NoError(t, err)
+	tamperedPayload, _, _ := strings.Cut(tamperedToken, ".")
+
+	tests := []struct {
+		name  string
+		token string
+		now   time.Time
+	}{
+		{name: "empty", token: "", now: now},
+		{name: "no signature", token: encoded, now: now},
+		{name: "other secret", token: other, now: now},
+		{name: "consent request", token: consentRequest, now: now},
+		{name: "payload swapped", token: tamperedPayload + "." + signature, now: now},
+		{name: "expired", token: token, now: now.Add(time.Minute)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grant, err := service.Open(tt.token, tt.now)
+
+			assert.Nil(t, grant)
+			assert.Equal(t, shared.ErrInvalidGrant, err)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package clientauth
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+const (
+	// maxAssertionLifetime caps how far in the future an assertion may
+	// expire, which bounds how long its jti has to be remembered
+	maxAssertionLifetime = 5 * time.Minute
+
+	// clockSkew is the leeway allowed between the client's and our clock
+	clockSkew = 30 * time.Second
+)
+
+// AssertionVerifier verifies private_key_jwt client assertions (RFC 7523)
+// and implements ports.ClientAssertionVerifier
+type AssertionVerifier struct {
+	audience string
+	now      func() time.Time
+}
+
+// NewAssertionVerifier creates a verifier that accepts assertions whose aud
+// is audience, the URL of the token endpoint
+func NewAssertionVerifier(audience string) *AssertionVerifier {
+	return &AssertionVerifier{
+		audience: audience,
+		now:      time.Now,
+	}
+}
+
+// Verify checks that assertion was issued by clientID for this server and is
+// signed by the private key of publicKey (PEM)
+func (v *AssertionVerifier) Verify(assertion, clientID, publicKey string) (*ports.ClientAssertion, error) {
+	key, methods, err := parsePublicKey(publicKey)
+	if err != nil {
+		return nil, shared.ErrInvalidClient
+	}
+
+	claims := &jwt.RegisteredClaims{}
+	_, err = jwt.ParseWithClaims(assertion, claims,
+		func(*jwt.Token) (interface{}, error) { return key, nil },
+		jwt.WithValidMethods(methods),
+		jwt.WithIssuer(clientID),
+		jwt.WithSubject(clientID),
+		jwt.WithAudience(v.audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(clockSkew),
+		jwt.WithTimeFunc(v.now),
+	)
+	if err != nil {
+		return nil, shared.ErrInvalidClient
+	}
+
+	if claims.ID == "" || claims.ExpiresAt.Sub(v.now()) > maxAssertionLifetime {
+		return nil, shared.ErrInvalidClient
+	}
+
+	return &ports.ClientAssertion{
+		ID:        claims.ID,
+		ExpiresAt: claims.ExpiresAt.Time.Add(clockSkew),
+	}, nil
+}
+
+// parsePublicKey parses a PEM-encoded public key and returns the signing
+// algorithms allowed for it
+func parsePublicKey(publicKey string) (interface{}, []string, error) {
+	block, _ := pem.Decode([]byte(publicKey))
+	if block == nil {
+		return nil, nil, shared.ErrInvalidServiceClient
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch key.(type) {
+	case *rsa.PublicKey:
+		return key, []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512"}, nil
+	case *ecdsa.PublicKey:
+		return key, []string{"ES256", "ES384", "ES512"}, nil
+	case ed25519.PublicKey:
+		return key, []string{"EdDSA"}, nil
+	default:
+		return nil, nil, shared.ErrInvalidServiceClient
+	}
+}
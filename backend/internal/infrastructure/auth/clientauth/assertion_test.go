@@ -0,0 +1,110 @@
+package clientauth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+const testAudience = "https://api.example.com/oauth/token"
+
+// newTestKey returns an ECDSA key and its PEM-encoded public key
+func newTestKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+// signAssertion signs a client assertion for "billing", applying modify to its claims
+func signAssertion(t *testing.T, key *ecdsa.PrivateKey, modify func(*jwt.RegisteredClaims)) string {
+	t.Helper()
+
+	now := time.Now()
+	claims := &jwt.RegisteredClaims{
+		Issuer:    "billing",
+		Subject:   "billing",
+		Audience:  jwt.ClaimStrings{testAudience},
+		ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ID:        "jti-1",
+	}
+	if modify != nil {
+		modify(claims)
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestAssertionVerifier_Verify_Success(t *testing.T) {
+	key, publicKey := newTestKey(t)
+	assertion := signAssertion(t, key, nil)
+
+	verified, err := NewAssertionVerifier(testAudience).Verify(assertion, "billing", publicKey)
+
+	require.NoError(t, err)
+	assert.Equal(t, "jti-1", verified.ID)
+	assert.WithinDuration(t, time.Now().Add(time.Minute+clockSkew), verified.ExpiresAt, time.Second)
+}
+
+func TestAssertionVerifier_Verify_RejectsInvalidAssertions(t *testing.T) {
+	key, publicKey := newTestKey(t)
+	otherKey, _ := newTestKey(t)
+
+	tests := []struct {
+		name      string
+		assertion string
+	}{
+		{"wrong audience", signAssertion(t, key, func(c *jwt.RegisteredClaims) { c.Audience = jwt.ClaimStrings{"https://other.example.com"} })},
+		{"issued by another client", signAssertion(t, key, func(c *jwt.RegisteredClaims) { c.Issuer = "reports" })},
+		{"subject is another client", signAssertion(t, key, func(c *jwt.RegisteredClaims) { c.Subject = "reports" })},
+		{"expired", signAssertion(t, key, func(c *jwt.RegisteredClaims) { c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute)) })},
+		{"no expiry", signAssertion(t, key, func(c *jwt.RegisteredClaims) { c.ExpiresAt = nil })},
+		{"lifetime too long", signAssertion(t, key, func(c *jwt.RegisteredClaims) { c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(time.Hour)) })},
+		{"no jti", signAssertion(t, key, func(c *jwt.RegisteredClaims) { c.ID = "" })},
+		{"signed by another key", signAssertion(t, otherKey, nil)},
+		{"not a JWT", "not-a-jwt"},
+	}
+
+	verifier := NewAssertionVerifier(testAudience)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verified, err := verifier.Verify(tt.assertion, "billing", publicKey)
+
+			assert.Nil(t, verified)
+			assert.Equal(t, shared.ErrInvalidClient, err)
+		})
+	}
+}
+
+func TestAssertionVerifier_Verify_RejectsHMACWithPublicKey(t *testing.T) {
+	_, publicKey := newTestKey(t)
+	// An attacker who knows the public key must not be able to use it as an HMAC secret
+	forged, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Issuer:    "billing",
+		Subject:   "billing",
+		Audience:  jwt.ClaimStrings{testAudience},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		ID:        "jti-1",
+	}).SignedString([]byte(publicKey))
+	require.NoError(t, err)
+
+	_, err = NewAssertionVerifier(testAudience).Verify(forged, "billing", publicKey)
+
+	assert.Equal(t, shared.ErrInvalidClient, err)
+}
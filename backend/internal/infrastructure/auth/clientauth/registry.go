@@ -0,0 +1,84 @@
+package clientauth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/serviceclient"
+)
+
+// registration is one entry of a service clients file
+type registration struct {
+	ClientID     string   `json:"client_id"`
+	Name         string   `json:"name"`
+	SecretSHA256 string   `json:"secret_sha256"`
+	PublicKey    string   `json:"public_key"`
+	Scopes       []string `json:"scopes"`
+	RedirectURIs []string `json:"redirect_uris"`
+	Public       bool     `json:"public"`
+
+	TokenExchangeAudiences []string `json:"token_exchange_audiences"`
+}
+
+// LoadClients reads service client registrations from a JSON file holding
+// an array of {"client_id", "name", "scopes"} objects. Each also has either
+// "secret_sha256", the hex SHA-256 hash of a client secret, or
+// "public_key", a PEM public key for private_key_jwt authentication, or
+// "public": true for clients that cannot keep a secret. Clients that sign
+// users in with the authorization code flow list their "redirect_uris", and
+// clients that exchange users' tokens list the "token_exchange_audiences"
+// they may request.
+func LoadClients(path string) ([]*serviceclient.Client, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service clients: %w", err)
+	}
+
+	var registrations []registration
+	if err := json.Unmarshal(data, &registrations); err != nil {
+		return nil, fmt.Errorf("failed to parse service clients: %w", err)
+	}
+
+	clients := make([]*serviceclient.Client, 0, len(registrations))
+	seen := make(map[string]bool, len(registrations))
+	for i, reg := range registrations {
+		client, err := reg.toClient()
+		if err != nil {
+			return nil, fmt.Errorf("service client %d (%q): %w", i, reg.ClientID, err)
+		}
+		if len(reg.TokenExchangeAudiences) > 0 {
+			if err := client.AllowTokenExchange(reg.TokenExchangeAudiences); err != nil {
+				return nil, fmt.Errorf("service client %d (%q): invalid token_exchange_audiences: %w", i, reg.ClientID, err)
+			}
+		}
+		if seen[client.ID()] {
+			return nil, fmt.Errorf("service client %q is registered twice", client.ID())
+		}
+		seen[client.ID()] = true
+		clients = append(clients, client)
+	}
+
+	return clients, nil
+}
+
+// toClient validates a registration and creates its client
+func (r registration) toClient() (*serviceclient.Client, error) {
+	switch {
+	case r.SecretSHA256 != "" && r.PublicKey != "":
+		return nil, fmt.Errorf("set either secret_sha256 or public_key, not both")
+	case r.Public && (r.SecretSHA256 != "" || r.PublicKey != ""):
+		return nil, fmt.Errorf("public clients have no secret_sha256 or public_key")
+	case r.Public:
+		return serviceclient.NewPublicClient(r.ClientID, r.Name, r.Scopes, r.RedirectURIs...)
+	case r.SecretSHA256 != "":
+		return serviceclient.NewSecretClient(r.ClientID, r.Name, r.SecretSHA256, r.Scopes, r.RedirectURIs...)
+	case r.PublicKey != "":
+		if _, _, err := parsePublicKey(r.PublicKey); err != nil {
+			return nil, fmt.Errorf("invalid public_key: %w", err)
+		}
+		return serviceclient.NewPrivateKeyJWTClient(r.ClientID, r.Name, r.PublicKey, r.Scopes, r.RedirectURIs...)
+	default:
+		return nil, fmt.Errorf("secret_sha256, public_key or public is required")
+	}
+}
@@ -0,0 +1,107 @@
+package clientauth
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuki5155/go-google-auth/internal/domain/serviceclient"
+)
+
+// writeClientsFile writes registrations to a temporary file and returns its path
+func writeClientsFile(t *testing.T, registrations []map[string]any) string {
+	t.Helper()
+
+	data, err := json.Marshal(registrations)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "clients.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func TestLoadClients_Success(t *testing.T) {
+	_, publicKey := newTestKey(t)
+	path := writeClientsFile(t, []map[string]any{
+		{"client_id": "billing", "name": "Billing", "secret_sha256": serviceclient.HashSecret("s3cret"), "scopes": []string{"users:read"}},
+		{"client_id": "reports", "public_key": publicKey, "scopes": []string{"users:read", "reports:write"}},
+	})
+
+	clients, err := LoadClients(path)
+
+	require.NoError(t, err)
+	require.Len(t, clients, 2)
+	assert.Equal(t, serviceclient.AuthMethodClientSecret, clients[0].AuthMethod())
+	assert.True(t, clients[0].VerifySecret("s3cret"))
+	assert.Equal(t, serviceclient.AuthMethodPrivateKeyJWT, clients[1].AuthMethod())
+	assert.Equal(t, []string{"reports:write", "users:read"}, clients[1].Scopes())
+}
+
+func TestLoadClients_RelyingParties(t *testing.T) {
+	path := writeClientsFile(t, []map[string]any{
+		{"client_id": "wiki", "secret_sha256": serviceclient.HashSecret("s3cret"), "scopes": []string{"openid", "email"}, "redirect_uris": []string{"https://wiki.example.com/callback"}},
+		{"client_id": "spa", "public": true, "scopes": []string{"openid"}, "redirect_uris": []string{"http://localhost:5173/callback"}},
+	})
+
+	clients, err := LoadClients(path)
+
+	require.NoError(t, err)
+	require.Len(t, clients, 2)
+	assert.True(t, clients[0].AllowsRedirectURI("https://wiki.example.com/callback"))
+	assert.True(t, clients[0].CanUseClientCredentials())
+	assert.True(t, clients[1].IsPublic())
+	assert.True(t, clients[1].CanUseAuthorizationCode())
+	assert.False(t, clients[1].CanUseClientCredentials())
+}
+
+func TestLoadClients_TokenExchangeAudiences(t *testing.T) {
+	path := writeClientsFile(t, []map[string]any{
+		{"client_id": "orders", "secret_sha256": serviceclient.HashSecret("s3cret"), "scopes": []string{"inventory:read"}, "token_exchange_audiences": []string{"inventory"}},
+	})
+
+	clients, err := LoadClients(path)
+
+	require.NoError(t, err)
+	require.Len(t, clients, 1)
+	assert.True(t, clients[0].CanExchangeTokenFor("inventory"))
+}
+
+func TestLoadClients_InvalidRegistrations(t *testing.T) {
+	_, publicKey := newTestKey(t)
+	secretHash := serviceclient.HashSecret("s3cret")
+
+	tests := []struct {
+		name          string
+		registrations []map[string]any
+	}{
+		{"no credential", []map[string]any{{"client_id": "billing", "scopes": []string{"users:read"}}}},
+		{"secret and key", []map[string]any{{"client_id": "billing", "secret_sha256": secretHash, "public_key": publicKey, "scopes": []string{"users:read"}}}},
+		{"invalid public key", []map[string]any{{"client_id": "billing", "public_key": "not a key", "scopes": []string{"users:read"}}}},
+		{"no scopes", []map[string]any{{"client_id": "billing", "secret_sha256": secretHash}}},
+		{"public without redirect URIs", []map[string]any{{"client_id": "spa", "public": true, "scopes": []string{"openid"}}}},
+		{"public with secret", []map[string]any{{"client_id": "spa", "public": true, "secret_sha256": secretHash, "scopes": []string{"openid"}, "redirect_uris": []string{"https://app.example.com/callback"}}}},
+		{"public with token exchange", []map[string]any{{"client_id": "spa", "public": true, "scopes": []string{"openid"}, "redirect_uris": []string{"https://app.example.com/callback"}, "token_exchange_audiences": []string{"inventory"}}}},
+		{"invalid redirect URI", []map[string]any{{"client_id": "billing", "secret_sha256": secretHash, "scopes": []string{"openid"}, "redirect_uris": []string{"http://app.example.com/callback"}}}},
+		{"registered twice", []map[string]any{
+			{"client_id": "billing", "secret_sha256": secretHash, "scopes": []string{"users:read"}},
+			{"client_id": "billing", "secret_sha256": secretHash, "scopes": []string{"users:read"}},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clients, err := LoadClients(writeClientsFile(t, tt.registrations))
+
+			assert.Nil(t, clients)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoadClients_MissingFile(t *testing.T) {
+	_, err := LoadClients(filepath.Join(t.TempDir(), "missing.json"))
+
+	assert.Error(t, err)
+}
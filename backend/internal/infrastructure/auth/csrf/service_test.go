@@ -0,0 +1,60 @@
+package csrf
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_GenerateAndVerify(t *testing.T) {
+	service := NewService("test-secret")
+
+	token, err := service.Generate("session-1")
+
+	require.NoError(t, err)
+	assert.Contains(t, token, ".")
+	assert.True(t, service.Verify(token, "session-1"))
+}
+
+func TestService_Generate_Unique(t *testing.T) {
+	service := NewService("test-secret")
+
+	first, err := service.Generate("session-1")
+	require.NoError(t, err)
+	second, err := service.Generate("session-1")
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first, second)
+}
+
+func TestService_Verify_Rejects(t *testing.T) {
+	service := NewService("test-secret")
+	token, err := service.Generate("session-1")
+	require.NoError(t, err)
+
+	nonce, _, _ := strings.Cut(token, ".")
+	other, _ := NewService("other-secret").Generate("session-1")
+	anonymous, _ := service.Generate("")
+	otherSession, _ := service.Generate("session-2")
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"no signature", nonce},
+		{"empty signature", nonce + "."},
+		{"tampered signature", nonce + ".AAAA"},
+		{"signed with another secret", other},
+		{"issued without a session", anonymous},
+		{"issued for another session", otherSession},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.False(t, service.Verify(tt.token, "session-1"))
+		})
+	}
+}
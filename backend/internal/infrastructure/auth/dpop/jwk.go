@@ -0,0 +1,131 @@
+package dpop
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// minRSAKeyBits is the smallest RSA key accepted for proofs
+const minRSAKeyBits = 2048
+
+// errInvalidKey is returned for a jwk header that is not a usable public key
+var errInvalidKey = errors.New("invalid DPoP proof key")
+
+// jsonWebKey is the public key in a proof's jwk header (RFC 7517)
+type jsonWebKey struct {
+	KeyType string `json:"kty"`
+	Curve   string `json:"crv"`
+	X       string `json:"x"`
+	Y       string `json:"y"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+	D       string `json:"d"` // set on private keys, which must never be sent
+}
+
+// parseJWK reads a jwk header into a key
+func parseJWK(header interface{}) (*jsonWebKey, error) {
+	if _, ok := header.(map[string]interface{}); !ok {
+		return nil, errInvalidKey
+	}
+	data, err := json.Marshal(header)
+	if err != nil {
+		return nil, errInvalidKey
+	}
+
+	var key jsonWebKey
+	if err := json.Unmarshal(data, &key); err != nil || key.D != "" {
+		return nil, errInvalidKey
+	}
+	return &key, nil
+}
+
+// publicKey returns the key for verifying signatures and the algorithms
+// allowed for it
+func (k *jsonWebKey) publicKey() (interface{}, []string, error) {
+	switch k.KeyType {
+	case "EC":
+		return k.ecdsaKey()
+	case "RSA":
+		return k.rsaKey()
+	case "OKP":
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if k.Curve != "Ed25519" || err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, nil, errInvalidKey
+		}
+		return ed25519.PublicKey(x), []string{"EdDSA"}, nil
+	default:
+		return nil, nil, errInvalidKey
+	}
+}
+
+// ecdsaKey returns an EC key, checking that its point is on the curve
+func (k *jsonWebKey) ecdsaKey() (interface{}, []string, error) {
+	var curve elliptic.Curve
+	var method string
+	switch k.Curve {
+	case "P-256":
+		curve, method = elliptic.P256(), "ES256"
+	case "P-384":
+		curve, method = elliptic.P384(), "ES384"
+	case "P-521":
+		curve, method = elliptic.P521(), "ES512"
+	default:
+		return nil, nil, errInvalidKey
+	}
+
+	size := (curve.Params().BitSize + 7) / 8
+	x, errX := base64.RawURLEncoding.DecodeString(k.X)
+	y, errY := base64.RawURLEncoding.DecodeString(k.Y)
+	if errX != nil || errY != nil || len(x) != size || len(y) != size {
+		return nil, nil, errInvalidKey
+	}
+
+	point := append(append([]byte{4}, x...), y...)
+	key, err := ecdsa.ParseUncompressedPublicKey(curve, point)
+	if err != nil {
+		return nil, nil, errInvalidKey
+	}
+	return key, []string{method}, nil
+}
+
+// rsaKey returns an RSA key of at least minRSAKeyBits
+func (k *jsonWebKey) rsaKey() (interface{}, []string, error) {
+	n, errN := base64.RawURLEncoding.DecodeString(k.N)
+	e, errE := base64.RawURLEncoding.DecodeString(k.E)
+	if errN != nil || errE != nil || len(e) == 0 || len(e) > 4 {
+		return nil, nil, errInvalidKey
+	}
+
+	key := &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}
+	if key.N.BitLen() < minRSAKeyBits || key.E < 3 {
+		return nil, nil, errInvalidKey
+	}
+	return key, []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512"}, nil
+}
+
+// thumbprint returns the JWK SHA-256 thumbprint of the key (RFC 7638)
+func (k *jsonWebKey) thumbprint() string {
+	// The required members are in lexicographic order, without whitespace
+	var canonical string
+	switch k.KeyType {
+	case "EC":
+		canonical = fmt.Sprintf(`{"crv":%q,"kty":"EC","x":%q,"y":%q}`, k.Curve, k.X, k.Y)
+	case "RSA":
+		canonical = fmt.Sprintf(`{"e":%q,"kty":"RSA","n":%q}`, k.E, k.N)
+	case "OKP":
+		canonical = fmt.Sprintf(`{"crv":%q,"kty":"OKP","x":%q}`, k.Curve, k.X)
+	}
+	sum := sha256.Sum256([]byte(canonical))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
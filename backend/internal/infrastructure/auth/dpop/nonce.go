@@ -0,0 +1,40 @@
+package dpop
+
+import (
+	"crypto/hmac"
+	"encoding/base64"
+	"encoding/binary"
+	"time"
+)
+
+// nonceMACSize is the number of MAC bytes in a nonce
+const nonceMACSize = 16
+
+// Nonce returns a fresh nonce for the DPoP-Nonce header. Nonces are
+// stateless, the time they were issued and a MAC over it, so that every
+// server instance accepts the nonces of the others.
+func (v *Verifier) Nonce() string {
+	issuedAt := make([]byte, 8)
+	binary.BigEndian.PutUint64(issuedAt, uint64(v.now().Unix()))
+	return base64.RawURLEncoding.EncodeToString(append(issuedAt, v.nonceMAC(issuedAt)...))
+}
+
+// validNonce reports whether nonce was issued by Nonce within the nonce lifetime
+func (v *Verifier) validNonce(nonce string) bool {
+	data, err := base64.RawURLEncoding.DecodeString(nonce)
+	if err != nil || len(data) != 8+nonceMACSize {
+		return false
+	}
+	issuedAt, mac := data[:8], data[8:]
+	if !hmac.Equal(mac, v.nonceMAC(issuedAt)) {
+		return false
+	}
+
+	age := v.now().Sub(time.Unix(int64(binary.BigEndian.Uint64(issuedAt)), 0))
+	return age >= -clockSkew && age <= v.nonceTTL
+}
+
+// nonceMAC authenticates the issue time of a nonce
+func (v *Verifier) nonceMAC(issuedAt []byte) []byte {
+	return v.nonces.MAC(issuedAt)[:nonceMACSize]
+}
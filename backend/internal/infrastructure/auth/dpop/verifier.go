@@ -0,0 +1,135 @@
+// Package dpop verifies DPoP proofs (RFC 9449), the signed JWTs with which
+// clients prove they hold the private key their tokens are bound to.
+package dpop
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/auth/signedtoken"
+)
+
+const (
+	// proofType is the typ header of DPoP proofs
+	proofType = "dpop+jwt"
+
+	// maxProofAge is how long after its iat a proof is accepted, which
+	// bounds how long its jti has to be remembered
+	maxProofAge = time.Minute
+
+	// clockSkew is the leeway allowed between the client's and our clock
+	clockSkew = 30 * time.Second
+)
+
+// signingMethods are the asymmetric algorithms proofs may be signed with
+var signingMethods = []string{"ES256", "ES384", "ES512", "RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "EdDSA"}
+
+// proofClaims are the claims of a DPoP proof
+type proofClaims struct {
+	Method          string `json:"htm"`
+	URL             string `json:"htu"`
+	AccessTokenHash string `json:"ath"`
+	Nonce           string `json:"nonce"`
+	jwt.RegisteredClaims
+}
+
+// Verifier verifies DPoP proofs and implements ports.DPoPVerifier
+type Verifier struct {
+	baseURL  string
+	nonces   *signedtoken.Signer
+	nonceTTL time.Duration
+	now      func() time.Time
+}
+
+// NewVerifier creates a verifier for proofs made for requests to baseURL,
+// the public URL of the API. Nonces are signed with secret and accepted for
+// nonceTTL.
+func NewVerifier(baseURL, secret string, nonceTTL time.Duration) *Verifier {
+	return &Verifier{
+		baseURL:  strings.TrimSuffix(baseURL, "/"),
+		nonces:   signedtoken.New(secret, "dpop-nonce-key"),
+		nonceTTL: nonceTTL,
+		now:      time.Now,
+	}
+}
+
+// Verify checks that proof is signed by the key in its jwk header and was
+// made for req, with a nonce from Nonce
+func (v *Verifier) Verify(proof string, req ports.DPoPProofRequest) (*ports.DPoPProof, error) {
+	var key *jsonWebKey
+	claims := &proofClaims{}
+	_, err := jwt.ParseWithClaims(proof, claims,
+		func(token *jwt.Token) (interface{}, error) {
+			if token.Header["typ"] != proofType {
+				return nil, errInvalidKey
+			}
+			jwk, err := parseJWK(token.Header["jwk"])
+			if err != nil {
+				return nil, err
+			}
+			publicKey, methods, err := jwk.publicKey()
+			if err != nil || !slices.Contains(methods, token.Method.Alg()) {
+				return nil, errInvalidKey
+			}
+			key = jwk
+			return publicKey, nil
+		},
+		jwt.WithValidMethods(signingMethods),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(clockSkew),
+		jwt.WithTimeFunc(v.now),
+	)
+	if err != nil {
+		return nil, shared.ErrInvalidDPoPProof
+	}
+
+	if claims.ID == "" || claims.IssuedAt == nil || v.now().Sub(claims.IssuedAt.Time) > maxProofAge {
+		return nil, shared.ErrInvalidDPoPProof
+	}
+	if claims.Method != req.Method || !v.matchesURL(claims.URL, req.Path) {
+		return nil, shared.ErrInvalidDPoPProof
+	}
+	if req.AccessToken != "" && claims.AccessTokenHash != accessTokenHash(req.AccessToken) {
+		return nil, shared.ErrInvalidDPoPProof
+	}
+	if !v.validNonce(claims.Nonce) {
+		return nil, shared.ErrUseDPoPNonce
+	}
+
+	return &ports.DPoPProof{
+		ID:         claims.ID,
+		Thumbprint: key.thumbprint(),
+		ExpiresAt:  claims.IssuedAt.Add(maxProofAge + clockSkew),
+	}, nil
+}
+
+// matchesURL reports whether htu names path on the API, ignoring its query
+// and fragment (RFC 9449, section 4.3)
+func (v *Verifier) matchesURL(htu, path string) bool {
+	got, err := url.Parse(htu)
+	if err != nil {
+		return false
+	}
+	want, err := url.Parse(v.baseURL + path)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(got.Scheme, want.Scheme) &&
+		strings.EqualFold(got.Host, want.Host) &&
+		got.EscapedPath() == want.EscapedPath()
+}
+
+// accessTokenHash returns the ath of an access token: the base64url-encoded
+// SHA-256 hash of it
+func accessTokenHash(accessToken string) string {
+	sum := sha256.Sum256([]byte(accessToken))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,211 @@
+package dpop
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+const testBaseURL = "https://api.example.com"
+
+var refreshRequest = ports.DPoPProofRequest{Method: "POST", Path: "/auth/refresh"}
+
+// testClient holds a client's DPoP key
+type testClient struct {
+	key    crypto.Signer
+	method jwt.SigningMethod
+	jwk    map[string]interface{}
+}
+
+func newECClient(t *testing.T) *testClient {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	point, err := key.PublicKey.Bytes()
+	require.NoError(t, err)
+	return &testClient{key: key, method: jwt.SigningMethodES256, jwk: map[string]interface{}{
+		"kty": "EC",
+		"crv": "P-256",
+		"x":   base64.RawURLEncoding.EncodeToString(point[1:33]),
+		"y":   base64.RawURLEncoding.EncodeToString(point[33:]),
+	}}
+}
+
+func newEd25519Client(t *testing.T) *testClient {
+	t.Helper()
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return &testClient{key: private, method: jwt.SigningMethodEdDSA, jwk: map[string]interface{}{
+		"kty": "OKP",
+		"crv": "Ed25519",
+		"x":   base64.RawURLEncoding.EncodeToString(public),
+	}}
+}
+
+// proof signs a DPoP proof with claims on top of valid defaults for refreshRequest
+func (c *testClient) proof(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	all := jwt.MapClaims{
+		"jti": rand.Text(),
+		"htm": "POST",
+		"htu": testBaseURL + "/auth/refresh",
+		"iat": time.Now().Unix(),
+	}
+	for name, value := range claims {
+		if value == nil {
+			delete(all, name)
+		} else {
+			all[name] = value
+		}
+	}
+
+	token := jwt.NewWithClaims(c.method, all)
+	token.Header["typ"] = proofType
+	token.Header["jwk"] = c.jwk
+	signed, err := token.SignedString(c.key)
+	require.NoError(t, err)
+	return signed
+}
+
+func newTestVerifier() *Verifier {
+	return NewVerifier(testBaseURL+"/", "test-secret", 5*time.Minute)
+}
+
+func TestVerifier_ValidProof(t *testing.T) {
+	verifier := newTestVerifier()
+
+	for _, client := range []*testClient{newECClient(t), newEd25519Client(t)} {
+		key, err := parseJWK(client.jwk)
+		require.NoError(t, err)
+		proof := client.proof(t, jwt.MapClaims{"jti": "jti-1", "nonce": verifier.Nonce()})
+
+		result, err := verifier.Verify(proof, refreshRequest)
+
+		require.NoError(t, err)
+		assert.Equal(t, "jti-1", result.ID)
+		assert.Equal(t, key.thumbprint(), result.Thumbprint)
+		assert.WithinDuration(t, time.Now().Add(maxProofAge+clockSkew), result.ExpiresAt, 2*time.Second)
+	}
+}
+
+func TestVerifier_AccessTokenHash(t *testing.T) {
+	verifier := newTestVerifier()
+	client := newECClient(t)
+	req := ports.DPoPProofRequest{Method: "GET", Path: "/api/me", AccessToken: "the-access-token"}
+	claims := jwt.MapClaims{"htm": "GET", "htu": testBaseURL + "/api/me?fields=email", "nonce": verifier.Nonce()}
+
+	claims["ath"] = accessTokenHash("the-access-token")
+	_, err := verifier.Verify(client.proof(t, claims), req)
+	assert.NoError(t, err)
+
+	claims["ath"] = accessTokenHash("another-token")
+	_, err = verifier.Verify(client.proof(t, claims), req)
+	assert.Equal(t, shared.ErrInvalidDPoPProof, err)
+
+	claims["ath"] = nil
+	_, err = verifier.Verify(client.proof(t, claims), req)
+	assert.Equal(t, shared.ErrInvalidDPoPProof, err)
+}
+
+func TestVerifier_InvalidProof(t *testing.T) {
+	verifier := newTestVerifier()
+	client := newECClient(t)
+	nonce := verifier.Nonce()
+
+	tests := []struct {
+		name  string
+		proof string
+	}{
+		{"not a JWT", "not-a-proof"},
+		{"other method", client.proof(t, jwt.MapClaims{"htm": "GET", "nonce": nonce})},
+		{"other URL", client.proof(t, jwt.MapClaims{"htu": testBaseURL + "/api/me", "nonce": nonce})},
+		{"other host", client.proof(t, jwt.MapClaims{"htu": "https://evil.example.com/auth/refresh", "nonce": nonce})},
+		{"no jti", client.proof(t, jwt.MapClaims{"jti": nil, "nonce": nonce})},
+		{"no iat", client.proof(t, jwt.MapClaims{"iat": nil, "nonce": nonce})},
+		{"too old", client.proof(t, jwt.MapClaims{"iat": time.Now().Add(-2 * time.Minute).Unix(), "nonce": nonce})},
+		{"from the future", client.proof(t, jwt.MapClaims{"iat": time.Now().Add(2 * time.Minute).Unix(), "nonce": nonce})},
+		{"wrong typ", func() string {
+			token := jwt.NewWithClaims(client.method, jwt.MapClaims{"jti": "x", "htm": "POST", "htu": testBaseURL + "/auth/refresh", "iat": time.Now().Unix(), "nonce": nonce})
+			token.Header["typ"] = "JWT"
+			token.Header["jwk"] = client.jwk
+			signed, _ := token.SignedString(client.key)
+			return signed
+		}()},
+		{"signed by another key", func() string {
+			other := newECClient(t)
+			other.jwk = client.jwk
+			return other.proof(t, jwt.MapClaims{"nonce": nonce})
+		}()},
+		{"private key in header", func() string {
+			leaky := *client
+			leaky.jwk = map[string]interface{}{"kty": "EC", "crv": "P-256", "x": client.jwk["x"], "y": client.jwk["y"], "d": "c2VjcmV0"}
+			return leaky.proof(t, jwt.MapClaims{"nonce": nonce})
+		}()},
+		{"symmetric algorithm", func() string {
+			token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"jti": "x", "htm": "POST", "htu": testBaseURL + "/auth/refresh", "iat": time.Now().Unix(), "nonce": nonce})
+			token.Header["typ"] = proofType
+			token.Header["jwk"] = map[string]interface{}{"kty": "oct", "k": "c2VjcmV0"}
+			signed, _ := token.SignedString([]byte("secret"))
+			return signed
+		}()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := verifier.Verify(tt.proof, refreshRequest)
+
+			assert.Nil(t, result)
+			assert.Equal(t, shared.ErrInvalidDPoPProof, err)
+		})
+	}
+}
+
+func TestVerifier_Nonce(t *testing.T) {
+	verifier := newTestVerifier()
+	client := newECClient(t)
+	now := time.Now()
+
+	stale := NewVerifier(testBaseURL, "test-secret", 5*time.Minute)
+	stale.now = func() time.Time { return now.Add(-10 * time.Minute) }
+	otherSecret := NewVerifier(testBaseURL, "other-secret", 5*time.Minute)
+
+	for name, nonce := range map[string]interface{}{
+		"missing":      nil,
+		"made up":      "bm9uY2U",
+		"expired":      stale.Nonce(),
+		"other secret": otherSecret.Nonce(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := verifier.Verify(client.proof(t, jwt.MapClaims{"nonce": nonce}), refreshRequest)
+
+			assert.Equal(t, shared.ErrUseDPoPNonce, err)
+		})
+	}
+}
+
+func TestThumbprint(t *testing.T) {
+	// The example of RFC 7638, section 3.1
+	key := &jsonWebKey{
+		KeyType: "RSA",
+		N:       "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+		E:       "AQAB",
+	}
+
+	assert.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", key.thumbprint())
+
+	_, methods, err := key.publicKey()
+	require.NoError(t, err)
+	assert.Contains(t, methods, "RS256")
+}
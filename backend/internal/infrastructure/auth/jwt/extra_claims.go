@@ -0,0 +1,91 @@
+package jwt
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+)
+
+// reservedClaims are the claims tokenClaims uses, which enrichers may not set
+var reservedClaims = []string{
+	"iss", "sub", "aud", "exp", "nbf", "iat", "jti",
+	"user_id", "email", "name", "picture", "sid", "amr", "auth_time", "acr",
+	"org_id", "client_id", "scope", "act", "cnf", "token_type",
+}
+
+// extraClaims runs the enrichers for user and checks what they add
+func (s *Service) extraClaims(user ports.UserInfo) (ports.ExtraClaims, error) {
+	if len(s.enrichers) == 0 {
+		return nil, nil
+	}
+
+	extras := ports.ExtraClaims{}
+	for _, enricher := range s.enrichers {
+		claims, err := enricher.EnrichClaims(user)
+		if err != nil {
+			return nil, fmt.Errorf("failed to enrich claims: %w", err)
+		}
+		for name, value := range claims {
+			if name == "" || slices.Contains(reservedClaims, name) {
+				return nil, fmt.Errorf("%w: %q", ports.ErrReservedClaim, name)
+			}
+			extras[name] = value
+		}
+	}
+
+	if len(extras) == 0 {
+		return nil, nil
+	}
+	if len(extras) > ports.MaxExtraClaims {
+		return nil, ports.ErrExtraClaimsTooLarge
+	}
+	data, err := json.Marshal(extras)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode extra claims: %w", err)
+	}
+	if len(data) > ports.MaxExtraClaimsSize {
+		return nil, ports.ErrExtraClaimsTooLarge
+	}
+
+	return extras, nil
+}
+
+// MarshalJSON writes Extras as top-level claims next to the others
+func (c tokenClaims) MarshalJSON() ([]byte, error) {
+	type plain tokenClaims
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.Extras) == 0 {
+		return data, err
+	}
+
+	extras, err := json.Marshal(c.Extras)
+	if err != nil {
+		return nil, err
+	}
+	// Both are non-empty objects, so they join into one
+	data = append(data[:len(data)-1], ',')
+	return append(data, extras[1:]...), nil
+}
+
+// UnmarshalJSON reads the claims, keeping unknown ones in Extras
+func (c *tokenClaims) UnmarshalJSON(data []byte) error {
+	type plain tokenClaims
+	if err := json.Unmarshal(data, (*plain)(c)); err != nil {
+		return err
+	}
+
+	var all map[string]any
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	for _, name := range reservedClaims {
+		delete(all, name)
+	}
+	c.Extras = nil
+	if len(all) > 0 {
+		c.Extras = all
+	}
+	return nil
+}
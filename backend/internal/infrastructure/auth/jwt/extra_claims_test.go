@@ -0,0 +1,153 @@
+package jwt
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+)
+
+// tenantEnricher adds the tenant and roles of every user
+var tenantEnricher = ports.ClaimsEnricherFunc(func(user ports.UserInfo) (ports.ExtraClaims, error) {
+	return ports.ExtraClaims{"tenant": "acme", "roles": []string{"admin", "billing"}}, nil
+})
+
+func TestClaimsEnrichers_AddClaimsToAccessTokens(t *testing.T) {
+	service := NewService(testSecretKey, WithClaimsEnrichers(tenantEnricher))
+
+	accessToken, refreshToken, err := service.GenerateTokenPair(ports.UserInfo{UserID: "user123", Email: "test@example.com"})
+	require.NoError(t, err)
+
+	claims, err := service.ValidateAccessToken(accessToken)
+	require.NoError(t, err)
+	assert.Equal(t, "user123", claims.UserID)
+	assert.Equal(t, "test@example.com", claims.Email)
+	tenant, ok := claims.Extras.String("tenant")
+	assert.True(t, ok)
+	assert.Equal(t, "acme", tenant)
+	roles, ok := claims.Extras.Strings("roles")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"admin", "billing"}, roles)
+
+	// Refresh tokens stay small; their access tokens are enriched again
+	refreshClaims, err := service.ValidateRefreshToken(refreshToken)
+	require.NoError(t, err)
+	assert.Nil(t, refreshClaims.Extras)
+}
+
+func TestClaimsEnrichers_RunAgainOnRefresh(t *testing.T) {
+	calls := 0
+	enricher := ports.ClaimsEnricherFunc(func(user ports.UserInfo) (ports.ExtraClaims, error) {
+		calls++
+		assert.Equal(t, "session-1", user.SessionID)
+		return ports.ExtraClaims{"plan": "pro", "beta": calls > 1}, nil
+	})
+	service := NewService(testSecretKey, WithClaimsEnrichers(enricher))
+
+	_, refreshToken, err := service.GenerateTokenPair(ports.UserInfo{UserID: "user123", SessionID: "session-1"})
+	require.NoError(t, err)
+	accessToken, err := service.RefreshAccessToken(refreshToken, "")
+	require.NoError(t, err)
+
+	claims, err := service.ValidateAccessToken(accessToken)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	beta, ok := claims.Extras.Bool("beta")
+	assert.True(t, ok)
+	assert.True(t, beta)
+}
+
+func TestClaimsEnrichers_LaterEnricherWins(t *testing.T) {
+	override := ports.ClaimsEnricherFunc(func(user ports.UserInfo) (ports.ExtraClaims, error) {
+		return ports.ExtraClaims{"tenant": "globex"}, nil
+	})
+	service := NewService(testSecretKey, WithClaimsEnrichers(tenantEnricher, override))
+
+	accessToken, _, err := service.GenerateTokenPair(ports.UserInfo{UserID: "user123"})
+	require.NoError(t, err)
+
+	claims, err := service.ValidateAccessToken(accessToken)
+	require.NoError(t, err)
+	tenant, _ := claims.Extras.String("tenant")
+	assert.Equal(t, "globex", tenant)
+	assert.Contains(t, claims.Extras, "roles")
+}
+
+func TestClaimsEnrichers_OnlySessionAccessTokens(t *testing.T) {
+	service := NewService(testSecretKey, WithClaimsEnrichers(tenantEnricher))
+
+	clientToken, err := service.GenerateClientToken("billing-service", nil)
+	require.NoError(t, err)
+	delegatedToken, err := service.GenerateDelegatedToken(ports.UserInfo{UserID: "user123"}, "wiki", []string{"openid"})
+	require.NoError(t, err)
+
+	for _, token := range []string{clientToken, delegatedToken} {
+		claims, err := service.ValidateAccessToken(token)
+		require.NoError(t, err)
+		assert.Nil(t, claims.Extras)
+	}
+}
+
+func TestClaimsEnrichers_Rejected(t *testing.T) {
+	tooMany := ports.ExtraClaims{}
+	for i := 0; i <= ports.MaxExtraClaims; i++ {
+		tooMany[strings.Repeat("x", i+1)] = true
+	}
+	enrichErr := errors.New("directory unavailable")
+
+	tests := []struct {
+		name   string
+		claims ports.ExtraClaims
+		err    error
+		want   error
+	}{
+		{"reserved claim", ports.ExtraClaims{"sub": "someone-else"}, nil, ports.ErrReservedClaim},
+		{"scope cannot be widened", ports.ExtraClaims{"scope": "admin"}, nil, ports.ErrReservedClaim},
+		{"too many claims", tooMany, nil, ports.ErrExtraClaimsTooLarge},
+		{"too large", ports.ExtraClaims{"blob": strings.Repeat("a", ports.MaxExtraClaimsSize)}, nil, ports.ErrExtraClaimsTooLarge},
+		{"enricher error", nil, enrichErr, enrichErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enricher := ports.ClaimsEnricherFunc(func(user ports.UserInfo) (ports.ExtraClaims, error) {
+				return tt.claims, tt.err
+			})
+			service := NewService(testSecretKey, WithClaimsEnrichers(enricher))
+
+			accessToken, refreshToken, err := service.GenerateTokenPair(ports.UserInfo{UserID: "user123"})
+
+			assert.ErrorIs(t, err, tt.want)
+			assert.Empty(t, accessToken)
+			assert.Empty(t, refreshToken)
+		})
+	}
+}
+
+func TestReservedClaims_CoverTokenClaims(t *testing.T) {
+	claims := NewService(testSecretKey).userClaims(ports.UserInfo{
+		UserID:    "user123",
+		SessionID: "session-1",
+		AMR:       []string{ports.AMRFederated},
+		AuthTime:  time.Now(),
+		ACR:       ports.ACRSingleFactor,
+	}, "access", time.Minute)
+	// Every claim a token can have is set
+	claims.ClientID = "wiki"
+	claims.Scope = "openid"
+	claims.Actor = &ports.Actor{Subject: "orders"}
+	claims.ID = "jti-1"
+	data, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	var all map[string]any
+	require.NoError(t, json.Unmarshal(data, &all))
+	for name := range all {
+		assert.Contains(t, reservedClaims, name)
+	}
+}
@@ -0,0 +1,138 @@
+package jwt
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+)
+
+// rsaKeyBits is the size of generated ID token signing keys
+const rsaKeyBits = 2048
+
+// IDTokenService signs OpenID Connect ID tokens with RS256 and implements
+// ports.IDTokenIssuer. Unlike access tokens, ID tokens are verified by other
+// applications, so they are signed with a private key rather than the secret.
+type IDTokenService struct {
+	issuer string
+	key    *rsa.PrivateKey
+	keyID  string
+	expiry time.Duration
+}
+
+// idTokenClaims represents the JWT claims of an ID token (OpenID Connect Core, section 2)
+type idTokenClaims struct {
+	Nonce         string           `json:"nonce,omitempty"`
+	SessionID     string           `json:"sid,omitempty"`
+	AuthTime      *jwt.NumericDate `json:"auth_time,omitempty"`
+	AMR           []string         `json:"amr,omitempty"`
+	ACR           string           `json:"acr,omitempty"`
+	Email         string           `json:"email,omitempty"`
+	EmailVerified *bool            `json:"email_verified,omitempty"`
+	Name          string           `json:"name,omitempty"`
+	Picture       string           `json:"picture,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// NewIDTokenService creates a new IDTokenService that names issuer in the
+// iss claim and signs with key
+func NewIDTokenService(issuer string, key *rsa.PrivateKey) *IDTokenService {
+	return &IDTokenService{
+		issuer: issuer,
+		key:    key,
+		keyID:  thumbprint(&key.PublicKey),
+		expiry: time.Hour, // ID tokens are only checked once, when the user signs in
+	}
+}
+
+// ParseRSAPrivateKey parses a PEM-encoded RSA private key in PKCS #1 or PKCS #8 form
+func ParseRSAPrivateKey(pemData string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(pemData))
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an RSA key")
+	}
+	return key, nil
+}
+
+// GenerateRSAKey generates a new ID token signing key
+func GenerateRSAKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, rsaKeyBits)
+}
+
+// IssueIDToken creates a signed ID token
+func (s *IDTokenService) IssueIDToken(claims ports.IDTokenClaims) (string, error) {
+	now := time.Now()
+	idClaims := idTokenClaims{
+		Nonce:     claims.Nonce,
+		SessionID: claims.SessionID,
+		AMR:       claims.AMR,
+		ACR:       claims.ACR,
+		Email:     claims.Email,
+		Name:      claims.Name,
+		Picture:   claims.Picture,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.issuer,
+			Subject:   claims.Subject,
+			Audience:  jwt.ClaimStrings{claims.Audience},
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.expiry)),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+	if !claims.AuthTime.IsZero() {
+		idClaims.AuthTime = jwt.NewNumericDate(claims.AuthTime)
+	}
+	// email_verified only accompanies an email address
+	if claims.Email != "" {
+		idClaims.EmailVerified = &claims.EmailVerified
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, idClaims)
+	token.Header["kid"] = s.keyID
+	return token.SignedString(s.key)
+}
+
+// PublicKeys returns the public half of the signing key
+func (s *IDTokenService) PublicKeys() []ports.JSONWebKey {
+	return []ports.JSONWebKey{{
+		KeyType:   "RSA",
+		Use:       "sig",
+		Algorithm: jwt.SigningMethodRS256.Alg(),
+		KeyID:     s.keyID,
+		Modulus:   base64.RawURLEncoding.EncodeToString(s.key.N.Bytes()),
+		Exponent:  base64.RawURLEncoding.EncodeToString(big.NewInt(int64(s.key.E)).Bytes()),
+	}}
+}
+
+// thumbprint returns the JWK thumbprint of a public key (RFC 7638), which
+// serves as its key ID so that a new key always gets a new ID
+func thumbprint(key *rsa.PublicKey) string {
+	// The members are required to be in lexicographic order, without whitespace
+	canonical := fmt.Sprintf(`{"e":%q,"kty":"RSA","n":%q}`,
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+	)
+	sum := sha256.Sum256([]byte(canonical))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,118 @@
+package jwt
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+)
+
+// publicKeyFromJWK rebuilds an RSA public key the way a relying party would
+func publicKeyFromJWK(t *testing.T, key ports.JSONWebKey) *rsa.PublicKey {
+	t.Helper()
+
+	n, err := base64.RawURLEncoding.DecodeString(key.Modulus)
+	require.NoError(t, err)
+	e, err := base64.RawURLEncoding.DecodeString(key.Exponent)
+	require.NoError(t, err)
+
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+}
+
+func TestIDTokenService_IssueIDToken(t *testing.T) {
+	key, err := GenerateRSAKey()
+	require.NoError(t, err)
+	service := NewIDTokenService("https://auth.example.com", key)
+	authTime := time.Now().Add(-time.Minute).Truncate(time.Second)
+
+	token, err := service.IssueIDToken(ports.IDTokenClaims{
+		Subject:       "user-123",
+		Audience:      "wiki",
+		Nonce:         "nonce-1",
+		AuthTime:      authTime,
+		AMR:           []string{ports.AMRFederated},
+		ACR:           ports.ACRSingleFactor,
+		Email:         "user@example.com",
+		EmailVerified: true,
+	})
+	require.NoError(t, err)
+
+	keys := service.PublicKeys()
+	require.Len(t, keys, 1)
+	assert.Equal(t, "RSA", keys[0].KeyType)
+	assert.Equal(t, "RS256", keys[0].Algorithm)
+
+	var claims idTokenClaims
+	parsed, err := jwt.ParseWithClaims(token, &claims, func(token *jwt.Token) (interface{}, error) {
+		assert.Equal(t, keys[0].KeyID, token.Header["kid"])
+		return publicKeyFromJWK(t, keys[0]), nil
+	},
+		jwt.WithValidMethods([]string{"RS256"}),
+		jwt.WithIssuer("https://auth.example.com"),
+		jwt.WithAudience("wiki"),
+	)
+	require.NoError(t, err)
+	require.True(t, parsed.Valid)
+
+	assert.Equal(t, "user-123", claims.Subject)
+	assert.Equal(t, "nonce-1", claims.Nonce)
+	assert.Equal(t, authTime, claims.AuthTime.Time)
+	assert.Equal(t, []string{ports.AMRFederated}, claims.AMR)
+	assert.Equal(t, "user@example.com", claims.Email)
+	require.NotNil(t, claims.EmailVerified)
+	assert.True(t, *claims.EmailVerified)
+	// Profile claims that were not granted are left out
+	assert.Empty(t, claims.Name)
+}
+
+func TestIDTokenService_OmitsEmailVerifiedWithoutEmail(t *testing.T) {
+	key, err := GenerateRSAKey()
+	require.NoError(t, err)
+	service := NewIDTokenService("https://auth.example.com", key)
+
+	token, err := service.IssueIDToken(ports.IDTokenClaims{Subject: "user-123", Audience: "wiki", EmailVerified: true})
+	require.NoError(t, err)
+
+	var claims idTokenClaims
+	_, _, err = jwt.NewParser().ParseUnverified(token, &claims)
+	require.NoError(t, err)
+	assert.Nil(t, claims.EmailVerified)
+}
+
+func TestParseRSAPrivateKey(t *testing.T) {
+	key, err := GenerateRSAKey()
+	require.NoError(t, err)
+
+	pkcs1 := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	pkcs8Bytes, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	pkcs8 := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8Bytes})
+
+	for _, data := range [][]byte{pkcs1, pkcs8} {
+		parsed, err := ParseRSAPrivateKey(string(data))
+		require.NoError(t, err)
+		assert.True(t, key.Equal(parsed))
+	}
+
+	_, err = ParseRSAPrivateKey("not a key")
+	assert.Error(t, err)
+}
+
+func TestThumbprint_IsStablePerKey(t *testing.T) {
+	key, err := GenerateRSAKey()
+	require.NoError(t, err)
+	other, err := GenerateRSAKey()
+	require.NoError(t, err)
+
+	assert.Equal(t, thumbprint(&key.PublicKey), NewIDTokenService("https://auth.example.com", key).PublicKeys()[0].KeyID)
+	assert.NotEqual(t, thumbprint(&key.PublicKey), thumbprint(&other.PublicKey))
+}
@@ -1,42 +1,163 @@
 package jwt
 
 import (
+	"crypto/rand"
+	"encoding/base64"
 	"errors"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/yuki5155/go-google-auth/internal/application/ports"
 )
 
+// tokenIDSize is the number of random bytes in a jti
+const tokenIDSize = 16
+
+// DefaultIssuer is the iss claim of tokens when no issuer is configured
+const DefaultIssuer = "go-google-auth"
+
 // Service handles JWT token operations and implements ports.TokenGenerator
 type Service struct {
 	secretKey          []byte
+	issuer             string
+	audiences          []string
+	enrichers          []ports.ClaimsEnricher
 	accessTokenExpiry  time.Duration
 	refreshTokenExpiry time.Duration
+	mfaTokenExpiry     time.Duration
+}
+
+// Option configures a Service
+type Option func(*Service)
+
+// WithIssuer sets the iss claim of issued tokens, which validation requires
+func WithIssuer(issuer string) Option {
+	return func(s *Service) {
+		if issuer != "" {
+			s.issuer = issuer
+		}
+	}
+}
+
+// WithAudiences sets the audiences that name this API. Tokens are issued for
+// the first one, and validation accepts any of them, so an audience can be
+// renamed without signing everyone out. It defaults to the issuer.
+func WithAudiences(audiences ...string) Option {
+	return func(s *Service) {
+		if len(audiences) > 0 {
+			s.audiences = audiences
+		}
+	}
 }
 
 // tokenClaims represents the internal JWT claims structure
 type tokenClaims struct {
-	UserID    string `json:"user_id"`
-	Email     string `json:"email"`
-	Name      string `json:"name"`
-	Picture   string `json:"picture"`
-	TokenType string `json:"token_type"` // "access" or "refresh"
+	UserID    string   `json:"user_id"`
+	Email     string   `json:"email"`
+	Name      string   `json:"name"`
+	Picture   string   `json:"picture"`
+	SessionID string   `json:"sid,omitempty"`
+	AMR       []string `json:"amr,omitempty"`
+	// AuthTime and ACR are named as in OpenID Connect Core
+	AuthTime  *jwt.NumericDate `json:"auth_time,omitempty"`
+	ACR       string           `json:"acr,omitempty"`
+	OrgID     string           `json:"org_id,omitempty"`    // selected organization
+	ClientID  string           `json:"client_id,omitempty"` // service client tokens (RFC 9068)
+	Scope     string           `json:"scope,omitempty"`     // space-separated scopes (RFC 9068)
+	Actor     *ports.Actor     `json:"act,omitempty"`       // exchanged tokens (RFC 8693)
+	Confirm   *confirmation    `json:"cnf,omitempty"`       // DPoP-bound tokens (RFC 9449)
+	TokenType string           `json:"token_type"`          // "access", "refresh" or "mfa_pending"
 	jwt.RegisteredClaims
+
+	// Extras are custom top-level claims from ports.ClaimsEnricher
+	Extras ports.ExtraClaims `json:"-"`
+}
+
+// confirmation names the key a token is bound to (RFC 7800)
+type confirmation struct {
+	JWKThumbprint string `json:"jkt"`
+}
+
+// toTokenClaims converts internal claims to ports.TokenClaims. ownAudiences
+// are left out of Audience, which only names other services.
+func (c *tokenClaims) toTokenClaims(ownAudiences []string) *ports.TokenClaims {
+	claims := &ports.TokenClaims{
+		UserID:    c.UserID,
+		Email:     c.Email,
+		Name:      c.Name,
+		Picture:   c.Picture,
+		SessionID: c.SessionID,
+		AMR:       c.AMR,
+		ACR:       c.ACR,
+		ClientID:  c.ClientID,
+		TokenID:   c.ID,
+		Actor:     c.Actor,
+		Extras:    c.Extras,
+
+		OrganizationID: c.OrgID,
+	}
+	if c.Confirm != nil {
+		claims.DPoPKey = c.Confirm.JWKThumbprint
+	}
+	if len(c.Audience) > 0 && !hasAudience(c.Audience, ownAudiences) {
+		claims.Audience = c.Audience[0]
+	}
+	if c.IssuedAt != nil {
+		claims.IssuedAt = c.IssuedAt.Time
+	}
+	if c.ExpiresAt != nil {
+		claims.ExpiresAt = c.ExpiresAt.Time
+	}
+	if c.AuthTime != nil {
+		claims.AuthTime = c.AuthTime.Time
+	}
+	// Scoped tokens get a non-nil slice, even when it is empty, so that they
+	// are never mistaken for unlimited user tokens
+	if c.ClientID != "" || c.Scope != "" {
+		claims.Scopes = append([]string{}, strings.Fields(c.Scope)...)
+	}
+	return claims
+}
+
+// hasAudience reports whether the aud claim names one of audiences
+func hasAudience(aud jwt.ClaimStrings, audiences []string) bool {
+	return slices.ContainsFunc(aud, func(audience string) bool {
+		return slices.Contains(audiences, audience)
+	})
+}
+
+// WithClaimsEnrichers adds custom claims to the access tokens of signed-in
+// users. The enrichers run in order each time a session's access token is
+// issued or refreshed.
+func WithClaimsEnrichers(enrichers ...ports.ClaimsEnricher) Option {
+	return func(s *Service) {
+		s.enrichers = append(s.enrichers, enrichers...)
+	}
 }
 
 // NewService creates a new JWT Service instance
-func NewService(secretKey string) *Service {
-	return &Service{
+func NewService(secretKey string, opts ...Option) *Service {
+	s := &Service{
 		secretKey:          []byte(secretKey),
+		issuer:             DefaultIssuer,
 		accessTokenExpiry:  15 * time.Minute,   // Access token expires in 15 minutes
 		refreshTokenExpiry: 7 * 24 * time.Hour, // Refresh token expires in 7 days
+		mfaTokenExpiry:     5 * time.Minute,    // MFA-pending token expires in 5 minutes
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if len(s.audiences) == 0 {
+		s.audiences = []string{s.issuer}
+	}
+	return s
 }
 
 // GenerateTokenPair generates both access and refresh tokens
 func (s *Service) GenerateTokenPair(user ports.UserInfo) (accessToken, refreshToken string, err error) {
-	accessToken, err = s.generateToken(user, "access", s.accessTokenExpiry)
+	accessToken, err = s.generateAccessToken(user)
 	if err != nil {
 		return "", "", err
 	}
@@ -51,35 +172,139 @@ func (s *Service) GenerateTokenPair(user ports.UserInfo) (accessToken, refreshTo
 
 // generateToken creates a JWT token with the specified claims
 func (s *Service) generateToken(user ports.UserInfo, tokenType string, expiry time.Duration) (string, error) {
+	return s.sign(s.userClaims(user, tokenType, expiry))
+}
+
+// generateAccessToken creates a session's access token, with the claims
+// the enrichers add
+func (s *Service) generateAccessToken(user ports.UserInfo) (string, error) {
+	claims := s.userClaims(user, "access", s.accessTokenExpiry)
+
+	extras, err := s.extraClaims(user)
+	if err != nil {
+		return "", err
+	}
+	claims.Extras = extras
+
+	return s.sign(claims)
+}
+
+// userClaims builds the claims of a token issued for a user
+func (s *Service) userClaims(user ports.UserInfo, tokenType string, expiry time.Duration) tokenClaims {
 	now := time.Now()
 	claims := tokenClaims{
 		UserID:    user.UserID,
 		Email:     user.Email,
 		Name:      user.Name,
 		Picture:   user.Picture,
+		SessionID: user.SessionID,
+		AMR:       user.AMR,
+		ACR:       user.ACR,
+		OrgID:     user.OrganizationID,
 		TokenType: tokenType,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(now.Add(expiry)),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
-			Issuer:    "go-google-auth",
+			Issuer:    s.issuer,
 			Subject:   user.UserID,
+			Audience:  jwt.ClaimStrings{s.audiences[0]},
 		},
 	}
 
+	if !user.AuthTime.IsZero() {
+		claims.AuthTime = jwt.NewNumericDate(user.AuthTime)
+	}
+	if user.DPoPKey != "" {
+		claims.Confirm = &confirmation{JWKThumbprint: user.DPoPKey}
+	}
+
+	return claims
+}
+
+// sign gives claims a random jti, so that single tokens can be revoked, and
+// signs them with the secret key
+func (s *Service) sign(claims tokenClaims) (string, error) {
+	id := make([]byte, tokenIDSize)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	claims.ID = base64.RawURLEncoding.EncodeToString(id)
+
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString(s.secretKey)
 }
 
-// ValidateToken validates a JWT token and returns the claims
-func (s *Service) validateToken(tokenString string) (*tokenClaims, error) {
+// GenerateClientToken generates an access token for a service client
+func (s *Service) GenerateClientToken(clientID string, scopes []string) (string, error) {
+	now := time.Now()
+	claims := tokenClaims{
+		ClientID:  clientID,
+		Scope:     strings.Join(scopes, " "),
+		TokenType: "access",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.accessTokenExpiry)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    s.issuer,
+			Subject:   clientID,
+			Audience:  jwt.ClaimStrings{s.audiences[0]},
+		},
+	}
+
+	return s.sign(claims)
+}
+
+// GenerateDelegatedToken generates an access token that a client uses on a
+// user's behalf, limited to the scopes the user granted it
+func (s *Service) GenerateDelegatedToken(user ports.UserInfo, clientID string, scopes []string) (string, error) {
+	claims := s.userClaims(user, "access", s.accessTokenExpiry)
+	claims.ClientID = clientID
+	claims.Scope = strings.Join(scopes, " ")
+
+	return s.sign(claims)
+}
+
+// GenerateExchangedToken generates an access token for token exchange. It
+// expires at token.ExpiresAt, which callers keep within the subject token's
+// lifetime.
+func (s *Service) GenerateExchangedToken(token ports.ExchangedToken) (string, error) {
+	claims := s.userClaims(token.User, "access", s.accessTokenExpiry)
+	claims.ClientID = token.ClientID
+	claims.Scope = strings.Join(token.Scopes, " ")
+	claims.Actor = token.Actor
+	claims.Audience = jwt.ClaimStrings{token.Audience}
+	claims.ExpiresAt = jwt.NewNumericDate(token.ExpiresAt)
+
+	return s.sign(claims)
+}
+
+// GenerateImpersonationToken generates an access token with which an
+// administrator acts as user. It has no session, so it cannot be refreshed.
+func (s *Service) GenerateImpersonationToken(user ports.UserInfo, adminID string, expiresAt time.Time) (string, error) {
+	claims := s.userClaims(user, "access", s.accessTokenExpiry)
+	claims.Actor = &ports.Actor{Subject: adminID}
+	claims.ExpiresAt = jwt.NewNumericDate(expiresAt)
+
+	extras, err := s.extraClaims(user)
+	if err != nil {
+		return "", err
+	}
+	claims.Extras = extras
+
+	return s.sign(claims)
+}
+
+// validateToken validates a JWT token and returns the claims. The token must
+// come from this service's issuer and name one of audiences in its aud claim.
+func (s *Service) validateToken(tokenString string, audiences []string) (*tokenClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &tokenClaims{}, func(token *jwt.Token) (interface{}, error) {
 		// Validate the signing method
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, ports.ErrInvalidToken
 		}
 		return s.secretKey, nil
-	})
+	}, jwt.WithIssuer(s.issuer), jwt.WithExpirationRequired())
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
@@ -93,12 +318,29 @@ func (s *Service) validateToken(tokenString string) (*tokenClaims, error) {
 		return nil, ports.ErrInvalidToken
 	}
 
+	// Any audience is accepted when audiences is nil
+	if audiences != nil && !hasAudience(claims.Audience, audiences) {
+		return nil, ports.ErrInvalidToken
+	}
+
 	return claims, nil
 }
 
-// ValidateAccessToken validates an access token and returns the claims
+// ValidateAccessToken validates an access token meant for this API and
+// returns the claims
 func (s *Service) ValidateAccessToken(tokenString string) (*ports.TokenClaims, error) {
-	claims, err := s.validateToken(tokenString)
+	return s.validateAccessToken(tokenString, s.audiences)
+}
+
+// ValidateIssuedAccessToken validates an access token issued by this service
+// for any audience and returns the claims
+func (s *Service) ValidateIssuedAccessToken(tokenString string) (*ports.TokenClaims, error) {
+	return s.validateAccessToken(tokenString, nil)
+}
+
+// validateAccessToken validates an access token meant for one of audiences
+func (s *Service) validateAccessToken(tokenString string, audiences []string) (*ports.TokenClaims, error) {
+	claims, err := s.validateToken(tokenString, audiences)
 	if err != nil {
 		return nil, err
 	}
@@ -108,17 +350,12 @@ func (s *Service) ValidateAccessToken(tokenString string) (*ports.TokenClaims, e
 	}
 
 	// Convert to ports.TokenClaims
-	return &ports.TokenClaims{
-		UserID:  claims.UserID,
-		Email:   claims.Email,
-		Name:    claims.Name,
-		Picture: claims.Picture,
-	}, nil
+	return claims.toTokenClaims(s.audiences), nil
 }
 
 // validateRefreshToken validates a refresh token
 func (s *Service) validateRefreshToken(tokenString string) (*tokenClaims, error) {
-	claims, err := s.validateToken(tokenString)
+	claims, err := s.validateToken(tokenString, s.audiences)
 	if err != nil {
 		return nil, err
 	}
@@ -130,21 +367,62 @@ func (s *Service) validateRefreshToken(tokenString string) (*tokenClaims, error)
 	return claims, nil
 }
 
-// RefreshAccessToken generates a new access token from a valid refresh token
-func (s *Service) RefreshAccessToken(refreshTokenString string) (string, error) {
+// ValidateRefreshToken validates a refresh token and returns the claims
+func (s *Service) ValidateRefreshToken(tokenString string) (*ports.TokenClaims, error) {
+	claims, err := s.validateRefreshToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	return claims.toTokenClaims(s.audiences), nil
+}
+
+// RefreshAccessToken generates a new access token from a valid refresh
+// token, bound to the DPoP key with thumbprint dpopKey unless it is empty
+func (s *Service) RefreshAccessToken(refreshTokenString, dpopKey string) (string, error) {
 	claims, err := s.validateRefreshToken(refreshTokenString)
 	if err != nil {
 		return "", err
 	}
 
 	user := ports.UserInfo{
-		UserID:  claims.UserID,
-		Email:   claims.Email,
-		Name:    claims.Name,
-		Picture: claims.Picture,
+		UserID:    claims.UserID,
+		Email:     claims.Email,
+		Name:      claims.Name,
+		Picture:   claims.Picture,
+		SessionID: claims.SessionID,
+		AMR:       claims.AMR,
+		ACR:       claims.ACR,
+		DPoPKey:   dpopKey,
+
+		OrganizationID: claims.OrgID,
+	}
+	// Refreshing is not a new authentication, so auth_time is carried over
+	if claims.AuthTime != nil {
+		user.AuthTime = claims.AuthTime.Time
 	}
 
-	return s.generateToken(user, "access", s.accessTokenExpiry)
+	// Enrichers run again, so the new token has current values
+	return s.generateAccessToken(user)
+}
+
+// GenerateMFAToken generates a short-lived MFA-pending token for a user
+func (s *Service) GenerateMFAToken(userID string, amr []string) (string, error) {
+	return s.generateToken(ports.UserInfo{UserID: userID, AMR: amr}, "mfa_pending", s.mfaTokenExpiry)
+}
+
+// ValidateMFAToken validates an MFA-pending token and returns the claims
+func (s *Service) ValidateMFAToken(tokenString string) (*ports.TokenClaims, error) {
+	claims, err := s.validateToken(tokenString, s.audiences)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.TokenType != "mfa_pending" {
+		return nil, ports.ErrInvalidToken
+	}
+
+	return claims.toTokenClaims(s.audiences), nil
 }
 
 // GetAccessTokenExpiry returns the access token expiry duration in seconds
@@ -156,3 +434,8 @@ func (s *Service) GetAccessTokenExpiry() int {
 func (s *Service) GetRefreshTokenExpiry() int {
 	return int(s.refreshTokenExpiry.Seconds())
 }
+
+// GetMFATokenExpiry returns the MFA-pending token expiry duration in seconds
+func (s *Service) GetMFATokenExpiry() int {
+	return int(s.mfaTokenExpiry.Seconds())
+}
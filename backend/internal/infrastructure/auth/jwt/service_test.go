@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/yuki5155/go-google-auth/internal/application/ports"
@@ -18,6 +19,8 @@ func TestNewService(t *testing.T) {
 	assert.Equal(t, []byte(testSecretKey), service.secretKey)
 	assert.Equal(t, 15*time.Minute, service.accessTokenExpiry)
 	assert.Equal(t, 7*24*time.Hour, service.refreshTokenExpiry)
+	assert.Equal(t, DefaultIssuer, service.issuer)
+	assert.Equal(t, []string{DefaultIssuer}, service.audiences)
 }
 
 func TestGenerateTokenPair(t *testing.T) {
@@ -126,6 +129,41 @@ func TestValidateAccessToken_ExpiredToken(t *testing.T) {
 	assert.Nil(t, claims)
 }
 
+func TestValidateRefreshToken_ValidToken(t *testing.T) {
+	service := NewService(testSecretKey)
+	user := ports.UserInfo{
+		UserID: "user123",
+		Email:  "test@example.com",
+		Name:   "Test User",
+	}
+
+	_, refreshToken, err := service.GenerateTokenPair(user)
+	require.NoError(t, err)
+
+	claims, err := service.ValidateRefreshToken(refreshToken)
+
+	require.NoError(t, err)
+	assert.Equal(t, user.UserID, claims.UserID)
+	assert.Equal(t, user.Email, claims.Email)
+	assert.Equal(t, user.Name, claims.Name)
+}
+
+func TestValidateRefreshToken_AccessTokenRejected(t *testing.T) {
+	service := NewService(testSecretKey)
+	user := ports.UserInfo{
+		UserID: "user123",
+		Email:  "test@example.com",
+	}
+
+	accessToken, _, err := service.GenerateTokenPair(user)
+	require.NoError(t, err)
+
+	claims, err := service.ValidateRefreshToken(accessToken)
+
+	assert.Equal(t, ports.ErrInvalidToken, err)
+	assert.Nil(t, claims)
+}
+
 func TestRefreshAccessToken_ValidRefreshToken(t *testing.T) {
 	service := NewService(testSecretKey)
 	user := ports.UserInfo{
@@ -138,7 +176,7 @@ func TestRefreshAccessToken_ValidRefreshToken(t *testing.T) {
 	_, refreshToken, err := service.GenerateTokenPair(user)
 	require.NoError(t, err)
 
-	newAccessToken, err := service.RefreshAccessToken(refreshToken)
+	newAccessToken, err := service.RefreshAccessToken(refreshToken, "")
 
 	require.NoError(t, err)
 	assert.NotEmpty(t, newAccessToken)
@@ -152,6 +190,153 @@ func TestRefreshAccessToken_ValidRefreshToken(t *testing.T) {
 	assert.Equal(t, user.Picture, claims.Picture)
 }
 
+func TestRefreshAccessToken_PreservesSessionID(t *testing.T) {
+	service := NewService(testSecretKey)
+	user := ports.UserInfo{
+		UserID:    "user123",
+		Email:     "test@example.com",
+		SessionID: "session-abc",
+	}
+
+	_, refreshToken, err := service.GenerateTokenPair(user)
+	require.NoError(t, err)
+
+	refreshClaims, err := service.ValidateRefreshToken(refreshToken)
+	require.NoError(t, err)
+	assert.Equal(t, "session-abc", refreshClaims.SessionID)
+
+	newAccessToken, err := service.RefreshAccessToken(refreshToken, "")
+	require.NoError(t, err)
+
+	accessClaims, err := service.ValidateAccessToken(newAccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, "session-abc", accessClaims.SessionID)
+}
+
+func TestRefreshAccessToken_BindsDPoPKey(t *testing.T) {
+	service := NewService(testSecretKey)
+	user := ports.UserInfo{UserID: "user123", SessionID: "session-abc"}
+
+	accessToken, refreshToken, err := service.GenerateTokenPair(user)
+	require.NoError(t, err)
+
+	claims, err := service.ValidateAccessToken(accessToken)
+	require.NoError(t, err)
+	assert.Empty(t, claims.DPoPKey)
+
+	boundToken, err := service.RefreshAccessToken(refreshToken, "key-thumbprint")
+	require.NoError(t, err)
+
+	claims, err = service.ValidateAccessToken(boundToken)
+	require.NoError(t, err)
+	assert.Equal(t, "key-thumbprint", claims.DPoPKey)
+}
+
+func TestRefreshAccessToken_PreservesAMR(t *testing.T) {
+	service := NewService(testSecretKey)
+	user := ports.UserInfo{
+		UserID: "user123",
+		AMR:    []string{ports.AMRFederated, ports.AMROTP, ports.AMRMultiFactor},
+	}
+
+	accessToken, refreshToken, err := service.GenerateTokenPair(user)
+	require.NoError(t, err)
+
+	accessClaims, err := service.ValidateAccessToken(accessToken)
+	require.NoError(t, err)
+	assert.Equal(t, user.AMR, accessClaims.AMR)
+
+	newAccessToken, err := service.RefreshAccessToken(refreshToken, "")
+	require.NoError(t, err)
+
+	refreshedClaims, err := service.ValidateAccessToken(newAccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, user.AMR, refreshedClaims.AMR)
+}
+
+func TestRefreshAccessToken_PreservesAuthTime(t *testing.T) {
+	service := NewService(testSecretKey)
+	authTime := time.Now().Add(-5 * time.Minute).Truncate(time.Second)
+	user := ports.UserInfo{
+		UserID:   "user123",
+		AuthTime: authTime,
+		ACR:      ports.ACRMultiFactor,
+	}
+
+	accessToken, refreshToken, err := service.GenerateTokenPair(user)
+	require.NoError(t, err)
+
+	accessClaims, err := service.ValidateAccessToken(accessToken)
+	require.NoError(t, err)
+	assert.True(t, authTime.Equal(accessClaims.AuthTime))
+	assert.Equal(t, ports.ACRMultiFactor, accessClaims.ACR)
+
+	newAccessToken, err := service.RefreshAccessToken(refreshToken, "")
+	require.NoError(t, err)
+
+	refreshedClaims, err := service.ValidateAccessToken(newAccessToken)
+	require.NoError(t, err)
+	assert.True(t, authTime.Equal(refreshedClaims.AuthTime))
+	assert.Equal(t, ports.ACRMultiFactor, refreshedClaims.ACR)
+}
+
+func TestRefreshAccessToken_PreservesOrganization(t *testing.T) {
+	service := NewService(testSecretKey)
+
+	accessToken, refreshToken, err := service.GenerateTokenPair(ports.UserInfo{
+		UserID:         "user123",
+		OrganizationID: "org-1",
+	})
+	require.NoError(t, err)
+
+	accessClaims, err := service.ValidateAccessToken(accessToken)
+	require.NoError(t, err)
+	assert.Equal(t, "org-1", accessClaims.OrganizationID)
+
+	newAccessToken, err := service.RefreshAccessToken(refreshToken, "")
+	require.NoError(t, err)
+
+	refreshedClaims, err := service.ValidateAccessToken(newAccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, "org-1", refreshedClaims.OrganizationID)
+}
+
+func TestValidateAccessToken_WithoutAuthTime(t *testing.T) {
+	service := NewService(testSecretKey)
+
+	accessToken, _, err := service.GenerateTokenPair(ports.UserInfo{UserID: "user123"})
+	require.NoError(t, err)
+
+	claims, err := service.ValidateAccessToken(accessToken)
+	require.NoError(t, err)
+	assert.True(t, claims.AuthTime.IsZero())
+	assert.False(t, claims.AuthenticatedWithin(time.Hour, time.Now()))
+}
+
+func TestMFAToken(t *testing.T) {
+	service := NewService(testSecretKey)
+
+	mfaToken, err := service.GenerateMFAToken("user123", []string{ports.AMRFederated})
+	require.NoError(t, err)
+
+	claims, err := service.ValidateMFAToken(mfaToken)
+	require.NoError(t, err)
+	assert.Equal(t, "user123", claims.UserID)
+	assert.Equal(t, []string{ports.AMRFederated}, claims.AMR)
+	assert.Equal(t, 300, service.GetMFATokenExpiry())
+
+	// An MFA-pending token is not an access or refresh token, and vice versa
+	_, err = service.ValidateAccessToken(mfaToken)
+	assert.Equal(t, ports.ErrInvalidToken, err)
+	_, err = service.ValidateRefreshToken(mfaToken)
+	assert.Equal(t, ports.ErrInvalidToken, err)
+
+	accessToken, _, err := service.GenerateTokenPair(ports.UserInfo{UserID: "user123"})
+	require.NoError(t, err)
+	_, err = service.ValidateMFAToken(accessToken)
+	assert.Equal(t, ports.ErrInvalidToken, err)
+}
+
 func TestRefreshAccessToken_InvalidRefreshToken(t *testing.T) {
 	service := NewService(testSecretKey)
 
@@ -165,7 +350,7 @@ func TestRefreshAccessToken_InvalidRefreshToken(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			newAccessToken, err := service.RefreshAccessToken(tt.token)
+			newAccessToken, err := service.RefreshAccessToken(tt.token, "")
 
 			assert.Error(t, err)
 			assert.Empty(t, newAccessToken)
@@ -183,7 +368,7 @@ func TestRefreshAccessToken_AccessTokenRejected(t *testing.T) {
 	accessToken, _, err := service.GenerateTokenPair(user)
 	require.NoError(t, err)
 
-	newAccessToken, err := service.RefreshAccessToken(accessToken)
+	newAccessToken, err := service.RefreshAccessToken(accessToken, "")
 
 	assert.Error(t, err)
 	assert.Empty(t, newAccessToken)
@@ -202,7 +387,7 @@ func TestRefreshAccessToken_ExpiredRefreshToken(t *testing.T) {
 
 	service.refreshTokenExpiry = 7 * 24 * time.Hour
 
-	newAccessToken, err := service.RefreshAccessToken(expiredRefreshToken)
+	newAccessToken, err := service.RefreshAccessToken(expiredRefreshToken, "")
 
 	assert.Error(t, err)
 	assert.Equal(t, ports.ErrExpiredToken, err)
@@ -244,7 +429,7 @@ func TestTokenLifecycle(t *testing.T) {
 	assert.Equal(t, user.UserID, accessClaims.UserID)
 
 	// 3. Refresh access token
-	newAccessToken, err := service.RefreshAccessToken(refreshToken)
+	newAccessToken, err := service.RefreshAccessToken(refreshToken, "")
 	require.NoError(t, err)
 
 	// 4. Validate new access token
@@ -300,3 +485,219 @@ func TestWrongSigningKey(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, claims)
 }
+
+func TestGenerateClientToken(t *testing.T) {
+	service := NewService(testSecretKey)
+
+	token, err := service.GenerateClientToken("billing-service", []string{"invoices:write", "users:read"})
+	require.NoError(t, err)
+
+	claims, err := service.ValidateAccessToken(token)
+
+	require.NoError(t, err)
+	assert.Equal(t, "billing-service", claims.ClientID)
+	assert.Equal(t, []string{"invoices:write", "users:read"}, claims.Scopes)
+	assert.Empty(t, claims.UserID)
+	assert.True(t, claims.IsServiceClient())
+	assert.True(t, claims.HasScope("users:read"))
+	assert.False(t, claims.HasScope("users:write"))
+}
+
+func TestGenerateClientToken_WithoutScopesIsStillLimited(t *testing.T) {
+	service := NewService(testSecretKey)
+
+	token, err := service.GenerateClientToken("billing-service", nil)
+	require.NoError(t, err)
+
+	claims, err := service.ValidateAccessToken(token)
+
+	require.NoError(t, err)
+	assert.NotNil(t, claims.Scopes)
+	assert.False(t, claims.HasScope("users:read"))
+}
+
+func TestUserTokens_AreNotServiceClientTokens(t *testing.T) {
+	service := NewService(testSecretKey)
+
+	accessToken, _, err := service.GenerateTokenPair(ports.UserInfo{UserID: "user123"})
+	require.NoError(t, err)
+
+	claims, err := service.ValidateAccessToken(accessToken)
+
+	require.NoError(t, err)
+	assert.Empty(t, claims.ClientID)
+	assert.Nil(t, claims.Scopes)
+	assert.False(t, claims.IsServiceClient())
+}
+
+func TestGenerateDelegatedToken(t *testing.T) {
+	service := NewService(testSecretKey)
+	authTime := time.Now().Add(-time.Minute).Truncate(time.Second)
+
+	token, err := service.GenerateDelegatedToken(ports.UserInfo{
+		UserID:    "user123",
+		Email:     "user@example.com",
+		SessionID: "session-1",
+		AuthTime:  authTime,
+	}, "wiki", []string{"email", "openid"})
+	require.NoError(t, err)
+
+	claims, err := service.ValidateAccessToken(token)
+
+	require.NoError(t, err)
+	assert.Equal(t, "user123", claims.UserID)
+	assert.Equal(t, "session-1", claims.SessionID)
+	assert.Equal(t, authTime, claims.AuthTime)
+	assert.Equal(t, "wiki", claims.ClientID)
+	assert.Equal(t, []string{"email", "openid"}, claims.Scopes)
+	// The client acts for a user, so this is not a service client token
+	assert.False(t, claims.IsServiceClient())
+	assert.False(t, claims.HasScope("profile:read"))
+}
+
+func TestTokens_HaveUniqueIDsAndLifetimes(t *testing.T) {
+	service := NewService(testSecretKey)
+
+	first, err := service.GenerateClientToken("billing-service", nil)
+	require.NoError(t, err)
+	second, err := service.GenerateClientToken("billing-service", nil)
+	require.NoError(t, err)
+
+	firstClaims, err := service.ValidateAccessToken(first)
+	require.NoError(t, err)
+	secondClaims, err := service.ValidateAccessToken(second)
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, firstClaims.TokenID)
+	assert.NotEqual(t, firstClaims.TokenID, secondClaims.TokenID)
+	assert.Equal(t, 15*time.Minute, firstClaims.ExpiresAt.Sub(firstClaims.IssuedAt))
+}
+
+func TestGenerateExchangedToken(t *testing.T) {
+	service := NewService(testSecretKey)
+	expiresAt := time.Now().Add(5 * time.Minute).Truncate(time.Second)
+	actor := &ports.Actor{Subject: "orders", Actor: &ports.Actor{Subject: "web-api"}}
+
+	token, err := service.GenerateExchangedToken(ports.ExchangedToken{
+		User:      ports.UserInfo{UserID: "user123", SessionID: "session-1"},
+		ClientID:  "orders",
+		Audience:  "inventory",
+		Scopes:    []string{"inventory:read"},
+		Actor:     actor,
+		ExpiresAt: expiresAt,
+	})
+	require.NoError(t, err)
+
+	// The token is meant for the inventory service, not this API
+	_, err = service.ValidateAccessToken(token)
+	assert.Equal(t, ports.ErrInvalidToken, err)
+
+	claims, err := service.ValidateIssuedAccessToken(token)
+
+	require.NoError(t, err)
+	assert.Equal(t, "user123", claims.UserID)
+	assert.Equal(t, "session-1", claims.SessionID)
+	assert.Equal(t, "orders", claims.ClientID)
+	assert.Equal(t, "inventory", claims.Audience)
+	assert.Equal(t, []string{"inventory:read"}, claims.Scopes)
+	assert.Equal(t, actor, claims.Actor)
+	assert.Equal(t, expiresAt, claims.ExpiresAt)
+}
+
+func TestGenerateImpersonationToken(t *testing.T) {
+	service := NewService(testSecretKey)
+	expiresAt := time.Now().Add(10 * time.Minute).Truncate(time.Second)
+
+	token, err := service.GenerateImpersonationToken(ports.UserInfo{UserID: "user123", Email: "test@example.com"}, "admin-1", expiresAt)
+	require.NoError(t, err)
+
+	claims, err := service.ValidateAccessToken(token)
+
+	require.NoError(t, err)
+	assert.Equal(t, "user123", claims.UserID)
+	assert.Equal(t, "test@example.com", claims.Email)
+	assert.Empty(t, claims.SessionID)
+	assert.Equal(t, &ports.Actor{Subject: "admin-1"}, claims.Actor)
+	assert.Equal(t, "admin-1", claims.ImpersonatorID())
+	assert.Equal(t, expiresAt, claims.ExpiresAt)
+}
+
+func TestTokens_NameIssuerAndAudience(t *testing.T) {
+	service := NewService(testSecretKey, WithIssuer("https://auth.example.com"), WithAudiences("https://api.example.com", "legacy-api"))
+
+	accessToken, refreshToken, err := service.GenerateTokenPair(ports.UserInfo{UserID: "user123"})
+	require.NoError(t, err)
+	clientToken, err := service.GenerateClientToken("billing-service", nil)
+	require.NoError(t, err)
+
+	for _, token := range []string{accessToken, refreshToken, clientToken} {
+		parsed := &tokenClaims{}
+		_, _, err := jwt.NewParser().ParseUnverified(token, parsed)
+		require.NoError(t, err)
+		assert.Equal(t, "https://auth.example.com", parsed.Issuer)
+		assert.Equal(t, jwt.ClaimStrings{"https://api.example.com"}, parsed.Audience)
+	}
+
+	claims, err := service.ValidateAccessToken(accessToken)
+	require.NoError(t, err)
+	// Audience only names other services
+	assert.Empty(t, claims.Audience)
+}
+
+func TestValidateAccessToken_OtherAcceptedAudience(t *testing.T) {
+	issuer := NewService(testSecretKey, WithAudiences("legacy-api"))
+	validator := NewService(testSecretKey, WithAudiences("https://api.example.com", "legacy-api"))
+
+	token, _, err := issuer.GenerateTokenPair(ports.UserInfo{UserID: "user123"})
+	require.NoError(t, err)
+
+	claims, err := validator.ValidateAccessToken(token)
+
+	require.NoError(t, err)
+	assert.Equal(t, "user123", claims.UserID)
+	assert.Empty(t, claims.Audience)
+}
+
+func TestValidateToken_RejectsWrongIssuerOrAudience(t *testing.T) {
+	service := NewService(testSecretKey, WithIssuer("https://auth.example.com"), WithAudiences("https://api.example.com"))
+
+	tests := []struct {
+		name    string
+		service *Service
+	}{
+		{"other issuer", NewService(testSecretKey, WithIssuer("https://other.example.com"), WithAudiences("https://api.example.com"))},
+		{"other audience", NewService(testSecretKey, WithIssuer("https://auth.example.com"), WithAudiences("https://other.example.com"))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessToken, refreshToken, err := tt.service.GenerateTokenPair(ports.UserInfo{UserID: "user123"})
+			require.NoError(t, err)
+
+			_, err = service.ValidateAccessToken(accessToken)
+			assert.Equal(t, ports.ErrInvalidToken, err)
+			_, err = service.ValidateRefreshToken(refreshToken)
+			assert.Equal(t, ports.ErrInvalidToken, err)
+		})
+	}
+
+	// Tokens of another issuer are not accepted for any audience either
+	token, _, err := tests[0].service.GenerateTokenPair(ports.UserInfo{UserID: "user123"})
+	require.NoError(t, err)
+	_, err = service.ValidateIssuedAccessToken(token)
+	assert.Equal(t, ports.ErrInvalidToken, err)
+}
+
+func TestValidateAccessToken_RejectsTokenWithoutAudience(t *testing.T) {
+	service := NewService(testSecretKey)
+
+	// Tokens issued before aud was recorded
+	claims := service.userClaims(ports.UserInfo{UserID: "user123"}, "access", time.Minute)
+	claims.Audience = nil
+	token, err := service.sign(claims)
+	require.NoError(t, err)
+
+	_, err = service.ValidateAccessToken(token)
+
+	assert.Equal(t, ports.ErrInvalidToken, err)
+}
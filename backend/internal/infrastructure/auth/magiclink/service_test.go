@@ -0,0 +1,82 @@
+package magiclink
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+func TestService_GenerateAndVerify(t *testing.T) {
+	service := NewService("test-secret")
+	now := time.Unix(1_700_000_000, 0)
+	expiresAt := now.Add(15 * time.Minute)
+
+	token, err := service.Generate("user@example.com", expiresAt)
+	require.NoError(t, err)
+
+	claims, err := service.Verify(token, now)
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, claims.ID)
+	assert.Equal(t, "user@example.com", claims.Email)
+	assert.True(t, expiresAt.Equal(claims.ExpiresAt))
+}
+
+func TestService_Generate_UniqueIDs(t *testing.T) {
+	service := NewService("test-secret")
+	now := time.Unix(1_700_000_000, 0)
+	expiresAt := now.Add(time.Minute)
+
+	first, err := service.Generate("user@example.com", expiresAt)
+	require.NoError(t, err)
+	second, err := service.Generate("user@example.com", expiresAt)
+	require.NoError(t, err)
+
+	firstClaims, err := service.Verify(first, now)
+	require.NoError(t, err)
+	secondClaims, err := service.Verify(second, now)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, firstClaims.ID, secondClaims.ID)
+}
+
+func TestService_Verify_Rejects(t *testing.T) {
+	service := NewService("test-secret")
+	now := time.Unix(1_700_000_000, 0)
+
+	token, err := service.Generate("user@example.com", now.Add(time.Minute))
+	require.NoError(t, err)
+	encoded, signature, _ := strings.Cut(token, ".")
+
+	other, err := NewService("other-secret").Generate("user@example.com", now.Add(time.Minute))
+	require.NoError(t, err)
+	tampered, err := service.Generate("attacker@example.com", now.Add(time.Minute))
+	require.NoError(t, err)
+	tamperedPayload, _, _ := strings.Cut(tampered, ".")
+
+	tests := []struct {
+		name  string
+		token string
+		now   time.Time
+	}{
+		{name: "empty", token: "", now: now},
+		{name: "no signature", token: encoded, now: now},
+		{name: "other secret", token: other, now: now},
+		{name: "payload swapped", token: tamperedPayload + "." + signature, now: now},
+		{name: "expired", token: token, now: now.Add(time.Minute)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := service.Verify(tt.token, tt.now)
+
+			assert.Nil(t, claims)
+			assert.Equal(t, shared.ErrInvalidMagicLink, err)
+		})
+	}
+}
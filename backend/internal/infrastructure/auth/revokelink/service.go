@@ -0,0 +1,55 @@
+package revokelink
+
+import (
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/auth/signedtoken"
+)
+
+// payload is the signed content of a token
+type payload struct {
+	UserID    string `json:"sub"`
+	SessionID string `json:"sid"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// Service issues signed session revoke tokens and implements ports.RevokeLinkTokenService
+// Revoking is idempotent, so unlike login links they need no single-use record.
+type Service struct {
+	signer *signedtoken.Signer
+}
+
+// NewService creates a new revoke link token Service
+func NewService(secret string) *Service {
+	return &Service{
+		signer: signedtoken.New(secret, "revoke-link-key"),
+	}
+}
+
+// Generate creates a signed token for a user's session
+func (s *Service) Generate(userID, sessionID string, expiresAt time.Time) (string, error) {
+	return s.signer.Seal(payload{
+		UserID:    userID,
+		SessionID: sessionID,
+		ExpiresAt: expiresAt.Unix(),
+	})
+}
+
+// Verify checks a token's signature and expiry and returns its claims
+func (s *Service) Verify(token string, now time.Time) (*ports.RevokeLinkClaims, error) {
+	var p payload
+	if !s.signer.Open(token, &p) || p.UserID == "" || p.SessionID == "" {
+		return nil, shared.ErrInvalidRevokeLink
+	}
+
+	if !now.Before(time.Unix(p.ExpiresAt, 0)) {
+		return nil, shared.ErrInvalidRevokeLink
+	}
+
+	return &ports.RevokeLinkClaims{
+		UserID:    p.UserID,
+		SessionID: p.SessionID,
+	}, nil
+}
@@ -0,0 +1,65 @@
+package revokelink
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/auth/magiclink"
+)
+
+func TestService_GenerateAndVerify(t *testing.T) {
+	service := NewService("test-secret")
+	now := time.Unix(1_700_000_000, 0)
+
+	token, err := service.Generate("user-123", "session-1", now.Add(time.Hour))
+	require.NoError(t, err)
+
+	claims, err := service.Verify(token, now)
+
+	require.NoError(t, err)
+	assert.Equal(t, "user-123", claims.UserID)
+	assert.Equal(t, "session-1", claims.SessionID)
+}
+
+func TestService_Verify_Rejects(t *testing.T) {
+	service := NewService("test-secret")
+	now := time.Unix(1_700_000_000, 0)
+
+	token, err := service.Generate("user-123", "session-1", now.Add(time.Hour))
+	require.NoError(t, err)
+	_, signature, _ := strings.Cut(token, ".")
+
+	tampered, err := service.Generate("user-456", "session-2", now.Add(time.Hour))
+	require.NoError(t, err)
+	tamperedPayload, _, _ := strings.Cut(tampered, ".")
+
+	// A login link signed with the same secret must not work as a revoke link
+	loginLink, err := magiclink.NewService("test-secret").Generate("user@example.com", now.Add(time.Hour))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		token string
+		now   time.Time
+	}{
+		{"empty", "", now},
+		{"no signature", "payload", now},
+		{"swapped payload", tamperedPayload + "." + signature, now},
+		{"login link", loginLink, now},
+		{"expired", token, now.Add(time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := service.Verify(tt.token, tt.now)
+
+			assert.Nil(t, claims)
+			assert.Equal(t, shared.ErrInvalidRevokeLink, err)
+		})
+	}
+}
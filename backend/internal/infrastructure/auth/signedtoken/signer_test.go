@@ -0,0 +1,82 @@
+package signedtoken
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testPayload struct {
+	Subject string `json:"sub"`
+}
+
+func TestSigner_SealAndOpen(t *testing.T) {
+	signer := New("test-secret", "test-key")
+
+	token, err := signer.Seal(testPayload{Subject: "user-123"})
+	require.NoError(t, err)
+
+	var p testPayload
+	require.True(t, signer.Open(token, &p))
+	assert.Equal(t, "user-123", p.Subject)
+}
+
+func TestSigner_Open_Rejects(t *testing.T) {
+	signer := New("test-secret", "test-key")
+
+	token, err := signer.Seal(testPayload{Subject: "user-123"})
+	require.NoError(t, err)
+	encoded, signature, _ := strings.Cut(token, ".")
+
+	tampered, err := signer.Seal(testPayload{Subject: "user-456"})
+	require.NoError(t, err)
+	tamperedPayload, _, _ := strings.Cut(tampered, ".")
+
+	otherPurpose, _ := New("test-secret", "other-key").Seal(testPayload{Subject: "user-123"})
+	otherSecret, _ := New("other-secret", "test-key").Seal(testPayload{Subject: "user-123"})
+	notJSON := "bm90LWpzb24." + base64MAC(signer, "bm90LWpzb24")
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"no signature", encoded},
+		{"empty signature", encoded + "."},
+		{"tampered payload", tamperedPayload + "." + signature},
+		{"signed for another purpose", otherPurpose},
+		{"signed with another secret", otherSecret},
+		{"payload is not JSON", notJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p testPayload
+			assert.False(t, signer.Open(tt.token, &p))
+		})
+	}
+}
+
+func TestSigner_MAC_SeparatesParts(t *testing.T) {
+	signer := New("test-secret", "test-key")
+
+	assert.NotEqual(t, signer.MAC([]byte("ab"), []byte("c")), signer.MAC([]byte("a"), []byte("bc")))
+	assert.NotEqual(t, signer.MAC([]byte("abc")), New("test-secret", "other-key").MAC([]byte("abc")))
+}
+
+func TestSigner_Check(t *testing.T) {
+	signer := New("test-secret", "test-key")
+	signature := base64MAC(signer, "nonce")
+
+	assert.True(t, signer.Check(signature, []byte("nonce")))
+	assert.False(t, signer.Check(signature, []byte("other")))
+	assert.False(t, signer.Check("not base64!", []byte("nonce")))
+}
+
+// base64MAC returns the encoded MAC of data, as Seal signs it
+func base64MAC(signer *Signer, data string) string {
+	return base64.RawURLEncoding.EncodeToString(signer.MAC([]byte(data)))
+}
@@ -0,0 +1,99 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	secretSize = 20 // 160 bits, as recommended by RFC 4226
+	digits     = 6
+	period     = 30 * time.Second
+	// skew is how many steps before and after the current one are accepted
+	skew = 1
+)
+
+// secretEncoding is the base32 form authenticator apps expect
+var secretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Service implements ports.TOTPService with the parameters every common
+// authenticator app supports: HMAC-SHA1, 6 digits, 30 second steps
+type Service struct {
+	issuer string
+}
+
+// NewService creates a new TOTP Service; issuer is shown in authenticator apps
+func NewService(issuer string) *Service {
+	return &Service{
+		issuer: issuer,
+	}
+}
+
+// GenerateSecret creates a new random shared secret
+func (s *Service) GenerateSecret() ([]byte, error) {
+	secret := make([]byte, secretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// EncodeSecret renders a secret as unpadded base32
+func (s *Service) EncodeSecret(secret []byte) string {
+	return secretEncoding.EncodeToString(secret)
+}
+
+// ProvisioningURI builds the otpauth:// URI for a QR code
+func (s *Service) ProvisioningURI(secret []byte, accountName string) string {
+	query := url.Values{}
+	query.Set("secret", s.EncodeSecret(secret))
+	query.Set("issuer", s.issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(digits))
+	query.Set("period", fmt.Sprint(int(period.Seconds())))
+
+	label := url.PathEscape(s.issuer) + ":" + url.PathEscape(accountName)
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// Validate checks a code against the steps around the given time
+func (s *Service) Validate(secret []byte, code string, at time.Time) (int64, bool) {
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if len(code) != digits {
+		return 0, false
+	}
+
+	current := at.Unix() / int64(period.Seconds())
+	for step := current - skew; step <= current+skew; step++ {
+		expected := generateCode(secret, step)
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// generateCode computes the HOTP value (RFC 4226) for a time step
+func generateCode(secret []byte, step int64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", digits, value%1000000)
+}
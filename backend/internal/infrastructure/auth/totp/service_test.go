@@ -0,0 +1,94 @@
+package totp
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the SHA-1 seed from the RFC 6238 test vectors
+var rfcSecret = []byte("12345678901234567890")
+
+func TestGenerateCode_RFC6238Vectors(t *testing.T) {
+	// Six-digit suffixes of the eight-digit RFC 6238 SHA-1 values
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.code, generateCode(rfcSecret, tt.unix/30), "time %d", tt.unix)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	service := NewService("go-google-auth")
+	at := time.Unix(1111111111, 0)
+
+	step, ok := service.Validate(rfcSecret, "050471", at)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1111111111/30), step)
+
+	// Spaces are ignored
+	_, ok = service.Validate(rfcSecret, " 050 471 ", at)
+	assert.True(t, ok)
+
+	_, ok = service.Validate(rfcSecret, "123456", at)
+	assert.False(t, ok)
+	_, ok = service.Validate(rfcSecret, "05047", at)
+	assert.False(t, ok)
+	_, ok = service.Validate(rfcSecret, "", at)
+	assert.False(t, ok)
+}
+
+func TestValidate_AllowsOneStepOfDrift(t *testing.T) {
+	service := NewService("go-google-auth")
+	at := time.Unix(1111111111, 0)
+	code := generateCode(rfcSecret, at.Unix()/30)
+
+	_, ok := service.Validate(rfcSecret, code, at.Add(30*time.Second))
+	assert.True(t, ok)
+	_, ok = service.Validate(rfcSecret, code, at.Add(-30*time.Second))
+	assert.True(t, ok)
+	_, ok = service.Validate(rfcSecret, code, at.Add(90*time.Second))
+	assert.False(t, ok)
+}
+
+func TestGenerateSecret(t *testing.T) {
+	service := NewService("go-google-auth")
+
+	secret1, err := service.GenerateSecret()
+	require.NoError(t, err)
+	secret2, err := service.GenerateSecret()
+	require.NoError(t, err)
+
+	assert.Len(t, secret1, 20)
+	assert.NotEqual(t, secret1, secret2)
+	assert.Len(t, service.EncodeSecret(secret1), 32)
+}
+
+func TestProvisioningURI(t *testing.T) {
+	service := NewService("Go Google Auth")
+
+	uri := service.ProvisioningURI(rfcSecret, "user@example.com")
+
+	require.True(t, strings.HasPrefix(uri, "otpauth://totp/Go%20Google%20Auth:user@example.com?"))
+	parsed, err := url.Parse(uri)
+	require.NoError(t, err)
+	query := parsed.Query()
+	assert.Equal(t, "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", query.Get("secret"))
+	assert.Equal(t, "Go Google Auth", query.Get("issuer"))
+	assert.Equal(t, "SHA1", query.Get("algorithm"))
+	assert.Equal(t, "6", query.Get("digits"))
+	assert.Equal(t, "30", query.Get("period"))
+}
@@ -0,0 +1,87 @@
+package webauthn
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// Authenticator data flags
+const (
+	flagUserPresent       = 0x01
+	flagUserVerified      = 0x04
+	flagAttestedCredData  = 0x40
+	flagExtensionDataIncl = 0x80
+)
+
+// minAuthenticatorDataLength covers rpIdHash (32), flags (1) and signCount (4)
+const minAuthenticatorDataLength = 37
+
+var errMalformedAuthenticatorData = errors.New("malformed authenticator data")
+
+// authenticatorData is the parsed authenticator data of a WebAuthn response
+type authenticatorData struct {
+	rpIDHash     []byte
+	flags        byte
+	signCount    uint32
+	credentialID []byte // only present during registration
+	publicKey    []byte // COSE_Key, only present during registration
+}
+
+// userPresent reports whether the user touched the authenticator
+func (a *authenticatorData) userPresent() bool {
+	return a.flags&flagUserPresent != 0
+}
+
+// userVerified reports whether the authenticator verified the user (PIN or biometrics)
+func (a *authenticatorData) userVerified() bool {
+	return a.flags&flagUserVerified != 0
+}
+
+// parseAuthenticatorData decodes authenticator data as defined in WebAuthn §6.1
+func parseAuthenticatorData(data []byte) (*authenticatorData, error) {
+	if len(data) < minAuthenticatorDataLength {
+		return nil, errMalformedAuthenticatorData
+	}
+
+	parsed := &authenticatorData{
+		rpIDHash:  data[:32],
+		flags:     data[32],
+		signCount: binary.BigEndian.Uint32(data[33:37]),
+	}
+	rest := data[minAuthenticatorDataLength:]
+
+	if parsed.flags&flagAttestedCredData != 0 {
+		// aaguid (16) and credentialIdLength (2), then the ID and its COSE key
+		if len(rest) < 18 {
+			return nil, errMalformedAuthenticatorData
+		}
+		idLength := int(binary.BigEndian.Uint16(rest[16:18]))
+		rest = rest[18:]
+		if idLength == 0 || len(rest) < idLength {
+			return nil, errMalformedAuthenticatorData
+		}
+		parsed.credentialID = rest[:idLength]
+		rest = rest[idLength:]
+
+		_, keyLength, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, errMalformedAuthenticatorData
+		}
+		parsed.publicKey = rest[:keyLength]
+		rest = rest[keyLength:]
+	}
+
+	if parsed.flags&flagExtensionDataIncl != 0 {
+		_, extLength, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, errMalformedAuthenticatorData
+		}
+		rest = rest[extLength:]
+	}
+
+	if len(rest) != 0 {
+		return nil, errMalformedAuthenticatorData
+	}
+
+	return parsed, nil
+}
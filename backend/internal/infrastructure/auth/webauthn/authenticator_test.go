@@ -0,0 +1,226 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+)
+
+// softwareAuthenticator is a test fixture that behaves like a WebAuthn
+// authenticator and browser: it creates "none" attestations and signs
+// assertions with a software key
+type softwareAuthenticator struct {
+	t            *testing.T
+	algorithm    int
+	signer       crypto.Signer
+	credentialID []byte
+	signCount    uint32
+	userVerified bool
+}
+
+// newSoftwareAuthenticator creates an authenticator with a fresh key for the algorithm
+func newSoftwareAuthenticator(t *testing.T, algorithm int) *softwareAuthenticator {
+	t.Helper()
+
+	var signer crypto.Signer
+	var err error
+	switch algorithm {
+	case AlgES256:
+		signer, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case AlgEdDSA:
+		_, signer, err = ed25519.GenerateKey(rand.Reader)
+	case AlgRS256:
+		signer, err = rsa.GenerateKey(rand.Reader, 2048)
+	default:
+		t.Fatalf("unsupported algorithm %d", algorithm)
+	}
+	require.NoError(t, err)
+
+	credentialID := make([]byte, 16)
+	_, err = rand.Read(credentialID)
+	require.NoError(t, err)
+
+	return &softwareAuthenticator{
+		t:            t,
+		algorithm:    algorithm,
+		signer:       signer,
+		credentialID: credentialID,
+		userVerified: true,
+	}
+}
+
+// coseKey encodes the authenticator's public key as a COSE_Key
+func (a *softwareAuthenticator) coseKey() []byte {
+	switch key := a.signer.Public().(type) {
+	case *ecdsa.PublicKey:
+		return encodeCBOR(map[int64]any{
+			coseKeyType:   int64(coseKeyTypeEC2),
+			coseAlgorithm: int64(AlgES256),
+			coseParam1:    int64(coseCurveP256),
+			coseParam2:    key.X.FillBytes(make([]byte, 32)),
+			coseParam3:    key.Y.FillBytes(make([]byte, 32)),
+		})
+	case ed25519.PublicKey:
+		return encodeCBOR(map[int64]any{
+			coseKeyType:   int64(coseKeyTypeOKP),
+			coseAlgorithm: int64(AlgEdDSA),
+			coseParam1:    int64(coseCurveEd25519),
+			coseParam2:    []byte(key),
+		})
+	case *rsa.PublicKey:
+		return encodeCBOR(map[int64]any{
+			coseKeyType:   int64(coseKeyTypeRSA),
+			coseAlgorithm: int64(AlgRS256),
+			coseParam1:    key.N.Bytes(),
+			coseParam2:    big.NewInt(int64(key.E)).Bytes(),
+		})
+	}
+	a.t.Fatal("unknown key type")
+	return nil
+}
+
+// flags returns the authenticator data flags for a ceremony
+func (a *softwareAuthenticator) flags() byte {
+	flags := byte(flagUserPresent)
+	if a.userVerified {
+		flags |= flagUserVerified
+	}
+	return flags
+}
+
+// authData builds authenticator data for the RP ID
+func (a *softwareAuthenticator) authData(rpID string, flags byte, attested []byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	data := append([]byte{}, rpIDHash[:]...)
+	data = append(data, flags)
+	data = binary.BigEndian.AppendUint32(data, a.signCount)
+	return append(data, attested...)
+}
+
+// register answers navigator.credentials.create()
+func (a *softwareAuthenticator) register(rpID, origin, challenge string) ports.WebAuthnAttestation {
+	attested := make([]byte, 16) // zero AAGUID, as sent with "none" attestation
+	attested = binary.BigEndian.AppendUint16(attested, uint16(len(a.credentialID)))
+	attested = append(attested, a.credentialID...)
+	attested = append(attested, a.coseKey()...)
+
+	authData := a.authData(rpID, a.flags()|flagAttestedCredData, attested)
+	return ports.WebAuthnAttestation{
+		ClientDataJSON: clientDataJSON(a.t, clientDataTypeCreate, challenge, origin),
+		AttestationObject: encodeCBOR(map[string]any{
+			"fmt":      attestationFormatNone,
+			"attStmt":  map[string]any{},
+			"authData": authData,
+		}),
+	}
+}
+
+// assert answers navigator.credentials.get(), incrementing the signature counter
+func (a *softwareAuthenticator) assert(rpID, origin, challenge string, userHandle []byte) ports.WebAuthnAssertion {
+	a.signCount++
+	authData := a.authData(rpID, a.flags(), nil)
+	clientData := clientDataJSON(a.t, clientDataTypeGet, challenge, origin)
+
+	clientDataHash := sha256.Sum256(clientData)
+	signed := append(append([]byte{}, authData...), clientDataHash[:]...)
+
+	var signature []byte
+	var err error
+	if a.algorithm == AlgEdDSA {
+		signature, err = a.signer.Sign(rand.Reader, signed, crypto.Hash(0))
+	} else {
+		digest := sha256.Sum256(signed)
+		signature, err = a.signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	}
+	require.NoError(a.t, err)
+
+	return ports.WebAuthnAssertion{
+		CredentialID:      a.credentialID,
+		ClientDataJSON:    clientData,
+		AuthenticatorData: authData,
+		Signature:         signature,
+		UserHandle:        userHandle,
+	}
+}
+
+// clientDataJSON builds CollectedClientData as a browser would
+func clientDataJSON(t *testing.T, ceremonyType, challenge, origin string) []byte {
+	data, err := json.Marshal(map[string]any{
+		"type":        ceremonyType,
+		"challenge":   challenge,
+		"origin":      origin,
+		"crossOrigin": false,
+	})
+	require.NoError(t, err)
+	return data
+}
+
+// encodeCBOR encodes the values the fixtures need in canonical CBOR
+func encodeCBOR(value any) []byte {
+	switch v := value.(type) {
+	case int64:
+		if v < 0 {
+			return cborHead(cborNegative, uint64(-1-v))
+		}
+		return cborHead(cborUnsigned, uint64(v))
+	case int:
+		return encodeCBOR(int64(v))
+	case []byte:
+		return append(cborHead(cborBytes, uint64(len(v))), v...)
+	case string:
+		return append(cborHead(cborText, uint64(len(v))), v...)
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		out := cborHead(cborMap, uint64(len(v)))
+		for _, key := range keys {
+			out = append(out, encodeCBOR(key)...)
+			out = append(out, encodeCBOR(v[key])...)
+		}
+		return out
+	case map[int64]any:
+		keys := make([]int64, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		out := cborHead(cborMap, uint64(len(v)))
+		for _, key := range keys {
+			out = append(out, encodeCBOR(key)...)
+			out = append(out, encodeCBOR(v[key])...)
+		}
+		return out
+	}
+	panic("unsupported CBOR value")
+}
+
+// cborHead encodes an item's major type and argument
+func cborHead(major byte, arg uint64) []byte {
+	switch {
+	case arg < 24:
+		return []byte{major<<5 | byte(arg)}
+	case arg <= 0xff:
+		return []byte{major<<5 | 24, byte(arg)}
+	case arg <= 0xffff:
+		return binary.BigEndian.AppendUint16([]byte{major<<5 | 25}, uint16(arg))
+	case arg <= 0xffffffff:
+		return binary.BigEndian.AppendUint32([]byte{major<<5 | 26}, uint32(arg))
+	}
+	return binary.BigEndian.AppendUint64([]byte{major<<5 | 27}, arg)
+}
@@ -0,0 +1,188 @@
+package webauthn
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// CBOR major types (RFC 8949)
+const (
+	cborUnsigned = 0
+	cborNegative = 1
+	cborBytes    = 2
+	cborText     = 3
+	cborArray    = 4
+	cborMap      = 5
+	cborTag      = 6
+	cborSimple   = 7
+)
+
+// maxCBORDepth bounds nesting so malicious input cannot exhaust the stack
+const maxCBORDepth = 16
+
+var errMalformedCBOR = errors.New("malformed CBOR")
+
+// decodeCBOR decodes the first CBOR item in data and returns it together
+// with the number of bytes it used. It supports the subset of CBOR that
+// WebAuthn authenticators produce: definite-length integers, byte and text
+// strings, arrays, maps and the simple values false, true and null.
+//
+// Integers decode to int64, byte strings to []byte, text strings to string,
+// arrays to []any and maps to map[any]any.
+func decodeCBOR(data []byte) (any, int, error) {
+	d := &cborDecoder{data: data}
+	value, err := d.decode(0)
+	if err != nil {
+		return nil, 0, err
+	}
+	return value, d.pos, nil
+}
+
+// cborDecoder reads CBOR items from a byte slice
+type cborDecoder struct {
+	data []byte
+	pos  int
+}
+
+// decode reads one item at the given nesting depth
+func (d *cborDecoder) decode(depth int) (any, error) {
+	if depth > maxCBORDepth {
+		return nil, errMalformedCBOR
+	}
+
+	major, arg, err := d.readHead()
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case cborUnsigned:
+		if arg > math.MaxInt64 {
+			return nil, errMalformedCBOR
+		}
+		return int64(arg), nil
+	case cborNegative:
+		if arg > math.MaxInt64 {
+			return nil, errMalformedCBOR
+		}
+		return -1 - int64(arg), nil
+	case cborBytes:
+		raw, err := d.readN(arg)
+		if err != nil {
+			return nil, err
+		}
+		value := make([]byte, len(raw))
+		copy(value, raw)
+		return value, nil
+	case cborText:
+		raw, err := d.readN(arg)
+		if err != nil {
+			return nil, err
+		}
+		return string(raw), nil
+	case cborArray:
+		if arg > uint64(len(d.data)-d.pos) {
+			return nil, errMalformedCBOR
+		}
+		items := make([]any, 0, arg)
+		for i := uint64(0); i < arg; i++ {
+			item, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case cborMap:
+		if arg > uint64(len(d.data)-d.pos) {
+			return nil, errMalformedCBOR
+		}
+		entries := make(map[any]any, arg)
+		for i := uint64(0); i < arg; i++ {
+			key, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			switch key.(type) {
+			case int64, string:
+			default:
+				return nil, errMalformedCBOR
+			}
+			if _, duplicate := entries[key]; duplicate {
+				return nil, errMalformedCBOR
+			}
+			value, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			entries[key] = value
+		}
+		return entries, nil
+	case cborSimple:
+		switch arg {
+		case 20:
+			return false, nil
+		case 21:
+			return true, nil
+		case 22:
+			return nil, nil
+		}
+	}
+
+	return nil, errMalformedCBOR
+}
+
+// readHead reads an item's major type and argument
+func (d *cborDecoder) readHead() (major byte, arg uint64, err error) {
+	if d.pos >= len(d.data) {
+		return 0, 0, errMalformedCBOR
+	}
+	initial := d.data[d.pos]
+	d.pos++
+
+	major = initial >> 5
+	info := initial & 0x1f
+
+	switch {
+	case info < 24:
+		return major, uint64(info), nil
+	case info == 24:
+		raw, err := d.readN(1)
+		if err != nil {
+			return 0, 0, err
+		}
+		return major, uint64(raw[0]), nil
+	case info == 25:
+		raw, err := d.readN(2)
+		if err != nil {
+			return 0, 0, err
+		}
+		return major, uint64(binary.BigEndian.Uint16(raw)), nil
+	case info == 26:
+		raw, err := d.readN(4)
+		if err != nil {
+			return 0, 0, err
+		}
+		return major, uint64(binary.BigEndian.Uint32(raw)), nil
+	case info == 27:
+		raw, err := d.readN(8)
+		if err != nil {
+			return 0, 0, err
+		}
+		return major, binary.BigEndian.Uint64(raw), nil
+	default:
+		// Indefinite lengths (31) and reserved values are not used by authenticators
+		return 0, 0, errMalformedCBOR
+	}
+}
+
+// readN reads n bytes
+func (d *cborDecoder) readN(n uint64) ([]byte, error) {
+	if n > uint64(len(d.data)-d.pos) {
+		return nil, errMalformedCBOR
+	}
+	raw := d.data[d.pos : d.pos+int(n)]
+	d.pos += int(n)
+	return raw, nil
+}
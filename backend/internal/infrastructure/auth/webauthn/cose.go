@@ -0,0 +1,118 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+)
+
+// COSE algorithm identifiers (RFC 9053)
+const (
+	AlgES256 = -7
+	AlgEdDSA = -8
+	AlgRS256 = -257
+)
+
+// COSE key parameters and values
+const (
+	coseKeyType   = 1
+	coseAlgorithm = 3
+
+	coseKeyTypeOKP = 1
+	coseKeyTypeEC2 = 2
+	coseKeyTypeRSA = 3
+
+	coseCurveP256    = 1
+	coseCurveEd25519 = 6
+
+	// EC2 and OKP: -1 curve, -2 x, -3 y; RSA: -1 n, -2 e
+	coseParam1 = -1
+	coseParam2 = -2
+	coseParam3 = -3
+)
+
+// minRSAKeyBits is the smallest RSA modulus accepted
+const minRSAKeyBits = 2048
+
+var errUnsupportedKey = errors.New("unsupported or malformed COSE key")
+
+// publicKey is a credential public key with its signature algorithm
+type publicKey struct {
+	algorithm int
+	key       crypto.PublicKey
+}
+
+// parseCOSEKey decodes a COSE_Key (as stored with a passkey) into a public key
+func parseCOSEKey(data []byte) (*publicKey, error) {
+	value, n, err := decodeCBOR(data)
+	if err != nil || n != len(data) {
+		return nil, errUnsupportedKey
+	}
+	return coseKeyFromMap(value)
+}
+
+// coseKeyFromMap builds a public key from a decoded COSE_Key map
+func coseKeyFromMap(value any) (*publicKey, error) {
+	params, ok := value.(map[any]any)
+	if !ok {
+		return nil, errUnsupportedKey
+	}
+
+	keyType, _ := params[int64(coseKeyType)].(int64)
+	algorithm, _ := params[int64(coseAlgorithm)].(int64)
+
+	switch {
+	case keyType == coseKeyTypeEC2 && algorithm == AlgES256:
+		curve, _ := params[int64(coseParam1)].(int64)
+		x, _ := params[int64(coseParam2)].([]byte)
+		y, _ := params[int64(coseParam3)].([]byte)
+		if curve != coseCurveP256 || len(x) != 32 || len(y) != 32 {
+			return nil, errUnsupportedKey
+		}
+		key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !key.Curve.IsOnCurve(key.X, key.Y) {
+			return nil, errUnsupportedKey
+		}
+		return &publicKey{algorithm: AlgES256, key: key}, nil
+
+	case keyType == coseKeyTypeOKP && algorithm == AlgEdDSA:
+		curve, _ := params[int64(coseParam1)].(int64)
+		x, _ := params[int64(coseParam2)].([]byte)
+		if curve != coseCurveEd25519 || len(x) != ed25519.PublicKeySize {
+			return nil, errUnsupportedKey
+		}
+		return &publicKey{algorithm: AlgEdDSA, key: ed25519.PublicKey(x)}, nil
+
+	case keyType == coseKeyTypeRSA && algorithm == AlgRS256:
+		n, _ := params[int64(coseParam1)].([]byte)
+		e, _ := params[int64(coseParam2)].([]byte)
+		modulus := new(big.Int).SetBytes(n)
+		exponent := new(big.Int).SetBytes(e)
+		if modulus.BitLen() < minRSAKeyBits || !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+			return nil, errUnsupportedKey
+		}
+		return &publicKey{algorithm: AlgRS256, key: &rsa.PublicKey{N: modulus, E: int(exponent.Int64())}}, nil
+	}
+
+	return nil, errUnsupportedKey
+}
+
+// verify checks a signature over data
+func (k *publicKey) verify(data, signature []byte) bool {
+	switch key := k.key.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(data)
+		return ecdsa.VerifyASN1(key, digest[:], signature)
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, data, signature)
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(data)
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil
+	}
+	return false
+}
@@ -0,0 +1,217 @@
+package webauthn
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+// challengeSize is the number of random bytes in a challenge
+const challengeSize = 32
+
+// Client data types (WebAuthn §5.8.1)
+const (
+	clientDataTypeCreate = "webauthn.create"
+	clientDataTypeGet    = "webauthn.get"
+)
+
+// attestationFormatNone is the only attestation format accepted; the service
+// asks for "none" because it does not restrict which authenticators are used
+const attestationFormatNone = "none"
+
+// supportedAlgorithms lists the COSE algorithms offered to authenticators, most preferred first
+var supportedAlgorithms = []int{AlgES256, AlgEdDSA, AlgRS256}
+
+// clientData is the subset of CollectedClientData that is verified
+type clientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin"`
+}
+
+// Service implements ports.WebAuthnVerifier for a single relying party
+type Service struct {
+	rpID     string
+	rpName   string
+	rpIDHash []byte
+	origins  map[string]bool
+}
+
+// NewService creates a new WebAuthn Service. rpID is the domain credentials are
+// scoped to and origins are the exact origins the frontend is served from.
+func NewService(rpID, rpName string, origins []string) *Service {
+	allowed := make(map[string]bool, len(origins))
+	for _, origin := range origins {
+		allowed[origin] = true
+	}
+
+	hash := sha256.Sum256([]byte(rpID))
+	return &Service{
+		rpID:     rpID,
+		rpName:   rpName,
+		rpIDHash: hash[:],
+		origins:  allowed,
+	}
+}
+
+// RelyingPartyID returns the RP ID
+func (s *Service) RelyingPartyID() string {
+	return s.rpID
+}
+
+// RelyingPartyName returns the RP name
+func (s *Service) RelyingPartyName() string {
+	return s.rpName
+}
+
+// Algorithms returns the supported COSE algorithm identifiers
+func (s *Service) Algorithms() []int {
+	algorithms := make([]int, len(supportedAlgorithms))
+	copy(algorithms, supportedAlgorithms)
+	return algorithms
+}
+
+// NewChallenge creates a random challenge
+func (s *Service) NewChallenge() (string, error) {
+	challenge := make([]byte, challengeSize)
+	if _, err := rand.Read(challenge); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(challenge), nil
+}
+
+// ClientChallenge extracts the challenge from clientDataJSON
+func (s *Service) ClientChallenge(clientDataJSON []byte) (string, error) {
+	var data clientData
+	if err := json.Unmarshal(clientDataJSON, &data); err != nil || data.Challenge == "" {
+		return "", verificationError("malformed client data")
+	}
+	return data.Challenge, nil
+}
+
+// VerifyRegistration verifies a registration ceremony (WebAuthn §7.1)
+func (s *Service) VerifyRegistration(attestation ports.WebAuthnAttestation, challenge string, requireUserVerification bool) (*ports.WebAuthnCredential, error) {
+	if err := s.verifyClientData(attestation.ClientDataJSON, clientDataTypeCreate, challenge); err != nil {
+		return nil, err
+	}
+
+	decoded, n, err := decodeCBOR(attestation.AttestationObject)
+	if err != nil || n != len(attestation.AttestationObject) {
+		return nil, verificationError("malformed attestation object")
+	}
+	object, ok := decoded.(map[any]any)
+	if !ok {
+		return nil, verificationError("malformed attestation object")
+	}
+
+	format, _ := object["fmt"].(string)
+	statement, _ := object["attStmt"].(map[any]any)
+	if format != attestationFormatNone || len(statement) != 0 {
+		return nil, verificationError("unsupported attestation format %q", format)
+	}
+
+	rawAuthData, _ := object["authData"].([]byte)
+	authData, err := s.verifyAuthenticatorData(rawAuthData, requireUserVerification)
+	if err != nil {
+		return nil, err
+	}
+	if authData.credentialID == nil {
+		return nil, verificationError("missing attested credential data")
+	}
+	if _, err := parseCOSEKey(authData.publicKey); err != nil {
+		return nil, verificationError("unsupported credential public key")
+	}
+
+	return &ports.WebAuthnCredential{
+		ID:           bytes.Clone(authData.credentialID),
+		PublicKey:    bytes.Clone(authData.publicKey),
+		SignCount:    authData.signCount,
+		UserVerified: authData.userVerified(),
+	}, nil
+}
+
+// VerifyAssertion verifies an authentication ceremony (WebAuthn §7.2)
+func (s *Service) VerifyAssertion(assertion ports.WebAuthnAssertion, challenge string, coseKey []byte, requireUserVerification bool) (*ports.WebAuthnAssertionResult, error) {
+	if err := s.verifyClientData(assertion.ClientDataJSON, clientDataTypeGet, challenge); err != nil {
+		return nil, err
+	}
+
+	authData, err := s.verifyAuthenticatorData(assertion.AuthenticatorData, requireUserVerification)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := parseCOSEKey(coseKey)
+	if err != nil {
+		return nil, verificationError("unsupported credential public key")
+	}
+
+	clientDataHash := sha256.Sum256(assertion.ClientDataJSON)
+	signed := make([]byte, 0, len(assertion.AuthenticatorData)+len(clientDataHash))
+	signed = append(signed, assertion.AuthenticatorData...)
+	signed = append(signed, clientDataHash[:]...)
+	if !key.verify(signed, assertion.Signature) {
+		return nil, verificationError("invalid signature")
+	}
+
+	return &ports.WebAuthnAssertionResult{
+		SignCount:    authData.signCount,
+		UserVerified: authData.userVerified(),
+	}, nil
+}
+
+// verifyClientData checks the ceremony type, challenge and origin
+func (s *Service) verifyClientData(clientDataJSON []byte, expectedType, challenge string) error {
+	var data clientData
+	if err := json.Unmarshal(clientDataJSON, &data); err != nil {
+		return verificationError("malformed client data")
+	}
+
+	if data.Type != expectedType {
+		return verificationError("unexpected client data type %q", data.Type)
+	}
+	if challenge == "" || subtle.ConstantTimeCompare([]byte(data.Challenge), []byte(challenge)) != 1 {
+		return verificationError("challenge mismatch")
+	}
+	if !s.origins[data.Origin] {
+		return verificationError("origin %q is not allowed", data.Origin)
+	}
+	if data.CrossOrigin {
+		return verificationError("cross-origin ceremonies are not allowed")
+	}
+
+	return nil
+}
+
+// verifyAuthenticatorData checks the RP ID hash and user presence and verification flags
+func (s *Service) verifyAuthenticatorData(raw []byte, requireUserVerification bool) (*authenticatorData, error) {
+	authData, err := parseAuthenticatorData(raw)
+	if err != nil {
+		return nil, verificationError("malformed authenticator data")
+	}
+
+	if subtle.ConstantTimeCompare(authData.rpIDHash, s.rpIDHash) != 1 {
+		return nil, verificationError("RP ID mismatch")
+	}
+	if !authData.userPresent() {
+		return nil, verificationError("user presence is required")
+	}
+	if requireUserVerification && !authData.userVerified() {
+		return nil, verificationError("user verification is required")
+	}
+
+	return authData, nil
+}
+
+// verificationError wraps shared.ErrPasskeyVerification with a reason
+func verificationError(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", shared.ErrPasskeyVerification, fmt.Sprintf(format, args...))
+}
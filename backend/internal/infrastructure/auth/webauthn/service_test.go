@@ -0,0 +1,214 @@
+package webauthn
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+const (
+	testRPID   = "example.com"
+	testOrigin = "https://app.example.com"
+)
+
+func newTestService() *Service {
+	return NewService(testRPID, "Example", []string{testOrigin})
+}
+
+func newTestChallenge(t *testing.T, service *Service) string {
+	t.Helper()
+	challenge, err := service.NewChallenge()
+	require.NoError(t, err)
+	return challenge
+}
+
+func TestService_NewChallenge(t *testing.T) {
+	service := newTestService()
+
+	first := newTestChallenge(t, service)
+	second := newTestChallenge(t, service)
+
+	raw, err := base64.RawURLEncoding.DecodeString(first)
+	require.NoError(t, err)
+	assert.Len(t, raw, challengeSize)
+	assert.NotEqual(t, first, second)
+}
+
+func TestService_RegisterAndAssert(t *testing.T) {
+	algorithms := map[string]int{"ES256": AlgES256, "EdDSA": AlgEdDSA, "RS256": AlgRS256}
+
+	for name, algorithm := range algorithms {
+		t.Run(name, func(t *testing.T) {
+			service := newTestService()
+			authenticator := newSoftwareAuthenticator(t, algorithm)
+
+			challenge := newTestChallenge(t, service)
+			attestation := authenticator.register(testRPID, testOrigin, challenge)
+
+			clientChallenge, err := service.ClientChallenge(attestation.ClientDataJSON)
+			require.NoError(t, err)
+			assert.Equal(t, challenge, clientChallenge)
+
+			credential, err := service.VerifyRegistration(attestation, challenge, true)
+			require.NoError(t, err)
+			assert.Equal(t, authenticator.credentialID, credential.ID)
+			assert.True(t, credential.UserVerified)
+			assert.Equal(t, uint32(0), credential.SignCount)
+
+			challenge = newTestChallenge(t, service)
+			assertion := authenticator.assert(testRPID, testOrigin, challenge, []byte("user-123"))
+
+			result, err := service.VerifyAssertion(assertion, challenge, credential.PublicKey, true)
+			require.NoError(t, err)
+			assert.Equal(t, uint32(1), result.SignCount)
+			assert.True(t, result.UserVerified)
+		})
+	}
+}
+
+func TestService_VerifyRegistration_Rejects(t *testing.T) {
+	service := newTestService()
+
+	tests := []struct {
+		name   string
+		modify func(a *softwareAuthenticator, challenge string) (ports.WebAuthnAttestation, string)
+	}{
+		{
+			name: "wrong challenge",
+			modify: func(a *softwareAuthenticator, challenge string) (ports.WebAuthnAttestation, string) {
+				return a.register(testRPID, testOrigin, "other-challenge"), challenge
+			},
+		},
+		{
+			name: "wrong origin",
+			modify: func(a *softwareAuthenticator, challenge string) (ports.WebAuthnAttestation, string) {
+				return a.register(testRPID, "https://evil.example.net", challenge), challenge
+			},
+		},
+		{
+			name: "wrong RP ID",
+			modify: func(a *softwareAuthenticator, challenge string) (ports.WebAuthnAttestation, string) {
+				return a.register("evil.example.net", testOrigin, challenge), challenge
+			},
+		},
+		{
+			name: "assertion instead of attestation",
+			modify: func(a *softwareAuthenticator, challenge string) (ports.WebAuthnAttestation, string) {
+				attestation := a.register(testRPID, testOrigin, challenge)
+				attestation.ClientDataJSON = clientDataJSON(a.t, clientDataTypeGet, challenge, testOrigin)
+				return attestation, challenge
+			},
+		},
+		{
+			name: "user not verified",
+			modify: func(a *softwareAuthenticator, challenge string) (ports.WebAuthnAttestation, string) {
+				a.userVerified = false
+				return a.register(testRPID, testOrigin, challenge), challenge
+			},
+		},
+		{
+			name: "attestation format other than none",
+			modify: func(a *softwareAuthenticator, challenge string) (ports.WebAuthnAttestation, string) {
+				attestation := a.register(testRPID, testOrigin, challenge)
+				attestation.AttestationObject = encodeCBOR(map[string]any{
+					"fmt":      "packed",
+					"attStmt":  map[string]any{"alg": int64(AlgES256)},
+					"authData": a.authData(testRPID, a.flags(), nil),
+				})
+				return attestation, challenge
+			},
+		},
+		{
+			name: "malformed attestation object",
+			modify: func(a *softwareAuthenticator, challenge string) (ports.WebAuthnAttestation, string) {
+				attestation := a.register(testRPID, testOrigin, challenge)
+				attestation.AttestationObject = attestation.AttestationObject[:10]
+				return attestation, challenge
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authenticator := newSoftwareAuthenticator(t, AlgES256)
+			attestation, challenge := tt.modify(authenticator, newTestChallenge(t, service))
+
+			credential, err := service.VerifyRegistration(attestation, challenge, true)
+
+			assert.Nil(t, credential)
+			assert.True(t, errors.Is(err, shared.ErrPasskeyVerification), "got %v", err)
+		})
+	}
+}
+
+func TestService_VerifyAssertion_Rejects(t *testing.T) {
+	service := newTestService()
+	authenticator := newSoftwareAuthenticator(t, AlgES256)
+	challenge := newTestChallenge(t, service)
+	credential, err := service.VerifyRegistration(authenticator.register(testRPID, testOrigin, challenge), challenge, true)
+	require.NoError(t, err)
+
+	t.Run("tampered signature", func(t *testing.T) {
+		challenge := newTestChallenge(t, service)
+		assertion := authenticator.assert(testRPID, testOrigin, challenge, nil)
+		assertion.Signature[len(assertion.Signature)-1] ^= 0xff
+
+		_, err := service.VerifyAssertion(assertion, challenge, credential.PublicKey, false)
+		assert.True(t, errors.Is(err, shared.ErrPasskeyVerification))
+	})
+
+	t.Run("signed by another key", func(t *testing.T) {
+		other := newSoftwareAuthenticator(t, AlgES256)
+		challenge := newTestChallenge(t, service)
+		assertion := other.assert(testRPID, testOrigin, challenge, nil)
+
+		_, err := service.VerifyAssertion(assertion, challenge, credential.PublicKey, false)
+		assert.True(t, errors.Is(err, shared.ErrPasskeyVerification))
+	})
+
+	t.Run("replayed challenge", func(t *testing.T) {
+		assertion := authenticator.assert(testRPID, testOrigin, newTestChallenge(t, service), nil)
+
+		_, err := service.VerifyAssertion(assertion, newTestChallenge(t, service), credential.PublicKey, false)
+		assert.True(t, errors.Is(err, shared.ErrPasskeyVerification))
+	})
+
+	t.Run("user verification required", func(t *testing.T) {
+		authenticator.userVerified = false
+		defer func() { authenticator.userVerified = true }()
+
+		challenge := newTestChallenge(t, service)
+		assertion := authenticator.assert(testRPID, testOrigin, challenge, nil)
+
+		_, err := service.VerifyAssertion(assertion, challenge, credential.PublicKey, true)
+		assert.True(t, errors.Is(err, shared.ErrPasskeyVerification))
+
+		result, err := service.VerifyAssertion(assertion, challenge, credential.PublicKey, false)
+		require.NoError(t, err)
+		assert.False(t, result.UserVerified)
+	})
+}
+
+func TestDecodeCBOR_Malformed(t *testing.T) {
+	tests := map[string][]byte{
+		"empty":                 {},
+		"truncated byte string": {0x45, 0x01, 0x02},
+		"indefinite length":     {0x5f},
+		"huge array length":     {0x9b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		"duplicate map key":     {0xa2, 0x01, 0x01, 0x01, 0x02},
+		"array map key":         {0xa1, 0x80, 0x01},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := decodeCBOR(data)
+			assert.Error(t, err)
+		})
+	}
+}
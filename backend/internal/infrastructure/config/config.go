@@ -4,10 +4,44 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"log"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// Security header defaults
+const (
+	DefaultHSTSMaxAge            = 2 * 365 * 24 * time.Hour
+	DefaultContentSecurityPolicy = "default-src 'none'; frame-ancestors 'none'"
+)
+
+// Token precedence values; they decide which credential authenticates a
+// request that carries both an auth cookie and a bearer token
+const (
+	TokenPrecedenceHeader = "header"
+	TokenPrecedenceCookie = "cookie"
+)
+
+// Rate limit store values; memory buckets are per instance, redis buckets are
+// shared by every instance
+const (
+	RateLimitStoreMemory = "memory"
+	RateLimitStoreRedis  = "redis"
+)
+
+// RateLimit allows Limit requests per Window; a zero Limit disables the limit
+type RateLimit struct {
+	Limit  int
+	Window time.Duration
+}
+
+// Enabled reports whether the rate limit applies
+func (r RateLimit) Enabled() bool {
+	return r.Limit > 0 && r.Window > 0
+}
+
 type Config struct {
 	Port              string
 	Environment       string
@@ -17,15 +51,179 @@ type Config struct {
 	GoogleSecret      string
 	GoogleRedirectURL string
 	JWTSecret         string
+
+	// TrustedProxies are the addresses and CIDR ranges of reverse proxies
+	// whose X-Forwarded-For headers are believed (empty trusts none)
+	TrustedProxies []string
+
+	// JWTIssuer is the iss claim of the tokens this API issues, which
+	// validation requires
+	JWTIssuer string
+
+	// JWTAudiences name this API in the aud claim of its tokens. Tokens are
+	// issued for the first, and any of them is accepted (defaults to JWTIssuer).
+	JWTAudiences []string
+
+	// TokenPrecedence is TokenPrecedenceHeader to prefer an Authorization header
+	// or request body token over auth cookies, or TokenPrecedenceCookie
+	TokenPrecedence string
+
+	// BodyTokensEnabled lets non-browser clients ask for tokens in response
+	// bodies instead of cookies
+	BodyTokensEnabled bool
+
+	// APITokenDefaultTTL is how long personal access tokens last when the
+	// request does not ask for a lifetime, up to APITokenMaxTTL
+	APITokenDefaultTTL time.Duration
+	APITokenMaxTTL     time.Duration
+
+	// ServiceClientsFile is a JSON file registering the service clients that
+	// may use the client credentials grant (empty registers none)
+	ServiceClientsFile string
+
+	// OAuthTokenURL is the public URL of the token endpoint, which
+	// private_key_jwt client assertions must name as their audience
+	OAuthTokenURL string
+
+	// OIDCIssuer is the public base URL of this OpenID Connect provider, named
+	// in the iss claim of ID tokens and in the discovery document
+	OIDCIssuer string
+
+	// OIDCSigningKey is the PEM-encoded RSA private key ID tokens are signed
+	// with (empty generates a key at startup, which is lost on restart)
+	OIDCSigningKey string
+
+	// OIDCRegistrationToken is the initial access token that registering an
+	// OpenID Connect client requires (empty disables registration)
+	OIDCRegistrationToken string
+
+	// DPoPNonceTTL is how long a DPoP-Nonce issued to a client may be used
+	// in its proofs
+	DPoPNonceTTL time.Duration
+
+	// CORSMaxAge is how long browsers may cache CORS preflight responses
+	CORSMaxAge time.Duration
+
+	// AccountStatusCacheTTL is how long the auth middleware reuses a user's account status
+	AccountStatusCacheTTL time.Duration
+
+	// UserCacheTTL is how long user lookups are cached in front of the repository (0 disables)
+	UserCacheTTL time.Duration
+
+	// RecentAuthMaxAge is how recently a user must have authenticated for sensitive
+	// operations such as deleting their account
+	RecentAuthMaxAge time.Duration
+
+	// AccountDeletionGracePeriod is how long a deleted account is kept before it is purged
+	AccountDeletionGracePeriod time.Duration
+
+	// ImpersonationTTL is how long the token an administrator acts as a user
+	// with lasts
+	ImpersonationTTL time.Duration
+
+	// AdminUserIDs and AdminEmails name the users who have the admin role,
+	// by user ID or by verified email address
+	AdminUserIDs []string
+	AdminEmails  []string
+
+	// MFAIssuer is the account issuer shown in authenticator apps
+	MFAIssuer string
+
+	// WebAuthnRPID is the domain passkeys are scoped to (defaults to the frontend's host)
+	WebAuthnRPID string
+
+	// WebAuthnRPName is the site name shown when creating a passkey
+	WebAuthnRPName string
+
+	// WebAuthnOrigins are the origins passkey ceremonies may run on (defaults to AllowedOrigins)
+	WebAuthnOrigins []string
+
+	// WebAuthnChallengeTTL is how long a passkey ceremony may take
+	WebAuthnChallengeTTL time.Duration
+
+	// MagicLinkURL is the public URL of the email login verify endpoint that links point to
+	MagicLinkURL string
+
+	// MagicLinkTTL is how long an email login link stays valid
+	MagicLinkTTL time.Duration
+
+	// ReauthLinkURL is the frontend page emailed re-authentication links
+	// point to; the token is added as a query parameter
+	ReauthLinkURL string
+
+	// OrgInvitationURL is the frontend page organization invitation links
+	// point to; the token is added as a query parameter
+	OrgInvitationURL string
+
+	// OrgInvitationTTL is how long an organization invitation stays valid
+	OrgInvitationTTL time.Duration
+
+	// MailFrom is the sender address of outgoing email
+	MailFrom string
+
+	// SMTPHost enables sending email through SMTP; when empty, email is written to the outbox
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+
+	// MailOutboxDir is where email is written when SMTP is not configured (empty logs it)
+	MailOutboxDir string
+
+	// LoginAlertsEnabled turns on new-device and new-location login alerts
+	LoginAlertsEnabled bool
+
+	// GeoIPDatabase is a "<cidr>,<country>" CSV file used to add countries to
+	// device fingerprints (empty disables geo lookup)
+	GeoIPDatabase string
+
+	// LoginAlertWebhookURL also posts login alerts to a webhook (empty disables)
+	LoginAlertWebhookURL    string
+	LoginAlertWebhookSecret string
+
+	// SessionRevokeURL is the public URL of the endpoint "this wasn't me" links point to
+	SessionRevokeURL string
+
+	// HSTSMaxAge enables Strict-Transport-Security when positive (defaults on in production only)
+	HSTSMaxAge            time.Duration
+	HSTSIncludeSubdomains bool
+	HSTSPreload           bool
+
+	// ContentSecurityPolicy is sent on ordinary API responses (empty disables)
+	ContentSecurityPolicy string
+
+	// ReferrerPolicy is sent on ordinary API responses (empty disables)
+	ReferrerPolicy string
+
+	// RateLimitLogin limits Google logins per client IP
+	RateLimitLogin RateLimit
+
+	// RateLimitRefreshIP limits token refreshes per client IP
+	RateLimitRefreshIP RateLimit
+
+	// RateLimitRefreshFamily limits token refreshes per refresh-token family (login session)
+	RateLimitRefreshFamily RateLimit
+
+	// RateLimitMFA limits second-factor code attempts per client IP and per user
+	RateLimitMFA RateLimit
+
+	// RateLimitEmailLogin limits login links sent per email address
+	RateLimitEmailLogin RateLimit
+
+	// RateLimitAPIUser limits authenticated API requests per user
+	RateLimitAPIUser RateLimit
+
+	// RateLimitStore is RateLimitStoreMemory or RateLimitStoreRedis
+	RateLimitStore string
+
+	// RateLimitRedisURL locates the Redis-compatible server shared rate limit
+	// buckets are kept in (e.g. redis://:password@host:6379/0)
+	RateLimitRedisURL string
 }
 
 func Load() *Config {
 	// CORS Allowed Origins - comma separated
-	allowedOriginsStr := getEnv("ALLOWED_ORIGINS", "http://localhost:5173")
-	allowedOrigins := strings.Split(allowedOriginsStr, ",")
-	for i := range allowedOrigins {
-		allowedOrigins[i] = strings.TrimSpace(allowedOrigins[i])
-	}
+	allowedOrigins := splitList(getEnv("ALLOWED_ORIGINS", "http://localhost:5173"))
 
 	// JWT Secret - generate a random one if not provided (for development only)
 	jwtSecret := getEnv("JWT_SECRET", "")
@@ -34,7 +232,7 @@ func Load() *Config {
 		log.Println("WARNING: JWT_SECRET not set, using auto-generated secret. Set JWT_SECRET environment variable in production.")
 	}
 
-	return &Config{
+	cfg := &Config{
 		Port:              getEnv("PORT", "8080"),
 		Environment:       getEnv("GO_ENV", "development"),
 		AllowedOrigins:    allowedOrigins,
@@ -43,7 +241,107 @@ func Load() *Config {
 		GoogleSecret:      getEnv("GOOGLE_CLIENT_SECRET", ""),
 		GoogleRedirectURL: getEnv("GOOGLE_REDIRECT_URL", ""),
 		JWTSecret:         jwtSecret,
+		JWTIssuer:         getEnv("JWT_ISSUER", "go-google-auth"),
+
+		TokenPrecedence:   getEnvChoice("TOKEN_PRECEDENCE", TokenPrecedenceHeader, TokenPrecedenceCookie),
+		BodyTokensEnabled: getEnvBool("BODY_TOKENS_ENABLED", false),
+
+		APITokenDefaultTTL: getEnvDuration("API_TOKEN_DEFAULT_TTL", 30*24*time.Hour),
+		APITokenMaxTTL:     getEnvDuration("API_TOKEN_MAX_TTL", 365*24*time.Hour),
+
+		ServiceClientsFile: getEnv("SERVICE_CLIENTS_FILE", ""),
+
+		OIDCSigningKey:        getEnv("OIDC_SIGNING_KEY", ""),
+		OIDCRegistrationToken: getEnv("OIDC_REGISTRATION_TOKEN", ""),
+
+		DPoPNonceTTL: getEnvDuration("DPOP_NONCE_TTL", 5*time.Minute),
+
+		CORSMaxAge:                 getEnvDuration("CORS_MAX_AGE", 10*time.Minute),
+		AccountStatusCacheTTL:      getEnvDuration("ACCOUNT_STATUS_CACHE_TTL", 30*time.Second),
+		UserCacheTTL:               getEnvDuration("USER_CACHE_TTL", 0),
+		RecentAuthMaxAge:           getEnvDuration("RECENT_AUTH_MAX_AGE", 10*time.Minute),
+		AccountDeletionGracePeriod: getEnvDuration("ACCOUNT_DELETION_GRACE_PERIOD", 30*24*time.Hour),
+		ImpersonationTTL:           getEnvDuration("IMPERSONATION_TTL", 15*time.Minute),
+
+		MFAIssuer: getEnv("MFA_ISSUER", "go-google-auth"),
+
+		WebAuthnRPName:       getEnv("WEBAUTHN_RP_NAME", "go-google-auth"),
+		WebAuthnChallengeTTL: getEnvDuration("WEBAUTHN_CHALLENGE_TTL", 5*time.Minute),
+
+		MagicLinkTTL:  getEnvDuration("MAGIC_LINK_TTL", 15*time.Minute),
+		MailFrom:      getEnv("MAIL_FROM", "no-reply@localhost"),
+		SMTPHost:      getEnv("SMTP_HOST", ""),
+		SMTPPort:      getEnv("SMTP_PORT", "587"),
+		SMTPUsername:  getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:  getEnv("SMTP_PASSWORD", ""),
+		MailOutboxDir: getEnv("MAIL_OUTBOX_DIR", ""),
+
+		OrgInvitationTTL: getEnvDuration("ORG_INVITATION_TTL", 7*24*time.Hour),
+
+		LoginAlertsEnabled:      getEnvBool("LOGIN_ALERTS_ENABLED", true),
+		GeoIPDatabase:           getEnv("GEOIP_DATABASE", ""),
+		LoginAlertWebhookURL:    getEnv("LOGIN_ALERT_WEBHOOK_URL", ""),
+		LoginAlertWebhookSecret: getEnv("LOGIN_ALERT_WEBHOOK_SECRET", ""),
+
+		RateLimitLogin:         getEnvRateLimit("RATE_LIMIT_LOGIN", RateLimit{Limit: 10, Window: time.Minute}),
+		RateLimitRefreshIP:     getEnvRateLimit("RATE_LIMIT_REFRESH_IP", RateLimit{Limit: 30, Window: time.Minute}),
+		RateLimitRefreshFamily: getEnvRateLimit("RATE_LIMIT_REFRESH_FAMILY", RateLimit{Limit: 10, Window: time.Minute}),
+		RateLimitMFA:           getEnvRateLimit("RATE_LIMIT_MFA", RateLimit{Limit: 5, Window: time.Minute}),
+		RateLimitEmailLogin:    getEnvRateLimit("RATE_LIMIT_EMAIL_LOGIN", RateLimit{Limit: 3, Window: 15 * time.Minute}),
+		RateLimitAPIUser:       getEnvRateLimit("RATE_LIMIT_API_USER", RateLimit{Limit: 120, Window: time.Minute}),
+		RateLimitStore:         getEnvChoice("RATE_LIMIT_STORE", RateLimitStoreMemory, RateLimitStoreRedis),
+		RateLimitRedisURL:      getEnv("RATE_LIMIT_REDIS_URL", ""),
+
+		HSTSIncludeSubdomains: getEnvBool("HSTS_INCLUDE_SUBDOMAINS", true),
+		HSTSPreload:           getEnvBool("HSTS_PRELOAD", false),
+		ContentSecurityPolicy: getEnvOptional("CONTENT_SECURITY_POLICY", DefaultContentSecurityPolicy),
+		ReferrerPolicy:        getEnvOptional("REFERRER_POLICY", "no-referrer"),
+	}
+
+	// HSTS is only on by default in production; local development runs over plain HTTP
+	defaultHSTSMaxAge := time.Duration(0)
+	if cfg.IsProduction() {
+		defaultHSTSMaxAge = DefaultHSTSMaxAge
+	}
+	cfg.HSTSMaxAge = getEnvDuration("HSTS_MAX_AGE", defaultHSTSMaxAge)
+
+	cfg.JWTAudiences = splitList(getEnv("JWT_AUDIENCE", cfg.JWTIssuer))
+	if proxies := getEnv("TRUSTED_PROXIES", ""); proxies != "" {
+		cfg.TrustedProxies = splitList(proxies)
+	}
+	if ids := getEnv("ADMIN_USER_IDS", ""); ids != "" {
+		cfg.AdminUserIDs = splitList(ids)
+	}
+	if emails := getEnv("ADMIN_EMAILS", ""); emails != "" {
+		cfg.AdminEmails = splitList(emails)
+	}
+
+	// Passkeys belong to the site the frontend is served from
+	cfg.WebAuthnRPID = getEnv("WEBAUTHN_RP_ID", hostname(cfg.FrontendURL))
+	cfg.WebAuthnOrigins = allowedOrigins
+	if origins := getEnv("WEBAUTHN_ORIGINS", ""); origins != "" {
+		cfg.WebAuthnOrigins = splitList(origins)
+	}
+
+	// Login links point at this server's verify endpoint
+	cfg.MagicLinkURL = getEnv("MAGIC_LINK_URL", "http://localhost:"+cfg.Port+"/auth/email/verify")
+	cfg.SessionRevokeURL = getEnv("SESSION_REVOKE_URL", "http://localhost:"+cfg.Port+"/auth/sessions/revoke")
+	// Invitations and re-authentication links are used by a signed-in user in the frontend
+	cfg.OrgInvitationURL = getEnv("ORG_INVITATION_URL", strings.TrimSuffix(cfg.FrontendURL, "/")+"/invitations/accept")
+	cfg.ReauthLinkURL = getEnv("REAUTH_LINK_URL", strings.TrimSuffix(cfg.FrontendURL, "/")+"/reauth")
+	cfg.OIDCIssuer = strings.TrimSuffix(getEnv("OIDC_ISSUER", "http://localhost:"+cfg.Port), "/")
+	cfg.OAuthTokenURL = getEnv("OAUTH_TOKEN_URL", cfg.OIDCIssuer+"/oauth/token")
+
+	return cfg
+}
+
+// hostname returns the host of a URL without its port, or "" if it cannot be parsed
+func hostname(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
 	}
+	return parsed.Hostname()
 }
 
 // generateRandomSecret generates a random 32-byte secret for development
@@ -60,9 +358,97 @@ func (c *Config) IsProduction() bool {
 	return c.Environment == "production" || c.Environment == "prod"
 }
 
+// splitList splits a comma separated list, trimming spaces around each value
+func splitList(value string) []string {
+	values := strings.Split(value, ",")
+	for i := range values {
+		values[i] = strings.TrimSpace(values[i])
+	}
+	return values
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
+
+// getEnvOptional is like getEnv, but "off" disables the setting by returning ""
+func getEnvOptional(key, defaultValue string) string {
+	value := getEnv(key, defaultValue)
+	if value == "off" {
+		return ""
+	}
+	return value
+}
+
+// getEnvChoice returns the variable if it is the default or one of the other
+// allowed values, falling back to the default when it is unset or invalid
+func getEnvChoice(key, defaultValue string, allowed ...string) string {
+	value := strings.ToLower(os.Getenv(key))
+	if value == "" || value == defaultValue {
+		return defaultValue
+	}
+
+	for _, candidate := range allowed {
+		if value == candidate {
+			return value
+		}
+	}
+	log.Printf("WARNING: invalid value for %s (%q), using default %s", key, value, defaultValue)
+	return defaultValue
+}
+
+// getEnvBool parses a boolean such as "true" or "0", falling back to the default
+// when the variable is unset or invalid
+func getEnvBool(key string, defaultValue bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("WARNING: invalid boolean for %s (%q), using default %t", key, value, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
+
+// getEnvDuration parses a duration such as "30s" or "5m", falling back to the default
+// when the variable is unset or invalid
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("WARNING: invalid duration for %s (%q), using default %s", key, value, defaultValue)
+		return defaultValue
+	}
+	return duration
+}
+
+// getEnvRateLimit parses a rate limit such as "10/1m" (10 requests per minute) or
+// "off", falling back to the default when the variable is unset or invalid
+func getEnvRateLimit(key string, defaultValue RateLimit) RateLimit {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	if value == "off" || value == "0" {
+		return RateLimit{}
+	}
+
+	limitStr, windowStr, found := strings.Cut(value, "/")
+	limit, limitErr := strconv.Atoi(strings.TrimSpace(limitStr))
+	window, windowErr := time.ParseDuration(strings.TrimSpace(windowStr))
+	if !found || limitErr != nil || windowErr != nil || limit < 0 || window <= 0 {
+		log.Printf("WARNING: invalid rate limit for %s (%q), using default %d/%s", key, value, defaultValue.Limit, defaultValue.Window)
+		return defaultValue
+	}
+	return RateLimit{Limit: limit, Window: window}
+}
@@ -1,13 +1,42 @@
 package container
 
 import (
+	"context"
+	"crypto/rsa"
+	"log"
+	"strings"
+
+	"github.com/yuki5155/go-google-auth/internal/application/account"
 	"github.com/yuki5155/go-google-auth/internal/application/auth"
 	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/application/tenancy"
+	"github.com/yuki5155/go-google-auth/internal/domain/apitoken"
+	"github.com/yuki5155/go-google-auth/internal/domain/audit"
+	"github.com/yuki5155/go-google-auth/internal/domain/consent"
+	"github.com/yuki5155/go-google-auth/internal/domain/mfa"
+	"github.com/yuki5155/go-google-auth/internal/domain/organization"
+	"github.com/yuki5155/go-google-auth/internal/domain/serviceclient"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
 	"github.com/yuki5155/go-google-auth/internal/domain/user"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/auth/authcode"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/auth/clientauth"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/auth/csrf"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/auth/dpop"
 	"github.com/yuki5155/go-google-auth/internal/infrastructure/auth/google"
 	"github.com/yuki5155/go-google-auth/internal/infrastructure/auth/jwt"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/auth/magiclink"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/auth/revokelink"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/auth/totp"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/auth/webauthn"
 	"github.com/yuki5155/go-google-auth/internal/infrastructure/config"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/device"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/events"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/mail"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/notify"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/persistence/cache"
 	"github.com/yuki5155/go-google-auth/internal/infrastructure/persistence/memory"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/ratelimit"
 )
 
 // Container holds all application dependencies
@@ -16,45 +45,510 @@ type Container struct {
 	Config *config.Config
 
 	// Infrastructure
-	UserRepository user.Repository
-	TokenGenerator ports.TokenGenerator
-	OAuthValidator ports.OAuthValidator
+	UserRepository     user.Repository
+	SessionRepository  session.Repository
+	MFARepository      mfa.Repository
+	APITokenRepository apitoken.Repository
+	ServiceClients     serviceclient.Repository
+	ConsentRepository  consent.Repository
+	AuditRepository    audit.Repository
+	OrgRepository      organization.Repository
+	Memberships        organization.MembershipRepository
+	Invitations        organization.InvitationRepository
+	DeletionScheduler  ports.DeletionScheduler
+	EventPublisher     ports.EventPublisher
+	TokenGenerator     ports.TokenGenerator
+	CSRFTokens         ports.CSRFTokenService
+	TOTP               ports.TOTPService
+	WebAuthn           ports.WebAuthnVerifier
+	WebAuthnChallenges ports.WebAuthnChallengeStore
+	MagicLinks         ports.MagicLinkTokenService
+	UsedMagicLinks     ports.MagicLinkStore
+	Mailer             ports.Mailer
+	RevokeLinks        ports.RevokeLinkTokenService
+	ClientAssertions   ports.ClientAssertionVerifier
+	UsedAssertions     ports.ClientAssertionStore
+	DPoPProofs         ports.DPoPVerifier
+	UsedDPoPProofs     ports.DPoPReplayCache
+	AuthorizationCodes ports.AuthorizationGrantTokens
+	ConsentRequests    ports.AuthorizationGrantTokens
+	UsedCodes          ports.AuthorizationCodeStore
+	RevokedTokens      ports.RevokedTokenStore
+	IDTokens           ports.IDTokenIssuer
+	OAuthValidator     ports.OAuthValidator
+	RateLimiter        *ratelimit.Limiter
 
 	// Use Cases
 	GoogleLoginUseCase    *auth.GoogleLoginUseCase
 	RefreshTokenUseCase   *auth.RefreshTokenUseCase
 	GetCurrentUserUseCase *auth.GetCurrentUserUseCase
 	LogoutUseCase         *auth.LogoutUseCase
+	VerifyMFAUseCase      *auth.VerifyMFAUseCase
+	ReauthenticateUseCase *auth.ReauthenticateUseCase
+	StartReauthUseCase    *auth.StartReauthUseCase
+
+	StartEmailLoginUseCase *auth.StartEmailLoginUseCase
+	EmailLoginUseCase      *auth.EmailLoginUseCase
+
+	DisownLoginUseCase *auth.DisownLoginUseCase
+
+	GetMFAStatusUseCase            *auth.GetMFAStatusUseCase
+	EnrollTOTPUseCase              *auth.EnrollTOTPUseCase
+	ConfirmTOTPUseCase             *auth.ConfirmTOTPUseCase
+	DisableTOTPUseCase             *auth.DisableTOTPUseCase
+	RegenerateRecoveryCodesUseCase *auth.RegenerateRecoveryCodesUseCase
+
+	RegisterPasskeyUseCase *auth.RegisterPasskeyUseCase
+	ListPasskeysUseCase    *auth.ListPasskeysUseCase
+	RemovePasskeyUseCase   *auth.RemovePasskeyUseCase
+	PasskeyLoginUseCase    *auth.PasskeyLoginUseCase
+	PasskeyMFAUseCase      *auth.PasskeyMFAUseCase
+
+	CreateAPITokenUseCase *auth.CreateAPITokenUseCase
+	ListAPITokensUseCase  *auth.ListAPITokensUseCase
+	RevokeAPITokenUseCase *auth.RevokeAPITokenUseCase
+
+	ClientCredentialsUseCase *auth.ClientCredentialsUseCase
+	TokenExchangeUseCase     *auth.TokenExchangeUseCase
+	IntrospectTokenUseCase   *auth.IntrospectTokenUseCase
+	RevokeTokenUseCase       *auth.RevokeTokenUseCase
+
+	AuthorizeUseCase         *auth.AuthorizeUseCase
+	GetConsentRequestUseCase *auth.GetConsentRequestUseCase
+	DecideConsentUseCase     *auth.DecideConsentUseCase
+	AuthorizationCodeUseCase *auth.AuthorizationCodeUseCase
+	GetUserInfoUseCase       *auth.GetUserInfoUseCase
+	RegisterClientUseCase    *auth.RegisterClientUseCase
+
+	UpdateProfileUseCase        *account.UpdateProfileUseCase
+	DeleteAccountUseCase        *account.DeleteAccountUseCase
+	ExportDataUseCase           *account.ExportDataUseCase
+	PurgeDeletedAccountsUseCase *account.PurgeDeletedAccountsUseCase
+
+	StartImpersonationUseCase *auth.StartImpersonationUseCase
+	StopImpersonationUseCase  *auth.StopImpersonationUseCase
+
+	CreateOrganizationUseCase    *tenancy.CreateOrganizationUseCase
+	ListOrganizationsUseCase     *tenancy.ListOrganizationsUseCase
+	GetActiveOrganizationUseCase *tenancy.GetActiveOrganizationUseCase
+	SelectOrganizationUseCase    *auth.SelectOrganizationUseCase
+	InviteMemberUseCase          *tenancy.InviteMemberUseCase
+	RevokeInvitationUseCase      *tenancy.RevokeInvitationUseCase
+	AcceptInvitationUseCase      *tenancy.AcceptInvitationUseCase
+	ChangeMemberRoleUseCase      *tenancy.ChangeMemberRoleUseCase
+	RemoveMemberUseCase          *tenancy.RemoveMemberUseCase
+
+	// Services
+	AccountStatusService       *auth.AccountStatusService
+	LoginAlertService          *auth.LoginAlertService
+	APITokenAuthenticator      *auth.APITokenAuthenticator
+	ServiceClientAuthenticator *auth.ServiceClientAuthenticator
+	DPoPAuthenticator          *auth.DPoPAuthenticator
+}
+
+// Option customizes the dependencies a Container wires
+type Option func(*options)
+
+type options struct {
+	claimsEnrichers []ports.ClaimsEnricher
+}
+
+// WithClaimsEnrichers adds custom claims to users' access tokens
+func WithClaimsEnrichers(enrichers ...ports.ClaimsEnricher) Option {
+	return func(o *options) {
+		o.claimsEnrichers = append(o.claimsEnrichers, enrichers...)
+	}
 }
 
 // NewContainer creates and wires all dependencies
-func NewContainer(cfg *config.Config) *Container {
+func NewContainer(cfg *config.Config, opts ...Option) *Container {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	// Infrastructure layer
-	userRepo := memory.NewUserRepository()
-	tokenGen := jwt.NewService(cfg.JWTSecret)
+	var userRepo user.Repository = memory.NewUserRepository()
+	if cfg.UserCacheTTL > 0 {
+		userRepo = cache.NewUserRepository(userRepo, cfg.UserCacheTTL)
+	}
+	sessionRepo := memory.NewSessionRepository()
+	mfaRepo := memory.NewMFARepository()
+	apiTokenRepo := memory.NewAPITokenRepository()
+	serviceClients := newServiceClientRepository(cfg)
+	consentRepo := memory.NewConsentRepository()
+	auditRepo := memory.NewAuditRepository()
+	orgRepo := memory.NewOrganizationRepository()
+	membershipRepo := memory.NewMembershipRepository()
+	invitationRepo := memory.NewInvitationRepository()
+	deletionSchedule := memory.NewDeletionSchedule()
+	eventPublisher := events.NewAuditPublisher(auditRepo)
+	tokenGen := jwt.NewService(cfg.JWTSecret,
+		jwt.WithIssuer(cfg.JWTIssuer),
+		jwt.WithAudiences(cfg.JWTAudiences...),
+		jwt.WithClaimsEnrichers(o.claimsEnrichers...),
+	)
+	csrfTokens := csrf.NewService(cfg.JWTSecret)
+	totpService := totp.NewService(cfg.MFAIssuer)
+	webAuthn := webauthn.NewService(cfg.WebAuthnRPID, cfg.WebAuthnRPName, cfg.WebAuthnOrigins)
+	// Like the other memory stores, challenges are per instance; a passkey
+	// ceremony must finish on the instance that started it
+	webAuthnChallenges := memory.NewWebAuthnChallengeStore()
+	magicLinks := magiclink.NewService(cfg.JWTSecret)
+	// Links are stateless, but the record of used links is per instance
+	usedMagicLinks := memory.NewUsedIDStore(shared.ErrInvalidMagicLink)
+	mailer := newMailer(cfg)
+	revokeLinks := revokelink.NewService(cfg.JWTSecret)
 	oauthValidator := google.NewValidator()
+	clientAssertions := clientauth.NewAssertionVerifier(cfg.OAuthTokenURL)
+	// Like used login links, used client assertions are recorded per instance
+	usedAssertions := memory.NewUsedIDStore(shared.ErrInvalidClient)
+	// Proofs are checked against the public base URL; nonces are stateless,
+	// but the record of used proofs is per instance
+	dpopProofs := dpop.NewVerifier(cfg.OIDCIssuer, cfg.JWTSecret, cfg.DPoPNonceTTL)
+	usedDPoPProofs := memory.NewUsedIDStore(shared.ErrInvalidDPoPProof)
+	authorizationCodes := authcode.NewCodeService(cfg.JWTSecret)
+	consentRequests := authcode.NewConsentRequestService(cfg.JWTSecret)
+	// Codes are stateless, but the record of redeemed codes is per instance
+	usedCodes := memory.NewUsedIDStore(shared.ErrInvalidGrant)
+	// Revoked client tokens are also recorded per instance; session tokens
+	// are revoked with their session instead
+	revokedTokens := memory.NewRevokedTokenStore()
+	idTokens := jwt.NewIDTokenService(cfg.OIDCIssuer, newIDTokenSigningKey(cfg))
+	rateLimiter := ratelimit.NewLimiter(newRateLimitStore(cfg))
+
+	// Application layer - Login alerts (nil when disabled)
+	var loginAlertService *auth.LoginAlertService
+	if cfg.LoginAlertsEnabled {
+		loginAlertService = auth.NewLoginAlertService(
+			sessionRepo,
+			device.NewFingerprinter(newGeoDatabase(cfg)),
+			revokeLinks,
+			newLoginAlertNotifier(cfg, mailer),
+			eventPublisher,
+			cfg.SessionRevokeURL,
+		)
+	}
 
 	// Application layer - Use cases
 	googleLoginUC := auth.NewGoogleLoginUseCase(
 		userRepo,
+		sessionRepo,
+		mfaRepo,
 		oauthValidator,
 		tokenGen,
+		eventPublisher,
+		loginAlertService,
 		cfg.GoogleClientID,
 	)
-	refreshTokenUC := auth.NewRefreshTokenUseCase(tokenGen)
+	refreshTokenUC := auth.NewRefreshTokenUseCase(userRepo, sessionRepo, tokenGen)
 	getCurrentUserUC := auth.NewGetCurrentUserUseCase(userRepo, tokenGen)
 	logoutUC := auth.NewLogoutUseCase()
+	verifyMFAUC := auth.NewVerifyMFAUseCase(userRepo, sessionRepo, mfaRepo, tokenGen, totpService, eventPublisher)
+	reauthenticateUC := auth.NewReauthenticateUseCase(
+		userRepo,
+		sessionRepo,
+		mfaRepo,
+		oauthValidator,
+		magicLinks,
+		usedMagicLinks,
+		webAuthn,
+		webAuthnChallenges,
+		tokenGen,
+		totpService,
+		eventPublisher,
+		cfg.GoogleClientID,
+	)
+	startReauthUC := auth.NewStartReauthUseCase(
+		userRepo,
+		magicLinks,
+		mailer,
+		webAuthn,
+		webAuthnChallenges,
+		cfg.ReauthLinkURL,
+		cfg.MagicLinkTTL,
+		cfg.WebAuthnChallengeTTL,
+	)
+
+	// Application layer - Email login use cases
+	startEmailLoginUC := auth.NewStartEmailLoginUseCase(magicLinks, mailer, cfg.MagicLinkURL, cfg.MagicLinkTTL)
+	emailLoginUC := auth.NewEmailLoginUseCase(userRepo, sessionRepo, mfaRepo, magicLinks, usedMagicLinks, tokenGen, eventPublisher)
+	disownLoginUC := auth.NewDisownLoginUseCase(userRepo, sessionRepo, revokeLinks, eventPublisher)
+
+	// Application layer - MFA management use cases
+	getMFAStatusUC := auth.NewGetMFAStatusUseCase(userRepo, mfaRepo)
+	enrollTOTPUC := auth.NewEnrollTOTPUseCase(userRepo, mfaRepo, totpService)
+	confirmTOTPUC := auth.NewConfirmTOTPUseCase(mfaRepo, totpService, eventPublisher)
+	disableTOTPUC := auth.NewDisableTOTPUseCase(mfaRepo, totpService, eventPublisher)
+	regenerateRecoveryCodesUC := auth.NewRegenerateRecoveryCodesUseCase(mfaRepo, totpService, eventPublisher)
+
+	// Application layer - Passkey use cases
+	registerPasskeyUC := auth.NewRegisterPasskeyUseCase(userRepo, webAuthn, webAuthnChallenges, eventPublisher, cfg.WebAuthnChallengeTTL)
+	listPasskeysUC := auth.NewListPasskeysUseCase(userRepo)
+	removePasskeyUC := auth.NewRemovePasskeyUseCase(userRepo, eventPublisher)
+	passkeyLoginUC := auth.NewPasskeyLoginUseCase(userRepo, sessionRepo, webAuthn, webAuthnChallenges, tokenGen, eventPublisher, cfg.WebAuthnChallengeTTL)
+	passkeyMFAUC := auth.NewPasskeyMFAUseCase(userRepo, sessionRepo, webAuthn, webAuthnChallenges, tokenGen, eventPublisher, cfg.WebAuthnChallengeTTL)
+
+	// Application layer - Personal access token use cases
+	createAPITokenUC := auth.NewCreateAPITokenUseCase(userRepo, apiTokenRepo, eventPublisher, cfg.APITokenDefaultTTL, cfg.APITokenMaxTTL)
+	listAPITokensUC := auth.NewListAPITokensUseCase(apiTokenRepo)
+	revokeAPITokenUC := auth.NewRevokeAPITokenUseCase(apiTokenRepo, eventPublisher)
+
+	// Application layer - Service client use cases
+	serviceClientAuthenticator := auth.NewServiceClientAuthenticator(serviceClients, clientAssertions, usedAssertions)
+	clientCredentialsUC := auth.NewClientCredentialsUseCase(serviceClientAuthenticator, tokenGen)
+
+	// Application layer - OpenID Connect provider use cases
+	authorizeUC := auth.NewAuthorizeUseCase(serviceClients, consentRepo, authorizationCodes, consentRequests)
+	getConsentRequestUC := auth.NewGetConsentRequestUseCase(serviceClients, consentRequests)
+	decideConsentUC := auth.NewDecideConsentUseCase(serviceClients, consentRepo, authorizationCodes, consentRequests, eventPublisher)
+	authorizationCodeUC := auth.NewAuthorizationCodeUseCase(
+		serviceClientAuthenticator,
+		authorizationCodes,
+		usedCodes,
+		userRepo,
+		sessionRepo,
+		tokenGen,
+		idTokens,
+	)
+	getUserInfoUC := auth.NewGetUserInfoUseCase(userRepo)
+	registerClientUC := auth.NewRegisterClientUseCase(serviceClients, cfg.OIDCRegistrationToken)
+
+	// Application layer - Services
+	adminRoles := auth.NewAdminRoles(cfg.AdminUserIDs, cfg.AdminEmails)
+	accountStatusService := auth.NewAccountStatusService(userRepo, adminRoles, cfg.AccountStatusCacheTTL)
+	apiTokenAuthenticator := auth.NewAPITokenAuthenticator(userRepo, apiTokenRepo)
+	dpopAuthenticator := auth.NewDPoPAuthenticator(dpopProofs, usedDPoPProofs)
+
+	// Application layer - Token exchange, introspection and revocation use cases
+	tokenExchangeUC := auth.NewTokenExchangeUseCase(serviceClientAuthenticator, tokenGen, userRepo, sessionRepo, revokedTokens)
+	introspectTokenUC := auth.NewIntrospectTokenUseCase(serviceClientAuthenticator, tokenGen, apiTokenAuthenticator, userRepo, sessionRepo, revokedTokens)
+	revokeTokenUC := auth.NewRevokeTokenUseCase(serviceClientAuthenticator, tokenGen, apiTokenRepo, sessionRepo, revokedTokens, eventPublisher)
+
+	// Application layer - Account use cases
+	updateProfileUC := account.NewUpdateProfileUseCase(userRepo, eventPublisher)
+	deleteAccountUC := account.NewDeleteAccountUseCase(
+		userRepo,
+		sessionRepo,
+		membershipRepo,
+		deletionSchedule,
+		eventPublisher,
+		accountStatusService,
+		cfg.RecentAuthMaxAge,
+		cfg.AccountDeletionGracePeriod,
+	)
+	exportDataUC := account.NewExportDataUseCase(userRepo, sessionRepo, auditRepo)
+	purgeDeletedAccountsUC := account.NewPurgeDeletedAccountsUseCase(userRepo, sessionRepo, mfaRepo, apiTokenRepo, consentRepo, orgRepo, membershipRepo, invitationRepo, auditRepo, deletionSchedule)
+
+	// Application layer - Admin use cases
+	startImpersonationUC := auth.NewStartImpersonationUseCase(userRepo, adminRoles, tokenGen, eventPublisher, cfg.ImpersonationTTL)
+	stopImpersonationUC := auth.NewStopImpersonationUseCase(userRepo, revokedTokens, eventPublisher)
+
+	// Application layer - Organization use cases
+	createOrganizationUC := tenancy.NewCreateOrganizationUseCase(userRepo, orgRepo, membershipRepo, eventPublisher)
+	listOrganizationsUC := tenancy.NewListOrganizationsUseCase(orgRepo, membershipRepo)
+	getActiveOrganizationUC := tenancy.NewGetActiveOrganizationUseCase(userRepo, orgRepo, membershipRepo, invitationRepo)
+	selectOrganizationUC := auth.NewSelectOrganizationUseCase(userRepo, sessionRepo, membershipRepo, tokenGen)
+	inviteMemberUC := tenancy.NewInviteMemberUseCase(
+		orgRepo,
+		membershipRepo,
+		invitationRepo,
+		mailer,
+		eventPublisher,
+		cfg.OrgInvitationURL,
+		cfg.OrgInvitationTTL,
+	)
+	revokeInvitationUC := tenancy.NewRevokeInvitationUseCase(membershipRepo, invitationRepo, eventPublisher)
+	acceptInvitationUC := tenancy.NewAcceptInvitationUseCase(userRepo, orgRepo, membershipRepo, invitationRepo, eventPublisher)
+	changeMemberRoleUC := tenancy.NewChangeMemberRoleUseCase(membershipRepo, eventPublisher)
+	removeMemberUC := tenancy.NewRemoveMemberUseCase(userRepo, membershipRepo, eventPublisher)
 
 	return &Container{
-		Config:                cfg,
-		UserRepository:        userRepo,
-		TokenGenerator:        tokenGen,
-		OAuthValidator:        oauthValidator,
-		GoogleLoginUseCase:    googleLoginUC,
-		RefreshTokenUseCase:   refreshTokenUC,
-		GetCurrentUserUseCase: getCurrentUserUC,
-		LogoutUseCase:         logoutUC,
+		Config:                         cfg,
+		UserRepository:                 userRepo,
+		SessionRepository:              sessionRepo,
+		MFARepository:                  mfaRepo,
+		APITokenRepository:             apiTokenRepo,
+		ServiceClients:                 serviceClients,
+		ConsentRepository:              consentRepo,
+		AuditRepository:                auditRepo,
+		OrgRepository:                  orgRepo,
+		Memberships:                    membershipRepo,
+		Invitations:                    invitationRepo,
+		DeletionScheduler:              deletionSchedule,
+		EventPublisher:                 eventPublisher,
+		TokenGenerator:                 tokenGen,
+		CSRFTokens:                     csrfTokens,
+		TOTP:                           totpService,
+		WebAuthn:                       webAuthn,
+		WebAuthnChallenges:             webAuthnChallenges,
+		MagicLinks:                     magicLinks,
+		UsedMagicLinks:                 usedMagicLinks,
+		Mailer:                         mailer,
+		RevokeLinks:                    revokeLinks,
+		ClientAssertions:               clientAssertions,
+		UsedAssertions:                 usedAssertions,
+		DPoPProofs:                     dpopProofs,
+		UsedDPoPProofs:                 usedDPoPProofs,
+		AuthorizationCodes:             authorizationCodes,
+		ConsentRequests:                consentRequests,
+		UsedCodes:                      usedCodes,
+		RevokedTokens:                  revokedTokens,
+		IDTokens:                       idTokens,
+		OAuthValidator:                 oauthValidator,
+		RateLimiter:                    rateLimiter,
+		GoogleLoginUseCase:             googleLoginUC,
+		RefreshTokenUseCase:            refreshTokenUC,
+		GetCurrentUserUseCase:          getCurrentUserUC,
+		LogoutUseCase:                  logoutUC,
+		VerifyMFAUseCase:               verifyMFAUC,
+		ReauthenticateUseCase:          reauthenticateUC,
+		StartReauthUseCase:             startReauthUC,
+		StartEmailLoginUseCase:         startEmailLoginUC,
+		EmailLoginUseCase:              emailLoginUC,
+		DisownLoginUseCase:             disownLoginUC,
+		GetMFAStatusUseCase:            getMFAStatusUC,
+		EnrollTOTPUseCase:              enrollTOTPUC,
+		ConfirmTOTPUseCase:             confirmTOTPUC,
+		DisableTOTPUseCase:             disableTOTPUC,
+		RegenerateRecoveryCodesUseCase: regenerateRecoveryCodesUC,
+		RegisterPasskeyUseCase:         registerPasskeyUC,
+		ListPasskeysUseCase:            listPasskeysUC,
+		RemovePasskeyUseCase:           removePasskeyUC,
+		PasskeyLoginUseCase:            passkeyLoginUC,
+		PasskeyMFAUseCase:              passkeyMFAUC,
+		CreateAPITokenUseCase:          createAPITokenUC,
+		ListAPITokensUseCase:           listAPITokensUC,
+		RevokeAPITokenUseCase:          revokeAPITokenUC,
+		ClientCredentialsUseCase:       clientCredentialsUC,
+		TokenExchangeUseCase:           tokenExchangeUC,
+		IntrospectTokenUseCase:         introspectTokenUC,
+		RevokeTokenUseCase:             revokeTokenUC,
+		AuthorizeUseCase:               authorizeUC,
+		GetConsentRequestUseCase:       getConsentRequestUC,
+		DecideConsentUseCase:           decideConsentUC,
+		AuthorizationCodeUseCase:       authorizationCodeUC,
+		GetUserInfoUseCase:             getUserInfoUC,
+		RegisterClientUseCase:          registerClientUC,
+		UpdateProfileUseCase:           updateProfileUC,
+		DeleteAccountUseCase:           deleteAccountUC,
+		ExportDataUseCase:              exportDataUC,
+		PurgeDeletedAccountsUseCase:    purgeDeletedAccountsUC,
+		StartImpersonationUseCase:      startImpersonationUC,
+		StopImpersonationUseCase:       stopImpersonationUC,
+		CreateOrganizationUseCase:      createOrganizationUC,
+		ListOrganizationsUseCase:       listOrganizationsUC,
+		GetActiveOrganizationUseCase:   getActiveOrganizationUC,
+		SelectOrganizationUseCase:      selectOrganizationUC,
+		InviteMemberUseCase:            inviteMemberUC,
+		RevokeInvitationUseCase:        revokeInvitationUC,
+		AcceptInvitationUseCase:        acceptInvitationUC,
+		ChangeMemberRoleUseCase:        changeMemberRoleUC,
+		RemoveMemberUseCase:            removeMemberUC,
+		AccountStatusService:           accountStatusService,
+		LoginAlertService:              loginAlertService,
+		APITokenAuthenticator:          apiTokenAuthenticator,
+		ServiceClientAuthenticator:     serviceClientAuthenticator,
+		DPoPAuthenticator:              dpopAuthenticator,
+	}
+}
+
+// newMailer sends email through SMTP when configured, and otherwise to the
+// development outbox. Production never uses the outbox, which may log
+// login links; email is turned off there instead.
+func newMailer(cfg *config.Config) ports.Mailer {
+	if cfg.SMTPHost != "" {
+		return mail.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom)
+	}
+
+	if cfg.IsProduction() {
+		log.Println("WARNING: SMTP_HOST not set, email login, email login alerts and invitations are disabled. Set SMTP_HOST in production.")
+		return mail.NewDisabledMailer()
+	}
+	return mail.NewOutboxMailer(cfg.MailOutboxDir, cfg.MailFrom)
+}
+
+// newRateLimitStore keeps rate limit buckets in the configured Redis-compatible
+// server, shared by every instance, or otherwise in memory per instance
+func newRateLimitStore(cfg *config.Config) ratelimit.Store {
+	if cfg.RateLimitStore != config.RateLimitStoreRedis {
+		return ratelimit.NewMemoryStore()
+	}
+
+	kv, err := ratelimit.NewRedisKV(cfg.RateLimitRedisURL)
+	if err != nil {
+		log.Printf("WARNING: %v; rate limits are kept per instance. Set RATE_LIMIT_REDIS_URL to share them.", err)
+		return ratelimit.NewMemoryStore()
+	}
+	return ratelimit.NewSharedStore(kv)
+}
+
+// newServiceClientRepository registers the service clients from the
+// configured file; without one, the client credentials grant has no clients
+func newServiceClientRepository(cfg *config.Config) *memory.ServiceClientRepository {
+	repo := memory.NewServiceClientRepository()
+	if cfg.ServiceClientsFile == "" {
+		return repo
+	}
+
+	clients, err := clientauth.LoadClients(cfg.ServiceClientsFile)
+	if err != nil {
+		log.Printf("WARNING: %v; no service clients are registered", err)
+		return repo
+	}
+
+	for _, client := range clients {
+		_ = repo.Save(context.Background(), client)
+	}
+	log.Printf("Registered %d service client(s)", len(clients))
+	return repo
+}
+
+// newIDTokenSigningKey loads the ID token signing key, or generates one when
+// none is configured. A generated key changes on every restart and differs
+// between instances, so relying parties cannot verify ID tokens for long.
+func newIDTokenSigningKey(cfg *config.Config) *rsa.PrivateKey {
+	if cfg.OIDCSigningKey != "" {
+		// Environment variables often carry PEM newlines escaped as "\n"
+		key, err := jwt.ParseRSAPrivateKey(strings.ReplaceAll(cfg.OIDCSigningKey, `\n`, "\n"))
+		if err == nil {
+			return key
+		}
+		log.Printf("WARNING: invalid OIDC_SIGNING_KEY (%v); using a generated key", err)
+	} else {
+		log.Println("WARNING: OIDC_SIGNING_KEY not set, using a generated ID token signing key. Set OIDC_SIGNING_KEY in production.")
+	}
+
+	key, err := jwt.GenerateRSAKey()
+	if err != nil {
+		log.Fatalf("Failed to generate ID token signing key: %v", err)
+	}
+	return key
+}
+
+// newGeoDatabase loads the GeoIP database when configured; without it,
+// device fingerprints carry no country
+func newGeoDatabase(cfg *config.Config) *device.GeoDatabase {
+	if cfg.GeoIPDatabase == "" {
+		return nil
+	}
+
+	geo, err := device.LoadGeoDatabase(cfg.GeoIPDatabase)
+	if err != nil {
+		log.Printf("WARNING: %v; login alerts will not use countries", err)
+		return nil
+	}
+	return geo
+}
+
+// newLoginAlertNotifier emails login alerts, and also posts them to the
+// webhook when one is configured
+func newLoginAlertNotifier(cfg *config.Config, mailer ports.Mailer) ports.LoginAlertNotifier {
+	notifiers := notify.Notifiers{notify.NewEmailNotifier(mailer)}
+	if cfg.LoginAlertWebhookURL != "" {
+		notifiers = append(notifiers, notify.NewWebhookNotifier(cfg.LoginAlertWebhookURL, cfg.LoginAlertWebhookSecret))
 	}
+	return notifiers
 }
 
 // GetTokenGenerator returns the token generator (for middleware)
@@ -0,0 +1,55 @@
+package device
+
+import (
+	"net/netip"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+)
+
+// Network prefix lengths used to group client addresses; roughly one
+// home or office network each
+const (
+	ipv4PrefixBits = 24
+	ipv6PrefixBits = 48
+)
+
+// Fingerprinter implements ports.DeviceFingerprinter
+type Fingerprinter struct {
+	geo *GeoDatabase
+}
+
+// NewFingerprinter creates a new Fingerprinter
+// geo may be nil, in which case devices carry no country.
+func NewFingerprinter(geo *GeoDatabase) *Fingerprinter {
+	return &Fingerprinter{
+		geo: geo,
+	}
+}
+
+// Fingerprint returns the device for a user agent and client IP address
+func (f *Fingerprinter) Fingerprint(userAgent, ipAddress string) session.Device {
+	var network, country string
+	if addr, err := netip.ParseAddr(ipAddress); err == nil {
+		addr = addr.Unmap()
+		network = networkPrefix(addr)
+		if f.geo != nil {
+			country = f.geo.Country(addr)
+		}
+	}
+
+	return session.NewDevice(UserAgentFamily(userAgent), network, country)
+}
+
+// networkPrefix returns the network an address belongs to, e.g. "203.0.113.0/24"
+func networkPrefix(addr netip.Addr) string {
+	bits := ipv6PrefixBits
+	if addr.Is4() {
+		bits = ipv4PrefixBits
+	}
+
+	prefix, err := addr.Prefix(bits)
+	if err != nil {
+		return ""
+	}
+	return prefix.String()
+}
@@ -0,0 +1,93 @@
+package device
+
+import (
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	chromeWindows = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36"
+	edgeWindows   = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36 Edg/126.0.0.0"
+	safariIPhone  = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.5 Mobile/15E148 Safari/604.1"
+	firefoxLinux  = "Mozilla/5.0 (X11; Linux x86_64; rv:127.0) Gecko/20100101 Firefox/127.0"
+	chromeAndroid = "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Mobile Safari/537.36"
+)
+
+func TestUserAgentFamily(t *testing.T) {
+	tests := []struct {
+		userAgent string
+		want      string
+	}{
+		{chromeWindows, "Chrome on Windows"},
+		{edgeWindows, "Edge on Windows"},
+		{safariIPhone, "Safari on iOS"},
+		{firefoxLinux, "Firefox on Linux"},
+		{chromeAndroid, "Chrome on Android"},
+		{"curl/8.5.0", "Other browser on other OS"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, UserAgentFamily(tt.userAgent))
+		})
+	}
+}
+
+func writeGeoDatabase(t *testing.T, content string) *GeoDatabase {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "geo.csv")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	geo, err := LoadGeoDatabase(path)
+	require.NoError(t, err)
+	return geo
+}
+
+func TestGeoDatabase_Country(t *testing.T) {
+	geo := writeGeoDatabase(t, `network,country_iso_code
+# documentation ranges
+203.0.113.0/24,nl
+198.51.100.0/24,"US"
+2001:db8::/32,JP
+not-a-network,XX
+`)
+
+	assert.Equal(t, "NL", geo.Country(netip.MustParseAddr("203.0.113.7")))
+	assert.Equal(t, "US", geo.Country(netip.MustParseAddr("198.51.100.200")))
+	assert.Equal(t, "JP", geo.Country(netip.MustParseAddr("2001:db8:1::1")))
+	assert.Equal(t, "", geo.Country(netip.MustParseAddr("192.0.2.1")))
+	assert.Equal(t, "", geo.Country(netip.MustParseAddr("10.0.0.1")))
+}
+
+func TestLoadGeoDatabase_MissingFile(t *testing.T) {
+	_, err := LoadGeoDatabase(filepath.Join(t.TempDir(), "missing.csv"))
+
+	assert.Error(t, err)
+}
+
+func TestFingerprinter_Fingerprint(t *testing.T) {
+	geo := writeGeoDatabase(t, "203.0.113.0/24,NL\n")
+	fingerprinter := NewFingerprinter(geo)
+
+	device := fingerprinter.Fingerprint(chromeWindows, "203.0.113.7")
+	assert.Equal(t, "Chrome on Windows", device.UserAgentFamily())
+	assert.Equal(t, "203.0.113.0/24", device.Network())
+	assert.Equal(t, "NL", device.Country())
+
+	mapped := fingerprinter.Fingerprint(chromeWindows, "::ffff:203.0.113.7")
+	assert.Equal(t, device, mapped)
+
+	ipv6 := NewFingerprinter(nil).Fingerprint(firefoxLinux, "2001:db8:1:2::1")
+	assert.Equal(t, "2001:db8:1::/48", ipv6.Network())
+	assert.Empty(t, ipv6.Country())
+
+	unknown := NewFingerprinter(nil).Fingerprint("", "not-an-ip")
+	assert.True(t, unknown.IsZero())
+}
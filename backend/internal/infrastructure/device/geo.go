@@ -0,0 +1,85 @@
+package device
+
+import (
+	"bufio"
+	"fmt"
+	"net/netip"
+	"os"
+	"sort"
+	"strings"
+)
+
+// geoRange maps a network to an ISO country code
+type geoRange struct {
+	prefix  netip.Prefix
+	country string
+}
+
+// GeoDatabase looks up the country of an IP address in a local database
+// file, so that no request data leaves the service. The file is CSV with
+// one non-overlapping network per line, "<cidr>,<country code>", as
+// exported from common free GeoIP country databases. Blank lines, lines
+// starting with "#" and lines that do not parse (such as a header) are
+// skipped.
+type GeoDatabase struct {
+	ranges []geoRange // sorted by network address
+}
+
+// LoadGeoDatabase reads a GeoDatabase from a CSV file
+func LoadGeoDatabase(path string) (*GeoDatabase, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open geo database: %w", err)
+	}
+	defer file.Close()
+
+	var ranges []geoRange
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		cidr, country, ok := strings.Cut(line, ",")
+		if !ok {
+			continue
+		}
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+		if err != nil {
+			continue
+		}
+		country = strings.ToUpper(strings.Trim(strings.TrimSpace(country), `"`))
+		if country == "" {
+			continue
+		}
+
+		ranges = append(ranges, geoRange{prefix: prefix.Masked(), country: country})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read geo database: %w", err)
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].prefix.Addr().Less(ranges[j].prefix.Addr())
+	})
+
+	return &GeoDatabase{ranges: ranges}, nil
+}
+
+// Country returns the country code of an address, or "" if it is not listed
+func (g *GeoDatabase) Country(addr netip.Addr) string {
+	// Find the last network starting at or before addr
+	i := sort.Search(len(g.ranges), func(i int) bool {
+		return addr.Less(g.ranges[i].prefix.Addr())
+	})
+	if i == 0 {
+		return ""
+	}
+
+	candidate := g.ranges[i-1]
+	if !candidate.prefix.Contains(addr) {
+		return ""
+	}
+	return candidate.country
+}
@@ -0,0 +1,60 @@
+package device
+
+import "strings"
+
+// browserTokens identify browsers by a User-Agent substring; order matters
+// because most browsers also claim to be Chrome and Safari
+var browserTokens = []struct {
+	token string
+	name  string
+}{
+	{"Edg/", "Edge"},
+	{"OPR/", "Opera"},
+	{"SamsungBrowser/", "Samsung Internet"},
+	{"Firefox/", "Firefox"},
+	{"FxiOS/", "Firefox"},
+	{"CriOS/", "Chrome"},
+	{"Chrome/", "Chrome"},
+	{"Safari/", "Safari"},
+}
+
+// osTokens identify operating systems by a User-Agent substring; Android
+// and ChromeOS come before Linux, and iOS before macOS, for the same reason
+var osTokens = []struct {
+	token string
+	name  string
+}{
+	{"Android", "Android"},
+	{"CrOS", "ChromeOS"},
+	{"iPhone", "iOS"},
+	{"iPad", "iOS"},
+	{"Windows", "Windows"},
+	{"Mac OS X", "macOS"},
+	{"Linux", "Linux"},
+}
+
+// UserAgentFamily reduces a User-Agent header to its browser and OS, e.g.
+// "Chrome on Windows". Versions are dropped so that browser updates do not
+// look like new devices. An empty header returns an empty family.
+func UserAgentFamily(userAgent string) string {
+	if userAgent == "" {
+		return ""
+	}
+
+	browser := matchToken(userAgent, browserTokens, "Other browser")
+	os := matchToken(userAgent, osTokens, "other OS")
+	return browser + " on " + os
+}
+
+// matchToken returns the name of the first token found in userAgent
+func matchToken(userAgent string, tokens []struct {
+	token string
+	name  string
+}, fallback string) string {
+	for _, t := range tokens {
+		if strings.Contains(userAgent, t.token) {
+			return t.name
+		}
+	}
+	return fallback
+}
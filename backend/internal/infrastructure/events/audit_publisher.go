@@ -0,0 +1,36 @@
+package events
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/audit"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+// AuditPublisher implements ports.EventPublisher by recording every
+// domain event in the audit log of the aggregate it belongs to
+type AuditPublisher struct {
+	auditRepo audit.Repository
+}
+
+// NewAuditPublisher creates a new AuditPublisher
+func NewAuditPublisher(auditRepo audit.Repository) *AuditPublisher {
+	return &AuditPublisher{
+		auditRepo: auditRepo,
+	}
+}
+
+// Publish appends an audit entry for each event, attributed to the
+// administrator acting as the user if there is one (see audit.ContextWithActor)
+func (p *AuditPublisher) Publish(ctx context.Context, events []shared.DomainEvent) error {
+	actorID := audit.ActorFromContext(ctx)
+	for _, event := range events {
+		entry := audit.NewEntry(event.AggregateID(), event.EventType(), event.OccurredAt()).WithActor(actorID)
+		if err := p.auditRepo.Append(ctx, entry); err != nil {
+			return fmt.Errorf("failed to record %s event: %w", event.EventType(), err)
+		}
+	}
+
+	return nil
+}
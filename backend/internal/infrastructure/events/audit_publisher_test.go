@@ -0,0 +1,98 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/audit"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+	"github.com/yuki5155/go-google-auth/internal/mocks"
+)
+
+func newTestUserEvents(t *testing.T) []shared.DomainEvent {
+	t.Helper()
+
+	userID, _ := user.NewUserID("test-user-123")
+	email, _ := user.NewEmail("test@example.com", true)
+	u, err := user.NewUser(userID, email, user.NewProfile("Test User", ""))
+	require.NoError(t, err)
+	u.RecordLogin()
+
+	return u.DomainEvents()
+}
+
+func TestAuditPublisher_Publish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockAuditRepo := mocks.NewMockAuditRepository(ctrl)
+	events := newTestUserEvents(t)
+	require.Len(t, events, 2)
+
+	var recorded []audit.Entry
+	mockAuditRepo.EXPECT().
+		Append(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, entry audit.Entry) error {
+			recorded = append(recorded, entry)
+			return nil
+		}).
+		Times(2)
+
+	publisher := NewAuditPublisher(mockAuditRepo)
+
+	err := publisher.Publish(ctx, events)
+
+	require.NoError(t, err)
+	require.Len(t, recorded, 2)
+	for i, entry := range recorded {
+		assert.Equal(t, "test-user-123", entry.UserID())
+		assert.Equal(t, events[i].EventType(), entry.Action())
+		assert.Equal(t, events[i].OccurredAt(), entry.OccurredAt())
+	}
+}
+
+func TestAuditPublisher_Publish_Actor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := audit.ContextWithActor(context.Background(), "admin-123")
+	mockAuditRepo := mocks.NewMockAuditRepository(ctrl)
+
+	mockAuditRepo.EXPECT().
+		Append(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, entry audit.Entry) error {
+			assert.Equal(t, "admin-123", entry.ActorID())
+			return nil
+		}).
+		Times(2)
+
+	publisher := NewAuditPublisher(mockAuditRepo)
+
+	require.NoError(t, publisher.Publish(ctx, newTestUserEvents(t)))
+}
+
+func TestAuditPublisher_Publish_RepositoryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockAuditRepo := mocks.NewMockAuditRepository(ctrl)
+
+	mockAuditRepo.EXPECT().
+		Append(ctx, gomock.Any()).
+		Return(errors.New("database error"))
+
+	publisher := NewAuditPublisher(mockAuditRepo)
+
+	err := publisher.Publish(ctx, newTestUserEvents(t))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to record")
+}
@@ -0,0 +1,23 @@
+package mail
+
+import (
+	"context"
+
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+// DisabledMailer implements ports.Mailer where no mail server is configured
+// in production. It refuses every message instead of logging it, since
+// messages carry login links and other secrets.
+type DisabledMailer struct{}
+
+// NewDisabledMailer creates a new DisabledMailer
+func NewDisabledMailer() *DisabledMailer {
+	return &DisabledMailer{}
+}
+
+// Send always fails with shared.ErrEmailUnavailable
+func (m *DisabledMailer) Send(ctx context.Context, message ports.EmailMessage) error {
+	return shared.ErrEmailUnavailable
+}
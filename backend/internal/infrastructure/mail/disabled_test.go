@@ -0,0 +1,21 @@
+package mail
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+func TestDisabledMailer_RefusesMessages(t *testing.T) {
+	err := NewDisabledMailer().Send(context.Background(), ports.EmailMessage{
+		To:      "user@example.com",
+		Subject: "Your login link",
+		Body:    "https://example.com/verify?token=secret\n",
+	})
+
+	assert.ErrorIs(t, err, shared.ErrEmailUnavailable)
+}
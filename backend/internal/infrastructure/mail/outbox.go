@@ -0,0 +1,55 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+)
+
+// OutboxMailer implements ports.Mailer for local development and tests by
+// writing each message to a file in a directory, or to the log if no
+// directory is configured. Nothing is delivered.
+type OutboxMailer struct {
+	dir  string
+	from string
+	now  func() time.Time
+}
+
+// NewOutboxMailer creates a new OutboxMailer; an empty dir logs messages instead
+func NewOutboxMailer(dir, from string) *OutboxMailer {
+	return &OutboxMailer{
+		dir:  dir,
+		from: from,
+		now:  time.Now,
+	}
+}
+
+// Send writes the message to the outbox
+func (m *OutboxMailer) Send(ctx context.Context, message ports.EmailMessage) error {
+	now := m.now()
+	data, err := buildMessage(m.from, message, now)
+	if err != nil {
+		return err
+	}
+
+	if m.dir == "" {
+		log.Printf("Outbox email to %s:\n%s", message.To, data)
+		return nil
+	}
+
+	if err := os.MkdirAll(m.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create outbox: %w", err)
+	}
+
+	name := fmt.Sprintf("%d-%s.eml", now.UnixNano(), strings.NewReplacer("@", "_at_", "/", "_").Replace(message.To))
+	if err := os.WriteFile(filepath.Join(m.dir, name), data, 0o600); err != nil {
+		return fmt.Errorf("failed to write outbox email: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package mail
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+)
+
+func TestOutboxMailer_WritesMessage(t *testing.T) {
+	dir := t.TempDir()
+	mailer := NewOutboxMailer(dir, "no-reply@example.com")
+	mailer.now = func() time.Time { return time.Unix(1_700_000_000, 0).UTC() }
+
+	err := mailer.Send(context.Background(), ports.EmailMessage{
+		To:      "user@example.com",
+		Subject: "Your login link",
+		Body:    "Open this link:\nhttps://example.com/verify\n",
+	})
+	require.NoError(t, err)
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "1700000000000000000-user_at_example.com.eml", files[0].Name())
+
+	data, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+	require.NoError(t, err)
+	assert.Equal(t, "From: no-reply@example.com\r\n"+
+		"To: user@example.com\r\n"+
+		"Subject: Your login link\r\n"+
+		"Date: Tue, 14 Nov 2023 22:13:20 +0000\r\n"+
+		"MIME-Version: 1.0\r\n"+
+		"Content-Type: text/plain; charset=utf-8\r\n"+
+		"\r\n"+
+		"Open this link:\r\nhttps://example.com/verify\r\n", string(data))
+}
+
+func TestOutboxMailer_RejectsHeaderInjection(t *testing.T) {
+	mailer := NewOutboxMailer(t.TempDir(), "no-reply@example.com")
+
+	err := mailer.Send(context.Background(), ports.EmailMessage{
+		To:      "user@example.com\r\nBcc: victim@example.com",
+		Subject: "Hello",
+	})
+
+	assert.Equal(t, errHeaderInjection, err)
+}
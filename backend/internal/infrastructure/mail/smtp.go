@@ -0,0 +1,47 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+)
+
+// SMTPMailer sends email through an SMTP server and implements ports.Mailer
+// The connection is upgraded with STARTTLS when the server supports it.
+type SMTPMailer struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPMailer creates a new SMTPMailer; username may be empty for servers
+// that accept unauthenticated mail
+func NewSMTPMailer(host, port, username, password, from string) *SMTPMailer {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &SMTPMailer{
+		addr: net.JoinHostPort(host, port),
+		auth: auth,
+		from: from,
+	}
+}
+
+// Send delivers a message through the SMTP server
+func (m *SMTPMailer) Send(ctx context.Context, message ports.EmailMessage) error {
+	data, err := buildMessage(m.from, message, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if err := smtp.SendMail(m.addr, m.auth, m.from, []string{message.To}, data); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+)
+
+// EmailNotifier emails login alerts to the user and implements ports.LoginAlertNotifier
+type EmailNotifier struct {
+	mailer ports.Mailer
+}
+
+// NewEmailNotifier creates a new EmailNotifier
+func NewEmailNotifier(mailer ports.Mailer) *EmailNotifier {
+	return &EmailNotifier{
+		mailer: mailer,
+	}
+}
+
+// NotifySuspiciousLogin emails the alert with its "this wasn't me" link
+func (n *EmailNotifier) NotifySuspiciousLogin(ctx context.Context, alert ports.LoginAlert) error {
+	var body strings.Builder
+	fmt.Fprintf(&body, "Your account was just signed in to from %s.\n\n", describeReasons(alert.Reasons))
+	fmt.Fprintf(&body, "Time:    %s\n", alert.OccurredAt.UTC().Format(time.RFC1123))
+	fmt.Fprintf(&body, "Device:  %s\n", valueOrUnknown(alert.UserAgentFamily))
+	fmt.Fprintf(&body, "Network: %s\n", valueOrUnknown(alert.Network))
+	if alert.Country != "" {
+		fmt.Fprintf(&body, "Country: %s\n", alert.Country)
+	}
+	fmt.Fprintf(&body, "\nIf this was you, you can ignore this email. If it wasn't, use this link "+
+		"to sign that device out, then review your account's security settings:\n\n%s\n", alert.RevokeURL)
+
+	message := ports.EmailMessage{
+		To:      alert.Email,
+		Subject: "New sign-in to your account",
+		Body:    body.String(),
+	}
+	if err := n.mailer.Send(ctx, message); err != nil {
+		return fmt.Errorf("failed to email login alert: %w", err)
+	}
+	return nil
+}
+
+// describeReasons phrases the alert reasons for the email
+func describeReasons(reasons []string) string {
+	newDevice, newLocation := false, false
+	for _, reason := range reasons {
+		switch reason {
+		case session.ReasonNewDevice:
+			newDevice = true
+		case session.ReasonNewLocation:
+			newLocation = true
+		}
+	}
+
+	switch {
+	case newDevice && newLocation:
+		return "a new device in a new location"
+	case newLocation:
+		return "a new location"
+	default:
+		return "a new device"
+	}
+}
+
+// valueOrUnknown returns value, or "unknown" if it is empty
+func valueOrUnknown(value string) string {
+	if value == "" {
+		return "unknown"
+	}
+	return value
+}
@@ -0,0 +1,23 @@
+package notify
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+)
+
+// Notifiers sends each alert to every notifier and implements ports.LoginAlertNotifier
+// A failing notifier does not stop the others; their errors are joined.
+type Notifiers []ports.LoginAlertNotifier
+
+// NotifySuspiciousLogin delivers the alert through every notifier
+func (n Notifiers) NotifySuspiciousLogin(ctx context.Context, alert ports.LoginAlert) error {
+	var errs []error
+	for _, notifier := range n {
+		if err := notifier.NotifySuspiciousLogin(ctx, alert); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,109 @@
+package notify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+	"github.com/yuki5155/go-google-auth/internal/mocks"
+)
+
+func testAlert() ports.LoginAlert {
+	return ports.LoginAlert{
+		UserID:          "user-123",
+		Email:           "user@example.com",
+		SessionID:       "session-1",
+		Reasons:         []string{session.ReasonNewDevice, session.ReasonNewLocation},
+		UserAgentFamily: "Safari on iOS",
+		Network:         "192.0.2.0/24",
+		Country:         "BR",
+		OccurredAt:      time.Unix(1_700_000_000, 0),
+		RevokeURL:       "https://api.example.com/auth/sessions/revoke?token=abc",
+	}
+}
+
+func TestEmailNotifier_SendsAlert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mailer := mocks.NewMockMailer(ctrl)
+	mailer.EXPECT().
+		Send(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, message ports.EmailMessage) error {
+			assert.Equal(t, "user@example.com", message.To)
+			assert.Equal(t, "New sign-in to your account", message.Subject)
+			assert.Contains(t, message.Body, "a new device in a new location")
+			assert.Contains(t, message.Body, "Safari on iOS")
+			assert.Contains(t, message.Body, "Country: BR")
+			assert.Contains(t, message.Body, "https://api.example.com/auth/sessions/revoke?token=abc")
+			return nil
+		})
+
+	err := NewEmailNotifier(mailer).NotifySuspiciousLogin(context.Background(), testAlert())
+
+	require.NoError(t, err)
+}
+
+func TestWebhookNotifier_PostsSignedAlert(t *testing.T) {
+	var body []byte
+	var signature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(SignatureHeader)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	err := NewWebhookNotifier(server.URL, "webhook-secret").NotifySuspiciousLogin(context.Background(), testAlert())
+	require.NoError(t, err)
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, "user.suspicious_login_detected", payload["event"])
+	assert.Equal(t, "session-1", payload["session_id"])
+	assert.Equal(t, []any{"new_device", "new_location"}, payload["reasons"])
+
+	mac := hmac.New(sha256.New, []byte("webhook-secret"))
+	mac.Write(body)
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), signature)
+}
+
+func TestWebhookNotifier_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get(SignatureHeader))
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	err := NewWebhookNotifier(server.URL, "").NotifySuspiciousLogin(context.Background(), testAlert())
+
+	assert.ErrorContains(t, err, "status 502")
+}
+
+func TestNotifiers_NotifiesAllAndJoinsErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	failing := mocks.NewMockLoginAlertNotifier(ctrl)
+	working := mocks.NewMockLoginAlertNotifier(ctrl)
+	failing.EXPECT().NotifySuspiciousLogin(gomock.Any(), gomock.Any()).Return(errors.New("smtp down"))
+	working.EXPECT().NotifySuspiciousLogin(gomock.Any(), gomock.Any()).Return(nil)
+
+	err := Notifiers{failing, working}.NotifySuspiciousLogin(context.Background(), testAlert())
+
+	assert.ErrorContains(t, err, "smtp down")
+}
@@ -0,0 +1,96 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// SignatureHeader carries the HMAC-SHA256 of the request body, as
+// "sha256=<hex>", when a webhook secret is configured
+const SignatureHeader = "X-Webhook-Signature"
+
+// webhookTimeout bounds a webhook call, which runs during the login request
+const webhookTimeout = 5 * time.Second
+
+// webhookPayload is the JSON body posted for a login alert
+type webhookPayload struct {
+	Event           string    `json:"event"`
+	UserID          string    `json:"user_id"`
+	Email           string    `json:"email"`
+	SessionID       string    `json:"session_id"`
+	Reasons         []string  `json:"reasons"`
+	UserAgentFamily string    `json:"user_agent_family,omitempty"`
+	Network         string    `json:"network,omitempty"`
+	Country         string    `json:"country,omitempty"`
+	OccurredAt      time.Time `json:"occurred_at"`
+	RevokeURL       string    `json:"revoke_url"`
+}
+
+// WebhookNotifier posts login alerts to an HTTP endpoint, e.g. a chat or
+// SIEM integration, and implements ports.LoginAlertNotifier
+type WebhookNotifier struct {
+	url    string
+	secret []byte
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a new WebhookNotifier
+// When secret is set, requests are signed in the SignatureHeader.
+func NewWebhookNotifier(url, secret string) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:    url,
+		secret: []byte(secret),
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// NotifySuspiciousLogin posts the alert as JSON
+func (n *WebhookNotifier) NotifySuspiciousLogin(ctx context.Context, alert ports.LoginAlert) error {
+	body, err := json.Marshal(webhookPayload{
+		Event:           user.EventTypeSuspiciousLoginDetected,
+		UserID:          alert.UserID,
+		Email:           alert.Email,
+		SessionID:       alert.SessionID,
+		Reasons:         alert.Reasons,
+		UserAgentFamily: alert.UserAgentFamily,
+		Network:         alert.Network,
+		Country:         alert.Country,
+		OccurredAt:      alert.OccurredAt.UTC(),
+		RevokeURL:       alert.RevokeURL,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(n.secret) > 0 {
+		mac := hmac.New(sha256.New, n.secret)
+		mac.Write(body)
+		req.Header.Set(SignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call login alert webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("login alert webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
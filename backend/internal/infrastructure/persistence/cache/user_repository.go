@@ -0,0 +1,140 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// UserRepository is a read-through cache in front of another user.Repository.
+// FindByID results are kept for a short TTL; writes through this repository
+// invalidate the cached entry.
+type UserRepository struct {
+	next user.Repository
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.RWMutex
+	entries map[string]userCacheEntry // key: user ID
+}
+
+// userCacheEntry is a cached user lookup
+type userCacheEntry struct {
+	user      *user.User
+	expiresAt time.Time
+}
+
+// NewUserRepository wraps next with a read-through cache
+func NewUserRepository(next user.Repository, ttl time.Duration) *UserRepository {
+	return &UserRepository{
+		next:    next,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]userCacheEntry),
+	}
+}
+
+// Save persists a user and drops any cached copy
+func (r *UserRepository) Save(ctx context.Context, u *user.User) error {
+	err := r.next.Save(ctx, u)
+	r.Invalidate(u.ID().Value())
+	return err
+}
+
+// FindByID returns a cached user if available, otherwise loads and caches it
+// Lookup errors, including not found, are never cached
+func (r *UserRepository) FindByID(ctx context.Context, id user.UserID) (*user.User, error) {
+	if cached, ok := r.cached(id.Value()); ok {
+		return cached, nil
+	}
+
+	u, err := r.next.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.store(u)
+	return u, nil
+}
+
+// FindByEmail retrieves a user by their email (not cached)
+func (r *UserRepository) FindByEmail(ctx context.Context, email user.Email) (*user.User, error) {
+	return r.next.FindByEmail(ctx, email)
+}
+
+// FindByGoogleID retrieves the user a Google account is linked to (not cached)
+func (r *UserRepository) FindByGoogleID(ctx context.Context, googleID string) (*user.User, error) {
+	return r.next.FindByGoogleID(ctx, googleID)
+}
+
+// Delete removes a user and drops any cached copy
+func (r *UserRepository) Delete(ctx context.Context, id user.UserID) error {
+	err := r.next.Delete(ctx, id)
+	r.Invalidate(id.Value())
+	return err
+}
+
+// Exists checks if a user exists by ID
+func (r *UserRepository) Exists(ctx context.Context, id user.UserID) (bool, error) {
+	if _, ok := r.cached(id.Value()); ok {
+		return true, nil
+	}
+	return r.next.Exists(ctx, id)
+}
+
+// ExistsByEmail checks if a user exists by email (not cached)
+func (r *UserRepository) ExistsByEmail(ctx context.Context, email user.Email) (bool, error) {
+	return r.next.ExistsByEmail(ctx, email)
+}
+
+// Invalidate drops the cached copy of a user
+func (r *UserRepository) Invalidate(userID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.entries, userID)
+}
+
+// cached returns a copy of an unexpired cached user
+func (r *UserRepository) cached(userID string) (*user.User, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entry, ok := r.entries[userID]
+	if !ok || !r.now().Before(entry.expiresAt) {
+		return nil, false
+	}
+
+	return clone(entry.user), true
+}
+
+// store caches a copy of a user
+func (r *UserRepository) store(u *user.User) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries[u.ID().Value()] = userCacheEntry{
+		user:      clone(u),
+		expiresAt: r.now().Add(r.ttl),
+	}
+}
+
+// clone copies a user so callers cannot modify the cached instance
+func clone(u *user.User) *user.User {
+	return user.ReconstructUser(
+		u.ID(),
+		u.Email(),
+		u.Profile(),
+		u.Preferences(),
+		u.Roles(),
+		u.Status(),
+		u.LoginHistory(),
+		u.Passkeys(),
+		u.GoogleID(),
+		u.ActiveOrganizationID(),
+		u.CreatedAt(),
+		u.UpdatedAt(),
+	)
+}
@@ -0,0 +1,145 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+	"github.com/yuki5155/go-google-auth/internal/mocks"
+)
+
+func newCacheTestUser(t *testing.T) *user.User {
+	t.Helper()
+
+	userID, _ := user.NewUserID("test-user-123")
+	email, _ := user.NewEmail("test@example.com", true)
+	u, err := user.NewUser(userID, email, user.NewProfile("Test User", ""))
+	require.NoError(t, err)
+	return u
+}
+
+func TestUserRepository_FindByID_CachesResult(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	u := newCacheTestUser(t)
+
+	// The underlying repository is only hit once
+	mockRepo.EXPECT().
+		FindByID(ctx, u.ID()).
+		Return(u, nil).
+		Times(1)
+
+	repo := NewUserRepository(mockRepo, time.Minute)
+
+	first, err := repo.FindByID(ctx, u.ID())
+	require.NoError(t, err)
+	second, err := repo.FindByID(ctx, u.ID())
+	require.NoError(t, err)
+
+	assert.Equal(t, "Test User", second.Profile().Name())
+
+	// Callers get independent copies
+	first.UpdateProfile(user.NewProfile("Changed", ""))
+	third, err := repo.FindByID(ctx, u.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "Test User", third.Profile().Name())
+}
+
+func TestUserRepository_FindByID_Expires(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	u := newCacheTestUser(t)
+	now := time.Now()
+
+	mockRepo.EXPECT().
+		FindByID(ctx, u.ID()).
+		Return(u, nil).
+		Times(2)
+
+	repo := NewUserRepository(mockRepo, time.Minute)
+	repo.now = func() time.Time { return now }
+
+	_, err := repo.FindByID(ctx, u.ID())
+	require.NoError(t, err)
+
+	now = now.Add(2 * time.Minute)
+	_, err = repo.FindByID(ctx, u.ID())
+	require.NoError(t, err)
+}
+
+func TestUserRepository_Save_Invalidates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	u := newCacheTestUser(t)
+
+	mockRepo.EXPECT().FindByID(ctx, u.ID()).Return(u, nil).Times(2)
+	mockRepo.EXPECT().Save(ctx, u).Return(nil)
+
+	repo := NewUserRepository(mockRepo, time.Minute)
+
+	_, err := repo.FindByID(ctx, u.ID())
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, u))
+	_, err = repo.FindByID(ctx, u.ID())
+	require.NoError(t, err)
+}
+
+func TestUserRepository_Delete_Invalidates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	u := newCacheTestUser(t)
+
+	mockRepo.EXPECT().FindByID(ctx, u.ID()).Return(u, nil)
+	mockRepo.EXPECT().Delete(ctx, u.ID()).Return(nil)
+	mockRepo.EXPECT().FindByID(ctx, u.ID()).Return(nil, shared.ErrUserNotFound)
+
+	repo := NewUserRepository(mockRepo, time.Minute)
+
+	_, err := repo.FindByID(ctx, u.ID())
+	require.NoError(t, err)
+	require.NoError(t, repo.Delete(ctx, u.ID()))
+
+	found, err := repo.FindByID(ctx, u.ID())
+	assert.Nil(t, found)
+	assert.Equal(t, shared.ErrUserNotFound, err)
+}
+
+func TestUserRepository_FindByID_ErrorsAreNotCached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockRepository(ctrl)
+	u := newCacheTestUser(t)
+
+	mockRepo.EXPECT().FindByID(ctx, u.ID()).Return(nil, errors.New("database error"))
+	mockRepo.EXPECT().FindByID(ctx, u.ID()).Return(u, nil)
+
+	repo := NewUserRepository(mockRepo, time.Minute)
+
+	_, err := repo.FindByID(ctx, u.ID())
+	assert.Error(t, err)
+
+	found, err := repo.FindByID(ctx, u.ID())
+	require.NoError(t, err)
+	assert.Equal(t, u.ID(), found.ID())
+}
@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/apitoken"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// APITokenRepository is an in-memory implementation of apitoken.Repository
+type APITokenRepository struct {
+	mu     sync.RWMutex
+	tokens map[string]*apitoken.Token // key: token ID
+	byHash map[string]string          // token hash -> token ID
+}
+
+// NewAPITokenRepository creates a new in-memory personal access token repository
+func NewAPITokenRepository() *APITokenRepository {
+	return &APITokenRepository{
+		tokens: make(map[string]*apitoken.Token),
+		byHash: make(map[string]string),
+	}
+}
+
+// Save persists a token to the in-memory store
+func (r *APITokenRepository) Save(ctx context.Context, token *apitoken.Token) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tokens[token.ID().Value()] = snapshotAPIToken(token)
+	r.byHash[token.Hash()] = token.ID().Value()
+	return nil
+}
+
+// FindByID retrieves a token by its ID
+func (r *APITokenRepository) FindByID(ctx context.Context, id apitoken.TokenID) (*apitoken.Token, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	token, exists := r.tokens[id.Value()]
+	if !exists {
+		return nil, shared.ErrAPITokenNotFound
+	}
+
+	return snapshotAPIToken(token), nil
+}
+
+// FindByHash retrieves a token by the hash of its plain value
+func (r *APITokenRepository) FindByHash(ctx context.Context, hash string) (*apitoken.Token, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	token, exists := r.tokens[r.byHash[hash]]
+	if !exists {
+		return nil, shared.ErrAPITokenNotFound
+	}
+
+	return snapshotAPIToken(token), nil
+}
+
+// FindByUserID retrieves all tokens of a user, newest first
+func (r *APITokenRepository) FindByUserID(ctx context.Context, userID user.UserID) ([]*apitoken.Token, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tokens := make([]*apitoken.Token, 0)
+	for _, token := range r.tokens {
+		if token.UserID().Equals(userID) {
+			tokens = append(tokens, snapshotAPIToken(token))
+		}
+	}
+
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].CreatedAt().After(tokens[j].CreatedAt())
+	})
+
+	return tokens, nil
+}
+
+// DeleteByUserID removes all tokens of a user
+func (r *APITokenRepository) DeleteByUserID(ctx context.Context, userID user.UserID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, token := range r.tokens {
+		if token.UserID().Equals(userID) {
+			delete(r.byHash, token.Hash())
+			delete(r.tokens, id)
+		}
+	}
+
+	return nil
+}
+
+// snapshotAPIToken copies a token so that stored state is only changed by Save
+func snapshotAPIToken(token *apitoken.Token) *apitoken.Token {
+	return apitoken.ReconstructToken(
+		token.ID(),
+		token.UserID(),
+		token.Name(),
+		token.Scopes(),
+		token.Hash(),
+		token.CreatedAt(),
+		token.ExpiresAt(),
+		token.LastUsedAt(),
+		token.RevokedAt(),
+	)
+}
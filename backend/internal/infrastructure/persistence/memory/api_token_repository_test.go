@@ -0,0 +1,96 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuki5155/go-google-auth/internal/domain/apitoken"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+func newTestAPIToken(t *testing.T, userID string) (*apitoken.Token, string) {
+	t.Helper()
+
+	id, _ := user.NewUserID(userID)
+	token, plain, err := apitoken.NewToken(id, "script", []string{"profile:read"}, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	return token, plain
+}
+
+func TestAPITokenRepository_SaveAndFind(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAPITokenRepository()
+	token, plain := newTestAPIToken(t, "test-user-123")
+
+	require.NoError(t, repo.Save(ctx, token))
+
+	byID, err := repo.FindByID(ctx, token.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "script", byID.Name())
+	assert.Equal(t, []string{"profile:read"}, byID.Scopes())
+
+	byHash, err := repo.FindByHash(ctx, apitoken.Hash(plain))
+	require.NoError(t, err)
+	assert.True(t, byHash.ID().Equals(token.ID()))
+}
+
+func TestAPITokenRepository_NotFound(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAPITokenRepository()
+	id, _ := apitoken.NewTokenID("non-existent")
+
+	byID, err := repo.FindByID(ctx, id)
+	assert.Nil(t, byID)
+	assert.Equal(t, shared.ErrAPITokenNotFound, err)
+
+	byHash, err := repo.FindByHash(ctx, apitoken.Hash("pat_unknown"))
+	assert.Nil(t, byHash)
+	assert.Equal(t, shared.ErrAPITokenNotFound, err)
+}
+
+func TestAPITokenRepository_StoresSnapshot(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAPITokenRepository()
+	token, _ := newTestAPIToken(t, "test-user-123")
+	require.NoError(t, repo.Save(ctx, token))
+
+	// Changes are only stored when saved
+	token.Revoke(time.Now())
+
+	found, err := repo.FindByID(ctx, token.ID())
+	require.NoError(t, err)
+	assert.False(t, found.IsRevoked())
+}
+
+func TestAPITokenRepository_FindAndDeleteByUserID(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAPITokenRepository()
+	first, _ := newTestAPIToken(t, "test-user-123")
+	second, plain := newTestAPIToken(t, "test-user-123")
+	other, _ := newTestAPIToken(t, "other-user")
+	require.NoError(t, repo.Save(ctx, first))
+	require.NoError(t, repo.Save(ctx, second))
+	require.NoError(t, repo.Save(ctx, other))
+
+	userID, _ := user.NewUserID("test-user-123")
+	tokens, err := repo.FindByUserID(ctx, userID)
+	require.NoError(t, err)
+	assert.Len(t, tokens, 2)
+
+	require.NoError(t, repo.DeleteByUserID(ctx, userID))
+
+	tokens, err = repo.FindByUserID(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, tokens)
+	_, err = repo.FindByHash(ctx, apitoken.Hash(plain))
+	assert.Equal(t, shared.ErrAPITokenNotFound, err)
+
+	otherID, _ := user.NewUserID("other-user")
+	tokens, err = repo.FindByUserID(ctx, otherID)
+	require.NoError(t, err)
+	assert.Len(t, tokens, 1)
+}
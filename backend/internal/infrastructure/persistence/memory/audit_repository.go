@@ -0,0 +1,49 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/audit"
+)
+
+// AuditRepository is an in-memory implementation of audit.Repository
+type AuditRepository struct {
+	mu      sync.RWMutex
+	entries map[string][]audit.Entry // key: user ID
+}
+
+// NewAuditRepository creates a new in-memory audit repository
+func NewAuditRepository() *AuditRepository {
+	return &AuditRepository{
+		entries: make(map[string][]audit.Entry),
+	}
+}
+
+// Append adds an entry to a user's audit history
+func (r *AuditRepository) Append(ctx context.Context, entry audit.Entry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries[entry.UserID()] = append(r.entries[entry.UserID()], entry)
+	return nil
+}
+
+// FindByUserID retrieves a user's audit history, oldest first
+func (r *AuditRepository) FindByUserID(ctx context.Context, userID string) ([]audit.Entry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := make([]audit.Entry, len(r.entries[userID]))
+	copy(entries, r.entries[userID])
+	return entries, nil
+}
+
+// DeleteByUserID removes a user's audit history
+func (r *AuditRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.entries, userID)
+	return nil
+}
@@ -0,0 +1,37 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuki5155/go-google-auth/internal/domain/audit"
+)
+
+func TestAuditRepository_AppendAndDelete(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAuditRepository()
+	now := time.Now()
+
+	require.NoError(t, repo.Append(ctx, audit.NewEntry("test-user-123", "user.registered", now)))
+	require.NoError(t, repo.Append(ctx, audit.NewEntry("test-user-123", "user.logged_in", now.Add(time.Second))))
+	require.NoError(t, repo.Append(ctx, audit.NewEntry("other-user", "user.registered", now)))
+
+	entries, err := repo.FindByUserID(ctx, "test-user-123")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "user.registered", entries[0].Action())
+	assert.Equal(t, "user.logged_in", entries[1].Action())
+
+	require.NoError(t, repo.DeleteByUserID(ctx, "test-user-123"))
+
+	entries, err = repo.FindByUserID(ctx, "test-user-123")
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	entries, err = repo.FindByUserID(ctx, "other-user")
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
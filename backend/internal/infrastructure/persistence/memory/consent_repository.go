@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/consent"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// ConsentRepository is an in-memory implementation of consent.Repository
+type ConsentRepository struct {
+	mu       sync.RWMutex
+	consents map[string]map[string]*consent.Consent // key: user ID, then client ID
+}
+
+// NewConsentRepository creates a new in-memory consent repository
+func NewConsentRepository() *ConsentRepository {
+	return &ConsentRepository{
+		consents: make(map[string]map[string]*consent.Consent),
+	}
+}
+
+// Save persists a consent to the in-memory store
+func (r *ConsentRepository) Save(ctx context.Context, c *consent.Consent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	userID := c.UserID().Value()
+	if r.consents[userID] == nil {
+		r.consents[userID] = make(map[string]*consent.Consent)
+	}
+	r.consents[userID][c.ClientID()] = snapshotConsent(c)
+	return nil
+}
+
+// Find retrieves a user's consent to a client
+func (r *ConsentRepository) Find(ctx context.Context, userID user.UserID, clientID string) (*consent.Consent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, exists := r.consents[userID.Value()][clientID]
+	if !exists {
+		return nil, shared.ErrConsentNotFound
+	}
+
+	return snapshotConsent(c), nil
+}
+
+// DeleteByUserID removes all consents of a user
+func (r *ConsentRepository) DeleteByUserID(ctx context.Context, userID user.UserID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.consents, userID.Value())
+	return nil
+}
+
+// snapshotConsent copies a consent so that stored state is only changed by Save
+func snapshotConsent(c *consent.Consent) *consent.Consent {
+	return consent.ReconstructConsent(c.UserID(), c.ClientID(), c.Scopes(), c.GrantedAt())
+}
@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuki5155/go-google-auth/internal/domain/consent"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+func TestConsentRepository_SaveAndFind(t *testing.T) {
+	ctx := context.Background()
+	repo := NewConsentRepository()
+	userID, _ := user.NewUserID("test-user-123")
+	c, err := consent.NewConsent(userID, "wiki", []string{"openid", "email"})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Save(ctx, c))
+
+	found, err := repo.Find(ctx, userID, "wiki")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"email", "openid"}, found.Scopes())
+
+	// Changes are only stored by Save
+	found.Grant([]string{"profile"})
+	stored, err := repo.Find(ctx, userID, "wiki")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"email", "openid"}, stored.Scopes())
+
+	_, err = repo.Find(ctx, userID, "other")
+	assert.Equal(t, shared.ErrConsentNotFound, err)
+}
+
+func TestConsentRepository_DeleteByUserID(t *testing.T) {
+	ctx := context.Background()
+	repo := NewConsentRepository()
+	userID, _ := user.NewUserID("test-user-123")
+	otherID, _ := user.NewUserID("test-user-456")
+	for _, id := range []user.UserID{userID, otherID} {
+		c, err := consent.NewConsent(id, "wiki", []string{"openid"})
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, c))
+	}
+
+	require.NoError(t, repo.DeleteByUserID(ctx, userID))
+
+	_, err := repo.Find(ctx, userID, "wiki")
+	assert.Equal(t, shared.ErrConsentNotFound, err)
+	_, err = repo.Find(ctx, otherID, "wiki")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,58 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DeletionSchedule is an in-memory implementation of ports.DeletionScheduler
+type DeletionSchedule struct {
+	mu       sync.RWMutex
+	purgeAts map[string]time.Time // key: user ID
+}
+
+// NewDeletionSchedule creates a new in-memory deletion schedule
+func NewDeletionSchedule() *DeletionSchedule {
+	return &DeletionSchedule{
+		purgeAts: make(map[string]time.Time),
+	}
+}
+
+// Schedule records that a user's data should be purged at purgeAt
+func (s *DeletionSchedule) Schedule(ctx context.Context, userID string, purgeAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.purgeAts[userID] = purgeAt
+	return nil
+}
+
+// Due returns the IDs of users whose purge time is at or before now, oldest first
+func (s *DeletionSchedule) Due(ctx context.Context, now time.Time) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	due := make([]string, 0)
+	for userID, purgeAt := range s.purgeAts {
+		if !purgeAt.After(now) {
+			due = append(due, userID)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		return s.purgeAts[due[i]].Before(s.purgeAts[due[j]])
+	})
+
+	return due, nil
+}
+
+// Remove drops a user from the schedule
+func (s *DeletionSchedule) Remove(ctx context.Context, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.purgeAts, userID)
+	return nil
+}
@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeletionSchedule_Due(t *testing.T) {
+	ctx := context.Background()
+	schedule := NewDeletionSchedule()
+	now := time.Now()
+
+	require.NoError(t, schedule.Schedule(ctx, "later", now.Add(time.Hour)))
+	require.NoError(t, schedule.Schedule(ctx, "due-second", now.Add(-time.Minute)))
+	require.NoError(t, schedule.Schedule(ctx, "due-first", now.Add(-time.Hour)))
+
+	due, err := schedule.Due(ctx, now)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"due-first", "due-second"}, due)
+}
+
+func TestDeletionSchedule_Remove(t *testing.T) {
+	ctx := context.Background()
+	schedule := NewDeletionSchedule()
+	now := time.Now()
+
+	require.NoError(t, schedule.Schedule(ctx, "test-user-123", now.Add(-time.Hour)))
+	require.NoError(t, schedule.Remove(ctx, "test-user-123"))
+
+	due, err := schedule.Due(ctx, now)
+
+	require.NoError(t, err)
+	assert.Empty(t, due)
+}
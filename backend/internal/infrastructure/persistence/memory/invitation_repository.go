@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/organization"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+// InvitationRepository is an in-memory implementation of organization.InvitationRepository
+type InvitationRepository struct {
+	mu          sync.RWMutex
+	invitations map[string]*organization.Invitation // key: invitation ID
+	byHash      map[string]string                   // token hash -> invitation ID
+}
+
+// NewInvitationRepository creates a new in-memory invitation repository
+func NewInvitationRepository() *InvitationRepository {
+	return &InvitationRepository{
+		invitations: make(map[string]*organization.Invitation),
+		byHash:      make(map[string]string),
+	}
+}
+
+// Save persists an invitation to the in-memory store
+func (r *InvitationRepository) Save(ctx context.Context, invitation *organization.Invitation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.invitations[invitation.ID().Value()] = snapshotInvitation(invitation)
+	r.byHash[invitation.Hash()] = invitation.ID().Value()
+	return nil
+}
+
+// FindByID retrieves one of an organization's invitations
+func (r *InvitationRepository) FindByID(ctx context.Context, orgID organization.OrganizationID, id organization.InvitationID) (*organization.Invitation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	invitation, exists := r.invitations[id.Value()]
+	if !exists || !invitation.OrganizationID().Equals(orgID) {
+		return nil, shared.ErrInvitationNotFound
+	}
+
+	return snapshotInvitation(invitation), nil
+}
+
+// FindByHash retrieves an invitation by the hash of its token
+func (r *InvitationRepository) FindByHash(ctx context.Context, hash string) (*organization.Invitation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	invitation, exists := r.invitations[r.byHash[hash]]
+	if !exists {
+		return nil, shared.ErrInvitationNotFound
+	}
+
+	return snapshotInvitation(invitation), nil
+}
+
+// FindByOrganization retrieves the invitations of an organization, newest first
+func (r *InvitationRepository) FindByOrganization(ctx context.Context, orgID organization.OrganizationID) ([]*organization.Invitation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	invitations := make([]*organization.Invitation, 0)
+	for _, invitation := range r.invitations {
+		if invitation.OrganizationID().Equals(orgID) {
+			invitations = append(invitations, snapshotInvitation(invitation))
+		}
+	}
+
+	sort.Slice(invitations, func(i, j int) bool {
+		return invitations[i].CreatedAt().After(invitations[j].CreatedAt())
+	})
+
+	return invitations, nil
+}
+
+// Delete removes an invitation
+func (r *InvitationRepository) Delete(ctx context.Context, id organization.InvitationID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if invitation, exists := r.invitations[id.Value()]; exists {
+		delete(r.byHash, invitation.Hash())
+		delete(r.invitations, id.Value())
+	}
+
+	return nil
+}
+
+// snapshotInvitation copies an invitation so that stored state is only changed by Save
+func snapshotInvitation(invitation *organization.Invitation) *organization.Invitation {
+	return organization.ReconstructInvitation(
+		invitation.ID(),
+		invitation.OrganizationID(),
+		invitation.Email(),
+		invitation.Role(),
+		invitation.InvitedBy(),
+		invitation.Hash(),
+		invitation.CreatedAt(),
+		invitation.ExpiresAt(),
+	)
+}
@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuki5155/go-google-auth/internal/domain/organization"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+func newTestInvitation(t *testing.T, orgID, email string) (*organization.Invitation, string) {
+	t.Helper()
+
+	inviter := newTestMembership(orgID, "owner-1", organization.RoleOwner, time.Now())
+	address, err := user.NewEmail(email, false)
+	require.NoError(t, err)
+	invitation, plain, err := organization.NewInvitation(inviter, address, organization.RoleMember, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	return invitation, plain
+}
+
+func TestInvitationRepository_SaveAndFind(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInvitationRepository()
+	invitation, plain := newTestInvitation(t, "org-1", "new@example.com")
+
+	require.NoError(t, repo.Save(ctx, invitation))
+
+	byID, err := repo.FindByID(ctx, invitation.OrganizationID(), invitation.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "new@example.com", byID.Email().Value())
+	assert.Empty(t, byID.DomainEvents())
+
+	byHash, err := repo.FindByHash(ctx, organization.HashInvitationToken(plain))
+	require.NoError(t, err)
+	assert.True(t, byHash.ID().Equals(invitation.ID()))
+}
+
+func TestInvitationRepository_FindByIDIsScopedToOrganization(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInvitationRepository()
+	invitation, _ := newTestInvitation(t, "org-1", "new@example.com")
+	require.NoError(t, repo.Save(ctx, invitation))
+
+	otherOrg, _ := organization.NewOrganizationID("org-2")
+	found, err := repo.FindByID(ctx, otherOrg, invitation.ID())
+	assert.Nil(t, found)
+	assert.Equal(t, shared.ErrInvitationNotFound, err)
+}
+
+func TestInvitationRepository_FindByOrganizationAndDelete(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInvitationRepository()
+	first, _ := newTestInvitation(t, "org-1", "first@example.com")
+	second, plain := newTestInvitation(t, "org-1", "second@example.com")
+	other, _ := newTestInvitation(t, "org-2", "other@example.com")
+	for _, invitation := range []*organization.Invitation{first, second, other} {
+		require.NoError(t, repo.Save(ctx, invitation))
+	}
+
+	invitations, err := repo.FindByOrganization(ctx, first.OrganizationID())
+	require.NoError(t, err)
+	assert.Len(t, invitations, 2)
+
+	require.NoError(t, repo.Delete(ctx, second.ID()))
+	invitations, _ = repo.FindByOrganization(ctx, first.OrganizationID())
+	require.Len(t, invitations, 1)
+	assert.Equal(t, "first@example.com", invitations[0].Email().Value())
+
+	found, err := repo.FindByHash(ctx, organization.HashInvitationToken(plain))
+	assert.Nil(t, found)
+	assert.Equal(t, shared.ErrInvitationNotFound, err)
+}
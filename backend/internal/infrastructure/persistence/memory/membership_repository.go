@@ -0,0 +1,119 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/organization"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// MembershipRepository is an in-memory implementation of organization.MembershipRepository
+type MembershipRepository struct {
+	mu          sync.RWMutex
+	memberships map[membershipKey]*organization.Membership
+}
+
+// membershipKey identifies a user's membership of an organization
+type membershipKey struct {
+	orgID  string
+	userID string
+}
+
+// NewMembershipRepository creates a new in-memory membership repository
+func NewMembershipRepository() *MembershipRepository {
+	return &MembershipRepository{
+		memberships: make(map[membershipKey]*organization.Membership),
+	}
+}
+
+// Save persists a membership to the in-memory store
+func (r *MembershipRepository) Save(ctx context.Context, membership *organization.Membership) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := membershipKey{membership.OrganizationID().Value(), membership.UserID().Value()}
+	r.memberships[key] = snapshotMembership(membership)
+	return nil
+}
+
+// Find retrieves a user's membership of an organization
+func (r *MembershipRepository) Find(ctx context.Context, orgID organization.OrganizationID, userID user.UserID) (*organization.Membership, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	membership, exists := r.memberships[membershipKey{orgID.Value(), userID.Value()}]
+	if !exists {
+		return nil, shared.ErrNotOrganizationMember
+	}
+
+	return snapshotMembership(membership), nil
+}
+
+// FindByOrganization retrieves the members of an organization, longest-standing first
+func (r *MembershipRepository) FindByOrganization(ctx context.Context, orgID organization.OrganizationID) ([]*organization.Membership, error) {
+	return r.findWhere(func(m *organization.Membership) bool {
+		return m.OrganizationID().Equals(orgID)
+	}), nil
+}
+
+// FindByUserID retrieves the memberships of a user, oldest first
+func (r *MembershipRepository) FindByUserID(ctx context.Context, userID user.UserID) ([]*organization.Membership, error) {
+	return r.findWhere(func(m *organization.Membership) bool {
+		return m.UserID().Equals(userID)
+	}), nil
+}
+
+// Delete removes a user's membership of an organization
+func (r *MembershipRepository) Delete(ctx context.Context, orgID organization.OrganizationID, userID user.UserID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.memberships, membershipKey{orgID.Value(), userID.Value()})
+	return nil
+}
+
+// DeleteByUserID removes all memberships of a user
+func (r *MembershipRepository) DeleteByUserID(ctx context.Context, userID user.UserID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, membership := range r.memberships {
+		if membership.UserID().Equals(userID) {
+			delete(r.memberships, key)
+		}
+	}
+
+	return nil
+}
+
+// findWhere returns the memberships matching keep, oldest first
+func (r *MembershipRepository) findWhere(keep func(*organization.Membership) bool) []*organization.Membership {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	memberships := make([]*organization.Membership, 0)
+	for _, membership := range r.memberships {
+		if keep(membership) {
+			memberships = append(memberships, snapshotMembership(membership))
+		}
+	}
+
+	sort.Slice(memberships, func(i, j int) bool {
+		return memberships[i].JoinedAt().Before(memberships[j].JoinedAt())
+	})
+
+	return memberships
+}
+
+// snapshotMembership copies a membership so that stored state is only changed by Save
+func snapshotMembership(membership *organization.Membership) *organization.Membership {
+	return organization.ReconstructMembership(
+		membership.OrganizationID(),
+		membership.UserID(),
+		membership.Role(),
+		membership.JoinedAt(),
+	)
+}
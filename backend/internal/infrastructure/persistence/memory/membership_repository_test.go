@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuki5155/go-google-auth/internal/domain/organization"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+func newTestMembership(orgID, userID string, role organization.Role, joinedAt time.Time) *organization.Membership {
+	org, _ := organization.NewOrganizationID(orgID)
+	id, _ := user.NewUserID(userID)
+	return organization.ReconstructMembership(org, id, role, joinedAt)
+}
+
+func TestMembershipRepository_SaveAndFind(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMembershipRepository()
+	membership := newTestMembership("org-1", "user-1", organization.RoleAdmin, time.Now())
+
+	require.NoError(t, repo.Save(ctx, membership))
+
+	found, err := repo.Find(ctx, membership.OrganizationID(), membership.UserID())
+	require.NoError(t, err)
+	assert.Equal(t, organization.RoleAdmin, found.Role())
+
+	otherOrg, _ := organization.NewOrganizationID("org-2")
+	found, err = repo.Find(ctx, otherOrg, membership.UserID())
+	assert.Nil(t, found)
+	assert.Equal(t, shared.ErrNotOrganizationMember, err)
+}
+
+func TestMembershipRepository_ScopedQueries(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMembershipRepository()
+	now := time.Now()
+	require.NoError(t, repo.Save(ctx, newTestMembership("org-1", "user-2", organization.RoleMember, now)))
+	require.NoError(t, repo.Save(ctx, newTestMembership("org-1", "user-1", organization.RoleOwner, now.Add(-time.Hour))))
+	require.NoError(t, repo.Save(ctx, newTestMembership("org-2", "user-1", organization.RoleMember, now)))
+
+	orgID, _ := organization.NewOrganizationID("org-1")
+	members, err := repo.FindByOrganization(ctx, orgID)
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+	assert.Equal(t, "user-1", members[0].UserID().Value())
+	assert.Equal(t, "user-2", members[1].UserID().Value())
+
+	userID, _ := user.NewUserID("user-1")
+	memberships, err := repo.FindByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, memberships, 2)
+	assert.Equal(t, "org-1", memberships[0].OrganizationID().Value())
+	assert.Equal(t, "org-2", memberships[1].OrganizationID().Value())
+}
+
+func TestMembershipRepository_Delete(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMembershipRepository()
+	now := time.Now()
+	require.NoError(t, repo.Save(ctx, newTestMembership("org-1", "user-1", organization.RoleOwner, now)))
+	require.NoError(t, repo.Save(ctx, newTestMembership("org-2", "user-1", organization.RoleMember, now)))
+	require.NoError(t, repo.Save(ctx, newTestMembership("org-1", "user-2", organization.RoleMember, now)))
+
+	orgID, _ := organization.NewOrganizationID("org-1")
+	userID, _ := user.NewUserID("user-2")
+	require.NoError(t, repo.Delete(ctx, orgID, userID))
+	members, _ := repo.FindByOrganization(ctx, orgID)
+	assert.Len(t, members, 1)
+
+	userID, _ = user.NewUserID("user-1")
+	require.NoError(t, repo.DeleteByUserID(ctx, userID))
+	memberships, _ := repo.FindByUserID(ctx, userID)
+	assert.Empty(t, memberships)
+}
@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/mfa"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// MFARepository is an in-memory implementation of mfa.Repository
+type MFARepository struct {
+	mu          sync.RWMutex
+	enrollments map[string]*mfa.Enrollment // key: user ID
+}
+
+// NewMFARepository creates a new in-memory MFA enrollment repository
+func NewMFARepository() *MFARepository {
+	return &MFARepository{
+		enrollments: make(map[string]*mfa.Enrollment),
+	}
+}
+
+// Save persists an enrollment, replacing any existing one for the user
+func (r *MFARepository) Save(ctx context.Context, e *mfa.Enrollment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.enrollments[e.UserID().Value()] = snapshotEnrollment(e)
+	return nil
+}
+
+// FindByUserID retrieves a user's enrollment
+func (r *MFARepository) FindByUserID(ctx context.Context, userID user.UserID) (*mfa.Enrollment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	e, exists := r.enrollments[userID.Value()]
+	if !exists {
+		return nil, shared.ErrMFANotEnabled
+	}
+
+	return snapshotEnrollment(e), nil
+}
+
+// DeleteByUserID removes a user's enrollment
+func (r *MFARepository) DeleteByUserID(ctx context.Context, userID user.UserID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.enrollments, userID.Value())
+	return nil
+}
+
+// snapshotEnrollment copies an enrollment so that stored state is only changed by Save
+func snapshotEnrollment(e *mfa.Enrollment) *mfa.Enrollment {
+	return mfa.ReconstructEnrollment(
+		e.UserID(),
+		e.Secret(),
+		mfa.ReconstructRecoveryCodes(e.RecoveryCodes().Hashes()),
+		e.LastUsedStep(),
+		e.CreatedAt(),
+		e.ConfirmedAt(),
+	)
+}
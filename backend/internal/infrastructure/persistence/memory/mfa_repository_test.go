@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuki5155/go-google-auth/internal/domain/mfa"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+func TestMFARepository_SaveAndFindByUserID(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMFARepository()
+
+	userID, _ := user.NewUserID("test-user-123")
+	e, err := mfa.NewEnrollment(userID, []byte("12345678901234567890"))
+	require.NoError(t, err)
+	_, err = e.Confirm(100)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Save(ctx, e))
+
+	found, err := repo.FindByUserID(ctx, userID)
+
+	require.NoError(t, err)
+	assert.True(t, found.IsConfirmed())
+	assert.Equal(t, []byte("12345678901234567890"), found.Secret())
+	assert.Equal(t, int64(100), found.LastUsedStep())
+	assert.Equal(t, mfa.RecoveryCodeCount, found.RecoveryCodes().Remaining())
+	assert.Empty(t, found.DomainEvents())
+}
+
+func TestMFARepository_FindByUserID_NotFound(t *testing.T) {
+	repo := NewMFARepository()
+	userID, _ := user.NewUserID("test-user-123")
+
+	found, err := repo.FindByUserID(context.Background(), userID)
+
+	assert.Nil(t, found)
+	assert.Equal(t, shared.ErrMFANotEnabled, err)
+}
+
+func TestMFARepository_StoresSnapshot(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMFARepository()
+
+	userID, _ := user.NewUserID("test-user-123")
+	e, _ := mfa.NewEnrollment(userID, []byte("secret"))
+	require.NoError(t, repo.Save(ctx, e))
+
+	// Changes after Save are not visible until saved again
+	require.NoError(t, e.AcceptStep(5))
+
+	found, err := repo.FindByUserID(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), found.LastUsedStep())
+}
+
+func TestMFARepository_DeleteByUserID(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMFARepository()
+
+	userID, _ := user.NewUserID("test-user-123")
+	e, _ := mfa.NewEnrollment(userID, []byte("secret"))
+	require.NoError(t, repo.Save(ctx, e))
+
+	require.NoError(t, repo.DeleteByUserID(ctx, userID))
+
+	_, err := repo.FindByUserID(ctx, userID)
+	assert.Equal(t, shared.ErrMFANotEnabled, err)
+}
@@ -0,0 +1,58 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/organization"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+// OrganizationRepository is an in-memory implementation of organization.Repository
+type OrganizationRepository struct {
+	mu            sync.RWMutex
+	organizations map[string]*organization.Organization // key: organization ID
+}
+
+// NewOrganizationRepository creates a new in-memory organization repository
+func NewOrganizationRepository() *OrganizationRepository {
+	return &OrganizationRepository{
+		organizations: make(map[string]*organization.Organization),
+	}
+}
+
+// Save persists an organization to the in-memory store
+func (r *OrganizationRepository) Save(ctx context.Context, org *organization.Organization) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.organizations[org.ID().Value()] = snapshotOrganization(org)
+	return nil
+}
+
+// FindByID retrieves an organization by its ID
+func (r *OrganizationRepository) FindByID(ctx context.Context, id organization.OrganizationID) (*organization.Organization, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	org, exists := r.organizations[id.Value()]
+	if !exists {
+		return nil, shared.ErrOrganizationNotFound
+	}
+
+	return snapshotOrganization(org), nil
+}
+
+// Delete removes an organization from the in-memory store
+func (r *OrganizationRepository) Delete(ctx context.Context, id organization.OrganizationID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.organizations, id.Value())
+	return nil
+}
+
+// snapshotOrganization copies an organization so that stored state is only changed by Save
+func snapshotOrganization(org *organization.Organization) *organization.Organization {
+	return organization.ReconstructOrganization(org.ID(), org.Name(), org.CreatedBy(), org.CreatedAt())
+}
@@ -0,0 +1,37 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuki5155/go-google-auth/internal/domain/organization"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+func TestOrganizationRepository_SaveAndFind(t *testing.T) {
+	ctx := context.Background()
+	repo := NewOrganizationRepository()
+	creator, _ := user.NewUserID("test-user-123")
+	org, err := organization.NewOrganization("Acme", creator)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Save(ctx, org))
+
+	found, err := repo.FindByID(ctx, org.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "Acme", found.Name())
+	assert.True(t, found.CreatedBy().Equals(creator))
+	assert.Empty(t, found.DomainEvents())
+}
+
+func TestOrganizationRepository_NotFound(t *testing.T) {
+	repo := NewOrganizationRepository()
+	id, _ := organization.NewOrganizationID("non-existent")
+
+	found, err := repo.FindByID(context.Background(), id)
+	assert.Nil(t, found)
+	assert.Equal(t, shared.ErrOrganizationNotFound, err)
+}
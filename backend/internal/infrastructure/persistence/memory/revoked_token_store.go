@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RevokedTokenStore is an in-memory implementation of ports.RevokedTokenStore
+type RevokedTokenStore struct {
+	mu      sync.Mutex
+	revoked map[string]time.Time // key: jti, value: token expiry
+	now     func() time.Time
+}
+
+// NewRevokedTokenStore creates a new in-memory revoked-token store
+func NewRevokedTokenStore() *RevokedTokenStore {
+	return &RevokedTokenStore{
+		revoked: make(map[string]time.Time),
+		now:     time.Now,
+	}
+}
+
+// Revoke records a token as revoked, dropping tokens that have expired
+// since they are rejected anyway
+func (s *RevokedTokenStore) Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for key, expiry := range s.revoked {
+		if !now.Before(expiry) {
+			delete(s.revoked, key)
+		}
+	}
+
+	s.revoked[tokenID] = expiresAt
+	return nil
+}
+
+// IsRevoked reports whether a token was revoked
+func (s *RevokedTokenStore) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, revoked := s.revoked[tokenID]
+	return revoked, nil
+}
@@ -0,0 +1,38 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevokedTokenStore_Revoke(t *testing.T) {
+	ctx := context.Background()
+	store := NewRevokedTokenStore()
+
+	require.NoError(t, store.Revoke(ctx, "token-1", time.Now().Add(time.Minute)))
+
+	revoked, err := store.IsRevoked(ctx, "token-1")
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	revoked, err = store.IsRevoked(ctx, "token-2")
+	require.NoError(t, err)
+	assert.False(t, revoked)
+}
+
+func TestRevokedTokenStore_DropsExpired(t *testing.T) {
+	ctx := context.Background()
+	store := NewRevokedTokenStore()
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	require.NoError(t, store.Revoke(ctx, "expired", now.Add(-time.Second)))
+	require.NoError(t, store.Revoke(ctx, "valid", now.Add(time.Minute)))
+
+	assert.NotContains(t, store.revoked, "expired")
+	assert.Contains(t, store.revoked, "valid")
+}
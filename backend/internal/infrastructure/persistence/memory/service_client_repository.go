@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/serviceclient"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+// ServiceClientRepository is an in-memory implementation of serviceclient.Repository.
+// Clients are immutable, so they are stored as-is.
+type ServiceClientRepository struct {
+	mu      sync.RWMutex
+	clients map[string]*serviceclient.Client // key: client ID
+}
+
+// NewServiceClientRepository creates a new in-memory service client repository
+func NewServiceClientRepository() *ServiceClientRepository {
+	return &ServiceClientRepository{
+		clients: make(map[string]*serviceclient.Client),
+	}
+}
+
+// Save registers or replaces a client in the in-memory store
+func (r *ServiceClientRepository) Save(ctx context.Context, client *serviceclient.Client) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.clients[client.ID()] = client
+	return nil
+}
+
+// FindByID retrieves a client by its client ID
+func (r *ServiceClientRepository) FindByID(ctx context.Context, id string) (*serviceclient.Client, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	client, exists := r.clients[id]
+	if !exists {
+		return nil, shared.ErrServiceClientNotFound
+	}
+
+	return client, nil
+}
@@ -0,0 +1,30 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuki5155/go-google-auth/internal/domain/serviceclient"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+func TestServiceClientRepository_SaveAndFind(t *testing.T) {
+	ctx := context.Background()
+	repo := NewServiceClientRepository()
+	client, err := serviceclient.NewSecretClient("billing", "Billing", serviceclient.HashSecret("s3cret"), []string{"users:read"})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Save(ctx, client))
+
+	found, err := repo.FindByID(ctx, "billing")
+	require.NoError(t, err)
+	assert.Equal(t, "Billing", found.Name())
+}
+
+func TestServiceClientRepository_NotFound(t *testing.T) {
+	_, err := NewServiceClientRepository().FindByID(context.Background(), "missing")
+
+	assert.Equal(t, shared.ErrServiceClientNotFound, err)
+}
@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// SessionRepository is an in-memory implementation of session.Repository
+type SessionRepository struct {
+	mu       sync.RWMutex
+	sessions map[string]*session.Session // key: session ID
+}
+
+// NewSessionRepository creates a new in-memory session repository
+func NewSessionRepository() *SessionRepository {
+	return &SessionRepository{
+		sessions: make(map[string]*session.Session),
+	}
+}
+
+// Save persists a session to the in-memory store
+func (r *SessionRepository) Save(ctx context.Context, s *session.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sessions[s.ID().Value()] = snapshotSession(s)
+	return nil
+}
+
+// FindByID retrieves a session by its ID
+func (r *SessionRepository) FindByID(ctx context.Context, id session.SessionID) (*session.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s, exists := r.sessions[id.Value()]
+	if !exists {
+		return nil, shared.ErrSessionNotFound
+	}
+
+	return snapshotSession(s), nil
+}
+
+// FindByUserID retrieves all sessions of a user, newest first
+func (r *SessionRepository) FindByUserID(ctx context.Context, userID user.UserID) ([]*session.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sessions := make([]*session.Session, 0)
+	for _, s := range r.sessions {
+		if s.UserID().Equals(userID) {
+			sessions = append(sessions, snapshotSession(s))
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].CreatedAt().After(sessions[j].CreatedAt())
+	})
+
+	return sessions, nil
+}
+
+// DeleteByUserID removes all sessions of a user
+func (r *SessionRepository) DeleteByUserID(ctx context.Context, userID user.UserID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, s := range r.sessions {
+		if s.UserID().Equals(userID) {
+			delete(r.sessions, id)
+		}
+	}
+
+	return nil
+}
+
+// snapshotSession copies a session so that stored state is only changed by Save
+func snapshotSession(s *session.Session) *session.Session {
+	return session.ReconstructSession(
+		s.ID(),
+		s.UserID(),
+		s.CreatedAt(),
+		s.LastUsedAt(),
+		s.ExpiresAt(),
+		s.RevokedAt(),
+		s.Device(),
+		s.DPoPKey(),
+	)
+}
@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+func TestSessionRepository_SaveAndFindByID(t *testing.T) {
+	ctx := context.Background()
+	repo := NewSessionRepository()
+
+	userID, _ := user.NewUserID("test-user-123")
+	s, err := session.NewSession(userID, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Save(ctx, s))
+
+	found, err := repo.FindByID(ctx, s.ID())
+
+	require.NoError(t, err)
+	assert.True(t, found.ID().Equals(s.ID()))
+	assert.True(t, found.UserID().Equals(userID))
+	assert.False(t, found.IsRevoked())
+}
+
+func TestSessionRepository_FindByID_NotFound(t *testing.T) {
+	ctx := context.Background()
+	repo := NewSessionRepository()
+
+	id, _ := session.NewSessionID("non-existent")
+
+	found, err := repo.FindByID(ctx, id)
+
+	assert.Nil(t, found)
+	assert.Equal(t, shared.ErrSessionNotFound, err)
+}
+
+func TestSessionRepository_StoresSnapshot(t *testing.T) {
+	ctx := context.Background()
+	repo := NewSessionRepository()
+
+	userID, _ := user.NewUserID("test-user-123")
+	s, _ := session.NewSession(userID, time.Now().Add(time.Hour))
+	require.NoError(t, repo.Save(ctx, s))
+
+	// Mutating the aggregate without saving must not change stored state
+	s.Revoke(time.Now())
+
+	found, err := repo.FindByID(ctx, s.ID())
+	require.NoError(t, err)
+	assert.False(t, found.IsRevoked())
+}
+
+func TestSessionRepository_FindByUserID(t *testing.T) {
+	ctx := context.Background()
+	repo := NewSessionRepository()
+
+	userID, _ := user.NewUserID("test-user-123")
+	otherID, _ := user.NewUserID("other-user")
+
+	older := session.ReconstructSession(mustSessionID(t, "older"), userID, time.Now().Add(-time.Hour), time.Now().Add(-time.Hour), time.Now().Add(time.Hour), time.Time{}, session.Device{}, "")
+	newer := session.ReconstructSession(mustSessionID(t, "newer"), userID, time.Now(), time.Now(), time.Now().Add(time.Hour), time.Time{}, session.Device{}, "")
+	other := session.ReconstructSession(mustSessionID(t, "other"), otherID, time.Now(), time.Now(), time.Now().Add(time.Hour), time.Time{}, session.Device{}, "")
+
+	require.NoError(t, repo.Save(ctx, older))
+	require.NoError(t, repo.Save(ctx, newer))
+	require.NoError(t, repo.Save(ctx, other))
+
+	sessions, err := repo.FindByUserID(ctx, userID)
+
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	assert.Equal(t, "newer", sessions[0].ID().Value())
+	assert.Equal(t, "older", sessions[1].ID().Value())
+}
+
+func TestSessionRepository_DeleteByUserID(t *testing.T) {
+	ctx := context.Background()
+	repo := NewSessionRepository()
+
+	userID, _ := user.NewUserID("test-user-123")
+	otherID, _ := user.NewUserID("other-user")
+	s1, _ := session.NewSession(userID, time.Now().Add(time.Hour))
+	s2, _ := session.NewSession(otherID, time.Now().Add(time.Hour))
+	require.NoError(t, repo.Save(ctx, s1))
+	require.NoError(t, repo.Save(ctx, s2))
+
+	require.NoError(t, repo.DeleteByUserID(ctx, userID))
+
+	assert.Len(t, repo.sessions, 1)
+	_, err := repo.FindByID(ctx, s2.ID())
+	assert.NoError(t, err)
+}
+
+func mustSessionID(t *testing.T, value string) session.SessionID {
+	t.Helper()
+
+	id, err := session.NewSessionID(value)
+	require.NoError(t, err)
+	return id
+}
@@ -0,0 +1,49 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// UsedIDStore is an in-memory record of single-use IDs, such as those of
+// email login links, client assertions, DPoP proofs and authorization codes.
+// It implements ports.MagicLinkStore, ports.ClientAssertionStore,
+// ports.DPoPReplayCache and ports.AuthorizationCodeStore.
+type UsedIDStore struct {
+	mu     sync.Mutex
+	used   map[string]time.Time // key: ID, value: expiry of what it identifies
+	reused error
+	now    func() time.Time
+}
+
+// NewUsedIDStore creates a new in-memory used-ID store that rejects an ID
+// used a second time with reused
+func NewUsedIDStore(reused error) *UsedIDStore {
+	return &UsedIDStore{
+		used:   make(map[string]time.Time),
+		reused: reused,
+		now:    time.Now,
+	}
+}
+
+// MarkUsed records an ID as used, dropping IDs that have expired since what
+// they identify can no longer be verified anyway
+func (s *UsedIDStore) MarkUsed(ctx context.Context, id string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for key, expiry := range s.used {
+		if !now.Before(expiry) {
+			delete(s.used, key)
+		}
+	}
+
+	if _, exists := s.used[id]; exists {
+		return s.reused
+	}
+
+	s.used[id] = expiresAt
+	return nil
+}
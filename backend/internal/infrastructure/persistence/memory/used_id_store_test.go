@@ -0,0 +1,35 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+func TestUsedIDStore_MarkUsedOnce(t *testing.T) {
+	ctx := context.Background()
+	store := NewUsedIDStore(shared.ErrInvalidMagicLink)
+	expiresAt := time.Now().Add(time.Minute)
+
+	require.NoError(t, store.MarkUsed(ctx, "id-1", expiresAt))
+	assert.Equal(t, shared.ErrInvalidMagicLink, store.MarkUsed(ctx, "id-1", expiresAt))
+	assert.NoError(t, store.MarkUsed(ctx, "id-2", expiresAt))
+}
+
+func TestUsedIDStore_DropsExpired(t *testing.T) {
+	ctx := context.Background()
+	store := NewUsedIDStore(shared.ErrInvalidGrant)
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	require.NoError(t, store.MarkUsed(ctx, "expired", now.Add(-time.Second)))
+	require.NoError(t, store.MarkUsed(ctx, "valid", now.Add(time.Minute)))
+
+	assert.NotContains(t, store.used, "expired")
+	assert.Contains(t, store.used, "valid")
+}
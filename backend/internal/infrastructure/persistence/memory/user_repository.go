@@ -10,9 +10,10 @@ import (
 
 // UserRepository is an in-memory implementation of user.Repository
 type UserRepository struct {
-	mu    sync.RWMutex
-	users map[string]*user.User // key: user ID
-	emails map[string]string    // key: email, value: user ID
+	mu     sync.RWMutex
+	users  map[string]*user.User // key: user ID
+	emails map[string]string     // key: email, value: user ID
+	google map[string]string     // key: linked Google ID, value: user ID
 }
 
 // NewUserRepository creates a new in-memory user repository
@@ -20,6 +21,7 @@ func NewUserRepository() *UserRepository {
 	return &UserRepository{
 		users:  make(map[string]*user.User),
 		emails: make(map[string]string),
+		google: make(map[string]string),
 	}
 }
 
@@ -36,8 +38,11 @@ func (r *UserRepository) Save(ctx context.Context, u *user.User) error {
 		return shared.ErrUserAlreadyExists
 	}
 
-	r.users[userID] = u
+	r.users[userID] = snapshot(u)
 	r.emails[email] = userID
+	if googleID := u.GoogleID(); googleID != "" {
+		r.google[googleID] = userID
+	}
 
 	return nil
 }
@@ -52,7 +57,7 @@ func (r *UserRepository) FindByID(ctx context.Context, id user.UserID) (*user.Us
 		return nil, shared.ErrUserNotFound
 	}
 
-	return u, nil
+	return snapshot(u), nil
 }
 
 // FindByEmail retrieves a user by their email
@@ -70,7 +75,25 @@ func (r *UserRepository) FindByEmail(ctx context.Context, email user.Email) (*us
 		return nil, shared.ErrUserNotFound
 	}
 
-	return u, nil
+	return snapshot(u), nil
+}
+
+// FindByGoogleID retrieves the user a Google account is linked to
+func (r *UserRepository) FindByGoogleID(ctx context.Context, googleID string) (*user.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	userID, exists := r.google[googleID]
+	if !exists {
+		return nil, shared.ErrUserNotFound
+	}
+
+	u, exists := r.users[userID]
+	if !exists {
+		return nil, shared.ErrUserNotFound
+	}
+
+	return snapshot(u), nil
 }
 
 // Delete removes a user from the repository
@@ -84,8 +107,9 @@ func (r *UserRepository) Delete(ctx context.Context, id user.UserID) error {
 		return shared.ErrUserNotFound
 	}
 
-	// Remove from email index
+	// Remove from email and Google indexes
 	delete(r.emails, u.Email().Value())
+	delete(r.google, u.GoogleID())
 	delete(r.users, userID)
 
 	return nil
@@ -108,3 +132,22 @@ func (r *UserRepository) ExistsByEmail(ctx context.Context, email user.Email) (b
 	_, exists := r.emails[email.Value()]
 	return exists, nil
 }
+
+// snapshot copies a user through ReconstructUser so that stored state is only
+// changed by Save, mirroring how a persistent store would behave
+func snapshot(u *user.User) *user.User {
+	return user.ReconstructUser(
+		u.ID(),
+		u.Email(),
+		u.Profile(),
+		u.Preferences(),
+		u.Roles(),
+		u.Status(),
+		u.LoginHistory(),
+		u.Passkeys(),
+		u.GoogleID(),
+		u.ActiveOrganizationID(),
+		u.CreatedAt(),
+		u.UpdatedAt(),
+	)
+}
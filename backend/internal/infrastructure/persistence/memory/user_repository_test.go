@@ -104,6 +104,31 @@ func TestUserRepository_FindByID_Success(t *testing.T) {
 	assert.Equal(t, "Test User", foundUser.Profile().Name())
 }
 
+func TestUserRepository_Save_PersistsLoginHistory(t *testing.T) {
+	ctx := context.Background()
+	repo := NewUserRepository()
+
+	userID, _ := user.NewUserID("test-user-123")
+	email, _ := user.NewEmail("test@example.com", true)
+	profile := user.NewProfile("Test User", "")
+	u, _ := user.NewUser(userID, email, profile)
+	u.RecordLogin()
+	u.RecordLogin()
+
+	require.NoError(t, repo.Save(ctx, u))
+
+	savedUser, err := repo.FindByID(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, savedUser.LoginCount())
+	assert.Equal(t, u.LastLoginAt(), savedUser.LastLoginAt())
+	assert.Equal(t, u.LoginHistory().RecentLogins(), savedUser.LoginHistory().RecentLogins())
+
+	// Changes to a loaded user are not visible until it is saved
+	savedUser.RecordLogin()
+	reloaded, _ := repo.FindByID(ctx, userID)
+	assert.Equal(t, 2, reloaded.LoginCount())
+}
+
 func TestUserRepository_FindByID_NotFound(t *testing.T) {
 	ctx := context.Background()
 	repo := NewUserRepository()
@@ -148,6 +173,31 @@ func TestUserRepository_FindByEmail_NotFound(t *testing.T) {
 	assert.Nil(t, foundUser)
 }
 
+func TestUserRepository_FindByGoogleID(t *testing.T) {
+	ctx := context.Background()
+	repo := NewUserRepository()
+
+	userID, _ := user.NewUserID("email-123")
+	email, _ := user.NewEmail("test@example.com", true)
+	u, _ := user.NewUser(userID, email, user.NewProfile("Test User", ""))
+	require.NoError(t, repo.Save(ctx, u))
+
+	_, err := repo.FindByGoogleID(ctx, "google-456")
+	assert.Equal(t, shared.ErrUserNotFound, err)
+
+	require.NoError(t, u.LinkGoogleAccount("google-456"))
+	require.NoError(t, repo.Save(ctx, u))
+
+	found, err := repo.FindByGoogleID(ctx, "google-456")
+	require.NoError(t, err)
+	assert.Equal(t, userID, found.ID())
+	assert.Equal(t, "google-456", found.GoogleID())
+
+	require.NoError(t, repo.Delete(ctx, userID))
+	_, err = repo.FindByGoogleID(ctx, "google-456")
+	assert.Equal(t, shared.ErrUserNotFound, err)
+}
+
 func TestUserRepository_Delete_Success(t *testing.T) {
 	ctx := context.Background()
 	repo := NewUserRepository()
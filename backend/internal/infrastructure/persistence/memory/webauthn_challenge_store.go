@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+// WebAuthnChallengeStore is an in-memory implementation of ports.WebAuthnChallengeStore
+type WebAuthnChallengeStore struct {
+	mu         sync.Mutex
+	challenges map[string]ports.WebAuthnChallenge // key: challenge
+	now        func() time.Time
+}
+
+// NewWebAuthnChallengeStore creates a new in-memory challenge store
+func NewWebAuthnChallengeStore() *WebAuthnChallengeStore {
+	return &WebAuthnChallengeStore{
+		challenges: make(map[string]ports.WebAuthnChallenge),
+		now:        time.Now,
+	}
+}
+
+// Save stores a challenge, dropping any that have expired
+func (s *WebAuthnChallengeStore) Save(ctx context.Context, challenge ports.WebAuthnChallenge) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for key, existing := range s.challenges {
+		if !now.Before(existing.ExpiresAt) {
+			delete(s.challenges, key)
+		}
+	}
+
+	s.challenges[challenge.Challenge] = challenge
+	return nil
+}
+
+// Consume removes a challenge and returns it if it has not expired
+func (s *WebAuthnChallengeStore) Consume(ctx context.Context, challenge string) (*ports.WebAuthnChallenge, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, exists := s.challenges[challenge]
+	if !exists {
+		return nil, shared.ErrPasskeyChallengeInvalid
+	}
+	delete(s.challenges, challenge)
+
+	if !s.now().Before(stored.ExpiresAt) {
+		return nil, shared.ErrPasskeyChallengeInvalid
+	}
+
+	return &stored, nil
+}
@@ -0,0 +1,51 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+func TestWebAuthnChallengeStore_ConsumeOnce(t *testing.T) {
+	ctx := context.Background()
+	store := NewWebAuthnChallengeStore()
+	challenge := ports.WebAuthnChallenge{
+		Challenge: "challenge-1",
+		UserID:    "test-user-123",
+		Purpose:   ports.WebAuthnPurposeRegistration,
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+	require.NoError(t, store.Save(ctx, challenge))
+
+	consumed, err := store.Consume(ctx, "challenge-1")
+	require.NoError(t, err)
+	assert.Equal(t, challenge, *consumed)
+
+	_, err = store.Consume(ctx, "challenge-1")
+	assert.Equal(t, shared.ErrPasskeyChallengeInvalid, err)
+}
+
+func TestWebAuthnChallengeStore_Expired(t *testing.T) {
+	ctx := context.Background()
+	store := NewWebAuthnChallengeStore()
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	require.NoError(t, store.Save(ctx, ports.WebAuthnChallenge{Challenge: "expired", ExpiresAt: now.Add(-time.Second)}))
+	require.NoError(t, store.Save(ctx, ports.WebAuthnChallenge{Challenge: "valid", ExpiresAt: now.Add(time.Minute)}))
+
+	_, err := store.Consume(ctx, "expired")
+	assert.Equal(t, shared.ErrPasskeyChallengeInvalid, err)
+
+	_, err = store.Consume(ctx, "unknown")
+	assert.Equal(t, shared.ErrPasskeyChallengeInvalid, err)
+
+	_, err = store.Consume(ctx, "valid")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,85 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Policy allows Limit requests per Window, refilled continuously (token bucket)
+type Policy struct {
+	Limit  int
+	Window time.Duration
+}
+
+// Result is the outcome of a rate limit check
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAfter time.Duration // until the bucket is full again
+	RetryAfter time.Duration // until the next request is allowed (zero if allowed)
+}
+
+// Store keeps token buckets and atomically takes a token from one
+type Store interface {
+	Take(ctx context.Context, key string, policy Policy, now time.Time) (Result, error)
+}
+
+// Limiter checks requests against rate limit policies
+type Limiter struct {
+	store Store
+	now   func() time.Time
+}
+
+// NewLimiter creates a Limiter backed by the given store
+func NewLimiter(store Store) *Limiter {
+	return &Limiter{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// Allow takes a token for key and reports whether the request may proceed
+func (l *Limiter) Allow(ctx context.Context, key string, policy Policy) (Result, error) {
+	return l.store.Take(ctx, key, policy, l.now())
+}
+
+// bucket is the persisted state of a token bucket
+type bucket struct {
+	Tokens    float64   `json:"tokens"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// take refills the bucket up to now and tries to take one token
+func (b bucket) take(policy Policy, now time.Time) (bucket, Result) {
+	capacity := float64(policy.Limit)
+	rate := capacity / policy.Window.Seconds() // tokens per second
+
+	tokens := capacity
+	if !b.UpdatedAt.IsZero() {
+		elapsed := now.Sub(b.UpdatedAt).Seconds()
+		if elapsed < 0 {
+			elapsed = 0
+		}
+		tokens = math.Min(capacity, b.Tokens+elapsed*rate)
+	}
+
+	result := Result{Limit: policy.Limit}
+	if tokens >= 1 {
+		tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = secondsToDuration((1 - tokens) / rate)
+	}
+
+	result.Remaining = int(math.Floor(tokens))
+	result.ResetAfter = secondsToDuration((capacity - tokens) / rate)
+
+	return bucket{Tokens: tokens, UpdatedAt: now}, result
+}
+
+// secondsToDuration converts fractional seconds to a Duration
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
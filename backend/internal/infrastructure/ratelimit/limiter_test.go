@@ -0,0 +1,27 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	now := time.Now()
+	limiter := NewLimiter(NewMemoryStore())
+	limiter.now = func() time.Time { return now }
+
+	policy := Policy{Limit: 1, Window: time.Minute}
+
+	result, err := limiter.Allow(context.Background(), "login_ip:10.0.0.1", policy)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	result, err = limiter.Allow(context.Background(), "login_ip:10.0.0.1", policy)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, time.Minute, result.RetryAfter)
+}
@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how many Take calls happen between sweeps of idle buckets
+const sweepInterval = 1000
+
+// MemoryStore is an in-process Store, suitable for a single server instance
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]memoryBucket
+	calls   int
+}
+
+// memoryBucket is a bucket plus the time after which it is full and can be dropped
+type memoryBucket struct {
+	bucket
+	idleAt time.Time
+}
+
+// NewMemoryStore creates a new in-memory rate limit store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: make(map[string]memoryBucket),
+	}
+}
+
+// Take atomically takes a token from the bucket for key
+func (s *MemoryStore) Take(ctx context.Context, key string, policy Policy, now time.Time) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls++
+	if s.calls%sweepInterval == 0 {
+		s.sweep(now)
+	}
+
+	updated, result := s.buckets[key].take(policy, now)
+	s.buckets[key] = memoryBucket{
+		bucket: updated,
+		idleAt: now.Add(result.ResetAfter),
+	}
+
+	return result, nil
+}
+
+// sweep drops buckets that have refilled completely
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, b := range s.buckets {
+		if !now.Before(b.idleAt) {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPolicy = Policy{Limit: 3, Window: 3 * time.Second} // one token per second
+
+func TestMemoryStore_Take_AllowsUpToLimit(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	now := time.Now()
+
+	for i := 2; i >= 0; i-- {
+		result, err := store.Take(ctx, "ip:1", testPolicy, now)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 3, result.Limit)
+		assert.Equal(t, i, result.Remaining)
+	}
+
+	result, err := store.Take(ctx, "ip:1", testPolicy, now)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+	assert.Equal(t, time.Second, result.RetryAfter)
+	assert.Equal(t, 3*time.Second, result.ResetAfter)
+}
+
+func TestMemoryStore_Take_Refills(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	now := time.Now()
+
+	for i := 0; i < 3; i++ {
+		_, err := store.Take(ctx, "ip:1", testPolicy, now)
+		require.NoError(t, err)
+	}
+
+	// Half a token is not enough
+	result, err := store.Take(ctx, "ip:1", testPolicy, now.Add(500*time.Millisecond))
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 500*time.Millisecond, result.RetryAfter)
+
+	result, err = store.Take(ctx, "ip:1", testPolicy, now.Add(time.Second))
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+
+	// Refilling never exceeds the limit
+	result, err = store.Take(ctx, "ip:1", testPolicy, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 2, result.Remaining)
+}
+
+func TestMemoryStore_Take_KeysAreIndependent(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	now := time.Now()
+
+	for i := 0; i < 3; i++ {
+		_, err := store.Take(ctx, "ip:1", testPolicy, now)
+		require.NoError(t, err)
+	}
+
+	result, err := store.Take(ctx, "ip:2", testPolicy, now)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 2, result.Remaining)
+}
+
+func TestMemoryStore_Take_Concurrent(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	now := time.Now()
+	policy := Policy{Limit: 50, Window: time.Hour}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		allowed int
+	)
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := store.Take(ctx, "user:1", policy, now)
+			if err == nil && result.Allowed {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 50, allowed)
+}
+
+func TestMemoryStore_Sweep_DropsFullBuckets(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	now := time.Now()
+
+	_, err := store.Take(ctx, "ip:1", testPolicy, now)
+	require.NoError(t, err)
+	_, err = store.Take(ctx, "ip:2", Policy{Limit: 3, Window: time.Hour}, now)
+	require.NoError(t, err)
+
+	store.sweep(now.Add(2 * time.Second))
+
+	assert.NotContains(t, store.buckets, "ip:1")
+	assert.Contains(t, store.buckets, "ip:2")
+}
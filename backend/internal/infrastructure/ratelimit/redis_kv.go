@@ -0,0 +1,268 @@
+package ratelimit
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// redisKeyPrefix namespaces rate limit buckets in a shared Redis
+	redisKeyPrefix = "ratelimit:"
+
+	// redisTimeout bounds a command when the context has no deadline
+	redisTimeout = 2 * time.Second
+
+	// redisMaxIdle is how many connections are kept open between commands
+	redisMaxIdle = 8
+)
+
+// redisCASScript stores a bucket as a hash of its value (v) and version (n),
+// but only if the version is still the one the caller read
+const redisCASScript = `
+if (redis.call('HGET', KEYS[1], 'n') or '0') ~= ARGV[2] then
+	return 0
+end
+redis.call('HSET', KEYS[1], 'v', ARGV[1], 'n', tostring(tonumber(ARGV[2]) + 1))
+redis.call('PEXPIRE', KEYS[1], ARGV[3])
+return 1
+`
+
+// RedisKV is a KV on any server speaking the Redis protocol (Redis, Valkey,
+// ElastiCache, ...), so that rate limits are shared by every instance
+type RedisKV struct {
+	addr      string
+	password  string
+	db        int
+	tlsConfig *tls.Config
+	dialer    net.Dialer
+	idle      chan *redisConn
+}
+
+// redisConn is an open connection to the server
+type redisConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// redisError is an error reply from the server
+type redisError string
+
+func (e redisError) Error() string {
+	return "redis: " + string(e)
+}
+
+// NewRedisKV creates a RedisKV from a URL such as redis://:password@host:6379/0;
+// use rediss:// for TLS
+func NewRedisKV(rawURL string) (*RedisKV, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis URL: %w", err)
+	}
+	if u.Scheme != "redis" && u.Scheme != "rediss" {
+		return nil, fmt.Errorf("invalid redis URL scheme %q", u.Scheme)
+	}
+
+	kv := &RedisKV{
+		addr:   u.Host,
+		dialer: net.Dialer{Timeout: redisTimeout},
+		idle:   make(chan *redisConn, redisMaxIdle),
+	}
+	if u.Port() == "" {
+		kv.addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+	if u.Scheme == "rediss" {
+		kv.tlsConfig = &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12}
+	}
+	if password, ok := u.User.Password(); ok {
+		kv.password = password
+	}
+	if path := strings.Trim(u.Path, "/"); path != "" {
+		if kv.db, err = strconv.Atoi(path); err != nil {
+			return nil, fmt.Errorf("invalid redis database %q", path)
+		}
+	}
+	return kv, nil
+}
+
+// Get returns the value and version of key; version is 0 if the key does not exist
+func (kv *RedisKV) Get(ctx context.Context, key string) ([]byte, int64, error) {
+	reply, err := kv.do(ctx, "HMGET", redisKeyPrefix+key, "v", "n")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	fields, ok := reply.([]any)
+	if !ok || len(fields) != 2 {
+		return nil, 0, fmt.Errorf("redis: unexpected HMGET reply %v", reply)
+	}
+	value, _ := fields[0].([]byte)
+	rawVersion, _ := fields[1].([]byte)
+	if rawVersion == nil {
+		return nil, 0, nil
+	}
+
+	version, err := strconv.ParseInt(string(rawVersion), 10, 64)
+	if err != nil {
+		return nil, 0, fmt.Errorf("redis: invalid bucket version %q", rawVersion)
+	}
+	return value, version, nil
+}
+
+// CompareAndSwap stores value if the key is still at version, returning false
+// if another writer got there first; the key expires after ttl
+func (kv *RedisKV) CompareAndSwap(ctx context.Context, key string, value []byte, version int64, ttl time.Duration) (bool, error) {
+	reply, err := kv.do(ctx, "EVAL", redisCASScript, "1", redisKeyPrefix+key,
+		string(value), strconv.FormatInt(version, 10), strconv.FormatInt(ttl.Milliseconds(), 10))
+	if err != nil {
+		return false, err
+	}
+
+	swapped, ok := reply.(int64)
+	if !ok {
+		return false, fmt.Errorf("redis: unexpected EVAL reply %v", reply)
+	}
+	return swapped == 1, nil
+}
+
+// do sends a command on an idle or new connection and reads its reply
+func (kv *RedisKV) do(ctx context.Context, args ...string) (any, error) {
+	conn, err := kv.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(redisTimeout)
+	}
+	if err := conn.conn.SetDeadline(deadline); err != nil {
+		conn.conn.Close()
+		return nil, err
+	}
+
+	reply, err := conn.command(args...)
+	var replyErr redisError
+	if err != nil && !errors.As(err, &replyErr) {
+		// The connection may be mid-reply; don't reuse it
+		conn.conn.Close()
+		return nil, err
+	}
+
+	select {
+	case kv.idle <- conn:
+	default:
+		conn.conn.Close()
+	}
+	return reply, err
+}
+
+// conn returns an idle connection, or dials, authenticates and selects the
+// database on a new one
+func (kv *RedisKV) conn(ctx context.Context) (*redisConn, error) {
+	select {
+	case conn := <-kv.idle:
+		return conn, nil
+	default:
+	}
+
+	var (
+		raw net.Conn
+		err error
+	)
+	if kv.tlsConfig != nil {
+		dialer := tls.Dialer{NetDialer: &kv.dialer, Config: kv.tlsConfig}
+		raw, err = dialer.DialContext(ctx, "tcp", kv.addr)
+	} else {
+		raw, err = kv.dialer.DialContext(ctx, "tcp", kv.addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("redis: failed to connect: %w", err)
+	}
+
+	conn := &redisConn{conn: raw, r: bufio.NewReader(raw)}
+	if err := raw.SetDeadline(time.Now().Add(redisTimeout)); err != nil {
+		raw.Close()
+		return nil, err
+	}
+	if kv.password != "" {
+		if _, err := conn.command("AUTH", kv.password); err != nil {
+			raw.Close()
+			return nil, err
+		}
+	}
+	if kv.db != 0 {
+		if _, err := conn.command("SELECT", strconv.Itoa(kv.db)); err != nil {
+			raw.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+// command writes a command as an array of bulk strings and reads the reply
+func (c *redisConn) command(args ...string) (any, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(c.conn, b.String()); err != nil {
+		return nil, err
+	}
+	return readReply(c.r)
+}
+
+// readReply reads one reply: a simple or bulk string ([]byte, nil when
+// missing), an integer (int64), an array ([]any) or an error (redisError)
+func readReply(r *bufio.Reader) (any, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, errors.New("redis: empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return []byte(line[1:]), nil
+	case '-':
+		return nil, redisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil || size < 0 {
+			return nil, err
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		return data[:size], nil
+	case '*':
+		count, err := strconv.Atoi(line[1:])
+		if err != nil || count < 0 {
+			return nil, err
+		}
+		items := make([]any, count)
+		for i := range items {
+			if items[i], err = readReply(r); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("redis: unexpected reply %q", line)
+	}
+}
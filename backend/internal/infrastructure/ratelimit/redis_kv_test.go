@@ -0,0 +1,154 @@
+package ratelimit
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRedis serves the commands RedisKV sends, keeping buckets as hashes
+type fakeRedis struct {
+	mu       sync.Mutex
+	hashes   map[string]map[string]string
+	ttls     map[string]string
+	commands [][]string
+}
+
+// startFakeRedis listens on a local port and returns the server and its URL
+func startFakeRedis(t *testing.T) (*fakeRedis, string) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	server := &fakeRedis{
+		hashes: make(map[string]map[string]string),
+		ttls:   make(map[string]string),
+	}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+	return server, "redis://:secret@" + listener.Addr().String() + "/2"
+}
+
+func (s *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	for {
+		reply, err := readReply(r)
+		if err != nil {
+			return
+		}
+		var args []string
+		for _, arg := range reply.([]any) {
+			args = append(args, string(arg.([]byte)))
+		}
+		fmt.Fprint(conn, s.handle(args))
+	}
+}
+
+func (s *fakeRedis) handle(args []string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.commands = append(s.commands, args)
+
+	switch args[0] {
+	case "AUTH", "SELECT":
+		return "+OK\r\n"
+	case "HMGET":
+		reply := fmt.Sprintf("*%d\r\n", len(args)-2)
+		for _, field := range args[2:] {
+			value, ok := s.hashes[args[1]][field]
+			if !ok {
+				reply += "$-1\r\n"
+				continue
+			}
+			reply += fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+		}
+		return reply
+	case "EVAL":
+		key, value, version, ttl := args[3], args[4], args[5], args[6]
+		current := s.hashes[key]["n"]
+		if current == "" {
+			current = "0"
+		}
+		if current != version {
+			return ":0\r\n"
+		}
+		next, _ := strconv.Atoi(version)
+		s.hashes[key] = map[string]string{"v": value, "n": strconv.Itoa(next + 1)}
+		s.ttls[key] = ttl
+		return ":1\r\n"
+	default:
+		return "-ERR unknown command\r\n"
+	}
+}
+
+func TestRedisKV_SharedStore(t *testing.T) {
+	server, redisURL := startFakeRedis(t)
+	kv, err := NewRedisKV(redisURL)
+	require.NoError(t, err)
+	store := NewSharedStore(kv)
+	ctx := context.Background()
+	now := time.Now()
+
+	for i := 2; i >= 0; i-- {
+		result, err := store.Take(ctx, "ip:1", testPolicy, now)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, i, result.Remaining)
+	}
+
+	result, err := store.Take(ctx, "ip:1", testPolicy, now)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	assert.Equal(t, []string{"AUTH", "secret"}, server.commands[0])
+	assert.Equal(t, []string{"SELECT", "2"}, server.commands[1])
+	assert.Equal(t, "4", server.hashes["ratelimit:ip:1"]["n"])
+	assert.Equal(t, "4000", server.ttls["ratelimit:ip:1"])
+}
+
+func TestRedisKV_CompareAndSwap_StaleVersion(t *testing.T) {
+	_, redisURL := startFakeRedis(t)
+	kv, err := NewRedisKV(redisURL)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	swapped, err := kv.CompareAndSwap(ctx, "ip:1", []byte("first"), 0, time.Second)
+	require.NoError(t, err)
+	require.True(t, swapped)
+
+	swapped, err = kv.CompareAndSwap(ctx, "ip:1", []byte("second"), 0, time.Second)
+	require.NoError(t, err)
+	assert.False(t, swapped)
+
+	value, version, err := kv.Get(ctx, "ip:1")
+	require.NoError(t, err)
+	assert.Equal(t, "first", string(value))
+	assert.Equal(t, int64(1), version)
+}
+
+func TestNewRedisKV_InvalidURL(t *testing.T) {
+	for _, rawURL := range []string{"http://localhost:6379", "redis://localhost:6379/not-a-db", "::"} {
+		_, err := NewRedisKV(rawURL)
+		assert.Error(t, err, rawURL)
+	}
+}
@@ -0,0 +1,75 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// maxCASAttempts bounds retries when concurrent requests update the same bucket
+const maxCASAttempts = 5
+
+// ErrContention is returned when a bucket could not be updated due to concurrent writers
+var ErrContention = errors.New("rate limit bucket update contention")
+
+// KV is a key-value store with optimistic concurrency control. It can be
+// implemented with DynamoDB conditional writes (version attribute plus TTL)
+// or Redis WATCH/MULTI (or a Lua script) so that limits are shared by every
+// server or Lambda instance.
+type KV interface {
+	// Get returns the value and version of key; version is 0 if the key does not exist
+	Get(ctx context.Context, key string) (value []byte, version int64, err error)
+
+	// CompareAndSwap stores value if the key is still at version, returning false
+	// if another writer got there first. The key should expire after ttl.
+	CompareAndSwap(ctx context.Context, key string, value []byte, version int64, ttl time.Duration) (bool, error)
+}
+
+// SharedStore is a Store on top of a KV shared between instances
+type SharedStore struct {
+	kv KV
+}
+
+// NewSharedStore creates a Store backed by a shared KV
+func NewSharedStore(kv KV) *SharedStore {
+	return &SharedStore{
+		kv: kv,
+	}
+}
+
+// Take atomically takes a token from the bucket for key
+func (s *SharedStore) Take(ctx context.Context, key string, policy Policy, now time.Time) (Result, error) {
+	for attempt := 0; attempt < maxCASAttempts; attempt++ {
+		raw, version, err := s.kv.Get(ctx, key)
+		if err != nil {
+			return Result{}, fmt.Errorf("failed to read rate limit bucket: %w", err)
+		}
+
+		var current bucket
+		if version != 0 {
+			if err := json.Unmarshal(raw, &current); err != nil {
+				return Result{}, fmt.Errorf("failed to decode rate limit bucket: %w", err)
+			}
+		}
+
+		updated, result := current.take(policy, now)
+		encoded, err := json.Marshal(updated)
+		if err != nil {
+			return Result{}, fmt.Errorf("failed to encode rate limit bucket: %w", err)
+		}
+
+		// Keep the item around at least until it would be full again
+		ttl := result.ResetAfter + time.Second
+		swapped, err := s.kv.CompareAndSwap(ctx, key, encoded, version, ttl)
+		if err != nil {
+			return Result{}, fmt.Errorf("failed to write rate limit bucket: %w", err)
+		}
+		if swapped {
+			return result, nil
+		}
+	}
+
+	return Result{}, ErrContention
+}
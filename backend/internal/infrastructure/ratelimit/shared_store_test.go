@@ -0,0 +1,119 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeKV is an in-memory KV with versioned compare-and-swap
+type fakeKV struct {
+	mu       sync.Mutex
+	values   map[string][]byte
+	versions map[string]int64
+	ttls     map[string]time.Duration
+
+	// conflicts makes the next n CompareAndSwap calls lose the race to a
+	// concurrent writer storing the same value
+	conflicts int
+	err       error
+}
+
+func newFakeKV() *fakeKV {
+	return &fakeKV{
+		values:   make(map[string][]byte),
+		versions: make(map[string]int64),
+		ttls:     make(map[string]time.Duration),
+	}
+}
+
+func (kv *fakeKV) Get(ctx context.Context, key string) ([]byte, int64, error) {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+
+	if kv.err != nil {
+		return nil, 0, kv.err
+	}
+	return kv.values[key], kv.versions[key], nil
+}
+
+func (kv *fakeKV) CompareAndSwap(ctx context.Context, key string, value []byte, version int64, ttl time.Duration) (bool, error) {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+
+	if kv.conflicts > 0 {
+		kv.conflicts--
+		kv.values[key] = value
+		kv.versions[key]++
+		return false, nil
+	}
+	if kv.versions[key] != version {
+		return false, nil
+	}
+
+	kv.values[key] = value
+	kv.versions[key] = version + 1
+	kv.ttls[key] = ttl
+	return true, nil
+}
+
+func TestSharedStore_Take_AllowsUpToLimit(t *testing.T) {
+	kv := newFakeKV()
+	store := NewSharedStore(kv)
+	ctx := context.Background()
+	now := time.Now()
+
+	for i := 2; i >= 0; i-- {
+		result, err := store.Take(ctx, "ip:1", testPolicy, now)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, i, result.Remaining)
+	}
+
+	result, err := store.Take(ctx, "ip:1", testPolicy, now)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, time.Second, result.RetryAfter)
+
+	// Items expire once the bucket would be full again
+	assert.Equal(t, 4*time.Second, kv.ttls["ip:1"])
+}
+
+func TestSharedStore_Take_RetriesOnConflict(t *testing.T) {
+	kv := newFakeKV()
+	kv.conflicts = 1
+	store := NewSharedStore(kv)
+
+	result, err := store.Take(context.Background(), "ip:1", testPolicy, time.Now())
+
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	// The retry builds on the bucket written by the concurrent request
+	assert.Equal(t, 1, result.Remaining)
+}
+
+func TestSharedStore_Take_GivesUpUnderContention(t *testing.T) {
+	kv := newFakeKV()
+	kv.conflicts = maxCASAttempts
+	store := NewSharedStore(kv)
+
+	_, err := store.Take(context.Background(), "ip:1", testPolicy, time.Now())
+
+	assert.ErrorIs(t, err, ErrContention)
+}
+
+func TestSharedStore_Take_KVError(t *testing.T) {
+	kv := newFakeKV()
+	kv.err = errors.New("connection refused")
+	store := NewSharedStore(kv)
+
+	_, err := store.Take(context.Background(), "ip:1", testPolicy, time.Now())
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "connection refused")
+}
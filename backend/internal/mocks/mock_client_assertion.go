@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/application/ports/client_assertion.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/application/ports/client_assertion.go -destination=internal/mocks/mock_client_assertion.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	ports "github.com/yuki5155/go-google-auth/internal/application/ports"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockClientAssertionVerifier is a mock of ClientAssertionVerifier interface.
+type MockClientAssertionVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientAssertionVerifierMockRecorder
+	isgomock struct{}
+}
+
+// MockClientAssertionVerifierMockRecorder is the mock recorder for MockClientAssertionVerifier.
+type MockClientAssertionVerifierMockRecorder struct {
+	mock *MockClientAssertionVerifier
+}
+
+// NewMockClientAssertionVerifier creates a new mock instance.
+func NewMockClientAssertionVerifier(ctrl *gomock.Controller) *MockClientAssertionVerifier {
+	mock := &MockClientAssertionVerifier{ctrl: ctrl}
+	mock.recorder = &MockClientAssertionVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClientAssertionVerifier) EXPECT() *MockClientAssertionVerifierMockRecorder {
+	return m.recorder
+}
+
+// Verify mocks base method.
+func (m *MockClientAssertionVerifier) Verify(assertion, clientID, publicKey string) (*ports.ClientAssertion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", assertion, clientID, publicKey)
+	ret0, _ := ret[0].(*ports.ClientAssertion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockClientAssertionVerifierMockRecorder) Verify(assertion, clientID, publicKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockClientAssertionVerifier)(nil).Verify), assertion, clientID, publicKey)
+}
+
+// MockClientAssertionStore is a mock of ClientAssertionStore interface.
+type MockClientAssertionStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientAssertionStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockClientAssertionStoreMockRecorder is the mock recorder for MockClientAssertionStore.
+type MockClientAssertionStoreMockRecorder struct {
+	mock *MockClientAssertionStore
+}
+
+// NewMockClientAssertionStore creates a new mock instance.
+func NewMockClientAssertionStore(ctrl *gomock.Controller) *MockClientAssertionStore {
+	mock := &MockClientAssertionStore{ctrl: ctrl}
+	mock.recorder = &MockClientAssertionStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClientAssertionStore) EXPECT() *MockClientAssertionStoreMockRecorder {
+	return m.recorder
+}
+
+// MarkUsed mocks base method.
+func (m *MockClientAssertionStore) MarkUsed(ctx context.Context, id string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", ctx, id, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockClientAssertionStoreMockRecorder) MarkUsed(ctx, id, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockClientAssertionStore)(nil).MarkUsed), ctx, id, expiresAt)
+}
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/consent/repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/consent/repository.go -destination=internal/mocks/mock_consent_repository.go -package=mocks -mock_names=Repository=MockConsentRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	consent "github.com/yuki5155/go-google-auth/internal/domain/consent"
+	user "github.com/yuki5155/go-google-auth/internal/domain/user"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockConsentRepository is a mock of Repository interface.
+type MockConsentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockConsentRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockConsentRepositoryMockRecorder is the mock recorder for MockConsentRepository.
+type MockConsentRepositoryMockRecorder struct {
+	mock *MockConsentRepository
+}
+
+// NewMockConsentRepository creates a new mock instance.
+func NewMockConsentRepository(ctrl *gomock.Controller) *MockConsentRepository {
+	mock := &MockConsentRepository{ctrl: ctrl}
+	mock.recorder = &MockConsentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConsentRepository) EXPECT() *MockConsentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteByUserID mocks base method.
+func (m *MockConsentRepository) DeleteByUserID(ctx context.Context, userID user.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUserID", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUserID indicates an expected call of DeleteByUserID.
+func (mr *MockConsentRepositoryMockRecorder) DeleteByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserID", reflect.TypeOf((*MockConsentRepository)(nil).DeleteByUserID), ctx, userID)
+}
+
+// Find mocks base method.
+func (m *MockConsentRepository) Find(ctx context.Context, userID user.UserID, clientID string) (*consent.Consent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, userID, clientID)
+	ret0, _ := ret[0].(*consent.Consent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockConsentRepositoryMockRecorder) Find(ctx, userID, clientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockConsentRepository)(nil).Find), ctx, userID, clientID)
+}
+
+// Save mocks base method.
+func (m *MockConsentRepository) Save(ctx context.Context, arg1 *consent.Consent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockConsentRepositoryMockRecorder) Save(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockConsentRepository)(nil).Save), ctx, arg1)
+}
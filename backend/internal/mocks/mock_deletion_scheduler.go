@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/application/ports/deletion_scheduler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/application/ports/deletion_scheduler.go -destination=internal/mocks/mock_deletion_scheduler.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDeletionScheduler is a mock of DeletionScheduler interface.
+type MockDeletionScheduler struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeletionSchedulerMockRecorder
+	isgomock struct{}
+}
+
+// MockDeletionSchedulerMockRecorder is the mock recorder for MockDeletionScheduler.
+type MockDeletionSchedulerMockRecorder struct {
+	mock *MockDeletionScheduler
+}
+
+// NewMockDeletionScheduler creates a new mock instance.
+func NewMockDeletionScheduler(ctrl *gomock.Controller) *MockDeletionScheduler {
+	mock := &MockDeletionScheduler{ctrl: ctrl}
+	mock.recorder = &MockDeletionSchedulerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeletionScheduler) EXPECT() *MockDeletionSchedulerMockRecorder {
+	return m.recorder
+}
+
+// Due mocks base method.
+func (m *MockDeletionScheduler) Due(ctx context.Context, now time.Time) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Due", ctx, now)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Due indicates an expected call of Due.
+func (mr *MockDeletionSchedulerMockRecorder) Due(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Due", reflect.TypeOf((*MockDeletionScheduler)(nil).Due), ctx, now)
+}
+
+// Remove mocks base method.
+func (m *MockDeletionScheduler) Remove(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockDeletionSchedulerMockRecorder) Remove(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockDeletionScheduler)(nil).Remove), ctx, userID)
+}
+
+// Schedule mocks base method.
+func (m *MockDeletionScheduler) Schedule(ctx context.Context, userID string, purgeAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Schedule", ctx, userID, purgeAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Schedule indicates an expected call of Schedule.
+func (mr *MockDeletionSchedulerMockRecorder) Schedule(ctx, userID, purgeAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Schedule", reflect.TypeOf((*MockDeletionScheduler)(nil).Schedule), ctx, userID, purgeAt)
+}
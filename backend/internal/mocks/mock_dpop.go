@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/application/ports/dpop.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/application/ports/dpop.go -destination=internal/mocks/mock_dpop.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	ports "github.com/yuki5155/go-google-auth/internal/application/ports"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDPoPVerifier is a mock of DPoPVerifier interface.
+type MockDPoPVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockDPoPVerifierMockRecorder
+	isgomock struct{}
+}
+
+// MockDPoPVerifierMockRecorder is the mock recorder for MockDPoPVerifier.
+type MockDPoPVerifierMockRecorder struct {
+	mock *MockDPoPVerifier
+}
+
+// NewMockDPoPVerifier creates a new mock instance.
+func NewMockDPoPVerifier(ctrl *gomock.Controller) *MockDPoPVerifier {
+	mock := &MockDPoPVerifier{ctrl: ctrl}
+	mock.recorder = &MockDPoPVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDPoPVerifier) EXPECT() *MockDPoPVerifierMockRecorder {
+	return m.recorder
+}
+
+// Nonce mocks base method.
+func (m *MockDPoPVerifier) Nonce() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Nonce")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Nonce indicates an expected call of Nonce.
+func (mr *MockDPoPVerifierMockRecorder) Nonce() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Nonce", reflect.TypeOf((*MockDPoPVerifier)(nil).Nonce))
+}
+
+// Verify mocks base method.
+func (m *MockDPoPVerifier) Verify(proof string, req ports.DPoPProofRequest) (*ports.DPoPProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", proof, req)
+	ret0, _ := ret[0].(*ports.DPoPProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockDPoPVerifierMockRecorder) Verify(proof, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockDPoPVerifier)(nil).Verify), proof, req)
+}
+
+// MockDPoPReplayCache is a mock of DPoPReplayCache interface.
+type MockDPoPReplayCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockDPoPReplayCacheMockRecorder
+	isgomock struct{}
+}
+
+// MockDPoPReplayCacheMockRecorder is the mock recorder for MockDPoPReplayCache.
+type MockDPoPReplayCacheMockRecorder struct {
+	mock *MockDPoPReplayCache
+}
+
+// NewMockDPoPReplayCache creates a new mock instance.
+func NewMockDPoPReplayCache(ctrl *gomock.Controller) *MockDPoPReplayCache {
+	mock := &MockDPoPReplayCache{ctrl: ctrl}
+	mock.recorder = &MockDPoPReplayCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDPoPReplayCache) EXPECT() *MockDPoPReplayCacheMockRecorder {
+	return m.recorder
+}
+
+// MarkUsed mocks base method.
+func (m *MockDPoPReplayCache) MarkUsed(ctx context.Context, id string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", ctx, id, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockDPoPReplayCacheMockRecorder) MarkUsed(ctx, id, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockDPoPReplayCache)(nil).MarkUsed), ctx, id, expiresAt)
+}
@@ -0,0 +1,150 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/application/ports/login_alert.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/application/ports/login_alert.go -destination=internal/mocks/mock_login_alert.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	ports "github.com/yuki5155/go-google-auth/internal/application/ports"
+	session "github.com/yuki5155/go-google-auth/internal/domain/session"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDeviceFingerprinter is a mock of DeviceFingerprinter interface.
+type MockDeviceFingerprinter struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeviceFingerprinterMockRecorder
+	isgomock struct{}
+}
+
+// MockDeviceFingerprinterMockRecorder is the mock recorder for MockDeviceFingerprinter.
+type MockDeviceFingerprinterMockRecorder struct {
+	mock *MockDeviceFingerprinter
+}
+
+// NewMockDeviceFingerprinter creates a new mock instance.
+func NewMockDeviceFingerprinter(ctrl *gomock.Controller) *MockDeviceFingerprinter {
+	mock := &MockDeviceFingerprinter{ctrl: ctrl}
+	mock.recorder = &MockDeviceFingerprinterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeviceFingerprinter) EXPECT() *MockDeviceFingerprinterMockRecorder {
+	return m.recorder
+}
+
+// Fingerprint mocks base method.
+func (m *MockDeviceFingerprinter) Fingerprint(userAgent, ipAddress string) session.Device {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fingerprint", userAgent, ipAddress)
+	ret0, _ := ret[0].(session.Device)
+	return ret0
+}
+
+// Fingerprint indicates an expected call of Fingerprint.
+func (mr *MockDeviceFingerprinterMockRecorder) Fingerprint(userAgent, ipAddress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fingerprint", reflect.TypeOf((*MockDeviceFingerprinter)(nil).Fingerprint), userAgent, ipAddress)
+}
+
+// MockLoginAlertNotifier is a mock of LoginAlertNotifier interface.
+type MockLoginAlertNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginAlertNotifierMockRecorder
+	isgomock struct{}
+}
+
+// MockLoginAlertNotifierMockRecorder is the mock recorder for MockLoginAlertNotifier.
+type MockLoginAlertNotifierMockRecorder struct {
+	mock *MockLoginAlertNotifier
+}
+
+// NewMockLoginAlertNotifier creates a new mock instance.
+func NewMockLoginAlertNotifier(ctrl *gomock.Controller) *MockLoginAlertNotifier {
+	mock := &MockLoginAlertNotifier{ctrl: ctrl}
+	mock.recorder = &MockLoginAlertNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginAlertNotifier) EXPECT() *MockLoginAlertNotifierMockRecorder {
+	return m.recorder
+}
+
+// NotifySuspiciousLogin mocks base method.
+func (m *MockLoginAlertNotifier) NotifySuspiciousLogin(ctx context.Context, alert ports.LoginAlert) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifySuspiciousLogin", ctx, alert)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifySuspiciousLogin indicates an expected call of NotifySuspiciousLogin.
+func (mr *MockLoginAlertNotifierMockRecorder) NotifySuspiciousLogin(ctx, alert any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifySuspiciousLogin", reflect.TypeOf((*MockLoginAlertNotifier)(nil).NotifySuspiciousLogin), ctx, alert)
+}
+
+// MockRevokeLinkTokenService is a mock of RevokeLinkTokenService interface.
+type MockRevokeLinkTokenService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevokeLinkTokenServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockRevokeLinkTokenServiceMockRecorder is the mock recorder for MockRevokeLinkTokenService.
+type MockRevokeLinkTokenServiceMockRecorder struct {
+	mock *MockRevokeLinkTokenService
+}
+
+// NewMockRevokeLinkTokenService creates a new mock instance.
+func NewMockRevokeLinkTokenService(ctrl *gomock.Controller) *MockRevokeLinkTokenService {
+	mock := &MockRevokeLinkTokenService{ctrl: ctrl}
+	mock.recorder = &MockRevokeLinkTokenServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevokeLinkTokenService) EXPECT() *MockRevokeLinkTokenServiceMockRecorder {
+	return m.recorder
+}
+
+// Generate mocks base method.
+func (m *MockRevokeLinkTokenService) Generate(userID, sessionID string, expiresAt time.Time) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Generate", userID, sessionID, expiresAt)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Generate indicates an expected call of Generate.
+func (mr *MockRevokeLinkTokenServiceMockRecorder) Generate(userID, sessionID, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockRevokeLinkTokenService)(nil).Generate), userID, sessionID, expiresAt)
+}
+
+// Verify mocks base method.
+func (m *MockRevokeLinkTokenService) Verify(token string, now time.Time) (*ports.RevokeLinkClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", token, now)
+	ret0, _ := ret[0].(*ports.RevokeLinkClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockRevokeLinkTokenServiceMockRecorder) Verify(token, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockRevokeLinkTokenService)(nil).Verify), token, now)
+}
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/application/ports/magic_link.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/application/ports/magic_link.go -destination=internal/mocks/mock_magic_link.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	ports "github.com/yuki5155/go-google-auth/internal/application/ports"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMagicLinkTokenService is a mock of MagicLinkTokenService interface.
+type MockMagicLinkTokenService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMagicLinkTokenServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockMagicLinkTokenServiceMockRecorder is the mock recorder for MockMagicLinkTokenService.
+type MockMagicLinkTokenServiceMockRecorder struct {
+	mock *MockMagicLinkTokenService
+}
+
+// NewMockMagicLinkTokenService creates a new mock instance.
+func NewMockMagicLinkTokenService(ctrl *gomock.Controller) *MockMagicLinkTokenService {
+	mock := &MockMagicLinkTokenService{ctrl: ctrl}
+	mock.recorder = &MockMagicLinkTokenServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMagicLinkTokenService) EXPECT() *MockMagicLinkTokenServiceMockRecorder {
+	return m.recorder
+}
+
+// Generate mocks base method.
+func (m *MockMagicLinkTokenService) Generate(email string, expiresAt time.Time) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Generate", email, expiresAt)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Generate indicates an expected call of Generate.
+func (mr *MockMagicLinkTokenServiceMockRecorder) Generate(email, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockMagicLinkTokenService)(nil).Generate), email, expiresAt)
+}
+
+// Verify mocks base method.
+func (m *MockMagicLinkTokenService) Verify(token string, now time.Time) (*ports.MagicLinkClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", token, now)
+	ret0, _ := ret[0].(*ports.MagicLinkClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockMagicLinkTokenServiceMockRecorder) Verify(token, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockMagicLinkTokenService)(nil).Verify), token, now)
+}
+
+// MockMagicLinkStore is a mock of MagicLinkStore interface.
+type MockMagicLinkStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockMagicLinkStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockMagicLinkStoreMockRecorder is the mock recorder for MockMagicLinkStore.
+type MockMagicLinkStoreMockRecorder struct {
+	mock *MockMagicLinkStore
+}
+
+// NewMockMagicLinkStore creates a new mock instance.
+func NewMockMagicLinkStore(ctrl *gomock.Controller) *MockMagicLinkStore {
+	mock := &MockMagicLinkStore{ctrl: ctrl}
+	mock.recorder = &MockMagicLinkStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMagicLinkStore) EXPECT() *MockMagicLinkStoreMockRecorder {
+	return m.recorder
+}
+
+// MarkUsed mocks base method.
+func (m *MockMagicLinkStore) MarkUsed(ctx context.Context, id string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", ctx, id, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockMagicLinkStoreMockRecorder) MarkUsed(ctx, id, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockMagicLinkStore)(nil).MarkUsed), ctx, id, expiresAt)
+}
@@ -0,0 +1,164 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/application/ports/oidc.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/application/ports/oidc.go -destination=internal/mocks/mock_oidc.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	ports "github.com/yuki5155/go-google-auth/internal/application/ports"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuthorizationGrantTokens is a mock of AuthorizationGrantTokens interface.
+type MockAuthorizationGrantTokens struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorizationGrantTokensMockRecorder
+	isgomock struct{}
+}
+
+// MockAuthorizationGrantTokensMockRecorder is the mock recorder for MockAuthorizationGrantTokens.
+type MockAuthorizationGrantTokensMockRecorder struct {
+	mock *MockAuthorizationGrantTokens
+}
+
+// NewMockAuthorizationGrantTokens creates a new mock instance.
+func NewMockAuthorizationGrantTokens(ctrl *gomock.Controller) *MockAuthorizationGrantTokens {
+	mock := &MockAuthorizationGrantTokens{ctrl: ctrl}
+	mock.recorder = &MockAuthorizationGrantTokensMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorizationGrantTokens) EXPECT() *MockAuthorizationGrantTokensMockRecorder {
+	return m.recorder
+}
+
+// Open mocks base method.
+func (m *MockAuthorizationGrantTokens) Open(token string, now time.Time) (*ports.AuthorizationGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", token, now)
+	ret0, _ := ret[0].(*ports.AuthorizationGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockAuthorizationGrantTokensMockRecorder) Open(token, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockAuthorizationGrantTokens)(nil).Open), token, now)
+}
+
+// Seal mocks base method.
+func (m *MockAuthorizationGrantTokens) Seal(grant ports.AuthorizationGrant) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Seal", grant)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Seal indicates an expected call of Seal.
+func (mr *MockAuthorizationGrantTokensMockRecorder) Seal(grant any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Seal", reflect.TypeOf((*MockAuthorizationGrantTokens)(nil).Seal), grant)
+}
+
+// MockAuthorizationCodeStore is a mock of AuthorizationCodeStore interface.
+type MockAuthorizationCodeStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorizationCodeStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockAuthorizationCodeStoreMockRecorder is the mock recorder for MockAuthorizationCodeStore.
+type MockAuthorizationCodeStoreMockRecorder struct {
+	mock *MockAuthorizationCodeStore
+}
+
+// NewMockAuthorizationCodeStore creates a new mock instance.
+func NewMockAuthorizationCodeStore(ctrl *gomock.Controller) *MockAuthorizationCodeStore {
+	mock := &MockAuthorizationCodeStore{ctrl: ctrl}
+	mock.recorder = &MockAuthorizationCodeStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorizationCodeStore) EXPECT() *MockAuthorizationCodeStoreMockRecorder {
+	return m.recorder
+}
+
+// MarkUsed mocks base method.
+func (m *MockAuthorizationCodeStore) MarkUsed(ctx context.Context, id string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", ctx, id, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockAuthorizationCodeStoreMockRecorder) MarkUsed(ctx, id, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockAuthorizationCodeStore)(nil).MarkUsed), ctx, id, expiresAt)
+}
+
+// MockIDTokenIssuer is a mock of IDTokenIssuer interface.
+type MockIDTokenIssuer struct {
+	ctrl     *gomock.Controller
+	recorder *MockIDTokenIssuerMockRecorder
+	isgomock struct{}
+}
+
+// MockIDTokenIssuerMockRecorder is the mock recorder for MockIDTokenIssuer.
+type MockIDTokenIssuerMockRecorder struct {
+	mock *MockIDTokenIssuer
+}
+
+// NewMockIDTokenIssuer creates a new mock instance.
+func NewMockIDTokenIssuer(ctrl *gomock.Controller) *MockIDTokenIssuer {
+	mock := &MockIDTokenIssuer{ctrl: ctrl}
+	mock.recorder = &MockIDTokenIssuerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIDTokenIssuer) EXPECT() *MockIDTokenIssuerMockRecorder {
+	return m.recorder
+}
+
+// IssueIDToken mocks base method.
+func (m *MockIDTokenIssuer) IssueIDToken(claims ports.IDTokenClaims) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueIDToken", claims)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueIDToken indicates an expected call of IssueIDToken.
+func (mr *MockIDTokenIssuerMockRecorder) IssueIDToken(claims any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueIDToken", reflect.TypeOf((*MockIDTokenIssuer)(nil).IssueIDToken), claims)
+}
+
+// PublicKeys mocks base method.
+func (m *MockIDTokenIssuer) PublicKeys() []ports.JSONWebKey {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublicKeys")
+	ret0, _ := ret[0].([]ports.JSONWebKey)
+	return ret0
+}
+
+// PublicKeys indicates an expected call of PublicKeys.
+func (mr *MockIDTokenIssuerMockRecorder) PublicKeys() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublicKeys", reflect.TypeOf((*MockIDTokenIssuer)(nil).PublicKeys))
+}
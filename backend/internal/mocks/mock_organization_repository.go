@@ -0,0 +1,294 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/organization/repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/organization/repository.go -destination=internal/mocks/mock_organization_repository.go -package=mocks -mock_names=Repository=MockOrganizationRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	organization "github.com/yuki5155/go-google-auth/internal/domain/organization"
+	user "github.com/yuki5155/go-google-auth/internal/domain/user"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOrganizationRepository is a mock of Repository interface.
+type MockOrganizationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOrganizationRepositoryMockRecorder is the mock recorder for MockOrganizationRepository.
+type MockOrganizationRepositoryMockRecorder struct {
+	mock *MockOrganizationRepository
+}
+
+// NewMockOrganizationRepository creates a new mock instance.
+func NewMockOrganizationRepository(ctrl *gomock.Controller) *MockOrganizationRepository {
+	mock := &MockOrganizationRepository{ctrl: ctrl}
+	mock.recorder = &MockOrganizationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganizationRepository) EXPECT() *MockOrganizationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockOrganizationRepository) Delete(ctx context.Context, id organization.OrganizationID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockOrganizationRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockOrganizationRepository)(nil).Delete), ctx, id)
+}
+
+// FindByID mocks base method.
+func (m *MockOrganizationRepository) FindByID(ctx context.Context, id organization.OrganizationID) (*organization.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*organization.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockOrganizationRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockOrganizationRepository)(nil).FindByID), ctx, id)
+}
+
+// Save mocks base method.
+func (m *MockOrganizationRepository) Save(ctx context.Context, org *organization.Organization) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, org)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockOrganizationRepositoryMockRecorder) Save(ctx, org any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockOrganizationRepository)(nil).Save), ctx, org)
+}
+
+// MockMembershipRepository is a mock of MembershipRepository interface.
+type MockMembershipRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMembershipRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockMembershipRepositoryMockRecorder is the mock recorder for MockMembershipRepository.
+type MockMembershipRepositoryMockRecorder struct {
+	mock *MockMembershipRepository
+}
+
+// NewMockMembershipRepository creates a new mock instance.
+func NewMockMembershipRepository(ctrl *gomock.Controller) *MockMembershipRepository {
+	mock := &MockMembershipRepository{ctrl: ctrl}
+	mock.recorder = &MockMembershipRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMembershipRepository) EXPECT() *MockMembershipRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockMembershipRepository) Delete(ctx context.Context, orgID organization.OrganizationID, userID user.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, orgID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMembershipRepositoryMockRecorder) Delete(ctx, orgID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMembershipRepository)(nil).Delete), ctx, orgID, userID)
+}
+
+// DeleteByUserID mocks base method.
+func (m *MockMembershipRepository) DeleteByUserID(ctx context.Context, userID user.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUserID", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUserID indicates an expected call of DeleteByUserID.
+func (mr *MockMembershipRepositoryMockRecorder) DeleteByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserID", reflect.TypeOf((*MockMembershipRepository)(nil).DeleteByUserID), ctx, userID)
+}
+
+// Find mocks base method.
+func (m *MockMembershipRepository) Find(ctx context.Context, orgID organization.OrganizationID, userID user.UserID) (*organization.Membership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, orgID, userID)
+	ret0, _ := ret[0].(*organization.Membership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockMembershipRepositoryMockRecorder) Find(ctx, orgID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockMembershipRepository)(nil).Find), ctx, orgID, userID)
+}
+
+// FindByOrganization mocks base method.
+func (m *MockMembershipRepository) FindByOrganization(ctx context.Context, orgID organization.OrganizationID) ([]*organization.Membership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByOrganization", ctx, orgID)
+	ret0, _ := ret[0].([]*organization.Membership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByOrganization indicates an expected call of FindByOrganization.
+func (mr *MockMembershipRepositoryMockRecorder) FindByOrganization(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByOrganization", reflect.TypeOf((*MockMembershipRepository)(nil).FindByOrganization), ctx, orgID)
+}
+
+// FindByUserID mocks base method.
+func (m *MockMembershipRepository) FindByUserID(ctx context.Context, userID user.UserID) ([]*organization.Membership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserID", ctx, userID)
+	ret0, _ := ret[0].([]*organization.Membership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserID indicates an expected call of FindByUserID.
+func (mr *MockMembershipRepositoryMockRecorder) FindByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockMembershipRepository)(nil).FindByUserID), ctx, userID)
+}
+
+// Save mocks base method.
+func (m *MockMembershipRepository) Save(ctx context.Context, membership *organization.Membership) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, membership)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockMembershipRepositoryMockRecorder) Save(ctx, membership any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockMembershipRepository)(nil).Save), ctx, membership)
+}
+
+// MockInvitationRepository is a mock of InvitationRepository interface.
+type MockInvitationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvitationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockInvitationRepositoryMockRecorder is the mock recorder for MockInvitationRepository.
+type MockInvitationRepositoryMockRecorder struct {
+	mock *MockInvitationRepository
+}
+
+// NewMockInvitationRepository creates a new mock instance.
+func NewMockInvitationRepository(ctrl *gomock.Controller) *MockInvitationRepository {
+	mock := &MockInvitationRepository{ctrl: ctrl}
+	mock.recorder = &MockInvitationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvitationRepository) EXPECT() *MockInvitationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockInvitationRepository) Delete(ctx context.Context, id organization.InvitationID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockInvitationRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockInvitationRepository)(nil).Delete), ctx, id)
+}
+
+// FindByHash mocks base method.
+func (m *MockInvitationRepository) FindByHash(ctx context.Context, hash string) (*organization.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByHash", ctx, hash)
+	ret0, _ := ret[0].(*organization.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByHash indicates an expected call of FindByHash.
+func (mr *MockInvitationRepositoryMockRecorder) FindByHash(ctx, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByHash", reflect.TypeOf((*MockInvitationRepository)(nil).FindByHash), ctx, hash)
+}
+
+// FindByID mocks base method.
+func (m *MockInvitationRepository) FindByID(ctx context.Context, orgID organization.OrganizationID, id organization.InvitationID) (*organization.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, orgID, id)
+	ret0, _ := ret[0].(*organization.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockInvitationRepositoryMockRecorder) FindByID(ctx, orgID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockInvitationRepository)(nil).FindByID), ctx, orgID, id)
+}
+
+// FindByOrganization mocks base method.
+func (m *MockInvitationRepository) FindByOrganization(ctx context.Context, orgID organization.OrganizationID) ([]*organization.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByOrganization", ctx, orgID)
+	ret0, _ := ret[0].([]*organization.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByOrganization indicates an expected call of FindByOrganization.
+func (mr *MockInvitationRepositoryMockRecorder) FindByOrganization(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByOrganization", reflect.TypeOf((*MockInvitationRepository)(nil).FindByOrganization), ctx, orgID)
+}
+
+// Save mocks base method.
+func (m *MockInvitationRepository) Save(ctx context.Context, invitation *organization.Invitation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, invitation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockInvitationRepositoryMockRecorder) Save(ctx, invitation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockInvitationRepository)(nil).Save), ctx, invitation)
+}
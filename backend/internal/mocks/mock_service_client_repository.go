@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/serviceclient/repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/serviceclient/repository.go -destination=internal/mocks/mock_service_client_repository.go -package=mocks -mock_names=Repository=MockServiceClientRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	serviceclient "github.com/yuki5155/go-google-auth/internal/domain/serviceclient"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockServiceClientRepository is a mock of Repository interface.
+type MockServiceClientRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceClientRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceClientRepositoryMockRecorder is the mock recorder for MockServiceClientRepository.
+type MockServiceClientRepositoryMockRecorder struct {
+	mock *MockServiceClientRepository
+}
+
+// NewMockServiceClientRepository creates a new mock instance.
+func NewMockServiceClientRepository(ctrl *gomock.Controller) *MockServiceClientRepository {
+	mock := &MockServiceClientRepository{ctrl: ctrl}
+	mock.recorder = &MockServiceClientRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceClientRepository) EXPECT() *MockServiceClientRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByID mocks base method.
+func (m *MockServiceClientRepository) FindByID(ctx context.Context, id string) (*serviceclient.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*serviceclient.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockServiceClientRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockServiceClientRepository)(nil).FindByID), ctx, id)
+}
+
+// Save mocks base method.
+func (m *MockServiceClientRepository) Save(ctx context.Context, client *serviceclient.Client) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, client)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockServiceClientRepositoryMockRecorder) Save(ctx, client any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockServiceClientRepository)(nil).Save), ctx, client)
+}
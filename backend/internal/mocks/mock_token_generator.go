@@ -11,6 +11,7 @@ package mocks
 
 import (
 	reflect "reflect"
+	time "time"
 
 	ports "github.com/yuki5155/go-google-auth/internal/application/ports"
 	gomock "go.uber.org/mock/gomock"
@@ -40,6 +41,81 @@ func (m *MockTokenGenerator) EXPECT() *MockTokenGeneratorMockRecorder {
 	return m.recorder
 }
 
+// GenerateClientToken mocks base method.
+func (m *MockTokenGenerator) GenerateClientToken(clientID string, scopes []string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateClientToken", clientID, scopes)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateClientToken indicates an expected call of GenerateClientToken.
+func (mr *MockTokenGeneratorMockRecorder) GenerateClientToken(clientID, scopes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateClientToken", reflect.TypeOf((*MockTokenGenerator)(nil).GenerateClientToken), clientID, scopes)
+}
+
+// GenerateDelegatedToken mocks base method.
+func (m *MockTokenGenerator) GenerateDelegatedToken(user ports.UserInfo, clientID string, scopes []string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateDelegatedToken", user, clientID, scopes)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateDelegatedToken indicates an expected call of GenerateDelegatedToken.
+func (mr *MockTokenGeneratorMockRecorder) GenerateDelegatedToken(user, clientID, scopes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateDelegatedToken", reflect.TypeOf((*MockTokenGenerator)(nil).GenerateDelegatedToken), user, clientID, scopes)
+}
+
+// GenerateExchangedToken mocks base method.
+func (m *MockTokenGenerator) GenerateExchangedToken(token ports.ExchangedToken) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateExchangedToken", token)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateExchangedToken indicates an expected call of GenerateExchangedToken.
+func (mr *MockTokenGeneratorMockRecorder) GenerateExchangedToken(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateExchangedToken", reflect.TypeOf((*MockTokenGenerator)(nil).GenerateExchangedToken), token)
+}
+
+// GenerateImpersonationToken mocks base method.
+func (m *MockTokenGenerator) GenerateImpersonationToken(user ports.UserInfo, adminID string, expiresAt time.Time) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateImpersonationToken", user, adminID, expiresAt)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateImpersonationToken indicates an expected call of GenerateImpersonationToken.
+func (mr *MockTokenGeneratorMockRecorder) GenerateImpersonationToken(user, adminID, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateImpersonationToken", reflect.TypeOf((*MockTokenGenerator)(nil).GenerateImpersonationToken), user, adminID, expiresAt)
+}
+
+// GenerateMFAToken mocks base method.
+func (m *MockTokenGenerator) GenerateMFAToken(userID string, amr []string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateMFAToken", userID, amr)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateMFAToken indicates an expected call of GenerateMFAToken.
+func (mr *MockTokenGeneratorMockRecorder) GenerateMFAToken(userID, amr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateMFAToken", reflect.TypeOf((*MockTokenGenerator)(nil).GenerateMFAToken), userID, amr)
+}
+
 // GenerateTokenPair mocks base method.
 func (m *MockTokenGenerator) GenerateTokenPair(userInfo ports.UserInfo) (string, string, error) {
 	m.ctrl.T.Helper()
@@ -70,6 +146,20 @@ func (mr *MockTokenGeneratorMockRecorder) GetAccessTokenExpiry() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccessTokenExpiry", reflect.TypeOf((*MockTokenGenerator)(nil).GetAccessTokenExpiry))
 }
 
+// GetMFATokenExpiry mocks base method.
+func (m *MockTokenGenerator) GetMFATokenExpiry() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMFATokenExpiry")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetMFATokenExpiry indicates an expected call of GetMFATokenExpiry.
+func (mr *MockTokenGeneratorMockRecorder) GetMFATokenExpiry() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMFATokenExpiry", reflect.TypeOf((*MockTokenGenerator)(nil).GetMFATokenExpiry))
+}
+
 // GetRefreshTokenExpiry mocks base method.
 func (m *MockTokenGenerator) GetRefreshTokenExpiry() int {
 	m.ctrl.T.Helper()
@@ -85,18 +175,18 @@ func (mr *MockTokenGeneratorMockRecorder) GetRefreshTokenExpiry() *gomock.Call {
 }
 
 // RefreshAccessToken mocks base method.
-func (m *MockTokenGenerator) RefreshAccessToken(refreshToken string) (string, error) {
+func (m *MockTokenGenerator) RefreshAccessToken(refreshToken, dpopKey string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RefreshAccessToken", refreshToken)
+	ret := m.ctrl.Call(m, "RefreshAccessToken", refreshToken, dpopKey)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RefreshAccessToken indicates an expected call of RefreshAccessToken.
-func (mr *MockTokenGeneratorMockRecorder) RefreshAccessToken(refreshToken any) *gomock.Call {
+func (mr *MockTokenGeneratorMockRecorder) RefreshAccessToken(refreshToken, dpopKey any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshAccessToken", reflect.TypeOf((*MockTokenGenerator)(nil).RefreshAccessToken), refreshToken)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshAccessToken", reflect.TypeOf((*MockTokenGenerator)(nil).RefreshAccessToken), refreshToken, dpopKey)
 }
 
 // ValidateAccessToken mocks base method.
@@ -113,3 +203,48 @@ func (mr *MockTokenGeneratorMockRecorder) ValidateAccessToken(accessToken any) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateAccessToken", reflect.TypeOf((*MockTokenGenerator)(nil).ValidateAccessToken), accessToken)
 }
+
+// ValidateIssuedAccessToken mocks base method.
+func (m *MockTokenGenerator) ValidateIssuedAccessToken(accessToken string) (*ports.TokenClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateIssuedAccessToken", accessToken)
+	ret0, _ := ret[0].(*ports.TokenClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateIssuedAccessToken indicates an expected call of ValidateIssuedAccessToken.
+func (mr *MockTokenGeneratorMockRecorder) ValidateIssuedAccessToken(accessToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateIssuedAccessToken", reflect.TypeOf((*MockTokenGenerator)(nil).ValidateIssuedAccessToken), accessToken)
+}
+
+// ValidateMFAToken mocks base method.
+func (m *MockTokenGenerator) ValidateMFAToken(mfaToken string) (*ports.TokenClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateMFAToken", mfaToken)
+	ret0, _ := ret[0].(*ports.TokenClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateMFAToken indicates an expected call of ValidateMFAToken.
+func (mr *MockTokenGeneratorMockRecorder) ValidateMFAToken(mfaToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateMFAToken", reflect.TypeOf((*MockTokenGenerator)(nil).ValidateMFAToken), mfaToken)
+}
+
+// ValidateRefreshToken mocks base method.
+func (m *MockTokenGenerator) ValidateRefreshToken(refreshToken string) (*ports.TokenClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateRefreshToken", refreshToken)
+	ret0, _ := ret[0].(*ports.TokenClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateRefreshToken indicates an expected call of ValidateRefreshToken.
+func (mr *MockTokenGeneratorMockRecorder) ValidateRefreshToken(refreshToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateRefreshToken", reflect.TypeOf((*MockTokenGenerator)(nil).ValidateRefreshToken), refreshToken)
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/application/ports/token_revocation.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/application/ports/token_revocation.go -destination=internal/mocks/mock_token_revocation.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRevokedTokenStore is a mock of RevokedTokenStore interface.
+type MockRevokedTokenStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevokedTokenStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockRevokedTokenStoreMockRecorder is the mock recorder for MockRevokedTokenStore.
+type MockRevokedTokenStoreMockRecorder struct {
+	mock *MockRevokedTokenStore
+}
+
+// NewMockRevokedTokenStore creates a new mock instance.
+func NewMockRevokedTokenStore(ctrl *gomock.Controller) *MockRevokedTokenStore {
+	mock := &MockRevokedTokenStore{ctrl: ctrl}
+	mock.recorder = &MockRevokedTokenStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevokedTokenStore) EXPECT() *MockRevokedTokenStoreMockRecorder {
+	return m.recorder
+}
+
+// IsRevoked mocks base method.
+func (m *MockRevokedTokenStore) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsRevoked", ctx, tokenID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsRevoked indicates an expected call of IsRevoked.
+func (mr *MockRevokedTokenStoreMockRecorder) IsRevoked(ctx, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRevoked", reflect.TypeOf((*MockRevokedTokenStore)(nil).IsRevoked), ctx, tokenID)
+}
+
+// Revoke mocks base method.
+func (m *MockRevokedTokenStore) Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, tokenID, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockRevokedTokenStoreMockRecorder) Revoke(ctx, tokenID, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockRevokedTokenStore)(nil).Revoke), ctx, tokenID, expiresAt)
+}
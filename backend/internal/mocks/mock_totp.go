@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/application/ports/totp.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/application/ports/totp.go -destination=internal/mocks/mock_totp.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTOTPService is a mock of TOTPService interface.
+type MockTOTPService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTOTPServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockTOTPServiceMockRecorder is the mock recorder for MockTOTPService.
+type MockTOTPServiceMockRecorder struct {
+	mock *MockTOTPService
+}
+
+// NewMockTOTPService creates a new mock instance.
+func NewMockTOTPService(ctrl *gomock.Controller) *MockTOTPService {
+	mock := &MockTOTPService{ctrl: ctrl}
+	mock.recorder = &MockTOTPServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTOTPService) EXPECT() *MockTOTPServiceMockRecorder {
+	return m.recorder
+}
+
+// EncodeSecret mocks base method.
+func (m *MockTOTPService) EncodeSecret(secret []byte) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EncodeSecret", secret)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// EncodeSecret indicates an expected call of EncodeSecret.
+func (mr *MockTOTPServiceMockRecorder) EncodeSecret(secret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EncodeSecret", reflect.TypeOf((*MockTOTPService)(nil).EncodeSecret), secret)
+}
+
+// GenerateSecret mocks base method.
+func (m *MockTOTPService) GenerateSecret() ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateSecret")
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateSecret indicates an expected call of GenerateSecret.
+func (mr *MockTOTPServiceMockRecorder) GenerateSecret() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateSecret", reflect.TypeOf((*MockTOTPService)(nil).GenerateSecret))
+}
+
+// ProvisioningURI mocks base method.
+func (m *MockTOTPService) ProvisioningURI(secret []byte, accountName string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProvisioningURI", secret, accountName)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ProvisioningURI indicates an expected call of ProvisioningURI.
+func (mr *MockTOTPServiceMockRecorder) ProvisioningURI(secret, accountName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProvisioningURI", reflect.TypeOf((*MockTOTPService)(nil).ProvisioningURI), secret, accountName)
+}
+
+// Validate mocks base method.
+func (m *MockTOTPService) Validate(secret []byte, code string, at time.Time) (int64, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", secret, code, at)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockTOTPServiceMockRecorder) Validate(secret, code, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockTOTPService)(nil).Validate), secret, code, at)
+}
@@ -100,6 +100,21 @@ func (mr *MockRepositoryMockRecorder) FindByEmail(ctx, email any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmail", reflect.TypeOf((*MockRepository)(nil).FindByEmail), ctx, email)
 }
 
+// FindByGoogleID mocks base method.
+func (m *MockRepository) FindByGoogleID(ctx context.Context, googleID string) (*user.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByGoogleID", ctx, googleID)
+	ret0, _ := ret[0].(*user.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByGoogleID indicates an expected call of FindByGoogleID.
+func (mr *MockRepositoryMockRecorder) FindByGoogleID(ctx, googleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByGoogleID", reflect.TypeOf((*MockRepository)(nil).FindByGoogleID), ctx, googleID)
+}
+
 // FindByID mocks base method.
 func (m *MockRepository) FindByID(ctx context.Context, id user.UserID) (*user.User, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,197 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/application/ports/webauthn.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/application/ports/webauthn.go -destination=internal/mocks/mock_webauthn.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	ports "github.com/yuki5155/go-google-auth/internal/application/ports"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebAuthnChallengeStore is a mock of WebAuthnChallengeStore interface.
+type MockWebAuthnChallengeStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebAuthnChallengeStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockWebAuthnChallengeStoreMockRecorder is the mock recorder for MockWebAuthnChallengeStore.
+type MockWebAuthnChallengeStoreMockRecorder struct {
+	mock *MockWebAuthnChallengeStore
+}
+
+// NewMockWebAuthnChallengeStore creates a new mock instance.
+func NewMockWebAuthnChallengeStore(ctrl *gomock.Controller) *MockWebAuthnChallengeStore {
+	mock := &MockWebAuthnChallengeStore{ctrl: ctrl}
+	mock.recorder = &MockWebAuthnChallengeStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebAuthnChallengeStore) EXPECT() *MockWebAuthnChallengeStoreMockRecorder {
+	return m.recorder
+}
+
+// Consume mocks base method.
+func (m *MockWebAuthnChallengeStore) Consume(ctx context.Context, challenge string) (*ports.WebAuthnChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Consume", ctx, challenge)
+	ret0, _ := ret[0].(*ports.WebAuthnChallenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Consume indicates an expected call of Consume.
+func (mr *MockWebAuthnChallengeStoreMockRecorder) Consume(ctx, challenge any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consume", reflect.TypeOf((*MockWebAuthnChallengeStore)(nil).Consume), ctx, challenge)
+}
+
+// Save mocks base method.
+func (m *MockWebAuthnChallengeStore) Save(ctx context.Context, challenge ports.WebAuthnChallenge) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, challenge)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockWebAuthnChallengeStoreMockRecorder) Save(ctx, challenge any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockWebAuthnChallengeStore)(nil).Save), ctx, challenge)
+}
+
+// MockWebAuthnVerifier is a mock of WebAuthnVerifier interface.
+type MockWebAuthnVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebAuthnVerifierMockRecorder
+	isgomock struct{}
+}
+
+// MockWebAuthnVerifierMockRecorder is the mock recorder for MockWebAuthnVerifier.
+type MockWebAuthnVerifierMockRecorder struct {
+	mock *MockWebAuthnVerifier
+}
+
+// NewMockWebAuthnVerifier creates a new mock instance.
+func NewMockWebAuthnVerifier(ctrl *gomock.Controller) *MockWebAuthnVerifier {
+	mock := &MockWebAuthnVerifier{ctrl: ctrl}
+	mock.recorder = &MockWebAuthnVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebAuthnVerifier) EXPECT() *MockWebAuthnVerifierMockRecorder {
+	return m.recorder
+}
+
+// Algorithms mocks base method.
+func (m *MockWebAuthnVerifier) Algorithms() []int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Algorithms")
+	ret0, _ := ret[0].([]int)
+	return ret0
+}
+
+// Algorithms indicates an expected call of Algorithms.
+func (mr *MockWebAuthnVerifierMockRecorder) Algorithms() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Algorithms", reflect.TypeOf((*MockWebAuthnVerifier)(nil).Algorithms))
+}
+
+// ClientChallenge mocks base method.
+func (m *MockWebAuthnVerifier) ClientChallenge(clientDataJSON []byte) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClientChallenge", clientDataJSON)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClientChallenge indicates an expected call of ClientChallenge.
+func (mr *MockWebAuthnVerifierMockRecorder) ClientChallenge(clientDataJSON any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientChallenge", reflect.TypeOf((*MockWebAuthnVerifier)(nil).ClientChallenge), clientDataJSON)
+}
+
+// NewChallenge mocks base method.
+func (m *MockWebAuthnVerifier) NewChallenge() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewChallenge")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewChallenge indicates an expected call of NewChallenge.
+func (mr *MockWebAuthnVerifierMockRecorder) NewChallenge() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewChallenge", reflect.TypeOf((*MockWebAuthnVerifier)(nil).NewChallenge))
+}
+
+// RelyingPartyID mocks base method.
+func (m *MockWebAuthnVerifier) RelyingPartyID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RelyingPartyID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// RelyingPartyID indicates an expected call of RelyingPartyID.
+func (mr *MockWebAuthnVerifierMockRecorder) RelyingPartyID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelyingPartyID", reflect.TypeOf((*MockWebAuthnVerifier)(nil).RelyingPartyID))
+}
+
+// RelyingPartyName mocks base method.
+func (m *MockWebAuthnVerifier) RelyingPartyName() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RelyingPartyName")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// RelyingPartyName indicates an expected call of RelyingPartyName.
+func (mr *MockWebAuthnVerifierMockRecorder) RelyingPartyName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelyingPartyName", reflect.TypeOf((*MockWebAuthnVerifier)(nil).RelyingPartyName))
+}
+
+// VerifyAssertion mocks base method.
+func (m *MockWebAuthnVerifier) VerifyAssertion(assertion ports.WebAuthnAssertion, challenge string, publicKey []byte, requireUserVerification bool) (*ports.WebAuthnAssertionResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyAssertion", assertion, challenge, publicKey, requireUserVerification)
+	ret0, _ := ret[0].(*ports.WebAuthnAssertionResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyAssertion indicates an expected call of VerifyAssertion.
+func (mr *MockWebAuthnVerifierMockRecorder) VerifyAssertion(assertion, challenge, publicKey, requireUserVerification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyAssertion", reflect.TypeOf((*MockWebAuthnVerifier)(nil).VerifyAssertion), assertion, challenge, publicKey, requireUserVerification)
+}
+
+// VerifyRegistration mocks base method.
+func (m *MockWebAuthnVerifier) VerifyRegistration(attestation ports.WebAuthnAttestation, challenge string, requireUserVerification bool) (*ports.WebAuthnCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyRegistration", attestation, challenge, requireUserVerification)
+	ret0, _ := ret[0].(*ports.WebAuthnCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyRegistration indicates an expected call of VerifyRegistration.
+func (mr *MockWebAuthnVerifierMockRecorder) VerifyRegistration(attestation, challenge, requireUserVerification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyRegistration", reflect.TypeOf((*MockWebAuthnVerifier)(nil).VerifyRegistration), attestation, challenge, requireUserVerification)
+}
@@ -0,0 +1,36 @@
+// Package clientip determines the address a request came from. Rate limits
+// and login alert fingerprints key on it, so forwarding headers such as
+// X-Forwarded-For are only believed when sent by a configured proxy.
+package clientip
+
+import (
+	"log"
+	"net"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Configure makes the router trust forwarding headers only from proxies,
+// a list of IP addresses and CIDR ranges; an empty list trusts none. On
+// Lambda, API Gateway passes the caller's address as the remote address,
+// so no proxy needs to be trusted there.
+func Configure(r *gin.Engine, proxies []string) {
+	if err := r.SetTrustedProxies(proxies); err != nil {
+		log.Printf("WARNING: invalid TRUSTED_PROXIES (%v); forwarding headers are ignored", err)
+		_ = r.SetTrustedProxies(nil)
+	}
+}
+
+// FromContext returns the client IP address of a request. The remote
+// address may lack a port, as API Gateway requests do.
+func FromContext(c *gin.Context) string {
+	if ip := c.ClientIP(); ip != "" {
+		return ip
+	}
+
+	if ip := net.ParseIP(strings.TrimSpace(c.Request.RemoteAddr)); ip != nil {
+		return ip.String()
+	}
+	return ""
+}
@@ -0,0 +1,54 @@
+package clientip
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// clientIP runs a request through a router configured with proxies and
+// returns the address FromContext reports
+func clientIP(proxies []string, remoteAddr, forwardedFor string) string {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	Configure(r, proxies)
+
+	var ip string
+	r.GET("/", func(c *gin.Context) {
+		ip = FromContext(c)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = remoteAddr
+	if forwardedFor != "" {
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	return ip
+}
+
+func TestFromContext_IgnoresSpoofedForwardedFor(t *testing.T) {
+	assert.Equal(t, "203.0.113.7", clientIP(nil, "203.0.113.7:51234", ""))
+	assert.Equal(t, "203.0.113.7", clientIP(nil, "203.0.113.7:51234", "198.51.100.1"))
+	assert.Equal(t, "203.0.113.7", clientIP(nil, "203.0.113.7:51234", "198.51.100.2, 10.0.0.1"))
+}
+
+func TestFromContext_TrustedProxy(t *testing.T) {
+	proxies := []string{"10.0.0.0/8"}
+
+	assert.Equal(t, "198.51.100.1", clientIP(proxies, "10.0.0.5:443", "198.51.100.1"))
+	assert.Equal(t, "203.0.113.7", clientIP(proxies, "203.0.113.7:51234", "198.51.100.1"))
+}
+
+func TestFromContext_RemoteAddrWithoutPort(t *testing.T) {
+	// API Gateway requests carry the caller's address without a port
+	assert.Equal(t, "203.0.113.7", clientIP(nil, "203.0.113.7", "198.51.100.1"))
+	assert.Equal(t, "2001:db8::1", clientIP(nil, "2001:db8::1", ""))
+}
+
+func TestConfigure_InvalidProxiesTrustsNone(t *testing.T) {
+	assert.Equal(t, "203.0.113.7", clientIP([]string{"not-an-ip"}, "203.0.113.7:51234", "198.51.100.1"))
+}
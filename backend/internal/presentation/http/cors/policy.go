@@ -0,0 +1,293 @@
+package cors
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/config"
+)
+
+// Default policy values used by PolicyFromConfig
+var (
+	DefaultAllowedMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+	DefaultAllowedHeaders = []string{
+		"Content-Type", "Content-Length", "Accept-Encoding", "X-CSRF-Token", "Authorization",
+		"Accept", "Origin", "Cache-Control", "X-Requested-With", "DPoP",
+	}
+	// DefaultExposedHeaders lets the frontend read rate limit feedback and
+	// DPoP nonce challenges
+	DefaultExposedHeaders = []string{
+		"Retry-After", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy",
+		"DPoP-Nonce", "WWW-Authenticate",
+	}
+)
+
+// Options configures a Policy
+type Options struct {
+	// AllowedOrigins lists exact origins ("https://app.example.com"), single-label
+	// wildcard patterns ("https://*.dev.example.com") or "*" for any origin.
+	// "*" never allows credentials.
+	AllowedOrigins []string
+
+	// AllowedMethods are returned in preflight responses for routes without their own method list
+	AllowedMethods []string
+
+	// AllowedHeaders are the request headers a cross-origin request may send
+	AllowedHeaders []string
+
+	// ExposedHeaders are the response headers readable by cross-origin JavaScript
+	ExposedHeaders []string
+
+	// AllowCredentials allows cookies on cross-origin requests from listed origins
+	AllowCredentials bool
+
+	// MaxAge is how long browsers may cache a preflight response (0 omits the header)
+	MaxAge time.Duration
+}
+
+// Policy decides which CORS headers a response carries. It is shared by the
+// gin middleware and raw Lambda responses so both behave identically.
+type Policy struct {
+	exact            map[string]bool
+	patterns         []originPattern
+	allowAny         bool
+	allowedMethods   []string
+	allowedHeaders   map[string]bool
+	allowHeaders     string
+	exposeHeaders    string
+	allowCredentials bool
+	maxAge           time.Duration
+}
+
+// originPattern matches origins of the form scheme://<label><suffix>
+type originPattern struct {
+	prefix string // e.g. "https://"
+	suffix string // e.g. ".dev.example.com"
+}
+
+// NewPolicy creates a Policy; invalid origin patterns are logged and ignored
+func NewPolicy(opts Options) *Policy {
+	p := &Policy{
+		exact:            make(map[string]bool),
+		allowedMethods:   normalizeMethods(opts.AllowedMethods),
+		allowedHeaders:   make(map[string]bool),
+		allowHeaders:     strings.Join(opts.AllowedHeaders, ", "),
+		exposeHeaders:    strings.Join(opts.ExposedHeaders, ", "),
+		allowCredentials: opts.AllowCredentials,
+		maxAge:           opts.MaxAge,
+	}
+
+	for _, origin := range opts.AllowedOrigins {
+		origin = normalizeOrigin(origin)
+		switch {
+		case origin == "":
+			continue
+		case origin == "*":
+			p.allowAny = true
+		case strings.Contains(origin, "*"):
+			pattern, ok := parseOriginPattern(origin)
+			if !ok {
+				log.Printf("WARNING: ignoring invalid CORS origin pattern %q", origin)
+				continue
+			}
+			p.patterns = append(p.patterns, pattern)
+		default:
+			p.exact[origin] = true
+		}
+	}
+
+	for _, header := range opts.AllowedHeaders {
+		p.allowedHeaders[strings.ToLower(header)] = true
+	}
+
+	return p
+}
+
+// PolicyFromConfig creates the application's CORS policy
+func PolicyFromConfig(cfg *config.Config) *Policy {
+	return NewPolicy(Options{
+		AllowedOrigins:   cfg.AllowedOrigins,
+		AllowedMethods:   DefaultAllowedMethods,
+		AllowedHeaders:   DefaultAllowedHeaders,
+		ExposedHeaders:   DefaultExposedHeaders,
+		AllowCredentials: true,
+		MaxAge:           cfg.CORSMaxAge,
+	})
+}
+
+// IsOriginAllowed reports whether cross-origin requests from origin are allowed
+func (p *Policy) IsOriginAllowed(origin string) bool {
+	origin = normalizeOrigin(origin)
+	if origin == "" {
+		return false
+	}
+	if p.allowAny || p.exact[origin] {
+		return true
+	}
+	for _, pattern := range p.patterns {
+		if pattern.matches(origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// ResponseHeaders returns the headers for an actual (non-preflight) response
+// Disallowed or missing origins only get "Vary: Origin".
+func (p *Policy) ResponseHeaders(origin string) map[string]string {
+	headers := map[string]string{"Vary": "Origin"}
+	if !p.IsOriginAllowed(origin) {
+		return headers
+	}
+
+	p.setOriginHeaders(headers, origin)
+	if p.exposeHeaders != "" {
+		headers["Access-Control-Expose-Headers"] = p.exposeHeaders
+	}
+	return headers
+}
+
+// PreflightHeaders returns the headers for a preflight response and whether the
+// preflight is allowed. routeMethods lists the methods of the requested route;
+// when empty the policy's default methods are used.
+func (p *Policy) PreflightHeaders(origin, requestMethod, requestHeaders string, routeMethods []string) (map[string]string, bool) {
+	headers := map[string]string{
+		"Vary": "Origin, Access-Control-Request-Method, Access-Control-Request-Headers",
+	}
+	if !p.IsOriginAllowed(origin) {
+		return headers, false
+	}
+
+	methods := p.allowedMethods
+	if len(routeMethods) > 0 {
+		methods = normalizeMethods(routeMethods)
+	}
+	if !containsMethod(methods, requestMethod) || !p.headersAllowed(requestHeaders) {
+		return headers, false
+	}
+
+	p.setOriginHeaders(headers, origin)
+	headers["Access-Control-Allow-Methods"] = strings.Join(methods, ", ")
+	if p.allowHeaders != "" {
+		headers["Access-Control-Allow-Headers"] = p.allowHeaders
+	}
+	if p.maxAge > 0 {
+		headers["Access-Control-Max-Age"] = strconv.Itoa(int(p.maxAge.Seconds()))
+	}
+	return headers, true
+}
+
+// IsPreflight reports whether r is a CORS preflight request
+func IsPreflight(r *http.Request) bool {
+	return r.Method == http.MethodOptions &&
+		r.Header.Get("Origin") != "" &&
+		r.Header.Get("Access-Control-Request-Method") != ""
+}
+
+// setOriginHeaders sets Allow-Origin and, for listed origins, Allow-Credentials
+func (p *Policy) setOriginHeaders(headers map[string]string, origin string) {
+	if p.allowAny && !p.isListed(origin) {
+		// A wildcard origin must never be combined with credentials
+		headers["Access-Control-Allow-Origin"] = "*"
+		return
+	}
+
+	headers["Access-Control-Allow-Origin"] = origin
+	if p.allowCredentials {
+		headers["Access-Control-Allow-Credentials"] = "true"
+	}
+}
+
+// isListed reports whether origin matches an explicit origin or pattern
+func (p *Policy) isListed(origin string) bool {
+	origin = normalizeOrigin(origin)
+	if p.exact[origin] {
+		return true
+	}
+	for _, pattern := range p.patterns {
+		if pattern.matches(origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// headersAllowed checks an Access-Control-Request-Headers value
+func (p *Policy) headersAllowed(requestHeaders string) bool {
+	for _, header := range strings.Split(requestHeaders, ",") {
+		header = strings.ToLower(strings.TrimSpace(header))
+		if header != "" && !p.allowedHeaders[header] {
+			return false
+		}
+	}
+	return true
+}
+
+// matches reports whether origin is scheme://<single DNS label><suffix>
+func (o originPattern) matches(origin string) bool {
+	if !strings.HasPrefix(origin, o.prefix) || !strings.HasSuffix(origin, o.suffix) {
+		return false
+	}
+
+	label := origin[len(o.prefix) : len(origin)-len(o.suffix)]
+	return isDNSLabel(label)
+}
+
+// parseOriginPattern parses "scheme://*.example.com[:port]"
+func parseOriginPattern(origin string) (originPattern, bool) {
+	scheme, host, ok := strings.Cut(origin, "://")
+	if !ok || scheme == "" || !strings.HasPrefix(host, "*.") || strings.Count(host, "*") != 1 {
+		return originPattern{}, false
+	}
+
+	return originPattern{
+		prefix: scheme + "://",
+		suffix: host[1:],
+	}, true
+}
+
+// isDNSLabel reports whether s is a single hostname label
+func isDNSLabel(s string) bool {
+	if s == "" || len(s) > 63 || s[0] == '-' || s[len(s)-1] == '-' {
+		return false
+	}
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z') && !(r >= '0' && r <= '9') && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeOrigin lowercases an origin and strips a trailing slash
+func normalizeOrigin(origin string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(origin)), "/")
+}
+
+// normalizeMethods upper-cases methods and makes sure OPTIONS is included
+func normalizeMethods(methods []string) []string {
+	normalized := make([]string, 0, len(methods)+1)
+	for _, method := range methods {
+		method = strings.ToUpper(strings.TrimSpace(method))
+		if method != "" && !containsMethod(normalized, method) {
+			normalized = append(normalized, method)
+		}
+	}
+	if !containsMethod(normalized, http.MethodOptions) {
+		normalized = append(normalized, http.MethodOptions)
+	}
+	return normalized
+}
+
+// containsMethod reports whether methods contains method
+func containsMethod(methods []string, method string) bool {
+	for _, m := range methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
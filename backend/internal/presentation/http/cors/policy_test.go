@@ -0,0 +1,136 @@
+package cors
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPolicy(origins ...string) *Policy {
+	return NewPolicy(Options{
+		AllowedOrigins:   origins,
+		AllowedMethods:   []string{"GET", "POST"},
+		AllowedHeaders:   []string{"Content-Type", "X-CSRF-Token"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	})
+}
+
+func TestPolicy_IsOriginAllowed(t *testing.T) {
+	policy := newTestPolicy("https://app.example.com", "https://*.dev.example.com", "http://localhost:5173/")
+
+	tests := []struct {
+		origin  string
+		allowed bool
+	}{
+		{"https://app.example.com", true},
+		{"HTTPS://APP.EXAMPLE.COM", true},
+		{"http://localhost:5173", true},
+		{"https://feature-1.dev.example.com", true},
+		{"https://a.b.dev.example.com", false},
+		{"https://dev.example.com", false},
+		{"http://feature-1.dev.example.com", false},
+		{"https://evil-dev.example.com", false},
+		{"https://app.example.com.evil.com", false},
+		{"https://evil.com", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.origin, func(t *testing.T) {
+			assert.Equal(t, tt.allowed, policy.IsOriginAllowed(tt.origin))
+		})
+	}
+}
+
+func TestPolicy_ResponseHeaders_Allowed(t *testing.T) {
+	policy := newTestPolicy("https://app.example.com")
+
+	headers := policy.ResponseHeaders("https://app.example.com")
+
+	assert.Equal(t, "https://app.example.com", headers["Access-Control-Allow-Origin"])
+	assert.Equal(t, "true", headers["Access-Control-Allow-Credentials"])
+	assert.Equal(t, "Origin", headers["Vary"])
+}
+
+func TestPolicy_ResponseHeaders_DisallowedOriginGetsNoCORSHeaders(t *testing.T) {
+	policy := newTestPolicy("https://app.example.com")
+
+	headers := policy.ResponseHeaders("https://evil.com")
+
+	assert.Equal(t, map[string]string{"Vary": "Origin"}, headers)
+}
+
+func TestPolicy_Wildcard_NeverAllowsCredentials(t *testing.T) {
+	policy := newTestPolicy("*")
+
+	headers := policy.ResponseHeaders("https://anything.com")
+
+	assert.Equal(t, "*", headers["Access-Control-Allow-Origin"])
+	assert.NotContains(t, headers, "Access-Control-Allow-Credentials")
+}
+
+func TestPolicy_Wildcard_ListedOriginKeepsCredentials(t *testing.T) {
+	policy := newTestPolicy("*", "https://app.example.com")
+
+	headers := policy.ResponseHeaders("https://app.example.com")
+
+	assert.Equal(t, "https://app.example.com", headers["Access-Control-Allow-Origin"])
+	assert.Equal(t, "true", headers["Access-Control-Allow-Credentials"])
+}
+
+func TestPolicy_PreflightHeaders(t *testing.T) {
+	policy := newTestPolicy("https://app.example.com")
+
+	headers, ok := policy.PreflightHeaders("https://app.example.com", "POST", "content-type, x-csrf-token", nil)
+
+	assert.True(t, ok)
+	assert.Equal(t, "https://app.example.com", headers["Access-Control-Allow-Origin"])
+	assert.Equal(t, "GET, POST, OPTIONS", headers["Access-Control-Allow-Methods"])
+	assert.Equal(t, "Content-Type, X-CSRF-Token", headers["Access-Control-Allow-Headers"])
+	assert.Equal(t, "600", headers["Access-Control-Max-Age"])
+	assert.Contains(t, headers["Vary"], "Origin")
+}
+
+func TestPolicy_PreflightHeaders_RouteMethods(t *testing.T) {
+	policy := newTestPolicy("https://app.example.com")
+
+	headers, ok := policy.PreflightHeaders("https://app.example.com", "DELETE", "", []string{"GET", "DELETE"})
+	assert.True(t, ok)
+	assert.Equal(t, "GET, DELETE, OPTIONS", headers["Access-Control-Allow-Methods"])
+
+	_, ok = policy.PreflightHeaders("https://app.example.com", "POST", "", []string{"GET", "DELETE"})
+	assert.False(t, ok)
+}
+
+func TestPolicy_PreflightHeaders_Rejects(t *testing.T) {
+	policy := newTestPolicy("https://app.example.com")
+
+	headers, ok := policy.PreflightHeaders("https://evil.com", "POST", "", nil)
+	assert.False(t, ok)
+	assert.NotContains(t, headers, "Access-Control-Allow-Origin")
+
+	_, ok = policy.PreflightHeaders("https://app.example.com", "PUT", "", nil)
+	assert.False(t, ok)
+
+	_, ok = policy.PreflightHeaders("https://app.example.com", "POST", "X-Custom-Header", nil)
+	assert.False(t, ok)
+}
+
+func TestNewPolicy_IgnoresInvalidPatterns(t *testing.T) {
+	policy := newTestPolicy("https://app.*.example.com", "*.example.com")
+
+	assert.False(t, policy.IsOriginAllowed("https://app.foo.example.com"))
+	assert.False(t, policy.IsOriginAllowed("https://foo.example.com"))
+}
+
+func TestIsPreflight(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodOptions, "/auth/refresh", nil)
+	assert.False(t, IsPreflight(req))
+
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	assert.True(t, IsPreflight(req))
+}
@@ -0,0 +1,179 @@
+// Package credentials finds the tokens a request authenticates with. Browsers
+// send them in HttpOnly cookies; CLI tools and mobile apps send them in an
+// "Authorization: Bearer" or "Authorization: DPoP" header or, for refresh
+// tokens, in the JSON body.
+package credentials
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/config"
+)
+
+// Cookie names of the tokens issued to browsers
+const (
+	AccessTokenCookie  = "access_token"
+	RefreshTokenCookie = "refresh_token"
+	MFATokenCookie     = "mfa_token"
+)
+
+const (
+	// DeliveryHeader lets a non-browser client ask for tokens in the
+	// response body instead of cookies by sending "X-Token-Delivery: body"
+	DeliveryHeader = "X-Token-Delivery"
+	DeliveryBody   = "body"
+)
+
+const (
+	// ProofHeader carries a DPoP proof of possession of the key a token is
+	// bound to; NonceHeader gives clients the nonce to put in their next proof
+	ProofHeader = "DPoP"
+	NonceHeader = "DPoP-Nonce"
+)
+
+// maxBodySize caps how much of a request body is read to find a refresh token
+const maxBodySize = 4096
+
+// Source says where a request's token was found
+type Source int
+
+const (
+	SourceNone Source = iota
+	SourceCookie
+	SourceHeader
+	SourceBody
+	SourceDPoPHeader // "Authorization: DPoP" (RFC 9449)
+)
+
+// Token is a credential and where it was found
+type Token struct {
+	Value  string
+	Source Source
+}
+
+// Found reports whether the request carried the token
+func (t Token) Found() bool {
+	return t.Value != ""
+}
+
+// FromCookie reports whether the token was sent by a browser in a cookie
+func (t Token) FromCookie() bool {
+	return t.Source == SourceCookie
+}
+
+// Lookup returns the token in the named cookie or in the Authorization header.
+// When the request carries both, precedence (config.TokenPrecedenceHeader or
+// config.TokenPrecedenceCookie) decides which one is used.
+func Lookup(r *http.Request, cookieName, precedence string) Token {
+	return choose(cookie(r, cookieName), authorization(r), precedence)
+}
+
+// RefreshToken returns the refresh token cookie or the "refresh_token" field
+// of the JSON body (see dto.RefreshTokenRequest), restoring the body for the
+// handler. When the request carries both, precedence decides.
+func RefreshToken(r *http.Request, precedence string) Token {
+	return choose(cookie(r, RefreshTokenCookie), Token{Value: refreshTokenFromBody(r), Source: SourceBody}, precedence)
+}
+
+// Bearer returns the token of an "Authorization: Bearer <token>" header, or ""
+func Bearer(r *http.Request) string {
+	return schemeToken(r, "Bearer")
+}
+
+// Proof returns the request's DPoP proof, or ""
+func Proof(r *http.Request) string {
+	return strings.TrimSpace(r.Header.Get(ProofHeader))
+}
+
+// authorization returns the token of a Bearer or DPoP Authorization header
+func authorization(r *http.Request) Token {
+	if token := Bearer(r); token != "" {
+		return Token{Value: token, Source: SourceHeader}
+	}
+	if token := schemeToken(r, "DPoP"); token != "" {
+		return Token{Value: token, Source: SourceDPoPHeader}
+	}
+	return Token{}
+}
+
+// schemeToken returns the token of an "Authorization: <scheme> <token>" header, or ""
+func schemeToken(r *http.Request, scheme string) string {
+	got, token, found := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !found || !strings.EqualFold(got, scheme) {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// WantsBody reports whether the client asked for tokens in the response body
+func WantsBody(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get(DeliveryHeader), DeliveryBody)
+}
+
+// choose picks between a cookie and a token sent another way
+func choose(cookieToken, otherToken Token, precedence string) Token {
+	first, second := otherToken, cookieToken
+	if precedence == config.TokenPrecedenceCookie {
+		first, second = cookieToken, otherToken
+	}
+
+	if first.Found() {
+		return first
+	}
+	if second.Found() {
+		return second
+	}
+	return Token{}
+}
+
+// SessionID returns the login session of the auth cookies a request carries,
+// or "" when neither the access nor the refresh token cookie is valid
+func SessionID(r *http.Request, tokens ports.TokenGenerator) string {
+	if access := cookie(r, AccessTokenCookie); access.Found() {
+		if claims, err := tokens.ValidateAccessToken(access.Value); err == nil {
+			return claims.SessionID
+		}
+	}
+	if refresh := cookie(r, RefreshTokenCookie); refresh.Found() {
+		if claims, err := tokens.ValidateRefreshToken(refresh.Value); err == nil {
+			return claims.SessionID
+		}
+	}
+	return ""
+}
+
+// cookie returns the named cookie as a Token
+func cookie(r *http.Request, name string) Token {
+	c, err := r.Cookie(name)
+	if err != nil || c.Value == "" {
+		return Token{}
+	}
+	return Token{Value: c.Value, Source: SourceCookie}
+}
+
+// refreshTokenFromBody reads the "refresh_token" field of a JSON body and
+// puts the body back so it can be read again
+func refreshTokenFromBody(r *http.Request) string {
+	if r.Body == nil || r.Body == http.NoBody {
+		return ""
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+	if err != nil {
+		return ""
+	}
+	r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+
+	var req struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return ""
+	}
+	return req.RefreshToken
+}
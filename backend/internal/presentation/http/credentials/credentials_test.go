@@ -0,0 +1,120 @@
+package credentials
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/config"
+)
+
+func newRequest(body string, cookies map[string]string, headers map[string]string) *http.Request {
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/auth/refresh", reader)
+	for name, value := range cookies {
+		req.AddCookie(&http.Cookie{Name: name, Value: value})
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	return req
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name       string
+		cookies    map[string]string
+		headers    map[string]string
+		precedence string
+		want       Token
+	}{
+		{
+			name:       "cookie only",
+			cookies:    map[string]string{AccessTokenCookie: "cookie-token"},
+			precedence: config.TokenPrecedenceHeader,
+			want:       Token{Value: "cookie-token", Source: SourceCookie},
+		},
+		{
+			name:       "bearer only",
+			headers:    map[string]string{"Authorization": "Bearer header-token"},
+			precedence: config.TokenPrecedenceCookie,
+			want:       Token{Value: "header-token", Source: SourceHeader},
+		},
+		{
+			name:       "both, header first",
+			cookies:    map[string]string{AccessTokenCookie: "cookie-token"},
+			headers:    map[string]string{"Authorization": "Bearer header-token"},
+			precedence: config.TokenPrecedenceHeader,
+			want:       Token{Value: "header-token", Source: SourceHeader},
+		},
+		{
+			name:       "both, cookie first",
+			cookies:    map[string]string{AccessTokenCookie: "cookie-token"},
+			headers:    map[string]string{"Authorization": "bearer header-token"},
+			precedence: config.TokenPrecedenceCookie,
+			want:       Token{Value: "cookie-token", Source: SourceCookie},
+		},
+		{
+			name:       "DPoP-bound token",
+			cookies:    map[string]string{AccessTokenCookie: "cookie-token"},
+			headers:    map[string]string{"Authorization": "DPoP header-token"},
+			precedence: config.TokenPrecedenceHeader,
+			want:       Token{Value: "header-token", Source: SourceDPoPHeader},
+		},
+		{
+			name:       "other scheme",
+			headers:    map[string]string{"Authorization": "Basic dXNlcjpwYXNz"},
+			precedence: config.TokenPrecedenceHeader,
+			want:       Token{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newRequest("", tt.cookies, tt.headers)
+
+			assert.Equal(t, tt.want, Lookup(req, AccessTokenCookie, tt.precedence))
+		})
+	}
+}
+
+func TestRefreshToken_FromBody(t *testing.T) {
+	body := `{"refresh_token":"body-token"}`
+	req := newRequest(body, nil, nil)
+
+	token := RefreshToken(req, config.TokenPrecedenceHeader)
+
+	assert.Equal(t, Token{Value: "body-token", Source: SourceBody}, token)
+	assert.False(t, token.FromCookie())
+
+	// The handler can still bind the body
+	restored, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(restored))
+}
+
+func TestRefreshToken_Precedence(t *testing.T) {
+	cookies := map[string]string{RefreshTokenCookie: "cookie-token"}
+
+	headerFirst := RefreshToken(newRequest(`{"refresh_token":"body-token"}`, cookies, nil), config.TokenPrecedenceHeader)
+	cookieFirst := RefreshToken(newRequest(`{"refresh_token":"body-token"}`, cookies, nil), config.TokenPrecedenceCookie)
+	cookieOnly := RefreshToken(newRequest("", cookies, nil), config.TokenPrecedenceHeader)
+
+	assert.Equal(t, "body-token", headerFirst.Value)
+	assert.Equal(t, "cookie-token", cookieFirst.Value)
+	assert.True(t, cookieOnly.FromCookie())
+}
+
+func TestWantsBody(t *testing.T) {
+	assert.True(t, WantsBody(newRequest("", nil, map[string]string{DeliveryHeader: "body"})))
+	assert.False(t, WantsBody(newRequest("", nil, map[string]string{DeliveryHeader: "cookie"})))
+	assert.False(t, WantsBody(newRequest("", nil, nil)))
+}
@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yuki5155/go-google-auth/internal/application/account"
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/config"
+	"github.com/yuki5155/go-google-auth/internal/presentation/http/middleware"
+)
+
+// AccountHandler handles self-service account requests (thin controller)
+type AccountHandler struct {
+	updateProfileUC *account.UpdateProfileUseCase
+	deleteAccountUC *account.DeleteAccountUseCase
+	exportDataUC    *account.ExportDataUseCase
+	config          *config.Config
+}
+
+// NewAccountHandler creates a new AccountHandler
+func NewAccountHandler(
+	updateProfileUC *account.UpdateProfileUseCase,
+	deleteAccountUC *account.DeleteAccountUseCase,
+	exportDataUC *account.ExportDataUseCase,
+	config *config.Config,
+) *AccountHandler {
+	return &AccountHandler{
+		updateProfileUC: updateProfileUC,
+		deleteAccountUC: deleteAccountUC,
+		exportDataUC:    exportDataUC,
+		config:          config,
+	}
+}
+
+// UpdateProfile applies a partial update to the current user's profile and preferences
+func (h *AccountHandler) UpdateProfile(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body",
+		})
+		return
+	}
+
+	result, err := h.updateProfileUC.Execute(c.Request.Context(), claims, req)
+	if err != nil {
+		if isProfileValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "invalid_profile",
+				"message": err.Error(),
+			})
+			return
+		}
+		if err == shared.ErrUnauthorized {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "unauthorized",
+				"message": "User not found",
+			})
+			return
+		}
+		log.Printf("Profile update failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_error",
+			"message": "Failed to update profile",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user": result,
+	})
+}
+
+// DeleteAccount soft-deletes the current user's account and logs them out
+func (h *AccountHandler) DeleteAccount(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.deleteAccountUC.Execute(c.Request.Context(), claims)
+	if err != nil {
+		switch err {
+		case shared.ErrReauthenticationRequired:
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":   "reauthentication_required",
+				"message": "Please re-authenticate before deleting your account",
+			})
+		case shared.ErrUnauthorized:
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "unauthorized",
+				"message": "User not found",
+			})
+		case shared.ErrInvalidStatusTransition:
+			c.JSON(http.StatusConflict, gin.H{
+				"error":   "account_already_deleted",
+				"message": "This account has already been deleted",
+			})
+		case shared.ErrLastOrganizationOwner:
+			c.JSON(http.StatusConflict, gin.H{
+				"error":   "last_owner",
+				"message": "Transfer ownership of your organizations before deleting your account",
+			})
+		default:
+			log.Printf("Account deletion failed: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "internal_error",
+				"message": "Failed to delete account",
+			})
+		}
+		return
+	}
+
+	clearAuthCookies(c, h.config.IsProduction())
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ExportData returns everything stored about the current user as a JSON download
+func (h *AccountHandler) ExportData(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.exportDataUC.Execute(c.Request.Context(), claims)
+	if err != nil {
+		if err == shared.ErrUnauthorized {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "unauthorized",
+				"message": "User not found",
+			})
+			return
+		}
+		log.Printf("Account export failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_error",
+			"message": "Failed to export account data",
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="account-export.json"`)
+	c.JSON(http.StatusOK, result)
+}
+
+// claimsFromContext returns the claims set by the auth middleware,
+// responding with 401 if they are missing and with 403 for a scoped token on
+// a route that does not accept it
+func claimsFromContext(c *gin.Context) (*ports.TokenClaims, bool) {
+	claimsInterface, exists := c.Get("claims")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User not authenticated",
+		})
+		return nil, false
+	}
+
+	claims, ok := claimsInterface.(*ports.TokenClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Invalid authentication",
+		})
+		return nil, false
+	}
+
+	// Scoped tokens only reach routes that declare a scope, and service
+	// client tokens have no user for these routes to act on
+	if claims.IsServiceClient() || (claims.Scopes != nil && !c.GetBool(middleware.ScopeCheckedKey)) {
+		middleware.AbortInsufficientScope(c, "")
+		return nil, false
+	}
+
+	return claims, true
+}
+
+// isProfileValidationError reports whether err was caused by invalid profile input
+func isProfileValidationError(err error) bool {
+	return errors.Is(err, shared.ErrInvalidDisplayName) ||
+		errors.Is(err, shared.ErrInvalidPictureURL) ||
+		errors.Is(err, shared.ErrInvalidLocale) ||
+		errors.Is(err, shared.ErrInvalidTimezone) ||
+		errors.Is(err, shared.ErrInvalidPreference)
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yuki5155/go-google-auth/internal/application/auth"
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+// AdminHandler handles administrator requests (thin controller)
+type AdminHandler struct {
+	startImpersonationUC *auth.StartImpersonationUseCase
+	stopImpersonationUC  *auth.StopImpersonationUseCase
+}
+
+// NewAdminHandler creates a new AdminHandler
+func NewAdminHandler(
+	startImpersonationUC *auth.StartImpersonationUseCase,
+	stopImpersonationUC *auth.StopImpersonationUseCase,
+) *AdminHandler {
+	return &AdminHandler{
+		startImpersonationUC: startImpersonationUC,
+		stopImpersonationUC:  stopImpersonationUC,
+	}
+}
+
+// Impersonate issues the administrator a short-lived token to act as a user.
+// The token is only returned in the body, so the administrator's own
+// session cookie is left alone.
+func (h *AdminHandler) Impersonate(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	// The body, with the reason for impersonating, is optional
+	var req dto.ImpersonateRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "The reason must be at most 200 characters",
+		})
+		return
+	}
+
+	result, err := h.startImpersonationUC.Execute(c.Request.Context(), claims, c.Param("userID"), req)
+	if err != nil {
+		respondAdminError(c, err, "Failed to start impersonation")
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, result)
+}
+
+// StopImpersonation ends the impersonation the request's token was issued
+// for; the token stops working
+func (h *AdminHandler) StopImpersonation(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	if err := h.stopImpersonationUC.Execute(c.Request.Context(), claims); err != nil {
+		respondAdminError(c, err, "Failed to stop impersonation")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Impersonation ended",
+	})
+}
+
+// respondAdminError maps administrator errors to HTTP responses
+func respondAdminError(c *gin.Context, err error, message string) {
+	switch err {
+	case shared.ErrAdminRequired:
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "admin_required",
+			"message": "Administrator role required",
+		})
+	case shared.ErrImpersonationNotAllowed:
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "impersonation_not_allowed",
+			"message": "This user cannot be impersonated",
+		})
+	case shared.ErrNotImpersonating:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "not_impersonating",
+			"message": "This token is not an impersonation token",
+		})
+	case shared.ErrUserNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "user_not_found",
+			"message": "User not found",
+		})
+	case shared.ErrAccountSuspended:
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "account_suspended",
+			"message": "This account has been suspended",
+		})
+	case shared.ErrUnauthorized, shared.ErrAccountDeleted:
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User not found",
+		})
+	default:
+		log.Printf("Admin request failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_error",
+			"message": message,
+		})
+	}
+}
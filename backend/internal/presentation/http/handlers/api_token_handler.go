@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yuki5155/go-google-auth/internal/application/auth"
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+// APITokenHandler handles personal access token requests (thin controller)
+type APITokenHandler struct {
+	createUC *auth.CreateAPITokenUseCase
+	listUC   *auth.ListAPITokensUseCase
+	revokeUC *auth.RevokeAPITokenUseCase
+}
+
+// NewAPITokenHandler creates a new APITokenHandler
+func NewAPITokenHandler(
+	createUC *auth.CreateAPITokenUseCase,
+	listUC *auth.ListAPITokensUseCase,
+	revokeUC *auth.RevokeAPITokenUseCase,
+) *APITokenHandler {
+	return &APITokenHandler{
+		createUC: createUC,
+		listUC:   listUC,
+		revokeUC: revokeUC,
+	}
+}
+
+// List returns the current user's personal access tokens
+func (h *APITokenHandler) List(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.listUC.Execute(c.Request.Context(), claims)
+	if err != nil {
+		respondAPITokenError(c, err, "Failed to load tokens")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Create issues a personal access token; the plain token is only returned here
+func (h *APITokenHandler) Create(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateAPITokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "A name and at least one scope are required",
+		})
+		return
+	}
+
+	result, err := h.createUC.Execute(c.Request.Context(), claims, req)
+	if err != nil {
+		respondAPITokenError(c, err, "Failed to create token")
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// Revoke disables one of the current user's personal access tokens
+func (h *APITokenHandler) Revoke(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	if err := h.revokeUC.Execute(c.Request.Context(), claims, c.Param("id")); err != nil {
+		respondAPITokenError(c, err, "Failed to revoke token")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Token revoked",
+	})
+}
+
+// respondAPITokenError maps personal access token errors to HTTP responses
+func respondAPITokenError(c *gin.Context, err error, message string) {
+	switch err {
+	case shared.ErrInvalidAPITokenName, shared.ErrInvalidAPITokenScope, shared.ErrInvalidAPITokenExpiry:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": err.Error(),
+		})
+	case shared.ErrAPITokenNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "token_not_found",
+			"message": "Token not found",
+		})
+	case shared.ErrTooManyAPITokens:
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "token_limit_reached",
+			"message": "The maximum number of tokens is reached",
+		})
+	case shared.ErrAccountSuspended:
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "account_suspended",
+			"message": "This account has been suspended",
+		})
+	case shared.ErrUnauthorized, shared.ErrAccountDeleted:
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User not found",
+		})
+	default:
+		log.Printf("Token request failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_error",
+			"message": message,
+		})
+	}
+}
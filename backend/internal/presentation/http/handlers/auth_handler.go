@@ -10,16 +10,33 @@ import (
 	"github.com/yuki5155/go-google-auth/internal/application/ports"
 	"github.com/yuki5155/go-google-auth/internal/domain/shared"
 	"github.com/yuki5155/go-google-auth/internal/infrastructure/config"
+	"github.com/yuki5155/go-google-auth/internal/presentation/http/clientip"
+	"github.com/yuki5155/go-google-auth/internal/presentation/http/credentials"
 )
 
+// MFA-pending token cookie, sent only to the MFA verification endpoint
+const (
+	mfaTokenCookieName = credentials.MFATokenCookie
+	mfaTokenCookiePath = "/auth/mfa"
+)
+
+// loginCookies issues and clears the cookies of the login flows; it is
+// shared by the handlers that complete a login
+type loginCookies struct {
+	tokenGenerator ports.TokenGenerator
+	csrfTokens     ports.CSRFTokenService
+	config         *config.Config
+}
+
 // AuthHandler handles HTTP authentication requests (thin controller)
 type AuthHandler struct {
+	loginCookies
 	googleLoginUC    *auth.GoogleLoginUseCase
 	refreshTokenUC   *auth.RefreshTokenUseCase
 	getCurrentUserUC *auth.GetCurrentUserUseCase
 	logoutUC         *auth.LogoutUseCase
-	tokenGenerator   ports.TokenGenerator
-	config           *config.Config
+	verifyMFAUC      *auth.VerifyMFAUseCase
+	dpop             *auth.DPoPAuthenticator
 }
 
 // NewAuthHandler creates a new thin AuthHandler
@@ -28,16 +45,24 @@ func NewAuthHandler(
 	refreshTokenUC *auth.RefreshTokenUseCase,
 	getCurrentUserUC *auth.GetCurrentUserUseCase,
 	logoutUC *auth.LogoutUseCase,
+	verifyMFAUC *auth.VerifyMFAUseCase,
+	dpop *auth.DPoPAuthenticator,
 	tokenGenerator ports.TokenGenerator,
+	csrfTokens ports.CSRFTokenService,
 	config *config.Config,
 ) *AuthHandler {
 	return &AuthHandler{
+		loginCookies: loginCookies{
+			tokenGenerator: tokenGenerator,
+			csrfTokens:     csrfTokens,
+			config:         config,
+		},
 		googleLoginUC:    googleLoginUC,
 		refreshTokenUC:   refreshTokenUC,
 		getCurrentUserUC: getCurrentUserUC,
 		logoutUC:         logoutUC,
-		tokenGenerator:   tokenGenerator,
-		config:           config,
+		verifyMFAUC:      verifyMFAUC,
+		dpop:             dpop,
 	}
 }
 
@@ -52,7 +77,7 @@ func (h *AuthHandler) GoogleLogin(c *gin.Context) {
 		return
 	}
 
-	result, err := h.googleLoginUC.Execute(c.Request.Context(), req.Credential)
+	result, err := h.googleLoginUC.Execute(c.Request.Context(), req.Credential, clientInfo(c))
 	if err != nil {
 		if err == shared.ErrUnverifiedEmail {
 			c.JSON(http.StatusUnauthorized, gin.H{
@@ -61,6 +86,20 @@ func (h *AuthHandler) GoogleLogin(c *gin.Context) {
 			})
 			return
 		}
+		if err == shared.ErrGoogleAccountConflict {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":   "account_conflict",
+				"message": "This email address belongs to an account that can't be signed in to with this Google account",
+			})
+			return
+		}
+		if err == shared.ErrAccountSuspended {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":   "account_suspended",
+				"message": "This account has been suspended",
+			})
+			return
+		}
 		log.Printf("Google login failed: %v", err)
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"error":   "authentication_failed",
@@ -69,18 +108,148 @@ func (h *AuthHandler) GoogleLogin(c *gin.Context) {
 		return
 	}
 
-	h.setAuthCookies(c, result.AccessToken, result.RefreshToken)
+	if result.MFARequired {
+		// The second step is completed at POST /auth/mfa/verify or /auth/mfa/passkey
+		response := gin.H{
+			"message":      result.Message,
+			"mfa_required": true,
+			"mfa_methods":  result.MFAMethods,
+		}
+		if h.wantsBodyTokens(c) {
+			// Sent back as "Authorization: Bearer <mfa_token>"
+			response["mfa_token"] = result.MFAToken
+		} else {
+			h.setMFATokenCookie(c, result.MFAToken)
+		}
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	h.completeLogin(c, result)
+}
+
+// VerifyMFA completes an MFA-pending login with a TOTP or recovery code
+func (h *AuthHandler) VerifyMFA(c *gin.Context) {
+	mfaToken, ok := h.mfaTokenFromRequest(c)
+	if !ok {
+		return
+	}
+
+	var req dto.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body",
+		})
+		return
+	}
+
+	result, err := h.verifyMFAUC.Execute(c.Request.Context(), mfaToken, req)
+	if err != nil {
+		switch err {
+		case shared.ErrInvalidMFACode:
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "invalid_mfa_code",
+				"message": "Invalid verification code",
+			})
+		case shared.ErrMFANotEnabled:
+			// The login stays pending: the user may have another method
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "mfa_method_unavailable",
+				"message": "Two-factor codes are not set up for this account",
+			})
+		case shared.ErrAccountSuspended:
+			h.clearMFATokenCookie(c)
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":   "account_suspended",
+				"message": "This account has been suspended",
+			})
+		case ports.ErrExpiredToken, ports.ErrInvalidToken,
+			shared.ErrUnauthorized, shared.ErrAccountDeleted:
+			h.clearMFATokenCookie(c)
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "mfa_token_invalid",
+				"message": "Pending login is no longer valid, please login again",
+			})
+		default:
+			log.Printf("MFA verification failed: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "internal_error",
+				"message": "Failed to verify code",
+			})
+		}
+		return
+	}
+
+	h.clearMFATokenCookie(c)
+	h.completeLogin(c, result)
+}
+
+// clientInfo returns the user agent and IP address of the request
+func clientInfo(c *gin.Context) dto.ClientInfo {
+	return dto.ClientInfo{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: clientip.FromContext(c),
+	}
+}
+
+// completeLogin sets the auth cookies and responds with the user and a CSRF
+// token, or returns the tokens in the body to clients that asked for it
+func (l *loginCookies) completeLogin(c *gin.Context, result *dto.LoginResponse) {
+	if l.wantsBodyTokens(c) {
+		c.JSON(http.StatusOK, dto.NewBearerTokenResponse(result, l.tokenGenerator.GetAccessTokenExpiry()))
+		return
+	}
+
+	l.setAuthCookies(c, result.AccessToken, result.RefreshToken)
+
+	var sessionID string
+	if claims, err := l.tokenGenerator.ValidateAccessToken(result.AccessToken); err == nil {
+		sessionID = claims.SessionID
+	}
+	csrfToken, err := l.issueCSRFToken(c, sessionID)
+	if err != nil {
+		log.Printf("Failed to issue CSRF token: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_error",
+			"message": "Failed to complete login",
+		})
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": result.Message,
-		"user":    result.User,
+		"message":    result.Message,
+		"user":       result.User,
+		"csrf_token": csrfToken,
+	})
+}
+
+// CSRFToken issues a fresh CSRF token (cookie plus response body) for the
+// frontend to echo in the X-CSRF-Token header on state-changing requests.
+// The token is bound to the login session of the request's auth cookies.
+func (h *AuthHandler) CSRFToken(c *gin.Context) {
+	csrfToken, err := h.issueCSRFToken(c, credentials.SessionID(c.Request, h.tokenGenerator))
+	if err != nil {
+		log.Printf("Failed to issue CSRF token: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_error",
+			"message": "Failed to issue CSRF token",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"csrf_token": csrfToken,
 	})
 }
 
 // RefreshToken handles token refresh requests
+// The refresh token is read from its cookie or from the body (dto.RefreshTokenRequest);
+// a token sent in the body gets the new access token back in the body. A DPoP
+// proof binds the session to the client's key, and the access token to it.
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
-	refreshToken, err := c.Cookie("refresh_token")
-	if err != nil {
+	refreshToken := credentials.RefreshToken(c.Request, h.config.TokenPrecedence)
+	if !refreshToken.Found() {
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"error":   "missing_refresh_token",
 			"message": "Refresh token not found",
@@ -88,7 +257,12 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	result, err := h.refreshTokenUC.Execute(c.Request.Context(), refreshToken)
+	dpopKey, ok := h.verifyDPoPProof(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.refreshTokenUC.Execute(c.Request.Context(), refreshToken.Value, dpopKey)
 	if err != nil {
 		if err == ports.ErrExpiredToken {
 			h.clearAuthCookies(c)
@@ -98,6 +272,29 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 			})
 			return
 		}
+		if err == shared.ErrAccountSuspended {
+			h.clearAuthCookies(c)
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":   "account_suspended",
+				"message": "This account has been suspended",
+			})
+			return
+		}
+		if err == shared.ErrSessionRevoked || err == shared.ErrSessionExpired {
+			h.clearAuthCookies(c)
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "session_revoked",
+				"message": "Session is no longer valid, please login again",
+			})
+			return
+		}
+		if err == shared.ErrDPoPKeyMismatch {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "invalid_dpop_proof",
+				"message": "Session is bound to a different DPoP key",
+			})
+			return
+		}
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"error":   "invalid_refresh_token",
 			"message": "Invalid refresh token",
@@ -105,6 +302,20 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
+	if !refreshToken.FromCookie() {
+		tokenType := "Bearer"
+		if result.DPoPBound {
+			tokenType = "DPoP"
+		}
+		c.JSON(http.StatusOK, dto.BearerTokenResponse{
+			Message:     result.Message,
+			AccessToken: result.AccessToken,
+			TokenType:   tokenType,
+			ExpiresIn:   h.tokenGenerator.GetAccessTokenExpiry(),
+		})
+		return
+	}
+
 	h.setAccessTokenCookie(c, result.AccessToken)
 
 	c.JSON(http.StatusOK, gin.H{
@@ -112,6 +323,44 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	})
 }
 
+// verifyDPoPProof checks the request's DPoP proof, if it sent one, and
+// returns the thumbprint of its key. It responds and returns false when the
+// proof is rejected.
+func (h *AuthHandler) verifyDPoPProof(c *gin.Context) (string, bool) {
+	proof := credentials.Proof(c.Request)
+	if proof == "" || h.dpop == nil {
+		return "", true
+	}
+
+	verified, err := h.dpop.Verify(c.Request.Context(), proof, ports.DPoPProofRequest{
+		Method: c.Request.Method,
+		Path:   c.Request.URL.Path,
+	})
+	c.Header(credentials.NonceHeader, h.dpop.Nonce())
+	if err != nil {
+		switch err {
+		case shared.ErrUseDPoPNonce:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "use_dpop_nonce",
+				"message": "DPoP proof must include the nonce from the DPoP-Nonce header",
+			})
+		case shared.ErrInvalidDPoPProof:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "invalid_dpop_proof",
+				"message": "Invalid DPoP proof",
+			})
+		default:
+			log.Printf("Failed to verify DPoP proof: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "internal_error",
+				"message": "Failed to verify DPoP proof",
+			})
+		}
+		return "", false
+	}
+	return verified.Thumbprint, true
+}
+
 // Logout handles user logout
 func (h *AuthHandler) Logout(c *gin.Context) {
 	result := h.logoutUC.Execute(c.Request.Context())
@@ -123,46 +372,75 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 	})
 }
 
-// GetCurrentUser returns the current authenticated user's information
+// GetCurrentUser returns the current authenticated user's information, loaded fresh from the repository
 func (h *AuthHandler) GetCurrentUser(c *gin.Context) {
 	// Get claims from context (set by auth middleware)
-	claimsInterface, exists := c.Get("claims")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error":   "unauthorized",
-			"message": "User not authenticated",
-		})
+	claims, ok := claimsFromContext(c)
+	if !ok {
 		return
 	}
 
-	claims, ok := claimsInterface.(*ports.TokenClaims)
-	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error":   "unauthorized",
-			"message": "Invalid authentication",
+	result, err := h.getCurrentUserUC.ExecuteFromClaims(c.Request.Context(), claims)
+	if err != nil {
+		if err == shared.ErrUnauthorized {
+			// The cookies of an impersonating administrator are their own
+			if claims.ImpersonatorID() == "" {
+				h.clearAuthCookies(c)
+			}
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "unauthorized",
+				"message": "User no longer exists",
+			})
+			return
+		}
+		log.Printf("Failed to load current user: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_error",
+			"message": "Failed to load user",
 		})
 		return
 	}
 
-	// Return user info directly from JWT claims
-	c.JSON(http.StatusOK, gin.H{
-		"user": gin.H{
-			"id":      claims.UserID,
-			"email":   claims.Email,
-			"name":    claims.Name,
-			"picture": claims.Picture,
-		},
-	})
+	response := gin.H{
+		"user": result,
+	}
+	if adminID := claims.ImpersonatorID(); adminID != "" {
+		response["impersonation"] = dto.ImpersonationStatus{
+			AdminID:   adminID,
+			ExpiresAt: claims.ExpiresAt,
+		}
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// wantsBodyTokens reports whether a non-browser client asked for tokens in
+// the response body, which BODY_TOKENS_ENABLED must allow
+func (l *loginCookies) wantsBodyTokens(c *gin.Context) bool {
+	return l.config.BodyTokensEnabled && credentials.WantsBody(c.Request)
+}
+
+// mfaTokenFromRequest reads the MFA-pending token from its cookie or an
+// "Authorization: Bearer" header, responding with 401 if it is missing
+func (l *loginCookies) mfaTokenFromRequest(c *gin.Context) (string, bool) {
+	mfaToken := credentials.Lookup(c.Request, mfaTokenCookieName, l.config.TokenPrecedence)
+	if !mfaToken.Found() {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "mfa_token_invalid",
+			"message": "No pending login, please login again",
+		})
+		return "", false
+	}
+	return mfaToken.Value, true
 }
 
 // setAuthCookies sets both access and refresh token cookies
-func (h *AuthHandler) setAuthCookies(c *gin.Context, accessToken, refreshToken string) {
-	secure := h.config.IsProduction()
+func (l *loginCookies) setAuthCookies(c *gin.Context, accessToken, refreshToken string) {
+	secure := l.config.IsProduction()
 
 	c.SetCookie(
 		"access_token",
 		accessToken,
-		h.tokenGenerator.GetAccessTokenExpiry(),
+		l.tokenGenerator.GetAccessTokenExpiry(),
 		"/",
 		"",
 		secure,
@@ -172,7 +450,7 @@ func (h *AuthHandler) setAuthCookies(c *gin.Context, accessToken, refreshToken s
 	c.SetCookie(
 		"refresh_token",
 		refreshToken,
-		h.tokenGenerator.GetRefreshTokenExpiry(),
+		l.tokenGenerator.GetRefreshTokenExpiry(),
 		"/",
 		"",
 		secure,
@@ -180,14 +458,35 @@ func (h *AuthHandler) setAuthCookies(c *gin.Context, accessToken, refreshToken s
 	)
 }
 
+// issueCSRFToken generates a CSRF token for a login session and stores it in
+// a cookie readable by the frontend
+func (l *loginCookies) issueCSRFToken(c *gin.Context, sessionID string) (string, error) {
+	csrfToken, err := l.csrfTokens.Generate(sessionID)
+	if err != nil {
+		return "", err
+	}
+
+	c.SetCookie(
+		"csrf_token",
+		csrfToken,
+		l.tokenGenerator.GetRefreshTokenExpiry(),
+		"/",
+		"",
+		l.config.IsProduction(),
+		false, // must be readable by JavaScript for the double-submit pattern
+	)
+
+	return csrfToken, nil
+}
+
 // setAccessTokenCookie sets only the access token cookie
-func (h *AuthHandler) setAccessTokenCookie(c *gin.Context, accessToken string) {
-	secure := h.config.IsProduction()
+func (l *loginCookies) setAccessTokenCookie(c *gin.Context, accessToken string) {
+	secure := l.config.IsProduction()
 
 	c.SetCookie(
 		"access_token",
 		accessToken,
-		h.tokenGenerator.GetAccessTokenExpiry(),
+		l.tokenGenerator.GetAccessTokenExpiry(),
 		"/",
 		"",
 		secure,
@@ -195,10 +494,32 @@ func (h *AuthHandler) setAccessTokenCookie(c *gin.Context, accessToken string) {
 	)
 }
 
+// setMFATokenCookie stores the MFA-pending token, scoped to the MFA endpoints
+func (l *loginCookies) setMFATokenCookie(c *gin.Context, mfaToken string) {
+	c.SetCookie(
+		mfaTokenCookieName,
+		mfaToken,
+		l.tokenGenerator.GetMFATokenExpiry(),
+		mfaTokenCookiePath,
+		"",
+		l.config.IsProduction(),
+		true, // HttpOnly
+	)
+}
+
+// clearMFATokenCookie removes the MFA-pending token
+func (l *loginCookies) clearMFATokenCookie(c *gin.Context) {
+	c.SetCookie(mfaTokenCookieName, "", -1, mfaTokenCookiePath, "", l.config.IsProduction(), true)
+}
+
 // clearAuthCookies removes authentication cookies
-func (h *AuthHandler) clearAuthCookies(c *gin.Context) {
-	secure := h.config.IsProduction()
+func (l *loginCookies) clearAuthCookies(c *gin.Context) {
+	clearAuthCookies(c, l.config.IsProduction())
+}
 
+// clearAuthCookies removes the access, refresh and CSRF token cookies
+func clearAuthCookies(c *gin.Context, secure bool) {
 	c.SetCookie("access_token", "", -1, "/", "", secure, true)
 	c.SetCookie("refresh_token", "", -1, "/", "", secure, true)
+	c.SetCookie("csrf_token", "", -1, "/", "", secure, false)
 }
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yuki5155/go-google-auth/internal/application/auth"
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/config"
+)
+
+// EmailLoginHandler handles email login link requests (thin controller)
+type EmailLoginHandler struct {
+	loginCookies
+	startUC *auth.StartEmailLoginUseCase
+	loginUC *auth.EmailLoginUseCase
+}
+
+// NewEmailLoginHandler creates a new EmailLoginHandler
+func NewEmailLoginHandler(
+	startUC *auth.StartEmailLoginUseCase,
+	loginUC *auth.EmailLoginUseCase,
+	tokenGenerator ports.TokenGenerator,
+	csrfTokens ports.CSRFTokenService,
+	config *config.Config,
+) *EmailLoginHandler {
+	return &EmailLoginHandler{
+		loginCookies: loginCookies{
+			tokenGenerator: tokenGenerator,
+			csrfTokens:     csrfTokens,
+			config:         config,
+		},
+		startUC: startUC,
+		loginUC: loginUC,
+	}
+}
+
+// Start emails a login link to the requested address
+func (h *EmailLoginHandler) Start(c *gin.Context) {
+	var req dto.EmailLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Missing or invalid email",
+		})
+		return
+	}
+
+	if err := h.startUC.Execute(c.Request.Context(), req); err != nil {
+		if err == shared.ErrEmptyEmail || err == shared.ErrInvalidEmail {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "invalid_request",
+				"message": "Missing or invalid email",
+			})
+			return
+		}
+		if errors.Is(err, shared.ErrEmailUnavailable) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"error":   "email_login_unavailable",
+				"message": "Email login is not available",
+			})
+			return
+		}
+		log.Printf("Failed to send login link: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_error",
+			"message": "Failed to send login link",
+		})
+		return
+	}
+
+	// The same response is sent whether or not the address has an account
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "If the address can receive email, a login link is on its way",
+	})
+}
+
+// Verify consumes a login link opened from an email. The browser navigated
+// here directly, so every outcome redirects back to the frontend.
+func (h *EmailLoginHandler) Verify(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		redirectToLogin(c, h.config.FrontendURL, url.Values{"error": {"magic_link_invalid"}})
+		return
+	}
+
+	result, err := h.loginUC.Execute(c.Request.Context(), token)
+	if err != nil {
+		switch err {
+		case shared.ErrInvalidMagicLink:
+			redirectToLogin(c, h.config.FrontendURL, url.Values{"error": {"magic_link_invalid"}})
+		case shared.ErrAccountSuspended:
+			redirectToLogin(c, h.config.FrontendURL, url.Values{"error": {"account_suspended"}})
+		default:
+			log.Printf("Email login failed: %v", err)
+			redirectToLogin(c, h.config.FrontendURL, url.Values{"error": {"authentication_failed"}})
+		}
+		return
+	}
+
+	if result.MFARequired {
+		// The second step is completed at POST /auth/mfa/verify or /auth/mfa/passkey
+		h.setMFATokenCookie(c, result.MFAToken)
+		redirectToLogin(c, h.config.FrontendURL, url.Values{
+			"mfa_required": {"true"},
+			"mfa_methods":  {strings.Join(result.MFAMethods, ",")},
+		})
+		return
+	}
+
+	// The frontend fetches a CSRF token from /auth/csrf on its first unsafe request
+	h.setAuthCookies(c, result.AccessToken, result.RefreshToken)
+	c.Redirect(http.StatusSeeOther, strings.TrimSuffix(h.config.FrontendURL, "/")+"/dashboard")
+}
+
+// redirectToLogin sends the browser to the frontend login page with query parameters
+func redirectToLogin(c *gin.Context, frontendURL string, query url.Values) {
+	c.Redirect(http.StatusSeeOther, strings.TrimSuffix(frontendURL, "/")+"/login?"+query.Encode())
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yuki5155/go-google-auth/internal/application/auth"
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/config"
+)
+
+// LoginAlertHandler handles the links in login alerts (thin controller)
+type LoginAlertHandler struct {
+	disownLoginUC *auth.DisownLoginUseCase
+	config        *config.Config
+}
+
+// NewLoginAlertHandler creates a new LoginAlertHandler
+func NewLoginAlertHandler(disownLoginUC *auth.DisownLoginUseCase, config *config.Config) *LoginAlertHandler {
+	return &LoginAlertHandler{
+		disownLoginUC: disownLoginUC,
+		config:        config,
+	}
+}
+
+// ConfirmDisownLogin sends the browser from a "this wasn't me" link to the
+// frontend page that asks the user to confirm. Mail scanners follow links,
+// so following one must not revoke anything.
+func (h *LoginAlertHandler) ConfirmDisownLogin(c *gin.Context) {
+	query := url.Values{}
+	if token := c.Query("token"); token != "" {
+		query.Set("token", token)
+	}
+	c.Redirect(http.StatusSeeOther, strings.TrimSuffix(h.config.FrontendURL, "/")+"/sessions/revoke?"+query.Encode())
+}
+
+// DisownLogin revokes the session a "this wasn't me" link was sent for,
+// once the user confirmed it
+func (h *LoginAlertHandler) DisownLogin(c *gin.Context) {
+	var req dto.DisownLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Missing token",
+		})
+		return
+	}
+
+	if err := h.disownLoginUC.Execute(c.Request.Context(), req.Token); err != nil {
+		if err == shared.ErrInvalidRevokeLink {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "revoke_link_invalid",
+				"message": "This link is invalid or has expired",
+			})
+			return
+		}
+		log.Printf("Failed to revoke disowned session: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_error",
+			"message": "Failed to sign out the session",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Session revoked",
+	})
+}
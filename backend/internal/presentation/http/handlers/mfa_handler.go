@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yuki5155/go-google-auth/internal/application/auth"
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+// MFAHandler handles second-factor management requests (thin controller)
+type MFAHandler struct {
+	getStatusUC               *auth.GetMFAStatusUseCase
+	enrollTOTPUC              *auth.EnrollTOTPUseCase
+	confirmTOTPUC             *auth.ConfirmTOTPUseCase
+	disableTOTPUC             *auth.DisableTOTPUseCase
+	regenerateRecoveryCodesUC *auth.RegenerateRecoveryCodesUseCase
+}
+
+// NewMFAHandler creates a new MFAHandler
+func NewMFAHandler(
+	getStatusUC *auth.GetMFAStatusUseCase,
+	enrollTOTPUC *auth.EnrollTOTPUseCase,
+	confirmTOTPUC *auth.ConfirmTOTPUseCase,
+	disableTOTPUC *auth.DisableTOTPUseCase,
+	regenerateRecoveryCodesUC *auth.RegenerateRecoveryCodesUseCase,
+) *MFAHandler {
+	return &MFAHandler{
+		getStatusUC:               getStatusUC,
+		enrollTOTPUC:              enrollTOTPUC,
+		confirmTOTPUC:             confirmTOTPUC,
+		disableTOTPUC:             disableTOTPUC,
+		regenerateRecoveryCodesUC: regenerateRecoveryCodesUC,
+	}
+}
+
+// Status returns the current user's MFA status
+func (h *MFAHandler) Status(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.getStatusUC.Execute(c.Request.Context(), claims)
+	if err != nil {
+		respondMFAError(c, err, "Failed to load MFA status")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// EnrollTOTP starts TOTP enrollment and returns the secret and otpauth URI
+func (h *MFAHandler) EnrollTOTP(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.enrollTOTPUC.Execute(c.Request.Context(), claims)
+	if err != nil {
+		respondMFAError(c, err, "Failed to start TOTP enrollment")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ConfirmTOTP activates TOTP with a code from the authenticator app
+func (h *MFAHandler) ConfirmTOTP(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.MFACodeRequest
+	if !bindMFACodeRequest(c, &req) {
+		return
+	}
+
+	result, err := h.confirmTOTPUC.Execute(c.Request.Context(), claims, req)
+	if err != nil {
+		respondMFAError(c, err, "Failed to confirm TOTP enrollment")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// DisableTOTP removes the TOTP factor; requires a TOTP or recovery code
+func (h *MFAHandler) DisableTOTP(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.MFACodeRequest
+	if !bindMFACodeRequest(c, &req) {
+		return
+	}
+
+	if err := h.disableTOTPUC.Execute(c.Request.Context(), claims, req); err != nil {
+		respondMFAError(c, err, "Failed to disable TOTP")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Two-factor authentication disabled",
+	})
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes; requires a TOTP code
+func (h *MFAHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.MFACodeRequest
+	if !bindMFACodeRequest(c, &req) {
+		return
+	}
+
+	result, err := h.regenerateRecoveryCodesUC.Execute(c.Request.Context(), claims, req)
+	if err != nil {
+		respondMFAError(c, err, "Failed to regenerate recovery codes")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// bindMFACodeRequest parses the request body, responding with 400 on failure
+func bindMFACodeRequest(c *gin.Context, req *dto.MFACodeRequest) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body",
+		})
+		return false
+	}
+	return true
+}
+
+// respondMFAError maps MFA use case errors to HTTP responses
+func respondMFAError(c *gin.Context, err error, message string) {
+	switch err {
+	case shared.ErrInvalidMFACode:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_mfa_code",
+			"message": "Invalid verification code",
+		})
+	case shared.ErrMFANotEnabled:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "mfa_not_enabled",
+			"message": "Two-factor authentication is not set up",
+		})
+	case shared.ErrMFAAlreadyEnabled:
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "mfa_already_enabled",
+			"message": "Two-factor authentication is already enabled",
+		})
+	case shared.ErrUnauthorized:
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User not found",
+		})
+	default:
+		log.Printf("MFA request failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_error",
+			"message": message,
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yuki5155/go-google-auth/internal/application/auth"
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+)
+
+// Grant types accepted by the token endpoint
+const (
+	grantTypeClientCredentials = "client_credentials"
+	grantTypeAuthorizationCode = "authorization_code"
+	grantTypeTokenExchange     = "urn:ietf:params:oauth:grant-type:token-exchange"
+)
+
+// errInvalidOAuthRequest is a malformed OAuth request (invalid_request)
+var errInvalidOAuthRequest = errors.New("invalid OAuth request")
+
+// OAuthHandler handles the OAuth 2.0 token, introspection and revocation
+// endpoints (thin controller)
+type OAuthHandler struct {
+	clientCredentialsUC *auth.ClientCredentialsUseCase
+	authorizationCodeUC *auth.AuthorizationCodeUseCase
+	tokenExchangeUC     *auth.TokenExchangeUseCase
+	introspectTokenUC   *auth.IntrospectTokenUseCase
+	revokeTokenUC       *auth.RevokeTokenUseCase
+}
+
+// NewOAuthHandler creates a new OAuthHandler
+func NewOAuthHandler(
+	clientCredentialsUC *auth.ClientCredentialsUseCase,
+	authorizationCodeUC *auth.AuthorizationCodeUseCase,
+	tokenExchangeUC *auth.TokenExchangeUseCase,
+	introspectTokenUC *auth.IntrospectTokenUseCase,
+	revokeTokenUC *auth.RevokeTokenUseCase,
+) *OAuthHandler {
+	return &OAuthHandler{
+		clientCredentialsUC: clientCredentialsUC,
+		authorizationCodeUC: authorizationCodeUC,
+		tokenExchangeUC:     tokenExchangeUC,
+		introspectTokenUC:   introspectTokenUC,
+		revokeTokenUC:       revokeTokenUC,
+	}
+}
+
+// Token is the token endpoint (RFC 6749, section 3.2). Requests are form
+// encoded, and clients authenticate with HTTP Basic authentication, a
+// client_secret in the body or a private_key_jwt client assertion; public
+// clients send only their client_id.
+func (h *OAuthHandler) Token(c *gin.Context) {
+	// Token responses must never be cached
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+
+	switch c.PostForm("grant_type") {
+	case grantTypeClientCredentials:
+		creds, err := clientCredentialsFromRequest(c)
+		if err != nil {
+			respondOAuthError(c, err)
+			return
+		}
+
+		result, err := h.clientCredentialsUC.Execute(c.Request.Context(), creds, c.PostForm("scope"))
+		if err != nil {
+			respondOAuthError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	case grantTypeAuthorizationCode:
+		creds, err := clientCredentialsFromRequest(c)
+		if err != nil {
+			respondOAuthError(c, err)
+			return
+		}
+
+		code, redirectURI, codeVerifier := c.PostForm("code"), c.PostForm("redirect_uri"), c.PostForm("code_verifier")
+		if code == "" || redirectURI == "" || codeVerifier == "" {
+			respondOAuthError(c, errInvalidOAuthRequest)
+			return
+		}
+
+		result, err := h.authorizationCodeUC.Execute(c.Request.Context(), creds, code, redirectURI, codeVerifier)
+		if err != nil {
+			respondOAuthError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	case grantTypeTokenExchange:
+		h.exchangeToken(c)
+	case "":
+		respondOAuthError(c, errInvalidOAuthRequest)
+	default:
+		respondOAuthError(c, shared.ErrUnsupportedGrantType)
+	}
+}
+
+// exchangeToken handles the token exchange grant (RFC 8693). Only access
+// tokens are accepted and issued, for a single audience.
+func (h *OAuthHandler) exchangeToken(c *gin.Context) {
+	creds, err := clientCredentialsFromRequest(c)
+	if err != nil {
+		respondOAuthError(c, err)
+		return
+	}
+
+	req := dto.TokenExchangeRequest{
+		SubjectToken: c.PostForm("subject_token"),
+		Audience:     c.PostForm("audience"),
+		Scope:        c.PostForm("scope"),
+	}
+	requestedType := c.PostForm("requested_token_type")
+	if req.SubjectToken == "" || c.PostForm("subject_token_type") != auth.TokenTypeAccessToken ||
+		(requestedType != "" && requestedType != auth.TokenTypeAccessToken) {
+		respondOAuthError(c, errInvalidOAuthRequest)
+		return
+	}
+	if req.Audience == "" || len(c.PostFormArray("audience")) > 1 {
+		respondOAuthError(c, shared.ErrInvalidTarget)
+		return
+	}
+
+	result, err := h.tokenExchangeUC.Execute(c.Request.Context(), creds, req)
+	if err != nil {
+		respondOAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Introspect is the token introspection endpoint (RFC 7662). Resource
+// servers authenticate as confidential clients and post the token, with an
+// optional token_type_hint.
+func (h *OAuthHandler) Introspect(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+
+	creds, err := clientCredentialsFromRequest(c)
+	if err != nil {
+		respondOAuthError(c, err)
+		return
+	}
+
+	token := c.PostForm("token")
+	if token == "" {
+		respondOAuthError(c, errInvalidOAuthRequest)
+		return
+	}
+
+	result, err := h.introspectTokenUC.Execute(c.Request.Context(), creds, token, c.PostForm("token_type_hint"))
+	if err != nil {
+		respondOAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Revoke is the token revocation endpoint (RFC 7009). It responds 200 with
+// an empty body whether or not the token was still valid.
+func (h *OAuthHandler) Revoke(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+
+	creds, err := clientCredentialsFromRequest(c)
+	if err != nil {
+		respondOAuthError(c, err)
+		return
+	}
+
+	token := c.PostForm("token")
+	if token == "" {
+		respondOAuthError(c, errInvalidOAuthRequest)
+		return
+	}
+
+	if err := h.revokeTokenUC.Execute(c.Request.Context(), creds, token, c.PostForm("token_type_hint")); err != nil {
+		respondOAuthError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// clientCredentialsFromRequest reads the client's credentials from the
+// Authorization header or the form. Using more than one method is an error.
+func clientCredentialsFromRequest(c *gin.Context) (dto.ClientCredentials, error) {
+	creds := dto.ClientCredentials{
+		ClientID:            c.PostForm("client_id"),
+		ClientSecret:        c.PostForm("client_secret"),
+		ClientAssertionType: c.PostForm("client_assertion_type"),
+		ClientAssertion:     c.PostForm("client_assertion"),
+	}
+
+	username, password, ok := c.Request.BasicAuth()
+	if !ok {
+		return creds, nil
+	}
+	if creds.ClientSecret != "" || creds.ClientAssertion != "" {
+		return creds, errInvalidOAuthRequest
+	}
+
+	// Basic credentials are form encoded first (RFC 6749, section 2.3.1)
+	clientID, err := url.QueryUnescape(username)
+	if err != nil {
+		return creds, shared.ErrInvalidClient
+	}
+	secret, err := url.QueryUnescape(password)
+	if err != nil {
+		return creds, shared.ErrInvalidClient
+	}
+	if creds.ClientID != "" && creds.ClientID != clientID {
+		return creds, errInvalidOAuthRequest
+	}
+
+	creds.ClientID = clientID
+	creds.ClientSecret = secret
+	return creds, nil
+}
+
+// respondOAuthError maps errors to OAuth error responses (RFC 6749, section
+// 5.2), which use "error_description" rather than "message"
+func respondOAuthError(c *gin.Context, err error) {
+	switch err {
+	case errInvalidOAuthRequest:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":             "invalid_request",
+			"error_description": "The request is missing a parameter or uses more than one client authentication method",
+		})
+	case shared.ErrInvalidClient:
+		c.Header("WWW-Authenticate", `Basic realm="oauth"`)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":             "invalid_client",
+			"error_description": "Client authentication failed",
+		})
+	case shared.ErrInvalidScope:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":             "invalid_scope",
+			"error_description": "The requested scope is invalid or not allowed for this client",
+		})
+	case shared.ErrInvalidGrant:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":             "invalid_grant",
+			"error_description": "The authorization code is invalid, expired or already used",
+		})
+	case shared.ErrUnauthorizedClient:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":             "unauthorized_client",
+			"error_description": "The client is not allowed to make this request",
+		})
+	case shared.ErrInvalidTarget:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":             "invalid_target",
+			"error_description": "Exactly one audience is required, and the client must be allowed to exchange tokens for it",
+		})
+	case shared.ErrInvalidSubjectToken:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":             "invalid_request",
+			"error_description": "The subject token is invalid, expired or revoked, or not a user's token",
+		})
+	case shared.ErrUnsupportedGrantType:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":             "unsupported_grant_type",
+			"error_description": "The grant type is not supported",
+		})
+	default:
+		log.Printf("OAuth request failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":             "server_error",
+			"error_description": "The request could not be completed",
+		})
+	}
+}
@@ -0,0 +1,292 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yuki5155/go-google-auth/internal/application/auth"
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/config"
+)
+
+// OIDCHandler handles the OpenID Connect provider endpoints that let other
+// applications sign users in with this service (thin controller)
+type OIDCHandler struct {
+	authorizeUC         *auth.AuthorizeUseCase
+	getConsentRequestUC *auth.GetConsentRequestUseCase
+	decideConsentUC     *auth.DecideConsentUseCase
+	getUserInfoUC       *auth.GetUserInfoUseCase
+	registerClientUC    *auth.RegisterClientUseCase
+	idTokens            ports.IDTokenIssuer
+	config              *config.Config
+}
+
+// NewOIDCHandler creates a new OIDCHandler
+func NewOIDCHandler(
+	authorizeUC *auth.AuthorizeUseCase,
+	getConsentRequestUC *auth.GetConsentRequestUseCase,
+	decideConsentUC *auth.DecideConsentUseCase,
+	getUserInfoUC *auth.GetUserInfoUseCase,
+	registerClientUC *auth.RegisterClientUseCase,
+	idTokens ports.IDTokenIssuer,
+	cfg *config.Config,
+) *OIDCHandler {
+	return &OIDCHandler{
+		authorizeUC:         authorizeUC,
+		getConsentRequestUC: getConsentRequestUC,
+		decideConsentUC:     decideConsentUC,
+		getUserInfoUC:       getUserInfoUC,
+		registerClientUC:    registerClientUC,
+		idTokens:            idTokens,
+		config:              cfg,
+	}
+}
+
+// Authorize is the authorization endpoint (OpenID Connect Core, section
+// 3.1.2). It sends the browser to the login page or the consent screen when
+// needed, and back to the client with a code once the user has approved it.
+// It must run after OptionalAuth.
+func (h *OIDCHandler) Authorize(c *gin.Context) {
+	req := dto.AuthorizationRequest{
+		ClientID:            c.Query("client_id"),
+		RedirectURI:         c.Query("redirect_uri"),
+		ResponseType:        c.Query("response_type"),
+		Scope:               c.Query("scope"),
+		State:               c.Query("state"),
+		Nonce:               c.Query("nonce"),
+		CodeChallenge:       c.Query("code_challenge"),
+		CodeChallengeMethod: c.Query("code_challenge_method"),
+		Prompt:              c.Query("prompt"),
+	}
+
+	var claims *ports.TokenClaims
+	if claimsInterface, exists := c.Get("claims"); exists {
+		claims, _ = claimsInterface.(*ports.TokenClaims)
+	}
+
+	result, err := h.authorizeUC.Execute(c.Request.Context(), req, claims)
+	if err != nil {
+		respondAuthorizeError(c, err)
+		return
+	}
+
+	frontendURL := strings.TrimSuffix(h.config.FrontendURL, "/")
+	switch {
+	case result.LoginRequired:
+		// The login page comes back here once the user has signed in
+		returnTo := h.config.OIDCIssuer + "/oauth/authorize?" + c.Request.URL.RawQuery
+		redirectToLogin(c, frontendURL, url.Values{"return_to": {returnTo}})
+	case result.ConsentRequest != "":
+		query := url.Values{"request": {result.ConsentRequest}}
+		c.Redirect(http.StatusSeeOther, frontendURL+"/oauth/consent?"+query.Encode())
+	default:
+		c.Redirect(http.StatusSeeOther, result.RedirectTo)
+	}
+}
+
+// ConsentRequest describes a pending consent request to the consent screen
+func (h *OIDCHandler) ConsentRequest(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.getConsentRequestUC.Execute(c.Request.Context(), claims.UserID, c.Query("request"))
+	if err != nil {
+		respondConsentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// DecideConsent records the user's answer to a consent request and returns
+// where the consent screen should send the browser
+func (h *OIDCHandler) DecideConsent(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ConsentDecisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request format",
+		})
+		return
+	}
+
+	result, err := h.decideConsentUC.Execute(c.Request.Context(), claims, req.Request, req.Approve)
+	if err != nil {
+		respondConsentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// UserInfo is the UserInfo endpoint (OpenID Connect Core, section 5.3). It
+// must run after Auth and RequireScope(ports.ScopeOpenID).
+func (h *OIDCHandler) UserInfo(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.getUserInfoUC.Execute(c.Request.Context(), claims)
+	if err != nil {
+		switch err {
+		case shared.ErrUnauthorized:
+			c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "invalid_token",
+				"message": "The user no longer exists",
+			})
+		default:
+			log.Printf("UserInfo request failed: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "internal_error",
+				"message": "Failed to get user info",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Discovery serves the OpenID Provider configuration (OpenID Connect Discovery, section 4)
+func (h *OIDCHandler) Discovery(c *gin.Context) {
+	issuer := h.config.OIDCIssuer
+	document := dto.OpenIDConfiguration{
+		Issuer:                            issuer,
+		AuthorizationEndpoint:             issuer + "/oauth/authorize",
+		TokenEndpoint:                     h.config.OAuthTokenURL,
+		UserInfoEndpoint:                  issuer + "/oauth/userinfo",
+		JWKSURI:                           issuer + "/.well-known/jwks.json",
+		IntrospectionEndpoint:             issuer + "/oauth/introspect",
+		RevocationEndpoint:                issuer + "/oauth/revoke",
+		ScopesSupported:                   ports.OIDCScopes,
+		ResponseTypesSupported:            []string{"code"},
+		GrantTypesSupported:               []string{grantTypeAuthorizationCode, grantTypeClientCredentials, grantTypeTokenExchange},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{"RS256"},
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "private_key_jwt", "none"},
+		CodeChallengeMethodsSupported:     []string{ports.PKCEMethodS256},
+		PromptValuesSupported:             []string{"none", "consent"},
+		ClaimsSupported: []string{
+			"sub", "iss", "aud", "exp", "iat", "auth_time", "nonce", "acr", "amr", "sid",
+			"name", "picture", "email", "email_verified",
+		},
+	}
+	if h.config.OIDCRegistrationToken != "" {
+		document.RegistrationEndpoint = issuer + "/oauth/register"
+	}
+
+	c.Header("Cache-Control", "public, max-age=3600")
+	c.JSON(http.StatusOK, document)
+}
+
+// JWKS serves the public keys ID tokens are verified with (RFC 7517)
+func (h *OIDCHandler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=3600")
+	c.JSON(http.StatusOK, gin.H{"keys": h.idTokens.PublicKeys()})
+}
+
+// Register is the dynamic client registration endpoint (RFC 7591). It
+// requires the initial access token as an "Authorization: Bearer" header.
+func (h *OIDCHandler) Register(c *gin.Context) {
+	accessToken, _ := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+
+	var req dto.ClientRegistrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":             "invalid_client_metadata",
+			"error_description": "The request body must be a JSON client metadata object",
+		})
+		return
+	}
+
+	result, err := h.registerClientUC.Execute(c.Request.Context(), accessToken, req)
+	if err != nil {
+		switch err {
+		case shared.ErrRegistrationDisabled:
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":             "access_denied",
+				"error_description": "Client registration is disabled",
+			})
+		case shared.ErrUnauthorized:
+			c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":             "invalid_token",
+				"error_description": "A valid initial access token is required",
+			})
+		case shared.ErrInvalidRedirectURI:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":             "invalid_redirect_uri",
+				"error_description": "redirect_uris must be https URLs, or http on a loopback address, without fragments",
+			})
+		case shared.ErrInvalidClientMetadata:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":             "invalid_client_metadata",
+				"error_description": "Unsupported token_endpoint_auth_method or scope; scope must include openid",
+			})
+		default:
+			log.Printf("Client registration failed: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":             "server_error",
+				"error_description": "The request could not be completed",
+			})
+		}
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusCreated, result)
+}
+
+// respondAuthorizeError responds to an authorization request that cannot be
+// redirected back to the client
+func respondAuthorizeError(c *gin.Context, err error) {
+	switch err {
+	case shared.ErrInvalidClient:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":             "invalid_client",
+			"error_description": "Unknown client_id",
+		})
+	case shared.ErrInvalidRedirectURI:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":             "invalid_request",
+			"error_description": "redirect_uri is missing or not registered for this client",
+		})
+	default:
+		log.Printf("Authorization request failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":             "server_error",
+			"error_description": "The request could not be completed",
+		})
+	}
+}
+
+// respondConsentError responds to a failed consent screen request
+func respondConsentError(c *gin.Context, err error) {
+	switch err {
+	case shared.ErrInvalidConsentRequest:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_consent_request",
+			"message": "The sign-in request is invalid or has expired; return to the application and try again",
+		})
+	default:
+		log.Printf("Consent request failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_error",
+			"message": "Failed to process consent",
+		})
+	}
+}
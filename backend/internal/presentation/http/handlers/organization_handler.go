@@ -0,0 +1,338 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yuki5155/go-google-auth/internal/application/auth"
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/application/tenancy"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/config"
+)
+
+// OrganizationHandler handles organization, membership and invitation
+// requests (thin controller)
+type OrganizationHandler struct {
+	loginCookies
+	createUC           *tenancy.CreateOrganizationUseCase
+	listUC             *tenancy.ListOrganizationsUseCase
+	getActiveUC        *tenancy.GetActiveOrganizationUseCase
+	selectUC           *auth.SelectOrganizationUseCase
+	inviteUC           *tenancy.InviteMemberUseCase
+	revokeInvitationUC *tenancy.RevokeInvitationUseCase
+	acceptInvitationUC *tenancy.AcceptInvitationUseCase
+	changeRoleUC       *tenancy.ChangeMemberRoleUseCase
+	removeMemberUC     *tenancy.RemoveMemberUseCase
+}
+
+// NewOrganizationHandler creates a new OrganizationHandler
+func NewOrganizationHandler(
+	createUC *tenancy.CreateOrganizationUseCase,
+	listUC *tenancy.ListOrganizationsUseCase,
+	getActiveUC *tenancy.GetActiveOrganizationUseCase,
+	selectUC *auth.SelectOrganizationUseCase,
+	inviteUC *tenancy.InviteMemberUseCase,
+	revokeInvitationUC *tenancy.RevokeInvitationUseCase,
+	acceptInvitationUC *tenancy.AcceptInvitationUseCase,
+	changeRoleUC *tenancy.ChangeMemberRoleUseCase,
+	removeMemberUC *tenancy.RemoveMemberUseCase,
+	tokenGenerator ports.TokenGenerator,
+	csrfTokens ports.CSRFTokenService,
+	config *config.Config,
+) *OrganizationHandler {
+	return &OrganizationHandler{
+		loginCookies: loginCookies{
+			tokenGenerator: tokenGenerator,
+			csrfTokens:     csrfTokens,
+			config:         config,
+		},
+		createUC:           createUC,
+		listUC:             listUC,
+		getActiveUC:        getActiveUC,
+		selectUC:           selectUC,
+		inviteUC:           inviteUC,
+		revokeInvitationUC: revokeInvitationUC,
+		acceptInvitationUC: acceptInvitationUC,
+		changeRoleUC:       changeRoleUC,
+		removeMemberUC:     removeMemberUC,
+	}
+}
+
+// List returns the organizations the current user belongs to
+func (h *OrganizationHandler) List(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.listUC.Execute(c.Request.Context(), claims)
+	if err != nil {
+		respondOrganizationError(c, err, "Failed to load organizations")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Create creates an organization owned by the current user
+func (h *OrganizationHandler) Create(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "A name is required",
+		})
+		return
+	}
+
+	result, err := h.createUC.Execute(c.Request.Context(), claims, req)
+	if err != nil {
+		respondOrganizationError(c, err, "Failed to create organization")
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// Select switches the organization the current user's tokens act in and
+// replaces the auth cookies with tokens carrying the new org_id claim
+func (h *OrganizationHandler) Select(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.SelectOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body",
+		})
+		return
+	}
+
+	result, err := h.selectUC.Execute(c.Request.Context(), claims, req)
+	if err != nil {
+		respondOrganizationError(c, err, "Failed to select organization")
+		return
+	}
+
+	h.completeLogin(c, result)
+}
+
+// GetActive returns the active organization with its members
+func (h *OrganizationHandler) GetActive(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.getActiveUC.Execute(c.Request.Context(), claims)
+	if err != nil {
+		respondOrganizationError(c, err, "Failed to load organization")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Invite emails an invitation to join the active organization
+func (h *OrganizationHandler) Invite(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.InviteMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "A valid email address and a role are required",
+		})
+		return
+	}
+
+	result, err := h.inviteUC.Execute(c.Request.Context(), claims, req)
+	if err != nil {
+		respondOrganizationError(c, err, "Failed to send invitation")
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// RevokeInvitation withdraws a pending invitation to the active organization
+func (h *OrganizationHandler) RevokeInvitation(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	if err := h.revokeInvitationUC.Execute(c.Request.Context(), claims, c.Param("id")); err != nil {
+		respondOrganizationError(c, err, "Failed to revoke invitation")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Invitation revoked",
+	})
+}
+
+// AcceptInvitation makes the current user a member of the organization an
+// invitation was sent for
+func (h *OrganizationHandler) AcceptInvitation(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.AcceptInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "An invitation token is required",
+		})
+		return
+	}
+
+	result, err := h.acceptInvitationUC.Execute(c.Request.Context(), claims, req)
+	if err != nil {
+		respondOrganizationError(c, err, "Failed to accept invitation")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ChangeMemberRole gives a member of the active organization another role
+func (h *OrganizationHandler) ChangeMemberRole(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ChangeMemberRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "A role is required",
+		})
+		return
+	}
+
+	if err := h.changeRoleUC.Execute(c.Request.Context(), claims, c.Param("userID"), req); err != nil {
+		respondOrganizationError(c, err, "Failed to change role")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Role changed",
+	})
+}
+
+// RemoveMember removes a member from the active organization; members leave
+// by removing themselves
+func (h *OrganizationHandler) RemoveMember(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	if err := h.removeMemberUC.Execute(c.Request.Context(), claims, c.Param("userID")); err != nil {
+		respondOrganizationError(c, err, "Failed to remove member")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Member removed",
+	})
+}
+
+// respondOrganizationError maps organization errors to HTTP responses
+func respondOrganizationError(c *gin.Context, err error, message string) {
+	if errors.Is(err, shared.ErrEmailUnavailable) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error":   "email_unavailable",
+			"message": "Invitations cannot be emailed",
+		})
+		return
+	}
+
+	switch err {
+	case shared.ErrInvalidOrganizationName, shared.ErrInvalidOrganizationRole, shared.ErrInvalidEmail, shared.ErrEmptyEmail:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": err.Error(),
+		})
+	case shared.ErrNoActiveOrganization:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "no_active_organization",
+			"message": "Select an organization first",
+		})
+	case shared.ErrNotOrganizationMember, shared.ErrOrganizationNotFound:
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "not_a_member",
+			"message": "Not a member of this organization",
+		})
+	case shared.ErrOrganizationPermissionDenied:
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "insufficient_role",
+			"message": "Your role in this organization does not allow this",
+		})
+	case shared.ErrInvitationEmailMismatch:
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "invitation_email_mismatch",
+			"message": "The invitation was sent to a different verified email address",
+		})
+	case shared.ErrInvitationNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "invitation_not_found",
+			"message": "Invitation not found or expired",
+		})
+	case shared.ErrLastOrganizationOwner:
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "last_owner",
+			"message": "An organization must keep at least one owner",
+		})
+	case shared.ErrAlreadyOrganizationMember:
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "already_a_member",
+			"message": "Already a member of this organization",
+		})
+	case shared.ErrTooManyInvitations:
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "invitation_limit_reached",
+			"message": "The maximum number of pending invitations is reached",
+		})
+	case shared.ErrAccountSuspended:
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "account_suspended",
+			"message": "This account has been suspended",
+		})
+	case shared.ErrSessionRevoked, shared.ErrSessionExpired:
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "session_revoked",
+			"message": "Session is no longer valid, please login again",
+		})
+	case shared.ErrMissingToken, shared.ErrUnauthorized, shared.ErrAccountDeleted:
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User not found",
+		})
+	default:
+		log.Printf("Organization request failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_error",
+			"message": message,
+		})
+	}
+}
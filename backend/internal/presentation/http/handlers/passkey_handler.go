@@ -0,0 +1,292 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yuki5155/go-google-auth/internal/application/auth"
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/config"
+)
+
+// PasskeyHandler handles passkey registration and login requests (thin controller)
+type PasskeyHandler struct {
+	loginCookies
+	registerUC *auth.RegisterPasskeyUseCase
+	listUC     *auth.ListPasskeysUseCase
+	removeUC   *auth.RemovePasskeyUseCase
+	loginUC    *auth.PasskeyLoginUseCase
+	mfaUC      *auth.PasskeyMFAUseCase
+}
+
+// NewPasskeyHandler creates a new PasskeyHandler
+func NewPasskeyHandler(
+	registerUC *auth.RegisterPasskeyUseCase,
+	listUC *auth.ListPasskeysUseCase,
+	removeUC *auth.RemovePasskeyUseCase,
+	loginUC *auth.PasskeyLoginUseCase,
+	mfaUC *auth.PasskeyMFAUseCase,
+	tokenGenerator ports.TokenGenerator,
+	csrfTokens ports.CSRFTokenService,
+	config *config.Config,
+) *PasskeyHandler {
+	return &PasskeyHandler{
+		loginCookies: loginCookies{
+			tokenGenerator: tokenGenerator,
+			csrfTokens:     csrfTokens,
+			config:         config,
+		},
+		registerUC: registerUC,
+		listUC:     listUC,
+		removeUC:   removeUC,
+		loginUC:    loginUC,
+		mfaUC:      mfaUC,
+	}
+}
+
+// RegistrationOptions starts registering a passkey for the current user
+func (h *PasskeyHandler) RegistrationOptions(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.registerUC.Begin(c.Request.Context(), claims)
+	if err != nil {
+		respondPasskeyError(c, err, "Failed to start passkey registration")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Register stores the passkey created with the registration options
+func (h *PasskeyHandler) Register(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.PasskeyRegistrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body",
+		})
+		return
+	}
+
+	result, err := h.registerUC.Finish(c.Request.Context(), claims, req)
+	if err != nil {
+		respondPasskeyError(c, err, "Failed to register passkey")
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// List returns the current user's passkeys
+func (h *PasskeyHandler) List(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.listUC.Execute(c.Request.Context(), claims)
+	if err != nil {
+		respondPasskeyError(c, err, "Failed to load passkeys")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Remove deletes one of the current user's passkeys
+func (h *PasskeyHandler) Remove(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	if err := h.removeUC.Execute(c.Request.Context(), claims, c.Param("id")); err != nil {
+		respondPasskeyError(c, err, "Failed to remove passkey")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Passkey removed",
+	})
+}
+
+// LoginOptions starts a passkey login
+func (h *PasskeyHandler) LoginOptions(c *gin.Context) {
+	result, err := h.loginUC.Begin(c.Request.Context())
+	if err != nil {
+		log.Printf("Failed to start passkey login: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_error",
+			"message": "Failed to start passkey login",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Login completes a passkey login and sets the auth cookies
+func (h *PasskeyHandler) Login(c *gin.Context) {
+	var req dto.PasskeyAssertionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body",
+		})
+		return
+	}
+
+	result, err := h.loginUC.Finish(c.Request.Context(), req)
+	if err != nil {
+		respondPasskeyLoginError(c, err)
+		return
+	}
+
+	h.completeLogin(c, result)
+}
+
+// MFAOptions starts verifying a passkey as the second factor of a pending login
+func (h *PasskeyHandler) MFAOptions(c *gin.Context) {
+	mfaToken, ok := h.mfaTokenFromRequest(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.mfaUC.Begin(c.Request.Context(), mfaToken)
+	if err != nil {
+		h.respondPasskeyMFAError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// VerifyMFA completes an MFA-pending login with a passkey
+func (h *PasskeyHandler) VerifyMFA(c *gin.Context) {
+	mfaToken, ok := h.mfaTokenFromRequest(c)
+	if !ok {
+		return
+	}
+
+	var req dto.PasskeyAssertionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body",
+		})
+		return
+	}
+
+	result, err := h.mfaUC.Finish(c.Request.Context(), mfaToken, req)
+	if err != nil {
+		h.respondPasskeyMFAError(c, err)
+		return
+	}
+
+	h.clearMFATokenCookie(c)
+	h.completeLogin(c, result)
+}
+
+// respondPasskeyMFAError maps passkey second-factor errors to HTTP responses
+func (h *PasskeyHandler) respondPasskeyMFAError(c *gin.Context, err error) {
+	switch err {
+	case shared.ErrMFANotEnabled:
+		// The login stays pending: the user may have another method
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "mfa_method_unavailable",
+			"message": "No passkeys are registered for this account",
+		})
+	case shared.ErrAccountSuspended:
+		h.clearMFATokenCookie(c)
+		respondPasskeyLoginError(c, err)
+	case ports.ErrExpiredToken, ports.ErrInvalidToken, shared.ErrUnauthorized, shared.ErrAccountDeleted:
+		h.clearMFATokenCookie(c)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "mfa_token_invalid",
+			"message": "Pending login is no longer valid, please login again",
+		})
+	default:
+		respondPasskeyLoginError(c, err)
+	}
+}
+
+// respondPasskeyLoginError maps passkey login errors to HTTP responses
+func respondPasskeyLoginError(c *gin.Context, err error) {
+	switch err {
+	case shared.ErrInvalidPasskey:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Malformed passkey response",
+		})
+	case shared.ErrPasskeyVerification, shared.ErrPasskeyChallengeInvalid,
+		shared.ErrPasskeyCloned, shared.ErrAccountDeleted:
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "passkey_verification_failed",
+			"message": "Passkey could not be verified",
+		})
+	case shared.ErrAccountSuspended:
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "account_suspended",
+			"message": "This account has been suspended",
+		})
+	default:
+		log.Printf("Passkey login failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_error",
+			"message": "Failed to verify passkey",
+		})
+	}
+}
+
+// respondPasskeyError maps passkey management errors to HTTP responses
+func respondPasskeyError(c *gin.Context, err error, message string) {
+	switch err {
+	case shared.ErrInvalidPasskey, shared.ErrInvalidPasskeyName:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": err.Error(),
+		})
+	case shared.ErrPasskeyVerification, shared.ErrPasskeyChallengeInvalid:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "passkey_verification_failed",
+			"message": "Passkey could not be verified",
+		})
+	case shared.ErrPasskeyNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "passkey_not_found",
+			"message": "Passkey not found",
+		})
+	case shared.ErrPasskeyAlreadyRegistered:
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "passkey_already_registered",
+			"message": "This passkey is already registered",
+		})
+	case shared.ErrTooManyPasskeys:
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "passkey_limit_reached",
+			"message": "The maximum number of passkeys is registered",
+		})
+	case shared.ErrUnauthorized:
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User not found",
+		})
+	default:
+		log.Printf("Passkey request failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_error",
+			"message": message,
+		})
+	}
+}
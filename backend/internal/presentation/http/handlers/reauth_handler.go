@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yuki5155/go-google-auth/internal/application/auth"
+	"github.com/yuki5155/go-google-auth/internal/application/dto"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/config"
+)
+
+// ReauthHandler handles step-up re-authentication requests (thin controller)
+type ReauthHandler struct {
+	loginCookies
+	reauthUC      *auth.ReauthenticateUseCase
+	startReauthUC *auth.StartReauthUseCase
+}
+
+// NewReauthHandler creates a new ReauthHandler
+func NewReauthHandler(
+	reauthUC *auth.ReauthenticateUseCase,
+	startReauthUC *auth.StartReauthUseCase,
+	tokenGenerator ports.TokenGenerator,
+	csrfTokens ports.CSRFTokenService,
+	config *config.Config,
+) *ReauthHandler {
+	return &ReauthHandler{
+		loginCookies: loginCookies{
+			tokenGenerator: tokenGenerator,
+			csrfTokens:     csrfTokens,
+			config:         config,
+		},
+		reauthUC:      reauthUC,
+		startReauthUC: startReauthUC,
+	}
+}
+
+// SendEmailLink emails the user a link to re-authenticate with, for users
+// who do not sign in with Google
+func (h *ReauthHandler) SendEmailLink(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	if err := h.startReauthUC.SendEmailLink(c.Request.Context(), claims); err != nil {
+		respondReauthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "A re-authentication link is on its way",
+	})
+}
+
+// PasskeyOptions returns the options for re-authenticating with one of the
+// user's passkeys
+func (h *ReauthHandler) PasskeyOptions(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.startReauthUC.PasskeyOptions(c.Request.Context(), claims)
+	if err != nil {
+		respondReauthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Reauthenticate verifies the user's credentials again and replaces the
+// auth cookies with tokens carrying a fresh auth_time
+func (h *ReauthHandler) Reauthenticate(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ReauthRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body",
+		})
+		return
+	}
+
+	result, err := h.reauthUC.Execute(c.Request.Context(), claims, req)
+	if err != nil {
+		respondReauthError(c, err)
+		return
+	}
+
+	h.completeLogin(c, result)
+}
+
+// respondReauthError maps re-authentication errors to HTTP responses
+func respondReauthError(c *gin.Context, err error) {
+	if errors.Is(err, shared.ErrEmailUnavailable) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error":   "email_unavailable",
+			"message": "Email is not available, re-authenticate another way",
+		})
+		return
+	}
+
+	switch err {
+	case shared.ErrMissingCredential:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "A credential or verification code is required",
+		})
+	case shared.ErrInvalidMFACode:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_mfa_code",
+			"message": "Invalid verification code",
+		})
+	case shared.ErrMFANotEnabled:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "mfa_not_enabled",
+			"message": "Two-factor authentication is not set up",
+		})
+	case shared.ErrInvalidMagicLink:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_email_token",
+			"message": "The link is invalid, expired or already used",
+		})
+	case shared.ErrInvalidPasskey:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Malformed passkey response",
+		})
+	case shared.ErrPasskeyVerification, shared.ErrPasskeyChallengeInvalid, shared.ErrPasskeyCloned:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "passkey_verification_failed",
+			"message": "Passkey could not be verified",
+		})
+	case shared.ErrPasskeyNotFound:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "no_passkeys",
+			"message": "No passkey is registered, re-authenticate another way",
+		})
+	case shared.ErrCredentialMismatch:
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "credential_mismatch",
+			"message": "The credential belongs to a different account",
+		})
+	case shared.ErrAccountSuspended:
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "account_suspended",
+			"message": "This account has been suspended",
+		})
+	case shared.ErrSessionRevoked, shared.ErrSessionExpired:
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "session_revoked",
+			"message": "Session is no longer valid, please login again",
+		})
+	case shared.ErrMissingToken, shared.ErrUnauthorized:
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User not authenticated",
+		})
+	default:
+		log.Printf("Re-authentication failed: %v", err)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "authentication_failed",
+			"message": "Failed to re-authenticate",
+		})
+	}
+}
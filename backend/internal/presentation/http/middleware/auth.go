@@ -1,17 +1,100 @@
 package middleware
 
 import (
+	"context"
+	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/apitoken"
+	"github.com/yuki5155/go-google-auth/internal/domain/audit"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/config"
+	"github.com/yuki5155/go-google-auth/internal/presentation/http/credentials"
 )
 
+// AccountStatusChecker reports whether a user account may still authenticate,
+// and whether an administrator may still use an impersonation token
+type AccountStatusChecker interface {
+	CheckStatus(ctx context.Context, userID string) error
+	CheckImpersonator(ctx context.Context, adminID string) error
+}
+
+// APITokenAuthenticator checks personal access tokens and returns claims in
+// the same form as for an access token
+type APITokenAuthenticator interface {
+	Authenticate(ctx context.Context, token string) (*ports.TokenClaims, error)
+}
+
+// RevokedTokenChecker reports whether a single token was revoked by its jti
+type RevokedTokenChecker interface {
+	IsRevoked(ctx context.Context, tokenID string) (bool, error)
+}
+
+// AuthOption configures the Auth and OptionalAuth middlewares
+type AuthOption func(*authOptions)
+
+// authOptions holds optional Auth middleware dependencies
+type authOptions struct {
+	statusChecker AccountStatusChecker
+	precedence    string
+	apiTokens     APITokenAuthenticator
+	revokedTokens RevokedTokenChecker
+	dpop          DPoPChecker
+}
+
+// WithAccountStatus rejects tokens belonging to suspended or deleted accounts
+func WithAccountStatus(checker AccountStatusChecker) AuthOption {
+	return func(o *authOptions) {
+		o.statusChecker = checker
+	}
+}
+
+// WithTokenPrecedence decides whether the access_token cookie or an
+// "Authorization: Bearer" header wins when a request carries both
+// (config.TokenPrecedenceHeader by default)
+func WithTokenPrecedence(precedence string) AuthOption {
+	return func(o *authOptions) {
+		o.precedence = precedence
+	}
+}
+
+// WithAPITokens also accepts personal access tokens ("pat_...") sent in an
+// "Authorization: Bearer" header
+func WithAPITokens(authenticator APITokenAuthenticator) AuthOption {
+	return func(o *authOptions) {
+		o.apiTokens = authenticator
+	}
+}
+
+// WithRevokedTokens rejects access tokens that were revoked at the
+// revocation endpoint
+func WithRevokedTokens(checker RevokedTokenChecker) AuthOption {
+	return func(o *authOptions) {
+		o.revokedTokens = checker
+	}
+}
+
+// newAuthOptions applies the given options
+func newAuthOptions(opts []AuthOption) *authOptions {
+	options := &authOptions{precedence: config.TokenPrecedenceHeader}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
 // Auth creates a middleware for JWT authentication using ports.TokenGenerator
-func Auth(tokenGen ports.TokenGenerator) gin.HandlerFunc {
+// The access token is read from the access_token cookie or an
+// "Authorization: Bearer" header, or an "Authorization: DPoP" header for
+// tokens bound to a DPoP key (see WithDPoP).
+func Auth(tokenGen ports.TokenGenerator, opts ...AuthOption) gin.HandlerFunc {
+	options := newAuthOptions(opts)
+
 	return func(c *gin.Context) {
-		accessToken, err := c.Cookie("access_token")
-		if err != nil {
+		accessToken := credentials.Lookup(c.Request, credentials.AccessTokenCookie, options.precedence)
+		if !accessToken.Found() {
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"error":   "unauthorized",
 				"message": "Access token not found",
@@ -20,7 +103,18 @@ func Auth(tokenGen ports.TokenGenerator) gin.HandlerFunc {
 			return
 		}
 
-		claims, err := tokenGen.ValidateAccessToken(accessToken)
+		if options.isAPIToken(accessToken) {
+			claims, err := options.apiTokens.Authenticate(c.Request.Context(), accessToken.Value)
+			if err != nil {
+				abortWithAPITokenError(c, err)
+				return
+			}
+			setClaims(c, claims)
+			c.Next()
+			return
+		}
+
+		claims, err := tokenGen.ValidateAccessToken(accessToken.Value)
 		if err != nil {
 			if ports.IsTokenExpired(err) {
 				c.JSON(http.StatusUnauthorized, gin.H{
@@ -38,42 +132,199 @@ func Auth(tokenGen ports.TokenGenerator) gin.HandlerFunc {
 			return
 		}
 
+		if err := options.checkDPoP(c, accessToken, claims); err != nil {
+			abortWithDPoPError(c, err)
+			return
+		}
+
+		if options.revokedTokens != nil && claims.TokenID != "" {
+			revoked, err := options.revokedTokens.IsRevoked(c.Request.Context(), claims.TokenID)
+			if err != nil {
+				log.Printf("Failed to check token revocation: %v", err)
+				c.JSON(http.StatusInternalServerError, gin.H{
+					"error":   "internal_error",
+					"message": "Failed to verify access token",
+				})
+				c.Abort()
+				return
+			}
+			if revoked {
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error":   "invalid_token",
+					"message": "Access token has been revoked",
+				})
+				c.Abort()
+				return
+			}
+		}
+
+		// Service client tokens have no user account to check
+		if options.statusChecker != nil && !claims.IsServiceClient() {
+			if err := options.statusChecker.CheckStatus(c.Request.Context(), claims.UserID); err != nil {
+				abortWithAccountStatusError(c, err)
+				return
+			}
+			if err := options.checkImpersonator(c.Request.Context(), claims); err != nil {
+				abortWithImpersonatorError(c, err)
+				return
+			}
+		}
+
 		// Set user information in context for handlers to use
-		c.Set("userID", claims.UserID)
-		c.Set("email", claims.Email)
-		c.Set("name", claims.Name)
-		c.Set("picture", claims.Picture)
-		c.Set("claims", claims)
+		setClaims(c, claims)
 
 		c.Next()
 	}
 }
 
 // OptionalAuth creates a middleware that validates JWT if present but doesn't require it
-func OptionalAuth(tokenGen ports.TokenGenerator) gin.HandlerFunc {
+func OptionalAuth(tokenGen ports.TokenGenerator, opts ...AuthOption) gin.HandlerFunc {
+	options := newAuthOptions(opts)
+
 	return func(c *gin.Context) {
-		accessToken, err := c.Cookie("access_token")
-		if err != nil {
+		accessToken := credentials.Lookup(c.Request, credentials.AccessTokenCookie, options.precedence)
+		if !accessToken.Found() {
 			// No token, but that's okay - continue without authentication
 			c.Next()
 			return
 		}
 
-		claims, err := tokenGen.ValidateAccessToken(accessToken)
+		if options.isAPIToken(accessToken) {
+			claims, err := options.apiTokens.Authenticate(c.Request.Context(), accessToken.Value)
+			if err == nil {
+				setClaims(c, claims)
+				c.Set("authenticated", true)
+			}
+			c.Next()
+			return
+		}
+
+		claims, err := tokenGen.ValidateAccessToken(accessToken.Value)
 		if err != nil {
 			// Invalid token, but still continue - user is just not authenticated
 			c.Next()
 			return
 		}
 
+		if err := options.checkDPoP(c, accessToken, claims); err != nil {
+			// Missing or invalid proof - treat the request as unauthenticated
+			c.Next()
+			return
+		}
+
+		if options.revokedTokens != nil && claims.TokenID != "" {
+			revoked, err := options.revokedTokens.IsRevoked(c.Request.Context(), claims.TokenID)
+			if err != nil {
+				log.Printf("Failed to check token revocation: %v", err)
+			}
+			if err != nil || revoked {
+				// Revoked, or not known not to be - treat the request as unauthenticated
+				c.Next()
+				return
+			}
+		}
+
+		if options.statusChecker != nil && !claims.IsServiceClient() {
+			if err := options.statusChecker.CheckStatus(c.Request.Context(), claims.UserID); err != nil {
+				// Inactive account - treat the request as unauthenticated
+				c.Next()
+				return
+			}
+			if err := options.checkImpersonator(c.Request.Context(), claims); err != nil {
+				c.Next()
+				return
+			}
+		}
+
 		// Set user information in context
-		c.Set("userID", claims.UserID)
-		c.Set("email", claims.Email)
-		c.Set("name", claims.Name)
-		c.Set("picture", claims.Picture)
-		c.Set("claims", claims)
+		setClaims(c, claims)
 		c.Set("authenticated", true)
 
 		c.Next()
 	}
 }
+
+// isAPIToken reports whether the request authenticates with a personal access
+// token; those are only accepted in the Authorization header
+func (o *authOptions) isAPIToken(token credentials.Token) bool {
+	return o.apiTokens != nil && token.Source == credentials.SourceHeader && apitoken.LooksLikeToken(token.Value)
+}
+
+// checkImpersonator checks that the administrator named by an impersonation
+// token is still an active administrator
+func (o *authOptions) checkImpersonator(ctx context.Context, claims *ports.TokenClaims) error {
+	adminID := claims.ImpersonatorID()
+	if adminID == "" {
+		return nil
+	}
+	return o.statusChecker.CheckImpersonator(ctx, adminID)
+}
+
+// setClaims stores the authenticated user's claims in the context for handlers to use
+func setClaims(c *gin.Context, claims *ports.TokenClaims) {
+	c.Set("userID", claims.UserID)
+	c.Set("email", claims.Email)
+	c.Set("name", claims.Name)
+	c.Set("picture", claims.Picture)
+	c.Set("claims", claims)
+
+	// Everything done with an impersonation token is audited as the
+	// administrator's doing
+	if adminID := claims.ImpersonatorID(); adminID != "" {
+		c.Request = c.Request.WithContext(audit.ContextWithActor(c.Request.Context(), adminID))
+	}
+}
+
+// abortWithAPITokenError responds to a rejected personal access token
+func abortWithAPITokenError(c *gin.Context, err error) {
+	switch err {
+	case shared.ErrInvalidAPIToken:
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "invalid_token",
+			"message": "Invalid, expired or revoked personal access token",
+		})
+		c.Abort()
+	case shared.ErrUnauthorized:
+		abortWithAccountStatusError(c, shared.ErrUserNotFound)
+	default:
+		abortWithAccountStatusError(c, err)
+	}
+}
+
+// abortWithImpersonatorError responds to an impersonation token whose
+// administrator was suspended or demoted
+func abortWithImpersonatorError(c *gin.Context, err error) {
+	switch err {
+	case shared.ErrAdminRequired, shared.ErrAccountSuspended, shared.ErrAccountDeleted, shared.ErrUserNotFound:
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "invalid_token",
+			"message": "The administrator may no longer impersonate users",
+		})
+		c.Abort()
+	default:
+		abortWithAccountStatusError(c, err)
+	}
+}
+
+// abortWithAccountStatusError responds to a failed account status check
+func abortWithAccountStatusError(c *gin.Context, err error) {
+	switch err {
+	case shared.ErrAccountSuspended:
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "account_suspended",
+			"message": "This account has been suspended",
+		})
+	case shared.ErrAccountDeleted, shared.ErrUserNotFound:
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "account_disabled",
+			"message": "This account is no longer available",
+		})
+	default:
+		log.Printf("Account status check failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_error",
+			"message": "Failed to verify account status",
+		})
+	}
+	c.Abort()
+}
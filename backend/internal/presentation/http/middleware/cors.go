@@ -1,40 +1,154 @@
 package middleware
 
 import (
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
 	"github.com/gin-gonic/gin"
-	"github.com/yuki5155/go-google-auth/internal/infrastructure/config"
+	"github.com/yuki5155/go-google-auth/internal/presentation/http/cors"
 )
 
-// CORS creates a CORS middleware with the given configuration
-func CORS(cfg *config.Config) gin.HandlerFunc {
+// CORS creates a CORS middleware enforcing the given policy.
+// routes is typically the engine's Routes method; it is read on the first
+// request so that preflight responses list only the methods each route supports.
+func CORS(policy *cors.Policy, routes func() gin.RoutesInfo) gin.HandlerFunc {
+	var (
+		once    sync.Once
+		methods routeMethods
+	)
+
 	return func(c *gin.Context) {
 		origin := c.Request.Header.Get("Origin")
 
-		// Check if origin is allowed
-		allowed := false
-		for _, allowedOrigin := range cfg.AllowedOrigins {
-			if origin == allowedOrigin || allowedOrigin == "*" {
-				allowed = true
-				break
-			}
-		}
+		if cors.IsPreflight(c.Request) {
+			once.Do(func() {
+				methods = newRouteMethods(routes())
+			})
+
+			headers, ok := policy.PreflightHeaders(
+				origin,
+				c.Request.Header.Get("Access-Control-Request-Method"),
+				c.Request.Header.Get("Access-Control-Request-Headers"),
+				methods.lookup(c.Request.URL.Path),
+			)
+			setHeaders(c, headers)
 
-		if allowed {
-			c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
-		} else if len(cfg.AllowedOrigins) > 0 {
-			// Use first origin as default
-			c.Writer.Header().Set("Access-Control-Allow-Origin", cfg.AllowedOrigins[0])
+			if !ok {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			c.AbortWithStatus(http.StatusNoContent)
+			return
 		}
 
-		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
+		setHeaders(c, policy.ResponseHeaders(origin))
 
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
+		if c.Request.Method == http.MethodOptions {
+			c.AbortWithStatus(http.StatusNoContent)
 			return
 		}
 
 		c.Next()
 	}
 }
+
+// setHeaders copies policy headers onto the response, appending to Vary
+func setHeaders(c *gin.Context, headers map[string]string) {
+	for name, value := range headers {
+		if name == "Vary" {
+			c.Writer.Header().Add(name, value)
+			continue
+		}
+		c.Writer.Header().Set(name, value)
+	}
+}
+
+// routeMethods lists registered route patterns and their methods, most
+// specific first
+type routeMethods []routePattern
+
+// routePattern is a registered route path and its methods
+type routePattern struct {
+	path    string
+	methods []string
+}
+
+// newRouteMethods groups registered routes by path and orders them so that
+// lookup prefers static segments over :params and :params over *wildcards
+func newRouteMethods(routes gin.RoutesInfo) routeMethods {
+	var methods routeMethods
+	index := make(map[string]int)
+	for _, route := range routes {
+		i, ok := index[route.Path]
+		if !ok {
+			i = len(methods)
+			index[route.Path] = i
+			methods = append(methods, routePattern{path: route.Path})
+		}
+		methods[i].methods = append(methods[i].methods, route.Method)
+	}
+
+	sort.SliceStable(methods, func(i, j int) bool {
+		return moreSpecific(methods[i].path, methods[j].path)
+	})
+	return methods
+}
+
+// lookup returns the methods of the most specific route matching path, or
+// nil if none matches
+func (m routeMethods) lookup(path string) []string {
+	for _, route := range m {
+		if matchRoute(route.path, path) {
+			return route.methods
+		}
+	}
+	return nil
+}
+
+// moreSpecific reports whether route pattern a should be tried before b: at
+// the first segment where they differ in kind, static beats :param beats
+// *wildcard; otherwise the longer pattern wins
+func moreSpecific(a, b string) bool {
+	aParts := strings.Split(strings.Trim(a, "/"), "/")
+	bParts := strings.Split(strings.Trim(b, "/"), "/")
+
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		if ka, kb := segmentKind(aParts[i]), segmentKind(bParts[i]); ka != kb {
+			return ka < kb
+		}
+	}
+	return len(aParts) > len(bParts)
+}
+
+// segmentKind ranks a route segment: 0 for static, 1 for :param, 2 for *wildcard
+func segmentKind(part string) int {
+	switch {
+	case strings.HasPrefix(part, "*"):
+		return 2
+	case strings.HasPrefix(part, ":"):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// matchRoute matches a path against a gin route pattern with :param and *wildcard segments
+func matchRoute(pattern, path string) bool {
+	patternParts := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i, part := range patternParts {
+		if strings.HasPrefix(part, "*") {
+			return true
+		}
+		if i >= len(pathParts) {
+			return false
+		}
+		if !strings.HasPrefix(part, ":") && part != pathParts[i] {
+			return false
+		}
+	}
+	return len(patternParts) == len(pathParts)
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouteMethods_PrefersMostSpecificPattern(t *testing.T) {
+	// Registered least specific first, so that map or registration order
+	// would pick the wrong route
+	methods := newRouteMethods(gin.RoutesInfo{
+		{Method: "GET", Path: "/files/*path"},
+		{Method: "DELETE", Path: "/files/:id"},
+		{Method: "PUT", Path: "/files/:id/content"},
+		{Method: "POST", Path: "/files/options"},
+		{Method: "GET", Path: "/files/:id"},
+	})
+
+	assert.Equal(t, []string{"POST"}, methods.lookup("/files/options"))
+	assert.Equal(t, []string{"DELETE", "GET"}, methods.lookup("/files/file-1"))
+	assert.Equal(t, []string{"PUT"}, methods.lookup("/files/file-1/content"))
+	assert.Equal(t, []string{"GET"}, methods.lookup("/files/file-1/versions/2"))
+	assert.Nil(t, methods.lookup("/other"))
+}
@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/presentation/http/cors"
+	"github.com/yuki5155/go-google-auth/internal/presentation/http/credentials"
+)
+
+const (
+	// CSRFCookieName is the cookie holding the double-submit CSRF token
+	CSRFCookieName = "csrf_token"
+	// CSRFHeaderName is the request header that must echo the CSRF token
+	CSRFHeaderName = "X-CSRF-Token"
+)
+
+// authCookieNames are the cookies that authenticate a request
+var authCookieNames = []string{credentials.AccessTokenCookie, credentials.RefreshTokenCookie}
+
+// CSRF creates a middleware that protects state-changing requests.
+// Unsafe methods must come from an allowed origin (checked via Origin and
+// Sec-Fetch-Site against the CORS policy), and requests carrying auth cookies
+// must also send the signed double-submit token in the X-CSRF-Token header,
+// issued for the login session of those cookies.
+func CSRF(tokens ports.CSRFTokenService, tokenGenerator ports.TokenGenerator, policy *cors.Policy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if isSafeMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		if !isTrustedRequestOrigin(c.Request, policy) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":   "invalid_origin",
+				"message": "Cross-site request rejected",
+			})
+			c.Abort()
+			return
+		}
+
+		// Requests without valid auth cookies cannot ride on the user's session
+		if !hasAuthCookie(c.Request) {
+			c.Next()
+			return
+		}
+		sessionID := credentials.SessionID(c.Request, tokenGenerator)
+		if sessionID == "" {
+			c.Next()
+			return
+		}
+
+		if !validCSRFToken(c.Request, tokens, sessionID) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":   "csrf_token_invalid",
+				"message": "Missing or invalid CSRF token",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// isSafeMethod returns true for methods that must not change state
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+// isTrustedRequestOrigin checks the Origin and Sec-Fetch-Site headers
+// Requests without either header come from non-browser clients and are allowed
+func isTrustedRequestOrigin(r *http.Request, policy *cors.Policy) bool {
+	if origin := r.Header.Get("Origin"); origin != "" {
+		return isSameHost(origin, r.Host) || policy.IsOriginAllowed(origin)
+	}
+
+	switch r.Header.Get("Sec-Fetch-Site") {
+	case "cross-site", "same-site":
+		// A browser sent a cross-origin request but withheld the Origin header
+		return false
+	default:
+		return true
+	}
+}
+
+// isSameHost reports whether origin points at the host serving the request
+func isSameHost(origin, host string) bool {
+	parsed, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return parsed.Host != "" && parsed.Host == host
+}
+
+// hasAuthCookie reports whether the request carries an authentication cookie
+func hasAuthCookie(r *http.Request) bool {
+	for _, name := range authCookieNames {
+		if cookie, err := r.Cookie(name); err == nil && cookie.Value != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// validCSRFToken checks that the header token matches the cookie and is
+// signed for the session
+func validCSRFToken(r *http.Request, tokens ports.CSRFTokenService, sessionID string) bool {
+	headerToken := r.Header.Get(CSRFHeaderName)
+	if headerToken == "" {
+		return false
+	}
+
+	cookie, err := r.Cookie(CSRFCookieName)
+	if err != nil || cookie.Value == "" {
+		return false
+	}
+
+	if subtle.ConstantTimeCompare([]byte(headerToken), []byte(cookie.Value)) != 1 {
+		return false
+	}
+
+	return tokens.Verify(headerToken, sessionID)
+}
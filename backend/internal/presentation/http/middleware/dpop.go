@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/shared"
+	"github.com/yuki5155/go-google-auth/internal/presentation/http/credentials"
+)
+
+// DPoPChecker verifies the DPoP proofs (RFC 9449) sent with tokens bound to
+// a client's key
+type DPoPChecker interface {
+	Verify(ctx context.Context, proof string, req ports.DPoPProofRequest) (*ports.DPoPProof, error)
+	Nonce() string
+}
+
+// errDPoPScheme is returned when a token is sent with the wrong
+// Authorization scheme for whether it is DPoP-bound
+var errDPoPScheme = errors.New("authorization scheme does not match token binding")
+
+// WithDPoP requires a DPoP proof with access tokens bound to a key (a cnf
+// claim), made with that key for the request
+func WithDPoP(checker DPoPChecker) AuthOption {
+	return func(o *authOptions) {
+		o.dpop = checker
+	}
+}
+
+// checkDPoP checks the proof of possession for a token's key. Unbound tokens
+// need no proof, but may not claim the DPoP scheme; bound tokens may not be
+// sent as plain Bearer tokens.
+func (o *authOptions) checkDPoP(c *gin.Context, token credentials.Token, claims *ports.TokenClaims) error {
+	if claims.DPoPKey == "" {
+		if token.Source == credentials.SourceDPoPHeader {
+			return errDPoPScheme
+		}
+		return nil
+	}
+	if o.dpop == nil || token.Source == credentials.SourceHeader {
+		return errDPoPScheme
+	}
+
+	proof, err := o.dpop.Verify(c.Request.Context(), credentials.Proof(c.Request), ports.DPoPProofRequest{
+		Method:      c.Request.Method,
+		Path:        c.Request.URL.Path,
+		AccessToken: token.Value,
+	})
+	c.Header(credentials.NonceHeader, o.dpop.Nonce())
+	if err != nil {
+		return err
+	}
+	if proof.Thumbprint != claims.DPoPKey {
+		return shared.ErrInvalidDPoPProof
+	}
+	return nil
+}
+
+// abortWithDPoPError responds to a rejected DPoP-bound request
+func abortWithDPoPError(c *gin.Context, err error) {
+	switch err {
+	case shared.ErrUseDPoPNonce:
+		c.Header("WWW-Authenticate", `DPoP error="use_dpop_nonce"`)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "use_dpop_nonce",
+			"message": "DPoP proof must include the nonce from the DPoP-Nonce header",
+		})
+	case shared.ErrInvalidDPoPProof:
+		c.Header("WWW-Authenticate", `DPoP error="invalid_dpop_proof"`)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "invalid_dpop_proof",
+			"message": "Invalid DPoP proof",
+		})
+	case errDPoPScheme:
+		c.Header("WWW-Authenticate", `DPoP error="invalid_token"`)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "invalid_token",
+			"message": "Access token was sent with the wrong authorization scheme",
+		})
+	default:
+		log.Printf("Failed to verify DPoP proof: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_error",
+			"message": "Failed to verify DPoP proof",
+		})
+	}
+	c.Abort()
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+)
+
+// BlockImpersonation creates a middleware for sensitive operations, such as
+// changing a user's credentials or deleting their account, that an
+// administrator impersonating the user may not perform. It must run after
+// Auth.
+func BlockImpersonation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claimsInterface, _ := c.Get("claims")
+		if claims, ok := claimsInterface.(*ports.TokenClaims); ok && claims.ImpersonatorID() != "" {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":   "impersonation_forbidden",
+				"message": "This action is not allowed while impersonating a user",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,238 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/config"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/ratelimit"
+	"github.com/yuki5155/go-google-auth/internal/presentation/http/clientip"
+	"github.com/yuki5155/go-google-auth/internal/presentation/http/credentials"
+)
+
+// Rate limit response headers (RFC 9110 Retry-After and the IETF RateLimit header fields)
+const (
+	RetryAfterHeader         = "Retry-After"
+	RateLimitLimitHeader     = "RateLimit-Limit"
+	RateLimitRemainingHeader = "RateLimit-Remaining"
+	RateLimitResetHeader     = "RateLimit-Reset"
+	RateLimitPolicyHeader    = "RateLimit-Policy"
+)
+
+// RateLimitKeyFunc returns the key a request is counted against; an empty key skips the rule
+type RateLimitKeyFunc func(c *gin.Context) string
+
+// RateLimitRule counts requests sharing a key against a limit
+type RateLimitRule struct {
+	Name  string // namespaces the keys, e.g. "login_ip"
+	Limit config.RateLimit
+	Key   RateLimitKeyFunc
+}
+
+// RateLimit creates a middleware that rejects requests exceeding any of the
+// rules with 429 Too Many Requests. Rules are checked in order and a rejected
+// request does not consume tokens from later rules. Store failures are logged
+// and the request is let through.
+func RateLimit(limiter *ratelimit.Limiter, rules ...RateLimitRule) gin.HandlerFunc {
+	active := make([]RateLimitRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Limit.Enabled() {
+			active = append(active, rule)
+		}
+	}
+
+	return func(c *gin.Context) {
+		if limiter == nil || len(active) == 0 {
+			c.Next()
+			return
+		}
+
+		var (
+			tightest     ratelimit.Result
+			tightestRule RateLimitRule
+			checked      bool
+		)
+
+		for _, rule := range active {
+			key := rule.Key(c)
+			if key == "" {
+				continue
+			}
+
+			policy := ratelimit.Policy{Limit: rule.Limit.Limit, Window: rule.Limit.Window}
+			result, err := limiter.Allow(c.Request.Context(), rule.Name+":"+key, policy)
+			if err != nil {
+				log.Printf("Rate limit check %s failed: %v", rule.Name, err)
+				continue
+			}
+
+			if !result.Allowed {
+				setRateLimitHeaders(c, rule, result)
+				c.Header(RetryAfterHeader, strconv.Itoa(ceilSeconds(result.RetryAfter)))
+				c.JSON(http.StatusTooManyRequests, gin.H{
+					"error":   "rate_limited",
+					"message": "Too many requests, please retry later",
+				})
+				c.Abort()
+				return
+			}
+
+			if !checked || result.Remaining < tightest.Remaining {
+				tightest, tightestRule, checked = result, rule, true
+			}
+		}
+
+		if checked {
+			setRateLimitHeaders(c, tightestRule, tightest)
+		}
+
+		c.Next()
+	}
+}
+
+// LoginRateLimit limits Google logins per client IP
+func LoginRateLimit(limiter *ratelimit.Limiter, cfg *config.Config) gin.HandlerFunc {
+	return RateLimit(limiter,
+		RateLimitRule{Name: "login_ip", Limit: cfg.RateLimitLogin, Key: ClientIPKey},
+	)
+}
+
+// EmailLoginRateLimit limits login link requests per client IP and per email
+// address, so a mailbox cannot be flooded from many IPs
+func EmailLoginRateLimit(limiter *ratelimit.Limiter, cfg *config.Config) gin.HandlerFunc {
+	return RateLimit(limiter,
+		RateLimitRule{Name: "login_ip", Limit: cfg.RateLimitLogin, Key: ClientIPKey},
+		RateLimitRule{Name: "email_login_address", Limit: cfg.RateLimitEmailLogin, Key: EmailBodyKey},
+	)
+}
+
+// RefreshRateLimit limits token refreshes per client IP and per refresh-token family
+func RefreshRateLimit(limiter *ratelimit.Limiter, tokenGen ports.TokenGenerator, cfg *config.Config) gin.HandlerFunc {
+	return RateLimit(limiter,
+		RateLimitRule{Name: "refresh_ip", Limit: cfg.RateLimitRefreshIP, Key: ClientIPKey},
+		RateLimitRule{Name: "refresh_family", Limit: cfg.RateLimitRefreshFamily, Key: RefreshFamilyKey(tokenGen, cfg.TokenPrecedence)},
+	)
+}
+
+// MFAVerifyRateLimit limits second-factor attempts on an MFA-pending login per
+// client IP and per user; the per-user budget is shared with MFACodeRateLimit
+func MFAVerifyRateLimit(limiter *ratelimit.Limiter, tokenGen ports.TokenGenerator, cfg *config.Config) gin.HandlerFunc {
+	return RateLimit(limiter,
+		RateLimitRule{Name: "mfa_ip", Limit: cfg.RateLimitMFA, Key: ClientIPKey},
+		RateLimitRule{Name: "mfa_user", Limit: cfg.RateLimitMFA, Key: MFATokenUserKey(tokenGen, cfg.TokenPrecedence)},
+	)
+}
+
+// MFACodeRateLimit limits second-factor attempts by authenticated users; it must run after Auth
+func MFACodeRateLimit(limiter *ratelimit.Limiter, cfg *config.Config) gin.HandlerFunc {
+	return RateLimit(limiter,
+		RateLimitRule{Name: "mfa_user", Limit: cfg.RateLimitMFA, Key: UserIDKey},
+	)
+}
+
+// APIRateLimit limits authenticated API requests per user; it must run after Auth
+func APIRateLimit(limiter *ratelimit.Limiter, cfg *config.Config) gin.HandlerFunc {
+	return RateLimit(limiter,
+		RateLimitRule{Name: "api_user", Limit: cfg.RateLimitAPIUser, Key: UserIDKey},
+	)
+}
+
+// ClientIPKey keys requests by client IP address (see clientip.FromContext)
+func ClientIPKey(c *gin.Context) string {
+	return clientip.FromContext(c)
+}
+
+// UserIDKey keys requests by the authenticated user set by the Auth middleware
+func UserIDKey(c *gin.Context) string {
+	return c.GetString("userID")
+}
+
+// maxKeyBodySize caps how much of a request body EmailBodyKey reads
+const maxKeyBodySize = 4096
+
+// EmailBodyKey keys requests by the normalized "email" field of their JSON
+// body, restoring the body for the handler. Requests without one are skipped;
+// the handler rejects them anyway.
+func EmailBodyKey(c *gin.Context) string {
+	if c.Request.Body == nil {
+		return ""
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxKeyBodySize))
+	if err != nil {
+		return ""
+	}
+	c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), c.Request.Body))
+
+	var req struct {
+		Email string `json:"email"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(req.Email))
+}
+
+// RefreshFamilyKey keys requests by the login session of their refresh token,
+// so every token rotated from the same login shares one bucket. Requests
+// without a valid refresh token are skipped; the handler rejects them anyway.
+// The token is found the same way as by the handler, in the cookie or body.
+func RefreshFamilyKey(tokenGen ports.TokenGenerator, precedence string) RateLimitKeyFunc {
+	return func(c *gin.Context) string {
+		refreshToken := credentials.RefreshToken(c.Request, precedence)
+		if !refreshToken.Found() {
+			return ""
+		}
+
+		claims, err := tokenGen.ValidateRefreshToken(refreshToken.Value)
+		if err != nil {
+			return ""
+		}
+
+		if claims.SessionID != "" {
+			return "session:" + claims.SessionID
+		}
+		// Tokens issued before sessions existed fall back to the user
+		return "user:" + claims.UserID
+	}
+}
+
+// MFATokenUserKey keys requests by the user of their MFA-pending token.
+// Requests without a valid token are skipped; the handler rejects them anyway.
+func MFATokenUserKey(tokenGen ports.TokenGenerator, precedence string) RateLimitKeyFunc {
+	return func(c *gin.Context) string {
+		mfaToken := credentials.Lookup(c.Request, credentials.MFATokenCookie, precedence)
+		if !mfaToken.Found() {
+			return ""
+		}
+
+		claims, err := tokenGen.ValidateMFAToken(mfaToken.Value)
+		if err != nil {
+			return ""
+		}
+		return claims.UserID
+	}
+}
+
+// setRateLimitHeaders describes the rule's current quota on the response
+func setRateLimitHeaders(c *gin.Context, rule RateLimitRule, result ratelimit.Result) {
+	c.Header(RateLimitLimitHeader, strconv.Itoa(result.Limit))
+	c.Header(RateLimitRemainingHeader, strconv.Itoa(result.Remaining))
+	c.Header(RateLimitResetHeader, strconv.Itoa(ceilSeconds(result.ResetAfter)))
+	c.Header(RateLimitPolicyHeader, fmt.Sprintf("%d;w=%d", rule.Limit.Limit, ceilSeconds(rule.Limit.Window)))
+}
+
+// ceilSeconds rounds a duration up to whole seconds
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+)
+
+// RequireRecentAuth creates a middleware for sensitive operations that
+// requires the user to have authenticated within maxAge. It must run after
+// Auth. Stale sessions get a 403 reauthentication_required response, which
+// the client resolves by calling POST /api/me/reauth and retrying.
+func RequireRecentAuth(maxAge time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claimsInterface, _ := c.Get("claims")
+		claims, ok := claimsInterface.(*ports.TokenClaims)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "unauthorized",
+				"message": "User not authenticated",
+			})
+			c.Abort()
+			return
+		}
+
+		if !claims.AuthenticatedWithin(maxAge, time.Now()) {
+			response := gin.H{
+				"error":   "reauthentication_required",
+				"message": "Please re-authenticate to continue",
+				"max_age": int(maxAge.Seconds()),
+			}
+			if !claims.AuthTime.IsZero() {
+				response["auth_time"] = claims.AuthTime.Unix()
+			}
+			c.JSON(http.StatusForbidden, response)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
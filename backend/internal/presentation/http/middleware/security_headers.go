@@ -0,0 +1,15 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/yuki5155/go-google-auth/internal/presentation/http/securityheaders"
+)
+
+// SecurityHeaders creates a middleware that sets the policy's security headers.
+// Headers are set before the handler runs so a handler can still override them.
+func SecurityHeaders(policy *securityheaders.Policy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		setHeaders(c, policy.Headers(c.Request.URL.Path))
+		c.Next()
+	}
+}
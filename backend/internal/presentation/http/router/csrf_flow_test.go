@@ -0,0 +1,43 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// csrfToken fetches a CSRF token with the given auth cookie, if any
+func (f *oidcFlow) csrfToken(cookie *http.Cookie) string {
+	f.t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/auth/csrf", nil)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	var body map[string]string
+	f.decode(f.do(req), &body)
+	return body["csrf_token"]
+}
+
+func TestCSRF_TokenIsBoundToSession(t *testing.T) {
+	f := newOIDCFlow(t)
+	victim := &http.Cookie{Name: "access_token", Value: f.signInUser("victim-1", "victim@example.com")}
+	attacker := &http.Cookie{Name: "access_token", Value: f.signInUser("attacker-1", "attacker@example.com")}
+
+	patch := func(token string) int {
+		req := httptest.NewRequest(http.MethodPatch, "/api/me", strings.NewReader(`{"name":"Renamed"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-CSRF-Token", token)
+		req.AddCookie(&http.Cookie{Name: "csrf_token", Value: token})
+		req.AddCookie(victim)
+		return f.do(req).Code
+	}
+
+	// A token planted next to the victim's cookies only works for its own session
+	assert.Equal(t, http.StatusForbidden, patch(f.csrfToken(nil)))
+	assert.Equal(t, http.StatusForbidden, patch(f.csrfToken(attacker)))
+	assert.Equal(t, http.StatusOK, patch(f.csrfToken(victim)))
+}
@@ -0,0 +1,125 @@
+package router
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dpopClient holds the key a client proves possession of
+type dpopClient struct {
+	t   *testing.T
+	key *ecdsa.PrivateKey
+}
+
+func newDPoPClient(t *testing.T) *dpopClient {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return &dpopClient{t: t, key: key}
+}
+
+// proof returns a DPoP proof for a request, bound to accessToken unless it is empty
+func (c *dpopClient) proof(method, htu, nonce, accessToken string) string {
+	c.t.Helper()
+
+	claims := gojwt.MapClaims{
+		"jti": rand.Text(),
+		"htm": method,
+		"htu": htu,
+		"iat": time.Now().Unix(),
+	}
+	if nonce != "" {
+		claims["nonce"] = nonce
+	}
+	if accessToken != "" {
+		sum := sha256.Sum256([]byte(accessToken))
+		claims["ath"] = base64.RawURLEncoding.EncodeToString(sum[:])
+	}
+
+	token := gojwt.NewWithClaims(gojwt.SigningMethodES256, claims)
+	token.Header["typ"] = "dpop+jwt"
+	token.Header["jwk"] = map[string]any{
+		"kty": "EC",
+		"crv": "P-256",
+		"x":   base64.RawURLEncoding.EncodeToString(c.key.X.FillBytes(make([]byte, 32))),
+		"y":   base64.RawURLEncoding.EncodeToString(c.key.Y.FillBytes(make([]byte, 32))),
+	}
+	proof, err := token.SignedString(c.key)
+	require.NoError(c.t, err)
+	return proof
+}
+
+// refresh sends a refresh token in the body with a DPoP proof, if one is given
+func (f *oidcFlow) refresh(refreshToken, proof string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/auth/refresh", strings.NewReader(`{"refresh_token":"`+refreshToken+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+	if proof != "" {
+		req.Header.Set("DPoP", proof)
+	}
+	return f.do(req)
+}
+
+func TestDPoPBoundTokens(t *testing.T) {
+	f := newOIDCFlow(t)
+	client := newDPoPClient(t)
+	_, refreshToken := f.signInTokens()
+	refreshURL := f.container.Config.OIDCIssuer + "/auth/refresh"
+	meURL := f.container.Config.OIDCIssuer + "/api/me"
+
+	// The first proof is answered with the nonce to use
+	w := f.refresh(refreshToken, client.proof(http.MethodPost, refreshURL, "", ""))
+	require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), "use_dpop_nonce")
+	nonce := w.Header().Get("DPoP-Nonce")
+	require.NotEmpty(t, nonce)
+
+	w = f.refresh(refreshToken, client.proof(http.MethodPost, refreshURL, nonce, ""))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var tokens map[string]any
+	f.decode(w, &tokens)
+	assert.Equal(t, "DPoP", tokens["token_type"])
+	accessToken := tokens["access_token"].(string)
+
+	// A bound token is refused without a proof of its key
+	req := httptest.NewRequest(http.MethodGet, "/api/me", nil)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	assert.Equal(t, http.StatusUnauthorized, f.do(req).Code)
+
+	proof := client.proof(http.MethodGet, meURL, nonce, accessToken)
+	req = httptest.NewRequest(http.MethodGet, "/api/me", nil)
+	req.Header.Set("Authorization", "DPoP "+accessToken)
+	req.Header.Set("DPoP", proof)
+	w = f.do(req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.NotEmpty(t, w.Header().Get("DPoP-Nonce"))
+
+	// Proofs cannot be replayed
+	req = httptest.NewRequest(http.MethodGet, "/api/me", nil)
+	req.Header.Set("Authorization", "DPoP "+accessToken)
+	req.Header.Set("DPoP", proof)
+	w = f.do(req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, `DPoP error="invalid_dpop_proof"`, w.Header().Get("WWW-Authenticate"))
+
+	// The session stays bound to the client's key
+	other := newDPoPClient(t)
+	w = f.refresh(refreshToken, other.proof(http.MethodPost, refreshURL, nonce, ""))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid_dpop_proof")
+	w = f.refresh(refreshToken, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = f.refresh(refreshToken, client.proof(http.MethodPost, refreshURL, nonce, ""))
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+}
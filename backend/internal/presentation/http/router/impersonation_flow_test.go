@@ -0,0 +1,148 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// signInAdmin creates the administrator configured by ADMIN_EMAILS with a
+// login session and returns their access token
+func (f *oidcFlow) signInAdmin() string {
+	f.t.Helper()
+
+	ctx := context.Background()
+	adminID, _ := user.NewUserID("admin-1")
+	email, _ := user.NewEmail("support@example.com", true)
+	admin, err := user.NewUser(adminID, email, user.NewProfile("Support", ""))
+	require.NoError(f.t, err)
+	require.NoError(f.t, f.container.UserRepository.Save(ctx, admin))
+
+	loginSession, err := session.NewSession(adminID, time.Now().Add(time.Hour))
+	require.NoError(f.t, err)
+	require.NoError(f.t, f.container.SessionRepository.Save(ctx, loginSession))
+
+	accessToken, _, err := f.container.TokenGenerator.GenerateTokenPair(ports.UserInfo{
+		UserID:    "admin-1",
+		Email:     "support@example.com",
+		Name:      "Support",
+		SessionID: loginSession.ID().Value(),
+		AMR:       []string{ports.AMRFederated},
+		AuthTime:  time.Now(),
+	})
+	require.NoError(f.t, err)
+	return accessToken
+}
+
+// bearer sends a request with a bearer token and an optional JSON body
+func (f *oidcFlow) bearer(method, path, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return f.do(req)
+}
+
+func TestAdminImpersonation(t *testing.T) {
+	t.Setenv("ADMIN_EMAILS", "support@example.com")
+	f := newOIDCFlow(t)
+	userToken, _ := f.signInTokens()
+	adminToken := f.signInAdmin()
+
+	// Only administrators may impersonate
+	w := f.bearer(http.MethodPost, "/admin/impersonate/admin-1", userToken, "")
+	require.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), "admin_required")
+
+	w = f.bearer(http.MethodPost, "/admin/impersonate/user-123", adminToken, `{"reason":"ticket 42"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Empty(t, w.Result().Cookies())
+	var started map[string]any
+	f.decode(w, &started)
+	impersonationToken := started["access_token"].(string)
+	assert.Equal(t, float64(15*60), started["expires_in"])
+
+	// The user is seen as they see themselves, flagged as impersonated
+	w = f.bearer(http.MethodGet, "/api/me", impersonationToken, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var me map[string]any
+	f.decode(w, &me)
+	assert.Equal(t, "user-123", me["user"].(map[string]any)["id"])
+	assert.Equal(t, "admin-1", me["impersonation"].(map[string]any)["admin_id"])
+
+	// Sensitive actions are blocked; the rest are attributed to the administrator
+	w = f.bearer(http.MethodDelete, "/api/me", impersonationToken, "")
+	assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), "impersonation_forbidden")
+	w = f.bearer(http.MethodPost, "/admin/impersonate/user-123", impersonationToken, "")
+	assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+
+	w = f.bearer(http.MethodPatch, "/api/me", impersonationToken, `{"locale":"en-GB"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// Stopping revokes the token
+	w = f.bearer(http.MethodDelete, "/admin/impersonate", impersonationToken, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = f.bearer(http.MethodGet, "/api/me", impersonationToken, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code, w.Body.String())
+
+	entries, err := f.container.AuditRepository.FindByUserID(context.Background(), "user-123")
+	require.NoError(t, err)
+	actors := map[string]string{}
+	for _, entry := range entries {
+		actors[entry.Action()] = entry.ActorID()
+	}
+	assert.Equal(t, map[string]string{
+		user.EventTypeImpersonationStarted: "admin-1",
+		user.EventTypeUserProfileUpdated:   "admin-1",
+		user.EventTypeImpersonationEnded:   "admin-1",
+	}, actors)
+}
+
+func TestAdminImpersonation_EndsWhenAdminIsSuspended(t *testing.T) {
+	t.Setenv("ADMIN_EMAILS", "support@example.com")
+	f := newOIDCFlow(t)
+	f.signInTokens()
+	adminToken := f.signInAdmin()
+
+	w := f.bearer(http.MethodPost, "/admin/impersonate/user-123", adminToken, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var started map[string]any
+	f.decode(w, &started)
+	impersonationToken := started["access_token"].(string)
+
+	// The configured address was granted the admin role
+	ctx := context.Background()
+	adminID, _ := user.NewUserID("admin-1")
+	admin, err := f.container.UserRepository.FindByID(ctx, adminID)
+	require.NoError(t, err)
+	assert.True(t, admin.HasRole(user.RoleAdmin))
+
+	require.NoError(t, admin.Suspend("left the company"))
+	require.NoError(t, f.container.UserRepository.Save(ctx, admin))
+
+	w = f.bearer(http.MethodGet, "/api/me", impersonationToken, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), "invalid_token")
+}
+
+func TestAdminImpersonation_RequiresConfiguredAdmin(t *testing.T) {
+	f := newOIDCFlow(t)
+	f.signInTokens()
+	adminToken := f.signInAdmin()
+
+	w := f.bearer(http.MethodPost, "/admin/impersonate/user-123", adminToken, "")
+	assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), "admin_required")
+}
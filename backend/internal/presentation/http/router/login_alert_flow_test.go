@@ -0,0 +1,55 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+)
+
+func TestDisownLogin_RevokesOnlyOnConfirmation(t *testing.T) {
+	f := newOIDCFlow(t)
+	claims, err := f.container.TokenGenerator.ValidateAccessToken(f.signInUser("user-1", "user@example.com"))
+	require.NoError(t, err)
+	token, err := f.container.RevokeLinks.Generate("user-1", claims.SessionID, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	sessionRevoked := func() bool {
+		sessionID, _ := session.NewSessionID(claims.SessionID)
+		loginSession, err := f.container.SessionRepository.FindByID(context.Background(), sessionID)
+		require.NoError(t, err)
+		return loginSession.IsRevoked()
+	}
+
+	// Following the emailed link, as mail scanners do, only leads to the confirmation page
+	w := f.do(httptest.NewRequest(http.MethodGet, "/auth/sessions/revoke?token="+url.QueryEscape(token), nil))
+	require.Equal(t, http.StatusSeeOther, w.Code)
+	location, err := url.Parse(w.Header().Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, "/sessions/revoke", location.Path)
+	assert.Equal(t, token, location.Query().Get("token"))
+	assert.False(t, sessionRevoked())
+
+	confirm := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/auth/sessions/revoke", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return f.do(req)
+	}
+
+	w = confirm(`{"token":"invalid"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), "revoke_link_invalid")
+	assert.False(t, sessionRevoked())
+
+	w = confirm(`{"token":"` + token + `"}`)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.True(t, sessionRevoked())
+}
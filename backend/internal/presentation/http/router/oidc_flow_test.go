@@ -0,0 +1,431 @@
+package router
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/serviceclient"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/config"
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/container"
+)
+
+const (
+	flowRegistrationToken = "initial-access-token"
+	flowRedirectURI       = "https://wiki.example.com/callback"
+	flowCodeVerifier      = "a-code-verifier-that-is-at-least-forty-three-characters"
+)
+
+// oidcFlow drives the OpenID Connect endpoints of a fully wired router
+type oidcFlow struct {
+	t         *testing.T
+	router    *gin.Engine
+	container *container.Container
+}
+
+func newOIDCFlow(t *testing.T) *oidcFlow {
+	t.Helper()
+
+	t.Setenv("JWT_SECRET", "oidc-flow-test-secret-0123456789abcdef")
+	t.Setenv("OIDC_REGISTRATION_TOKEN", flowRegistrationToken)
+	gin.SetMode(gin.TestMode)
+
+	c := container.NewContainer(config.Load())
+	return &oidcFlow{t: t, router: Setup(c), container: c}
+}
+
+func (f *oidcFlow) do(req *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, req)
+	return w
+}
+
+// decode unmarshals a JSON response body
+func (f *oidcFlow) decode(w *httptest.ResponseRecorder, v any) {
+	f.t.Helper()
+	require.NoError(f.t, json.Unmarshal(w.Body.Bytes(), v), w.Body.String())
+}
+
+// registerClient registers a confidential relying party and returns its credentials
+func (f *oidcFlow) registerClient() (clientID, secret string) {
+	f.t.Helper()
+
+	body := `{"client_name":"Wiki","redirect_uris":["` + flowRedirectURI + `"],"scope":"openid profile email"}`
+	req := httptest.NewRequest(http.MethodPost, "/oauth/register", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+flowRegistrationToken)
+	w := f.do(req)
+	require.Equal(f.t, http.StatusCreated, w.Code, w.Body.String())
+
+	var registered map[string]any
+	f.decode(w, &registered)
+	return registered["client_id"].(string), registered["client_secret"].(string)
+}
+
+// signIn creates a user with a login session and returns their access token cookie
+func (f *oidcFlow) signIn() *http.Cookie {
+	f.t.Helper()
+
+	accessToken, _ := f.signInTokens()
+	return &http.Cookie{Name: "access_token", Value: accessToken}
+}
+
+// signInTokens creates a user with a login session and returns their tokens
+func (f *oidcFlow) signInTokens() (accessToken, refreshToken string) {
+	f.t.Helper()
+
+	ctx := context.Background()
+	userID, _ := user.NewUserID("user-123")
+	email, _ := user.NewEmail("alice@example.com", true)
+	domainUser, err := user.NewUser(userID, email, user.NewProfile("Alice", "https://example.com/alice.jpg"))
+	require.NoError(f.t, err)
+	require.NoError(f.t, f.container.UserRepository.Save(ctx, domainUser))
+
+	loginSession, err := session.NewSession(userID, time.Now().Add(time.Hour))
+	require.NoError(f.t, err)
+	require.NoError(f.t, f.container.SessionRepository.Save(ctx, loginSession))
+
+	accessToken, refreshToken, err = f.container.TokenGenerator.GenerateTokenPair(ports.UserInfo{
+		UserID:    "user-123",
+		Email:     "alice@example.com",
+		Name:      "Alice",
+		SessionID: loginSession.ID().Value(),
+		AMR:       []string{ports.AMRFederated},
+		AuthTime:  time.Now(),
+	})
+	require.NoError(f.t, err)
+	return accessToken, refreshToken
+}
+
+// authorize sends an authentication request and returns where it redirects to
+func (f *oidcFlow) authorize(clientID string, cookie *http.Cookie) *url.URL {
+	f.t.Helper()
+
+	sum := sha256.Sum256([]byte(flowCodeVerifier))
+	query := url.Values{
+		"client_id":             {clientID},
+		"redirect_uri":          {flowRedirectURI},
+		"response_type":         {"code"},
+		"scope":                 {"openid email"},
+		"state":                 {"af0ifjsldkj"},
+		"nonce":                 {"n-0S6_WzA2Mj"},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(sum[:])},
+		"code_challenge_method": {"S256"},
+	}
+	req := httptest.NewRequest(http.MethodGet, "/oauth/authorize?"+query.Encode(), nil)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	w := f.do(req)
+	require.Equal(f.t, http.StatusSeeOther, w.Code, w.Body.String())
+
+	location, err := url.Parse(w.Header().Get("Location"))
+	require.NoError(f.t, err)
+	return location
+}
+
+// approveConsent answers the consent screen and returns where it sends the browser
+func (f *oidcFlow) approveConsent(request string, cookie *http.Cookie) *url.URL {
+	f.t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/oauth/consent?request="+url.QueryEscape(request), nil)
+	req.AddCookie(cookie)
+	w := f.do(req)
+	require.Equal(f.t, http.StatusOK, w.Code, w.Body.String())
+	var described map[string]any
+	f.decode(w, &described)
+	assert.Equal(f.t, "Wiki", described["client_name"])
+
+	// Cookie-authenticated requests need the double-submit CSRF token
+	req = httptest.NewRequest(http.MethodGet, "/auth/csrf", nil)
+	req.AddCookie(cookie)
+	w = f.do(req)
+	var csrf map[string]string
+	f.decode(w, &csrf)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/oauth/consent", strings.NewReader(`{"request":"`+request+`","approve":true}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-CSRF-Token", csrf["csrf_token"])
+	req.AddCookie(&http.Cookie{Name: "csrf_token", Value: csrf["csrf_token"]})
+	req.AddCookie(cookie)
+	w = f.do(req)
+	require.Equal(f.t, http.StatusOK, w.Code, w.Body.String())
+
+	var decision map[string]string
+	f.decode(w, &decision)
+	location, err := url.Parse(decision["redirect_to"])
+	require.NoError(f.t, err)
+	return location
+}
+
+// redeem exchanges an authorization code at the token endpoint
+func (f *oidcFlow) redeem(clientID, secret, code string) *httptest.ResponseRecorder {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {flowRedirectURI},
+		"code_verifier": {flowCodeVerifier},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/oauth/token", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(clientID, secret)
+	return f.do(req)
+}
+
+// verifyIDToken checks an ID token against the published JWKS and returns its claims
+func (f *oidcFlow) verifyIDToken(idToken, clientID string) gojwt.MapClaims {
+	f.t.Helper()
+
+	var discovery map[string]any
+	f.decode(f.do(httptest.NewRequest(http.MethodGet, "/.well-known/openid-configuration", nil)), &discovery)
+	jwksURL, err := url.Parse(discovery["jwks_uri"].(string))
+	require.NoError(f.t, err)
+
+	var jwks struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	f.decode(f.do(httptest.NewRequest(http.MethodGet, jwksURL.Path, nil)), &jwks)
+	require.Len(f.t, jwks.Keys, 1)
+
+	n, err := base64.RawURLEncoding.DecodeString(jwks.Keys[0]["n"])
+	require.NoError(f.t, err)
+	e, err := base64.RawURLEncoding.DecodeString(jwks.Keys[0]["e"])
+	require.NoError(f.t, err)
+	publicKey := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+
+	claims := gojwt.MapClaims{}
+	token, err := gojwt.ParseWithClaims(idToken, claims, func(token *gojwt.Token) (any, error) {
+		assert.Equal(f.t, jwks.Keys[0]["kid"], token.Header["kid"])
+		return publicKey, nil
+	},
+		gojwt.WithValidMethods([]string{"RS256"}),
+		gojwt.WithIssuer(discovery["issuer"].(string)),
+		gojwt.WithAudience(clientID),
+	)
+	require.NoError(f.t, err)
+	require.True(f.t, token.Valid)
+	return claims
+}
+
+func TestOIDCAuthorizationCodeFlow(t *testing.T) {
+	f := newOIDCFlow(t)
+	clientID, secret := f.registerClient()
+
+	// Without a session the user is sent to log in, and comes back here afterwards
+	location := f.authorize(clientID, nil)
+	assert.Equal(t, "/login", location.Path)
+	assert.Contains(t, location.Query().Get("return_to"), "/oauth/authorize?")
+
+	// Signed in but not yet approved: the consent screen
+	cookie := f.signIn()
+	location = f.authorize(clientID, cookie)
+	require.Equal(t, "/oauth/consent", location.Path)
+
+	// Approving it redirects back to the client with a code
+	location = f.approveConsent(location.Query().Get("request"), cookie)
+	assert.Equal(t, "wiki.example.com", location.Host)
+	assert.Equal(t, "af0ifjsldkj", location.Query().Get("state"))
+	code := location.Query().Get("code")
+	require.NotEmpty(t, code)
+
+	w := f.redeem(clientID, secret, code)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	var tokens map[string]any
+	f.decode(w, &tokens)
+	assert.Equal(t, "Bearer", tokens["token_type"])
+	assert.Equal(t, "email openid", tokens["scope"])
+
+	idClaims := f.verifyIDToken(tokens["id_token"].(string), clientID)
+	assert.Equal(t, "user-123", idClaims["sub"])
+	assert.Equal(t, "n-0S6_WzA2Mj", idClaims["nonce"])
+	assert.Equal(t, "alice@example.com", idClaims["email"])
+	assert.Nil(t, idClaims["name"], "the profile scope was not requested")
+
+	// A code can only be redeemed once
+	w = f.redeem(clientID, secret, code)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid_grant")
+
+	// The access token reads the user's claims from the UserInfo endpoint...
+	req := httptest.NewRequest(http.MethodGet, "/oauth/userinfo", nil)
+	req.Header.Set("Authorization", "Bearer "+tokens["access_token"].(string))
+	w = f.do(req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.JSONEq(t, `{"sub":"user-123","email":"alice@example.com","email_verified":true}`, w.Body.String())
+
+	// ...but does not reach the user's own API
+	req = httptest.NewRequest(http.MethodGet, "/api/me", nil)
+	req.Header.Set("Authorization", "Bearer "+tokens["access_token"].(string))
+	w = f.do(req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// Once approved, signing in again skips the consent screen
+	location = f.authorize(clientID, cookie)
+	assert.Equal(t, "wiki.example.com", location.Host)
+	assert.NotEmpty(t, location.Query().Get("code"))
+}
+
+func TestOIDCAuthorizationCodeFlow_WrongVerifier(t *testing.T) {
+	f := newOIDCFlow(t)
+	clientID, secret := f.registerClient()
+	cookie := f.signIn()
+
+	location := f.approveConsent(f.authorize(clientID, cookie).Query().Get("request"), cookie)
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {location.Query().Get("code")},
+		"redirect_uri":  {flowRedirectURI},
+		"code_verifier": {strings.Repeat("x", 43)},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/oauth/token", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(clientID, secret)
+	w := f.do(req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid_grant")
+}
+
+func TestOIDCAuthorize_RevokedTokenIsSignedOut(t *testing.T) {
+	f := newOIDCFlow(t)
+	clientID, _ := f.registerClient()
+	cookie := f.signIn()
+
+	claims, err := f.container.TokenGenerator.ValidateAccessToken(cookie.Value)
+	require.NoError(t, err)
+	require.NoError(t, f.container.RevokedTokens.Revoke(context.Background(), claims.TokenID, claims.ExpiresAt))
+
+	location := f.authorize(clientID, cookie)
+	assert.Equal(t, "/login", location.Path)
+}
+
+// postClientForm posts a form to an OAuth endpoint as the given client
+func (f *oidcFlow) postClientForm(path, clientID, secret string, form url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(clientID, secret)
+	return f.do(req)
+}
+
+// introspect returns the introspection response for token
+func (f *oidcFlow) introspect(clientID, secret, token string) map[string]any {
+	f.t.Helper()
+
+	w := f.postClientForm("/oauth/introspect", clientID, secret, url.Values{"token": {token}})
+	require.Equal(f.t, http.StatusOK, w.Code, w.Body.String())
+	var introspection map[string]any
+	f.decode(w, &introspection)
+	return introspection
+}
+
+func TestOIDCTokenIntrospectionAndRevocation(t *testing.T) {
+	f := newOIDCFlow(t)
+	clientID, secret := f.registerClient()
+	cookie := f.signIn()
+
+	location := f.approveConsent(f.authorize(clientID, cookie).Query().Get("request"), cookie)
+	w := f.redeem(clientID, secret, location.Query().Get("code"))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var tokens map[string]any
+	f.decode(w, &tokens)
+	accessToken := tokens["access_token"].(string)
+
+	introspection := f.introspect(clientID, secret, accessToken)
+	assert.Equal(t, true, introspection["active"])
+	assert.Equal(t, clientID, introspection["client_id"])
+	assert.Equal(t, "user-123", introspection["sub"])
+	assert.Equal(t, "email openid", introspection["scope"])
+
+	// Clients must authenticate to introspect
+	w = f.postClientForm("/oauth/introspect", clientID, "wrong-secret", url.Values{"token": {accessToken}})
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// Revoking the client's token leaves the user's own session alone
+	w = f.postClientForm("/oauth/revoke", clientID, secret, url.Values{"token": {accessToken}, "token_type_hint": {"access_token"}})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, map[string]any{"active": false}, f.introspect(clientID, secret, accessToken))
+	assert.Equal(t, true, f.introspect(clientID, secret, cookie.Value)["active"])
+
+	req := httptest.NewRequest(http.MethodGet, "/oauth/userinfo", nil)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	assert.Equal(t, http.StatusUnauthorized, f.do(req).Code)
+
+	// Revoking the user's own token ends their session
+	w = f.postClientForm("/oauth/revoke", clientID, secret, url.Values{"token": {cookie.Value}})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, false, f.introspect(clientID, secret, cookie.Value)["active"])
+
+	// Unknown tokens are not an error
+	w = f.postClientForm("/oauth/revoke", clientID, secret, url.Values{"token": {"not-a-token"}})
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestTokenExchange(t *testing.T) {
+	clients, err := json.Marshal([]map[string]any{{
+		"client_id":                "orders",
+		"secret_sha256":            serviceclient.HashSecret("s3cret"),
+		"scopes":                   []string{"inventory:read"},
+		"token_exchange_audiences": []string{"inventory"},
+	}})
+	require.NoError(t, err)
+	clientsFile := filepath.Join(t.TempDir(), "clients.json")
+	require.NoError(t, os.WriteFile(clientsFile, clients, 0o600))
+	t.Setenv("SERVICE_CLIENTS_FILE", clientsFile)
+
+	f := newOIDCFlow(t)
+	cookie := f.signIn()
+
+	w := f.postClientForm("/oauth/token", "orders", "s3cret", url.Values{
+		"grant_type":         {"urn:ietf:params:oauth:grant-type:token-exchange"},
+		"subject_token":      {cookie.Value},
+		"subject_token_type": {"urn:ietf:params:oauth:token-type:access_token"},
+		"audience":           {"inventory"},
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var tokens map[string]any
+	f.decode(w, &tokens)
+	assert.Equal(t, "urn:ietf:params:oauth:token-type:access_token", tokens["issued_token_type"])
+	assert.Equal(t, "inventory:read", tokens["scope"])
+	exchanged := tokens["access_token"].(string)
+
+	introspection := f.introspect("orders", "s3cret", exchanged)
+	assert.Equal(t, true, introspection["active"])
+	assert.Equal(t, "user-123", introspection["sub"])
+	assert.Equal(t, "inventory", introspection["aud"])
+	assert.Equal(t, map[string]any{"sub": "orders"}, introspection["act"])
+
+	// The exchanged token is meant for the inventory service, not this API
+	req := httptest.NewRequest(http.MethodGet, "/api/me", nil)
+	req.Header.Set("Authorization", "Bearer "+exchanged)
+	assert.Equal(t, http.StatusUnauthorized, f.do(req).Code)
+
+	// Other audiences are refused
+	w = f.postClientForm("/oauth/token", "orders", "s3cret", url.Values{
+		"grant_type":         {"urn:ietf:params:oauth:grant-type:token-exchange"},
+		"subject_token":      {cookie.Value},
+		"subject_token_type": {"urn:ietf:params:oauth:token-type:access_token"},
+		"audience":           {"payroll"},
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid_target")
+}
@@ -0,0 +1,161 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
+	"github.com/yuki5155/go-google-auth/internal/domain/session"
+	"github.com/yuki5155/go-google-auth/internal/domain/user"
+)
+
+// invitationTokenPattern finds the token in an invitation email
+var invitationTokenPattern = regexp.MustCompile(`token=(inv_[A-Za-z0-9_-]+)`)
+
+// signInUser creates a user with a verified email and a login session and
+// returns their access token
+func (f *oidcFlow) signInUser(rawID, address string) string {
+	f.t.Helper()
+
+	ctx := context.Background()
+	userID, _ := user.NewUserID(rawID)
+	email, _ := user.NewEmail(address, true)
+	domainUser, err := user.NewUser(userID, email, user.NewProfile(rawID, ""))
+	require.NoError(f.t, err)
+	require.NoError(f.t, f.container.UserRepository.Save(ctx, domainUser))
+
+	loginSession, err := session.NewSession(userID, time.Now().Add(time.Hour))
+	require.NoError(f.t, err)
+	require.NoError(f.t, f.container.SessionRepository.Save(ctx, loginSession))
+
+	accessToken, _, err := f.container.TokenGenerator.GenerateTokenPair(ports.UserInfo{
+		UserID:    rawID,
+		Email:     address,
+		SessionID: loginSession.ID().Value(),
+		AMR:       []string{ports.AMRFederated},
+		AuthTime:  time.Now(),
+	})
+	require.NoError(f.t, err)
+	return accessToken
+}
+
+// selectOrganization switches the organization a user's tokens act in and
+// returns the reissued access token
+func (f *oidcFlow) selectOrganization(token, orgID string) string {
+	f.t.Helper()
+
+	w := f.bearer(http.MethodPut, "/api/me/organization", token, `{"organization_id":"`+orgID+`"}`)
+	require.Equal(f.t, http.StatusOK, w.Code, w.Body.String())
+	return accessTokenCookie(f.t, w)
+}
+
+// accessTokenCookie returns the access token a response set in its cookie
+func accessTokenCookie(t *testing.T, w *httptest.ResponseRecorder) string {
+	t.Helper()
+
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == "access_token" {
+			return cookie.Value
+		}
+	}
+	require.Fail(t, "no access_token cookie", w.Body.String())
+	return ""
+}
+
+// readInvitationToken returns the token from the only email in outbox
+func readInvitationToken(t *testing.T, outbox string) string {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join(outbox, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	data, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+
+	match := invitationTokenPattern.FindSubmatch(data)
+	require.NotNil(t, match, string(data))
+	return string(match[1])
+}
+
+func TestOrganizations(t *testing.T) {
+	outbox := t.TempDir()
+	t.Setenv("MAIL_OUTBOX_DIR", outbox)
+	f := newOIDCFlow(t)
+	ownerToken := f.signInUser("owner-1", "owner@example.com")
+	bobToken := f.signInUser("bob-1", "bob@example.com")
+
+	w := f.bearer(http.MethodPost, "/api/organizations", ownerToken, `{"name":"Acme"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created map[string]any
+	f.decode(w, &created)
+	orgID := created["id"].(string)
+	assert.Equal(t, "owner", created["role"])
+
+	// Organization routes need an organization to be selected first
+	w = f.bearer(http.MethodGet, "/api/organization", ownerToken, "")
+	assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), "no_active_organization")
+
+	w = f.bearer(http.MethodPut, "/api/me/organization", bobToken, `{"organization_id":"`+orgID+`"}`)
+	assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+
+	ownerToken = f.selectOrganization(ownerToken, orgID)
+	claims, err := f.container.TokenGenerator.ValidateAccessToken(ownerToken)
+	require.NoError(t, err)
+	assert.Equal(t, orgID, claims.OrganizationID)
+
+	// Inviting emails a single-use link to the address
+	w = f.bearer(http.MethodPost, "/api/organization/invitations", ownerToken, `{"email":"bob@example.com","role":"member"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	invitationToken := readInvitationToken(t, outbox)
+
+	w = f.bearer(http.MethodPost, "/api/invitations/accept", bobToken, `{"token":"`+invitationToken+`"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = f.bearer(http.MethodPost, "/api/invitations/accept", bobToken, `{"token":"`+invitationToken+`"}`)
+	assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+
+	bobToken = f.selectOrganization(bobToken, orgID)
+	w = f.bearer(http.MethodGet, "/api/organization", bobToken, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var active map[string]any
+	f.decode(w, &active)
+	assert.Len(t, active["members"], 2)
+	assert.NotContains(t, active, "invitations")
+
+	// Members may not manage other members
+	w = f.bearer(http.MethodPost, "/api/organization/invitations", bobToken, `{"email":"eve@example.com","role":"member"}`)
+	assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), "insufficient_role")
+	w = f.bearer(http.MethodDelete, "/api/organization/members/owner-1", ownerToken, "")
+	assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+
+	w = f.bearer(http.MethodPatch, "/api/organization/members/bob-1", ownerToken, `{"role":"admin"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = f.bearer(http.MethodDelete, "/api/organization/members/bob-1", ownerToken, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// The org_id claim only selects the organization, so a removed member's
+	// token loses access straight away
+	w = f.bearer(http.MethodGet, "/api/organization", bobToken, "")
+	assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), "not_a_member")
+
+	// The selection is remembered for the next login, except by removed members
+	ownerID, _ := user.NewUserID("owner-1")
+	owner, err := f.container.UserRepository.FindByID(context.Background(), ownerID)
+	require.NoError(t, err)
+	assert.Equal(t, orgID, owner.ActiveOrganizationID())
+	bobID, _ := user.NewUserID("bob-1")
+	bob, err := f.container.UserRepository.FindByID(context.Background(), bobID)
+	require.NoError(t, err)
+	assert.Empty(t, bob.ActiveOrganizationID())
+}
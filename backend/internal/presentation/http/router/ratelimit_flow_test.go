@@ -0,0 +1,27 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginRateLimit_IgnoresSpoofedForwardedFor(t *testing.T) {
+	t.Setenv("RATE_LIMIT_LOGIN", "2/1m")
+	f := newOIDCFlow(t)
+
+	attempt := func(i int) int {
+		req := httptest.NewRequest(http.MethodGet, "/auth/email/verify?token=invalid", nil)
+		req.RemoteAddr = "203.0.113.7:51234"
+		req.Header.Set("X-Forwarded-For", fmt.Sprintf("198.51.100.%d", i))
+		return f.do(req).Code
+	}
+
+	// Rotating X-Forwarded-For does not give the client a fresh bucket
+	assert.NotEqual(t, http.StatusTooManyRequests, attempt(1))
+	assert.NotEqual(t, http.StatusTooManyRequests, attempt(2))
+	assert.Equal(t, http.StatusTooManyRequests, attempt(3))
+}
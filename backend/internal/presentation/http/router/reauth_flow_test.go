@@ -0,0 +1,66 @@
+package router
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reauthTokenPattern finds the token in a "Confirm it's you" email
+var reauthTokenPattern = regexp.MustCompile(`/reauth\?token=([A-Za-z0-9_.-]+)`)
+
+// readReauthToken returns the token from the only email in outbox
+func readReauthToken(t *testing.T, outbox string) string {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join(outbox, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	data, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+
+	match := reauthTokenPattern.FindSubmatch(data)
+	require.NotNil(t, match, string(data))
+	return string(match[1])
+}
+
+func TestReauthenticateWithEmailLink(t *testing.T) {
+	outbox := t.TempDir()
+	t.Setenv("MAIL_OUTBOX_DIR", outbox)
+	f := newOIDCFlow(t)
+	aliceToken := f.signInUser("alice-1", "alice@example.com")
+	bobToken := f.signInUser("bob-1", "bob@example.com")
+
+	w := f.bearer(http.MethodPost, "/api/me/reauth/email", aliceToken, "")
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+	linkToken := readReauthToken(t, outbox)
+
+	// The link only confirms the user it was sent to
+	w = f.bearer(http.MethodPost, "/api/me/reauth", bobToken, `{"email_token":"`+linkToken+`"}`)
+	assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+
+	w = f.bearer(http.MethodPost, "/api/me/reauth", aliceToken, `{"email_token":"`+linkToken+`"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	claims, err := f.container.TokenGenerator.ValidateAccessToken(accessTokenCookie(t, w))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"email"}, claims.AMR)
+
+	// Each link can only be used once
+	w = f.bearer(http.MethodPost, "/api/me/reauth", aliceToken, `{"email_token":"`+linkToken+`"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), "invalid_email_token")
+}
+
+func TestReauthPasskeyOptions_RequiresPasskey(t *testing.T) {
+	f := newOIDCFlow(t)
+	token := f.signInUser("alice-1", "alice@example.com")
+
+	w := f.bearer(http.MethodPost, "/api/me/reauth/passkey/options", token, "")
+	assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), "no_passkeys")
+}
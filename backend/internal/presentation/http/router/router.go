@@ -5,10 +5,14 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/yuki5155/go-google-auth/internal/application/ports"
 	"github.com/yuki5155/go-google-auth/internal/handlers"
 	"github.com/yuki5155/go-google-auth/internal/infrastructure/container"
+	"github.com/yuki5155/go-google-auth/internal/presentation/http/clientip"
+	"github.com/yuki5155/go-google-auth/internal/presentation/http/cors"
 	presentationHandlers "github.com/yuki5155/go-google-auth/internal/presentation/http/handlers"
 	"github.com/yuki5155/go-google-auth/internal/presentation/http/middleware"
+	"github.com/yuki5155/go-google-auth/internal/presentation/http/securityheaders"
 )
 
 // Setup initializes and configures the Gin router with all routes and middleware
@@ -23,8 +27,19 @@ func Setup(c *container.Container) *gin.Engine {
 	// Initialize Gin router
 	r := gin.Default()
 
+	// Only believe X-Forwarded-For from configured proxies, since rate
+	// limits and login alerts key on the client IP
+	clientip.Configure(r, cfg.TrustedProxies)
+
+	// Apply security response headers (stricter for token-bearing routes)
+	r.Use(middleware.SecurityHeaders(securityheaders.PolicyFromConfig(cfg)))
+
 	// Apply CORS middleware
-	r.Use(middleware.CORS(cfg))
+	corsPolicy := cors.PolicyFromConfig(cfg)
+	r.Use(middleware.CORS(corsPolicy, r.Routes))
+
+	// Apply CSRF protection to state-changing requests
+	r.Use(middleware.CSRF(c.CSRFTokens, c.TokenGenerator, corsPolicy))
 
 	// Initialize new presentation layer handler
 	authHandler := presentationHandlers.NewAuthHandler(
@@ -32,7 +47,88 @@ func Setup(c *container.Container) *gin.Engine {
 		c.RefreshTokenUseCase,
 		c.GetCurrentUserUseCase,
 		c.LogoutUseCase,
+		c.VerifyMFAUseCase,
+		c.DPoPAuthenticator,
+		c.TokenGenerator,
+		c.CSRFTokens,
+		cfg,
+	)
+	mfaHandler := presentationHandlers.NewMFAHandler(
+		c.GetMFAStatusUseCase,
+		c.EnrollTOTPUseCase,
+		c.ConfirmTOTPUseCase,
+		c.DisableTOTPUseCase,
+		c.RegenerateRecoveryCodesUseCase,
+	)
+	passkeyHandler := presentationHandlers.NewPasskeyHandler(
+		c.RegisterPasskeyUseCase,
+		c.ListPasskeysUseCase,
+		c.RemovePasskeyUseCase,
+		c.PasskeyLoginUseCase,
+		c.PasskeyMFAUseCase,
+		c.TokenGenerator,
+		c.CSRFTokens,
+		cfg,
+	)
+	emailLoginHandler := presentationHandlers.NewEmailLoginHandler(
+		c.StartEmailLoginUseCase,
+		c.EmailLoginUseCase,
+		c.TokenGenerator,
+		c.CSRFTokens,
+		cfg,
+	)
+	loginAlertHandler := presentationHandlers.NewLoginAlertHandler(c.DisownLoginUseCase, cfg)
+
+	reauthHandler := presentationHandlers.NewReauthHandler(
+		c.ReauthenticateUseCase,
+		c.StartReauthUseCase,
+		c.TokenGenerator,
+		c.CSRFTokens,
+		cfg,
+	)
+
+	accountHandler := presentationHandlers.NewAccountHandler(
+		c.UpdateProfileUseCase,
+		c.DeleteAccountUseCase,
+		c.ExportDataUseCase,
+		cfg,
+	)
+
+	apiTokenHandler := presentationHandlers.NewAPITokenHandler(
+		c.CreateAPITokenUseCase,
+		c.ListAPITokensUseCase,
+		c.RevokeAPITokenUseCase,
+	)
+
+	oauthHandler := presentationHandlers.NewOAuthHandler(
+		c.ClientCredentialsUseCase,
+		c.AuthorizationCodeUseCase,
+		c.TokenExchangeUseCase,
+		c.IntrospectTokenUseCase,
+		c.RevokeTokenUseCase,
+	)
+	oidcHandler := presentationHandlers.NewOIDCHandler(
+		c.AuthorizeUseCase,
+		c.GetConsentRequestUseCase,
+		c.DecideConsentUseCase,
+		c.GetUserInfoUseCase,
+		c.RegisterClientUseCase,
+		c.IDTokens,
+		cfg,
+	)
+	adminHandler := presentationHandlers.NewAdminHandler(c.StartImpersonationUseCase, c.StopImpersonationUseCase)
+	organizationHandler := presentationHandlers.NewOrganizationHandler(
+		c.CreateOrganizationUseCase,
+		c.ListOrganizationsUseCase,
+		c.GetActiveOrganizationUseCase,
+		c.SelectOrganizationUseCase,
+		c.InviteMemberUseCase,
+		c.RevokeInvitationUseCase,
+		c.AcceptInvitationUseCase,
+		c.ChangeMemberRoleUseCase,
+		c.RemoveMemberUseCase,
 		c.TokenGenerator,
+		c.CSRFTokens,
 		cfg,
 	)
 
@@ -52,15 +148,124 @@ func Setup(c *container.Container) *gin.Engine {
 	r.GET(checkCookieHandler.Path, checkCookieHandler.Handle)
 
 	// Auth endpoints (public) - using new presentation layer handlers
-	r.POST("/auth/google", authHandler.GoogleLogin)
-	r.POST("/auth/refresh", authHandler.RefreshToken)
+	loginLimit := middleware.LoginRateLimit(c.RateLimiter, cfg)
+	r.POST("/auth/google", loginLimit, authHandler.GoogleLogin)
+	r.POST("/auth/passkey/options", loginLimit, passkeyHandler.LoginOptions)
+	r.POST("/auth/passkey", loginLimit, passkeyHandler.Login)
+	r.POST("/auth/email/start", middleware.EmailLoginRateLimit(c.RateLimiter, cfg), emailLoginHandler.Start)
+	r.GET("/auth/email/verify", loginLimit, emailLoginHandler.Verify)
+	r.GET("/auth/sessions/revoke", loginLimit, loginAlertHandler.ConfirmDisownLogin)
+	r.POST("/auth/sessions/revoke", loginLimit, loginAlertHandler.DisownLogin)
+	r.POST("/auth/refresh", middleware.RefreshRateLimit(c.RateLimiter, c.TokenGenerator, cfg), authHandler.RefreshToken)
 	r.POST("/auth/logout", authHandler.Logout)
+	r.GET("/auth/csrf", authHandler.CSRFToken)
+	mfaVerifyLimit := middleware.MFAVerifyRateLimit(c.RateLimiter, c.TokenGenerator, cfg)
+	r.POST("/auth/mfa/verify", mfaVerifyLimit, authHandler.VerifyMFA)
+	r.POST("/auth/mfa/passkey/options", mfaVerifyLimit, passkeyHandler.MFAOptions)
+	r.POST("/auth/mfa/passkey", mfaVerifyLimit, passkeyHandler.VerifyMFA)
+
+	// OAuth 2.0 endpoints for service clients (public; clients authenticate themselves)
+	r.POST("/oauth/token", loginLimit, oauthHandler.Token)
+	// Resource servers introspect tokens on every request they serve, so
+	// introspection is not held to the login limit
+	r.POST("/oauth/introspect", oauthHandler.Introspect)
+	r.POST("/oauth/revoke", loginLimit, oauthHandler.Revoke)
+
+	// OpenID Connect provider endpoints
+	r.GET("/oauth/authorize",
+		middleware.OptionalAuth(c.TokenGenerator,
+			middleware.WithAccountStatus(c.AccountStatusService),
+			middleware.WithTokenPrecedence(cfg.TokenPrecedence),
+			middleware.WithRevokedTokens(c.RevokedTokens),
+		),
+		oidcHandler.Authorize,
+	)
+	r.GET("/oauth/userinfo",
+		middleware.Auth(c.TokenGenerator,
+			middleware.WithAccountStatus(c.AccountStatusService),
+			middleware.WithRevokedTokens(c.RevokedTokens),
+		),
+		middleware.RequireScope(ports.ScopeOpenID),
+		oidcHandler.UserInfo,
+	)
+	r.POST("/oauth/register", loginLimit, oidcHandler.Register)
+	r.GET("/.well-known/openid-configuration", oidcHandler.Discovery)
+	r.GET("/.well-known/jwks.json", oidcHandler.JWKS)
 
 	// Protected routes (require authentication)
 	protected := r.Group("/api")
-	protected.Use(middleware.Auth(c.TokenGenerator))
+	protected.Use(middleware.Auth(c.TokenGenerator,
+		middleware.WithAccountStatus(c.AccountStatusService),
+		middleware.WithTokenPrecedence(cfg.TokenPrecedence),
+		middleware.WithAPITokens(c.APITokenAuthenticator),
+		middleware.WithDPoP(c.DPoPAuthenticator),
+		middleware.WithRevokedTokens(c.RevokedTokens),
+	))
+	protected.Use(middleware.APIRateLimit(c.RateLimiter, cfg))
+	{
+		// Administrators impersonating a user may look around, but may not
+		// change how the user signs in, hand out access or delete the account
+		noImpersonation := middleware.BlockImpersonation()
+
+		// Personal access tokens only reach routes that declare a scope
+		protected.GET("/me", middleware.RequireScope(ports.ScopeProfileRead), authHandler.GetCurrentUser)
+		protected.PATCH("/me", middleware.RequireScope(ports.ScopeProfileWrite), accountHandler.UpdateProfile)
+		protected.DELETE("/me", noImpersonation, middleware.RequireRecentAuth(cfg.RecentAuthMaxAge), accountHandler.DeleteAccount)
+		protected.GET("/me/export", noImpersonation, middleware.RequireScope(ports.ScopeAccountExport), accountHandler.ExportData)
+
+		mfaCodeLimit := middleware.MFACodeRateLimit(c.RateLimiter, cfg)
+		protected.POST("/me/reauth", noImpersonation, mfaCodeLimit, reauthHandler.Reauthenticate)
+		protected.POST("/me/reauth/email", noImpersonation, middleware.EmailLoginRateLimit(c.RateLimiter, cfg), reauthHandler.SendEmailLink)
+		protected.POST("/me/reauth/passkey/options", noImpersonation, reauthHandler.PasskeyOptions)
+
+		protected.GET("/me/mfa", mfaHandler.Status)
+		protected.POST("/me/mfa/totp", noImpersonation, mfaHandler.EnrollTOTP)
+		protected.POST("/me/mfa/totp/confirm", noImpersonation, mfaCodeLimit, mfaHandler.ConfirmTOTP)
+		protected.DELETE("/me/mfa/totp", noImpersonation, mfaCodeLimit, mfaHandler.DisableTOTP)
+		protected.POST("/me/mfa/recovery-codes", noImpersonation, mfaCodeLimit, mfaHandler.RegenerateRecoveryCodes)
+
+		protected.GET("/me/passkeys", passkeyHandler.List)
+		protected.POST("/me/passkeys/options", noImpersonation, passkeyHandler.RegistrationOptions)
+		protected.POST("/me/passkeys", noImpersonation, passkeyHandler.Register)
+		protected.DELETE("/me/passkeys/:id", noImpersonation, passkeyHandler.Remove)
+
+		protected.GET("/tokens", apiTokenHandler.List)
+		protected.POST("/tokens", noImpersonation, apiTokenHandler.Create)
+		protected.DELETE("/tokens/:id", noImpersonation, apiTokenHandler.Revoke)
+
+		protected.GET("/oauth/consent", oidcHandler.ConsentRequest)
+		protected.POST("/oauth/consent", noImpersonation, oidcHandler.DecideConsent)
+
+		// Organization routes act in the organization selected by the
+		// token's org_id claim; membership is checked on every request
+		protected.GET("/organizations", organizationHandler.List)
+		protected.POST("/organizations", noImpersonation, organizationHandler.Create)
+		protected.PUT("/me/organization", noImpersonation, organizationHandler.Select)
+		protected.GET("/organization", organizationHandler.GetActive)
+		protected.POST("/organization/invitations", noImpersonation, organizationHandler.Invite)
+		protected.DELETE("/organization/invitations/:id", noImpersonation, organizationHandler.RevokeInvitation)
+		protected.PATCH("/organization/members/:userID", noImpersonation, organizationHandler.ChangeMemberRole)
+		protected.DELETE("/organization/members/:userID", noImpersonation, organizationHandler.RemoveMember)
+		protected.POST("/invitations/accept", noImpersonation, organizationHandler.AcceptInvitation)
+	}
+
+	// Administrator routes; personal access tokens are not accepted
+	admin := r.Group("/admin")
+	admin.Use(middleware.Auth(c.TokenGenerator,
+		middleware.WithAccountStatus(c.AccountStatusService),
+		middleware.WithTokenPrecedence(cfg.TokenPrecedence),
+		middleware.WithDPoP(c.DPoPAuthenticator),
+		middleware.WithRevokedTokens(c.RevokedTokens),
+	))
+	admin.Use(middleware.APIRateLimit(c.RateLimiter, cfg))
 	{
-		protected.GET("/me", authHandler.GetCurrentUser)
+		admin.POST("/impersonate/:userID",
+			middleware.BlockImpersonation(),
+			middleware.RequireRecentAuth(cfg.RecentAuthMaxAge),
+			adminHandler.Impersonate,
+		)
+		// Called with the impersonation token
+		admin.DELETE("/impersonate", adminHandler.StopImpersonation)
 	}
 
 	log.Printf("Router configured (environment: %s)", cfg.Environment)
@@ -0,0 +1,118 @@
+package securityheaders
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/config"
+)
+
+// StrictContentSecurityPolicy is used on token-bearing responses regardless of configuration
+const StrictContentSecurityPolicy = "default-src 'none'; frame-ancestors 'none'; sandbox"
+
+// DefaultStrictPathPrefixes are the routes that issue tokens or return user data
+var DefaultStrictPathPrefixes = []string{"/auth/", "/api/", "/oauth/"}
+
+// Options configures a Policy
+type Options struct {
+	// HSTSMaxAge enables Strict-Transport-Security when positive
+	HSTSMaxAge            time.Duration
+	HSTSIncludeSubdomains bool
+	HSTSPreload           bool
+
+	// ContentSecurityPolicy for ordinary responses (empty omits the header)
+	ContentSecurityPolicy string
+
+	// ReferrerPolicy for ordinary responses (empty omits the header)
+	ReferrerPolicy string
+
+	// StrictPathPrefixes get no-store caching, no referrer and a sandboxed CSP
+	StrictPathPrefixes []string
+}
+
+// Policy decides which security headers a response carries
+type Policy struct {
+	base           map[string]string
+	strict         map[string]string
+	strictPrefixes []string
+}
+
+// NewPolicy creates a Policy from options
+func NewPolicy(opts Options) *Policy {
+	base := map[string]string{
+		"X-Content-Type-Options": "nosniff",
+		"X-Frame-Options":        "DENY",
+	}
+	if hsts := hstsValue(opts); hsts != "" {
+		base["Strict-Transport-Security"] = hsts
+	}
+	if opts.ContentSecurityPolicy != "" {
+		base["Content-Security-Policy"] = opts.ContentSecurityPolicy
+	}
+	if opts.ReferrerPolicy != "" {
+		base["Referrer-Policy"] = opts.ReferrerPolicy
+	}
+
+	strict := make(map[string]string, len(base)+4)
+	for name, value := range base {
+		strict[name] = value
+	}
+	strict["Cache-Control"] = "no-store"
+	strict["Pragma"] = "no-cache"
+	strict["Referrer-Policy"] = "no-referrer"
+	strict["Content-Security-Policy"] = StrictContentSecurityPolicy
+
+	return &Policy{
+		base:           base,
+		strict:         strict,
+		strictPrefixes: opts.StrictPathPrefixes,
+	}
+}
+
+// PolicyFromConfig creates the application's security headers policy
+func PolicyFromConfig(cfg *config.Config) *Policy {
+	return NewPolicy(Options{
+		HSTSMaxAge:            cfg.HSTSMaxAge,
+		HSTSIncludeSubdomains: cfg.HSTSIncludeSubdomains,
+		HSTSPreload:           cfg.HSTSPreload,
+		ContentSecurityPolicy: cfg.ContentSecurityPolicy,
+		ReferrerPolicy:        cfg.ReferrerPolicy,
+		StrictPathPrefixes:    DefaultStrictPathPrefixes,
+	})
+}
+
+// Headers returns the headers for a response to path; the map must not be modified
+func (p *Policy) Headers(path string) map[string]string {
+	if p.IsStrict(path) {
+		return p.strict
+	}
+	return p.base
+}
+
+// IsStrict reports whether responses to path get the strict headers
+func (p *Policy) IsStrict(path string) bool {
+	for _, prefix := range p.strictPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// hstsValue builds the Strict-Transport-Security value, or "" when disabled
+func hstsValue(opts Options) string {
+	seconds := int64(opts.HSTSMaxAge / time.Second)
+	if seconds <= 0 {
+		return ""
+	}
+
+	value := fmt.Sprintf("max-age=%d", seconds)
+	if opts.HSTSIncludeSubdomains {
+		value += "; includeSubDomains"
+	}
+	if opts.HSTSPreload {
+		value += "; preload"
+	}
+	return value
+}
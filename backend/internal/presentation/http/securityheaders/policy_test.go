@@ -0,0 +1,106 @@
+package securityheaders
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yuki5155/go-google-auth/internal/infrastructure/config"
+)
+
+func newTestPolicy() *Policy {
+	return NewPolicy(Options{
+		HSTSMaxAge:            365 * 24 * time.Hour,
+		HSTSIncludeSubdomains: true,
+		ContentSecurityPolicy: "default-src 'none'",
+		ReferrerPolicy:        "strict-origin",
+		StrictPathPrefixes:    DefaultStrictPathPrefixes,
+	})
+}
+
+func TestPolicy_Headers_Default(t *testing.T) {
+	headers := newTestPolicy().Headers("/health")
+
+	assert.Equal(t, "nosniff", headers["X-Content-Type-Options"])
+	assert.Equal(t, "DENY", headers["X-Frame-Options"])
+	assert.Equal(t, "max-age=31536000; includeSubDomains", headers["Strict-Transport-Security"])
+	assert.Equal(t, "default-src 'none'", headers["Content-Security-Policy"])
+	assert.Equal(t, "strict-origin", headers["Referrer-Policy"])
+	assert.NotContains(t, headers, "Cache-Control")
+}
+
+func TestPolicy_Headers_StrictForTokenRoutes(t *testing.T) {
+	policy := newTestPolicy()
+
+	for _, path := range []string{"/auth/google", "/auth/refresh", "/api/me", "/api/me/export", "/oauth/token"} {
+		t.Run(path, func(t *testing.T) {
+			headers := policy.Headers(path)
+
+			assert.Equal(t, "no-store", headers["Cache-Control"])
+			assert.Equal(t, "no-cache", headers["Pragma"])
+			assert.Equal(t, "no-referrer", headers["Referrer-Policy"])
+			assert.Equal(t, StrictContentSecurityPolicy, headers["Content-Security-Policy"])
+			// Baseline headers still apply
+			assert.Equal(t, "nosniff", headers["X-Content-Type-Options"])
+			assert.Equal(t, "max-age=31536000; includeSubDomains", headers["Strict-Transport-Security"])
+		})
+	}
+}
+
+func TestPolicy_IsStrict(t *testing.T) {
+	policy := newTestPolicy()
+
+	assert.True(t, policy.IsStrict("/auth/csrf"))
+	assert.True(t, policy.IsStrict("/api/me"))
+	assert.False(t, policy.IsStrict("/health"))
+	assert.False(t, policy.IsStrict("/authorize"))
+	assert.False(t, policy.IsStrict("/hello"))
+}
+
+func TestPolicy_Headers_DisabledSettingsOmitted(t *testing.T) {
+	headers := NewPolicy(Options{}).Headers("/health")
+
+	assert.NotContains(t, headers, "Strict-Transport-Security")
+	assert.NotContains(t, headers, "Content-Security-Policy")
+	assert.NotContains(t, headers, "Referrer-Policy")
+	assert.Equal(t, "nosniff", headers["X-Content-Type-Options"])
+}
+
+func TestHSTSValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     Options
+		expected string
+	}{
+		{name: "disabled", opts: Options{}, expected: ""},
+		{name: "sub-second max age", opts: Options{HSTSMaxAge: time.Millisecond}, expected: ""},
+		{name: "max age only", opts: Options{HSTSMaxAge: time.Hour}, expected: "max-age=3600"},
+		{
+			name:     "preload",
+			opts:     Options{HSTSMaxAge: 2 * 365 * 24 * time.Hour, HSTSIncludeSubdomains: true, HSTSPreload: true},
+			expected: "max-age=63072000; includeSubDomains; preload",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, hstsValue(tt.opts))
+		})
+	}
+}
+
+func TestPolicyFromConfig(t *testing.T) {
+	cfg := &config.Config{
+		HSTSMaxAge:            config.DefaultHSTSMaxAge,
+		HSTSIncludeSubdomains: true,
+		ContentSecurityPolicy: config.DefaultContentSecurityPolicy,
+		ReferrerPolicy:        "no-referrer",
+	}
+
+	policy := PolicyFromConfig(cfg)
+
+	assert.Equal(t, "max-age=63072000; includeSubDomains", policy.Headers("/health")["Strict-Transport-Security"])
+	assert.Equal(t, config.DefaultContentSecurityPolicy, policy.Headers("/health")["Content-Security-Policy"])
+	assert.Equal(t, "no-store", policy.Headers("/auth/google")["Cache-Control"])
+}
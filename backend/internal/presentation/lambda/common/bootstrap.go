@@ -4,17 +4,20 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/yuki5155/go-google-auth/internal/infrastructure/config"
 	"github.com/yuki5155/go-google-auth/internal/infrastructure/container"
+	"github.com/yuki5155/go-google-auth/internal/presentation/http/clientip"
+	"github.com/yuki5155/go-google-auth/internal/presentation/http/cors"
 	"github.com/yuki5155/go-google-auth/internal/presentation/http/middleware"
+	"github.com/yuki5155/go-google-auth/internal/presentation/http/securityheaders"
 )
 
 // Bootstrap initializes the Gin router and dependency container for Lambda functions
-// Returns a configured router and the dependency container
-func Bootstrap() (*gin.Engine, *container.Container) {
+// Returns a configured router and the dependency container; opts customize the container
+func Bootstrap(opts ...container.Option) (*gin.Engine, *container.Container) {
 	// Load configuration
 	cfg := config.Load()
 
 	// Create dependency injection container
-	c := container.NewContainer(cfg)
+	c := container.NewContainer(cfg, opts...)
 
 	// Set Gin mode based on environment
 	if cfg.IsProduction() {
@@ -24,8 +27,19 @@ func Bootstrap() (*gin.Engine, *container.Container) {
 	// Create Gin router with default middleware
 	r := gin.Default()
 
+	// Only believe X-Forwarded-For from configured proxies, since rate
+	// limits and login alerts key on the client IP
+	clientip.Configure(r, cfg.TrustedProxies)
+
+	// Apply security response headers (stricter for token-bearing routes)
+	r.Use(middleware.SecurityHeaders(securityheaders.PolicyFromConfig(cfg)))
+
 	// Apply shared CORS middleware
-	r.Use(middleware.CORS(cfg))
+	corsPolicy := cors.PolicyFromConfig(cfg)
+	r.Use(middleware.CORS(corsPolicy, r.Routes))
+
+	// Apply shared CSRF protection to state-changing requests
+	r.Use(middleware.CSRF(c.CSRFTokens, c.TokenGenerator, corsPolicy))
 
 	return r, c
 }
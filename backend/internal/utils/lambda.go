@@ -1,30 +1,18 @@
 package utils
 
 import (
-	"os"
-
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/yuki5155/go-google-auth/internal/presentation/http/cors"
 )
 
-// GetCORSHeaders returns the common CORS headers for Lambda responses
-func GetCORSHeaders() map[string]string {
-	// Allowed origin should match the frontend URL
-	allowedOrigin := os.Getenv("ALLOWED_ORIGINS")
-	if allowedOrigin == "" {
-		allowedOrigin = "*"
-	}
-
-	return map[string]string{
-		"Access-Control-Allow-Origin":      allowedOrigin,
-		"Access-Control-Allow-Credentials": "true",
-		"Access-Control-Allow-Headers":     "Content-Type,X-Amz-Date,Authorization,X-Api-Key,X-Amz-Security-Token",
-		"Access-Control-Allow-Methods":     "GET,POST,PUT,DELETE,OPTIONS",
-	}
+// GetCORSHeaders returns the CORS headers for a Lambda response to a request from origin
+func GetCORSHeaders(policy *cors.Policy, origin string) map[string]string {
+	return policy.ResponseHeaders(origin)
 }
 
-// ApiResponse creates a formatted APIGatewayProxyResponse with CORS headers
-func ApiResponse(statusCode int, body string) events.APIGatewayProxyResponse {
-	headers := GetCORSHeaders()
+// ApiResponse creates a formatted APIGatewayProxyResponse with CORS headers for origin
+func ApiResponse(policy *cors.Policy, origin string, statusCode int, body string) events.APIGatewayProxyResponse {
+	headers := GetCORSHeaders(policy, origin)
 	headers["Content-Type"] = "application/json"
 
 	return events.APIGatewayProxyResponse{
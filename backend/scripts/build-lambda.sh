@@ -17,7 +17,57 @@ LAMBDA_FUNCTIONS=(
   "auth-google"
   "auth-refresh"
   "auth-logout"
+  "auth-csrf"
+  "auth-mfa-verify"
+  "auth-passkey-options"
+  "auth-passkey"
+  "auth-email-start"
+  "auth-email-verify"
+  "auth-session-revoke-link"
+  "auth-session-revoke"
+  "auth-mfa-passkey-options"
+  "auth-mfa-passkey"
   "get-user"
+  "update-user"
+  "delete-user"
+  "export-user"
+  "reauth-user"
+  "reauth-email-link"
+  "reauth-passkey-options"
+  "get-mfa"
+  "enroll-totp"
+  "confirm-totp"
+  "disable-totp"
+  "regenerate-recovery-codes"
+  "list-passkeys"
+  "passkey-registration-options"
+  "register-passkey"
+  "remove-passkey"
+  "list-api-tokens"
+  "create-api-token"
+  "revoke-api-token"
+  "get-oauth-consent"
+  "decide-oauth-consent"
+  "admin-impersonate"
+  "admin-impersonate-stop"
+  "list-organizations"
+  "create-organization"
+  "select-organization"
+  "get-organization"
+  "invite-member"
+  "revoke-invitation"
+  "update-member-role"
+  "remove-member"
+  "accept-invitation"
+  "oauth-token"
+  "oauth-introspect"
+  "oauth-revoke"
+  "oauth-authorize"
+  "oauth-userinfo"
+  "oauth-register"
+  "oidc-discovery"
+  "oidc-jwks"
+  "purge-accounts"
   "health"
   "hello"
 )
@@ -13,6 +13,15 @@ const user = ref<User | null>(null)
 const isLoading = ref(false)
 const error = ref<string | null>(null)
 
+// Set when Google sign-in succeeded but a second factor must still be verified
+const mfaRequired = ref(false)
+
+// Second factors offered for the pending login ("totp", "passkey")
+const mfaMethods = ref<string[]>([])
+
+// CSRF token echoed in the X-CSRF-Token header on state-changing requests
+let csrfToken: string | null = null
+
 // Backend URL from environment
 const backendUrl = import.meta.env.VITE_BACKEND_URL
 if (!backendUrl) {
@@ -20,6 +29,14 @@ if (!backendUrl) {
 }
 const finalBackendUrl = backendUrl || 'http://localhost:8080'
 
+// Consent request an OpenID Connect client is waiting on
+export interface ConsentRequest {
+  client_id: string
+  client_name: string
+  redirect_uri: string
+  scopes: string[]
+}
+
 // Computed properties
 const isAuthenticated = computed(() => user.value !== null)
 
@@ -83,8 +100,14 @@ async function loginWithGoogle(credential: string): Promise<boolean> {
 
     const data = await response.json()
 
-    if (response.ok) {
+    if (response.ok && data.mfa_required) {
+      // The server set a short-lived mfa_token cookie; finish with verifyMfa
+      mfaRequired.value = true
+      mfaMethods.value = data.mfa_methods ?? ['totp']
+      return false
+    } else if (response.ok) {
       user.value = data.user
+      csrfToken = data.csrf_token ?? null
       return true
     } else {
       error.value = data.message || 'Login failed'
@@ -99,11 +122,257 @@ async function loginWithGoogle(credential: string): Promise<boolean> {
   }
 }
 
+// Complete an MFA-pending login with an authenticator or recovery code
+async function verifyMfa(code: string, isRecoveryCode = false): Promise<boolean> {
+  isLoading.value = true
+  error.value = null
+
+  try {
+    const response = await fetch(`${finalBackendUrl}/auth/mfa/verify`, {
+      method: 'POST',
+      headers: {
+        'Content-Type': 'application/json',
+      },
+      credentials: 'include',
+      body: JSON.stringify(isRecoveryCode ? { recovery_code: code } : { code }),
+    })
+
+    const data = await response.json()
+
+    if (response.ok) {
+      user.value = data.user
+      csrfToken = data.csrf_token ?? null
+      mfaRequired.value = false
+      return true
+    }
+
+    if (data.error === 'mfa_token_invalid') {
+      // The pending login expired; start over with Google sign-in
+      mfaRequired.value = false
+    }
+    error.value = data.message || 'Verification failed'
+    return false
+  } catch (err) {
+    console.error('MFA verification error:', err)
+    error.value = err instanceof Error ? err.message : 'An error occurred during verification'
+    return false
+  } finally {
+    isLoading.value = false
+  }
+}
+
+// Email a single-use login link; the link itself completes the login
+async function startEmailLogin(email: string): Promise<boolean> {
+  isLoading.value = true
+  error.value = null
+
+  try {
+    const response = await fetch(`${finalBackendUrl}/auth/email/start`, {
+      method: 'POST',
+      headers: {
+        'Content-Type': 'application/json',
+      },
+      credentials: 'include',
+      body: JSON.stringify({ email }),
+    })
+
+    if (response.ok) {
+      return true
+    }
+
+    const data = await response.json().catch(() => ({}))
+    error.value = data.message || 'Failed to send login link'
+    return false
+  } catch (err) {
+    console.error('Email login error:', err)
+    error.value = err instanceof Error ? err.message : 'An error occurred while sending the login link'
+    return false
+  } finally {
+    isLoading.value = false
+  }
+}
+
+// Sign out the session a login alert was about, once the user confirmed that
+// they did not sign in; token comes from the alert's "this wasn't me" link
+async function disownLogin(token: string): Promise<boolean> {
+  isLoading.value = true
+  error.value = null
+
+  try {
+    const response = await fetch(`${finalBackendUrl}/auth/sessions/revoke`, {
+      method: 'POST',
+      headers: {
+        'Content-Type': 'application/json',
+        'X-CSRF-Token': await getCsrfToken(),
+      },
+      credentials: 'include',
+      body: JSON.stringify({ token }),
+    })
+
+    if (response.ok) {
+      return true
+    }
+
+    const data = await response.json().catch(() => ({}))
+    error.value = data.message || 'Failed to sign out the other session'
+    return false
+  } catch (err) {
+    console.error('Disown login error:', err)
+    error.value = err instanceof Error ? err.message : 'An error occurred while signing out the other session'
+    return false
+  } finally {
+    isLoading.value = false
+  }
+}
+
+// Confirm it's the signed-in user with the token from a "Confirm it's you"
+// email, so that sensitive account changes are allowed again
+async function reauthenticateWithEmailLink(token: string): Promise<boolean> {
+  isLoading.value = true
+  error.value = null
+
+  try {
+    const response = await fetch(`${finalBackendUrl}/api/me/reauth`, {
+      method: 'POST',
+      headers: {
+        'Content-Type': 'application/json',
+        'X-CSRF-Token': await getCsrfToken(),
+      },
+      credentials: 'include',
+      body: JSON.stringify({ email_token: token }),
+    })
+    const data = await response.json().catch(() => ({}))
+
+    if (response.ok) {
+      csrfToken = data.csrf_token ?? null
+      return true
+    }
+
+    error.value = data.message || 'Failed to confirm it was you'
+    return false
+  } catch (err) {
+    console.error('Re-authentication error:', err)
+    error.value = err instanceof Error ? err.message : 'An error occurred while confirming it was you'
+    return false
+  } finally {
+    isLoading.value = false
+  }
+}
+
+// Resume an MFA-pending login started by an email link, which redirects here
+// with the mfa_token cookie already set
+function resumeMfa(methods: string[]): void {
+  mfaRequired.value = true
+  mfaMethods.value = methods
+}
+
+// Ask the browser to sign the server's challenge with a passkey and post the
+// assertion to the given endpoint
+async function postPasskeyAssertion(optionsPath: string, verifyPath: string): Promise<Response> {
+  const optionsResponse = await fetch(`${finalBackendUrl}${optionsPath}`, {
+    method: 'POST',
+    credentials: 'include',
+  })
+  if (!optionsResponse.ok) {
+    return optionsResponse
+  }
+
+  const publicKey = PublicKeyCredential.parseRequestOptionsFromJSON(await optionsResponse.json())
+  const credential = (await navigator.credentials.get({ publicKey })) as PublicKeyCredential | null
+  if (!credential) {
+    throw new Error('No passkey was selected')
+  }
+
+  return fetch(`${finalBackendUrl}${verifyPath}`, {
+    method: 'POST',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    credentials: 'include',
+    body: JSON.stringify(credential.toJSON()),
+  })
+}
+
+// Log in with a passkey instead of Google
+async function loginWithPasskey(): Promise<boolean> {
+  isLoading.value = true
+  error.value = null
+
+  try {
+    const response = await postPasskeyAssertion('/auth/passkey/options', '/auth/passkey')
+    const data = await response.json()
+
+    if (response.ok) {
+      user.value = data.user
+      csrfToken = data.csrf_token ?? null
+      return true
+    }
+
+    error.value = data.message || 'Passkey login failed'
+    return false
+  } catch (err) {
+    console.error('Passkey login error:', err)
+    error.value = err instanceof Error ? err.message : 'An error occurred during passkey login'
+    return false
+  } finally {
+    isLoading.value = false
+  }
+}
+
+// Complete an MFA-pending login with a passkey
+async function verifyMfaWithPasskey(): Promise<boolean> {
+  isLoading.value = true
+  error.value = null
+
+  try {
+    const response = await postPasskeyAssertion('/auth/mfa/passkey/options', '/auth/mfa/passkey')
+    const data = await response.json()
+
+    if (response.ok) {
+      user.value = data.user
+      csrfToken = data.csrf_token ?? null
+      mfaRequired.value = false
+      return true
+    }
+
+    if (data.error === 'mfa_token_invalid') {
+      // The pending login expired; start over with Google sign-in
+      mfaRequired.value = false
+    }
+    error.value = data.message || 'Verification failed'
+    return false
+  } catch (err) {
+    console.error('Passkey verification error:', err)
+    error.value = err instanceof Error ? err.message : 'An error occurred during verification'
+    return false
+  } finally {
+    isLoading.value = false
+  }
+}
+
+// Get a CSRF token, fetching a new one if none has been issued in this page session
+async function getCsrfToken(): Promise<string> {
+  if (csrfToken) {
+    return csrfToken
+  }
+
+  const response = await fetch(`${finalBackendUrl}/auth/csrf`, {
+    method: 'GET',
+    credentials: 'include',
+  })
+  const data = await response.json()
+  csrfToken = data.csrf_token
+  return data.csrf_token
+}
+
 // Refresh the access token
 async function refreshToken(): Promise<boolean> {
   try {
     const response = await fetch(`${finalBackendUrl}/auth/refresh`, {
       method: 'POST',
+      headers: {
+        'X-CSRF-Token': await getCsrfToken(),
+      },
       credentials: 'include',
     })
 
@@ -121,12 +390,83 @@ async function logout(): Promise<void> {
   try {
     await fetch(`${finalBackendUrl}/auth/logout`, {
       method: 'POST',
+      headers: {
+        'X-CSRF-Token': await getCsrfToken(),
+      },
       credentials: 'include',
     })
   } catch (err) {
     console.error('Logout error:', err)
   } finally {
     user.value = null
+    csrfToken = null
+    isLoading.value = false
+  }
+}
+
+// Where to go after signing in: back to a pending OpenID Connect authorization
+// request when the login page was opened for one, otherwise null. Only this
+// backend's authorization endpoint is accepted, so the parameter cannot be
+// used to redirect to arbitrary sites.
+function oidcReturnTo(returnTo: unknown): string | null {
+  if (typeof returnTo !== 'string' || !returnTo.startsWith(`${finalBackendUrl}/oauth/authorize?`)) {
+    return null
+  }
+  return returnTo
+}
+
+// Describe the consent request a client sent the user here with
+async function getConsentRequest(request: string): Promise<ConsentRequest | null> {
+  error.value = null
+
+  try {
+    const response = await fetch(`${finalBackendUrl}/api/oauth/consent?${new URLSearchParams({ request })}`, {
+      method: 'GET',
+      credentials: 'include',
+    })
+    const data = await response.json()
+
+    if (response.ok) {
+      return data
+    }
+
+    error.value = data.message || 'Failed to load the sign-in request'
+    return null
+  } catch (err) {
+    console.error('Consent request error:', err)
+    error.value = err instanceof Error ? err.message : 'An error occurred while loading the sign-in request'
+    return null
+  }
+}
+
+// Approve or deny a consent request, returning where to send the browser
+async function decideConsent(request: string, approve: boolean): Promise<string | null> {
+  isLoading.value = true
+  error.value = null
+
+  try {
+    const response = await fetch(`${finalBackendUrl}/api/oauth/consent`, {
+      method: 'POST',
+      headers: {
+        'Content-Type': 'application/json',
+        'X-CSRF-Token': await getCsrfToken(),
+      },
+      credentials: 'include',
+      body: JSON.stringify({ request, approve }),
+    })
+    const data = await response.json()
+
+    if (response.ok) {
+      return data.redirect_to
+    }
+
+    error.value = data.message || 'Failed to answer the sign-in request'
+    return null
+  } catch (err) {
+    console.error('Consent decision error:', err)
+    error.value = err instanceof Error ? err.message : 'An error occurred while answering the sign-in request'
+    return null
+  } finally {
     isLoading.value = false
   }
 }
@@ -143,13 +483,25 @@ export function useAuth() {
     user: readonly(user),
     isLoading: readonly(isLoading),
     error: readonly(error),
+    mfaRequired: readonly(mfaRequired),
+    mfaMethods: readonly(mfaMethods),
     isAuthenticated,
 
     // Actions
     initAuth,
     loginWithGoogle,
+    verifyMfa,
+    loginWithPasskey,
+    startEmailLogin,
+    disownLogin,
+    reauthenticateWithEmailLink,
+    resumeMfa,
+    verifyMfaWithPasskey,
     refreshToken,
     logout,
+    getConsentRequest,
+    decideConsent,
+    oidcReturnTo,
     clearError,
   }
 }
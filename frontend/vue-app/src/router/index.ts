@@ -4,6 +4,10 @@ import HomeView from '../views/HomeView.vue'
 import AboutView from '../views/AboutView.vue'
 import LoginView from '../views/LoginView.vue'
 import DashboardView from '../views/DashboardView.vue'
+import ConsentView from '../views/ConsentView.vue'
+import ReauthView from '../views/ReauthView.vue'
+import RevokeSessionView from '../views/RevokeSessionView.vue'
+import { useAuth } from '../composables/useAuth'
 
 // Backend URL for auth check
 const backendUrl = import.meta.env.VITE_BACKEND_URL || 'http://localhost:8080'
@@ -35,14 +39,21 @@ async function requireAuth(
   }
 }
 
-// Navigation guard for guest routes (redirect if already logged in)
+// Navigation guard for guest routes (redirect if already logged in). A
+// signed-in user sent to log in by an OpenID Connect client goes straight back.
 async function requireGuest(
-  _to: RouteLocationNormalized,
+  to: RouteLocationNormalized,
   _from: RouteLocationNormalized,
   next: NavigationGuardNext
 ) {
   const authenticated = await isAuthenticated()
   if (authenticated) {
+    const returnTo = useAuth().oidcReturnTo(to.query.return_to)
+    if (returnTo) {
+      window.location.assign(returnTo)
+      next(false)
+      return
+    }
     next('/dashboard')
   } else {
     next()
@@ -77,6 +88,29 @@ const router = createRouter({
         requiresAuth: true,
       },
     },
+    {
+      path: '/oauth/consent',
+      name: 'oauth-consent',
+      component: ConsentView,
+      beforeEnter: requireAuth,
+      meta: {
+        requiresAuth: true,
+      },
+    },
+    {
+      path: '/sessions/revoke',
+      name: 'sessions-revoke',
+      component: RevokeSessionView,
+    },
+    {
+      path: '/reauth',
+      name: 'reauth',
+      component: ReauthView,
+      beforeEnter: requireAuth,
+      meta: {
+        requiresAuth: true,
+      },
+    },
   ],
 })
 
@@ -2,6 +2,8 @@ import * as cdk from 'aws-cdk-lib';
 import * as lambda from 'aws-cdk-lib/aws-lambda';
 import * as apigateway from 'aws-cdk-lib/aws-apigateway';
 import * as iam from 'aws-cdk-lib/aws-iam';
+import * as events from 'aws-cdk-lib/aws-events';
+import * as eventsTargets from 'aws-cdk-lib/aws-events-targets';
 import * as logs from 'aws-cdk-lib/aws-logs';
 import * as secretsmanager from 'aws-cdk-lib/aws-secretsmanager';
 import * as route53 from 'aws-cdk-lib/aws-route53';
@@ -25,6 +27,13 @@ interface LambdaConfig {
   requiresAuth?: boolean;
 }
 
+// Scheduled Lambda function configuration (invoked by EventBridge, not API Gateway)
+interface ScheduledLambdaConfig {
+  name: string;
+  schedule: string;
+  description: string;
+}
+
 (async () => {
   const app = new cdk.App();
   const projectName = app.node.tryGetContext('projectName');
@@ -73,11 +82,65 @@ interface LambdaConfig {
     { name: 'auth-google', path: '/auth/google', method: 'POST', description: 'Google Sign-In' },
     { name: 'auth-refresh', path: '/auth/refresh', method: 'POST', description: 'Token Refresh' },
     { name: 'auth-logout', path: '/auth/logout', method: 'POST', description: 'User Logout' },
+    { name: 'auth-csrf', path: '/auth/csrf', method: 'GET', description: 'Issue CSRF Token' },
+    { name: 'auth-mfa-verify', path: '/auth/mfa/verify', method: 'POST', description: 'Verify Second Factor' },
+    { name: 'auth-passkey-options', path: '/auth/passkey/options', method: 'POST', description: 'Start Passkey Login' },
+    { name: 'auth-passkey', path: '/auth/passkey', method: 'POST', description: 'Passkey Login' },
+    { name: 'auth-email-start', path: '/auth/email/start', method: 'POST', description: 'Send Email Login Link' },
+    { name: 'auth-email-verify', path: '/auth/email/verify', method: 'GET', description: 'Email Login Link' },
+    { name: 'auth-session-revoke-link', path: '/auth/sessions/revoke', method: 'GET', description: 'Confirm Disowned Session Revocation' },
+    { name: 'auth-session-revoke', path: '/auth/sessions/revoke', method: 'POST', description: 'Revoke Disowned Session' },
+    { name: 'auth-mfa-passkey-options', path: '/auth/mfa/passkey/options', method: 'POST', description: 'Start Passkey Second Factor' },
+    { name: 'auth-mfa-passkey', path: '/auth/mfa/passkey', method: 'POST', description: 'Verify Passkey Second Factor' },
     { name: 'get-user', path: '/api/me', method: 'GET', description: 'Get Current User', requiresAuth: true },
+    { name: 'update-user', path: '/api/me', method: 'PATCH', description: 'Update Current User', requiresAuth: true },
+    { name: 'delete-user', path: '/api/me', method: 'DELETE', description: 'Delete Current User', requiresAuth: true },
+    { name: 'export-user', path: '/api/me/export', method: 'GET', description: 'Export Current User Data', requiresAuth: true },
+    { name: 'reauth-user', path: '/api/me/reauth', method: 'POST', description: 'Re-authenticate Current User', requiresAuth: true },
+    { name: 'reauth-email-link', path: '/api/me/reauth/email', method: 'POST', description: 'Email Re-authentication Link', requiresAuth: true },
+    { name: 'reauth-passkey-options', path: '/api/me/reauth/passkey/options', method: 'POST', description: 'Get Re-authentication Passkey Options', requiresAuth: true },
+    { name: 'get-mfa', path: '/api/me/mfa', method: 'GET', description: 'Get MFA Status', requiresAuth: true },
+    { name: 'enroll-totp', path: '/api/me/mfa/totp', method: 'POST', description: 'Start TOTP Enrollment', requiresAuth: true },
+    { name: 'confirm-totp', path: '/api/me/mfa/totp/confirm', method: 'POST', description: 'Confirm TOTP Enrollment', requiresAuth: true },
+    { name: 'disable-totp', path: '/api/me/mfa/totp', method: 'DELETE', description: 'Disable TOTP', requiresAuth: true },
+    { name: 'regenerate-recovery-codes', path: '/api/me/mfa/recovery-codes', method: 'POST', description: 'Regenerate Recovery Codes', requiresAuth: true },
+    { name: 'list-passkeys', path: '/api/me/passkeys', method: 'GET', description: 'List Passkeys', requiresAuth: true },
+    { name: 'passkey-registration-options', path: '/api/me/passkeys/options', method: 'POST', description: 'Start Passkey Registration', requiresAuth: true },
+    { name: 'register-passkey', path: '/api/me/passkeys', method: 'POST', description: 'Register Passkey', requiresAuth: true },
+    { name: 'remove-passkey', path: '/api/me/passkeys/{id}', method: 'DELETE', description: 'Remove Passkey', requiresAuth: true },
+    { name: 'list-api-tokens', path: '/api/tokens', method: 'GET', description: 'List API Tokens', requiresAuth: true },
+    { name: 'create-api-token', path: '/api/tokens', method: 'POST', description: 'Create API Token', requiresAuth: true },
+    { name: 'revoke-api-token', path: '/api/tokens/{id}', method: 'DELETE', description: 'Revoke API Token', requiresAuth: true },
+    { name: 'get-oauth-consent', path: '/api/oauth/consent', method: 'GET', description: 'Get OAuth Consent Request', requiresAuth: true },
+    { name: 'decide-oauth-consent', path: '/api/oauth/consent', method: 'POST', description: 'Decide OAuth Consent Request', requiresAuth: true },
+    { name: 'admin-impersonate', path: '/admin/impersonate/{userID}', method: 'POST', description: 'Start Impersonating User', requiresAuth: true },
+    { name: 'admin-impersonate-stop', path: '/admin/impersonate', method: 'DELETE', description: 'Stop Impersonating User', requiresAuth: true },
+    { name: 'list-organizations', path: '/api/organizations', method: 'GET', description: 'List Organizations', requiresAuth: true },
+    { name: 'create-organization', path: '/api/organizations', method: 'POST', description: 'Create Organization', requiresAuth: true },
+    { name: 'select-organization', path: '/api/me/organization', method: 'PUT', description: 'Select Organization', requiresAuth: true },
+    { name: 'get-organization', path: '/api/organization', method: 'GET', description: 'Get Active Organization', requiresAuth: true },
+    { name: 'invite-member', path: '/api/organization/invitations', method: 'POST', description: 'Invite Organization Member', requiresAuth: true },
+    { name: 'revoke-invitation', path: '/api/organization/invitations/{id}', method: 'DELETE', description: 'Revoke Organization Invitation', requiresAuth: true },
+    { name: 'update-member-role', path: '/api/organization/members/{userID}', method: 'PATCH', description: 'Change Organization Member Role', requiresAuth: true },
+    { name: 'remove-member', path: '/api/organization/members/{userID}', method: 'DELETE', description: 'Remove Organization Member', requiresAuth: true },
+    { name: 'accept-invitation', path: '/api/invitations/accept', method: 'POST', description: 'Accept Organization Invitation', requiresAuth: true },
+    { name: 'oauth-token', path: '/oauth/token', method: 'POST', description: 'OAuth Token Endpoint' },
+    { name: 'oauth-introspect', path: '/oauth/introspect', method: 'POST', description: 'OAuth Token Introspection' },
+    { name: 'oauth-revoke', path: '/oauth/revoke', method: 'POST', description: 'OAuth Token Revocation' },
+    { name: 'oauth-authorize', path: '/oauth/authorize', method: 'GET', description: 'OpenID Connect Authorization Endpoint' },
+    { name: 'oauth-userinfo', path: '/oauth/userinfo', method: 'GET', description: 'OpenID Connect UserInfo Endpoint' },
+    { name: 'oauth-register', path: '/oauth/register', method: 'POST', description: 'OAuth Dynamic Client Registration' },
+    { name: 'oidc-discovery', path: '/.well-known/openid-configuration', method: 'GET', description: 'OpenID Connect Discovery' },
+    { name: 'oidc-jwks', path: '/.well-known/jwks.json', method: 'GET', description: 'OpenID Connect JWKS' },
     { name: 'health', path: '/health', method: 'GET', description: 'Health Check' },
     { name: 'hello', path: '/hello', method: 'GET', description: 'Hello Endpoint' },
   ];
 
+  // Define scheduled Lambda functions
+  const scheduledLambdaConfigs: ScheduledLambdaConfig[] = [
+    { name: 'purge-accounts', schedule: 'rate(1 hour)', description: 'Purge Deleted Accounts' },
+  ];
+
   console.log('=== Lambda Backend Configuration ===');
   console.log(`Deployment Type: ZIP (Go binaries)`);
   console.log(`Build Path: ${lambdaBuildPath}`);
@@ -91,7 +154,7 @@ interface LambdaConfig {
   console.log(`Secrets Manager: ${secretName}`);
   console.log(`Lambda Memory: ${memory} MB`);
   console.log(`Lambda Timeout: ${timeout} seconds`);
-  console.log(`Lambda Functions: ${lambdaConfigs.length}`);
+  console.log(`Lambda Functions: ${lambdaConfigs.length + scheduledLambdaConfigs.length}`);
 
   try {
     const stack = new cdk.Stack(app, stackName, {
@@ -134,13 +197,22 @@ interface LambdaConfig {
       FRONTEND_URL: frontendUrl,
       GOOGLE_CLIENT_ID: secret.secretValueFromJson('GOOGLE_CLIENT_ID').unsafeUnwrap(),
       GOOGLE_CLIENT_SECRET: secret.secretValueFromJson('GOOGLE_CLIENT_SECRET').unsafeUnwrap(),
-      JWT_SECRET: secret.secretValueFromJson('JWT_SECRET').unsafeUnwrap()
+      JWT_SECRET: secret.secretValueFromJson('JWT_SECRET').unsafeUnwrap(),
+      // Email login and login alert links point at the API's custom domain
+      MAGIC_LINK_URL: `https://${domainName}/auth/email/verify`,
+      SESSION_REVOKE_URL: `https://${domainName}/auth/sessions/revoke`,
+      // private_key_jwt client assertions are addressed to the public token endpoint
+      OAUTH_TOKEN_URL: `https://${domainName}/oauth/token`,
+      // ID tokens must be signed with the same key by every function
+      OIDC_ISSUER: `https://${domainName}`,
+      OIDC_SIGNING_KEY: process.env.OIDC_SIGNING_KEY || '',
+      OIDC_REGISTRATION_TOKEN: process.env.OIDC_REGISTRATION_TOKEN || ''
     };
 
     // Create Lambda functions
     const lambdaFunctions = new Map<string, lambda.Function>();
 
-    for (const config of lambdaConfigs) {
+    for (const config of [...lambdaConfigs, ...scheduledLambdaConfigs]) {
       // CloudWatch Logs group
       const logGroup = new logs.LogGroup(stack, `${config.name}LogGroup`, {
         logGroupName: `/aws/lambda/${projectName}-${environment}-lambda-${config.name}`,
@@ -176,7 +248,7 @@ interface LambdaConfig {
       description: `REST API for ${projectName} Lambda backend (${environment})`,
       defaultCorsPreflightOptions: {
         allowOrigins: [frontendUrl],
-        allowMethods: ['GET', 'POST', 'PUT', 'DELETE', 'OPTIONS'],
+        allowMethods: ['GET', 'POST', 'PUT', 'PATCH', 'DELETE', 'OPTIONS'],
         allowHeaders: [
           'Content-Type',
           'Content-Length',
@@ -186,7 +258,8 @@ interface LambdaConfig {
           'accept',
           'origin',
           'Cache-Control',
-          'X-Requested-With'
+          'X-Requested-With',
+          'DPoP'
         ],
         allowCredentials: true
       },
@@ -232,6 +305,18 @@ interface LambdaConfig {
       console.log(`✓ Mapped ${config.method} ${config.path} → ${config.name}`);
     }
 
+    // Invoke each scheduled Lambda function from an EventBridge rule
+    for (const config of scheduledLambdaConfigs) {
+      const lambdaFunction = lambdaFunctions.get(config.name)!;
+      new events.Rule(stack, `${config.name}Schedule`, {
+        ruleName: `${projectName}-${environment}-${config.name}`,
+        schedule: events.Schedule.expression(config.schedule),
+        targets: [new eventsTargets.LambdaFunction(lambdaFunction)]
+      });
+
+      console.log(`✓ Scheduled ${config.name} at ${config.schedule}`);
+    }
+
     // Custom Domain Configuration (REQUIRED)
     console.log(`Setting up custom domain: ${domainName}`);
 